@@ -5,19 +5,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/grpcserver"
 	"github.com/ratemybars/backend/internal/handler"
 	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
 	"github.com/ratemybars/backend/internal/seeddata"
 	"github.com/ratemybars/backend/internal/service"
+	"github.com/ratemybars/backend/internal/storage"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -31,9 +39,13 @@ func main() {
 		frontendURL = "http://localhost:3000"
 	}
 
+	// Per-endpoint page sizes, e.g. PAGINATION_LIMITS="venues=20:100,venue_ratings=50:200"
+	handler.ConfigurePagination(os.Getenv("PAGINATION_LIMITS"))
+
 	// Connect to PostgreSQL (Supabase) for persistence
 	var dbPool *pgxpool.Pool
 	var authSvc *service.AuthService
+	var snapshotPath string // set when in-memory accounts are snapshotted
 
 	dbURL := os.Getenv("DATABASE_URL")
 	if dbURL != "" {
@@ -55,6 +67,38 @@ func main() {
 		log.Println("WARNING: DATABASE_URL not set, using in-memory storage (data will not persist across restarts)")
 	}
 
+	// Optional read replica for lag-tolerant reads; without one (or while it
+	// is down) they go to the primary
+	var readReplica *service.ReadReplica
+	if readURL := os.Getenv("DATABASE_READ_URL"); readURL != "" && dbPool != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		replica, err := pgxpool.New(ctx, readURL)
+		if err != nil {
+			log.Printf("WARNING: Failed to connect to read replica: %v (reading from primary)", err)
+			replica = nil
+		} else {
+			// An unreachable replica is kept; reads switch to it once it answers
+			log.Println("Using PostgreSQL read replica")
+			defer replica.Close()
+		}
+		readReplica = service.NewReadReplica(dbPool, replica)
+		readReplica.Start(15 * time.Second)
+	}
+
+	// Hot-path queries are prepared and timed; slow ones are logged
+	service.SetSlowQueryThreshold(service.LoadSlowQueryThreshold())
+
+	// JWT signing: HS256 with AUTH_SIGNING_KEY, or an RSA/ECDSA key whose
+	// public half is published at /.well-known/jwks.json
+	signingKeys, err := middleware.LoadSigningKeys()
+	if err != nil {
+		log.Fatalf("Failed to load JWT signing key: %v", err)
+	}
+	middleware.SetSigningKeys(signingKeys)
+	log.Printf("Signing auth tokens with %s", signingKeys.Algorithm())
+
 	// Run all migrations if DB is available
 	if dbPool != nil {
 		authSvc = service.NewAuthService(dbPool)
@@ -67,66 +111,180 @@ func main() {
 		log.Println("Database migrations complete")
 	} else {
 		authSvc = service.NewAuthServiceInMemory()
-	}
-
-	// Initialize services (pass dbPool; nil = in-memory only)
-	schoolSvc := service.NewSchoolService()
-	venueSvc := service.NewVenueService(dbPool)
-	ratingSvc := service.NewRatingService(dbPool)
-
-	// Load school data: prefer DATA_PATH env var, then local files, then embedded
-	dataPath := os.Getenv("DATA_PATH")
-	if dataPath == "" {
-		candidates := []string{
-			"data/schools.json",
-			"../data/schools.json",
-			"../../data/schools.json",
-		}
-		for _, c := range candidates {
-			abs, _ := filepath.Abs(c)
-			if _, err := os.Stat(abs); err == nil {
-				dataPath = abs
-				break
+		// Without a database, accounts can be kept in a JSON snapshot on disk
+		if snap := service.LoadAuthSnapshotConfig(); snap.Path != "" {
+			if err := authSvc.LoadSnapshot(snap.Path); err != nil {
+				log.Fatalf("Failed to load auth snapshot: %v", err)
 			}
+			authSvc.StartSnapshots(snap)
+			// Saved once more on shutdown so the last changes survive a restart
+			snapshotPath = snap.Path
+			log.Printf("Auth snapshots enabled (%s every %s)", snap.Path, snap.Interval)
 		}
 	}
+	if readReplica != nil {
+		authSvc.SetReadReplica(readReplica)
+	}
 
-	if dataPath != "" {
-		if err := schoolSvc.LoadFromJSON(dataPath); err != nil {
-			log.Printf("WARNING: Failed to load school data from file: %v", err)
-		} else {
-			log.Printf("Loaded %d schools from %s", schoolSvc.Count(), dataPath)
-		}
+	// Transactional email; password reset links point at the frontend
+	mailer := service.NewMailer()
+	authSvc.SetPasswordResetSender(func(email, token string, expiresAt time.Time) error {
+		link := frontendURL + "/auth/reset-password?token=" + token
+		return mailer.Send(email, "Reset your RateMyBars password", fmt.Sprintf(
+			"Someone asked to reset the password for this account.\n\nReset it here: %s\n\nThe link expires at %s. If you didn't ask for this, ignore this email.",
+			link, expiresAt.UTC().Format("15:04 MST on Jan 2")))
+	})
+	authSvc.SetStudentVerificationSender(func(email, schoolName, token string, expiresAt time.Time) error {
+		link := frontendURL + "/auth/verify-student?token=" + token
+		return mailer.Send(email, "Confirm your student email", fmt.Sprintf(
+			"Confirm this address to show up as a verified %s student on RateMyBars: %s\n\nThe link expires at %s. If you didn't ask for this, ignore this email.",
+			schoolName, link, expiresAt.UTC().Format("15:04 MST on Jan 2")))
+	})
+	authSvc.SetEmailChangeSender(func(email, token string, expiresAt time.Time) error {
+		link := frontendURL + "/auth/confirm-email?token=" + token
+		return mailer.Send(email, "Confirm your new email address", fmt.Sprintf(
+			"Confirm this address to use it for your RateMyBars account: %s\n\nThe link expires at %s. If you didn't ask for this, ignore this email.",
+			link, expiresAt.UTC().Format("15:04 MST on Jan 2")))
+	}, func(oldEmail, newEmail string) error {
+		return mailer.Send(oldEmail, "Your RateMyBars email address changed", fmt.Sprintf(
+			"Your account now signs in with %s instead of this address.\n\nIf you didn't make this change, reset your password and contact support.",
+			newEmail))
+	})
+
+	// Optional server-side sessions (AUTH_SESSIONS=server) replace stateless
+	// JWTs so sessions can be listed and revoked
+	var sessionSvc *service.SessionService
+	if sessionCfg := service.LoadSessionConfig(); sessionCfg.Enabled {
+		sessionSvc = service.NewSessionService(dbPool, sessionCfg)
+		authSvc.SetSessions(sessionSvc)
+		middleware.SetSessionLookup(sessionSvc.Lookup)
+		handler.SetAuthCookieTTL(sessionCfg.TTL)
+		log.Printf("Server-side sessions enabled (TTL %s)", sessionCfg.TTL)
 	} else {
-		// Fall back to embedded data
-		if err := schoolSvc.LoadFromBytes(seeddata.SchoolsJSON); err != nil {
-			log.Printf("WARNING: Failed to parse embedded school data: %v", err)
-		} else {
-			log.Printf("Loaded %d schools from embedded data", schoolSvc.Count())
-		}
+		// JWTs that were logged out or issued before a password change or ban
+		// are refused
+		middleware.SetTokenCheck(authSvc.TokenValid)
 	}
+	// Banned users can read but get 403 on writes
+	middleware.SetBanCheck(authSvc.BanStatus)
 
-	// Seed venue data
-	venueSeeds := make([]struct {
-		SchoolID    string
-		Name        string
-		Category    string
-		Description string
-		Address     string
-		Latitude    float64
-		Longitude   float64
-	}, len(seeddata.SeedVenues))
-	for i, sv := range seeddata.SeedVenues {
-		venueSeeds[i].SchoolID = sv.SchoolID
-		venueSeeds[i].Name = sv.Name
-		venueSeeds[i].Category = sv.Category
-		venueSeeds[i].Description = sv.Description
-		venueSeeds[i].Address = sv.Address
-		venueSeeds[i].Latitude = sv.Latitude
-		venueSeeds[i].Longitude = sv.Longitude
-	}
-	venueSvc.LoadSeedData(venueSeeds)
-	log.Printf("Seeded %d venues", venueSvc.Count())
+	// Schools, venues, ratings and fraternity data don't depend on each other,
+	// so they load in parallel. /health reports how long each took.
+	startup := service.NewStartupTimings()
+	schoolSvc := service.NewSchoolService()
+	var (
+		venueSvc      *service.VenueService
+		ratingSvc     *service.RatingService
+		fratSvc       *service.FraternityService
+		fratRatingSvc *service.FratRatingService
+		loads         errgroup.Group
+	)
+	loads.Go(func() error {
+		startup.Track("schools", func() {
+			// Load school data for each enabled region: prefer DATA_PATH (US) or
+			// DATA_PATH_<REGION> env vars, then local files, then embedded (US only)
+			for _, region := range service.EnabledRegions() {
+				before := schoolSvc.Count()
+				dataPath := os.Getenv("DATA_PATH_" + region)
+				if dataPath == "" && region == "US" {
+					dataPath = os.Getenv("DATA_PATH")
+				}
+				if dataPath == "" {
+					file := service.RegionDataFile(region)
+					candidates := []string{
+						"data/" + file,
+						"../data/" + file,
+						"../../data/" + file,
+					}
+					for _, c := range candidates {
+						abs, _ := filepath.Abs(c)
+						if _, err := os.Stat(abs); err == nil {
+							dataPath = abs
+							break
+						}
+					}
+				}
+
+				if dataPath != "" {
+					if err := schoolSvc.LoadFromJSON(dataPath, region); err != nil {
+						log.Printf("WARNING: Failed to load %s school data from file: %v", region, err)
+					} else {
+						log.Printf("Loaded %d %s schools from %s", schoolSvc.Count()-before, region, dataPath)
+					}
+				} else if region == "US" {
+					// Fall back to embedded data
+					if err := schoolSvc.LoadFromBytes(seeddata.SchoolsJSON, region); err != nil {
+						log.Printf("WARNING: Failed to parse embedded school data: %v", err)
+					} else {
+						log.Printf("Loaded %d %s schools from embedded data", schoolSvc.Count()-before, region)
+					}
+				} else {
+					log.Printf("WARNING: No school data found for region %s", region)
+				}
+			}
+		})
+		return nil
+	})
+	loads.Go(func() error {
+		startup.Track("venues", func() {
+			venueSvc = service.NewVenueService(dbPool)
+			// Seed venue data
+			venueSeeds := make([]struct {
+				SchoolID    string
+				Name        string
+				Category    string
+				Description string
+				Address     string
+				Latitude    float64
+				Longitude   float64
+			}, len(seeddata.SeedVenues))
+			for i, sv := range seeddata.SeedVenues {
+				venueSeeds[i].SchoolID = sv.SchoolID
+				venueSeeds[i].Name = sv.Name
+				venueSeeds[i].Category = sv.Category
+				venueSeeds[i].Description = sv.Description
+				venueSeeds[i].Address = sv.Address
+				venueSeeds[i].Latitude = sv.Latitude
+				venueSeeds[i].Longitude = sv.Longitude
+			}
+			venueSvc.LoadSeedData(venueSeeds)
+			log.Printf("Seeded %d venues", venueSvc.Count())
+		})
+		return nil
+	})
+	loads.Go(func() error {
+		startup.Track("ratings", func() {
+			ratingSvc = service.NewRatingService(dbPool, service.LoadRatingCacheSize())
+		})
+		return nil
+	})
+	loads.Go(func() error {
+		startup.Track("fraternities", func() {
+			fratSvc = service.NewFraternityService(dbPool)
+			fratRatingSvc = service.NewFratRatingService(dbPool)
+			if err := fratSvc.Load(seeddata.FraternitiesJSON); err != nil {
+				log.Printf("WARNING: Failed to load fraternity data: %v", err)
+			} else {
+				log.Printf("Loaded fraternity data for %d schools", fratSvc.SchoolCount())
+			}
+		})
+		return nil
+	})
+	_ = loads.Wait()
+
+	ratingSvc.SetPIIPolicy(service.LoadPIIPolicy())
+	ratingSvc.SetThumbsConfig(service.LoadThumbsConfig())
+	ratingSvc.SetEditWindow(service.LoadRatingEditWindow())
+	quotaSvc := service.NewQuotaService(dbPool, service.LoadRatingDailyLimit())
+	quotaSvc.Start(time.Hour)
+	ratingSvc.SetQuota(quotaSvc)
+	taxonomySvc := service.NewTaxonomyService(dbPool)
+	venueSvc.SetTaxonomy(taxonomySvc)
+	ratingSvc.SetTaxonomy(taxonomySvc)
+	listSvc := service.NewVenueListService(dbPool)
+	listSvc.Start(time.Minute)
+	crawlSvc := service.NewCrawlService(dbPool)
+	groupVoteSvc := service.NewGroupVoteService(venueSvc)
 
 	// Seed ratings for all venues
 	allVenues := venueSvc.GetAllVenues()
@@ -137,54 +295,294 @@ func main() {
 	ratingSvc.LoadSeedData(ratingSeeds)
 	log.Printf("Seeded %d ratings", ratingSvc.Count())
 
-	// Update venue rating stats
-	venueSvc.UpdateRatingStats(ratingSvc.GetVenueStats, ratingSvc.GetVenueThumbs)
+	// Venue stats and the school aggregates built on them
+	recomputeSvc := service.NewRecomputeService(schoolSvc, venueSvc, ratingSvc)
+	startup.Track("aggregates", recomputeSvc.RebuildAggregates)
+	startup.Done()
 
-	// Update venue counts on schools
-	venueCounts := make(map[string]int)
-	for _, v := range allVenues {
-		venueCounts[v.SchoolID]++
+	fratRatingSvc.SetQuota(quotaSvc)
+	fratSvc.SetStatsFunc(fratRatingSvc.GetSchoolStats)
+	fratRatingSvc.SetFraternities(fratSvc)
+	schoolSvc.SetFratSearch(fratSvc.SchoolsForQuery)
+	schoolSvc.UpdateFratCounts(func(schoolID string) int {
+		return fratSvc.Count(schoolID)
+	})
+	for _, schoolID := range fratSvc.OptedOutSchools() {
+		schoolSvc.SetGreekOptOut(schoolID, true, 0)
 	}
-	schoolSvc.UpdateVenueCounts(venueCounts)
-	log.Printf("Updated venue counts for %d schools", len(venueCounts))
-
-	// Compute school avg ratings from their venues
-	schoolAvgs := make(map[string]float64)
-	schoolVenueRatings := make(map[string][]float64)
-	for _, v := range venueSvc.GetAllVenues() {
-		if v.AvgRating > 0 {
-			schoolVenueRatings[v.SchoolID] = append(schoolVenueRatings[v.SchoolID], v.AvgRating)
-		}
+	if n, err := schoolSvc.LoadConferences(seeddata.ConferencesJSON); err != nil {
+		log.Printf("WARNING: Failed to load conference data: %v", err)
+	} else {
+		log.Printf("Assigned conferences to %d schools", n)
+	}
+	authSvc.SetSchools(schoolSvc)
+	authSvc.SetLockoutConfig(service.LoadLockoutConfig())
+	hashCfg, err := service.LoadPasswordHashConfig()
+	if err != nil {
+		log.Fatalf("Invalid password hashing config: %v", err)
+	}
+	authSvc.SetPasswordHashConfig(hashCfg)
+	// Optional Turnstile/hCaptcha check at signup and after failed logins
+	authSvc.SetCaptcha(service.NewCaptchaService(service.LoadCaptchaConfig()))
+	authSvc.SetGoogle(service.NewGoogleVerifier(service.LoadGoogleAuthConfig()))
+	ratingSvc.SetProfiles(authSvc)
+
+	// Invite codes and referrals
+	inviteSvc := service.NewInviteService(dbPool, authSvc)
+	authSvc.SetInvites(inviteSvc)
+
+	// Campus ambassadors add venues at their school without review, up to
+	// AMBASSADOR_VENUE_QUOTA a week
+	ambassadorSvc := service.NewAmbassadorService(dbPool, service.LoadAmbassadorVenueQuota(), authSvc, schoolSvc)
+	venueSvc.SetAutoApprover(ambassadorSvc.AutoApproveVenue)
+
+	// Moderator-managed nightlife links per school
+	schoolLinkSvc := service.NewSchoolLinkService(dbPool, schoolSvc)
+
+	// Venue follows and Web Push notifications
+	followSvc := service.NewFollowService(dbPool)
+	pushSvc, err := service.NewPushService(dbPool)
+	if err != nil {
+		log.Fatalf("Failed to initialize push notifications: %v", err)
 	}
-	for sid, ratings := range schoolVenueRatings {
-		var sum float64
-		for _, r := range ratings {
-			sum += r
+
+	// Weekly digests (checked every 6h, generated once per week), emailed to
+	// followers of the school's venues unless they turned digests off
+	digestSvc := service.NewDigestService(dbPool, schoolSvc, venueSvc, ratingSvc)
+	digestSvc.SetNotifier(func(d model.SchoolDigest) {
+		subject, body := service.DigestEmail(d, frontendURL+"/school/"+d.SchoolID)
+		for _, userID := range followSvc.FollowersOfAny(venueSvc.GetVenueIDsBySchool(d.SchoolID)) {
+			prefs, err := authSvc.Preferences(userID)
+			if err != nil || prefs.EmailDigest == model.DigestOff {
+				continue
+			}
+			email, err := authSvc.AccountEmail(userID)
+			if err != nil {
+				continue
+			}
+			if err := mailer.Send(email, subject, body); err != nil {
+				log.Printf("WARNING: Failed to email school digest: %v", err)
+			}
+		}
+	})
+	digestSvc.Start(6 * time.Hour)
+
+	shareSvc := service.NewShareService(dbPool, frontendURL)
+	claimSvc := service.NewClaimService(dbPool)
+	promoSvc := service.NewPromotionService(dbPool)
+	auditSvc := service.NewAuditService(dbPool)
+
+	// Events/specials (followers get a push once published) and check-ins feed "tonight"
+	eventSvc := service.NewEventService(dbPool)
+	eventSvc.SetNotifier(func(e model.VenueEvent) {
+		venueName := "A venue you follow"
+		if v, err := venueSvc.GetByID(context.Background(), e.VenueID); err == nil {
+			venueName = v.Name
 		}
-		schoolAvgs[sid] = sum / float64(len(ratings))
+		pushSvc.NotifyUsers(followSvc.FollowersOf(e.VenueID), model.PushMessage{
+			Title: venueName,
+			Body:  "New " + e.Kind + ": " + e.Title,
+			URL:   "/venue/" + e.VenueID,
+			Tag:   e.ID,
+		})
+	})
+	eventSvc.Start(time.Minute)
+	checkInSvc := service.NewCheckInService(dbPool, service.LoadVisitConfig())
+	ratingSvc.SetCheckIns(checkInSvc)
+
+	// Check-ins without a rating get a push reminder the next day
+	reminderSvc := service.NewReminderService(dbPool, service.LoadReminderHour(), venueSvc, schoolSvc, ratingSvc)
+	reminderSvc.SetNotifier(func(r model.RatingReminder) {
+		pushSvc.NotifyUsers([]string{r.UserID}, model.PushMessage{
+			Title: "How was " + r.VenueName + "?",
+			Body:  "You checked in yesterday. Leave a rating while it's fresh.",
+			URL:   "/venue/" + r.VenueID,
+			Tag:   "rate_" + r.VenueID,
+		})
+	})
+	reminderSvc.Start(15 * time.Minute)
+	checkInSvc.SetNotifier(reminderSvc.Enqueue)
+	tonightSvc := service.NewTonightService(venueSvc, schoolSvc, eventSvc, checkInSvc)
+	// Patio weather is only computed when a weather provider is configured
+	weatherSvc := service.NewWeatherService(service.LoadWeatherConfig(), schoolSvc, ratingSvc)
+	tonightSvc.SetWeather(weatherSvc)
+	// Football schedules mark home-game days and boost sports bars on them
+	gameDaySvc := service.NewGameDayService(dbPool, schoolSvc, ratingSvc)
+	tonightSvc.SetGameDays(gameDaySvc)
+	// Owners' nightly statuses (live music, crowd, cover) expire overnight
+	venueStatusSvc := service.NewVenueStatusService(dbPool)
+	tonightSvc.SetStatuses(venueStatusSvc)
+	trendingSvc := service.NewTrendingService(venueSvc, schoolSvc, ratingSvc, checkInSvc, service.LoadTrendingCurve())
+	seasonalitySvc := service.NewSeasonalityService(venueSvc, schoolSvc, ratingSvc, checkInSvc)
+	photoSvc := service.NewPhotoService(dbPool, service.LoadPhotoConfig())
+	photoSvc.SetVenues(venueSvc)
+	photoSvc.Start(2)
+
+	// Uploaded files (avatars): S3-compatible storage when S3_BUCKET is set,
+	// local disk served under /uploads otherwise
+	store, err := storage.New(storage.LoadConfig())
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
 	}
-	schoolSvc.UpdateSchoolRatings(schoolAvgs)
-	log.Printf("Updated avg ratings for %d schools", len(schoolAvgs))
-
-	// Load fraternity data
-	fratSvc := service.NewFraternityService(dbPool)
-	fratRatingSvc := service.NewFratRatingService(dbPool)
-	if err := fratSvc.Load(seeddata.FraternitiesJSON); err != nil {
-		log.Printf("WARNING: Failed to load fraternity data: %v", err)
-	} else {
-		log.Printf("Loaded fraternity data for %d schools", fratSvc.SchoolCount())
+	avatarSvc := service.NewAvatarService(authSvc, store, service.LoadPhotoConfig())
+
+	// Data retention: services holding personal data register a job per policy
+	retentionSvc := service.NewRetentionService(service.LoadRetentionConfig())
+	retentionSvc.Register(service.InactiveAccountsJob(authSvc, ratingSvc, fratRatingSvc, checkInSvc))
+	retentionSvc.Register(service.ExpiredResetTokensJob(authSvc))
+	retentionSvc.Register(service.ExpiredStudentVerificationsJob(authSvc))
+	retentionSvc.Register(service.ExpiredEmailChangesJob(authSvc))
+	retentionSvc.Register(service.ExpiredRevokedTokensJob(authSvc))
+	retentionSvc.Register(service.ExpiredIdentityLinksJob(authSvc))
+	retentionSvc.Register(service.LoginHistoryJob(authSvc))
+	if sessionSvc != nil {
+		retentionSvc.Register(service.ExpiredSessionsJob(sessionSvc))
 	}
-	fratSvc.SetStatsFunc(fratRatingSvc.GetSchoolStats)
-	schoolSvc.UpdateFratCounts(func(schoolID string) int {
-		return fratSvc.Count(schoolID)
+	retentionSvc.Start()
+
+	// Per-hour API usage by route, API key and client IP for operators
+	usageSvc := service.NewUsageService(dbPool)
+	usageSvc.Start(5 * time.Minute)
+
+	// Review-bombing detection pages admins by push and the alert webhook
+	adminWebhook := service.NewAdminWebhook()
+	velocitySvc := service.NewVelocityService(dbPool, service.LoadVelocityConfig())
+	velocitySvc.SetVenues(venueSvc)
+	velocitySvc.SetNotifier(func(a model.RatingAlert) {
+		text := fmt.Sprintf("Rating spike: %d ratings on %s %q in %d minutes", a.Count, a.TargetType, a.TargetName, a.WindowMinutes)
+		if a.FrozenUntil != nil {
+			text += ", ratings frozen until " + a.FrozenUntil.Format(time.RFC3339)
+		}
+		adminWebhook.Send("rating_spike", text, a)
+		go func() {
+			admins, err := authSvc.AdminUserIDs()
+			if err != nil {
+				log.Printf("WARNING: Failed to list admins for rating alert: %v", err)
+				return
+			}
+			pushSvc.NotifyUsers(admins, model.PushMessage{
+				Title: "Rating spike detected",
+				Body:  text,
+				URL:   "/admin",
+				Tag:   a.ID,
+			})
+		}()
 	})
+	ratingSvc.SetVelocity(velocitySvc)
+	fratRatingSvc.SetVelocity(velocitySvc)
+
+	// Copy-pasted review text across accounts is clustered for moderation
+	duplicateTextSvc := service.NewDuplicateTextService(dbPool)
+	duplicateTextSvc.Index(ratingSvc.GetRecent(0))
+	duplicateTextSvc.SetNotifier(func(c model.TextCluster) {
+		adminWebhook.Send("duplicate_reviews", fmt.Sprintf("Duplicate review text posted by %d accounts", c.Authors), c)
+	})
+	ratingSvc.SetDuplicates(duplicateTextSvc)
+
+	// Admin locks on venues and frat chapters during incidents or disputes
+	lockSvc := service.NewRatingLockService(dbPool, venueSvc)
+	ratingSvc.SetLocks(lockSvc)
+	fratRatingSvc.SetLocks(lockSvc)
+
+	credibilitySvc := service.NewCredibilityService(dbPool, authSvc, ratingSvc)
+	ratingSvc.SetCredibility(credibilitySvc)
+	credibilitySvc.SetStudentVerifier(authSvc.IsVerifiedStudent)
+	ratingSvc.SetStudentVerifier(authSvc.IsVerifiedStudent)
+	credibilitySvc.Start()
+
+	heatmapSvc := service.NewHeatmapService(venueSvc, checkInSvc)
+	recomputeSvc.SetCredibility(credibilitySvc)
+	recomputeSvc.SetCaches(heatmapSvc, seasonalitySvc)
+
+	// Top review terms per venue, re-extracted hourly
+	keywordSvc := service.NewKeywordService(ratingSvc)
+	keywordSvc.Start(time.Hour)
+	recomputeSvc.SetKeywords(keywordSvc)
+
+	// Review sentiment, scored as reviews are published, and the per-venue
+	// vibe index, rebuilt hourly
+	sentimentSvc := service.NewSentimentService(service.LoadSentimentConfig(), ratingSvc)
+	sentimentSvc.Start(time.Hour)
+	ratingSvc.SetSentiment(sentimentSvc)
+	recomputeSvc.SetSentiment(sentimentSvc)
+
+	draftSvc := service.NewDraftService(dbPool, venueSvc)
+	draftSvc.Start(time.Hour)
 
 	// Initialize handlers
-	schoolHandler := handler.NewSchoolHandler(schoolSvc)
-	venueHandler := handler.NewVenueHandler(venueSvc)
-	ratingHandler := handler.NewRatingHandler(ratingSvc, venueSvc, schoolSvc)
-	authHandler := handler.NewAuthHandler(authSvc)
-	fratHandler := handler.NewFraternityHandler(fratSvc, fratRatingSvc)
+	heatmapHandler := handler.NewHeatmapHandler(heatmapSvc)
+	cityHandler := handler.NewCityHandler(service.NewCityService(schoolSvc, venueSvc))
+	expander := handler.NewExpander(schoolSvc, venueSvc, ratingSvc, fratSvc)
+	schoolHandler := handler.NewSchoolHandler(schoolSvc, expander)
+	schoolHandler.SetGameDays(gameDaySvc)
+	venueHandler := handler.NewVenueHandler(venueSvc, schoolSvc, ratingSvc, promoSvc, auditSvc, service.LoadRideshareConfig(), expander)
+	venueHandler.SetWeather(weatherSvc)
+	venueHandler.SetStatuses(venueStatusSvc)
+	venueHandler.SetArchive(service.NewVenueArchiveService(dbPool))
+	venueHandler.SetKeywords(keywordSvc)
+	venueHandler.SetSentiment(sentimentSvc)
+	ratingHandler := handler.NewRatingHandler(ratingSvc, venueSvc, schoolSvc, draftSvc)
+	ratingHandler.SetSentiment(sentimentSvc)
+	reviewImportHandler := handler.NewReviewImportHandler(service.NewReviewImportService(ratingSvc, venueSvc), ratingSvc, venueSvc, schoolSvc)
+	authHandler := handler.NewAuthHandler(authSvc, service.NewAccountDeletionService(authSvc, ratingSvc, fratRatingSvc, venueSvc), avatarSvc, auditSvc)
+	fratHandler := handler.NewFraternityHandler(fratSvc, fratRatingSvc, schoolSvc, auditSvc)
+	listHandler := handler.NewVenueListHandler(listSvc, venueSvc)
+	crawlHandler := handler.NewCrawlHandler(crawlSvc, venueSvc, schoolSvc)
+	groupVoteHandler := handler.NewGroupVoteHandler(groupVoteSvc, venueSvc, schoolSvc)
+	digestHandler := handler.NewDigestHandler(digestSvc)
+	followHandler := handler.NewFollowHandler(followSvc, venueSvc)
+	inviteHandler := handler.NewInviteHandler(inviteSvc)
+	pushHandler := handler.NewPushHandler(pushSvc)
+	shareHandler := handler.NewShareHandler(shareSvc, venueSvc, schoolSvc)
+	auditHandler := handler.NewAuditHandler(auditSvc)
+	claimHandler := handler.NewClaimHandler(claimSvc, venueSvc, authSvc)
+	ownerHandler := handler.NewOwnerHandler(claimSvc, venueSvc, ratingSvc, shareSvc, followSvc)
+	eventHandler := handler.NewEventHandler(eventSvc, venueSvc, schoolSvc, ownerHandler)
+	venueStatusHandler := handler.NewVenueStatusHandler(venueStatusSvc, venueSvc, schoolSvc, ownerHandler)
+	tonightHandler := handler.NewTonightHandler(tonightSvc, checkInSvc, venueSvc)
+	trendingHandler := handler.NewTrendingHandler(trendingSvc, schoolSvc)
+	seasonalityHandler := handler.NewSeasonalityHandler(seasonalitySvc)
+	retentionHandler := handler.NewRetentionHandler(retentionSvc)
+	recomputeHandler := handler.NewRecomputeHandler(recomputeSvc, auditSvc)
+	accountMergeHandler := handler.NewAccountMergeHandler(service.NewAccountMergeService(authSvc, ratingSvc, fratRatingSvc, venueSvc,
+		followSvc, listSvc, photoSvc, recomputeSvc), auditSvc)
+	usageHandler := handler.NewUsageHandler(usageSvc)
+	queryStatsHandler := handler.NewQueryStatsHandler(dbPool)
+	userOverviewHandler := handler.NewUserOverviewHandler(service.NewUserOverviewService(authSvc, ratingSvc, fratRatingSvc, sessionSvc, auditSvc))
+	metricsHandler := handler.NewMetricsHandler(ratingSvc)
+
+	// Deploy status (version, storage, data versions) and the "what's new" changelog
+	storageInfo := model.StorageInfo{Mode: "memory", ReadReplica: readReplica != nil, Sessions: "jwt", Uploads: "local"}
+	if dbPool != nil {
+		storageInfo.Mode = "postgres"
+	} else {
+		storageInfo.AuthSnapshot = service.LoadAuthSnapshotConfig().Path != ""
+	}
+	if sessionSvc != nil {
+		storageInfo.Sessions = "server"
+	}
+	if _, ok := store.(*storage.S3); ok {
+		storageInfo.Uploads = "s3"
+	}
+	changelogSvc := service.NewChangelogService(dbPool)
+	statusSvc := service.NewStatusService(service.LoadBuildInfo(), storageInfo, schoolSvc, len(seeddata.SeedVenues), changelogSvc)
+	statusHandler := handler.NewStatusHandler(statusSvc, changelogSvc, auditSvc)
+	velocityHandler := handler.NewVelocityHandler(velocitySvc, auditSvc)
+	duplicateTextHandler := handler.NewDuplicateTextHandler(duplicateTextSvc, auditSvc)
+	lockHandler := handler.NewLockHandler(lockSvc, auditSvc)
+	ambassadorHandler := handler.NewAmbassadorHandler(ambassadorSvc, auditSvc)
+	loginLockoutHandler := handler.NewLoginLockoutHandler(authSvc, auditSvc)
+	schoolLinkHandler := handler.NewSchoolLinkHandler(schoolLinkSvc, auditSvc)
+	gameDayHandler := handler.NewGameDayHandler(gameDaySvc, auditSvc)
+	banHandler := handler.NewBanHandler(authSvc, venueSvc, auditSvc)
+	sessionHandler := handler.NewSessionHandler(sessionSvc, auditSvc)
+	draftHandler := handler.NewDraftHandler(draftSvc)
+	moderationHandler := handler.NewModerationHandler(ratingSvc, venueSvc, schoolSvc, auditSvc)
+	taxonomyHandler := handler.NewTaxonomyHandler(taxonomySvc, venueSvc, ratingSvc)
+	photoHandler := handler.NewPhotoHandler(photoSvc, venueSvc, ratingSvc)
+	bootstrapHandler := handler.NewBootstrapHandler(authSvc, schoolSvc, taxonomySvc, service.LoadFeatureFlags())
+	userProfileHandler := handler.NewUserProfileHandler(authSvc, ratingSvc, fratRatingSvc, venueSvc)
+	feedHandler := handler.NewFeedHandler(authSvc, schoolSvc, venueSvc, ratingSvc, fratRatingSvc, crawlSvc)
 
 	// Build router
 	r := chi.NewRouter()
@@ -192,6 +590,7 @@ func main() {
 	// Global middleware
 	r.Use(chimiddleware.RequestID)
 	r.Use(chimiddleware.RealIP)
+	r.Use(middleware.Usage(usageSvc.Record))
 	r.Use(chimiddleware.Logger)
 	r.Use(chimiddleware.Recoverer)
 	r.Use(chimiddleware.Timeout(30 * time.Second))
@@ -199,8 +598,8 @@ func main() {
 	// CORS
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{frontendURL, "https://frontend-orpin-alpha-25.vercel.app"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedMethods:   []string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "If-Modified-Since"},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: true,
 		MaxAge:           300,
@@ -209,24 +608,68 @@ func main() {
 	// Health check
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"status":"ok","schools":` + fmt.Sprintf("%d", schoolSvc.Count()) + `}`))
+		json.NewEncoder(w).Encode(map[string]any{
+			"status":  "ok",
+			"schools": schoolSvc.Count(),
+			"startup": startup.Report(),
+		})
 	})
 
+	// Process and in-memory store usage
+	r.With(middleware.ReadRateLimit()).Get("/metrics", metricsHandler.Get)
+
+	// Public keys for verifying auth tokens
+	r.With(middleware.ReadRateLimit()).Get("/.well-known/jwks.json", handler.JWKS)
+
+	// Locally stored uploads (development)
+	if local, ok := store.(*storage.Local); ok {
+		r.With(middleware.ReadRateLimit()).Handle("/uploads/*", http.StripPrefix("/uploads", local.Handler()))
+	}
+
+	// Short link redirects (e.g. QR codes placed in bars)
+	r.With(middleware.ReadRateLimit()).Get("/s/{code}", shareHandler.Redirect)
+
 	// API routes
 	r.Route("/api", func(r chi.Router) {
-		// Public read routes (lenient rate limit)
+		// Public read routes (lenient rate limit). Anonymous responses may be
+		// cached by a CDN; heavy, slow-changing ones are kept longer.
+		heavyCache := middleware.PublicCache(5*time.Minute, time.Hour)
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.ReadRateLimit())
+			r.Use(middleware.PublicCache(time.Minute, 5*time.Minute))
 
 			// School routes
 			r.Get("/schools", schoolHandler.Search)
-			r.Get("/schools/map", schoolHandler.GetMapData)
+			r.With(heavyCache, middleware.Conditional).Get("/schools/map", schoolHandler.GetMapData)
+			r.With(heavyCache, middleware.Conditional).Head("/schools/map", schoolHandler.GetMapData)
 			r.Get("/schools/geo", schoolHandler.GetGeo)
 			r.Get("/schools/states", schoolHandler.GetStates)
+			r.Get("/regions", schoolHandler.GetRegions)
+			r.Get("/conferences", schoolHandler.GetConferences)
 			r.Get("/schools/{id}", schoolHandler.GetByID)
-			r.Get("/schools/{id}/venues", venueHandler.ListBySchool)
+			r.Get("/schools/{id}/nearby", schoolHandler.Nearby)
+			r.Get("/schools/{id}/pinned", ambassadorHandler.SchoolPinned)
+			r.Get("/schools/{id}/games", gameDayHandler.List)
+			r.With(heavyCache).Get("/cities/{state}/{city}", cityHandler.Get)
+			r.With(heavyCache).Get("/heatmap", heatmapHandler.Get)
+			r.With(heavyCache, middleware.Conditional).Get("/schools/{id}/venues", venueHandler.ListBySchool)
+			r.With(heavyCache, middleware.Conditional).Head("/schools/{id}/venues", venueHandler.ListBySchool)
+			r.Get("/schools/{id}/venues/archive", venueHandler.ListArchive)
 			r.Get("/schools/{id}/fraternities", fratHandler.GetBySchool)
 			r.Get("/schools/{id}/ratings", ratingHandler.ListBySchool)
+			r.Get("/schools/{id}/lists", listHandler.ListBySchool)
+			r.Get("/schools/{id}/crawls", crawlHandler.ListBySchool)
+			r.Get("/users/{id}", userProfileHandler.Get)
+			r.Get("/schools/{id}/digest/latest", digestHandler.Latest)
+			r.Get("/schools/{id}/trending", trendingHandler.BySchool)
+
+			// Venue list routes (private lists are visible to their owner)
+			r.With(middleware.OptionalAuth, middleware.NoStore).Get("/lists/{id}", listHandler.GetByID)
+			r.With(middleware.OptionalAuth, middleware.NoStore).Get("/crawls/{id}", crawlHandler.GetByID)
+
+			// Mobile cold-start bundle
+			r.With(middleware.OptionalAuth, middleware.NoStore).Get("/bootstrap", bootstrapHandler.Get)
+			r.With(middleware.OptionalAuth, middleware.NoStore).Get("/feed", feedHandler.Get)
 
 			// Fraternity routes
 			r.Get("/fraternities", fratHandler.ListAll)
@@ -234,7 +677,32 @@ func main() {
 
 			// Venue routes
 			r.Get("/venues/{id}", venueHandler.GetByID)
+			r.With(heavyCache).Get("/venues/{id}/seasonality", seasonalityHandler.ByVenue)
+			r.Post("/venues/stats", venueHandler.Stats)
 			r.Get("/venues/{id}/ratings", ratingHandler.ListByVenue)
+			r.Get("/venues/{id}/events", eventHandler.ListByVenue)
+			r.Get("/venues/{id}/status", venueStatusHandler.Get)
+			r.Get("/venues/{id}/photos", photoHandler.ListByVenue)
+			r.Get("/photos/{id}", photoHandler.Image)
+
+			// Review text search (scoped to a school or venue)
+			r.Get("/reviews/search", ratingHandler.SearchReviews)
+
+			// Where to go tonight
+			r.Get("/tonight", tonightHandler.Get)
+
+			// Web Push
+			r.Get("/push/vapid-public-key", pushHandler.PublicKey)
+
+			// Terms of Service version users must accept before posting
+			r.Get("/terms/version", authHandler.TermsVersion)
+
+			// Deploy status and "what's new"
+			r.With(middleware.NoStore).Get("/status", statusHandler.Get)
+			r.Get("/changelog", statusHandler.Changelog)
+
+			// Venue categories and review tags
+			r.Get("/taxonomies/{kind}", taxonomyHandler.List)
 
 			// Stats
 			r.Get("/stats", func(w http.ResponseWriter, r *http.Request) {
@@ -267,7 +735,7 @@ func main() {
 							schoolName = s.Name
 						}
 					}
-				text := fmt.Sprintf("%s rated %s %.0f/5", rating.AuthorName, venueName, rating.Score)
+					text := fmt.Sprintf("%s rated %s %s/5", rating.AuthorName, venueName, service.FormatScore(rating.Score))
 					if schoolName != "" {
 						text += " at " + schoolName
 					}
@@ -305,7 +773,7 @@ func main() {
 					if schoolName != "" {
 						text += " at " + schoolName
 					}
-					text += fmt.Sprintf(" %.1f", fr.Score)
+					text += " " + service.FormatScore(fr.Score)
 					items = append(items, activityItem{
 						Type:      "frat_rating",
 						Text:      text,
@@ -313,47 +781,180 @@ func main() {
 					})
 				}
 
-				w.Header().Set("Content-Type", "application/json")
-				json.NewEncoder(w).Encode(items)
+				handler.WritePage(w, r, "activity", items)
 			})
 
-			// Leaderboard
-			r.Get("/leaderboard/schools", func(w http.ResponseWriter, r *http.Request) {
-				w.Header().Set("Content-Type", "application/json")
-				json.NewEncoder(w).Encode(schoolSvc.GetTopSchools(25))
-			})
-			r.Get("/leaderboard/users", func(w http.ResponseWriter, r *http.Request) {
-				w.Header().Set("Content-Type", "application/json")
-				json.NewEncoder(w).Encode(ratingSvc.GetTopContributors(25))
-			})
+			// Leaderboard (HEAD and If-Modified-Since supported for cache validation)
+			leaderboardSchools := func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query()
+				handler.WritePage(w, r, "leaderboard", schoolSvc.GetTopSchools(handler.LeaderboardSize, q.Get("country"), q.Get("conference")))
+			}
+			leaderboardUsers := func(w http.ResponseWriter, r *http.Request) {
+				handler.WritePage(w, r, "leaderboard", ratingSvc.GetTopContributors(handler.LeaderboardSize))
+			}
+			r.With(heavyCache, middleware.Conditional).Get("/leaderboard/schools", leaderboardSchools)
+			r.With(heavyCache, middleware.Conditional).Head("/leaderboard/schools", leaderboardSchools)
+			r.With(heavyCache, middleware.Conditional).Get("/leaderboard/users", leaderboardUsers)
+			r.With(heavyCache, middleware.Conditional).Head("/leaderboard/users", leaderboardUsers)
+			r.With(heavyCache).Get("/leaderboard/referrals", inviteHandler.Leaderboard)
 		})
 
 		// Auth routes (moderate rate limit)
 		r.Group(func(r chi.Router) {
+			r.Use(middleware.NoStore)
 			r.Use(middleware.RateLimit(0.2, 10)) // ~12 req/min
 			r.Use(middleware.SanitizeInput)
 
 			r.Post("/auth/register", authHandler.Register)
 			r.Post("/auth/login", authHandler.Login)
 			r.Post("/auth/logout", authHandler.Logout)
+			r.Post("/auth/forgot-password", authHandler.ForgotPassword)
+			r.Post("/auth/reset-password", authHandler.ResetPassword)
+			r.Post("/auth/verify-student", authHandler.ConfirmStudent)
+			r.Post("/auth/confirm-email", authHandler.ConfirmEmailChange)
+			r.Post("/auth/google", authHandler.GoogleLogin)
+			r.Post("/auth/google/link", authHandler.ConfirmLink)
+		})
+
+		// Signup availability checks run as the user types, so they get their
+		// own limit instead of spending the auth one
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.NoStore)
+			r.Use(middleware.RateLimit(0.5, 20)) // ~30 req/min
+
+			r.Get("/auth/check-username", authHandler.CheckUsername)
+			r.Get("/auth/check-email", authHandler.CheckEmail)
+			r.Get("/auth/captcha", authHandler.CaptchaConfig)
+		})
+
+		// Review drafts autosave while typing, so they get a lenient limit
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.NoStore)
+			r.Use(middleware.AuthRequired)
+			r.Use(middleware.CSRF)
+			r.Use(middleware.ReadRateLimit())
+			r.Use(middleware.SanitizeInput)
+
+			r.Get("/me/drafts", draftHandler.ListMine)
+			r.Get("/me/drafts/rating", draftHandler.GetRating)
+			r.Put("/me/drafts/rating", draftHandler.SaveRating)
+			r.Delete("/me/drafts/rating", draftHandler.DeleteRating)
+		})
+
+		// Group votes are swiped through quickly and streamed live, so they
+		// also get the lenient limit
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.NoStore)
+			r.Use(middleware.AuthRequired)
+			r.Use(middleware.CSRF)
+			r.Use(middleware.ReadRateLimit())
+			r.Use(middleware.SanitizeInput)
+
+			r.Post("/groups", groupVoteHandler.Create)
+			r.Post("/groups/join", groupVoteHandler.Join)
+			r.Get("/groups/{id}", groupVoteHandler.Get)
+			r.Get("/groups/{id}/events", groupVoteHandler.Events)
+			r.Post("/groups/{id}/votes", groupVoteHandler.Vote)
+			r.Post("/groups/{id}/close", groupVoteHandler.Close)
 		})
 
 		// Protected routes (auth required, strict rate limit)
 		r.Group(func(r chi.Router) {
+			r.Use(middleware.NoStore)
 			r.Use(middleware.AuthRequired)
+			r.Use(middleware.CSRF)
 			r.Use(middleware.StrictRateLimit())
 			r.Use(middleware.SanitizeInput)
 
 			r.Get("/auth/me", authHandler.Me)
-			r.Post("/venues", venueHandler.Create)
-			r.Post("/ratings", ratingHandler.Create)
+			r.Get("/auth/me/logins", authHandler.Logins)
+			r.Get("/auth/me/preferences", authHandler.Preferences)
+			r.Put("/auth/me/preferences", authHandler.UpdatePreferences)
+			r.Get("/auth/me/identities", authHandler.Identities)
+			r.Post("/auth/me/identities/google", authHandler.LinkGoogle)
+			r.Delete("/auth/me/identities/{id}", authHandler.UnlinkIdentity)
+			r.Post("/auth/me/password", authHandler.SetPassword)
+			r.Get("/auth/csrf", authHandler.CSRFToken)
+			r.Put("/auth/me", authHandler.UpdateMe)
+			r.Delete("/auth/me", authHandler.DeleteMe)
+			r.Post("/auth/change-password", authHandler.ChangePassword)
+			r.Post("/auth/change-email", authHandler.ChangeEmail)
+			r.Post("/auth/me/avatar", authHandler.UploadAvatar)
+			r.Get("/auth/sessions", sessionHandler.List)
+			r.Delete("/auth/sessions", sessionHandler.RevokeOthers)
+			r.Delete("/auth/sessions/{id}", sessionHandler.Revoke)
+			r.Post("/me/confirm-age", authHandler.ConfirmAge)
+			r.Post("/me/accept-terms", authHandler.AcceptTerms)
+			r.Put("/me/home-school", authHandler.SetHomeSchool)
+			r.Post("/me/student-verification", authHandler.RequestStudentVerification)
 			r.Post("/ratings/{id}/vote", ratingHandler.VoteOnRating)
-			r.Post("/frat-ratings", fratHandler.CreateRating)
+			r.Post("/ratings/{id}/react", ratingHandler.ReactToRating)
+			r.Delete("/lists/{id}", listHandler.Delete)
+			r.Get("/me/lists", listHandler.ListMine)
+			r.Delete("/crawls/{id}", crawlHandler.Delete)
+			r.Post("/crawls/{id}/rsvp", crawlHandler.RSVP)
+			r.Delete("/crawls/{id}/rsvp", crawlHandler.CancelRSVP)
+			r.Post("/crawls/{id}/invite", crawlHandler.RotateInvite)
+			r.Get("/me/crawls", crawlHandler.ListMine)
+			r.Post("/venues/{id}/follow", followHandler.Follow)
+			r.Delete("/venues/{id}/follow", followHandler.Unfollow)
+			r.Get("/me/follows", followHandler.ListMine)
+			r.Get("/me/invite", inviteHandler.Mine)
+			r.Get("/ambassador/me", ambassadorHandler.Me)
+			r.Put("/ambassador/pin", ambassadorHandler.Pin)
+			r.Delete("/ambassador/pin", ambassadorHandler.Unpin)
+			r.Post("/push/subscriptions", pushHandler.Subscribe)
+			r.Delete("/push/subscriptions", pushHandler.Unsubscribe)
+			r.Get("/push/status", pushHandler.Status)
+			r.Put("/push/opt-in", pushHandler.SetOptIn)
+			r.Post("/share", shareHandler.Create)
+			r.Post("/venues/{id}/claim", claimHandler.Request)
+			r.Post("/venues/{id}/claim/verify", claimHandler.Verify)
+			r.Get("/owner/venues", ownerHandler.ListVenues)
+			r.Get("/owner/venues/{id}/analytics", ownerHandler.Analytics)
+			r.Put("/owner/venues/{id}/hours", ownerHandler.SetHours)
+			r.Delete("/owner/venues/{id}/status", venueStatusHandler.Clear)
+			r.Get("/owner/venues/{id}/events/scheduled", eventHandler.Scheduled)
+			r.Delete("/owner/events/{id}", eventHandler.Delete)
+
+			// User-generated content requires the current Terms of Service
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.TermsRequired(service.CurrentTermsVersion, authSvc.AcceptedTermsVersion))
+
+				r.Post("/venues", venueHandler.Create)
+				r.Post("/ratings", ratingHandler.Create)
+				r.Post("/me/import/google-maps", reviewImportHandler.GoogleTakeout)
+				r.Post("/frat-ratings", fratHandler.CreateRating)
+				r.Post("/lists", listHandler.Create)
+				r.Put("/lists/{id}", listHandler.Update)
+				r.Post("/crawls", crawlHandler.Create)
+				r.Put("/crawls/{id}", crawlHandler.Update)
+				r.Post("/owner/venues/{id}/events", eventHandler.Create)
+				r.Post("/owner/venues/{id}/events/batch", eventHandler.CreateBatch)
+				r.Put("/owner/venues/{id}/status", venueStatusHandler.Set)
+				r.Post("/venues/{id}/photos", photoHandler.Upload)
+			})
+
+			r.Post("/venues/{id}/checkin", tonightHandler.CheckIn)
+		})
+
+		// Moderator routes (auth + moderator or admin role required)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.NoStore)
+			r.Use(middleware.AuthRequired)
+			r.Use(middleware.CSRF)
+			r.Use(middleware.ModeratorRequired)
+			r.Use(middleware.StrictRateLimit())
+
+			r.Post("/admin/schools/{id}/links", schoolLinkHandler.Add)
+			r.Delete("/admin/schools/{id}/links/{linkID}", schoolLinkHandler.Remove)
 		})
 
 		// Admin routes (auth + admin role required)
 		r.Group(func(r chi.Router) {
+			r.Use(middleware.NoStore)
 			r.Use(middleware.AuthRequired)
+			r.Use(middleware.CSRF)
 			r.Use(middleware.AdminRequired)
 			r.Use(middleware.StrictRateLimit())
 
@@ -364,16 +965,126 @@ func main() {
 			r.Delete("/admin/venues/{id}", venueHandler.Delete)
 
 			r.Get("/admin/users", authHandler.ListUsers)
+			r.Get("/admin/users/{id}/overview", userOverviewHandler.Get)
 			r.Put("/admin/users/{id}/role", authHandler.UpdateUserRole)
+			r.Post("/admin/users/{id}/ban", banHandler.Ban)
+			r.Post("/admin/users/{id}/unban", banHandler.Unban)
+			r.Post("/admin/users/merge", accountMergeHandler.Merge)
+			r.Get("/admin/ambassadors", ambassadorHandler.Activity)
+			r.Put("/admin/ambassadors/{userID}", ambassadorHandler.Appoint)
+			r.Delete("/admin/ambassadors/{userID}", ambassadorHandler.Remove)
+			r.Get("/admin/login-lockouts", loginLockoutHandler.List)
+			r.Post("/admin/login-lockouts/clear", loginLockoutHandler.Clear)
+
+			r.Get("/admin/retention", retentionHandler.Get)
+			r.Post("/admin/retention/run", retentionHandler.Run)
+			r.Get("/admin/recompute", recomputeHandler.Status)
+			r.Post("/admin/recompute", recomputeHandler.Start)
+			r.Get("/admin/usage", usageHandler.Get)
+			r.Get("/admin/db/queries", queryStatsHandler.Get)
+
+			r.Get("/admin/rating-alerts", velocityHandler.ListAlerts)
+			r.Post("/admin/rating-alerts/{id}/freeze", velocityHandler.Freeze)
+			r.Delete("/admin/rating-alerts/{id}/freeze", velocityHandler.Unfreeze)
+			r.Get("/admin/text-clusters", duplicateTextHandler.List)
+			r.Post("/admin/text-clusters/{id}/dismiss", duplicateTextHandler.Dismiss)
+			r.Get("/admin/rating-locks", lockHandler.List)
+			r.Post("/admin/rating-locks", lockHandler.Create)
+			r.Delete("/admin/rating-locks/{id}", lockHandler.Delete)
+			r.Get("/admin/sessions", sessionHandler.AdminList)
+			r.Delete("/admin/sessions/{id}", sessionHandler.AdminRevoke)
 
 			r.Post("/admin/fraternities", fratHandler.AdminAdd)
 			r.Delete("/admin/fraternities", fratHandler.AdminRemove)
+			r.Put("/admin/fraternities/status", fratHandler.AdminSetStatus)
+			r.Get("/admin/greek-opt-outs", fratHandler.ListOptOuts)
+			r.Put("/admin/schools/{id}/greek-opt-out", fratHandler.SetOptOut)
+			r.Post("/admin/schools/{id}/games", gameDayHandler.Create)
+			r.Post("/admin/schools/{id}/games/import", gameDayHandler.Import)
+			r.Put("/admin/games/{id}", gameDayHandler.Update)
+			r.Delete("/admin/games/{id}", gameDayHandler.Delete)
+
+			r.Get("/admin/share-links", shareHandler.List)
+			r.Get("/admin/audit", auditHandler.List)
+
+			r.Get("/admin/changelog", statusHandler.AdminChangelog)
+			r.Post("/admin/changelog", statusHandler.CreateChangelog)
+			r.Put("/admin/changelog/{id}", statusHandler.UpdateChangelog)
+			r.Delete("/admin/changelog/{id}", statusHandler.DeleteChangelog)
+
+			r.Get("/admin/claims", claimHandler.ListOpen)
+			r.Post("/admin/claims/{id}/code", claimHandler.IssueCode)
+			r.Delete("/admin/claims/{id}", claimHandler.Reject)
+
+			r.Get("/admin/promotions", venueHandler.ListPromotions)
+			r.Post("/admin/promotions", venueHandler.CreatePromotion)
+			r.Delete("/admin/promotions/{id}", venueHandler.DeletePromotion)
+
+			r.Post("/admin/taxonomies/{kind}", taxonomyHandler.Create)
+			r.Put("/admin/taxonomies/{kind}/{slug}", taxonomyHandler.Update)
+			r.Delete("/admin/taxonomies/{kind}/{slug}", taxonomyHandler.Delete)
+
+			r.Get("/admin/photos/quarantine", photoHandler.ListQuarantined)
+			r.Get("/admin/photos/{id}/image", photoHandler.AdminImage)
+			r.Post("/admin/photos/{id}/approve", photoHandler.Approve)
+			r.Delete("/admin/photos/{id}", photoHandler.Reject)
+
+			r.Post("/admin/ratings/{id}/redact", moderationHandler.Redact)
+			r.Get("/admin/ratings/{id}/history", moderationHandler.History)
+			r.Get("/admin/ratings/pending", moderationHandler.ListPending)
+			r.Post("/admin/ratings/{id}/approve", moderationHandler.ApprovePending)
+			r.Delete("/admin/ratings/{id}/pending", moderationHandler.RejectPending)
 		})
 	})
 
-	log.Printf("RateMyCollegeParty API starting on :%s", port)
-	log.Printf("Frontend CORS origin: %s", frontendURL)
-	if err := http.ListenAndServe(":"+port, r); err != nil {
+	// Read-side gRPC API for internal tooling, served alongside REST when GRPC_PORT is set
+	var grpcSrv *grpc.Server
+	if grpcPort := os.Getenv("GRPC_PORT"); grpcPort != "" {
+		lis, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatalf("gRPC listen failed: %v", err)
+		}
+		grpcSrv = grpcserver.New(schoolSvc, venueSvc, ratingSvc)
+		go func() {
+			log.Printf("gRPC API starting on :%s", grpcPort)
+			if err := grpcSrv.Serve(lis); err != nil {
+				log.Printf("WARNING: gRPC server stopped: %v", err)
+			}
+		}()
+	}
+
+	// On SIGINT/SIGTERM, in-flight requests finish before list views are
+	// flushed, the snapshot is saved and the deferred pool closes run
+	stop, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	srv := &http.Server{Addr: ":" + port, Handler: r}
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("RateMyCollegeParty API starting on :%s", port)
+		log.Printf("Frontend CORS origin: %s", frontendURL)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
 		log.Fatal(err)
+	case <-stop.Done():
+	}
+	log.Println("Shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("WARNING: HTTP shutdown: %v", err)
+	}
+	if grpcSrv != nil {
+		grpcSrv.GracefulStop()
+	}
+	listSvc.FlushViews()
+	if snapshotPath != "" {
+		if err := authSvc.SaveSnapshot(snapshotPath); err != nil {
+			log.Printf("WARNING: %v", err)
+		}
 	}
 }
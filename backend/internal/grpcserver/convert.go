@@ -0,0 +1,102 @@
+package grpcserver
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ratemybars/backend/internal/model"
+	pb "github.com/ratemybars/backend/internal/pb/ratemybarsv1"
+)
+
+func toPage(r *model.PaginatedResponse) *pb.Page {
+	return &pb.Page{
+		Total:      int32(r.Total),
+		Page:       int32(r.Page),
+		Limit:      int32(r.Limit),
+		TotalPages: int32(r.TotalPages),
+	}
+}
+
+func toSchool(s *model.School) *pb.School {
+	return &pb.School{
+		Id:                 s.ID,
+		Unitid:             int32(s.UnitID),
+		Name:               s.Name,
+		AliasName:          s.AliasName,
+		Address:            s.Address,
+		City:               s.City,
+		State:              s.State,
+		Zip:                s.Zip,
+		Country:            s.Country,
+		Control:            s.Control,
+		Iclevel:            int32(s.ICLevel),
+		Website:            s.Website,
+		Latitude:           s.Latitude,
+		Longitude:          s.Longitude,
+		County:             s.County,
+		Locale:             int32(s.Locale),
+		Hbcu:               s.HBCU,
+		IsOnline:           s.IsOnline,
+		Instsize:           int32(s.InstSize),
+		IsTribal:           s.IsTribal,
+		IsReligious:        s.IsReligious,
+		IsCommunityCollege: s.IsCommunityCol,
+		IsLiberalArts:      s.IsLiberalArts,
+		IsGraduateOnly:     s.IsGraduateOnly,
+		VenueCount:         int32(s.VenueCount),
+		FratCount:          int32(s.FratCount),
+		AvgRating:          s.AvgRating,
+	}
+}
+
+func toVenue(v *model.Venue) *pb.Venue {
+	out := &pb.Venue{
+		Id:          v.ID,
+		Name:        v.Name,
+		Category:    v.Category,
+		Description: v.Description,
+		Address:     v.Address,
+		Latitude:    v.Latitude,
+		Longitude:   v.Longitude,
+		SchoolId:    v.SchoolID,
+		SchoolName:  v.SchoolName,
+		CreatedById: v.CreatedByID,
+		CreatedAt:   timestamppb.New(v.CreatedAt),
+		Verified:    v.Verified,
+		AvgRating:   v.AvgRating,
+		RatingCount: int32(v.RatingCount),
+		ThumbsUp:    int32(v.ThumbsUp),
+		ThumbsDown:  int32(v.ThumbsDown),
+	}
+	if v.RecommendPct != nil {
+		pct := int32(*v.RecommendPct)
+		out.RecommendPct = &pct
+	}
+	for _, h := range v.Hours {
+		out.Hours = append(out.Hours, &pb.OpeningHours{Day: int32(h.Day), Open: h.Open, Close: h.Close})
+	}
+	return out
+}
+
+func toRating(r *model.Rating) *pb.Rating {
+	out := &pb.Rating{
+		Id:            r.ID,
+		Score:         r.Score,
+		Review:        r.Review,
+		Tags:          r.Tags,
+		VenueId:       r.VenueID,
+		AuthorId:      r.AuthorID,
+		AuthorName:    r.AuthorName,
+		CreatedAt:     timestamppb.New(r.CreatedAt),
+		Upvotes:       int32(r.Upvotes),
+		Downvotes:     int32(r.Downvotes),
+		Redacted:      r.Redacted,
+		VerifiedVisit: r.VerifiedVisit,
+	}
+	if len(r.Reactions) > 0 {
+		out.Reactions = make(map[string]int32, len(r.Reactions))
+		for k, n := range r.Reactions {
+			out.Reactions[k] = int32(n)
+		}
+	}
+	return out
+}
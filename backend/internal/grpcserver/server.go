@@ -0,0 +1,160 @@
+// Package grpcserver exposes the read side of the API (school search, venue
+// lookup, ratings) over gRPC for internal tooling and the mobile
+// backend-for-frontend. It shares services, and page-size limits, with the
+// REST handlers.
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ratemybars/backend/internal/handler"
+	"github.com/ratemybars/backend/internal/model"
+	pb "github.com/ratemybars/backend/internal/pb/ratemybarsv1"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// New returns a gRPC server with the read-side services registered.
+func New(schoolSvc *service.SchoolService, venueSvc *service.VenueService, ratingSvc *service.RatingService, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	pb.RegisterSchoolServiceServer(s, &schoolServer{svc: schoolSvc})
+	pb.RegisterVenueServiceServer(s, &venueServer{svc: venueSvc})
+	pb.RegisterRatingServiceServer(s, &ratingServer{svc: ratingSvc, venueSvc: venueSvc})
+	return s
+}
+
+// pageParams applies the REST endpoint's default and maximum page size.
+func pageParams(endpoint string, page, limit int32) (int, int) {
+	l := handler.PaginationLimits()[endpoint]
+	p, n := int(page), int(limit)
+	if p < 1 {
+		p = 1
+	}
+	if n < 1 {
+		n = l.Default
+	}
+	if n > l.Max {
+		n = l.Max
+	}
+	return p, n
+}
+
+type schoolServer struct {
+	pb.UnimplementedSchoolServiceServer
+	svc *service.SchoolService
+}
+
+func (s *schoolServer) SearchSchools(ctx context.Context, req *pb.SearchSchoolsRequest) (*pb.SearchSchoolsResponse, error) {
+	page, limit := pageParams("schools", req.GetPage(), req.GetLimit())
+	result, err := s.svc.Search(ctx, model.SchoolSearchParams{
+		Query:   req.GetQuery(),
+		State:   req.GetState(),
+		Country: req.GetCountry(),
+		Control: req.GetControl(),
+		ICLevel: int(req.GetIclevel()),
+		Sort:    req.GetSort(),
+		Page:    page,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	schools, _ := result.Data.([]model.School)
+	resp := &pb.SearchSchoolsResponse{
+		Schools: make([]*pb.School, 0, len(schools)),
+		Page:    toPage(result),
+	}
+	for i := range schools {
+		resp.Schools = append(resp.Schools, toSchool(&schools[i]))
+	}
+	return resp, nil
+}
+
+func (s *schoolServer) GetSchool(ctx context.Context, req *pb.GetSchoolRequest) (*pb.School, error) {
+	school, err := s.svc.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "school not found")
+	}
+	return toSchool(school), nil
+}
+
+type venueServer struct {
+	pb.UnimplementedVenueServiceServer
+	svc *service.VenueService
+}
+
+func (s *venueServer) GetVenue(ctx context.Context, req *pb.GetVenueRequest) (*pb.Venue, error) {
+	venue, err := s.svc.GetByID(ctx, req.GetId())
+	if err != nil || !venue.Verified {
+		return nil, status.Error(codes.NotFound, "venue not found")
+	}
+	return toVenue(venue), nil
+}
+
+func (s *venueServer) ListSchoolVenues(ctx context.Context, req *pb.ListSchoolVenuesRequest) (*pb.ListSchoolVenuesResponse, error) {
+	page, limit := pageParams("venues", req.GetPage(), req.GetLimit())
+	result, err := s.svc.ListBySchool(ctx, req.GetSchoolId(), page, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	venues, _ := result.Data.([]model.Venue)
+	resp := &pb.ListSchoolVenuesResponse{
+		Venues: make([]*pb.Venue, 0, len(venues)),
+		Page:   toPage(result),
+	}
+	for i := range venues {
+		resp.Venues = append(resp.Venues, toVenue(&venues[i]))
+	}
+	return resp, nil
+}
+
+type ratingServer struct {
+	pb.UnimplementedRatingServiceServer
+	svc      *service.RatingService
+	venueSvc *service.VenueService
+}
+
+func (s *ratingServer) ListVenueRatings(ctx context.Context, req *pb.ListVenueRatingsRequest) (*pb.ListVenueRatingsResponse, error) {
+	venue, err := s.venueSvc.GetByID(ctx, req.GetVenueId())
+	if err != nil || !venue.Verified {
+		return nil, status.Error(codes.NotFound, "venue not found")
+	}
+
+	ratings, err := s.svc.ListByVenue(ctx, venue.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if req.GetSort() != "" {
+		s.svc.Sort(ratings, req.GetSort())
+	}
+
+	page, limit := pageParams("venue_ratings", req.GetPage(), req.GetLimit())
+	total := len(ratings)
+	start := (page - 1) * limit
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+
+	resp := &pb.ListVenueRatingsResponse{
+		Ratings: make([]*pb.Rating, 0, end-start),
+		Page: &pb.Page{
+			Total:      int32(total),
+			Page:       int32(page),
+			Limit:      int32(limit),
+			TotalPages: int32((total + limit - 1) / limit),
+		},
+	}
+	for i := start; i < end; i++ {
+		resp.Ratings = append(resp.Ratings, toRating(&ratings[i]))
+	}
+	return resp, nil
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// AccountMergeHandler lets admins merge duplicate accounts.
+type AccountMergeHandler struct {
+	svc      *service.AccountMergeService
+	auditSvc *service.AuditService
+}
+
+func NewAccountMergeHandler(svc *service.AccountMergeService, auditSvc *service.AuditService) *AccountMergeHandler {
+	return &AccountMergeHandler{svc: svc, auditSvc: auditSvc}
+}
+
+// Merge handles POST /api/admin/users/merge[?dry_run=true]. The older of the
+// two accounts is kept.
+func (h *AccountMergeHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req model.MergeUsersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.UserIDs) != 2 {
+		writeError(w, http.StatusBadRequest, "user_ids must name exactly two accounts")
+		return
+	}
+	self := middleware.GetUserID(r.Context())
+	if req.UserIDs[0] == self || req.UserIDs[1] == self {
+		writeError(w, http.StatusBadRequest, "You cannot merge your own account")
+		return
+	}
+
+	kept, mergedID, changes, err := h.svc.Merge(req.UserIDs[0], req.UserIDs[1], dryRun)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "user not found":
+			status = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "failed to"):
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	if dryRun {
+		preview := newDryRun("users.merge")
+		addDryRun(preview, "users_kept", []string{kept.ID})
+		for kind, ids := range changes {
+			addDryRun(preview, kind, ids)
+		}
+		writeJSON(w, http.StatusOK, preview)
+		return
+	}
+
+	counts := make(map[string]int, len(changes))
+	for kind, ids := range changes {
+		counts[kind] = len(ids)
+	}
+	h.auditSvc.Record(r.Context(), "users.merge", "user", kept.ID, map[string]interface{}{
+		"merged_id": mergedID,
+		"counts":    counts,
+	})
+	writeJSON(w, http.StatusOK, model.MergeUsersResult{KeptUser: kept, MergedID: mergedID, Counts: counts})
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// AmbassadorHandler serves the ambassador tools and their admin dashboard.
+type AmbassadorHandler struct {
+	svc      *service.AmbassadorService
+	auditSvc *service.AuditService
+}
+
+func NewAmbassadorHandler(svc *service.AmbassadorService, auditSvc *service.AuditService) *AmbassadorHandler {
+	return &AmbassadorHandler{svc: svc, auditSvc: auditSvc}
+}
+
+// Me handles GET /api/ambassador/me
+func (h *AmbassadorHandler) Me(w http.ResponseWriter, r *http.Request) {
+	status, err := h.svc.Status(middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// Pin handles PUT /api/ambassador/pin
+func (h *AmbassadorHandler) Pin(w http.ResponseWriter, r *http.Request) {
+	var req model.PinPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	post, err := h.svc.Pin(r.Context(), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "you are not an ambassador":
+			status = http.StatusForbidden
+		case strings.HasPrefix(err.Error(), "failed to"):
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, post)
+}
+
+// Unpin handles DELETE /api/ambassador/pin
+func (h *AmbassadorHandler) Unpin(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.Unpin(middleware.GetUserID(r.Context())); err != nil {
+		status := http.StatusNotFound
+		if err.Error() == "you are not an ambassador" {
+			status = http.StatusForbidden
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "post unpinned"})
+}
+
+// SchoolPinned handles GET /api/schools/{id}/pinned
+func (h *AmbassadorHandler) SchoolPinned(w http.ResponseWriter, r *http.Request) {
+	post := h.svc.PinnedFor(chi.URLParam(r, "id"))
+	if post == nil {
+		writeError(w, http.StatusNotFound, "nothing is pinned this week")
+		return
+	}
+	writeJSON(w, http.StatusOK, post)
+}
+
+// Activity handles GET /api/admin/ambassadors
+func (h *AmbassadorHandler) Activity(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, paginate(w, r, "admin", h.svc.Activity()))
+}
+
+// Appoint handles PUT /api/admin/ambassadors/{userID}
+func (h *AmbassadorHandler) Appoint(w http.ResponseWriter, r *http.Request) {
+	var req model.AppointAmbassadorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.SchoolID == "" {
+		writeError(w, http.StatusBadRequest, "school_id is required")
+		return
+	}
+
+	a, err := h.svc.Appoint(r.Context(), chi.URLParam(r, "userID"), req.SchoolID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "user not found" || err.Error() == "school not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "ambassador.appoint", "user", a.UserID, map[string]interface{}{
+		"school_id": a.SchoolID,
+	})
+	writeJSON(w, http.StatusOK, a)
+}
+
+// Remove handles DELETE /api/admin/ambassadors/{userID}
+func (h *AmbassadorHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	a, err := h.svc.Remove(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "ambassador.remove", "user", a.UserID, map[string]interface{}{
+		"school_id": a.SchoolID,
+	})
+	writeJSON(w, http.StatusOK, map[string]string{"message": "ambassador removed"})
+}
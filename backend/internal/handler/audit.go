@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// AuditHandler serves the admin audit log.
+type AuditHandler struct {
+	svc *service.AuditService
+}
+
+func NewAuditHandler(svc *service.AuditService) *AuditHandler {
+	return &AuditHandler{svc: svc}
+}
+
+// List handles GET /api/admin/audit?actor_id=&action=&target_type=&target_id=&since=&until=
+// (admin only). since and until are RFC 3339 timestamps; action may end in
+// "." to match a whole family, e.g. action=venue.
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := model.AuditFilter{
+		ActorID:    q.Get("actor_id"),
+		Action:     q.Get("action"),
+		TargetType: q.Get("target_type"),
+		TargetID:   q.Get("target_id"),
+	}
+	for param, dst := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		v := q.Get(param)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, param+" must be an RFC 3339 timestamp")
+			return
+		}
+		*dst = t
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, "admin", h.svc.List(filter)))
+}
@@ -2,7 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -13,11 +17,15 @@ import (
 
 // AuthHandler handles authentication HTTP requests.
 type AuthHandler struct {
-	svc *service.AuthService
+	svc      *service.AuthService
+	deletion *service.AccountDeletionService
+	avatars  *service.AvatarService
+	auditSvc *service.AuditService
 }
 
-func NewAuthHandler(svc *service.AuthService) *AuthHandler {
-	return &AuthHandler{svc: svc}
+func NewAuthHandler(svc *service.AuthService, deletion *service.AccountDeletionService, avatars *service.AvatarService,
+	auditSvc *service.AuditService) *AuthHandler {
+	return &AuthHandler{svc: svc, deletion: deletion, avatars: avatars, auditSvc: auditSvc}
 }
 
 // Register handles POST /api/auth/register
@@ -32,18 +40,58 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	req.Email = middleware.SanitizeString(req.Email)
 	req.Username = middleware.SanitizeString(req.Username)
 	// Note: password is not sanitized — it gets hashed, never rendered
+	req.Client = sessionClient(r)
 
 	resp, err := h.svc.Register(req)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		if !writePasswordError(w, err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
 		return
 	}
 
 	// Set auth cookie
-	setAuthCookie(w, resp.Token)
+	resp.CSRFToken = setAuthCookie(w, resp.Token)
 	writeJSON(w, http.StatusCreated, resp)
 }
 
+// CheckUsername handles GET /api/auth/check-username?u= so the signup form
+// can validate as the user types without attempting a registration.
+func (h *AuthHandler) CheckUsername(w http.ResponseWriter, r *http.Request) {
+	username := middleware.SanitizeString(r.URL.Query().Get("u"))
+	available, err := h.svc.UsernameAvailable(username)
+	h.writeAvailability(w, available, err, "username already taken")
+}
+
+// CheckEmail handles GET /api/auth/check-email?email=
+func (h *AuthHandler) CheckEmail(w http.ResponseWriter, r *http.Request) {
+	email := middleware.SanitizeString(r.URL.Query().Get("email"))
+	available, err := h.svc.EmailAvailable(email)
+	h.writeAvailability(w, available, err, "email already registered")
+}
+
+func (h *AuthHandler) writeAvailability(w http.ResponseWriter, available bool, err error, takenReason string) {
+	if err != nil {
+		status := http.StatusBadRequest
+		if strings.HasPrefix(err.Error(), "failed to") {
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	resp := model.AvailabilityResponse{Available: available}
+	if !available {
+		resp.Reason = takenReason
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CaptchaConfig handles GET /api/auth/captcha: which CAPTCHA widget, if
+// any, the signup and login forms should render.
+func (h *AuthHandler) CaptchaConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.svc.CaptchaConfig())
+}
+
 // Login handles POST /api/auth/login
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req model.LoginRequest
@@ -51,31 +99,456 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
+	req.Client = sessionClient(r)
 
 	resp, err := h.svc.Login(req)
 	if err != nil {
-		writeError(w, http.StatusUnauthorized, err.Error())
+		if !writeLoginError(w, err) {
+			writeError(w, http.StatusUnauthorized, err.Error())
+		}
+		return
+	}
+
+	resp.CSRFToken = setAuthCookie(w, resp.Token)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// writeLoginError writes a failed or throttled password check, with the
+// remaining attempts or Retry-After. It returns false for other errors.
+func writeLoginError(w http.ResponseWriter, err error) bool {
+	var lerr *service.LoginError
+	if !errors.As(err, &lerr) {
+		return false
+	}
+	status := http.StatusUnauthorized
+	body := model.LoginErrorResponse{Message: lerr.Message, Locked: lerr.Locked}
+	if lerr.RetryAfter > 0 {
+		status = http.StatusTooManyRequests
+		body.RetryAfterSeconds = int((lerr.RetryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(body.RetryAfterSeconds))
+	}
+	if !lerr.Locked {
+		body.RemainingAttempts = &lerr.RemainingAttempts
+	}
+	body.CaptchaRequired = lerr.CaptchaRequired
+	body.Error = http.StatusText(status)
+	writeJSON(w, status, body)
+	return true
+}
+
+// ForgotPassword handles POST /api/auth/forgot-password. It answers the same
+// way whether or not the email is registered.
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req model.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.svc.RequestPasswordReset(req.Email); err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "email is required" {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "If that email is registered, a reset link is on its way"})
+}
+
+// ResetPassword handles POST /api/auth/reset-password
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req model.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.svc.ResetPassword(req.Token, req.Password); err != nil {
+		if !writePasswordError(w, err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "password updated"})
+}
+
+// ChangePassword handles POST /api/auth/change-password. Other devices are
+// signed out; this one gets a fresh token.
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var req model.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.Client = sessionClient(r)
+
+	resp, err := h.svc.ChangePassword(middleware.GetUserID(r.Context()), req)
+	if writePasswordError(w, err) {
+		return
+	}
+	// A wrong current password stays a 403 so clients don't mistake it for
+	// an expired session; throttled attempts get the login 429.
+	var lerr *service.LoginError
+	if errors.As(err, &lerr) && lerr.RetryAfter == 0 {
+		writeJSON(w, http.StatusForbidden, model.LoginErrorResponse{
+			Error:             http.StatusText(http.StatusForbidden),
+			Message:           lerr.Message,
+			RemainingAttempts: &lerr.RemainingAttempts,
+		})
+		return
+	}
+	if writeLoginError(w, err) {
+		return
+	}
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "current password is incorrect":
+			status = http.StatusForbidden
+		case err.Error() == "user not found":
+			status = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "failed to"):
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	resp.CSRFToken = setAuthCookie(w, resp.Token)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ChangeEmail handles POST /api/auth/change-email. The address changes once
+// the link sent to it is confirmed.
+func (h *AuthHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	var req model.ChangeEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.svc.RequestEmailChange(middleware.GetUserID(r.Context()), req); err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "password is incorrect":
+			status = http.StatusForbidden
+		case err.Error() == "user not found":
+			status = http.StatusNotFound
+		case err.Error() == "email already registered":
+			status = http.StatusConflict
+		case strings.HasPrefix(err.Error(), "failed to"):
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]string{"message": "check your new email address for a confirmation link"})
+}
+
+// ConfirmEmailChange handles POST /api/auth/confirm-email
+func (h *AuthHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	var req model.ConfirmEmailChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.svc.ConfirmEmailChange(req.Token)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "email already registered":
+			status = http.StatusConflict
+		case strings.HasPrefix(err.Error(), "failed to"):
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
 		return
 	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// identityErrorStatus maps Google sign-in and account linking errors.
+func identityErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case msg == "Google sign-in is not enabled" || msg == "identity not found" || msg == "user not found":
+		return http.StatusNotFound
+	case msg == "invalid Google token" || msg == "password is incorrect" ||
+		msg == "sign in with a Google account linked to this account":
+		return http.StatusUnauthorized
+	case strings.HasSuffix(msg, "already linked to another user") || msg == "email already registered" ||
+		msg == "account already has a password":
+		return http.StatusConflict
+	case strings.HasPrefix(msg, "failed to verify Google token"):
+		return http.StatusBadGateway
+	case strings.HasPrefix(msg, "failed to"):
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
 
-	setAuthCookie(w, resp.Token)
+// GoogleLogin handles POST /api/auth/google with an ID token from Google
+// Identity Services. If the Google email belongs to an existing account it
+// answers 409 with a link token instead of signing in.
+func (h *AuthHandler) GoogleLogin(w http.ResponseWriter, r *http.Request) {
+	var req model.GoogleLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.Username = middleware.SanitizeString(req.Username)
+	req.Client = sessionClient(r)
+
+	resp, link, err := h.svc.GoogleLogin(r.Context(), req)
+	if err != nil {
+		writeError(w, identityErrorStatus(err), err.Error())
+		return
+	}
+	if link != nil {
+		writeJSON(w, http.StatusConflict, link)
+		return
+	}
+	resp.CSRFToken = setAuthCookie(w, resp.Token)
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// ConfirmLink handles POST /api/auth/google/link: the existing account's
+// password confirms linking the Google sign-in to it, then signs in.
+func (h *AuthHandler) ConfirmLink(w http.ResponseWriter, r *http.Request) {
+	var req model.ConfirmLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.Client = sessionClient(r)
+
+	resp, err := h.svc.ConfirmLink(req)
+	if err != nil {
+		if !writeLoginError(w, err) {
+			writeError(w, identityErrorStatus(err), err.Error())
+		}
+		return
+	}
+	resp.CSRFToken = setAuthCookie(w, resp.Token)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Identities handles GET /api/auth/me/identities
+func (h *AuthHandler) Identities(w http.ResponseWriter, r *http.Request) {
+	ids, err := h.svc.Identities(middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, identityErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, ids)
+}
+
+// LinkGoogle handles POST /api/auth/me/identities/google
+func (h *AuthHandler) LinkGoogle(w http.ResponseWriter, r *http.Request) {
+	var req model.LinkIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	req.Client = sessionClient(r)
+
+	ids, err := h.svc.LinkGoogle(r.Context(), middleware.GetUserID(r.Context()), req)
+	if err != nil {
+		if !writeLoginError(w, err) {
+			writeError(w, identityErrorStatus(err), err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, ids)
+}
+
+// SetPassword handles POST /api/auth/me/password, which adds a password to
+// an account created through Google.
+func (h *AuthHandler) SetPassword(w http.ResponseWriter, r *http.Request) {
+	var req model.SetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ids, err := h.svc.SetPassword(r.Context(), middleware.GetUserID(r.Context()), req)
+	if err != nil {
+		if !writePasswordError(w, err) {
+			writeError(w, identityErrorStatus(err), err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, ids)
+}
+
+// UnlinkIdentity handles DELETE /api/auth/me/identities/{id}
+func (h *AuthHandler) UnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if err := h.svc.UnlinkIdentity(userID, chi.URLParam(r, "id")); err != nil {
+		writeError(w, identityErrorStatus(err), err.Error())
+		return
+	}
+	ids, err := h.svc.Identities(userID)
+	if err != nil {
+		writeError(w, identityErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, ids)
+}
+
+// RequestStudentVerification handles POST /api/me/student-verification
+func (h *AuthHandler) RequestStudentVerification(w http.ResponseWriter, r *http.Request) {
+	var req model.StudentVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	school, err := h.svc.RequestStudentVerification(middleware.GetUserID(r.Context()), req.Email, req.SchoolID)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch err.Error() {
+		case "user not found":
+			status = http.StatusNotFound
+		case "this email is already verified on another account":
+			status = http.StatusConflict
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message": "check your school email for a confirmation link",
+		"school":  school,
+	})
+}
+
+// ConfirmStudent handles POST /api/auth/verify-student
+func (h *AuthHandler) ConfirmStudent(w http.ResponseWriter, r *http.Request) {
+	var req model.ConfirmStudentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.svc.ConfirmStudent(req.Token)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "this email is already verified on another account" {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
 // Logout handles POST /api/auth/logout
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	http.SetCookie(w, &http.Cookie{
-		Name:     "auth_token",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteLaxMode,
-	})
+	h.svc.Logout(middleware.TokenFromRequest(r))
+	clearAuthCookie(w)
 	writeJSON(w, http.StatusOK, map[string]string{"message": "logged out"})
 }
 
+// CSRFToken handles GET /api/auth/csrf. It reissues the double-submit token
+// for cookie sessions that predate it or lost the cookie.
+func (h *AuthHandler) CSRFToken(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"csrf_token": middleware.SetCSRFCookie(w, authCookieTTL)})
+}
+
+// UpdateMe handles PUT /api/auth/me
+func (h *AuthHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	var req model.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.DisplayName != nil {
+		name := middleware.SanitizeString(*req.DisplayName)
+		req.DisplayName = &name
+	}
+	if req.Bio != nil {
+		bio := middleware.SanitizeString(*req.Bio)
+		req.Bio = &bio
+	}
+
+	user, err := h.svc.UpdateProfile(middleware.GetUserID(r.Context()), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "user not found":
+			status = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "failed to"):
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// UploadAvatar handles POST /api/auth/me/avatar (multipart field "avatar").
+func (h *AuthHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	maxBytes := h.avatars.MaxBytes()
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes+1<<20) // headroom for multipart framing
+	if err := r.ParseMultipartForm(maxBytes); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "avatar exceeds "+strconv.FormatInt(maxBytes, 10)+" bytes")
+			return
+		}
+		writeError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+	file, _, err := r.FormFile("avatar")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "avatar file is required")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Failed to read avatar")
+		return
+	}
+
+	user, err := h.avatars.Upload(r.Context(), middleware.GetUserID(r.Context()), data)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "user not found":
+			status = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "avatar exceeds"):
+			status = http.StatusRequestEntityTooLarge
+		case strings.HasPrefix(err.Error(), "unsupported image type"):
+			status = http.StatusUnsupportedMediaType
+		case strings.HasPrefix(err.Error(), "failed to"):
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// DeleteMe handles DELETE /api/auth/me. The account is removed and its
+// ratings and venues are kept without an author.
+func (h *AuthHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
+	if err := h.deletion.Delete(middleware.GetUserID(r.Context())); err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "user not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	clearAuthCookie(w)
+	writeJSON(w, http.StatusOK, map[string]string{"message": "account deleted"})
+}
+
 // Me handles GET /api/auth/me
 func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r.Context())
@@ -93,6 +566,131 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, user)
 }
 
+// Logins handles GET /api/auth/me/logins?limit=20, the user's recent
+// logins with the IP and user agent each came from.
+func (h *AuthHandler) Logins(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+	logins, err := h.svc.RecentLogins(userID, 0)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, "logins", logins))
+}
+
+// Preferences handles GET /api/auth/me/preferences
+func (h *AuthHandler) Preferences(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		writeError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	prefs, err := h.svc.Preferences(userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "user not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, prefs)
+}
+
+// UpdatePreferences handles PUT /api/auth/me/preferences. Omitted fields
+// are left unchanged.
+func (h *AuthHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	var req model.UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	prefs, err := h.svc.UpdatePreferences(middleware.GetUserID(r.Context()), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "user not found":
+			status = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "failed to"):
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, prefs)
+}
+
+// ConfirmAge handles POST /api/me/confirm-age
+func (h *AuthHandler) ConfirmAge(w http.ResponseWriter, r *http.Request) {
+	var req model.ConfirmAgeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.svc.ConfirmAge(middleware.GetUserID(r.Context()), req.Jurisdiction)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "user not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// SetHomeSchool handles PUT /api/me/home-school
+func (h *AuthHandler) SetHomeSchool(w http.ResponseWriter, r *http.Request) {
+	var req model.SetHomeSchoolRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.svc.SetHomeSchool(middleware.GetUserID(r.Context()), req.SchoolID, req.GradYear)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "user not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// AcceptTerms handles POST /api/me/accept-terms
+func (h *AuthHandler) AcceptTerms(w http.ResponseWriter, r *http.Request) {
+	var req model.AcceptTermsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.svc.AcceptTerms(middleware.GetUserID(r.Context()), req.Version)
+	if err != nil {
+		status := http.StatusConflict
+		if err.Error() == "user not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// TermsVersion handles GET /api/terms/version
+func (h *AuthHandler) TermsVersion(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"version": service.CurrentTermsVersion()})
+}
+
 // ListUsers handles GET /api/admin/users (admin only)
 func (h *AuthHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	users, err := h.svc.ListUsers()
@@ -100,7 +698,7 @@ func (h *AuthHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	writeJSON(w, http.StatusOK, users)
+	writeJSON(w, http.StatusOK, paginate(w, r, "admin", users))
 }
 
 // UpdateUserRole handles PUT /api/admin/users/{id}/role (admin only)
@@ -122,23 +720,79 @@ func (h *AuthHandler) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	previous := ""
+	if user, err := h.svc.GetUser(userID); err == nil {
+		previous = user.Role
+	}
 	if err := h.svc.UpdateUserRole(userID, body.Role); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.auditSvc.Record(r.Context(), "user.role", "user", userID, map[string]interface{}{
+		"from": previous,
+		"to":   body.Role,
+	})
 
 	writeJSON(w, http.StatusOK, map[string]string{"message": "role updated"})
 }
 
-func setAuthCookie(w http.ResponseWriter, token string) {
+// sessionClient records which device a login came from, for the session list.
+func sessionClient(r *http.Request) model.SessionClient {
+	return model.SessionClient{UserAgent: r.UserAgent(), IP: middleware.ClientIP(r)}
+}
+
+func clearAuthCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	middleware.ClearCSRFCookie(w)
+}
+
+// authCookieTTL is how long browsers keep the auth cookie: a JWT's 24 hours,
+// or the session TTL with AUTH_SESSIONS=server.
+var authCookieTTL = 24 * time.Hour
+
+// SetAuthCookieTTL makes the auth cookie last as long as a server-side
+// session.
+func SetAuthCookieTTL(ttl time.Duration) {
+	authCookieTTL = ttl
+}
+
+// setAuthCookie sets the session cookie and a matching CSRF cookie, returning
+// the CSRF token for the response body.
+func setAuthCookie(w http.ResponseWriter, token string) string {
 	http.SetCookie(w, &http.Cookie{
 		Name:     "auth_token",
 		Value:    token,
 		Path:     "/",
-		MaxAge:   86400, // 24 hours
+		MaxAge:   int(authCookieTTL / time.Second),
 		HttpOnly: true,
 		Secure:   true,
 		SameSite: http.SameSiteLaxMode,
-		Expires:  time.Now().Add(24 * time.Hour),
+		Expires:  time.Now().Add(authCookieTTL),
+	})
+	return middleware.SetCSRFCookie(w, authCookieTTL)
+}
+
+// writePasswordError answers 400 with the reasons a new password was
+// rejected, if err is a *service.PasswordError.
+func writePasswordError(w http.ResponseWriter, err error) bool {
+	var perr *service.PasswordError
+	if !errors.As(err, &perr) {
+		return false
+	}
+	writeJSON(w, http.StatusBadRequest, model.PasswordErrorResponse{
+		Error:       http.StatusText(http.StatusBadRequest),
+		Message:     perr.Error(),
+		Score:       perr.Score,
+		Issues:      perr.Issues,
+		Suggestions: perr.Suggestions,
 	})
+	return true
 }
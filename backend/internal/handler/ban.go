@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// BanHandler lets admins suspend and reinstate users.
+type BanHandler struct {
+	authSvc  *service.AuthService
+	venueSvc *service.VenueService
+	auditSvc *service.AuditService
+}
+
+func NewBanHandler(authSvc *service.AuthService, venueSvc *service.VenueService, auditSvc *service.AuditService) *BanHandler {
+	return &BanHandler{authSvc: authSvc, venueSvc: venueSvc, auditSvc: auditSvc}
+}
+
+// Ban handles POST /api/admin/users/{id}/ban. The user's pending venue
+// submissions are rejected along with it.
+func (h *BanHandler) Ban(w http.ResponseWriter, r *http.Request) {
+	var req model.BanUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := chi.URLParam(r, "id")
+	ban, err := h.authSvc.BanUser(userID, req)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "user not found":
+			status = http.StatusNotFound
+		case err.Error() == "cannot ban an admin":
+			status = http.StatusForbidden
+		case strings.HasPrefix(err.Error(), "failed to"):
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	ban.RejectedVenues = h.venueSvc.RejectPendingBy(userID)
+
+	h.auditSvc.Record(r.Context(), "user.ban", "user", userID, map[string]interface{}{
+		"reason":          ban.Reason,
+		"banned_until":    ban.BannedUntil,
+		"permanent":       ban.Permanent,
+		"rejected_venues": ban.RejectedVenues,
+	})
+	writeJSON(w, http.StatusOK, ban)
+}
+
+// Unban handles POST /api/admin/users/{id}/unban
+func (h *BanHandler) Unban(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+	if err := h.authSvc.UnbanUser(userID); err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "user not found":
+			status = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "failed to"):
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "user.unban", "user", userID, nil)
+	writeJSON(w, http.StatusOK, map[string]string{"message": "user unbanned"})
+}
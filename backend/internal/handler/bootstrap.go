@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+const (
+	bootstrapNearbyRadiusKm = 50.0
+	bootstrapNearbyLimit    = 10
+)
+
+// BootstrapHandler serves everything the mobile apps need on cold start in a
+// single request.
+type BootstrapHandler struct {
+	authSvc     *service.AuthService
+	schoolSvc   *service.SchoolService
+	taxonomySvc *service.TaxonomyService
+	features    *service.FeatureFlags
+}
+
+func NewBootstrapHandler(authSvc *service.AuthService, schoolSvc *service.SchoolService, taxonomySvc *service.TaxonomyService, features *service.FeatureFlags) *BootstrapHandler {
+	return &BootstrapHandler{authSvc: authSvc, schoolSvc: schoolSvc, taxonomySvc: taxonomySvc, features: features}
+}
+
+// Get handles GET /api/bootstrap?lat=&lng=&school_id=
+// The user is included when the request is authenticated. school_id overrides
+// the home school stored on the user record; lat/lng fill in nearby schools.
+func (h *BootstrapHandler) Get(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	resp := model.Bootstrap{
+		NearbySchools: []model.SchoolSummary{},
+		Features:      h.features.All(),
+		Regions:       h.schoolSvc.Regions(),
+		TermsVersion:  service.CurrentTermsVersion(),
+	}
+
+	schoolID := q.Get("school_id")
+	if userID := middleware.GetUserID(r.Context()); userID != "" {
+		if user, err := h.authSvc.GetUser(userID); err == nil {
+			resp.User = user
+			if schoolID == "" {
+				schoolID = user.HomeSchoolID
+			}
+		}
+	}
+
+	if schoolID != "" {
+		if summary, err := h.schoolSvc.Summary(schoolID); err == nil {
+			resp.HomeSchool = summary
+		}
+	}
+
+	lat, latErr := strconv.ParseFloat(q.Get("lat"), 64)
+	lng, lngErr := strconv.ParseFloat(q.Get("lng"), 64)
+	if latErr == nil && lngErr == nil && lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180 {
+		resp.NearbySchools = h.schoolSvc.Nearby(lat, lng, bootstrapNearbyRadiusKm, bootstrapNearbyLimit)
+	}
+
+	resp.Categories, _ = h.taxonomySvc.List(service.TaxonomyCategory)
+	resp.Tags, _ = h.taxonomySvc.List(service.TaxonomyTag)
+
+	writeJSON(w, http.StatusOK, resp)
+}
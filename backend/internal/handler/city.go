@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// CityHandler serves city pages.
+type CityHandler struct {
+	svc *service.CityService
+}
+
+func NewCityHandler(svc *service.CityService) *CityHandler {
+	return &CityHandler{svc: svc}
+}
+
+// Get handles GET /api/cities/{state}/{city} — city is a name ("St. Louis")
+// or slug ("saint-louis").
+func (h *CityHandler) Get(w http.ResponseWriter, r *http.Request) {
+	page, err := h.svc.Get(chi.URLParam(r, "state"), chi.URLParam(r, "city"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// ClaimHandler handles venue ownership claims and code verification.
+type ClaimHandler struct {
+	svc      *service.ClaimService
+	venueSvc *service.VenueService
+	authSvc  *service.AuthService
+}
+
+func NewClaimHandler(svc *service.ClaimService, venueSvc *service.VenueService, authSvc *service.AuthService) *ClaimHandler {
+	return &ClaimHandler{svc: svc, venueSvc: venueSvc, authSvc: authSvc}
+}
+
+// Request handles POST /api/venues/{id}/claim
+func (h *ClaimHandler) Request(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	if _, err := h.venueSvc.GetByID(r.Context(), venueID); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	var req struct {
+		Message string `json:"message"`
+	}
+	// Body is optional
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	claim, err := h.svc.Request(r.Context(), venueID, req.Message)
+	if err != nil {
+		status := http.StatusConflict
+		if err.Error() == "authentication required" {
+			status = http.StatusUnauthorized
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, claim)
+}
+
+// Verify handles POST /api/venues/{id}/claim/verify
+func (h *ClaimHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		writeError(w, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	claim, err := h.svc.Verify(r.Context(), venueID, req.Code)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch err.Error() {
+		case "authentication required":
+			status = http.StatusUnauthorized
+		case "no verification code has been issued for your claim":
+			status = http.StatusNotFound
+		case "too many incorrect attempts, please contact support for a new code":
+			status = http.StatusTooManyRequests
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	// Complete the owner-role grant and hand back a token carrying it.
+	resp, err := h.authSvc.GrantOwnerRole(middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	csrfToken := setAuthCookie(w, resp.Token)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"claim":      claim,
+		"token":      resp.Token,
+		"csrf_token": csrfToken,
+		"user":       resp.User,
+	})
+}
+
+// ListOpen handles GET /api/admin/claims (admin only)
+func (h *ClaimHandler) ListOpen(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, paginate(w, r, "admin", h.svc.ListOpen()))
+}
+
+// IssueCode handles POST /api/admin/claims/{id}/code (admin only). The code is
+// returned once so the admin can pass it to the venue offline.
+func (h *ClaimHandler) IssueCode(w http.ResponseWriter, r *http.Request) {
+	code, claim, err := h.svc.IssueCode(chi.URLParam(r, "id"))
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "claim not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"code":  code,
+		"claim": claim,
+	})
+}
+
+// Reject handles DELETE /api/admin/claims/{id} (admin only)
+func (h *ClaimHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.Reject(chi.URLParam(r, "id")); err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "claim not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "claim rejected"})
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// CrawlHandler handles planned bar crawls and RSVPs.
+type CrawlHandler struct {
+	svc       *service.CrawlService
+	venueSvc  *service.VenueService
+	schoolSvc *service.SchoolService
+}
+
+func NewCrawlHandler(svc *service.CrawlService, venueSvc *service.VenueService, schoolSvc *service.SchoolService) *CrawlHandler {
+	return &CrawlHandler{svc: svc, venueSvc: venueSvc, schoolSvc: schoolSvc}
+}
+
+func crawlErrorStatus(err error) int {
+	switch {
+	case err.Error() == "authentication required":
+		return http.StatusUnauthorized
+	case err.Error() == "crawl not found":
+		return http.StatusNotFound
+	case err.Error() == "you can only edit your own crawls" || err.Error() == "you can only delete your own crawls":
+		return http.StatusForbidden
+	case err.Error() == "this crawl is already over" || err.Error() == "you have not RSVP'd to this crawl":
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// Create handles POST /api/crawls
+func (h *CrawlHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.CrawlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if msg := h.checkRequest(r, req); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	crawl, err := h.svc.Create(r.Context(), req)
+	if err != nil {
+		writeError(w, crawlErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, h.enrich(r, crawl))
+}
+
+// GetByID handles GET /api/crawls/{id}?invite= — private crawls need the
+// invite code unless the viewer hosts or has RSVP'd.
+func (h *CrawlHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	crawl, err := h.svc.Get(r.Context(), chi.URLParam(r, "id"), r.URL.Query().Get("invite"))
+	if err != nil {
+		writeError(w, crawlErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.enrich(r, crawl))
+}
+
+// Update handles PUT /api/crawls/{id}
+func (h *CrawlHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req model.CrawlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if msg := h.checkRequest(r, req); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	crawl, err := h.svc.Update(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		writeError(w, crawlErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.enrich(r, crawl))
+}
+
+// Delete handles DELETE /api/crawls/{id}
+func (h *CrawlHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeError(w, crawlErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "crawl deleted"})
+}
+
+// RSVP handles POST /api/crawls/{id}/rsvp
+func (h *CrawlHandler) RSVP(w http.ResponseWriter, r *http.Request) {
+	var req model.CrawlRSVPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	crawl, err := h.svc.RSVP(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		writeError(w, crawlErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.enrich(r, crawl))
+}
+
+// CancelRSVP handles DELETE /api/crawls/{id}/rsvp
+func (h *CrawlHandler) CancelRSVP(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.CancelRSVP(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeError(w, crawlErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "RSVP cancelled"})
+}
+
+// RotateInvite handles POST /api/crawls/{id}/invite — issues a new invite
+// code so old invite links stop working.
+func (h *CrawlHandler) RotateInvite(w http.ResponseWriter, r *http.Request) {
+	crawl, err := h.svc.RotateInvite(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, crawlErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.enrich(r, crawl))
+}
+
+// ListMine handles GET /api/me/crawls — upcoming crawls the user hosts or
+// has RSVP'd to.
+func (h *CrawlHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	crawls := h.svc.ListForUser(middleware.GetUserID(r.Context()), time.Now())
+	writeJSON(w, http.StatusOK, paginate(w, r, "crawls", crawls))
+}
+
+// ListBySchool handles GET /api/schools/{id}/crawls — upcoming public crawls.
+func (h *CrawlHandler) ListBySchool(w http.ResponseWriter, r *http.Request) {
+	crawls := h.svc.UpcomingBySchool(chi.URLParam(r, "id"), time.Now(), 0)
+	writeJSON(w, http.StatusOK, paginate(w, r, "crawls", crawls))
+}
+
+// checkRequest returns an error message if the school is unknown or a stop
+// isn't an approved venue at that school.
+func (h *CrawlHandler) checkRequest(r *http.Request, req model.CrawlRequest) string {
+	if req.SchoolID == "" {
+		return ""
+	}
+	if _, err := h.schoolSvc.GetByID(r.Context(), req.SchoolID); err != nil {
+		return "school not found"
+	}
+	for _, st := range req.Stops {
+		v, err := h.venueSvc.GetByID(r.Context(), st.VenueID)
+		if err != nil || !v.Verified {
+			return "venue not found: " + st.VenueID
+		}
+		if v.SchoolID != req.SchoolID {
+			return "venue " + st.VenueID + " is not at this school"
+		}
+	}
+	return ""
+}
+
+// enrich attaches venue details to each stop. Venues removed since the crawl
+// was planned are dropped from the response.
+func (h *CrawlHandler) enrich(r *http.Request, crawl *model.Crawl) *model.Crawl {
+	stops := make([]model.CrawlStop, 0, len(crawl.Stops))
+	for _, st := range crawl.Stops {
+		v, err := h.venueSvc.GetByID(r.Context(), st.VenueID)
+		if err != nil {
+			continue
+		}
+		venue := *v
+		st.Venue = &venue
+		stops = append(stops, st)
+	}
+	out := *crawl
+	out.Stops = stops
+	return &out
+}
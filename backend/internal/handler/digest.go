@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// DigestHandler serves weekly school digests.
+type DigestHandler struct {
+	svc *service.DigestService
+}
+
+func NewDigestHandler(svc *service.DigestService) *DigestHandler {
+	return &DigestHandler{svc: svc}
+}
+
+// Latest handles GET /api/schools/{id}/digest/latest
+func (h *DigestHandler) Latest(w http.ResponseWriter, r *http.Request) {
+	digest, err := h.svc.Latest(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, digest)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// DraftHandler serves autosaved review drafts.
+type DraftHandler struct {
+	svc *service.DraftService
+}
+
+func NewDraftHandler(svc *service.DraftService) *DraftHandler {
+	return &DraftHandler{svc: svc}
+}
+
+// SaveRating handles PUT /api/me/drafts/rating
+func (h *DraftHandler) SaveRating(w http.ResponseWriter, r *http.Request) {
+	var req model.RatingDraft
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	draft, err := h.svc.Save(r.Context(), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch err.Error() {
+		case "authentication required":
+			status = http.StatusUnauthorized
+		case "venue not found":
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, draft)
+}
+
+// GetRating handles GET /api/me/drafts/rating?venue_id=
+func (h *DraftHandler) GetRating(w http.ResponseWriter, r *http.Request) {
+	draft, err := h.svc.Get(r.Context(), r.URL.Query().Get("venue_id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, draft)
+}
+
+// DeleteRating handles DELETE /api/me/drafts/rating?venue_id=
+func (h *DraftHandler) DeleteRating(w http.ResponseWriter, r *http.Request) {
+	h.svc.Delete(middleware.GetUserID(r.Context()), r.URL.Query().Get("venue_id"))
+	writeJSON(w, http.StatusOK, map[string]string{"message": "draft deleted"})
+}
+
+// ListMine handles GET /api/me/drafts
+func (h *DraftHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.svc.ListMine(r.Context()))
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// maxDryRunIDs caps each ID list in a dry-run preview; counts stay exact.
+const maxDryRunIDs = 500
+
+// parseDryRun reads ?dry_run= on destructive admin endpoints.
+func parseDryRun(r *http.Request) (bool, error) {
+	v := r.URL.Query().Get("dry_run")
+	if v == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("dry_run must be true or false")
+	}
+	return dryRun, nil
+}
+
+func newDryRun(action string) *model.DryRunResult {
+	return &model.DryRunResult{
+		DryRun: true,
+		Action: action,
+		Counts: map[string]int{},
+		IDs:    map[string][]string{},
+	}
+}
+
+// addDryRun records the IDs of one kind of record the action would change.
+func addDryRun(d *model.DryRunResult, kind string, ids []string) {
+	d.Counts[kind] = len(ids)
+	d.IDs[kind] = ids[:min(len(ids), maxDryRunIDs)]
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// DuplicateTextHandler shows admins reviews copy-pasted across accounts.
+type DuplicateTextHandler struct {
+	svc      *service.DuplicateTextService
+	auditSvc *service.AuditService
+}
+
+func NewDuplicateTextHandler(svc *service.DuplicateTextService, auditSvc *service.AuditService) *DuplicateTextHandler {
+	return &DuplicateTextHandler{svc: svc, auditSvc: auditSvc}
+}
+
+// List handles GET /api/admin/text-clusters?status=open|dismissed|all
+// (default open).
+func (h *DuplicateTextHandler) List(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.TextClusterOpen
+	case "all":
+		status = ""
+	case service.TextClusterOpen, service.TextClusterDismissed:
+	default:
+		writeError(w, http.StatusBadRequest, "status must be open, dismissed or all")
+		return
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, "admin", h.svc.Clusters(status)))
+}
+
+// Dismiss handles POST /api/admin/text-clusters/{id}/dismiss once the
+// ratings have been dealt with or found legitimate.
+func (h *DuplicateTextHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	cluster, err := h.svc.Dismiss(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	ratingIDs := make([]string, len(cluster.Members))
+	for i, m := range cluster.Members {
+		ratingIDs[i] = m.RatingID
+	}
+	h.auditSvc.Record(r.Context(), "text_cluster.dismiss", "text_cluster", cluster.ID, map[string]interface{}{
+		"rating_ids": ratingIDs,
+	})
+	writeJSON(w, http.StatusOK, cluster)
+}
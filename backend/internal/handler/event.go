@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// EventHandler handles venue events and specials.
+type EventHandler struct {
+	svc       *service.EventService
+	venueSvc  *service.VenueService
+	schoolSvc *service.SchoolService
+	owners    *OwnerHandler
+}
+
+func NewEventHandler(svc *service.EventService, venueSvc *service.VenueService, schoolSvc *service.SchoolService, owners *OwnerHandler) *EventHandler {
+	return &EventHandler{svc: svc, venueSvc: venueSvc, schoolSvc: schoolSvc, owners: owners}
+}
+
+// ListByVenue handles GET /api/venues/{id}/events
+func (h *EventHandler) ListByVenue(w http.ResponseWriter, r *http.Request) {
+	venue, err := h.venueSvc.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.svc.Upcoming(venue.ID, time.Now(), h.schoolSvc.Location(venue.SchoolID)))
+}
+
+// Create handles POST /api/owner/venues/{id}/events (venue owner or admin)
+func (h *EventHandler) Create(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	if !h.owners.canManage(r, venueID) {
+		writeError(w, http.StatusForbidden, "You must be the verified owner of this venue")
+		return
+	}
+	if _, err := h.venueSvc.GetByID(r.Context(), venueID); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	var req model.CreateVenueEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body (dates must be RFC 3339)")
+		return
+	}
+
+	event, err := h.svc.Create(r.Context(), venueID, req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if strings.Contains(err.Error(), "authentication required") {
+			status = http.StatusUnauthorized
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, event)
+}
+
+// CreateBatch handles POST /api/owner/venues/{id}/events/batch (venue owner or admin)
+func (h *EventHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	if !h.owners.canManage(r, venueID) {
+		writeError(w, http.StatusForbidden, "You must be the verified owner of this venue")
+		return
+	}
+	if _, err := h.venueSvc.GetByID(r.Context(), venueID); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	var req model.CreateVenueEventsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body (dates must be RFC 3339)")
+		return
+	}
+
+	events, err := h.svc.CreateBatch(r.Context(), venueID, req.Events)
+	if err != nil {
+		status := http.StatusBadRequest
+		if strings.Contains(err.Error(), "authentication required") {
+			status = http.StatusUnauthorized
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, events)
+}
+
+// Scheduled handles GET /api/owner/venues/{id}/events/scheduled (venue owner or admin)
+func (h *EventHandler) Scheduled(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	if !h.owners.canManage(r, venueID) {
+		writeError(w, http.StatusForbidden, "You must be the verified owner of this venue")
+		return
+	}
+	writeJSON(w, http.StatusOK, h.svc.Scheduled(venueID, time.Now()))
+}
+
+// Delete handles DELETE /api/owner/events/{id} (venue owner or admin)
+func (h *EventHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	event, err := h.svc.Get(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if !h.owners.canManage(r, event.VenueID) {
+		writeError(w, http.StatusForbidden, "You must be the verified owner of this venue")
+		return
+	}
+	if err := h.svc.Delete(event.ID); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "event deleted"})
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+const (
+	feedLimit = 30
+	// feedCrawlWindow is how far ahead public crawls show up on a school's feed.
+	feedCrawlWindow = 14 * 24 * time.Hour
+)
+
+// FeedHandler serves the activity feed, scoped to the viewer's home school
+// when they have one.
+type FeedHandler struct {
+	authSvc       *service.AuthService
+	schoolSvc     *service.SchoolService
+	venueSvc      *service.VenueService
+	ratingSvc     *service.RatingService
+	fratRatingSvc *service.FratRatingService
+	crawlSvc      *service.CrawlService
+}
+
+func NewFeedHandler(authSvc *service.AuthService, schoolSvc *service.SchoolService, venueSvc *service.VenueService, ratingSvc *service.RatingService, fratRatingSvc *service.FratRatingService,
+	crawlSvc *service.CrawlService) *FeedHandler {
+	return &FeedHandler{
+		authSvc:       authSvc,
+		schoolSvc:     schoolSvc,
+		venueSvc:      venueSvc,
+		ratingSvc:     ratingSvc,
+		fratRatingSvc: fratRatingSvc,
+		crawlSvc:      crawlSvc,
+	}
+}
+
+type feedItem struct {
+	Type      string    `json:"type"`
+	Text      string    `json:"text"`
+	VenueID   string    `json:"venue_id,omitempty"`
+	SchoolID  string    `json:"school_id,omitempty"`
+	CrawlID   string    `json:"crawl_id,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type feedResponse struct {
+	School *model.SchoolSummary `json:"school"`
+	Items  []feedItem           `json:"items"`
+}
+
+// Get handles GET /api/feed?school_id=
+// Without school_id the feed is scoped to the signed-in user's home school,
+// falling back to sitewide activity. School feeds also list upcoming public
+// crawls, stamped with when they were published.
+func (h *FeedHandler) Get(w http.ResponseWriter, r *http.Request) {
+	schoolID := r.URL.Query().Get("school_id")
+	if schoolID == "" {
+		if userID := middleware.GetUserID(r.Context()); userID != "" {
+			if user, err := h.authSvc.GetUser(userID); err == nil {
+				schoolID = user.HomeSchoolID
+			}
+		}
+	}
+
+	resp := feedResponse{Items: []feedItem{}}
+	if schoolID != "" {
+		summary, err := h.schoolSvc.Summary(schoolID)
+		if err != nil {
+			writeError(w, http.StatusNotFound, "School not found")
+			return
+		}
+		resp.School = summary
+	}
+
+	var ratings []model.Rating
+	var venues []model.Venue
+	var fratRatings []model.FratRating
+	var crawls []model.Crawl
+	if resp.School != nil {
+		ratings = h.ratingSvc.ListByVenues(h.venueSvc.GetVenueIDsBySchool(schoolID))
+		sort.Slice(ratings, func(i, j int) bool { return ratings[i].CreatedAt.After(ratings[j].CreatedAt) })
+		if len(ratings) > feedLimit {
+			ratings = ratings[:feedLimit]
+		}
+		for _, v := range h.venueSvc.GetAllVenues() {
+			if v.SchoolID == schoolID {
+				venues = append(venues, v)
+			}
+		}
+		fratRatings = h.fratRatingSvc.GetRecentForSchool(schoolID, feedLimit)
+		crawls = h.crawlSvc.UpcomingBySchool(schoolID, time.Now(), feedCrawlWindow)
+	} else {
+		ratings = h.ratingSvc.GetRecent(feedLimit)
+		venues = h.venueSvc.GetRecent(feedLimit)
+		fratRatings = h.fratRatingSvc.GetRecent(feedLimit)
+	}
+
+	for _, rating := range ratings {
+		venueName, venueSchool := "a venue", ""
+		if v, err := h.venueSvc.GetByID(r.Context(), rating.VenueID); err == nil {
+			venueName, venueSchool = v.Name, v.SchoolID
+		}
+		resp.Items = append(resp.Items, feedItem{
+			Type:      "rating",
+			Text:      fmt.Sprintf("%s rated %s %.0f/5", rating.AuthorName, venueName, rating.Score),
+			VenueID:   rating.VenueID,
+			SchoolID:  venueSchool,
+			Timestamp: rating.CreatedAt,
+		})
+	}
+	for _, venue := range venues {
+		resp.Items = append(resp.Items, feedItem{
+			Type:      "venue",
+			Text:      "New venue added: " + venue.Name,
+			VenueID:   venue.ID,
+			SchoolID:  venue.SchoolID,
+			Timestamp: venue.CreatedAt,
+		})
+	}
+	for _, fr := range fratRatings {
+		resp.Items = append(resp.Items, feedItem{
+			Type:      "frat_rating",
+			Text:      fmt.Sprintf("%s rated %s %s", fr.AuthorName, fr.FratName, service.FormatScore(fr.Score)),
+			SchoolID:  fr.SchoolID,
+			Timestamp: fr.CreatedAt,
+		})
+	}
+
+	for _, c := range crawls {
+		starts := c.StartsAt.In(h.schoolSvc.Location(c.SchoolID)).Format("Mon Jan 2, 3:04 PM")
+		text := fmt.Sprintf("%s is hosting %s on %s", c.OwnerName, c.Title, starts)
+		if c.Going > 1 {
+			text += fmt.Sprintf(" (%d going)", c.Going)
+		}
+		resp.Items = append(resp.Items, feedItem{
+			Type:      "crawl",
+			Text:      text,
+			SchoolID:  c.SchoolID,
+			CrawlID:   c.ID,
+			Timestamp: c.CreatedAt,
+		})
+	}
+
+	sort.SliceStable(resp.Items, func(i, j int) bool { return resp.Items[i].Timestamp.After(resp.Items[j].Timestamp) })
+	if len(resp.Items) > feedLimit {
+		resp.Items = resp.Items[:feedLimit]
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
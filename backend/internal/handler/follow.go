@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// FollowHandler handles venue follow requests.
+type FollowHandler struct {
+	svc      *service.FollowService
+	venueSvc *service.VenueService
+}
+
+func NewFollowHandler(svc *service.FollowService, venueSvc *service.VenueService) *FollowHandler {
+	return &FollowHandler{svc: svc, venueSvc: venueSvc}
+}
+
+// Follow handles POST /api/venues/{id}/follow
+func (h *FollowHandler) Follow(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	if _, err := h.venueSvc.GetByID(r.Context(), venueID); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err := h.svc.Follow(r.Context(), venueID); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"following": true,
+		"followers": h.svc.FollowerCount(venueID),
+	})
+}
+
+// Unfollow handles DELETE /api/venues/{id}/follow
+func (h *FollowHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	if err := h.svc.Unfollow(r.Context(), venueID); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"following": false,
+		"followers": h.svc.FollowerCount(venueID),
+	})
+}
+
+// ListMine handles GET /api/me/follows
+func (h *FollowHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	venues := []model.Venue{}
+	for _, id := range h.svc.FollowedBy(middleware.GetUserID(r.Context())) {
+		if v, err := h.venueSvc.GetByID(r.Context(), id); err == nil {
+			venues = append(venues, *v)
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, "follows", venues))
+}
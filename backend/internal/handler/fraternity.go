@@ -12,29 +12,42 @@ import (
 type FraternityHandler struct {
 	svc       *service.FraternityService
 	ratingSvc *service.FratRatingService
+	schoolSvc *service.SchoolService
+	auditSvc  *service.AuditService
 }
 
-func NewFraternityHandler(svc *service.FraternityService, ratingSvc *service.FratRatingService) *FraternityHandler {
-	return &FraternityHandler{svc: svc, ratingSvc: ratingSvc}
+func NewFraternityHandler(svc *service.FraternityService, ratingSvc *service.FratRatingService, schoolSvc *service.SchoolService,
+	auditSvc *service.AuditService) *FraternityHandler {
+	return &FraternityHandler{svc: svc, ratingSvc: ratingSvc, schoolSvc: schoolSvc, auditSvc: auditSvc}
 }
 
 // GetBySchool handles GET /api/schools/{id}/fraternities
 func (h *FraternityHandler) GetBySchool(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	if h.svc.OptedOut(id) {
+		writeError(w, http.StatusNotFound, "Greek life is not listed for this school")
+		return
+	}
 	frats := h.svc.GetBySchool(id)
 	if frats == nil {
 		frats = []model.FratWithRating{}
 	}
-	writeJSON(w, http.StatusOK, frats)
+	writeJSON(w, http.StatusOK, paginate(w, r, "fraternities", frats))
 }
 
-// ListAll handles GET /api/fraternities
+// ListAll handles GET /api/fraternities?q=... — q matches names, Greek
+// letters, abbreviations and nicknames.
 func (h *FraternityHandler) ListAll(w http.ResponseWriter, r *http.Request) {
-	names := h.svc.ListAll()
+	var names []string
+	if q := r.URL.Query().Get("q"); q != "" {
+		names = h.svc.Search(q)
+	} else {
+		names = h.svc.ListAll()
+	}
 	if names == nil {
 		names = []string{}
 	}
-	writeJSON(w, http.StatusOK, names)
+	writeJSON(w, http.StatusOK, paginate(w, r, "fraternities", names))
 }
 
 // GetSchoolsByFrat handles GET /api/fraternities/schools?name=...
@@ -48,7 +61,7 @@ func (h *FraternityHandler) GetSchoolsByFrat(w http.ResponseWriter, r *http.Requ
 	if ids == nil {
 		ids = []string{}
 	}
-	writeJSON(w, http.StatusOK, ids)
+	writeJSON(w, http.StatusOK, paginate(w, r, "fraternities", ids))
 }
 
 // AdminAdd handles POST /api/admin/fraternities — adds a frat to a school.
@@ -69,6 +82,7 @@ func (h *FraternityHandler) AdminAdd(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusConflict, "fraternity already exists at this school")
 		return
 	}
+	h.auditSvc.Record(r.Context(), "fraternity.add", "frat", service.FratTargetID(req.SchoolID, req.FratName), nil)
 	writeJSON(w, http.StatusCreated, map[string]string{"status": "added"})
 }
 
@@ -80,13 +94,88 @@ func (h *FraternityHandler) AdminRemove(w http.ResponseWriter, r *http.Request)
 		writeError(w, http.StatusBadRequest, "frat_name and school_id query parameters are required")
 		return
 	}
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if dryRun {
+		if !h.svc.HasChapter(schoolID, fratName) {
+			writeError(w, http.StatusNotFound, "fraternity not found at this school")
+			return
+		}
+		// Chapter ratings stay stored but drop off the school page.
+		preview := newDryRun("fraternity.remove")
+		addDryRun(preview, "chapters", []string{service.FratTargetID(schoolID, fratName)})
+		addDryRun(preview, "frat_ratings", h.ratingSvc.IDsByChapter(schoolID, fratName))
+		writeJSON(w, http.StatusOK, preview)
+		return
+	}
 	if ok := h.svc.RemoveFromSchool(fratName, schoolID); !ok {
 		writeError(w, http.StatusNotFound, "fraternity not found at this school")
 		return
 	}
+	h.auditSvc.Record(r.Context(), "fraternity.remove", "frat", service.FratTargetID(schoolID, fratName), nil)
 	writeJSON(w, http.StatusOK, map[string]string{"status": "removed"})
 }
 
+// AdminSetStatus handles PUT /api/admin/fraternities/status — marks a chapter
+// active, suspended or banned. Banned chapters keep their rating history.
+func (h *FraternityHandler) AdminSetStatus(w http.ResponseWriter, r *http.Request) {
+	var req model.ChapterStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.FratName == "" || req.SchoolID == "" {
+		writeError(w, http.StatusBadRequest, "frat_name and school_id are required")
+		return
+	}
+	if err := h.svc.SetStatus(req.SchoolID, req.FratName, req.Status); err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "fraternity not found at this school" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "fraternity.status", "frat", service.FratTargetID(req.SchoolID, req.FratName), map[string]interface{}{
+		"status": req.Status,
+	})
+	writeJSON(w, http.StatusOK, map[string]string{"status": req.Status})
+}
+
+// ListOptOuts handles GET /api/admin/greek-opt-outs
+func (h *FraternityHandler) ListOptOuts(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.svc.OptedOutSchools())
+}
+
+// SetOptOut handles PUT /api/admin/schools/{id}/greek-opt-out — hides (or
+// restores) a school's fraternities, chapter ratings and frat counts.
+func (h *FraternityHandler) SetOptOut(w http.ResponseWriter, r *http.Request) {
+	schoolID := chi.URLParam(r, "id")
+	var req model.GreekOptOutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if _, err := h.schoolSvc.GetByID(r.Context(), schoolID); err != nil {
+		writeError(w, http.StatusNotFound, "school not found")
+		return
+	}
+
+	h.svc.SetOptOut(schoolID, req.OptOut)
+	h.schoolSvc.SetGreekOptOut(schoolID, req.OptOut, h.svc.Count(schoolID))
+
+	action := "school.greek_opt_out"
+	if !req.OptOut {
+		action = "school.greek_opt_in"
+	}
+	h.auditSvc.Record(r.Context(), action, "school", schoolID, nil)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"school_id": schoolID, "opt_out": req.OptOut})
+}
+
 // CreateRating handles POST /api/frat-ratings
 func (h *FraternityHandler) CreateRating(w http.ResponseWriter, r *http.Request) {
 	var req model.CreateFratRatingRequest
@@ -97,9 +186,12 @@ func (h *FraternityHandler) CreateRating(w http.ResponseWriter, r *http.Request)
 
 	rating, err := h.ratingSvc.Create(r.Context(), req)
 	if err != nil {
-		status := http.StatusBadRequest
-		if err.Error() == "authentication required" {
-			status = http.StatusUnauthorized
+		status := ratingErrorStatus(err)
+		switch err.Error() {
+		case "fraternity ratings are not available at this school":
+			status = http.StatusNotFound
+		case "this chapter is banned and no longer accepts ratings":
+			status = http.StatusForbidden
 		}
 		writeError(w, status, err.Error())
 		return
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// maxScheduleImportBytes caps the size of an uploaded CSV or iCalendar file.
+const maxScheduleImportBytes = 1 << 20
+
+// GameDayHandler serves football schedules and their admin tools.
+type GameDayHandler struct {
+	svc      *service.GameDayService
+	auditSvc *service.AuditService
+}
+
+func NewGameDayHandler(svc *service.GameDayService, auditSvc *service.AuditService) *GameDayHandler {
+	return &GameDayHandler{svc: svc, auditSvc: auditSvc}
+}
+
+func gameErrorStatus(err error) int {
+	switch {
+	case err.Error() == "school not found" || err.Error() == "game not found":
+		return http.StatusNotFound
+	case err.Error() == "a game is already scheduled at that kickoff":
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// List handles GET /api/schools/{id}/games?all=true — upcoming games by
+// default, the whole schedule with all=true.
+func (h *GameDayHandler) List(w http.ResponseWriter, r *http.Request) {
+	all := r.URL.Query().Get("all") == "true"
+	writeJSON(w, http.StatusOK, h.svc.List(chi.URLParam(r, "id"), !all, time.Now()))
+}
+
+// Create handles POST /api/admin/schools/{id}/games
+func (h *GameDayHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.FootballGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	game, err := h.svc.Create(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		writeError(w, gameErrorStatus(err), err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "game.create", "game", game.ID, map[string]interface{}{
+		"school_id": game.SchoolID,
+		"opponent":  game.Opponent,
+		"kickoff":   game.Kickoff,
+		"home":      game.Home,
+	})
+	writeJSON(w, http.StatusCreated, game)
+}
+
+// Update handles PUT /api/admin/games/{id}
+func (h *GameDayHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req model.FootballGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	game, err := h.svc.Update(chi.URLParam(r, "id"), req)
+	if err != nil {
+		writeError(w, gameErrorStatus(err), err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "game.update", "game", game.ID, map[string]interface{}{
+		"school_id": game.SchoolID,
+		"opponent":  game.Opponent,
+		"kickoff":   game.Kickoff,
+		"home":      game.Home,
+	})
+	writeJSON(w, http.StatusOK, game)
+}
+
+// Delete handles DELETE /api/admin/games/{id}
+func (h *GameDayHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	game, err := h.svc.Delete(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, gameErrorStatus(err), err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "game.delete", "game", game.ID, map[string]interface{}{
+		"school_id": game.SchoolID,
+		"opponent":  game.Opponent,
+		"kickoff":   game.Kickoff,
+	})
+	writeJSON(w, http.StatusOK, map[string]string{"message": "game deleted"})
+}
+
+// Import handles POST /api/admin/schools/{id}/games/import?format=csv|ics.
+// The body is the raw file; without ?format= it is picked from the
+// Content-Type (text/csv or text/calendar).
+func (h *GameDayHandler) Import(w http.ResponseWriter, r *http.Request) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		switch ct := r.Header.Get("Content-Type"); {
+		case strings.HasPrefix(ct, "text/csv"):
+			format = "csv"
+		case strings.HasPrefix(ct, "text/calendar"):
+			format = "ics"
+		}
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxScheduleImportBytes))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "schedule file is too large")
+		return
+	}
+
+	schoolID := chi.URLParam(r, "id")
+	result, err := h.svc.Import(r.Context(), schoolID, format, data)
+	if err != nil {
+		writeError(w, gameErrorStatus(err), err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "game.import", "school", schoolID, map[string]interface{}{
+		"format":    format,
+		"created":   result.Created,
+		"updated":   result.Updated,
+		"unchanged": result.Unchanged,
+		"skipped":   len(result.Errors),
+	})
+	writeJSON(w, http.StatusOK, result)
+}
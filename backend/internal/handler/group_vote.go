@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// GroupVoteHandler serves group votes on where to go.
+type GroupVoteHandler struct {
+	svc       *service.GroupVoteService
+	venueSvc  *service.VenueService
+	schoolSvc *service.SchoolService
+}
+
+func NewGroupVoteHandler(svc *service.GroupVoteService, venueSvc *service.VenueService, schoolSvc *service.SchoolService) *GroupVoteHandler {
+	return &GroupVoteHandler{svc: svc, venueSvc: venueSvc, schoolSvc: schoolSvc}
+}
+
+func groupErrorStatus(err error) int {
+	switch err.Error() {
+	case "authentication required":
+		return http.StatusUnauthorized
+	case "group session not found":
+		return http.StatusNotFound
+	case "only the host can close voting":
+		return http.StatusForbidden
+	case "voting has closed", "this group is full":
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
+
+// Create handles POST /api/groups — without venue_ids the school's top-rated
+// venues are the candidates.
+func (h *GroupVoteHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.GroupSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.SchoolID != "" {
+		if _, err := h.schoolSvc.GetByID(r.Context(), req.SchoolID); err != nil {
+			writeError(w, http.StatusNotFound, "school not found")
+			return
+		}
+	}
+
+	session, err := h.svc.Create(r.Context(), req)
+	if err != nil {
+		writeError(w, groupErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, h.enrich(r, session))
+}
+
+// Join handles POST /api/groups/join
+func (h *GroupVoteHandler) Join(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	session, err := h.svc.Join(r.Context(), req.Code)
+	if err != nil {
+		writeError(w, groupErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.enrich(r, session))
+}
+
+// Get handles GET /api/groups/{id}
+func (h *GroupVoteHandler) Get(w http.ResponseWriter, r *http.Request) {
+	session, err := h.svc.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, groupErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.enrich(r, session))
+}
+
+// Vote handles POST /api/groups/{id}/votes
+func (h *GroupVoteHandler) Vote(w http.ResponseWriter, r *http.Request) {
+	var req model.GroupVoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	session, err := h.svc.Vote(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		writeError(w, groupErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.enrich(r, session))
+}
+
+// Close handles POST /api/groups/{id}/close (host only)
+func (h *GroupVoteHandler) Close(w http.ResponseWriter, r *http.Request) {
+	session, err := h.svc.Close(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, groupErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.enrich(r, session))
+}
+
+// Events handles GET /api/groups/{id}/events — a Server-Sent Events stream
+// that sends a "state" event with the session on connect and after every
+// change, and "expired" when the session ends. Streams are closed after
+// eventStreamMaxDuration and clients reconnect.
+func (h *GroupVoteHandler) Events(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	changes, stop, err := h.svc.Watch(r.Context(), id)
+	if err != nil {
+		writeError(w, groupErrorStatus(err), err.Error())
+		return
+	}
+	defer stop()
+
+	stream, err := newEventStream(w)
+	if err != nil {
+		return
+	}
+	sendState := func() bool {
+		session, err := h.svc.Get(r.Context(), id)
+		if err != nil {
+			stream.Send("expired", map[string]string{"id": id})
+			return false
+		}
+		return stream.Send("state", h.enrich(r, session)) == nil
+	}
+	if !sendState() {
+		return
+	}
+
+	deadline := time.NewTimer(eventStreamMaxDuration)
+	defer deadline.Stop()
+	heartbeat := time.NewTicker(eventStreamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-deadline.C:
+			return
+		case <-heartbeat.C:
+			if stream.Ping() != nil {
+				return
+			}
+		case _, ok := <-changes:
+			if !ok {
+				stream.Send("expired", map[string]string{"id": id})
+				return
+			}
+			if !sendState() {
+				return
+			}
+		}
+	}
+}
+
+// enrich attaches venue details to the candidates and the pick.
+func (h *GroupVoteHandler) enrich(r *http.Request, session *model.GroupSession) *model.GroupSession {
+	for i := range session.Candidates {
+		if v, err := h.venueSvc.GetByID(r.Context(), session.Candidates[i].VenueID); err == nil {
+			venue := *v
+			session.Candidates[i].Venue = &venue
+		}
+	}
+	if session.Pick != nil {
+		for _, c := range session.Candidates {
+			if c.VenueID == session.Pick.VenueID {
+				pick := c
+				session.Pick = &pick
+			}
+		}
+	}
+	return session
+}
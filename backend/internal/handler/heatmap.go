@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// defaultHeatmapZoom is used when the client doesn't send ?zoom=.
+const defaultHeatmapZoom = 10
+
+// HeatmapHandler serves map heat layer data.
+type HeatmapHandler struct {
+	svc *service.HeatmapService
+}
+
+func NewHeatmapHandler(svc *service.HeatmapService) *HeatmapHandler {
+	return &HeatmapHandler{svc: svc}
+}
+
+// Get handles GET /api/heatmap?bbox=min_lng,min_lat,max_lng,max_lat&metric=ratings|checkins&zoom=10
+func (h *HeatmapHandler) Get(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	parts := strings.Split(q.Get("bbox"), ",")
+	var box [4]float64
+	valid := len(parts) == 4
+	for i := 0; valid && i < 4; i++ {
+		v, err := strconv.ParseFloat(strings.TrimSpace(parts[i]), 64)
+		valid = err == nil
+		box[i] = v
+	}
+	if !valid {
+		writeError(w, http.StatusBadRequest, "bbox must be min_lng,min_lat,max_lng,max_lat")
+		return
+	}
+
+	metric := q.Get("metric")
+	if metric == "" {
+		metric = service.HeatmapRatings
+	}
+	zoom := defaultHeatmapZoom
+	if v := q.Get("zoom"); v != "" {
+		z, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "zoom must be a whole number")
+			return
+		}
+		zoom = z
+	}
+
+	heatmap, err := h.svc.Get(metric, zoom, box[1], box[0], box[3], box[2])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, heatmap)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// maxIncludeDepth limits how far ?include= may nest ("venues.ratings" is 2).
+const maxIncludeDepth = 2
+
+// nestedIncludeLimit caps the items embedded per parent for nested
+// expansions, so one request can't fan out across a whole school.
+const nestedIncludeLimit = 5
+
+// Expansions accepted on each detail endpoint.
+var (
+	schoolIncludes = []string{"venues", "ratings", "fraternities", "venues.ratings"}
+	venueIncludes  = []string{"ratings", "school", "school.fraternities"}
+)
+
+// parseInclude reads ?include=a,b.c into a set of paths. A nested path also
+// includes its parents. Unknown or too-deep paths are rejected.
+func parseInclude(r *http.Request, allowed []string) (map[string]bool, error) {
+	raw := r.URL.Query().Get("include")
+	if raw == "" {
+		return nil, nil
+	}
+	include := make(map[string]bool)
+	for _, path := range strings.Split(raw, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		parts := strings.Split(path, ".")
+		if len(parts) > maxIncludeDepth {
+			return nil, fmt.Errorf("include depth is limited to %d", maxIncludeDepth)
+		}
+		known := false
+		for _, a := range allowed {
+			if a == path {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown include %q (allowed: %s)", path, strings.Join(allowed, ", "))
+		}
+		for i := range parts {
+			include[strings.Join(parts[:i+1], ".")] = true
+		}
+	}
+	return include, nil
+}
+
+// firstPage wraps the first limit items in the standard list envelope.
+func firstPage[T any](items []T, limit int) *model.PaginatedResponse {
+	data := pageSlice(items, 1, limit)
+	if data == nil {
+		data = []T{}
+	}
+	return &model.PaginatedResponse{
+		Data:       data,
+		Total:      len(items),
+		Page:       1,
+		Limit:      limit,
+		TotalPages: (len(items) + limit - 1) / limit,
+	}
+}
+
+// Expander embeds related collections into school and venue detail
+// responses for ?include=. Embedded lists are the first page at the
+// endpoint's default size; clients page further through the list endpoints.
+type Expander struct {
+	schoolSvc *service.SchoolService
+	venueSvc  *service.VenueService
+	ratingSvc *service.RatingService
+	fratSvc   *service.FraternityService
+}
+
+func NewExpander(schoolSvc *service.SchoolService, venueSvc *service.VenueService, ratingSvc *service.RatingService, fratSvc *service.FraternityService) *Expander {
+	return &Expander{schoolSvc: schoolSvc, venueSvc: venueSvc, ratingSvc: ratingSvc, fratSvc: fratSvc}
+}
+
+// School builds a school detail. prefix is the include path the school sits
+// under ("" at the top level, "school." inside a venue).
+func (e *Expander) School(ctx context.Context, school *model.School, include map[string]bool, prefix string) model.SchoolDetail {
+	detail := model.SchoolDetail{School: school}
+	limits := PaginationLimits()
+
+	if include[prefix+"venues"] {
+		limit := limits["venues"].Default
+		page, err := e.venueSvc.ListBySchool(ctx, school.ID, 1, limit)
+		if err == nil {
+			if include[prefix+"venues.ratings"] {
+				venues, _ := page.Data.([]model.Venue)
+				expanded := make([]model.VenueDetail, 0, len(venues))
+				for _, v := range venues {
+					expanded = append(expanded, model.VenueDetail{Venue: v, Ratings: e.venueRatings(ctx, v.ID, nestedIncludeLimit)})
+				}
+				page.Data = expanded
+			}
+			detail.Venues = page
+		}
+	}
+	if include[prefix+"ratings"] {
+		ratings := e.ratingSvc.ListByVenues(e.venueSvc.GetVenueIDsBySchool(school.ID))
+		e.ratingSvc.Sort(ratings, "")
+		detail.Ratings = firstPage(ratings, limits["school_ratings"].Default)
+	}
+	if include[prefix+"fraternities"] && !e.fratSvc.OptedOut(school.ID) {
+		detail.Fraternities = firstPage(e.fratSvc.GetBySchool(school.ID), limits["fraternities"].Default)
+	}
+	return detail
+}
+
+// Venue adds the requested expansions to a venue detail.
+func (e *Expander) Venue(ctx context.Context, venue model.Venue, include map[string]bool) model.VenueDetail {
+	detail := model.VenueDetail{Venue: venue}
+	if include["ratings"] {
+		detail.Ratings = e.venueRatings(ctx, venue.ID, PaginationLimits()["venue_ratings"].Default)
+	}
+	if include["school"] {
+		if school, err := e.schoolSvc.GetByID(ctx, venue.SchoolID); err == nil {
+			sd := e.School(ctx, school, include, "school.")
+			detail.School = &sd
+		}
+	}
+	return detail
+}
+
+func (e *Expander) venueRatings(ctx context.Context, venueID string, limit int) *model.PaginatedResponse {
+	ratings, err := e.ratingSvc.ListByVenue(ctx, venueID)
+	if err != nil {
+		return nil
+	}
+	e.ratingSvc.Sort(ratings, "")
+	return firstPage(ratings, limit)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// InviteHandler serves invite codes and the referral leaderboard.
+type InviteHandler struct {
+	svc *service.InviteService
+}
+
+func NewInviteHandler(svc *service.InviteService) *InviteHandler {
+	return &InviteHandler{svc: svc}
+}
+
+// Mine handles GET /api/me/invite
+func (h *InviteHandler) Mine(w http.ResponseWriter, r *http.Request) {
+	summary, err := h.svc.Summary(middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// Leaderboard handles GET /api/leaderboard/referrals
+func (h *InviteHandler) Leaderboard(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, paginate(w, r, "leaderboard", h.svc.Leaderboard(LeaderboardSize)))
+}
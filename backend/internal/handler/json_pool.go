@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// maxPooledBuffer caps the encode buffers kept for reuse, so one unusually
+// large response doesn't pin its memory in the pool.
+const maxPooledBuffer = 4 << 20
+
+type pooledEncoder struct {
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+var encoderPool = sync.Pool{New: func() any {
+	e := &pooledEncoder{}
+	e.enc = json.NewEncoder(&e.buf)
+	return e
+}}
+
+// writeJSONPooled is writeJSON for the hottest endpoints (the school map,
+// geo lookups and venue lists). The body is encoded into a reused buffer and
+// sent with a Content-Length, so large responses don't allocate a fresh
+// encoder and growing buffer per request, and an encoding failure becomes a
+// 500 instead of a truncated 200.
+func writeJSONPooled(w http.ResponseWriter, status int, data interface{}) {
+	e := encoderPool.Get().(*pooledEncoder)
+	defer func() {
+		if e.buf.Cap() <= maxPooledBuffer {
+			e.buf.Reset()
+			encoderPool.Put(e)
+		}
+	}()
+
+	e.buf.Reset()
+	if err := e.enc.Encode(data); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to encode response")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(e.buf.Len()))
+	w.WriteHeader(status)
+	w.Write(e.buf.Bytes())
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// discardWriter is a ResponseWriter that keeps nothing, so benchmarks only
+// count the encoder's allocations.
+type discardWriter struct{ header http.Header }
+
+func (w *discardWriter) Header() http.Header         { return w.header }
+func (w *discardWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (w *discardWriter) WriteHeader(int)             {}
+
+// mapPayload has the shape of GetAllForMap's result for every US school.
+func mapPayload() []map[string]interface{} {
+	out := make([]map[string]interface{}, 6000)
+	for i := range out {
+		out[i] = map[string]interface{}{
+			"id": fmt.Sprint(100000 + i), "name": fmt.Sprintf("School %d", i),
+			"latitude": 30 + float64(i%200)/10, "longitude": -120 + float64(i%500)/10,
+			"state": "WI", "country": "US", "control": "public", "iclevel": 1,
+			"venue_count": i % 40, "avg_rating": 3.5, "frat_count": i % 12, "instsize": 3,
+			"hbcu": false, "is_online": false, "is_tribal": false, "is_religious": false,
+			"is_community_college": false, "is_liberal_arts": false, "is_graduate_only": false,
+		}
+	}
+	return out
+}
+
+// geoPayload is a viewport's worth of schools from GetGeo.
+func geoPayload() []model.School {
+	out := make([]model.School, 500)
+	for i := range out {
+		out[i] = model.School{
+			ID: fmt.Sprint(100000 + i), UnitID: 100000 + i, Name: fmt.Sprintf("School %d", i),
+			Address: "1 University Ave", City: "Madison", CitySlug: "madison", State: "WI", Zip: "53706",
+			Country: "US", Control: "public", ICLevel: 1, Latitude: 43.07, Longitude: -89.4,
+			InstSize: 5, VenueCount: i % 40, FratCount: i % 12, AvgRating: 3.8,
+		}
+	}
+	return out
+}
+
+// venueListPayload is one page of a school's venue list.
+func venueListPayload() model.SchoolVenuesResponse {
+	venues := make([]model.Venue, 50)
+	for i := range venues {
+		pct := 70 + i%30
+		venues[i] = model.Venue{
+			ID: fmt.Sprintf("venue_%d", i), Name: fmt.Sprintf("Bar %d", i), Category: "bar",
+			Description: "Cheap pitchers and a patio", Address: "123 State St",
+			Latitude: 43.07, Longitude: -89.39, SchoolID: "240444", CreatedByID: "user_1",
+			CreatedAt: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), Verified: true,
+			AvgRating: 4.1, RatingCount: 120, ThumbsUp: 90, ThumbsDown: 12, RecommendPct: &pct,
+		}
+	}
+	return model.SchoolVenuesResponse{
+		PaginatedResponse: model.PaginatedResponse{Data: venues, Total: 180, Page: 1, Limit: 50, TotalPages: 4},
+		Sponsored:         venues[:2],
+	}
+}
+
+func benchmarkEncoders(b *testing.B, data interface{}) {
+	b.Run("writeJSON", func(b *testing.B) {
+		w := &discardWriter{header: make(http.Header)}
+		b.ReportAllocs()
+		for b.Loop() {
+			writeJSON(w, http.StatusOK, data)
+		}
+	})
+	b.Run("writeJSONPooled", func(b *testing.B) {
+		w := &discardWriter{header: make(http.Header)}
+		b.ReportAllocs()
+		for b.Loop() {
+			writeJSONPooled(w, http.StatusOK, data)
+		}
+	})
+}
+
+func BenchmarkEncodeSchoolMap(b *testing.B) {
+	benchmarkEncoders(b, mapPayload())
+}
+
+func BenchmarkEncodeSchoolGeo(b *testing.B) {
+	benchmarkEncoders(b, geoPayload())
+}
+
+func BenchmarkEncodeVenueList(b *testing.B) {
+	benchmarkEncoders(b, venueListPayload())
+}
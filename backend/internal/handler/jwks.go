@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ratemybars/backend/internal/middleware"
+)
+
+// JWKS handles GET /.well-known/jwks.json — the public keys other services
+// use to verify auth tokens. The set is empty with HS256 signing.
+func JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	writeJSON(w, http.StatusOK, map[string]interface{}{"keys": middleware.JWKS()})
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// LockHandler lets admins lock ratings on a venue or frat chapter.
+type LockHandler struct {
+	svc      *service.RatingLockService
+	auditSvc *service.AuditService
+}
+
+func NewLockHandler(svc *service.RatingLockService, auditSvc *service.AuditService) *LockHandler {
+	return &LockHandler{svc: svc, auditSvc: auditSvc}
+}
+
+// List handles GET /api/admin/rating-locks
+func (h *LockHandler) List(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.svc.Active())
+}
+
+// Create handles POST /api/admin/rating-locks
+func (h *LockHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateRatingLockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	lock, err := h.svc.Create(r.Context(), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch err.Error() {
+		case "venue not found":
+			status = http.StatusNotFound
+		case "ratings on this venue are already locked", "ratings on this frat are already locked":
+			status = http.StatusConflict
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "ratings.lock", lock.TargetType, lock.TargetID, map[string]interface{}{
+		"lock_id":    lock.ID,
+		"reason":     lock.Reason,
+		"expires_at": lock.ExpiresAt,
+	})
+	writeJSON(w, http.StatusCreated, lock)
+}
+
+// Delete handles DELETE /api/admin/rating-locks/{id}
+func (h *LockHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	lock, err := h.svc.Delete(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "ratings.unlock", lock.TargetType, lock.TargetID, map[string]interface{}{
+		"lock_id": lock.ID,
+	})
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Lock removed"})
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// LoginLockoutHandler lets admins see and lift login lockouts.
+type LoginLockoutHandler struct {
+	svc      *service.AuthService
+	auditSvc *service.AuditService
+}
+
+func NewLoginLockoutHandler(svc *service.AuthService, auditSvc *service.AuditService) *LoginLockoutHandler {
+	return &LoginLockoutHandler{svc: svc, auditSvc: auditSvc}
+}
+
+// List handles GET /api/admin/login-lockouts
+func (h *LoginLockoutHandler) List(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, paginate(w, r, "admin", h.svc.LoginLockouts()))
+}
+
+// Clear handles POST /api/admin/login-lockouts/clear
+func (h *LoginLockoutHandler) Clear(w http.ResponseWriter, r *http.Request) {
+	var req model.ClearLoginLockoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	n, err := h.svc.ClearLoginLockout(req.Email, req.IP)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if n == 0 {
+		writeError(w, http.StatusNotFound, "no failed logins recorded")
+		return
+	}
+
+	target := req.Email
+	if target == "" {
+		target = req.IP
+	}
+	h.auditSvc.Record(r.Context(), "login_lockout.clear", "login_lockout", target, map[string]interface{}{
+		"email": req.Email,
+		"ip":    req.IP,
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"message": "lockout cleared", "cleared": n})
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+	"runtime"
+
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// MetricsHandler reports process and in-memory store usage.
+type MetricsHandler struct {
+	ratings *service.RatingService
+}
+
+func NewMetricsHandler(ratings *service.RatingService) *MetricsHandler {
+	return &MetricsHandler{ratings: ratings}
+}
+
+// Get handles GET /metrics — heap usage plus how many ratings are resident
+// and, with RATING_CACHE_VENUES set, the venue cache's hit and eviction
+// counts.
+func (h *MetricsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	writeJSON(w, http.StatusOK, model.StoreMetrics{
+		HeapAllocBytes: mem.HeapAlloc,
+		HeapSysBytes:   mem.HeapSys,
+		NumGC:          mem.NumGC,
+		Goroutines:     runtime.NumGoroutine(),
+		Ratings:        h.ratings.StoreStats(),
+	})
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// ModerationHandler serves admin tools for moderating review content.
+type ModerationHandler struct {
+	ratingSvc *service.RatingService
+	venueSvc  *service.VenueService
+	schoolSvc *service.SchoolService
+	auditSvc  *service.AuditService
+}
+
+func NewModerationHandler(ratingSvc *service.RatingService, venueSvc *service.VenueService, schoolSvc *service.SchoolService,
+	auditSvc *service.AuditService) *ModerationHandler {
+	return &ModerationHandler{ratingSvc: ratingSvc, venueSvc: venueSvc, schoolSvc: schoolSvc, auditSvc: auditSvc}
+}
+
+// Redact handles POST /api/admin/ratings/{id}/redact (admin only)
+func (h *ModerationHandler) Redact(w http.ResponseWriter, r *http.Request) {
+	ratingID := chi.URLParam(r, "id")
+
+	var req model.RedactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.Terms) == 0 {
+		writeError(w, http.StatusBadRequest, "terms is required")
+		return
+	}
+
+	original, rating, err := h.ratingSvc.Redact(ratingID, req.Terms)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "rating not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "rating.redact", "rating", ratingID, map[string]interface{}{
+		"terms":           req.Terms,
+		"original_review": original,
+	})
+	writeJSON(w, http.StatusOK, rating)
+}
+
+// History handles GET /api/admin/ratings/{id}/history (admin only)
+func (h *ModerationHandler) History(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.auditSvc.ForTarget("rating", chi.URLParam(r, "id")))
+}
+
+// ListPending handles GET /api/admin/ratings/pending (admin only)
+func (h *ModerationHandler) ListPending(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, paginate(w, r, "admin", h.ratingSvc.ListPending()))
+}
+
+// ApprovePending handles POST /api/admin/ratings/{id}/approve (admin only).
+// An optional {"review": "..."} body publishes edited text instead.
+func (h *ModerationHandler) ApprovePending(w http.ResponseWriter, r *http.Request) {
+	ratingID := chi.URLParam(r, "id")
+
+	var req struct {
+		Review string `json:"review"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	rating, err := h.ratingSvc.ApprovePending(ratingID, req.Review)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	refreshVenueStats(h.ratingSvc, h.venueSvc, h.schoolSvc, rating.VenueID)
+
+	h.auditSvc.Record(r.Context(), "rating.approve", "rating", ratingID, map[string]interface{}{
+		"edited": req.Review != "",
+	})
+	writeJSON(w, http.StatusOK, rating)
+}
+
+// RejectPending handles DELETE /api/admin/ratings/{id}/pending (admin only)
+func (h *ModerationHandler) RejectPending(w http.ResponseWriter, r *http.Request) {
+	ratingID := chi.URLParam(r, "id")
+
+	rating, err := h.ratingSvc.RejectPending(ratingID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	h.auditSvc.Record(r.Context(), "rating.reject", "rating", ratingID, map[string]interface{}{
+		"venue_id":  rating.VenueID,
+		"author_id": rating.AuthorID,
+	})
+	writeJSON(w, http.StatusOK, map[string]string{"message": "rating rejected"})
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+const nearbyComparisonRadiusKm = 2.0
+
+// OwnerHandler serves tools for verified venue owners.
+type OwnerHandler struct {
+	claimSvc  *service.ClaimService
+	venueSvc  *service.VenueService
+	ratingSvc *service.RatingService
+	shareSvc  *service.ShareService
+	followSvc *service.FollowService
+}
+
+func NewOwnerHandler(claimSvc *service.ClaimService, venueSvc *service.VenueService, ratingSvc *service.RatingService,
+	shareSvc *service.ShareService, followSvc *service.FollowService) *OwnerHandler {
+	return &OwnerHandler{claimSvc: claimSvc, venueSvc: venueSvc, ratingSvc: ratingSvc, shareSvc: shareSvc, followSvc: followSvc}
+}
+
+// canManage reports whether the current user owns the venue (admins can see any venue).
+func (h *OwnerHandler) canManage(r *http.Request, venueID string) bool {
+	if middleware.GetUserRole(r.Context()) == "admin" {
+		return true
+	}
+	return h.claimSvc.IsOwner(middleware.GetUserID(r.Context()), venueID)
+}
+
+// ListVenues handles GET /api/owner/venues
+func (h *OwnerHandler) ListVenues(w http.ResponseWriter, r *http.Request) {
+	venues := []model.Venue{}
+	for _, id := range h.claimSvc.OwnedVenues(middleware.GetUserID(r.Context())) {
+		if v, err := h.venueSvc.GetByID(r.Context(), id); err == nil {
+			venues = append(venues, *v)
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, "owner_venues", venues))
+}
+
+// Analytics handles GET /api/owner/venues/{id}/analytics?weeks=12
+func (h *OwnerHandler) Analytics(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	if !h.canManage(r, venueID) {
+		writeError(w, http.StatusForbidden, "You must be the verified owner of this venue")
+		return
+	}
+
+	venue, err := h.venueSvc.GetByID(r.Context(), venueID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	weeks, _ := strconv.Atoi(r.URL.Query().Get("weeks"))
+	if weeks <= 0 || weeks > 104 {
+		weeks = 12
+	}
+
+	links := []model.ShareLink{}
+	for _, l := range h.shareSvc.List() {
+		if l.TargetType == "venue" && l.TargetID == venueID {
+			links = append(links, l)
+		}
+	}
+
+	report := model.VenueAnalytics{
+		VenueID:      venueID,
+		AvgRating:    venue.AvgRating,
+		RatingCount:  venue.RatingCount,
+		ThumbsUp:     venue.ThumbsUp,
+		ThumbsDown:   venue.ThumbsDown,
+		RecommendPct: venue.RecommendPct,
+		Followers:    h.followSvc.FollowerCount(venueID),
+		RatingTrend:  h.ratingSvc.GetVenueTrend(venueID, weeks),
+		ShareClicks:  h.shareSvc.ClicksForTarget("venue", venueID),
+		ShareLinks:   links,
+		TagCounts:    h.ratingSvc.GetVenueTagCounts(venueID),
+		Nearby:       h.nearbyComparison(*venue),
+		GeneratedAt:  time.Now(),
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// SetHours handles PUT /api/owner/venues/{id}/hours
+func (h *OwnerHandler) SetHours(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	if !h.canManage(r, venueID) {
+		writeError(w, http.StatusForbidden, "You must be the verified owner of this venue")
+		return
+	}
+
+	var req struct {
+		Hours []model.OpeningHours `json:"hours"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := h.venueSvc.SetHours(venueID, req.Hours); err != nil {
+		status := http.StatusBadRequest
+		if strings.Contains(err.Error(), "not found") {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	venue, _ := h.venueSvc.GetByID(r.Context(), venueID)
+	writeJSON(w, http.StatusOK, venue)
+}
+
+func (h *OwnerHandler) nearbyComparison(venue model.Venue) model.NearbyComparison {
+	cmp := model.NearbyComparison{RadiusKm: nearbyComparisonRadiusKm, Rank: 1}
+	if venue.Latitude == 0 && venue.Longitude == 0 {
+		return cmp
+	}
+
+	nearby := h.venueSvc.GetNearby(venue.Latitude, venue.Longitude, nearbyComparisonRadiusKm, venue.ID)
+	cmp.VenueCount = len(nearby)
+	if len(nearby) == 0 {
+		return cmp
+	}
+
+	var ratingSum, countSum float64
+	rated := 0
+	for _, v := range nearby {
+		countSum += float64(v.RatingCount)
+		if v.RatingCount > 0 {
+			ratingSum += v.AvgRating
+			rated++
+			if v.AvgRating > venue.AvgRating {
+				cmp.Rank++
+			}
+		}
+	}
+	if rated > 0 {
+		cmp.AvgRating = ratingSum / float64(rated)
+	}
+	cmp.AvgRatingCount = countSum / float64(len(nearby))
+	return cmp
+}
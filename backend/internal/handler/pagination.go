@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// PageLimit is the default and maximum page size for a list endpoint.
+type PageLimit struct {
+	Default int
+	Max     int
+}
+
+var (
+	pageLimitsMu sync.RWMutex
+	pageLimits   = map[string]PageLimit{
+		"schools":        {Default: 50, Max: 200},
+		"venues":         {Default: 20, Max: 100},
+		"school_ratings": {Default: 20, Max: 100},
+		"venue_ratings":  {Default: 50, Max: 100},
+		"lists":          {Default: 10, Max: 50},
+		"crawls":         {Default: 20, Max: 50},
+		"review_search":  {Default: 20, Max: 50},
+		"trending":       {Default: 10, Max: 50},
+		"tonight":        {Default: 20, Max: 50},
+		"admin":          {Default: 50, Max: 200},
+		"fraternities":   {Default: 500, Max: 1000},
+		"follows":        {Default: 50, Max: 200},
+		"owner_venues":   {Default: 50, Max: 200},
+		"nearby_schools": {Default: 50, Max: 50},
+		"sessions":       {Default: 50, Max: 100},
+		"logins":         {Default: 20, Max: 100},
+		"leaderboard":    {Default: 25, Max: 100},
+		"activity":       {Default: 35, Max: 35},
+		"photos":         {Default: 20, Max: 100},
+	}
+)
+
+// LeaderboardSize is how many entries leaderboards rank, across all pages.
+const LeaderboardSize = 100
+
+// venueSearchMaxResults caps how many matches admin venue search collects
+// before paging, so a one-letter query doesn't copy every venue.
+const venueSearchMaxResults = 1000
+
+// ConfigurePagination overrides page sizes from a spec such as
+// "venues=20:100,venue_ratings=50:200" (endpoint=default:max).
+func ConfigurePagination(spec string) {
+	pageLimitsMu.Lock()
+	defer pageLimitsMu.Unlock()
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, sizes, ok1 := strings.Cut(entry, "=")
+		def, max, ok2 := strings.Cut(sizes, ":")
+		d, err1 := strconv.Atoi(def)
+		m, err2 := strconv.Atoi(max)
+		if _, known := pageLimits[name]; !ok1 || !ok2 || !known || err1 != nil || err2 != nil || d <= 0 || m < d {
+			log.Printf("WARNING: Ignoring invalid pagination limit %q", entry)
+			continue
+		}
+		pageLimits[name] = PageLimit{Default: d, Max: m}
+	}
+}
+
+// PaginationLimits returns a copy of the configured page sizes per endpoint.
+func PaginationLimits() map[string]PageLimit {
+	pageLimitsMu.RLock()
+	defer pageLimitsMu.RUnlock()
+
+	out := make(map[string]PageLimit, len(pageLimits))
+	for k, v := range pageLimits {
+		out[k] = v
+	}
+	return out
+}
+
+// pageParams reads ?page= and ?limit= for an endpoint, applying its default and
+// clamping to its maximum. The caps are echoed in X-Page-Limit-Default and
+// X-Page-Limit-Max headers.
+func pageParams(w http.ResponseWriter, r *http.Request, endpoint string) (page, limit int) {
+	pageLimitsMu.RLock()
+	pl := pageLimits[endpoint]
+	pageLimitsMu.RUnlock()
+
+	q := r.URL.Query()
+	page, _ = strconv.Atoi(q.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	limit, _ = strconv.Atoi(q.Get("limit"))
+	if limit <= 0 {
+		limit = pl.Default
+	}
+	if limit > pl.Max {
+		limit = pl.Max
+	}
+
+	w.Header().Set("X-Page-Limit-Default", strconv.Itoa(pl.Default))
+	w.Header().Set("X-Page-Limit-Max", strconv.Itoa(pl.Max))
+	return page, limit
+}
+
+// paginate wraps one page of items in the standard list envelope.
+func paginate[T any](w http.ResponseWriter, r *http.Request, endpoint string, items []T) model.PaginatedResponse {
+	page, limit := pageParams(w, r, endpoint)
+	data := pageSlice(items, page, limit)
+	if data == nil {
+		data = []T{}
+	}
+	return model.PaginatedResponse{
+		Data:       data,
+		Total:      len(items),
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (len(items) + limit - 1) / limit,
+		MaxLimit:   PaginationLimits()[endpoint].Max,
+	}
+}
+
+// WritePage writes one page of items in the standard list envelope, for
+// routes defined outside this package.
+func WritePage[T any](w http.ResponseWriter, r *http.Request, endpoint string, items []T) {
+	writeJSON(w, http.StatusOK, paginate(w, r, endpoint, items))
+}
+
+// pageSlice returns the items on a 1-based page.
+func pageSlice[T any](items []T, page, limit int) []T {
+	start := (page - 1) * limit
+	if start >= len(items) {
+		return items[:0]
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
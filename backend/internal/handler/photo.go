@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// PhotoHandler handles venue photo uploads, public photo serving and the
+// admin moderation queue.
+type PhotoHandler struct {
+	svc       *service.PhotoService
+	venueSvc  *service.VenueService
+	ratingSvc *service.RatingService
+}
+
+func NewPhotoHandler(svc *service.PhotoService, venueSvc *service.VenueService, ratingSvc *service.RatingService) *PhotoHandler {
+	return &PhotoHandler{svc: svc, venueSvc: venueSvc, ratingSvc: ratingSvc}
+}
+
+// Upload handles POST /api/venues/{id}/photos (multipart field "photo",
+// optional "rating_id" to attach it to the uploader's review).
+func (h *PhotoHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	venue, err := h.venueSvc.GetByID(r.Context(), venueID)
+	if err != nil || !venue.Verified {
+		writeError(w, http.StatusNotFound, "venue not found")
+		return
+	}
+
+	maxBytes := h.svc.Config().MaxBytes
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes+1<<20) // headroom for multipart framing
+	if err := r.ParseMultipartForm(maxBytes); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "photo exceeds "+strconv.FormatInt(maxBytes, 10)+" bytes")
+			return
+		}
+		writeError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	ratingID := r.FormValue("rating_id")
+	if ratingID != "" {
+		rating, err := h.ratingSvc.GetByID(ratingID)
+		if err != nil || rating.VenueID != venueID || rating.AuthorID != middleware.GetUserID(r.Context()) {
+			writeError(w, http.StatusBadRequest, "rating_id must be your own rating of this venue")
+			return
+		}
+	}
+
+	file, _, err := r.FormFile("photo")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "photo file is required")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Failed to read photo")
+		return
+	}
+
+	photo, err := h.svc.Upload(r.Context(), venueID, ratingID, data)
+	if err != nil {
+		writeError(w, photoErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, photo)
+}
+
+// ListByVenue handles GET /api/venues/{id}/photos — approved photos only.
+func (h *PhotoHandler) ListByVenue(w http.ResponseWriter, r *http.Request) {
+	photos := h.svc.ListByVenue(chi.URLParam(r, "id"))
+	writeJSON(w, http.StatusOK, paginate(w, r, "photos", photos))
+}
+
+// Image handles GET /api/photos/{id}. Approved photos never change, so they
+// are cached aggressively.
+func (h *PhotoHandler) Image(w http.ResponseWriter, r *http.Request) {
+	data, contentType, err := h.svc.Image(chi.URLParam(r, "id"), false)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	w.Header().Set("Cache-Control", "public, max-age=86400, immutable")
+	writeImage(w, contentType, data)
+}
+
+// ListQuarantined handles GET /api/admin/photos/quarantine (admin only)
+func (h *PhotoHandler) ListQuarantined(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, paginate(w, r, "admin", h.svc.ListQuarantined()))
+}
+
+// AdminImage handles GET /api/admin/photos/{id}/image (admin only) so
+// moderators can see quarantined photos.
+func (h *PhotoHandler) AdminImage(w http.ResponseWriter, r *http.Request) {
+	data, contentType, err := h.svc.Image(chi.URLParam(r, "id"), true)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeImage(w, contentType, data)
+}
+
+// Approve handles POST /api/admin/photos/{id}/approve (admin only)
+func (h *PhotoHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	photo, err := h.svc.Approve(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, photoErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, photo)
+}
+
+// Reject handles DELETE /api/admin/photos/{id} (admin only)
+func (h *PhotoHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	photo, err := h.svc.Reject(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, photoErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, photo)
+}
+
+func writeImage(w http.ResponseWriter, contentType string, data []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func photoErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case msg == "authentication required":
+		return http.StatusUnauthorized
+	case msg == "photo not found":
+		return http.StatusNotFound
+	case msg == "photo is still processing":
+		return http.StatusConflict
+	case strings.HasPrefix(msg, "photo exceeds"):
+		return http.StatusRequestEntityTooLarge
+	case strings.HasPrefix(msg, "unsupported image type"):
+		return http.StatusUnsupportedMediaType
+	case strings.HasPrefix(msg, "photo queue is full"):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusBadRequest
+}
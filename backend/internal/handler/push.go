@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// PushHandler handles Web Push subscription management.
+type PushHandler struct {
+	svc *service.PushService
+}
+
+func NewPushHandler(svc *service.PushService) *PushHandler {
+	return &PushHandler{svc: svc}
+}
+
+// PublicKey handles GET /api/push/vapid-public-key
+func (h *PushHandler) PublicKey(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"public_key": h.svc.PublicKey()})
+}
+
+// Subscribe handles POST /api/push/subscriptions
+func (h *PushHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	var sub model.PushSubscription
+	if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := h.svc.Subscribe(r.Context(), sub); err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "authentication required" {
+			status = http.StatusUnauthorized
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]string{"message": "subscribed"})
+}
+
+// Unsubscribe handles DELETE /api/push/subscriptions
+func (h *PushHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Endpoint string `json:"endpoint"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Endpoint == "" {
+		writeError(w, http.StatusBadRequest, "endpoint is required")
+		return
+	}
+	if err := h.svc.Unsubscribe(r.Context(), req.Endpoint); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "unsubscribed"})
+}
+
+// Status handles GET /api/push/status
+func (h *PushHandler) Status(w http.ResponseWriter, r *http.Request) {
+	enabled, subs := h.svc.Status(middleware.GetUserID(r.Context()))
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"enabled":       enabled,
+		"subscriptions": subs,
+	})
+}
+
+// SetOptIn handles PUT /api/push/opt-in
+func (h *PushHandler) SetOptIn(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := h.svc.SetOptIn(r.Context(), req.Enabled); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"enabled": req.Enabled})
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// QueryStatsHandler exposes database latency to operators.
+type QueryStatsHandler struct {
+	pool *pgxpool.Pool // nil in in-memory mode
+}
+
+func NewQueryStatsHandler(pool *pgxpool.Pool) *QueryStatsHandler {
+	return &QueryStatsHandler{pool: pool}
+}
+
+// Get handles GET /api/admin/db/queries — per-query call counts and latency
+// for the prepared hot-path queries, plus connection pool usage.
+func (h *QueryStatsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	stats := service.QueryStats()
+	if h.pool != nil {
+		st := h.pool.Stat()
+		stats.Pool = &model.DBPoolStat{
+			TotalConns:        st.TotalConns(),
+			IdleConns:         st.IdleConns(),
+			AcquiredConns:     st.AcquiredConns(),
+			MaxConns:          st.MaxConns(),
+			AcquireCount:      st.AcquireCount(),
+			EmptyAcquireCount: st.EmptyAcquireCount(),
+		}
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/ratemybars/backend/internal/model"
@@ -14,10 +16,18 @@ type RatingHandler struct {
 	svc       *service.RatingService
 	venueSvc  *service.VenueService
 	schoolSvc *service.SchoolService
+	draftSvc  *service.DraftService
+	sentiment *service.SentimentService
 }
 
-func NewRatingHandler(svc *service.RatingService, venueSvc *service.VenueService, schoolSvc *service.SchoolService) *RatingHandler {
-	return &RatingHandler{svc: svc, venueSvc: venueSvc, schoolSvc: schoolSvc}
+func NewRatingHandler(svc *service.RatingService, venueSvc *service.VenueService, schoolSvc *service.SchoolService, draftSvc *service.DraftService) *RatingHandler {
+	return &RatingHandler{svc: svc, venueSvc: venueSvc, schoolSvc: schoolSvc, draftSvc: draftSvc}
+}
+
+// SetSentiment adds each review's sentiment score to venue and school
+// review lists.
+func (h *RatingHandler) SetSentiment(sentiment *service.SentimentService) {
+	h.sentiment = sentiment
 }
 
 // Create handles POST /api/ratings
@@ -30,26 +40,39 @@ func (h *RatingHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	rating, err := h.svc.Create(r.Context(), req)
 	if err != nil {
-		status := http.StatusBadRequest
-		if err.Error() == "authentication required" {
-			status = http.StatusUnauthorized
-		}
-		writeError(w, status, err.Error())
+		writeError(w, ratingErrorStatus(err), err.Error())
 		return
 	}
+	h.draftSvc.Delete(rating.AuthorID, rating.VenueID)
 
-	avg, count := h.svc.GetVenueStats(req.VenueID)
-	up, down := h.svc.GetVenueThumbs(req.VenueID)
-	schoolID := h.venueSvc.UpdateSingleVenueStats(req.VenueID, avg, count, up, down)
-
-	if schoolID != "" {
-		schoolAvg := h.venueSvc.GetSchoolAvgRating(schoolID)
-		h.schoolSvc.UpdateSingleSchoolRating(schoolID, schoolAvg)
+	// Reviews held by the PII policy don't count until a moderator approves them.
+	if rating.PendingReview {
+		writeJSON(w, http.StatusAccepted, rating)
+		return
 	}
 
+	refreshVenueStats(h.svc, h.venueSvc, h.schoolSvc, req.VenueID)
+	if rating.EditedAt != nil {
+		writeJSON(w, http.StatusOK, rating)
+		return
+	}
 	writeJSON(w, http.StatusCreated, rating)
 }
 
+// refreshVenueStats recomputes a venue's rating stats and its school's average.
+func refreshVenueStats(ratingSvc *service.RatingService, venueSvc *service.VenueService, schoolSvc *service.SchoolService, venueID string) {
+	avg, count := ratingSvc.GetVenueStats(venueID)
+	up, down := ratingSvc.GetVenueThumbs(venueID)
+	schoolID := venueSvc.UpdateSingleVenueStats(venueID, avg, count, up, down)
+
+	if schoolID != "" {
+		schoolAvg := venueSvc.GetSchoolAvgRating(schoolID)
+		schoolSvc.UpdateSingleSchoolRating(schoolID, schoolAvg)
+		recommend := ratingSvc.RecommendCountFor(venueSvc.GetVenueIDsBySchool(schoolID))
+		schoolSvc.UpdateSingleSchoolRecommend(schoolID, recommend.Pct())
+	}
+}
+
 // ListByVenue handles GET /api/venues/{id}/ratings
 func (h *RatingHandler) ListByVenue(w http.ResponseWriter, r *http.Request) {
 	venueID := chi.URLParam(r, "id")
@@ -60,7 +83,29 @@ func (h *RatingHandler) ListByVenue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, ratings)
+	if r.URL.Query().Get("verified_student") == "true" {
+		ratings = studentRatings(ratings)
+	}
+	if sortMode := r.URL.Query().Get("sort"); sortMode != "" {
+		h.svc.Sort(ratings, sortMode)
+	}
+
+	if h.sentiment != nil {
+		h.sentiment.Annotate(ratings)
+	}
+
+	writeJSON(w, http.StatusOK, paginate(w, r, "venue_ratings", ratings))
+}
+
+// studentRatings keeps ratings written by verified students.
+func studentRatings(ratings []model.Rating) []model.Rating {
+	out := ratings[:0]
+	for _, rt := range ratings {
+		if rt.VerifiedStudent {
+			out = append(out, rt)
+		}
+	}
+	return out
 }
 
 // VoteOnRating handles POST /api/ratings/{id}/vote
@@ -88,6 +133,34 @@ func (h *RatingHandler) VoteOnRating(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]int{"upvotes": upvotes, "downvotes": downvotes})
 }
 
+// ReactToRating handles POST /api/ratings/{id}/react
+func (h *RatingHandler) ReactToRating(w http.ResponseWriter, r *http.Request) {
+	ratingID := chi.URLParam(r, "id")
+
+	var req struct {
+		Reaction string `json:"reaction"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	reactions, err := h.svc.React(r.Context(), ratingID, req.Reaction)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch err.Error() {
+		case "authentication required":
+			status = http.StatusUnauthorized
+		case "rating not found":
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"reactions": reactions})
+}
+
 // ListBySchool handles GET /api/schools/{id}/ratings — returns recent reviews across all venues at a school.
 func (h *RatingHandler) ListBySchool(w http.ResponseWriter, r *http.Request) {
 	schoolID := chi.URLParam(r, "id")
@@ -95,23 +168,71 @@ func (h *RatingHandler) ListBySchool(w http.ResponseWriter, r *http.Request) {
 	venueIDs := h.venueSvc.GetVenueIDsBySchool(schoolID)
 	ratings := h.svc.ListByVenues(venueIDs)
 
+	if r.URL.Query().Get("verified_student") == "true" {
+		ratings = studentRatings(ratings)
+	}
 	if ratings == nil {
 		ratings = []model.Rating{}
 	}
 
-	// Sort by most recent first (in-place, ratings is a copy)
-	for i := 0; i < len(ratings); i++ {
-		for j := i + 1; j < len(ratings); j++ {
-			if ratings[j].CreatedAt.After(ratings[i].CreatedAt) {
-				ratings[i], ratings[j] = ratings[j], ratings[i]
-			}
+	// Sort by most recent first unless another order is requested (in-place, ratings is a copy)
+	h.svc.Sort(ratings, r.URL.Query().Get("sort"))
+
+	if h.sentiment != nil {
+		h.sentiment.Annotate(ratings)
+	}
+
+	writeJSON(w, http.StatusOK, paginate(w, r, "school_ratings", ratings))
+}
+
+// SearchReviews handles GET /api/reviews/search?q=no+cover&school_id=...|venue_id=...&limit=20
+func (h *RatingHandler) SearchReviews(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var venueIDs []string
+	switch {
+	case q.Get("venue_id") != "":
+		venue, err := h.venueSvc.GetByID(r.Context(), q.Get("venue_id"))
+		if err != nil {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
 		}
+		venueIDs = []string{venue.ID}
+	case q.Get("school_id") != "":
+		venueIDs = h.venueSvc.GetVenueIDsBySchool(q.Get("school_id"))
+	default:
+		writeError(w, http.StatusBadRequest, "school_id or venue_id is required")
+		return
 	}
 
-	// Limit to 20 most recent
-	if len(ratings) > 20 {
-		ratings = ratings[:20]
+	page, limit := pageParams(w, r, "review_search")
+
+	results, err := h.svc.SearchReviews(q.Get("q"), venueIDs, page*limit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
 	}
+	results = pageSlice(results, page, limit)
+	for i := range results {
+		if v, err := h.venueSvc.GetByID(r.Context(), results[i].Rating.VenueID); err == nil {
+			results[i].VenueName = v.Name
+		}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
 
-	writeJSON(w, http.StatusOK, ratings)
+// ratingErrorStatus maps rating submission errors shared by venue and frat
+// ratings to HTTP status codes.
+func ratingErrorStatus(err error) int {
+	switch {
+	case err.Error() == "authentication required":
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrEditWindowClosed):
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "ratings are temporarily frozen"),
+		strings.HasPrefix(err.Error(), "ratings on this venue are locked"),
+		strings.HasPrefix(err.Error(), "ratings on this frat are locked"):
+		return http.StatusLocked
+	}
+	return http.StatusBadRequest
 }
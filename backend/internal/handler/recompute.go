@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// RecomputeHandler lets admins rebuild derived aggregates after imports,
+// merges or bulk deletions.
+type RecomputeHandler struct {
+	svc      *service.RecomputeService
+	auditSvc *service.AuditService
+}
+
+func NewRecomputeHandler(svc *service.RecomputeService, auditSvc *service.AuditService) *RecomputeHandler {
+	return &RecomputeHandler{svc: svc, auditSvc: auditSvc}
+}
+
+// Start handles POST /api/admin/recompute. The rebuild runs in the
+// background; poll GET /api/admin/recompute for progress.
+func (h *RecomputeHandler) Start(w http.ResponseWriter, r *http.Request) {
+	status, err := h.svc.Start()
+	if err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "aggregates.recompute", "system", "aggregates", map[string]interface{}{
+		"steps": status.Total,
+	})
+	writeJSON(w, http.StatusAccepted, status)
+}
+
+// Status handles GET /api/admin/recompute
+func (h *RecomputeHandler) Status(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.svc.Status())
+}
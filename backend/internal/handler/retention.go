@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// RetentionHandler exposes data retention jobs to admins.
+type RetentionHandler struct {
+	svc *service.RetentionService
+}
+
+func NewRetentionHandler(svc *service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{svc: svc}
+}
+
+// Get handles GET /api/admin/retention — the retention periods, the jobs
+// registered under each policy and the last scheduled run.
+func (h *RetentionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"config":      h.svc.Config(),
+		"jobs":        h.svc.Jobs(),
+		"last_report": h.svc.LastReport(),
+	})
+}
+
+// Run handles POST /api/admin/retention/run. It's a dry run unless the body
+// says {"dry_run": false} or the query has ?dry_run=false.
+func (h *RetentionHandler) Run(w http.ResponseWriter, r *http.Request) {
+	var req model.RetentionRunRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+	if r.URL.Query().Has("dry_run") {
+		dryRun, err := parseDryRun(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		req.DryRun = &dryRun
+	}
+	dryRun := req.DryRun == nil || *req.DryRun
+	writeJSON(w, http.StatusOK, h.svc.Run(r.Context(), dryRun))
+}
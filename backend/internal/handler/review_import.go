@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// maxReviewImportBytes caps the size of an uploaded takeout file.
+const maxReviewImportBytes = 5 << 20
+
+// ReviewImportHandler imports a user's own reviews from other sites.
+type ReviewImportHandler struct {
+	svc       *service.ReviewImportService
+	ratingSvc *service.RatingService
+	venueSvc  *service.VenueService
+	schoolSvc *service.SchoolService
+}
+
+func NewReviewImportHandler(svc *service.ReviewImportService, ratingSvc *service.RatingService, venueSvc *service.VenueService, schoolSvc *service.SchoolService) *ReviewImportHandler {
+	return &ReviewImportHandler{svc: svc, ratingSvc: ratingSvc, venueSvc: venueSvc, schoolSvc: schoolSvc}
+}
+
+// GoogleTakeout handles POST /api/me/import/google-maps[?dry_run=true]. The
+// body is the "Reviews.json" file from a Google Maps takeout.
+func (h *ReviewImportHandler) GoogleTakeout(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxReviewImportBytes))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "takeout file is too large")
+		return
+	}
+
+	result, err := h.svc.ImportGoogleTakeout(r.Context(), data, dryRun)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	refreshed := map[string]bool{}
+	for _, item := range result.Imported {
+		if item.RatingID == "" || item.Pending || refreshed[item.VenueID] {
+			continue
+		}
+		refreshVenueStats(h.ratingSvc, h.venueSvc, h.schoolSvc, item.VenueID)
+		refreshed[item.VenueID] = true
+	}
+	writeJSON(w, http.StatusOK, result)
+}
@@ -2,8 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/ratemybars/backend/internal/model"
@@ -12,19 +14,25 @@ import (
 
 // SchoolHandler handles school-related HTTP requests.
 type SchoolHandler struct {
-	svc *service.SchoolService
+	svc      *service.SchoolService
+	expander *Expander
+	gameDays *service.GameDayService
 }
 
-func NewSchoolHandler(svc *service.SchoolService) *SchoolHandler {
-	return &SchoolHandler{svc: svc}
+func NewSchoolHandler(svc *service.SchoolService, expander *Expander) *SchoolHandler {
+	return &SchoolHandler{svc: svc, expander: expander}
+}
+
+// SetGameDays adds the game-day flag to school details.
+func (h *SchoolHandler) SetGameDays(gameDays *service.GameDayService) {
+	h.gameDays = gameDays
 }
 
 // Search handles GET /api/schools
 func (h *SchoolHandler) Search(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 
-	page, _ := strconv.Atoi(q.Get("page"))
-	limit, _ := strconv.Atoi(q.Get("limit"))
+	page, limit := pageParams(w, r, "schools")
 	iclevel, _ := strconv.Atoi(q.Get("iclevel"))
 	minLat, _ := strconv.ParseFloat(q.Get("min_lat"), 64)
 	maxLat, _ := strconv.ParseFloat(q.Get("max_lat"), 64)
@@ -34,6 +42,7 @@ func (h *SchoolHandler) Search(w http.ResponseWriter, r *http.Request) {
 	params := model.SchoolSearchParams{
 		Query:   q.Get("q"),
 		State:   q.Get("state"),
+		Country: q.Get("country"),
 		Control: q.Get("control"),
 		ICLevel: iclevel,
 		Sort:    q.Get("sort"),
@@ -50,21 +59,61 @@ func (h *SchoolHandler) Search(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	result.MaxLimit = PaginationLimits()["schools"].Max
 
 	writeJSON(w, http.StatusOK, result)
 }
 
-// GetByID handles GET /api/schools/{id}
+// GetByID handles GET /api/schools/{id}?include=venues,venues.ratings,ratings,fraternities
 func (h *SchoolHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
+	include, err := parseInclude(r, schoolIncludes)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	school, err := h.svc.GetByID(r.Context(), id)
 	if err != nil {
 		writeError(w, http.StatusNotFound, err.Error())
 		return
 	}
 
-	writeJSON(w, http.StatusOK, school)
+	detail := h.expander.School(r.Context(), school, include, "")
+	if game := h.gameDays.HomeGameAt(school.ID, time.Now()); game != nil {
+		detail.GameDay = true
+		detail.Game = game
+	}
+	writeJSON(w, http.StatusOK, detail)
+}
+
+// Nearby schools: default and maximum search radius, and result cap.
+const (
+	nearbySchoolsRadiusKm    = 25.0
+	nearbySchoolsMaxRadiusKm = 200.0
+	nearbySchoolsLimit       = 50
+)
+
+// Nearby handles GET /api/schools/{id}/nearby?radius_km=25 — other schools
+// within the radius, closest first, with their party scores.
+func (h *SchoolHandler) Nearby(w http.ResponseWriter, r *http.Request) {
+	radius := nearbySchoolsRadiusKm
+	if v := r.URL.Query().Get("radius_km"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed <= 0 || parsed > nearbySchoolsMaxRadiusKm {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("radius_km must be between 0 and %g", nearbySchoolsMaxRadiusKm))
+			return
+		}
+		radius = parsed
+	}
+
+	schools, err := h.svc.NearbySchool(chi.URLParam(r, "id"), radius, nearbySchoolsLimit)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, "nearby_schools", schools))
 }
 
 // GetGeo handles GET /api/schools/geo
@@ -75,32 +124,42 @@ func (h *SchoolHandler) GetGeo(w http.ResponseWriter, r *http.Request) {
 	minLng, _ := strconv.ParseFloat(q.Get("min_lng"), 64)
 	maxLng, _ := strconv.ParseFloat(q.Get("max_lng"), 64)
 
-	schools, err := h.svc.GetGeo(r.Context(), minLat, maxLat, minLng, maxLng)
+	schools, err := h.svc.GetGeo(r.Context(), q.Get("country"), minLat, maxLat, minLng, maxLng)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	writeJSON(w, http.StatusOK, schools)
+	writeJSONPooled(w, http.StatusOK, schools)
 }
 
 // GetMapData handles GET /api/schools/map - returns minimal data for all schools
 func (h *SchoolHandler) GetMapData(w http.ResponseWriter, r *http.Request) {
-	data, err := h.svc.GetAllForMap(r.Context())
+	data, err := h.svc.GetAllForMap(r.Context(), r.URL.Query().Get("country"))
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	writeJSON(w, http.StatusOK, data)
+	writeJSONPooled(w, http.StatusOK, data)
 }
 
 // GetStates handles GET /api/schools/states
 func (h *SchoolHandler) GetStates(w http.ResponseWriter, r *http.Request) {
-	states := h.svc.GetStates(r.Context())
+	states := h.svc.GetStates(r.Context(), r.URL.Query().Get("country"))
 	writeJSON(w, http.StatusOK, states)
 }
 
+// GetRegions handles GET /api/regions
+func (h *SchoolHandler) GetRegions(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.svc.Regions())
+}
+
+// GetConferences handles GET /api/conferences
+func (h *SchoolHandler) GetConferences(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.svc.Conferences())
+}
+
 // --- Helpers ---
 
 func writeJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// SchoolLinkHandler lets moderators manage a school's nightlife links.
+type SchoolLinkHandler struct {
+	svc      *service.SchoolLinkService
+	auditSvc *service.AuditService
+}
+
+func NewSchoolLinkHandler(svc *service.SchoolLinkService, auditSvc *service.AuditService) *SchoolLinkHandler {
+	return &SchoolLinkHandler{svc: svc, auditSvc: auditSvc}
+}
+
+// Add handles POST /api/admin/schools/{id}/links
+func (h *SchoolLinkHandler) Add(w http.ResponseWriter, r *http.Request) {
+	schoolID := chi.URLParam(r, "id")
+	var req model.CreateSchoolLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	link, err := h.svc.Add(r.Context(), schoolID, req)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "school not found":
+			status = http.StatusNotFound
+		case err.Error() == "this link is already listed":
+			status = http.StatusConflict
+		case strings.HasPrefix(err.Error(), "failed to"):
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "school.link_add", "school", schoolID, map[string]interface{}{
+		"link_id": link.ID,
+		"kind":    link.Kind,
+		"url":     link.URL,
+	})
+	writeJSON(w, http.StatusCreated, link)
+}
+
+// Remove handles DELETE /api/admin/schools/{id}/links/{linkID}
+func (h *SchoolLinkHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	schoolID := chi.URLParam(r, "id")
+	link, err := h.svc.Remove(schoolID, chi.URLParam(r, "linkID"))
+	if err != nil {
+		status := http.StatusNotFound
+		if strings.HasPrefix(err.Error(), "failed to") {
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "school.link_remove", "school", schoolID, map[string]interface{}{
+		"link_id": link.ID,
+		"url":     link.URL,
+	})
+	writeJSON(w, http.StatusOK, map[string]string{"message": "link removed"})
+}
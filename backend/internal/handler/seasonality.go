@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// SeasonalityHandler serves per-venue month-by-month activity.
+type SeasonalityHandler struct {
+	svc *service.SeasonalityService
+}
+
+func NewSeasonalityHandler(svc *service.SeasonalityService) *SeasonalityHandler {
+	return &SeasonalityHandler{svc: svc}
+}
+
+// ByVenue handles GET /api/venues/{id}/seasonality
+func (h *SeasonalityHandler) ByVenue(w http.ResponseWriter, r *http.Request) {
+	result, err := h.svc.ForVenue(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
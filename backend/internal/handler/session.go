@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// SessionHandler lets users manage their signed-in devices and admins revoke
+// any session. svc is nil unless AUTH_SESSIONS=server.
+type SessionHandler struct {
+	svc      *service.SessionService
+	auditSvc *service.AuditService
+}
+
+func NewSessionHandler(svc *service.SessionService, auditSvc *service.AuditService) *SessionHandler {
+	return &SessionHandler{svc: svc, auditSvc: auditSvc}
+}
+
+func (h *SessionHandler) enabled(w http.ResponseWriter) bool {
+	if h.svc == nil {
+		writeError(w, http.StatusNotFound, "server-side sessions are not enabled")
+		return false
+	}
+	return true
+}
+
+// List handles GET /api/auth/sessions
+func (h *SessionHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled(w) {
+		return
+	}
+	current := middleware.GetSessionID(r.Context())
+	sessions := h.svc.List(middleware.GetUserID(r.Context()))
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == current
+		sessions[i].UserID, sessions[i].Username = "", ""
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, "sessions", sessions))
+}
+
+// Revoke handles DELETE /api/auth/sessions/{id}
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled(w) {
+		return
+	}
+	if err := h.svc.Revoke(chi.URLParam(r, "id"), middleware.GetUserID(r.Context())); err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "session not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "session revoked"})
+}
+
+// RevokeOthers handles DELETE /api/auth/sessions, signing out every device
+// except the one making the request.
+func (h *SessionHandler) RevokeOthers(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled(w) {
+		return
+	}
+	n, err := h.svc.RevokeAll(middleware.GetUserID(r.Context()), middleware.GetSessionID(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"revoked": n})
+}
+
+// AdminList handles GET /api/admin/sessions?user_id=
+func (h *SessionHandler) AdminList(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled(w) {
+		return
+	}
+	writeJSON(w, http.StatusOK, h.svc.List(r.URL.Query().Get("user_id")))
+}
+
+// AdminRevoke handles DELETE /api/admin/sessions/{id}
+func (h *SessionHandler) AdminRevoke(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled(w) {
+		return
+	}
+	id := chi.URLParam(r, "id")
+	if err := h.svc.Revoke(id, ""); err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "session not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	h.auditSvc.Record(r.Context(), "session.revoke", "session", id, nil)
+	writeJSON(w, http.StatusOK, map[string]string{"message": "session revoked"})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// ShareHandler handles short link creation and redirects.
+type ShareHandler struct {
+	svc       *service.ShareService
+	venueSvc  *service.VenueService
+	schoolSvc *service.SchoolService
+}
+
+func NewShareHandler(svc *service.ShareService, venueSvc *service.VenueService, schoolSvc *service.SchoolService) *ShareHandler {
+	return &ShareHandler{svc: svc, venueSvc: venueSvc, schoolSvc: schoolSvc}
+}
+
+// Create handles POST /api/share
+func (h *ShareHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateShareLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	switch req.TargetType {
+	case "venue":
+		if _, err := h.venueSvc.GetByID(r.Context(), req.TargetID); err != nil {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+	case "school":
+		if _, err := h.schoolSvc.GetByID(r.Context(), req.TargetID); err != nil {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+	}
+
+	link, err := h.svc.Create(r.Context(), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "authentication required" {
+			status = http.StatusUnauthorized
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"code":      link.Code,
+		"short_url": shortLinkBase(r) + "/s/" + link.Code,
+		"link":      link,
+	})
+}
+
+// Redirect handles GET /s/{code}
+func (h *ShareHandler) Redirect(w http.ResponseWriter, r *http.Request) {
+	target, err := h.svc.Resolve(chi.URLParam(r, "code"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// List handles GET /api/admin/share-links (admin only)
+func (h *ShareHandler) List(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.svc.List())
+}
+
+// shortLinkBase returns SHORT_LINK_BASE_URL, or the API's own origin.
+func shortLinkBase(r *http.Request) string {
+	if base := os.Getenv("SHORT_LINK_BASE_URL"); base != "" {
+		return strings.TrimRight(base, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// eventStreamMaxDuration ends streams before the global request timeout
+	// does; EventSource clients reconnect on their own.
+	eventStreamMaxDuration = 25 * time.Second
+	eventStreamHeartbeat   = 10 * time.Second
+	eventStreamRetryMillis = 1000
+)
+
+// eventStream writes Server-Sent Events to a client.
+type eventStream struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// newEventStream starts an event-stream response.
+func newEventStream(w http.ResponseWriter) (*eventStream, error) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Accel-Buffering", "no") // don't let nginx buffer events
+	w.WriteHeader(http.StatusOK)
+
+	s := &eventStream{w: w, rc: http.NewResponseController(w)}
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", eventStreamRetryMillis); err != nil {
+		return nil, err
+	}
+	return s, s.rc.Flush()
+}
+
+// Send writes one event with a JSON payload.
+func (s *eventStream) Send(event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// Ping writes a comment line so proxies keep an idle stream open.
+func (s *eventStream) Ping() error {
+	if _, err := fmt.Fprint(s.w, ": ping\n\n"); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
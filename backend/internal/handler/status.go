@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// maxChangelogList caps GET /api/changelog.
+const maxChangelogList = 100
+
+// StatusHandler serves the deploy status and the "what's new" changelog.
+type StatusHandler struct {
+	svc       *service.StatusService
+	changelog *service.ChangelogService
+	auditSvc  *service.AuditService
+}
+
+func NewStatusHandler(svc *service.StatusService, changelog *service.ChangelogService, auditSvc *service.AuditService) *StatusHandler {
+	return &StatusHandler{svc: svc, changelog: changelog, auditSvc: auditSvc}
+}
+
+// Get handles GET /api/status
+func (h *StatusHandler) Get(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.svc.Status(time.Now()))
+}
+
+// Changelog handles GET /api/changelog?limit=20
+func (h *StatusHandler) Changelog(w http.ResponseWriter, r *http.Request) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 || limit > maxChangelogList {
+		limit = 20
+	}
+	writeJSON(w, http.StatusOK, h.changelog.List(limit, false, time.Now()))
+}
+
+// AdminChangelog handles GET /api/admin/changelog, including entries
+// scheduled for later.
+func (h *StatusHandler) AdminChangelog(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.changelog.List(0, true, time.Now()))
+}
+
+// CreateChangelog handles POST /api/admin/changelog
+func (h *StatusHandler) CreateChangelog(w http.ResponseWriter, r *http.Request) {
+	var req model.ChangelogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	entry, err := h.changelog.Create(r.Context(), req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.auditSvc.Record(r.Context(), "changelog.create", "changelog", entry.ID, map[string]interface{}{
+		"title":   entry.Title,
+		"version": entry.Version,
+	})
+	writeJSON(w, http.StatusCreated, entry)
+}
+
+// UpdateChangelog handles PUT /api/admin/changelog/{id}
+func (h *StatusHandler) UpdateChangelog(w http.ResponseWriter, r *http.Request) {
+	var req model.ChangelogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	entry, err := h.changelog.Update(chi.URLParam(r, "id"), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "changelog entry not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	h.auditSvc.Record(r.Context(), "changelog.update", "changelog", entry.ID, map[string]interface{}{
+		"title":   entry.Title,
+		"version": entry.Version,
+	})
+	writeJSON(w, http.StatusOK, entry)
+}
+
+// DeleteChangelog handles DELETE /api/admin/changelog/{id}
+func (h *StatusHandler) DeleteChangelog(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if err := h.changelog.Delete(id); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	h.auditSvc.Record(r.Context(), "changelog.delete", "changelog", id, nil)
+	writeJSON(w, http.StatusOK, map[string]string{"message": "changelog entry deleted"})
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// TaxonomyHandler serves venue categories and review tags, and lets admins edit them.
+type TaxonomyHandler struct {
+	svc       *service.TaxonomyService
+	venueSvc  *service.VenueService
+	ratingSvc *service.RatingService
+}
+
+func NewTaxonomyHandler(svc *service.TaxonomyService, venueSvc *service.VenueService, ratingSvc *service.RatingService) *TaxonomyHandler {
+	return &TaxonomyHandler{svc: svc, venueSvc: venueSvc, ratingSvc: ratingSvc}
+}
+
+// taxonomyKind maps the {kind} URL segment ("categories" or "tags") to a taxonomy kind.
+func taxonomyKind(r *http.Request) string {
+	switch chi.URLParam(r, "kind") {
+	case "categories":
+		return service.TaxonomyCategory
+	case "tags":
+		return service.TaxonomyTag
+	}
+	return ""
+}
+
+// List handles GET /api/taxonomies/{kind}
+func (h *TaxonomyHandler) List(w http.ResponseWriter, r *http.Request) {
+	terms, err := h.svc.List(taxonomyKind(r))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, terms)
+}
+
+// Create handles POST /api/admin/taxonomies/{kind} (admin only)
+func (h *TaxonomyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.TaxonomyTermRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	term, err := h.svc.Create(taxonomyKind(r), req)
+	if err != nil {
+		writeError(w, taxonomyErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, term)
+}
+
+// Update handles PUT /api/admin/taxonomies/{kind}/{slug} (admin only)
+func (h *TaxonomyHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req model.TaxonomyTermRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	term, err := h.svc.Update(taxonomyKind(r), chi.URLParam(r, "slug"), req)
+	if err != nil {
+		writeError(w, taxonomyErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, term)
+}
+
+// Delete handles DELETE /api/admin/taxonomies/{kind}/{slug} (admin only)
+func (h *TaxonomyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	kind, slug := taxonomyKind(r), chi.URLParam(r, "slug")
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if dryRun {
+		if _, err := h.svc.List(kind); err != nil {
+			writeError(w, taxonomyErrorStatus(err), err.Error())
+			return
+		}
+		if !h.svc.Valid(kind, slug) {
+			writeError(w, http.StatusNotFound, "term not found")
+			return
+		}
+		// Venues and reviews keep a deleted term but it can't be chosen again.
+		preview := newDryRun("taxonomy.delete")
+		addDryRun(preview, "terms", []string{slug})
+		if kind == service.TaxonomyCategory {
+			addDryRun(preview, "venues", h.venueSvc.IDsByCategory(slug))
+		} else {
+			addDryRun(preview, "ratings", h.ratingSvc.IDsByTag(slug))
+		}
+		writeJSON(w, http.StatusOK, preview)
+		return
+	}
+
+	if err := h.svc.Delete(kind, slug); err != nil {
+		writeError(w, taxonomyErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "term deleted"})
+}
+
+func taxonomyErrorStatus(err error) int {
+	switch {
+	case err.Error() == "term not found", strings.HasPrefix(err.Error(), "unknown taxonomy"):
+		return http.StatusNotFound
+	case err.Error() == "term already exists":
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+const (
+	defaultTonightRadiusKm = 5.0
+	maxTonightRadiusKm     = 25.0
+)
+
+// TonightHandler serves the "where to go tonight" ranking and venue check-ins.
+type TonightHandler struct {
+	svc        *service.TonightService
+	checkInSvc *service.CheckInService
+	venueSvc   *service.VenueService
+}
+
+func NewTonightHandler(svc *service.TonightService, checkInSvc *service.CheckInService, venueSvc *service.VenueService) *TonightHandler {
+	return &TonightHandler{svc: svc, checkInSvc: checkInSvc, venueSvc: venueSvc}
+}
+
+// Get handles GET /api/tonight?lat=&lng=&radius=&limit=&skip_closing_soon=
+// — skip_closing_soon=true leaves out venues closing within 30 minutes.
+func (h *TonightHandler) Get(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	lat, err1 := strconv.ParseFloat(q.Get("lat"), 64)
+	lng, err2 := strconv.ParseFloat(q.Get("lng"), 64)
+	if err1 != nil || err2 != nil || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		writeError(w, http.StatusBadRequest, "lat and lng are required")
+		return
+	}
+
+	radius, _ := strconv.ParseFloat(q.Get("radius"), 64)
+	if radius <= 0 {
+		radius = defaultTonightRadiusKm
+	}
+	if radius > maxTonightRadiusKm {
+		radius = maxTonightRadiusKm
+	}
+	page, limit := pageParams(w, r, "tonight")
+	skipClosingSoon := q.Get("skip_closing_soon") == "true"
+	writeJSON(w, http.StatusOK, pageSlice(h.svc.Rank(lat, lng, radius, time.Now(), skipClosingSoon), page, limit))
+}
+
+// CheckIn handles POST /api/venues/{id}/checkin. Sending latitude/longitude
+// inside the venue's geofence makes it a verified check-in.
+func (h *TonightHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	venue, err := h.venueSvc.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil || !venue.Verified {
+		writeError(w, http.StatusNotFound, "venue not found")
+		return
+	}
+
+	// The body is optional; without a location the check-in still counts for
+	// buzz but can't verify a visit.
+	var req model.CheckInRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+	verified := false
+	if req.Latitude != nil && req.Longitude != nil {
+		if !h.checkInSvc.InGeofence(venue, *req.Latitude, *req.Longitude) {
+			writeError(w, http.StatusForbidden, "you are not at this venue")
+			return
+		}
+		verified = true
+	}
+
+	checkIn, err := h.checkInSvc.CheckIn(r.Context(), venue.ID, verified)
+	if err != nil {
+		status := http.StatusTooManyRequests
+		if err.Error() == "authentication required" {
+			status = http.StatusUnauthorized
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, checkIn)
+}
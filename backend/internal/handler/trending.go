@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// TrendingHandler serves per-school trending venues.
+type TrendingHandler struct {
+	svc       *service.TrendingService
+	schoolSvc *service.SchoolService
+}
+
+func NewTrendingHandler(svc *service.TrendingService, schoolSvc *service.SchoolService) *TrendingHandler {
+	return &TrendingHandler{svc: svc, schoolSvc: schoolSvc}
+}
+
+// BySchool handles GET /api/schools/{id}/trending?limit=10
+func (h *TrendingHandler) BySchool(w http.ResponseWriter, r *http.Request) {
+	schoolID := chi.URLParam(r, "id")
+	if _, err := h.schoolSvc.GetByID(r.Context(), schoolID); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	page, limit := pageParams(w, r, "trending")
+	writeJSON(w, http.StatusOK, pageSlice(h.svc.BySchool(schoolID, time.Now(), page*limit), page, limit))
+}
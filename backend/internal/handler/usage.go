@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// usageWindows are the time windows the usage report supports.
+var usageWindows = map[string]time.Duration{
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+}
+
+// UsageHandler exposes API traffic statistics to operators.
+type UsageHandler struct {
+	svc *service.UsageService
+}
+
+func NewUsageHandler(svc *service.UsageService) *UsageHandler {
+	return &UsageHandler{svc: svc}
+}
+
+// Get handles GET /api/admin/usage?window=24h&ips=20 — requests and 429s per
+// route and API key, plus the top client IPs (window: 1h, 24h or 7d).
+func (h *UsageHandler) Get(w http.ResponseWriter, r *http.Request) {
+	label := r.URL.Query().Get("window")
+	if label == "" {
+		label = "24h"
+	}
+	window, ok := usageWindows[label]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "window must be 1h, 24h or 7d")
+		return
+	}
+	limit := 20
+	if v, err := strconv.Atoi(r.URL.Query().Get("ips")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	writeJSON(w, http.StatusOK, h.svc.Summary(window, label, limit))
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// UserOverviewHandler gives support a read-only view of an account.
+type UserOverviewHandler struct {
+	svc *service.UserOverviewService
+}
+
+func NewUserOverviewHandler(svc *service.UserOverviewService) *UserOverviewHandler {
+	return &UserOverviewHandler{svc: svc}
+}
+
+// Get handles GET /api/admin/users/{id}/overview (admin only) — the user's
+// profile and email, recent venue and chapter ratings, votes cast and
+// received, admin actions against them, sessions and login lockouts. There
+// are no reports filed or received: the site has no way to report a user or
+// review yet, so there is nothing to show.
+func (h *UserOverviewHandler) Get(w http.ResponseWriter, r *http.Request) {
+	overview, err := h.svc.Overview(chi.URLParam(r, "id"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "user not found" {
+			status = http.StatusNotFound
+		} else if !strings.HasPrefix(err.Error(), "failed to") {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, overview)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// profileRecentReviews is how many recent reviews a profile shows.
+const profileRecentReviews = 10
+
+// UserProfileHandler serves public user profiles.
+type UserProfileHandler struct {
+	authSvc       *service.AuthService
+	ratingSvc     *service.RatingService
+	fratRatingSvc *service.FratRatingService
+	venueSvc      *service.VenueService
+}
+
+func NewUserProfileHandler(authSvc *service.AuthService, ratingSvc *service.RatingService, fratRatingSvc *service.FratRatingService, venueSvc *service.VenueService) *UserProfileHandler {
+	return &UserProfileHandler{authSvc: authSvc, ratingSvc: ratingSvc, fratRatingSvc: fratRatingSvc, venueSvc: venueSvc}
+}
+
+// Get handles GET /api/users/{id} — username, member-since, rating counts and
+// the user's most recent venue and chapter reviews.
+func (h *UserProfileHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+	profile, err := h.authSvc.PublicProfile(userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "user not found" {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	ratings, ratingCount := h.ratingSvc.ListByAuthor(userID, profileRecentReviews)
+	fratRatings, fratCount := h.fratRatingSvc.ListByAuthor(userID, profileRecentReviews)
+	profile.RatingCount, profile.FratRatingCount = ratingCount, fratCount
+
+	for _, rt := range ratings {
+		review := model.ProfileReview{
+			Type:      "venue",
+			ID:        rt.ID,
+			Score:     rt.Score,
+			Review:    rt.Review,
+			Tags:      rt.Tags,
+			VenueID:   rt.VenueID,
+			CreatedAt: rt.CreatedAt,
+		}
+		if v, err := h.venueSvc.GetByID(r.Context(), rt.VenueID); err == nil {
+			review.VenueName, review.SchoolID = v.Name, v.SchoolID
+		}
+		profile.RecentReviews = append(profile.RecentReviews, review)
+	}
+	for _, fr := range fratRatings {
+		profile.RecentReviews = append(profile.RecentReviews, model.ProfileReview{
+			Type:      "frat",
+			ID:        fr.ID,
+			Score:     fr.Score,
+			FratName:  fr.FratName,
+			SchoolID:  fr.SchoolID,
+			CreatedAt: fr.CreatedAt,
+		})
+	}
+	sort.SliceStable(profile.RecentReviews, func(i, j int) bool {
+		return profile.RecentReviews[i].CreatedAt.After(profile.RecentReviews[j].CreatedAt)
+	})
+	if len(profile.RecentReviews) > profileRecentReviews {
+		profile.RecentReviews = profile.RecentReviews[:profileRecentReviews]
+	}
+
+	writeJSON(w, http.StatusOK, profile)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// maxFreezeHours caps manual freezes from the alerts page.
+const maxFreezeHours = 7 * 24
+
+// VelocityHandler exposes rating spike alerts to admins.
+type VelocityHandler struct {
+	svc      *service.VelocityService
+	auditSvc *service.AuditService
+}
+
+func NewVelocityHandler(svc *service.VelocityService, auditSvc *service.AuditService) *VelocityHandler {
+	return &VelocityHandler{svc: svc, auditSvc: auditSvc}
+}
+
+// ListAlerts handles GET /api/admin/rating-alerts
+func (h *VelocityHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.svc.Alerts())
+}
+
+// Freeze handles POST /api/admin/rating-alerts/{id}/freeze — stops new
+// ratings on the alert's target for the given number of hours.
+func (h *VelocityHandler) Freeze(w http.ResponseWriter, r *http.Request) {
+	var req model.FreezeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Hours < 1 || req.Hours > maxFreezeHours {
+		writeError(w, http.StatusBadRequest, "hours must be between 1 and 168")
+		return
+	}
+	h.setFreeze(w, r, time.Duration(req.Hours)*time.Hour)
+}
+
+// Unfreeze handles DELETE /api/admin/rating-alerts/{id}/freeze
+func (h *VelocityHandler) Unfreeze(w http.ResponseWriter, r *http.Request) {
+	h.setFreeze(w, r, 0)
+}
+
+func (h *VelocityHandler) setFreeze(w http.ResponseWriter, r *http.Request, d time.Duration) {
+	alert, err := h.svc.Freeze(chi.URLParam(r, "id"), d)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	action := "ratings.freeze"
+	if d == 0 {
+		action = "ratings.unfreeze"
+	}
+	h.auditSvc.Record(r.Context(), action, alert.TargetType, alert.TargetID, map[string]interface{}{
+		"alert_id":     alert.ID,
+		"frozen_until": alert.FrozenUntil,
+	})
+	writeJSON(w, http.StatusOK, alert)
+}
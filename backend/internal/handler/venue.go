@@ -2,8 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/ratemybars/backend/internal/model"
@@ -12,11 +15,62 @@ import (
 
 // VenueHandler handles venue-related HTTP requests.
 type VenueHandler struct {
-	svc *service.VenueService
+	svc       *service.VenueService
+	schoolSvc *service.SchoolService
+	ratingSvc *service.RatingService
+	promoSvc  *service.PromotionService
+	auditSvc  *service.AuditService
+	weather   *service.WeatherService
+	statuses  *service.VenueStatusService
+	archive   *service.VenueArchiveService
+	keywords  *service.KeywordService
+	sentiment *service.SentimentService
+	rideshare service.RideshareConfig
+	expander  *Expander
 }
 
-func NewVenueHandler(svc *service.VenueService) *VenueHandler {
-	return &VenueHandler{svc: svc}
+func NewVenueHandler(svc *service.VenueService, schoolSvc *service.SchoolService, ratingSvc *service.RatingService, promoSvc *service.PromotionService,
+	auditSvc *service.AuditService, rideshare service.RideshareConfig, expander *Expander) *VenueHandler {
+	return &VenueHandler{svc: svc, schoolSvc: schoolSvc, ratingSvc: ratingSvc, promoSvc: promoSvc, auditSvc: auditSvc, rideshare: rideshare, expander: expander}
+}
+
+// venueAuditDetails snapshots a venue before an admin action changes or
+// removes it.
+func (h *VenueHandler) venueAuditDetails(r *http.Request, id string) map[string]interface{} {
+	v, err := h.svc.GetByID(r.Context(), id)
+	if err != nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"name":          v.Name,
+		"school_id":     v.SchoolID,
+		"created_by_id": v.CreatedByID,
+	}
+}
+
+// SetWeather adds the patio weather flag to bulk stats.
+func (h *VenueHandler) SetWeather(weather *service.WeatherService) {
+	h.weather = weather
+}
+
+// SetStatuses includes owners' nightly statuses in bulk stats.
+func (h *VenueHandler) SetStatuses(statuses *service.VenueStatusService) {
+	h.statuses = statuses
+}
+
+// SetArchive keeps deleted approved venues in the school's archive.
+func (h *VenueHandler) SetArchive(archive *service.VenueArchiveService) {
+	h.archive = archive
+}
+
+// SetKeywords adds review keywords to venue detail responses.
+func (h *VenueHandler) SetKeywords(keywords *service.KeywordService) {
+	h.keywords = keywords
+}
+
+// SetSentiment adds the review vibe index to venue detail responses.
+func (h *VenueHandler) SetSentiment(sentiment *service.SentimentService) {
+	h.sentiment = sentiment
 }
 
 // Create handles POST /api/venues
@@ -36,81 +90,255 @@ func (h *VenueHandler) Create(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, venue)
 }
 
-// GetByID handles GET /api/venues/{id}
+// GetByID handles GET /api/venues/{id}?include=ratings,school,school.fraternities
 func (h *VenueHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
+	include, err := parseInclude(r, venueIncludes)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	venue, err := h.svc.GetByID(r.Context(), id)
 	if err != nil {
 		writeError(w, http.StatusNotFound, err.Error())
 		return
 	}
 
-	writeJSON(w, http.StatusOK, venue)
+	detail := *venue
+	detail.Rideshare = h.rideshare.Links(detail)
+	detail.Cohorts = h.ratingSvc.GetVenueCohorts(id)
+	if h.keywords != nil {
+		detail.Keywords = h.keywords.ForVenue(id)
+	}
+	if h.sentiment != nil {
+		detail.Vibe = h.sentiment.Vibe(id)
+	}
+	writeJSON(w, http.StatusOK, h.expander.Venue(r.Context(), detail, include))
+}
+
+// maxBulkStatsIDs caps how many venues one bulk stats request may ask for.
+const maxBulkStatsIDs = 200
+
+// Stats handles POST /api/venues/stats — rating stats for up to 200 venues
+// at once, keyed by venue ID. Unknown IDs are omitted.
+func (h *VenueHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	var req model.VenueStatsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.VenueIDs) == 0 {
+		writeError(w, http.StatusBadRequest, "venue_ids is required")
+		return
+	}
+	if len(req.VenueIDs) > maxBulkStatsIDs {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("at most %d venue_ids per request", maxBulkStatsIDs))
+		return
+	}
+
+	stats := h.svc.StatsFor(req.VenueIDs)
+	now := time.Now()
+	for id, st := range stats {
+		v, err := h.svc.GetByID(r.Context(), id)
+		if err != nil {
+			continue
+		}
+		st.ClosesInMinutes = service.ClosesInMinutes(v.Hours, now.In(h.schoolSvc.Location(v.SchoolID)))
+		st.PatioWeather = h.weather.PatioWeather(*v)
+		st.Status = h.statuses.Current(id, now)
+		stats[id] = st
+	}
+	writeJSON(w, http.StatusOK, stats)
 }
 
 // ListBySchool handles GET /api/schools/{id}/venues
 func (h *VenueHandler) ListBySchool(w http.ResponseWriter, r *http.Request) {
 	schoolID := chi.URLParam(r, "id")
-	q := r.URL.Query()
-	page, _ := strconv.Atoi(q.Get("page"))
-	limit, _ := strconv.Atoi(q.Get("limit"))
+	page, limit := pageParams(w, r, "venues")
 
 	result, err := h.svc.ListBySchool(r.Context(), schoolID, page, limit)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	result.MaxLimit = PaginationLimits()["venues"].Max
+
+	// Sponsored venues go in their own field and never alter organic ranking.
+	sponsored := []model.Venue{}
+	for _, id := range h.promoSvc.ActiveVenueIDs(schoolID, time.Now()) {
+		v, err := h.svc.GetByID(r.Context(), id)
+		if err != nil || !v.Verified || v.SchoolID != schoolID {
+			continue
+		}
+		sv := *v
+		sv.Sponsored = true
+		sponsored = append(sponsored, sv)
+	}
 
-	writeJSON(w, http.StatusOK, result)
+	writeJSONPooled(w, http.StatusOK, model.SchoolVenuesResponse{
+		PaginatedResponse: *result,
+		Sponsored:         sponsored,
+	})
 }
 
 // ListPending handles GET /api/admin/venues/pending (admin only)
 func (h *VenueHandler) ListPending(w http.ResponseWriter, r *http.Request) {
 	pending := h.svc.ListPending()
-	writeJSON(w, http.StatusOK, pending)
+	writeJSON(w, http.StatusOK, paginate(w, r, "admin", pending))
 }
 
 // Approve handles POST /api/admin/venues/{id}/approve (admin only)
 func (h *VenueHandler) Approve(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	details := h.venueAuditDetails(r, id)
 	if err := h.svc.ApproveVenue(id); err != nil {
 		writeError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	h.auditSvc.Record(r.Context(), "venue.approve", "venue", id, details)
 	writeJSON(w, http.StatusOK, map[string]string{"message": "venue approved"})
 }
 
 // Reject handles DELETE /api/admin/venues/{id}/reject (admin only)
 func (h *VenueHandler) Reject(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	details := h.venueAuditDetails(r, id)
 	if err := h.svc.RejectVenue(id); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.auditSvc.Record(r.Context(), "venue.reject", "venue", id, details)
 	writeJSON(w, http.StatusOK, map[string]string{"message": "venue rejected"})
 }
 
-// Delete handles DELETE /api/admin/venues/{id} (admin only, removes any venue)
+// Delete handles DELETE /api/admin/venues/{id}[?reason=&archive=false]
+// (admin only, removes any venue). Approved venues are kept in the school's
+// archive with their final ratings unless archive=false, e.g. for duplicates.
 func (h *VenueHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	archive := true
+	if v := r.URL.Query().Get("archive"); v != "" {
+		if archive, err = strconv.ParseBool(v); err != nil {
+			writeError(w, http.StatusBadRequest, "archive must be true or false")
+			return
+		}
+	}
+	venue, err := h.svc.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	// Only approved venues were ever part of the school's nightlife
+	archive = archive && venue.Verified && h.archive != nil
+
+	if dryRun {
+		// Ratings aren't deleted with the venue but are no longer reachable.
+		ratings, _ := h.ratingSvc.ListByVenue(r.Context(), id)
+		ratingIDs := make([]string, len(ratings))
+		for i, rt := range ratings {
+			ratingIDs[i] = rt.ID
+		}
+		preview := newDryRun("venue.delete")
+		addDryRun(preview, "venues", []string{id})
+		addDryRun(preview, "ratings", ratingIDs)
+		if archive {
+			addDryRun(preview, "archived", []string{id})
+		}
+		writeJSON(w, http.StatusOK, preview)
+		return
+	}
+
+	details := h.venueAuditDetails(r, id)
+	if archive {
+		var lastRated *time.Time
+		ratings, _ := h.ratingSvc.ListByVenue(r.Context(), id)
+		for _, rt := range ratings {
+			if lastRated == nil || rt.CreatedAt.After(*lastRated) {
+				t := rt.CreatedAt
+				lastRated = &t
+			}
+		}
+		if _, err := h.archive.Archive(r.Context(), *venue, lastRated, r.URL.Query().Get("reason")); err != nil {
+			status := http.StatusBadRequest
+			if strings.HasPrefix(err.Error(), "failed to") {
+				status = http.StatusInternalServerError
+			}
+			writeError(w, status, err.Error())
+			return
+		}
+		details["archived"] = true
+	}
 	if err := h.svc.DeleteVenue(id); err != nil {
 		writeError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	h.auditSvc.Record(r.Context(), "venue.delete", "venue", id, details)
 	writeJSON(w, http.StatusOK, map[string]string{"message": "venue deleted"})
 }
 
+// ListArchive handles GET /api/schools/{id}/venues/archive — closed and
+// removed venues with their final ratings, most recently closed first.
+func (h *VenueHandler) ListArchive(w http.ResponseWriter, r *http.Request) {
+	venues := []model.ArchivedVenue{}
+	if h.archive != nil {
+		venues = h.archive.ListBySchool(chi.URLParam(r, "id"))
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, "venues", venues))
+}
+
 // SearchVenues handles GET /api/admin/venues/search?q=... (admin only)
 func (h *VenueHandler) SearchVenues(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query().Get("q")
 	if q == "" {
-		writeJSON(w, http.StatusOK, []model.Venue{})
+		writeJSON(w, http.StatusOK, paginate(w, r, "admin", []model.Venue{}))
 		return
 	}
-	results := h.svc.SearchVenues(q, 20)
+	results := h.svc.SearchVenues(q, venueSearchMaxResults)
 	if results == nil {
 		results = []model.Venue{}
 	}
-	writeJSON(w, http.StatusOK, results)
+	writeJSON(w, http.StatusOK, paginate(w, r, "admin", results))
+}
+
+// ListPromotions handles GET /api/admin/promotions (admin only)
+func (h *VenueHandler) ListPromotions(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, paginate(w, r, "admin", h.promoSvc.List()))
+}
+
+// CreatePromotion handles POST /api/admin/promotions (admin only)
+func (h *VenueHandler) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	var req model.CreatePromotionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body (dates must be RFC 3339)")
+		return
+	}
+
+	venue, err := h.svc.GetByID(r.Context(), req.VenueID)
+	if err != nil || !venue.Verified {
+		writeError(w, http.StatusNotFound, "venue not found: "+req.VenueID)
+		return
+	}
+
+	promo, err := h.promoSvc.Create(r.Context(), venue.SchoolID, req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, promo)
+}
+
+// DeletePromotion handles DELETE /api/admin/promotions/{id} (admin only)
+func (h *VenueHandler) DeletePromotion(w http.ResponseWriter, r *http.Request) {
+	if err := h.promoSvc.Delete(chi.URLParam(r, "id")); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "promotion deleted"})
 }
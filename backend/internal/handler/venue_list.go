@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// VenueListHandler handles user-curated venue list requests.
+type VenueListHandler struct {
+	svc      *service.VenueListService
+	venueSvc *service.VenueService
+}
+
+func NewVenueListHandler(svc *service.VenueListService, venueSvc *service.VenueService) *VenueListHandler {
+	return &VenueListHandler{svc: svc, venueSvc: venueSvc}
+}
+
+// Create handles POST /api/lists
+func (h *VenueListHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.VenueListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if msg := h.checkVenues(r, req); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	list, err := h.svc.Create(r.Context(), req)
+	if err != nil {
+		writeError(w, listErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, h.enrich(r, list))
+}
+
+// GetByID handles GET /api/lists/{id}
+func (h *VenueListHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	list, err := h.svc.Get(r.Context(), chi.URLParam(r, "id"), middleware.ClientIP(r))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.enrich(r, list))
+}
+
+// Update handles PUT /api/lists/{id}
+func (h *VenueListHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req model.VenueListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if msg := h.checkVenues(r, req); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	list, err := h.svc.Update(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		writeError(w, listErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.enrich(r, list))
+}
+
+// Delete handles DELETE /api/lists/{id}
+func (h *VenueListHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeError(w, listErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "list deleted"})
+}
+
+// ListMine handles GET /api/me/lists
+func (h *VenueListHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	lists := h.svc.ListByOwner(middleware.GetUserID(r.Context()))
+	if lists == nil {
+		lists = []model.VenueList{}
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, "lists", lists))
+}
+
+// ListBySchool handles GET /api/schools/{id}/lists — popular public lists for a school.
+func (h *VenueListHandler) ListBySchool(w http.ResponseWriter, r *http.Request) {
+	lists := h.svc.GetPopularBySchool(chi.URLParam(r, "id"), 0)
+	writeJSON(w, http.StatusOK, paginate(w, r, "lists", lists))
+}
+
+// checkVenues returns an error message if any referenced venue doesn't exist.
+func (h *VenueListHandler) checkVenues(r *http.Request, req model.VenueListRequest) string {
+	for _, it := range req.Items {
+		v, err := h.venueSvc.GetByID(r.Context(), it.VenueID)
+		if err != nil || !v.Verified {
+			return "venue not found: " + it.VenueID
+		}
+	}
+	return ""
+}
+
+// enrich attaches venue details to each list item. Venues removed since the
+// list was made are dropped from the response.
+func (h *VenueListHandler) enrich(r *http.Request, list *model.VenueList) *model.VenueList {
+	items := make([]model.VenueListItem, 0, len(list.Items))
+	for _, it := range list.Items {
+		v, err := h.venueSvc.GetByID(r.Context(), it.VenueID)
+		if err != nil {
+			continue
+		}
+		venue := *v
+		it.Venue = &venue
+		items = append(items, it)
+	}
+	out := *list
+	out.Items = items
+	return &out
+}
+
+func listErrorStatus(err error) int {
+	switch err.Error() {
+	case "authentication required":
+		return http.StatusUnauthorized
+	case "list not found":
+		return http.StatusNotFound
+	case "you can only edit your own lists", "you can only delete your own lists":
+		return http.StatusForbidden
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/service"
+)
+
+// VenueStatusHandler handles owners' nightly venue statuses.
+type VenueStatusHandler struct {
+	svc       *service.VenueStatusService
+	venueSvc  *service.VenueService
+	schoolSvc *service.SchoolService
+	owners    *OwnerHandler
+}
+
+func NewVenueStatusHandler(svc *service.VenueStatusService, venueSvc *service.VenueService, schoolSvc *service.SchoolService, owners *OwnerHandler) *VenueStatusHandler {
+	return &VenueStatusHandler{svc: svc, venueSvc: venueSvc, schoolSvc: schoolSvc, owners: owners}
+}
+
+// Get handles GET /api/venues/{id}/status — null when nothing is posted.
+func (h *VenueStatusHandler) Get(w http.ResponseWriter, r *http.Request) {
+	venue, err := h.venueSvc.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.svc.Current(venue.ID, time.Now()))
+}
+
+// Set handles PUT /api/owner/venues/{id}/status (venue owner or admin)
+func (h *VenueStatusHandler) Set(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	if !h.owners.canManage(r, venueID) {
+		writeError(w, http.StatusForbidden, "You must be the verified owner of this venue")
+		return
+	}
+	venue, err := h.venueSvc.GetByID(r.Context(), venueID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	var req model.VenueStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	status, err := h.svc.Set(r.Context(), venue.ID, req, h.schoolSvc.Location(venue.SchoolID))
+	if err != nil {
+		code := http.StatusBadRequest
+		switch {
+		case err.Error() == "authentication required":
+			code = http.StatusUnauthorized
+		case strings.HasPrefix(err.Error(), "failed to"):
+			code = http.StatusInternalServerError
+		}
+		writeError(w, code, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// Clear handles DELETE /api/owner/venues/{id}/status (venue owner or admin)
+func (h *VenueStatusHandler) Clear(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	if !h.owners.canManage(r, venueID) {
+		writeError(w, http.StatusForbidden, "You must be the verified owner of this venue")
+		return
+	}
+	if err := h.svc.Clear(venueID); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "status cleared"})
+}
@@ -3,8 +3,8 @@ package middleware
 import (
 	"context"
 	"net/http"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -15,50 +15,116 @@ const (
 	UserIDKey   contextKey = "user_id"
 	UserNameKey contextKey = "username"
 	UserRoleKey contextKey = "user_role"
+
+	// SessionIDKey is only set for server-side sessions.
+	SessionIDKey contextKey = "session_id"
 )
 
-// AuthRequired is a middleware that checks for a valid JWT in the Authorization header or cookie.
-func AuthRequired(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		tokenStr := extractToken(r)
-		if tokenStr == "" {
-			http.Error(w, `{"error":"unauthorized","message":"Authentication required"}`, http.StatusUnauthorized)
-			return
-		}
+// SessionLookupFunc resolves an opaque server-side session token.
+type SessionLookupFunc func(token string) (userID, username, role, sessionID string, ok bool)
 
-		signingKey := os.Getenv("AUTH_SIGNING_KEY")
-		if signingKey == "" {
-			signingKey = "dev-signing-key-change-in-production"
-		}
+var sessionLookup SessionLookupFunc
 
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(signingKey), nil
-		})
+// SetSessionLookup enables server-side sessions: tokens are resolved with fn
+// and stateless JWTs are no longer accepted.
+func SetSessionLookup(fn SessionLookupFunc) {
+	sessionLookup = fn
+}
+
+// TokenCheckFunc reports whether a JWT with ID tokenID (its jti; empty for
+// older tokens) issued at issuedAt for userID is still valid, e.g. not
+// revoked at logout or from before a password change.
+type TokenCheckFunc func(userID, tokenID string, issuedAt time.Time) bool
+
+var tokenCheck TokenCheckFunc
 
+// SetTokenCheck makes JWT auth reject tokens fn refuses.
+func SetTokenCheck(fn TokenCheckFunc) {
+	tokenCheck = fn
+}
+
+// identify resolves the request's token into a context carrying the user, or
+// returns ok=false with a reason.
+func identify(r *http.Request, tokenStr string) (ctx context.Context, reason string, ok bool) {
+	var userID, username, role, sessionID string
+	if sessionLookup != nil {
+		userID, username, role, sessionID, ok = sessionLookup(tokenStr)
+		if !ok {
+			return nil, "Invalid or expired session", false
+		}
+	} else {
+		token, err := jwt.Parse(tokenStr, signingKeys.verificationKey)
 		if err != nil || !token.Valid {
-			http.Error(w, `{"error":"unauthorized","message":"Invalid or expired token"}`, http.StatusUnauthorized)
-			return
+			return nil, "Invalid or expired token", false
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			http.Error(w, `{"error":"unauthorized","message":"Invalid token claims"}`, http.StatusUnauthorized)
-			return
+			return nil, "Invalid token claims", false
 		}
+		userID, _ = claims["sub"].(string)
+		username, _ = claims["username"].(string)
+		role, _ = claims["role"].(string)
+		if tokenCheck != nil {
+			jti, _ := claims["jti"].(string)
+			iat, err := claims.GetIssuedAt()
+			if err != nil || iat == nil || !tokenCheck(userID, jti, iat.Time) {
+				return nil, "Token has been revoked", false
+			}
+		}
+	}
+	if role == "" {
+		role = "user"
+	}
+
+	ctx = context.WithValue(r.Context(), UserIDKey, userID)
+	ctx = context.WithValue(ctx, UserNameKey, username)
+	ctx = context.WithValue(ctx, UserRoleKey, role)
+	if sessionID != "" {
+		ctx = context.WithValue(ctx, SessionIDKey, sessionID)
+	}
+	return ctx, "", true
+}
+
+// TokenClaims returns the ID (jti), subject and expiry of a validly signed
+// JWT, whether or not it has been revoked.
+func TokenClaims(tokenStr string) (tokenID, userID string, expiresAt time.Time, ok bool) {
+	token, err := jwt.Parse(tokenStr, signingKeys.verificationKey)
+	if err != nil || !token.Valid {
+		return "", "", time.Time{}, false
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", "", time.Time{}, false
+	}
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return "", "", time.Time{}, false
+	}
+	tokenID, _ = claims["jti"].(string)
+	userID, _ = claims["sub"].(string)
+	return tokenID, userID, exp.Time, true
+}
 
-		userID, _ := claims["sub"].(string)
-		username, _ := claims["username"].(string)
-		role, _ := claims["role"].(string)
-		if role == "" {
-			role = "user"
+// AuthRequired is a middleware that checks for a valid JWT (or session token)
+// in the Authorization header or cookie. Banned users may still read but get
+// 403 on writes.
+func AuthRequired(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenStr := TokenFromRequest(r)
+		if tokenStr == "" {
+			http.Error(w, `{"error":"unauthorized","message":"Authentication required"}`, http.StatusUnauthorized)
+			return
 		}
 
-		ctx := context.WithValue(r.Context(), UserIDKey, userID)
-		ctx = context.WithValue(ctx, UserNameKey, username)
-		ctx = context.WithValue(ctx, UserRoleKey, role)
+		ctx, reason, ok := identify(r, tokenStr)
+		if !ok {
+			http.Error(w, `{"error":"unauthorized","message":"`+reason+`"}`, http.StatusUnauthorized)
+			return
+		}
+		if rejectBanned(w, r, GetUserID(ctx)) {
+			return
+		}
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
@@ -77,39 +143,23 @@ func AdminRequired(next http.Handler) http.Handler {
 	})
 }
 
-// OptionalAuth extracts user info if present but doesn't require it.
-func OptionalAuth(next http.Handler) http.Handler {
+// ModeratorRequired lets moderators and admins through.
+func ModeratorRequired(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		tokenStr := extractToken(r)
-		if tokenStr == "" {
-			next.ServeHTTP(w, r)
+		role := GetUserRole(r.Context())
+		if role != "moderator" && role != "admin" {
+			http.Error(w, `{"error":"forbidden","message":"Moderator access required"}`, http.StatusForbidden)
 			return
 		}
+		next.ServeHTTP(w, r)
+	})
+}
 
-		signingKey := os.Getenv("AUTH_SIGNING_KEY")
-		if signingKey == "" {
-			signingKey = "dev-signing-key-change-in-production"
-		}
-
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(signingKey), nil
-		})
-
-		if err == nil && token.Valid {
-			claims, ok := token.Claims.(jwt.MapClaims)
-			if ok {
-				userID, _ := claims["sub"].(string)
-				username, _ := claims["username"].(string)
-				role, _ := claims["role"].(string)
-				if role == "" {
-					role = "user"
-				}
-				ctx := context.WithValue(r.Context(), UserIDKey, userID)
-				ctx = context.WithValue(ctx, UserNameKey, username)
-				ctx = context.WithValue(ctx, UserRoleKey, role)
+// OptionalAuth extracts user info if present but doesn't require it.
+func OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenStr := TokenFromRequest(r); tokenStr != "" {
+			if ctx, _, ok := identify(r, tokenStr); ok {
 				r = r.WithContext(ctx)
 			}
 		}
@@ -118,7 +168,9 @@ func OptionalAuth(next http.Handler) http.Handler {
 	})
 }
 
-func extractToken(r *http.Request) string {
+// TokenFromRequest returns the bearer token from the Authorization header or
+// the auth cookie.
+func TokenFromRequest(r *http.Request) string {
 	// Check Authorization header first
 	authHeader := r.Header.Get("Authorization")
 	if authHeader != "" {
@@ -160,3 +212,11 @@ func GetUserRole(ctx context.Context) string {
 	}
 	return "user"
 }
+
+// GetSessionID returns the server-side session ID, or "" for JWT auth.
+func GetSessionID(ctx context.Context) string {
+	if v, ok := ctx.Value(SessionIDKey).(string); ok {
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// BanCheckFunc reports whether a user is banned, until when and why.
+type BanCheckFunc func(userID string) (until time.Time, reason string, banned bool)
+
+var banCheck BanCheckFunc
+
+// SetBanCheck makes AuthRequired refuse writes from banned users.
+func SetBanCheck(fn BanCheckFunc) {
+	banCheck = fn
+}
+
+// banExempt lists writes a banned user may still make: leaving, deleting the
+// account and signing out other devices.
+var banExempt = map[string]bool{
+	"DELETE /api/auth/me":       true,
+	"DELETE /api/auth/sessions": true,
+}
+
+// rejectBanned answers 403 for writes by a banned user and reports whether it
+// did.
+func rejectBanned(w http.ResponseWriter, r *http.Request, userID string) bool {
+	if banCheck == nil {
+		return false
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	if banExempt[r.Method+" "+r.URL.Path] {
+		return false
+	}
+	until, reason, banned := banCheck(userID)
+	if !banned {
+		return false
+	}
+
+	body := map[string]interface{}{
+		"error":   "banned",
+		"message": "Your account is suspended",
+		"reason":  reason,
+	}
+	if !until.IsZero() {
+		body["banned_until"] = until
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	json.NewEncoder(w).Encode(body)
+	return true
+}
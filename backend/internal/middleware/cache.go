@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// cachePolicyWriter applies a Cache-Control header just before the response
+// status is written, unless the handler already chose one.
+type cachePolicyWriter struct {
+	http.ResponseWriter
+	policy      func(status int) string
+	wroteHeader bool
+}
+
+func (c *cachePolicyWriter) WriteHeader(status int) {
+	if !c.wroteHeader {
+		c.wroteHeader = true
+		if c.Header().Get("Cache-Control") == "" {
+			c.Header().Set("Cache-Control", c.policy(status))
+		}
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *cachePolicyWriter) Write(p []byte) (int, error) {
+	if !c.wroteHeader {
+		c.WriteHeader(http.StatusOK)
+	}
+	return c.ResponseWriter.Write(p)
+}
+
+// PublicCache marks anonymous GET and HEAD responses as cacheable by shared
+// caches (CDNs) for sMaxAge, serving stale copies for up to swr while they
+// revalidate. Browsers always revalidate. Requests carrying credentials and
+// error responses are never stored.
+func PublicCache(sMaxAge, swr time.Duration) func(http.Handler) http.Handler {
+	public := fmt.Sprintf("public, max-age=0, s-maxage=%d, stale-while-revalidate=%d",
+		int(sMaxAge.Seconds()), int(swr.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if TokenFromRequest(r) != "" {
+				NoStore(next).ServeHTTP(w, r)
+				return
+			}
+
+			if !strings.Contains(strings.Join(w.Header().Values("Vary"), ","), "Authorization") {
+				w.Header().Add("Vary", "Authorization")
+			}
+			next.ServeHTTP(&cachePolicyWriter{ResponseWriter: w, policy: func(status int) string {
+				if status < 400 {
+					return public
+				}
+				return "no-store"
+			}}, r)
+		})
+	}
+}
+
+// NoStore forbids any cache from storing the response. Used on every
+// authenticated route so personalized data never lands in a CDN.
+func NoStore(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "private, no-store")
+		next.ServeHTTP(w, r)
+	})
+}
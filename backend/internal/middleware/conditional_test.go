@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type discardWriter struct{ header http.Header }
+
+func (w *discardWriter) Header() http.Header         { return w.header }
+func (w *discardWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (w *discardWriter) WriteHeader(int)             {}
+
+// BenchmarkConditional measures the capture buffer's cost on a response the
+// size of a page of venues.
+func BenchmarkConditional(b *testing.B) {
+	body := bytes.Repeat([]byte(`{"id":"venue_1","name":"Bar","avg_rating":4.1},`), 1000)
+	h := Conditional(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	r := httptest.NewRequest(http.MethodGet, "/api/schools/240444/venues", nil)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		h.ServeHTTP(&discardWriter{header: make(http.Header)}, r)
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCSRF(t *testing.T) {
+	const token = "3f9a"
+	tests := []struct {
+		name   string
+		method string
+		cookie string // csrf_token cookie value, "" for none
+		header string // X-CSRF-Token
+		auth   string // Authorization
+		want   int
+	}{
+		{"safe method needs no token", http.MethodGet, "", "", "", http.StatusOK},
+		{"matching header", http.MethodPost, token, token, "", http.StatusOK},
+		{"mismatched header", http.MethodPost, token, "3f9b", "", http.StatusForbidden},
+		{"missing header", http.MethodDelete, token, "", "", http.StatusForbidden},
+		{"missing cookie", http.MethodPut, "", token, "", http.StatusForbidden},
+		{"bearer token is exempt", http.MethodPost, "", "", "Bearer eyJhbGciOi", http.StatusOK},
+		{"empty bearer is not", http.MethodPost, "", "", "Bearer ", http.StatusForbidden},
+		{"other scheme is not", http.MethodPost, "", "", "Basic dXNlcjpwYXNz", http.StatusForbidden},
+	}
+	h := CSRF(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "/api/ratings", nil)
+		if tt.cookie != "" {
+			r.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: tt.cookie})
+		}
+		if tt.header != "" {
+			r.Header.Set(CSRFHeaderName, tt.header)
+		}
+		if tt.auth != "" {
+			r.Header.Set("Authorization", tt.auth)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+}
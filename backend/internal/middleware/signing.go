@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const devSigningKey = "dev-signing-key-change-in-production"
+
+// SigningKeys signs and verifies auth JWTs. The default is HS256 with
+// AUTH_SIGNING_KEY; with an RSA or ECDSA private key configured, tokens are
+// signed with it and other services can verify them from the public key
+// published at /.well-known/jwks.json.
+type SigningKeys struct {
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+	kid     string
+
+	// hmac verifies (and, without a private key, signs) HS256 tokens. With a
+	// private key it is only set when AUTH_SIGNING_KEY is, so tokens issued
+	// before switching algorithms stay valid until they expire.
+	hmac []byte
+}
+
+var signingKeys = &SigningKeys{method: jwt.SigningMethodHS256, hmac: []byte(devSigningKey)}
+
+// SetSigningKeys replaces the keys used to sign and verify JWTs.
+func SetSigningKeys(k *SigningKeys) {
+	signingKeys = k
+}
+
+// LoadSigningKeys reads AUTH_SIGNING_ALG (HS256, RS256, ES256 or ES384;
+// default HS256), AUTH_SIGNING_KEY for HS256, and the PEM private key for the
+// others from AUTH_PRIVATE_KEY_FILE or AUTH_PRIVATE_KEY. AUTH_KEY_ID overrides
+// the key ID, which defaults to the key's RFC 7638 thumbprint.
+func LoadSigningKeys() (*SigningKeys, error) {
+	secret := os.Getenv("AUTH_SIGNING_KEY")
+	alg := strings.ToUpper(strings.TrimSpace(os.Getenv("AUTH_SIGNING_ALG")))
+	if alg == "" || alg == "HS256" {
+		if secret == "" {
+			secret = devSigningKey
+		}
+		return &SigningKeys{method: jwt.SigningMethodHS256, hmac: []byte(secret)}, nil
+	}
+
+	pemData := []byte(os.Getenv("AUTH_PRIVATE_KEY"))
+	if path := os.Getenv("AUTH_PRIVATE_KEY_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read AUTH_PRIVATE_KEY_FILE: %w", err)
+		}
+		pemData = data
+	}
+	if len(pemData) == 0 {
+		return nil, fmt.Errorf("AUTH_SIGNING_ALG=%s needs AUTH_PRIVATE_KEY_FILE or AUTH_PRIVATE_KEY", alg)
+	}
+	private, err := parsePrivateKey(pemData)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &SigningKeys{private: private, public: private.Public()}
+	switch alg {
+	case "RS256":
+		key, ok := private.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("RS256 needs an RSA private key")
+		}
+		if key.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("RSA signing keys must be at least 2048 bits")
+		}
+		k.method = jwt.SigningMethodRS256
+	case "ES256", "ES384":
+		key, ok := private.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%s needs an ECDSA private key", alg)
+		}
+		want := map[string]elliptic.Curve{"ES256": elliptic.P256(), "ES384": elliptic.P384()}[alg]
+		if key.Curve != want {
+			return nil, fmt.Errorf("%s needs a %s key", alg, want.Params().Name)
+		}
+		k.method = jwt.GetSigningMethod(alg)
+	default:
+		return nil, fmt.Errorf("unsupported AUTH_SIGNING_ALG %q", alg)
+	}
+
+	k.kid = os.Getenv("AUTH_KEY_ID")
+	if k.kid == "" {
+		k.kid = keyThumbprint(k.public)
+	}
+	if secret != "" {
+		k.hmac = []byte(secret)
+	}
+	return k, nil
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("private key is not PEM encoded")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type")
+		}
+		return signer, nil
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
+
+// Algorithm is the JWT "alg" new tokens are signed with.
+func (k *SigningKeys) Algorithm() string {
+	return k.method.Alg()
+}
+
+// SignToken returns a signed JWT for claims.
+func SignToken(claims jwt.Claims) (string, error) {
+	k := signingKeys
+	token := jwt.NewWithClaims(k.method, claims)
+	if k.private == nil {
+		return token.SignedString(k.hmac)
+	}
+	token.Header["kid"] = k.kid
+	return token.SignedString(k.private)
+}
+
+// verificationKey is the jwt.Keyfunc for auth tokens. Only the configured
+// algorithm is accepted, plus HS256 while a shared secret is still set.
+func (k *SigningKeys) verificationKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if k.hmac == nil || token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return k.hmac, nil
+	}
+	if k.private == nil || token.Method.Alg() != k.method.Alg() {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	if kid, ok := token.Header["kid"].(string); ok && kid != k.kid {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	return k.public, nil
+}
+
+// JWK is one public key in a JSON Web Key Set.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS returns the public keys that verify auth tokens. It is empty when
+// tokens are signed with a shared secret.
+func JWKS() []JWK {
+	k := signingKeys
+	if k.private == nil {
+		return []JWK{}
+	}
+	jwk := publicJWK(k.public)
+	jwk.Use = "sig"
+	jwk.Alg = k.method.Alg()
+	jwk.Kid = k.kid
+	return []JWK{jwk}
+}
+
+func publicJWK(public crypto.PublicKey) JWK {
+	b64 := base64.RawURLEncoding.EncodeToString
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		return JWK{Kty: "RSA", N: b64(key.N.Bytes()), E: b64(big.NewInt(int64(key.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		x := make([]byte, size)
+		y := make([]byte, size)
+		key.X.FillBytes(x)
+		key.Y.FillBytes(y)
+		return JWK{Kty: "EC", Crv: key.Curve.Params().Name, X: b64(x), Y: b64(y)}
+	}
+	return JWK{}
+}
+
+// keyThumbprint is the RFC 7638 SHA-256 thumbprint of a public key.
+func keyThumbprint(public crypto.PublicKey) string {
+	jwk := publicJWK(public)
+	// The required members, in lexicographic order.
+	var members interface{}
+	if jwk.Kty == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Crv, jwk.Kty, jwk.X, jwk.Y}
+	}
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// TermsRequired blocks requests from users who haven't accepted the current
+// terms-of-service version with 428 Precondition Required, so clients can
+// prompt for re-acceptance and retry. Must be used after AuthRequired.
+func TermsRequired(currentVersion func() string, acceptedVersion func(userID string) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current := currentVersion()
+			if acceptedVersion(GetUserID(r.Context())) != current {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusPreconditionRequired)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error":         "terms_not_accepted",
+					"message":       "Please review and accept the updated Terms of Service and Privacy Policy",
+					"terms_version": current,
+				})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// UsageRecordFunc receives one finished request. route is the matched chi
+// pattern (e.g. "/api/venues/{id}"), apiKey a short fingerprint of the
+// X-API-Key header or "".
+type UsageRecordFunc func(route, apiKey, ip string, status int, at time.Time)
+
+// statusWriter remembers the response status.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusWriter) WriteHeader(status int) {
+	if s.status == 0 {
+		s.status = status
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusWriter) Write(p []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	return s.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to
+// flush event streams.
+func (s *statusWriter) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// Usage reports every request to record once it has been served, including
+// requests rejected by rate limiters further down the chain.
+func Usage(record UsageRecordFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sw := &statusWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r)
+
+			route := ""
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				route = rctx.RoutePattern()
+			}
+			if route == "" {
+				route = "unmatched"
+			}
+			status := sw.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			record(r.Method+" "+route, apiKeyFingerprint(r.Header.Get("X-API-Key")), ClientIP(r), status, time.Now())
+		})
+	}
+}
+
+// ClientIP is the originating address without a port or proxy chain.
+func ClientIP(r *http.Request) string {
+	ip, _, _ := strings.Cut(extractIP(r), ",")
+	ip = strings.TrimSpace(ip)
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		return host
+	}
+	return ip
+}
+
+// apiKeyFingerprint identifies an API key in reports without storing it.
+func apiKeyFingerprint(key string) string {
+	if key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(key))
+	return "key_" + hex.EncodeToString(sum[:])[:12]
+}
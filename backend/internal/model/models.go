@@ -10,8 +10,10 @@ type School struct {
 	AliasName      string  `json:"alias_name,omitempty"`
 	Address        string  `json:"address"`
 	City           string  `json:"city"`
-	State          string  `json:"state"`
+	CitySlug       string  `json:"city_slug"` // normalized for /api/cities/{state}/{city}
+	State          string  `json:"state"`     // state, or province for Canadian schools
 	Zip            string  `json:"zip"`
+	Country        string  `json:"country"` // region code, e.g. "US", "CA"
 	Control        string  `json:"control"` // "public" or "private_nonprofit"
 	ICLevel        int     `json:"iclevel"`
 	Website        string  `json:"website,omitempty"`
@@ -27,11 +29,21 @@ type School struct {
 	IsCommunityCol bool    `json:"is_community_college"`
 	IsLiberalArts  bool    `json:"is_liberal_arts"`
 	IsGraduateOnly bool    `json:"is_graduate_only"`
+	// Conference is the school's athletic conference (e.g. "Big Ten"), if known.
+	Conference string `json:"conference,omitempty"`
+	// GreekOptOut is set when the school asked for fraternity listings and
+	// ratings to be removed from its campus; FratCount is then always zero.
+	GreekOptOut bool `json:"greek_opt_out,omitempty"`
+	// Links are nightlife accounts and sites moderators listed for the school.
+	Links []SchoolLink `json:"links,omitempty"`
 
 	// Computed fields
 	VenueCount int     `json:"venue_count"`
 	FratCount  int     `json:"frat_count"`
 	AvgRating  float64 `json:"avg_rating,omitempty"`
+	// RecommendPct is the share of reviewers who'd recommend the school's
+	// nightlife to a friend; nil until someone has answered.
+	RecommendPct *int `json:"recommend_pct,omitempty"`
 }
 
 // Venue represents a user-submitted party venue.
@@ -49,11 +61,56 @@ type Venue struct {
 	CreatedAt   time.Time `json:"created_at"`
 	Verified    bool      `json:"verified"`
 
+	// Hours is the weekly opening schedule in the school's local time, if known.
+	Hours []OpeningHours `json:"hours,omitempty"`
+
 	// Computed fields
 	AvgRating   float64 `json:"avg_rating"`
 	RatingCount int     `json:"rating_count"`
 	ThumbsUp    int     `json:"thumbs_up"`
 	ThumbsDown  int     `json:"thumbs_down"`
+	// RecommendPct is the percentage of ratings that are a thumbs up; null
+	// until the venue has been rated.
+	RecommendPct *int `json:"recommend_pct"`
+
+	// Rideshare holds "get a ride here" deep links on venue detail responses.
+	Rideshare []RideshareLink `json:"rideshare,omitempty"`
+
+	// Cohorts breaks the average down by reviewers' class year on venue
+	// detail responses. Cohorts with too few ratings are left out.
+	Cohorts []CohortStat `json:"cohorts,omitempty"`
+	// Keywords are the terms reviews of this venue mention most, from the
+	// periodic keyword job; only on venue detail responses.
+	Keywords []VenueKeyword `json:"keywords,omitempty"`
+	// Vibe is the tone of the venue's review text, next to its stars; only
+	// on venue detail responses once enough reviews have text.
+	Vibe *VenueVibe `json:"vibe,omitempty"`
+
+	// Sponsored is only set on venues returned in a dedicated sponsored slot,
+	// never on organic listings.
+	Sponsored bool `json:"sponsored,omitempty"`
+}
+
+// ArchivedVenue is an approved venue that has closed or been removed, kept
+// with its final ratings as part of a school's nightlife history.
+type ArchivedVenue struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Category     string     `json:"category"`
+	Address      string     `json:"address,omitempty"`
+	Latitude     float64    `json:"latitude,omitempty"`
+	Longitude    float64    `json:"longitude,omitempty"`
+	SchoolID     string     `json:"school_id"`
+	AvgRating    float64    `json:"avg_rating"`
+	RatingCount  int        `json:"rating_count"`
+	ThumbsUp     int        `json:"thumbs_up"`
+	ThumbsDown   int        `json:"thumbs_down"`
+	RecommendPct *int       `json:"recommend_pct"`
+	ListedAt     time.Time  `json:"listed_at"`
+	LastRatedAt  *time.Time `json:"last_rated_at,omitempty"`
+	ClosedAt     time.Time  `json:"closed_at"`
+	Reason       string     `json:"reason,omitempty"`
+	ClosedByID   string     `json:"-"`
 }
 
 // Rating represents a user's rating and review of a venue.
@@ -61,28 +118,1044 @@ type Rating struct {
 	ID         string    `json:"id"`
 	Score      float32   `json:"score"`
 	Review     string    `json:"review,omitempty"`
+	Tags       []string  `json:"tags,omitempty"`
 	VenueID    string    `json:"venue_id"`
 	AuthorID   string    `json:"author_id"`
 	AuthorName string    `json:"author_name,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 	Upvotes    int       `json:"upvotes"`
 	Downvotes  int       `json:"downvotes"`
+
+	// Reactions maps a reaction name ("fire", "skull", "beers") to its count.
+	Reactions map[string]int `json:"reactions,omitempty"`
+
+	// Sentiment is the review text's tone from -1 (negative) to 1
+	// (positive), on venue and school review lists once scored.
+	Sentiment *float64 `json:"sentiment,omitempty"`
+
+	// Redacted is set once a moderator has blacked out part of the review.
+	Redacted bool `json:"redacted,omitempty"`
+
+	// PII lists personal details detected at submission. It's returned to the
+	// author and to moderators, never in public listings.
+	PII []PIIFinding `json:"pii,omitempty"`
+	// PendingReview is set when the rating is held for moderation.
+	PendingReview bool `json:"pending_review,omitempty"`
+	// VerifiedStudent is set when the author has verified a .edu address.
+	VerifiedStudent bool `json:"verified_student,omitempty"`
+	// VerifiedVisit is set when the author had a geofenced check-in at the
+	// venue shortly before rating it. Verified ratings weigh more in averages.
+	VerifiedVisit bool `json:"verified_visit,omitempty"`
+	// WouldRecommend is the author's answer to "would you recommend this
+	// school's nightlife to a friend?"; nil if they skipped it.
+	WouldRecommend *bool `json:"would_recommend,omitempty"`
+	// AuthorGradYear is the author's graduation year when they rated, used
+	// to group ratings by class-year cohort.
+	AuthorGradYear int `json:"author_grad_year,omitempty"`
+	// EditedAt is set once the author has updated the rating.
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+	// Imported is set on ratings brought in from the author's reviews
+	// elsewhere (a Google Maps takeout) rather than written here.
+	Imported bool `json:"imported,omitempty"`
+}
+
+// ReviewSearchResult is a rating matching a review text search. Snippet is
+// HTML-escaped with matches wrapped in <mark> tags.
+type ReviewSearchResult struct {
+	Rating      Rating `json:"rating"`
+	VenueName   string `json:"venue_name,omitempty"`
+	Snippet     string `json:"snippet"`
+	PhraseMatch bool   `json:"phrase_match"`
+}
+
+// PIIFinding is a piece of personal information detected in review text.
+type PIIFinding struct {
+	Kind   string `json:"kind"` // phone, email, name
+	Text   string `json:"text"`
+	Action string `json:"action"` // warn, redact, queue
 }
 
 // User represents an authenticated user.
 type User struct {
 	ID               string    `json:"id"`
 	Username         string    `json:"username"`
-	Role             string    `json:"role"` // "user" or "admin"
+	Role             string    `json:"role"` // "user", "owner", "ambassador", "moderator" or "admin"
 	DisplayName      string    `json:"display_name,omitempty"`
 	AvatarURL        string    `json:"avatar_url,omitempty"`
+	Bio              string    `json:"bio,omitempty"`
 	CreatedAt        time.Time `json:"created_at"`
 	LastRatingAt     time.Time `json:"last_rating_at,omitempty"`
 	RatingCountToday int       `json:"rating_count_today"`
+
+	// Age-gate acknowledgment: when the user confirmed they're of legal
+	// drinking age, and in which jurisdiction (e.g. "US-WI").
+	AgeConfirmed    bool       `json:"age_confirmed"`
+	AgeConfirmedAt  *time.Time `json:"age_confirmed_at,omitempty"`
+	AgeJurisdiction string     `json:"age_jurisdiction,omitempty"`
+
+	// Terms of Service / Privacy Policy version the user last accepted.
+	TermsVersion    string     `json:"terms_version,omitempty"`
+	TermsAcceptedAt *time.Time `json:"terms_accepted_at,omitempty"`
+	TermsCurrent    bool       `json:"terms_current"`
+
+	// Home school and graduation year, set during onboarding. Used to
+	// personalize the feed, map viewport and leaderboards.
+	HomeSchoolID string `json:"home_school_id,omitempty"`
+	GradYear     int    `json:"grad_year,omitempty"`
+
+	// SchoolID is the school whose .edu address the user verified.
+	SchoolID          string     `json:"school_id,omitempty"`
+	StudentEmail      string     `json:"student_email,omitempty"`
+	StudentVerifiedAt *time.Time `json:"student_verified_at,omitempty"`
+	VerifiedStudent   bool       `json:"verified_student"`
+
+	// Banned users can read but not write. BannedUntil is nil for a
+	// permanent ban.
+	Banned      bool       `json:"banned,omitempty"`
+	BannedUntil *time.Time `json:"banned_until,omitempty"`
+	BanReason   string     `json:"ban_reason,omitempty"`
+}
+
+// PublicProfile is what anyone can see about a user at /api/users/{id}.
+type PublicProfile struct {
+	ID              string          `json:"id"`
+	Username        string          `json:"username"`
+	DisplayName     string          `json:"display_name,omitempty"`
+	AvatarURL       string          `json:"avatar_url,omitempty"`
+	Bio             string          `json:"bio,omitempty"`
+	MemberSince     time.Time       `json:"member_since"`
+	VerifiedStudent bool            `json:"verified_student"`
+	RatingCount     int             `json:"rating_count"`
+	FratRatingCount int             `json:"frat_rating_count"`
+	RecentReviews   []ProfileReview `json:"recent_reviews"`
+}
+
+// ProfileReview is one of a user's venue or chapter ratings on their profile.
+type ProfileReview struct {
+	Type      string    `json:"type"` // "venue" or "frat"
+	ID        string    `json:"id"`
+	Score     float32   `json:"score"`
+	Review    string    `json:"review,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	VenueID   string    `json:"venue_id,omitempty"`
+	VenueName string    `json:"venue_name,omitempty"`
+	FratName  string    `json:"frat_name,omitempty"`
+	SchoolID  string    `json:"school_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BanUserRequest suspends a user; a zero DurationHours bans permanently.
+type BanUserRequest struct {
+	Reason        string `json:"reason"`
+	DurationHours int    `json:"duration_hours"`
+}
+
+// UserBan describes a ban just placed. RejectedVenues are the user's pending
+// venue submissions that were rejected with it.
+type UserBan struct {
+	UserID         string     `json:"user_id"`
+	Reason         string     `json:"reason"`
+	BannedUntil    *time.Time `json:"banned_until,omitempty"`
+	Permanent      bool       `json:"permanent"`
+	RejectedVenues []string   `json:"rejected_venues"`
+}
+
+// VenueList is a user-curated, ranked list of venues ("Best dives in Madison").
+type VenueList struct {
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	SchoolID    string          `json:"school_id,omitempty"`
+	OwnerID     string          `json:"owner_id"`
+	OwnerName   string          `json:"owner_name,omitempty"`
+	Visibility  string          `json:"visibility"` // "public" or "private"
+	Items       []VenueListItem `json:"items"`
+	ViewCount   int             `json:"view_count"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// VenueListItem is a single ranked entry in a VenueList.
+type VenueListItem struct {
+	Rank    int    `json:"rank"`
+	VenueID string `json:"venue_id"`
+	Note    string `json:"note,omitempty"`
+	Venue   *Venue `json:"venue,omitempty"`
+}
+
+// Crawl is a planned bar crawl: an ordered route of venues on a date that
+// other users RSVP to. Private crawls are only visible to the host, people on
+// the RSVP list and anyone holding the invite code.
+type Crawl struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	SchoolID    string      `json:"school_id"`
+	OwnerID     string      `json:"owner_id"`
+	OwnerName   string      `json:"owner_name,omitempty"`
+	StartsAt    time.Time   `json:"starts_at"`
+	Visibility  string      `json:"visibility"`            // "public" or "private"
+	InviteCode  string      `json:"invite_code,omitempty"` // host only
+	Stops       []CrawlStop `json:"stops"`
+	RSVPs       []CrawlRSVP `json:"rsvps"`
+	Going       int         `json:"going"`
+	Maybe       int         `json:"maybe"`
+	MyRSVP      string      `json:"my_rsvp,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// CrawlStop is one venue on a crawl's route, in visiting order.
+type CrawlStop struct {
+	Rank    int    `json:"rank"`
+	VenueID string `json:"venue_id"`
+	Note    string `json:"note,omitempty"`
+	Venue   *Venue `json:"venue,omitempty"`
+}
+
+// CrawlRSVP is one user's answer to a crawl.
+type CrawlRSVP struct {
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	Status    string    `json:"status"` // "going" or "maybe"
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GroupSession is an ephemeral "where should we go" vote: members join with a
+// short code and vote yes or no on each candidate venue.
+type GroupSession struct {
+	ID         string           `json:"id"`
+	Code       string           `json:"code"`
+	HostID     string           `json:"host_id"`
+	SchoolID   string           `json:"school_id"`
+	Status     string           `json:"status"` // "open" or "closed"
+	Candidates []GroupCandidate `json:"candidates"`
+	Members    []GroupMember    `json:"members"`
+	MyVotes    map[string]bool  `json:"my_votes"`
+	Pick       *GroupCandidate  `json:"pick,omitempty"`
+	Decided    bool             `json:"decided"`
+	CreatedAt  time.Time        `json:"created_at"`
+	ExpiresAt  time.Time        `json:"expires_at"`
+}
+
+// GroupCandidate is one venue up for a vote and its tally.
+type GroupCandidate struct {
+	VenueID string `json:"venue_id"`
+	Venue   *Venue `json:"venue,omitempty"`
+	Yes     int    `json:"yes"`
+	No      int    `json:"no"`
+}
+
+// GroupMember is someone in a group session and how many candidates they
+// have voted on.
+type GroupMember struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Voted    int    `json:"voted"`
+}
+
+// SchoolDigest is a weekly summary of nightlife activity at a school.
+type SchoolDigest struct {
+	SchoolID      string       `json:"school_id"`
+	SchoolName    string       `json:"school_name"`
+	WeekStart     time.Time    `json:"week_start"`
+	WeekEnd       time.Time    `json:"week_end"`
+	GeneratedAt   time.Time    `json:"generated_at"`
+	TopRated      *DigestVenue `json:"top_rated,omitempty"`
+	TopRatedIsNew bool         `json:"top_rated_is_new"` // true if the #1 venue changed since last week
+	MostReviewed  *DigestVenue `json:"most_reviewed,omitempty"`
+	NewRatings    int          `json:"new_ratings"`
+	NewVenues     int          `json:"new_venues"`
+	Rank          int          `json:"rank,omitempty"`
+	PreviousRank  int          `json:"previous_rank,omitempty"`
+	RankChange    int          `json:"rank_change"` // positive = moved up the leaderboard
+}
+
+// DigestVenue is a venue highlighted in a SchoolDigest.
+type DigestVenue struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Category      string  `json:"category"`
+	AvgRating     float64 `json:"avg_rating"`
+	RatingCount   int     `json:"rating_count"`
+	ThumbsUp      int     `json:"thumbs_up"`
+	ThumbsDown    int     `json:"thumbs_down"`
+	RecommendPct  *int    `json:"recommend_pct"`
+	WeeklyRatings int     `json:"weekly_ratings"`
+}
+
+// PushSubscription is a browser Web Push subscription (PushSubscription.toJSON()).
+type PushSubscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+	UserID    string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PushMessage is the JSON payload delivered to the service worker.
+type PushMessage struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url,omitempty"`
+	Tag   string `json:"tag,omitempty"`
+}
+
+// ShareLink is a short code that redirects to a frontend venue or school page.
+type ShareLink struct {
+	Code          string     `json:"code"`
+	TargetType    string     `json:"target_type"` // "venue" or "school"
+	TargetID      string     `json:"target_id"`
+	UTMSource     string     `json:"utm_source"`
+	UTMMedium     string     `json:"utm_medium"`
+	UTMCampaign   string     `json:"utm_campaign,omitempty"`
+	CreatedByID   string     `json:"created_by_id"`
+	CreatedAt     time.Time  `json:"created_at"`
+	Clicks        int        `json:"clicks"`
+	LastClickedAt *time.Time `json:"last_clicked_at,omitempty"`
+}
+
+// VenueClaim is an owner's request to manage a venue, verified by a one-time code.
+type VenueClaim struct {
+	ID           string     `json:"id"`
+	VenueID      string     `json:"venue_id"`
+	UserID       string     `json:"user_id"`
+	Username     string     `json:"username,omitempty"`
+	Status       string     `json:"status"` // "pending", "code_issued", "verified", "rejected"
+	Message      string     `json:"message,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CodeIssuedAt *time.Time `json:"code_issued_at,omitempty"`
+	VerifiedAt   *time.Time `json:"verified_at,omitempty"`
+	Attempts     int        `json:"attempts"`
+}
+
+// VenueAnalytics is the owner-facing performance report for a venue.
+type VenueAnalytics struct {
+	VenueID      string           `json:"venue_id"`
+	AvgRating    float64          `json:"avg_rating"`
+	RatingCount  int              `json:"rating_count"`
+	ThumbsUp     int              `json:"thumbs_up"`
+	ThumbsDown   int              `json:"thumbs_down"`
+	RecommendPct *int             `json:"recommend_pct"`
+	Followers    int              `json:"followers"`
+	RatingTrend  []TrendPoint     `json:"rating_trend"`
+	ShareClicks  int              `json:"share_clicks"`
+	ShareLinks   []ShareLink      `json:"share_links"`
+	TagCounts    map[string]int   `json:"tag_counts"`
+	Nearby       NearbyComparison `json:"nearby"`
+	GeneratedAt  time.Time        `json:"generated_at"`
+}
+
+// VenueStats is the rating summary for one venue in a bulk stats response.
+type VenueStats struct {
+	AvgRating    float64 `json:"avg_rating"`
+	RatingCount  int     `json:"rating_count"`
+	ThumbsUp     int     `json:"thumbs_up"`
+	ThumbsDown   int     `json:"thumbs_down"`
+	RecommendPct *int    `json:"recommend_pct"`
+	// ClosesInMinutes counts down to closing time while the venue is open;
+	// nil when it's closed or has no hours on file.
+	ClosesInMinutes *int `json:"closes_in_minutes"`
+	// PatioWeather is set for venues with outdoor seating when the weather
+	// at their school is known.
+	PatioWeather *bool `json:"patio_weather,omitempty"`
+	// Status is the owner's posted status for tonight, if any.
+	Status *VenueStatus `json:"status,omitempty"`
+}
+
+// VenueStatus is what a verified owner reports about their venue tonight.
+// It expires at the end of the night or sooner if the owner asked.
+type VenueStatus struct {
+	VenueID    string    `json:"venue_id"`
+	Crowd      string    `json:"crowd,omitempty"` // "quiet", "busy", "packed" or "at_capacity"
+	LiveMusic  bool      `json:"live_music"`
+	Cover      string    `json:"cover,omitempty"` // e.g. "no cover before 11"
+	Note       string    `json:"note,omitempty"`
+	PostedByID string    `json:"posted_by_id"`
+	PostedAt   time.Time `json:"posted_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// VenueStatusRequest posts a venue status. Hours (1-12) ends it early; by
+// default it lasts until the end of the night.
+type VenueStatusRequest struct {
+	Crowd     string `json:"crowd"`
+	LiveMusic bool   `json:"live_music"`
+	Cover     string `json:"cover"`
+	Note      string `json:"note"`
+	Hours     int    `json:"hours,omitempty"`
+}
+
+// TrendPoint is one bucket of a rating time series.
+// RatingDraft is an in-progress review autosaved while the user writes it.
+type RatingDraft struct {
+	VenueID        string    `json:"venue_id"`
+	Score          float32   `json:"score,omitempty"`
+	Review         string    `json:"review,omitempty"`
+	Tags           []string  `json:"tags,omitempty"`
+	WouldRecommend *bool     `json:"would_recommend,omitempty"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// CohortStat is a venue's average rating among one class-year cohort.
+type CohortStat struct {
+	Cohort      string  `json:"cohort"` // freshman, sophomore, junior, senior, alumni
+	AvgRating   float64 `json:"avg_rating"`
+	RatingCount int     `json:"rating_count"`
+}
+
+// VenueKeyword is a descriptive term ("fishbowls", "long line") and how
+// many of a venue's reviews use it.
+type VenueKeyword struct {
+	Term    string `json:"term"`
+	Reviews int    `json:"reviews"`
+}
+
+// VenueVibe compares what a venue's reviews say with how they score it.
+// Both indexes run 0–100: Index from the text's sentiment, StarIndex from
+// the same reviews' stars (1 star = 0, 5 stars = 100). Mismatch flags venues
+// where they're far apart, e.g. glowing stars on reviews full of complaints.
+type VenueVibe struct {
+	Index     int  `json:"index"`
+	StarIndex int  `json:"star_index"`
+	Reviews   int  `json:"reviews"`
+	Mismatch  bool `json:"mismatch,omitempty"`
+}
+
+// MonthStat is a venue's activity in one calendar month, pooled across years.
+type MonthStat struct {
+	Month     int     `json:"month"` // 1 = January
+	Ratings   int     `json:"ratings"`
+	CheckIns  int     `json:"check_ins"`
+	AvgRating float64 `json:"avg_rating,omitempty"`
+	// Activity is ratings plus check-ins relative to the busiest month (0-100).
+	Activity int `json:"activity"`
+}
+
+// Seasonality is a venue's month-by-month activity profile.
+type Seasonality struct {
+	VenueID     string      `json:"venue_id"`
+	Months      []MonthStat `json:"months"`
+	PeakMonth   int         `json:"peak_month,omitempty"`
+	QuietMonth  int         `json:"quiet_month,omitempty"`
+	GeneratedAt time.Time   `json:"generated_at"`
+}
+
+// RetentionJobResult is what one retention job did (or would do, on a dry run).
+type RetentionJobResult struct {
+	Policy   string    `json:"policy"`
+	Job      string    `json:"job"`
+	Cutoff   time.Time `json:"cutoff"`
+	Affected int       `json:"affected"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// UsageCount is request volume for one route, API key or client IP.
+type UsageCount struct {
+	Key       string `json:"key"`
+	Requests  int    `json:"requests"`
+	Throttled int    `json:"throttled"` // 429 responses
+}
+
+// UsageHour is total request volume in one hour.
+type UsageHour struct {
+	Hour      time.Time `json:"hour"`
+	Requests  int       `json:"requests"`
+	Throttled int       `json:"throttled"`
+}
+
+// UsageSummary is the operators' API usage report for a time window.
+type UsageSummary struct {
+	Window    string       `json:"window"`
+	From      time.Time    `json:"from"`
+	To        time.Time    `json:"to"`
+	Requests  int          `json:"requests"`
+	Throttled int          `json:"throttled"`
+	Routes    []UsageCount `json:"routes"`
+	APIKeys   []UsageCount `json:"api_keys"`
+	TopIPs    []UsageCount `json:"top_ips"`
+	Hourly    []UsageHour  `json:"hourly"`
+}
+
+// RatingStoreStats describes how much of the rating store is in memory.
+// Mode is "resident" (every rating loaded) or "lru" (RATING_CACHE_VENUES).
+type RatingStoreStats struct {
+	Mode            string `json:"mode"`
+	ResidentRatings int    `json:"resident_ratings"`
+	ResidentBytes   int64  `json:"resident_bytes"` // rough estimate
+	PendingRatings  int    `json:"pending_ratings"`
+
+	// Only set in "lru" mode
+	CachedVenues     int   `json:"cached_venues,omitempty"`
+	CacheCapacity    int   `json:"cache_capacity,omitempty"`
+	AggregatedVenues int   `json:"aggregated_venues,omitempty"`
+	CacheHits        int64 `json:"cache_hits,omitempty"`
+	CacheMisses      int64 `json:"cache_misses,omitempty"`
+	CacheEvictions   int64 `json:"cache_evictions,omitempty"`
+}
+
+// StoreMetrics is served at /metrics: in-memory store sizes alongside the
+// process heap, so operators can see what is holding memory.
+type StoreMetrics struct {
+	HeapAllocBytes uint64           `json:"heap_alloc_bytes"`
+	HeapSysBytes   uint64           `json:"heap_sys_bytes"`
+	NumGC          uint32           `json:"num_gc"`
+	Goroutines     int              `json:"goroutines"`
+	Ratings        RatingStoreStats `json:"ratings"`
+}
+
+// LoadTiming is how long one startup load took.
+type LoadTiming struct {
+	Name string `json:"name"`
+	Ms   int64  `json:"ms"`
+}
+
+// StartupReport is the startup section of /health. TotalMs is wall time for
+// all loads, which run in parallel where they are independent.
+type StartupReport struct {
+	TotalMs int64        `json:"total_ms"`
+	Loads   []LoadTiming `json:"loads"`
+}
+
+// SchoolDataset is one school data file loaded at startup.
+type SchoolDataset struct {
+	Region  string `json:"region"`
+	Source  string `json:"source"` // file name, or "embedded"
+	Schools int    `json:"schools"`
+	SHA256  string `json:"sha256"`
+}
+
+// StorageInfo says where the running instance keeps its data.
+type StorageInfo struct {
+	Mode         string `json:"mode"` // "postgres" or "memory"
+	ReadReplica  bool   `json:"read_replica,omitempty"`
+	AuthSnapshot bool   `json:"auth_snapshot,omitempty"` // memory mode with AUTH_SNAPSHOT_PATH
+	Sessions     string `json:"sessions"`                // "jwt" or "server"
+	Uploads      string `json:"uploads"`                 // "local" or "s3"
+}
+
+// DatasetVersions identifies the reference data and policies being served.
+type DatasetVersions struct {
+	Schools      []SchoolDataset `json:"schools"`
+	SeedVenues   int             `json:"seed_venues"`
+	TermsVersion string          `json:"terms_version"`
+}
+
+// Status is GET /api/status: what's deployed, what it serves and recent
+// changes, for "what's new" in the frontend and for checking deploys.
+type Status struct {
+	Version       string           `json:"version"`
+	GitSHA        string           `json:"git_sha,omitempty"`
+	BuildTime     string           `json:"build_time,omitempty"`
+	StartedAt     time.Time        `json:"started_at"`
+	UptimeSeconds int64            `json:"uptime_seconds"`
+	Storage       StorageInfo      `json:"storage"`
+	Datasets      DatasetVersions  `json:"datasets"`
+	Changelog     []ChangelogEntry `json:"changelog"` // newest first
+}
+
+// ChangelogEntry is an admin-written "what's new" note.
+type ChangelogEntry struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body,omitempty"`
+	Version     string    `json:"version,omitempty"` // release it shipped in, if any
+	PublishedAt time.Time `json:"published_at"`
+	CreatedByID string    `json:"created_by_id,omitempty"`
+}
+
+// ChangelogRequest creates or replaces a changelog entry. PublishedAt
+// defaults to now.
+type ChangelogRequest struct {
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	Version     string     `json:"version"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// QueryStat is latency for one named database query since startup.
+type QueryStat struct {
+	Name    string  `json:"name"`
+	Calls   int64   `json:"calls"`
+	Errors  int64   `json:"errors"`
+	Slow    int64   `json:"slow"` // calls at or over the slow threshold
+	TotalMs float64 `json:"total_ms"`
+	AvgMs   float64 `json:"avg_ms"`
+	MaxMs   float64 `json:"max_ms"`
+}
+
+// DBPoolStat is a snapshot of the primary connection pool.
+type DBPoolStat struct {
+	TotalConns    int32 `json:"total_conns"`
+	IdleConns     int32 `json:"idle_conns"`
+	AcquiredConns int32 `json:"acquired_conns"`
+	MaxConns      int32 `json:"max_conns"`
+	AcquireCount  int64 `json:"acquire_count"`
+	// EmptyAcquireCount counts acquires that had to wait for a connection
+	EmptyAcquireCount int64 `json:"empty_acquire_count"`
+}
+
+// QueryStats is the operators' view of database query latency.
+type QueryStats struct {
+	SlowThresholdMs int64       `json:"slow_threshold_ms"`
+	Pool            *DBPoolStat `json:"pool,omitempty"` // nil in in-memory mode
+	Queries         []QueryStat `json:"queries"`
+}
+
+// RetentionReport summarizes one pass over all retention jobs.
+type RetentionReport struct {
+	DryRun bool                 `json:"dry_run"`
+	RanAt  time.Time            `json:"ran_at"`
+	Jobs   []RetentionJobResult `json:"jobs"`
+}
+
+// RetentionRunRequest triggers retention jobs from the admin panel. DryRun
+// defaults to true so nothing is deleted by accident.
+type RetentionRunRequest struct {
+	DryRun *bool `json:"dry_run,omitempty"`
+}
+
+// DryRunResult describes what a destructive admin action would change,
+// returned instead of acting when the request has ?dry_run=true. Counts are
+// complete; ID lists may be truncated.
+type DryRunResult struct {
+	DryRun bool                `json:"dry_run"`
+	Action string              `json:"action"`
+	Counts map[string]int      `json:"counts"`
+	IDs    map[string][]string `json:"ids"`
+}
+
+// RecomputeStatus reports the progress of an admin-triggered rebuild of
+// derived aggregates.
+type RecomputeStatus struct {
+	State      string          `json:"state"` // idle, running, done or failed
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+	Completed  int             `json:"completed"`
+	Total      int             `json:"total"`
+	Steps      []RecomputeStep `json:"steps"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// RecomputeStep is one stage of a recompute; Updated counts the records it
+// rewrote.
+type RecomputeStep struct {
+	Name       string `json:"name"`
+	State      string `json:"state"` // pending, running, done or failed
+	Updated    int    `json:"updated,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+type TrendPoint struct {
+	PeriodStart   time.Time `json:"period_start"`
+	Count         int       `json:"count"`
+	AvgScore      float64   `json:"avg_score"`
+	CumulativeAvg float64   `json:"cumulative_avg"`
+}
+
+// NearbyComparison compares a venue to others within a radius.
+type NearbyComparison struct {
+	RadiusKm       float64 `json:"radius_km"`
+	VenueCount     int     `json:"venue_count"`
+	AvgRating      float64 `json:"avg_rating"`
+	AvgRatingCount float64 `json:"avg_rating_count"`
+	Rank           int     `json:"rank"` // 1 = highest rated among nearby venues (including this one)
+}
+
+// RideshareLink is a universal link that opens a rideshare app with the venue as destination.
+type RideshareLink struct {
+	Provider string `json:"provider"` // uber, lyft
+	Label    string `json:"label"`
+	URL      string `json:"url"`
+}
+
+// OpeningHours is one opening window on a day of the week (0 = Sunday).
+// Times are "HH:MM" local time; a Close at or before Open runs past midnight.
+type OpeningHours struct {
+	Day   int    `json:"day"`
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// VenueEvent is an event or drink special posted by a venue owner.
+// Weekly items repeat every week at the same local day and time. Items with
+// a PublishAt are hidden from everyone but the venue's owners until then.
+type VenueEvent struct {
+	ID          string     `json:"id"`
+	VenueID     string     `json:"venue_id"`
+	Kind        string     `json:"kind"` // event, special
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      time.Time  `json:"ends_at"`
+	Weekly      bool       `json:"weekly"`
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	CreatedByID string     `json:"created_by_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CheckIn records a user saying they're at a venue.
+type CheckIn struct {
+	ID        string    `json:"id"`
+	VenueID   string    `json:"venue_id"`
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Verified is set when the user's location was inside the venue's geofence.
+	Verified bool `json:"verified"`
+}
+
+// RatingReminder prompts a user to rate a venue they checked in at.
+type RatingReminder struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	VenueID   string    `json:"venue_id"`
+	VenueName string    `json:"venue_name"`
+	DueAt     time.Time `json:"due_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TonightVenue is one entry in the "where to go tonight" ranking.
+type TonightVenue struct {
+	Venue      Venue   `json:"venue"`
+	DistanceKm float64 `json:"distance_km"`
+	OpenNow    *bool   `json:"open_now"`
+	// ClosesInMinutes is set while the venue is open and has hours on file.
+	ClosesInMinutes *int         `json:"closes_in_minutes"`
+	Specials        []VenueEvent `json:"specials"`
+	Events          []VenueEvent `json:"events"`
+	RecentCheckIns  int          `json:"recent_checkins"`
+	PatioWeather    *bool        `json:"patio_weather,omitempty"`
+	// GameDay is set on home-game days at the venue's school.
+	GameDay bool `json:"game_day,omitempty"`
+	// Status is the owner's posted status for tonight, if any.
+	Status *VenueStatus `json:"status,omitempty"`
+	Score  float64      `json:"score"`
+}
+
+// FootballGame is one game on a school's football schedule.
+type FootballGame struct {
+	ID        string    `json:"id"`
+	SchoolID  string    `json:"school_id"`
+	Opponent  string    `json:"opponent"`
+	Kickoff   time.Time `json:"kickoff"`
+	Home      bool      `json:"home"`
+	Location  string    `json:"location,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FootballGameRequest creates or edits a game. Home defaults to true on
+// create; omitted fields are left unchanged on update.
+type FootballGameRequest struct {
+	Opponent string    `json:"opponent"`
+	Kickoff  time.Time `json:"kickoff"`
+	Home     *bool     `json:"home"`
+	Location string    `json:"location"`
+}
+
+// GameImportResult summarizes a schedule import. Errors lists rows or events
+// that were skipped.
+type GameImportResult struct {
+	Created   int      `json:"created"`
+	Updated   int      `json:"updated"`
+	Unchanged int      `json:"unchanged"`
+	Errors    []string `json:"errors"`
+}
+
+// ReviewImportResult reports which imported reviews became ratings and why
+// the rest were skipped. A dry run reports what would be imported.
+type ReviewImportResult struct {
+	DryRun   bool               `json:"dry_run"`
+	Imported []ReviewImportItem `json:"imported"`
+	Skipped  []ReviewImportItem `json:"skipped"`
+}
+
+// ReviewImportItem is one review from an import file.
+type ReviewImportItem struct {
+	Place     string  `json:"place"`
+	Score     float32 `json:"score"`
+	VenueID   string  `json:"venue_id,omitempty"`
+	VenueName string  `json:"venue_name,omitempty"`
+	RatingID  string  `json:"rating_id,omitempty"`
+	// Pending is set when the review is held for moderation.
+	Pending bool   `json:"pending,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// WeatherConditions is the current weather near a school.
+type WeatherConditions struct {
+	TemperatureC    float64   `json:"temperature_c"`
+	PrecipitationMm float64   `json:"precipitation_mm"`
+	WindKph         float64   `json:"wind_kph"`
+	Wet             bool      `json:"wet"`
+	PatioWeather    bool      `json:"patio_weather"`
+	ObservedAt      time.Time `json:"observed_at"`
+}
+
+// TrendingVenue is a venue ranked by recent, time-of-day weighted activity.
+type TrendingVenue struct {
+	Venue    Venue   `json:"venue"`
+	Score    float64 `json:"score"`
+	Ratings  int     `json:"ratings"`
+	CheckIns int     `json:"checkins"`
+}
+
+// AuditEntry records a moderation or admin action for later review.
+type AuditEntry struct {
+	ID         string                 `json:"id"`
+	ActorID    string                 `json:"actor_id"`
+	ActorName  string                 `json:"actor_name,omitempty"`
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"target_type"`
+	TargetID   string                 `json:"target_id"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// UserVote is one helpful vote a user cast on a review.
+type UserVote struct {
+	RatingID  string `json:"rating_id"`
+	VenueID   string `json:"venue_id,omitempty"`
+	Direction string `json:"direction"` // "up" or "down"
+}
+
+// UserOverview is the read-only support view of an account at
+// GET /api/admin/users/{id}/overview: its profile, what it has posted and
+// voted on, admin actions taken against it and how it signs in. Reports
+// filed and received are left out until there's a report feature.
+type UserOverview struct {
+	User              *User          `json:"user"`
+	Email             string         `json:"email"`
+	RatingCount       int            `json:"rating_count"`
+	Ratings           []Rating       `json:"ratings"` // most recent first
+	FratRatingCount   int            `json:"frat_rating_count"`
+	FratRatings       []FratRating   `json:"frat_ratings"`
+	VotesCast         []UserVote     `json:"votes_cast"`
+	UpvotesReceived   int            `json:"upvotes_received"`
+	DownvotesReceived int            `json:"downvotes_received"`
+	AdminActions      []AuditEntry   `json:"admin_actions"`      // bans, role changes, merges...
+	Logins            []LoginRecord  `json:"logins"`             // most recent first
+	Sessions          []Session      `json:"sessions,omitempty"` // AUTH_SESSIONS=server only
+	LoginLockouts     []LoginLockout `json:"login_lockouts"`     // current throttling of their email
+}
+
+// AuditFilter narrows the admin audit log. Empty fields match everything;
+// Action ending in "." matches every action with that prefix, e.g. "venue.".
+type AuditFilter struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	Since      time.Time
+	Until      time.Time
+}
+
+// RatingAlert flags a sudden burst of ratings on one venue or frat chapter,
+// which usually means review bombing.
+type RatingAlert struct {
+	ID            string     `json:"id"`
+	TargetType    string     `json:"target_type"` // "venue" or "frat"
+	TargetID      string     `json:"target_id"`   // venue ID, or "<school_id>:<frat_name>"
+	TargetName    string     `json:"target_name,omitempty"`
+	Count         int        `json:"count"`
+	WindowMinutes int        `json:"window_minutes"`
+	DetectedAt    time.Time  `json:"detected_at"`
+	FrozenUntil   *time.Time `json:"frozen_until,omitempty"`
+}
+
+// MergeUsersRequest names two accounts belonging to the same person; the
+// older one is kept.
+type MergeUsersRequest struct {
+	UserIDs []string `json:"user_ids"`
+}
+
+// MergeUsersResult reports a completed account merge.
+type MergeUsersResult struct {
+	KeptUser *User          `json:"kept_user"`
+	MergedID string         `json:"merged_id"`
+	Counts   map[string]int `json:"counts"`
+}
+
+// TextCluster groups ratings by different accounts whose review text is
+// near-identical, which usually means one person (or a frat) posting the same
+// review from several accounts.
+type TextCluster struct {
+	ID          string              `json:"id"`
+	Status      string              `json:"status"` // "open" or "dismissed"
+	Authors     int                 `json:"authors"`
+	Members     []TextClusterMember `json:"members"`
+	DetectedAt  time.Time           `json:"detected_at"`
+	DismissedAt *time.Time          `json:"dismissed_at,omitempty"`
+}
+
+// TextClusterMember is one rating in a TextCluster.
+type TextClusterMember struct {
+	RatingID   string    `json:"rating_id"`
+	VenueID    string    `json:"venue_id"`
+	AuthorID   string    `json:"author_id"`
+	AuthorName string    `json:"author_name,omitempty"`
+	Excerpt    string    `json:"excerpt"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Ambassador is a user an admin appointed to represent a school.
+type Ambassador struct {
+	UserID      string    `json:"user_id"`
+	Username    string    `json:"username,omitempty"`
+	SchoolID    string    `json:"school_id"`
+	AppointedBy string    `json:"appointed_by,omitempty"`
+	AppointedAt time.Time `json:"appointed_at"`
+}
+
+type AppointAmbassadorRequest struct {
+	SchoolID string `json:"school_id"`
+}
+
+// AmbassadorStatus is what an ambassador sees about their own appointment.
+type AmbassadorStatus struct {
+	Ambassador
+	VenueQuota      int         `json:"venue_quota"`      // venues per 7 days added without review
+	VenuesRemaining int         `json:"venues_remaining"` // left in the current 7 days
+	Pinned          *PinnedPost `json:"pinned,omitempty"`
+}
+
+// PinnedPost is an ambassador's post of the week on their school's page.
+type PinnedPost struct {
+	ID         string    `json:"id"`
+	SchoolID   string    `json:"school_id"`
+	AuthorID   string    `json:"author_id"`
+	AuthorName string    `json:"author_name,omitempty"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body"`
+	WeekStart  time.Time `json:"week_start"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type PinPostRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// AmbassadorSchoolActivity summarizes ambassador work at one school.
+type AmbassadorSchoolActivity struct {
+	SchoolID       string       `json:"school_id"`
+	SchoolName     string       `json:"school_name,omitempty"`
+	Ambassadors    []Ambassador `json:"ambassadors"`
+	VenuesSeeded   int          `json:"venues_seeded"`
+	VenuesSeeded7d int          `json:"venues_seeded_7d"`
+	PostsPinned    int          `json:"posts_pinned"`
+	LastActiveAt   *time.Time   `json:"last_active_at,omitempty"`
+}
+
+// Badge is a reward for reaching a milestone.
+type Badge struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Referrals int    `json:"referrals"` // signups needed to earn it
+}
+
+// InviteSummary is a user's invite code and what it has earned them.
+type InviteSummary struct {
+	Code      string  `json:"code"`
+	Referrals int     `json:"referrals"`
+	Badges    []Badge `json:"badges"`
+	NextBadge *Badge  `json:"next_badge,omitempty"`
+}
+
+// ReferralLeader is one row of the referral leaderboard.
+type ReferralLeader struct {
+	UserID    string  `json:"user_id"`
+	Username  string  `json:"username"`
+	Referrals int     `json:"referrals"`
+	Badges    []Badge `json:"badges"`
+}
+
+// FreezeRequest temporarily stops new ratings on an alert's target.
+type FreezeRequest struct {
+	Hours int `json:"hours"`
+}
+
+// RatingLock stops new ratings and reviews on a venue or frat chapter, e.g.
+// during an active incident or dispute.
+type RatingLock struct {
+	ID          string     `json:"id"`
+	TargetType  string     `json:"target_type"` // "venue" or "frat"
+	TargetID    string     `json:"target_id"`   // venue ID, or "<school_id>:<frat_name>"
+	TargetName  string     `json:"target_name,omitempty"`
+	Reason      string     `json:"reason"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedByID string     `json:"created_by_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreateRatingLockRequest locks a venue (venue_id) or a frat chapter
+// (school_id + frat_name). Hours is optional; zero locks until removed.
+type CreateRatingLockRequest struct {
+	TargetType string `json:"target_type"`
+	VenueID    string `json:"venue_id,omitempty"`
+	SchoolID   string `json:"school_id,omitempty"`
+	FratName   string `json:"frat_name,omitempty"`
+	Reason     string `json:"reason"`
+	Hours      int    `json:"hours,omitempty"`
+}
+
+// Promotion marks a venue as sponsored at a school for a date range.
+type Promotion struct {
+	ID          string    `json:"id"`
+	VenueID     string    `json:"venue_id"`
+	SchoolID    string    `json:"school_id"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Note        string    `json:"note,omitempty"`
+	CreatedByID string    `json:"created_by_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Photo is an uploaded venue or review photo. It is only public once the
+// moderation pipeline or an admin has approved it.
+type Photo struct {
+	ID          string   `json:"id"`
+	VenueID     string   `json:"venue_id"`
+	RatingID    string   `json:"rating_id,omitempty"`
+	UploaderID  string   `json:"uploader_id"`
+	Status      string   `json:"status"` // "processing", "quarantined", "approved" or "rejected"
+	Flags       []string `json:"flags,omitempty"`
+	NSFWScore   float64  `json:"nsfw_score"`
+	DuplicateOf string   `json:"duplicate_of,omitempty"`
+	// GeotagDistanceKm is how far the photo's GPS EXIF position was from the
+	// venue. The coordinates themselves are never kept.
+	GeotagDistanceKm *float64   `json:"geotag_distance_km,omitempty"`
+	ContentType      string     `json:"content_type"`
+	Width            int        `json:"width"`
+	Height           int        `json:"height"`
+	Size             int        `json:"size"`
+	URL              string     `json:"url,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	ReviewedAt       *time.Time `json:"reviewed_at,omitempty"`
+	ReviewedByID     string     `json:"reviewed_by_id,omitempty"`
+}
+
+// TaxonomyTerm is an admin-managed venue category or review tag.
+type TaxonomyTerm struct {
+	Kind      string    `json:"kind"` // "category" or "tag"
+	Slug      string    `json:"slug"`
+	Label     string    `json:"label"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // --- Request/Response DTOs ---
 
+type TaxonomyTermRequest struct {
+	Slug     string `json:"slug"`
+	Label    string `json:"label"`
+	Position *int   `json:"position,omitempty"`
+}
+
 type CreateVenueRequest struct {
 	Name        string  `json:"name"`
 	Category    string  `json:"category"`
@@ -93,10 +1166,61 @@ type CreateVenueRequest struct {
 	SchoolID    string  `json:"school_id"`
 }
 
+type VenueListRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	SchoolID    string `json:"school_id,omitempty"`
+	Visibility  string `json:"visibility,omitempty"`
+	Items       []struct {
+		VenueID string `json:"venue_id"`
+		Note    string `json:"note,omitempty"`
+	} `json:"items"`
+}
+
+type CrawlRequest struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	SchoolID    string    `json:"school_id"`
+	StartsAt    time.Time `json:"starts_at"`
+	Visibility  string    `json:"visibility,omitempty"`
+	Stops       []struct {
+		VenueID string `json:"venue_id"`
+		Note    string `json:"note,omitempty"`
+	} `json:"stops"`
+}
+
+type CrawlRSVPRequest struct {
+	Status     string `json:"status"`
+	InviteCode string `json:"invite_code,omitempty"`
+}
+
+type GroupSessionRequest struct {
+	SchoolID string   `json:"school_id"`
+	VenueIDs []string `json:"venue_ids,omitempty"`
+}
+
+type GroupVoteRequest struct {
+	VenueID string `json:"venue_id"`
+	Yes     bool   `json:"yes"`
+}
+
+type CreateShareLinkRequest struct {
+	TargetType  string `json:"target_type"`
+	TargetID    string `json:"target_id"`
+	UTMSource   string `json:"utm_source,omitempty"`
+	UTMMedium   string `json:"utm_medium,omitempty"`
+	UTMCampaign string `json:"utm_campaign,omitempty"`
+}
+
 type CreateRatingRequest struct {
-	Score   float32 `json:"score"`
-	Review  string  `json:"review,omitempty"`
-	VenueID string  `json:"venue_id"`
+	Score          float32  `json:"score"`
+	Review         string   `json:"review,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	VenueID        string   `json:"venue_id"`
+	WouldRecommend *bool    `json:"would_recommend,omitempty"`
+	// Imported is set by the review importer, never by clients. Imported
+	// ratings are marked as such and don't count towards the daily limit.
+	Imported bool `json:"-"`
 }
 
 // FratRating represents a user's rating of a fraternity chapter at a specific school.
@@ -119,6 +1243,9 @@ type CreateFratRatingRequest struct {
 // FratWithRating is the enriched response returned for fraternity listings.
 type FratWithRating struct {
 	Name        string  `json:"name"`
+	Chapter     string  `json:"chapter,omitempty"` // e.g. "Beta Theta"
+	FoundedYear int     `json:"founded_year,omitempty"`
+	Status      string  `json:"status"` // active, suspended or banned
 	AvgRating   float64 `json:"avg_rating"`
 	RatingCount int     `json:"rating_count"`
 }
@@ -127,21 +1254,235 @@ type RegisterRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 	Username string `json:"username"`
+
+	// TermsVersion, if it matches the current version, records acceptance at signup.
+	TermsVersion string `json:"terms_version,omitempty"`
+
+	// InviteCode credits the member who shared it with the signup.
+	InviteCode string `json:"invite_code,omitempty"`
+
+	// CaptchaToken is the widget response when CAPTCHA_PROVIDER is set.
+	CaptchaToken string `json:"captcha_token,omitempty"`
+
+	Client SessionClient `json:"-"`
+}
+
+// AvailabilityResponse answers the signup form's username and email checks.
+type AvailabilityResponse struct {
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
 }
 
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+
+	// CaptchaToken is required once the email or IP has repeated failures.
+	CaptchaToken string `json:"captcha_token,omitempty"`
+
+	Client SessionClient `json:"-"`
+}
+
+// SessionClient describes the device a session was started from.
+type SessionClient struct {
+	UserAgent string
+	IP        string
+}
+
+// LoginRecord is one successful password login, listed at
+// GET /api/auth/me/logins so users can spot sign-ins they don't recognize.
+type LoginRecord struct {
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Session is a server-side login session (AUTH_SESSIONS=server).
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id,omitempty"`
+	Username   string    `json:"username,omitempty"`
+	Role       string    `json:"-"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current,omitempty"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ChangePasswordRequest changes a signed-in user's password.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+
+	Client SessionClient `json:"-"`
+}
+
+// StudentVerificationRequest starts .edu verification. SchoolID picks the
+// school when several share the email's domain.
+type StudentVerificationRequest struct {
+	Email    string `json:"email"`
+	SchoolID string `json:"school_id,omitempty"`
+}
+
+// GoogleLoginRequest signs in with an ID token from Google Identity
+// Services. Username and TermsVersion are only used when the token creates a
+// new account.
+type GoogleLoginRequest struct {
+	IDToken      string `json:"id_token"`
+	Username     string `json:"username,omitempty"`
+	TermsVersion string `json:"terms_version,omitempty"`
+
+	// InviteCode and CaptchaToken are checked as in RegisterRequest when the
+	// sign-in creates a new account.
+	InviteCode   string `json:"invite_code,omitempty"`
+	CaptchaToken string `json:"captcha_token,omitempty"`
+
+	Client SessionClient `json:"-"`
+}
+
+// LinkRequiredResponse is returned with 409 when a Google account's email
+// belongs to an existing password account. Posting the link token with that
+// account's password to /api/auth/google/link connects the two.
+type LinkRequiredResponse struct {
+	Error     string    `json:"error"` // "link_required"
+	Message   string    `json:"message"`
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	LinkToken string    `json:"link_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ConfirmLinkRequest proves ownership of the existing account so a pending
+// sign-in identity can be attached to it.
+type ConfirmLinkRequest struct {
+	LinkToken string `json:"link_token"`
+	Password  string `json:"password"`
+
+	Client SessionClient `json:"-"`
+}
+
+// LinkIdentityRequest attaches a Google account to the signed-in user.
+// Password is required when the account has one.
+type LinkIdentityRequest struct {
+	IDToken  string `json:"id_token"`
+	Password string `json:"password,omitempty"`
+
+	Client SessionClient `json:"-"`
+}
+
+// SetPasswordRequest adds a password to an account created through Google.
+// IDToken is a fresh Google sign-in for a Google account already linked to
+// it, so a stolen session alone can't add a password.
+type SetPasswordRequest struct {
+	IDToken  string `json:"id_token"`
+	Password string `json:"password"`
+}
+
+// Identity is an external sign-in (e.g. Google) attached to an account.
+type Identity struct {
+	ID        string    `json:"id"`
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Subject string `json:"-"` // the provider's user ID
+	UserID  string `json:"-"`
+}
+
+// IdentitiesResponse lists the ways a user can sign in.
+type IdentitiesResponse struct {
+	Password   bool       `json:"password"`
+	Identities []Identity `json:"identities"`
+}
+
+// ChangeEmailRequest starts moving an account to a new email address.
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email"`
+	Password string `json:"password"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token"`
+}
+
+type ConfirmStudentRequest struct {
+	Token string `json:"token"`
+}
+
+// LoginErrorResponse is the body of a failed login. RemainingAttempts is
+// how many more failures are allowed before a lockout.
+type LoginErrorResponse struct {
+	Error             string `json:"error"`
+	Message           string `json:"message,omitempty"`
+	RemainingAttempts *int   `json:"remaining_attempts,omitempty"`
+	RetryAfterSeconds int    `json:"retry_after_seconds,omitempty"`
+	Locked            bool   `json:"locked,omitempty"`
+	CaptchaRequired   bool   `json:"captcha_required,omitempty"`
+}
+
+// CaptchaConfig tells the frontend whether and how to render a CAPTCHA
+// widget. Logins need one after LoginAfter failures.
+type CaptchaConfig struct {
+	Enabled    bool   `json:"enabled"`
+	Provider   string `json:"provider,omitempty"` // "turnstile" or "hcaptcha"
+	SiteKey    string `json:"site_key,omitempty"`
+	LoginAfter int    `json:"login_after,omitempty"`
+}
+
+// PasswordIssue is one reason a new password was rejected. Codes are
+// stable so the frontend can pick its own wording.
+type PasswordIssue struct {
+	Code    string `json:"code"` // too_short, common, personal_info, predictable or weak
+	Message string `json:"message"`
+}
+
+// PasswordErrorResponse is the body when a new password is too weak. Score
+// is the estimated strength from 0 (trivial) to 4 (strong).
+type PasswordErrorResponse struct {
+	Error       string          `json:"error"`
+	Message     string          `json:"message"`
+	Score       int             `json:"score"`
+	Issues      []PasswordIssue `json:"issues"`
+	Suggestions []string        `json:"suggestions"`
+}
+
+// LoginLockout is an email or client IP whose logins are being refused.
+type LoginLockout struct {
+	Kind     string    `json:"kind"` // "email" or "ip"
+	Value    string    `json:"value"`
+	Failures int       `json:"failures"` // since the last lockout
+	Lockouts int       `json:"lockouts"`
+	Locked   bool      `json:"locked"` // false while only backing off
+	Until    time.Time `json:"until"`
+}
+
+type ClearLoginLockoutRequest struct {
+	Email string `json:"email,omitempty"`
+	IP    string `json:"ip,omitempty"`
 }
 
 type AuthResponse struct {
 	Token string `json:"token"`
 	User  *User  `json:"user"`
+	// CSRFToken mirrors the csrf_token cookie for clients that can't read it
+	CSRFToken string `json:"csrf_token,omitempty"`
 }
 
 type SchoolSearchParams struct {
 	Query   string  `json:"query,omitempty"`
 	State   string  `json:"state,omitempty"`
+	Country string  `json:"country,omitempty"`
 	Control string  `json:"control,omitempty"` // "public" or "private_nonprofit"
 	ICLevel int     `json:"iclevel,omitempty"` // 1 = 4-year, 2 = 2-year, 3 = less-than-2-year; 0 = all
 	Sort    string  `json:"sort,omitempty"`    // "venue_count", "name"
@@ -153,12 +1494,215 @@ type SchoolSearchParams struct {
 	MaxLng  float64 `json:"max_lng,omitempty"`
 }
 
+// Region is a country the platform serves schools in.
+type Region struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	SchoolCount int    `json:"school_count"`
+}
+
+// Heatmap is gridded activity for a map heat layer. Cells are the centers of
+// non-empty grid squares CellDeg degrees wide; Intensity is Value / Max.
+type Heatmap struct {
+	Metric  string     `json:"metric"`
+	Zoom    int        `json:"zoom"`
+	CellDeg float64    `json:"cell_deg"`
+	Max     int        `json:"max"`
+	Cells   []HeatCell `json:"cells"`
+}
+
+type HeatCell struct {
+	Lat       float64 `json:"lat"`
+	Lng       float64 `json:"lng"`
+	Value     int     `json:"value"`
+	Intensity float64 `json:"intensity"`
+}
+
+// CityPage combines every school and venue in a city. Schools are ranked by
+// party score; Leaderboard ranks the city's rated venues.
+type CityPage struct {
+	State       string          `json:"state"`
+	Slug        string          `json:"slug"`
+	Name        string          `json:"name"`
+	AvgRating   float64         `json:"avg_rating"`
+	RatingCount int             `json:"rating_count"`
+	Schools     []SchoolSummary `json:"schools"`
+	Venues      []Venue         `json:"venues"`
+	Leaderboard []Venue         `json:"leaderboard"`
+}
+
+// SchoolSummary is a compact school record for lists such as nearby schools.
+type SchoolSummary struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	City       string       `json:"city"`
+	State      string       `json:"state"`
+	Country    string       `json:"country"`
+	Latitude   float64      `json:"latitude"`
+	Longitude  float64      `json:"longitude"`
+	VenueCount int          `json:"venue_count"`
+	AvgRating  float64      `json:"avg_rating"`
+	PartyScore int          `json:"party_score"`
+	Conference string       `json:"conference,omitempty"`
+	Links      []SchoolLink `json:"links,omitempty"`
+	DistanceKm *float64     `json:"distance_km,omitempty"`
+}
+
+// SchoolLink is a nightlife-related account or site for a school, e.g. a
+// party Instagram or the local Barstool affiliate.
+type SchoolLink struct {
+	ID    string `json:"id"`
+	Kind  string `json:"kind"` // "instagram", "tiktok", "x", "barstool" or "website"
+	Label string `json:"label,omitempty"`
+	URL   string `json:"url"`
+}
+
+type CreateSchoolLinkRequest struct {
+	Kind  string `json:"kind"`
+	Label string `json:"label,omitempty"`
+	URL   string `json:"url"`
+}
+
+// Bootstrap bundles what the mobile apps need on cold start.
+type Bootstrap struct {
+	User          *User           `json:"user"`
+	HomeSchool    *SchoolSummary  `json:"home_school"`
+	NearbySchools []SchoolSummary `json:"nearby_schools"`
+	Features      map[string]bool `json:"features"`
+	Categories    []TaxonomyTerm  `json:"categories"`
+	Tags          []TaxonomyTerm  `json:"tags"`
+	Regions       []Region        `json:"regions"`
+	TermsVersion  string          `json:"terms_version"`
+}
+
 type PaginatedResponse struct {
 	Data       interface{} `json:"data"`
 	Total      int         `json:"total"`
 	Page       int         `json:"page"`
 	Limit      int         `json:"limit"`
 	TotalPages int         `json:"total_pages"`
+	MaxLimit   int         `json:"max_limit,omitempty"`
+}
+
+// SchoolVenuesResponse is the school venue listing: organic, paginated results
+// plus any currently sponsored venues in their own field.
+type SchoolVenuesResponse struct {
+	PaginatedResponse
+	Sponsored []Venue `json:"sponsored"`
+}
+
+// SchoolDetail is a school with the related lists requested through
+// ?include=. Lists that weren't requested are omitted.
+type SchoolDetail struct {
+	*School
+	// GameDay is set on home-game days, with the game.
+	GameDay      bool               `json:"game_day,omitempty"`
+	Game         *FootballGame      `json:"game,omitempty"`
+	Venues       *PaginatedResponse `json:"venues,omitempty"`
+	Ratings      *PaginatedResponse `json:"ratings,omitempty"`
+	Fraternities *PaginatedResponse `json:"fraternities,omitempty"`
+}
+
+// VenueDetail is a venue with the related data requested through ?include=.
+type VenueDetail struct {
+	Venue
+	Ratings *PaginatedResponse `json:"ratings,omitempty"`
+	School  *SchoolDetail      `json:"school,omitempty"`
+}
+
+type CreatePromotionRequest struct {
+	VenueID  string    `json:"venue_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Note     string    `json:"note,omitempty"`
+}
+
+type CreateVenueEventRequest struct {
+	Kind        string     `json:"kind"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      time.Time  `json:"ends_at"`
+	Weekly      bool       `json:"weekly"`
+	PublishAt   *time.Time `json:"publish_at,omitempty"` // hide until then; omitted or past publishes now
+}
+
+// CreateVenueEventsRequest is the body of POST /api/owner/venues/{id}/events/batch.
+type CreateVenueEventsRequest struct {
+	Events []CreateVenueEventRequest `json:"events"`
+}
+
+// CheckInRequest optionally carries the user's location so the check-in can
+// be verified against the venue's geofence.
+type CheckInRequest struct {
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+type VenueStatsRequest struct {
+	VenueIDs []string `json:"venue_ids"`
+}
+
+type ConfirmAgeRequest struct {
+	Jurisdiction string `json:"jurisdiction"`
+}
+
+type AcceptTermsRequest struct {
+	Version string `json:"version"`
+}
+
+// UpdateProfileRequest edits the public profile. Omitted fields are left
+// unchanged; empty strings clear them.
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"display_name"`
+	AvatarURL   *string `json:"avatar_url"`
+	Bio         *string `json:"bio"`
+}
+
+// Email digest frequencies. Digests only go out weekly.
+const (
+	DigestOff    = "off"
+	DigestWeekly = "weekly"
+)
+
+// UserPreferences controls what mail and notifications a user gets. It's
+// stored as one JSON blob per user, so keys can be added without a
+// migration; keys missing from the stored blob take their defaults.
+type UserPreferences struct {
+	EmailDigest        string `json:"email_digest"` // off or weekly
+	ReplyNotifications bool   `json:"reply_notifications"`
+	MarketingOptOut    bool   `json:"marketing_opt_out"`
+}
+
+// UpdatePreferencesRequest changes some preferences; omitted fields are left
+// unchanged.
+type UpdatePreferencesRequest struct {
+	EmailDigest        *string `json:"email_digest,omitempty"`
+	ReplyNotifications *bool   `json:"reply_notifications,omitempty"`
+	MarketingOptOut    *bool   `json:"marketing_opt_out,omitempty"`
+}
+
+// SetHomeSchoolRequest is the onboarding step after registration. An empty
+// school_id or zero grad_year clears that field.
+type SetHomeSchoolRequest struct {
+	SchoolID string `json:"school_id"`
+	GradYear int    `json:"grad_year,omitempty"`
+}
+
+// GreekOptOutRequest hides (opt_out: true) or restores a school's Greek life.
+type GreekOptOutRequest struct {
+	OptOut bool `json:"opt_out"`
+}
+
+// ChapterStatusRequest sets a chapter's status (admin only).
+type ChapterStatusRequest struct {
+	SchoolID string `json:"school_id"`
+	FratName string `json:"frat_name"`
+	Status   string `json:"status"`
+}
+
+type RedactRequest struct {
+	Terms []string `json:"terms"`
 }
 
 type ErrorResponse struct {
@@ -0,0 +1,3 @@
+package ratemybarsv1
+
+//go:generate protoc -I ../../../proto --go_out=../../.. --go_opt=module=github.com/ratemybars/backend --go-grpc_out=../../.. --go-grpc_opt=module=github.com/ratemybars/backend ratemybars/v1/ratemybars.proto
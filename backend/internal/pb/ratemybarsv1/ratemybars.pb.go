@@ -0,0 +1,1420 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: ratemybars/v1/ratemybars.proto
+
+// Read-side API for internal tooling and the mobile backend-for-frontend.
+// Messages mirror the JSON models in internal/model; field names match their
+// JSON tags. Regenerate the Go code with `go generate ./internal/pb/...`.
+
+package ratemybarsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// School is model.School.
+type School struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Unitid             int32                  `protobuf:"varint,2,opt,name=unitid,proto3" json:"unitid,omitempty"`
+	Name               string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	AliasName          string                 `protobuf:"bytes,4,opt,name=alias_name,json=aliasName,proto3" json:"alias_name,omitempty"`
+	Address            string                 `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	City               string                 `protobuf:"bytes,6,opt,name=city,proto3" json:"city,omitempty"`
+	State              string                 `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	Zip                string                 `protobuf:"bytes,8,opt,name=zip,proto3" json:"zip,omitempty"`
+	Country            string                 `protobuf:"bytes,9,opt,name=country,proto3" json:"country,omitempty"`
+	Control            string                 `protobuf:"bytes,10,opt,name=control,proto3" json:"control,omitempty"`
+	Iclevel            int32                  `protobuf:"varint,11,opt,name=iclevel,proto3" json:"iclevel,omitempty"`
+	Website            string                 `protobuf:"bytes,12,opt,name=website,proto3" json:"website,omitempty"`
+	Latitude           float64                `protobuf:"fixed64,13,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude          float64                `protobuf:"fixed64,14,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	County             string                 `protobuf:"bytes,15,opt,name=county,proto3" json:"county,omitempty"`
+	Locale             int32                  `protobuf:"varint,16,opt,name=locale,proto3" json:"locale,omitempty"`
+	Hbcu               bool                   `protobuf:"varint,17,opt,name=hbcu,proto3" json:"hbcu,omitempty"`
+	IsOnline           bool                   `protobuf:"varint,18,opt,name=is_online,json=isOnline,proto3" json:"is_online,omitempty"`
+	Instsize           int32                  `protobuf:"varint,19,opt,name=instsize,proto3" json:"instsize,omitempty"`
+	IsTribal           bool                   `protobuf:"varint,20,opt,name=is_tribal,json=isTribal,proto3" json:"is_tribal,omitempty"`
+	IsReligious        bool                   `protobuf:"varint,21,opt,name=is_religious,json=isReligious,proto3" json:"is_religious,omitempty"`
+	IsCommunityCollege bool                   `protobuf:"varint,22,opt,name=is_community_college,json=isCommunityCollege,proto3" json:"is_community_college,omitempty"`
+	IsLiberalArts      bool                   `protobuf:"varint,23,opt,name=is_liberal_arts,json=isLiberalArts,proto3" json:"is_liberal_arts,omitempty"`
+	IsGraduateOnly     bool                   `protobuf:"varint,24,opt,name=is_graduate_only,json=isGraduateOnly,proto3" json:"is_graduate_only,omitempty"`
+	VenueCount         int32                  `protobuf:"varint,25,opt,name=venue_count,json=venueCount,proto3" json:"venue_count,omitempty"`
+	FratCount          int32                  `protobuf:"varint,26,opt,name=frat_count,json=fratCount,proto3" json:"frat_count,omitempty"`
+	AvgRating          float64                `protobuf:"fixed64,27,opt,name=avg_rating,json=avgRating,proto3" json:"avg_rating,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *School) Reset() {
+	*x = School{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *School) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*School) ProtoMessage() {}
+
+func (x *School) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use School.ProtoReflect.Descriptor instead.
+func (*School) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *School) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *School) GetUnitid() int32 {
+	if x != nil {
+		return x.Unitid
+	}
+	return 0
+}
+
+func (x *School) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *School) GetAliasName() string {
+	if x != nil {
+		return x.AliasName
+	}
+	return ""
+}
+
+func (x *School) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *School) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *School) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *School) GetZip() string {
+	if x != nil {
+		return x.Zip
+	}
+	return ""
+}
+
+func (x *School) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *School) GetControl() string {
+	if x != nil {
+		return x.Control
+	}
+	return ""
+}
+
+func (x *School) GetIclevel() int32 {
+	if x != nil {
+		return x.Iclevel
+	}
+	return 0
+}
+
+func (x *School) GetWebsite() string {
+	if x != nil {
+		return x.Website
+	}
+	return ""
+}
+
+func (x *School) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *School) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *School) GetCounty() string {
+	if x != nil {
+		return x.County
+	}
+	return ""
+}
+
+func (x *School) GetLocale() int32 {
+	if x != nil {
+		return x.Locale
+	}
+	return 0
+}
+
+func (x *School) GetHbcu() bool {
+	if x != nil {
+		return x.Hbcu
+	}
+	return false
+}
+
+func (x *School) GetIsOnline() bool {
+	if x != nil {
+		return x.IsOnline
+	}
+	return false
+}
+
+func (x *School) GetInstsize() int32 {
+	if x != nil {
+		return x.Instsize
+	}
+	return 0
+}
+
+func (x *School) GetIsTribal() bool {
+	if x != nil {
+		return x.IsTribal
+	}
+	return false
+}
+
+func (x *School) GetIsReligious() bool {
+	if x != nil {
+		return x.IsReligious
+	}
+	return false
+}
+
+func (x *School) GetIsCommunityCollege() bool {
+	if x != nil {
+		return x.IsCommunityCollege
+	}
+	return false
+}
+
+func (x *School) GetIsLiberalArts() bool {
+	if x != nil {
+		return x.IsLiberalArts
+	}
+	return false
+}
+
+func (x *School) GetIsGraduateOnly() bool {
+	if x != nil {
+		return x.IsGraduateOnly
+	}
+	return false
+}
+
+func (x *School) GetVenueCount() int32 {
+	if x != nil {
+		return x.VenueCount
+	}
+	return 0
+}
+
+func (x *School) GetFratCount() int32 {
+	if x != nil {
+		return x.FratCount
+	}
+	return 0
+}
+
+func (x *School) GetAvgRating() float64 {
+	if x != nil {
+		return x.AvgRating
+	}
+	return 0
+}
+
+// OpeningHours is model.OpeningHours.
+type OpeningHours struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           int32                  `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	Open          string                 `protobuf:"bytes,2,opt,name=open,proto3" json:"open,omitempty"`
+	Close         string                 `protobuf:"bytes,3,opt,name=close,proto3" json:"close,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpeningHours) Reset() {
+	*x = OpeningHours{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpeningHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpeningHours) ProtoMessage() {}
+
+func (x *OpeningHours) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpeningHours.ProtoReflect.Descriptor instead.
+func (*OpeningHours) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OpeningHours) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *OpeningHours) GetOpen() string {
+	if x != nil {
+		return x.Open
+	}
+	return ""
+}
+
+func (x *OpeningHours) GetClose() string {
+	if x != nil {
+		return x.Close
+	}
+	return ""
+}
+
+// Venue is model.Venue.
+type Venue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Address       string                 `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,6,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,7,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	SchoolId      string                 `protobuf:"bytes,8,opt,name=school_id,json=schoolId,proto3" json:"school_id,omitempty"`
+	SchoolName    string                 `protobuf:"bytes,9,opt,name=school_name,json=schoolName,proto3" json:"school_name,omitempty"`
+	CreatedById   string                 `protobuf:"bytes,10,opt,name=created_by_id,json=createdById,proto3" json:"created_by_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Verified      bool                   `protobuf:"varint,12,opt,name=verified,proto3" json:"verified,omitempty"`
+	Hours         []*OpeningHours        `protobuf:"bytes,13,rep,name=hours,proto3" json:"hours,omitempty"`
+	AvgRating     float64                `protobuf:"fixed64,14,opt,name=avg_rating,json=avgRating,proto3" json:"avg_rating,omitempty"`
+	RatingCount   int32                  `protobuf:"varint,15,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	ThumbsUp      int32                  `protobuf:"varint,16,opt,name=thumbs_up,json=thumbsUp,proto3" json:"thumbs_up,omitempty"`
+	ThumbsDown    int32                  `protobuf:"varint,17,opt,name=thumbs_down,json=thumbsDown,proto3" json:"thumbs_down,omitempty"`
+	RecommendPct  *int32                 `protobuf:"varint,18,opt,name=recommend_pct,json=recommendPct,proto3,oneof" json:"recommend_pct,omitempty"` // unset until the venue has been rated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Venue) Reset() {
+	*x = Venue{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Venue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Venue) ProtoMessage() {}
+
+func (x *Venue) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Venue.ProtoReflect.Descriptor instead.
+func (*Venue) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Venue) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Venue) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Venue) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Venue) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Venue) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Venue) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Venue) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Venue) GetSchoolId() string {
+	if x != nil {
+		return x.SchoolId
+	}
+	return ""
+}
+
+func (x *Venue) GetSchoolName() string {
+	if x != nil {
+		return x.SchoolName
+	}
+	return ""
+}
+
+func (x *Venue) GetCreatedById() string {
+	if x != nil {
+		return x.CreatedById
+	}
+	return ""
+}
+
+func (x *Venue) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Venue) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *Venue) GetHours() []*OpeningHours {
+	if x != nil {
+		return x.Hours
+	}
+	return nil
+}
+
+func (x *Venue) GetAvgRating() float64 {
+	if x != nil {
+		return x.AvgRating
+	}
+	return 0
+}
+
+func (x *Venue) GetRatingCount() int32 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *Venue) GetThumbsUp() int32 {
+	if x != nil {
+		return x.ThumbsUp
+	}
+	return 0
+}
+
+func (x *Venue) GetThumbsDown() int32 {
+	if x != nil {
+		return x.ThumbsDown
+	}
+	return 0
+}
+
+func (x *Venue) GetRecommendPct() int32 {
+	if x != nil && x.RecommendPct != nil {
+		return *x.RecommendPct
+	}
+	return 0
+}
+
+// Rating is the public form of model.Rating.
+type Rating struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Score         float32                `protobuf:"fixed32,2,opt,name=score,proto3" json:"score,omitempty"`
+	Review        string                 `protobuf:"bytes,3,opt,name=review,proto3" json:"review,omitempty"`
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	VenueId       string                 `protobuf:"bytes,5,opt,name=venue_id,json=venueId,proto3" json:"venue_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,6,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	AuthorName    string                 `protobuf:"bytes,7,opt,name=author_name,json=authorName,proto3" json:"author_name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Upvotes       int32                  `protobuf:"varint,9,opt,name=upvotes,proto3" json:"upvotes,omitempty"`
+	Downvotes     int32                  `protobuf:"varint,10,opt,name=downvotes,proto3" json:"downvotes,omitempty"`
+	Reactions     map[string]int32       `protobuf:"bytes,11,rep,name=reactions,proto3" json:"reactions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Redacted      bool                   `protobuf:"varint,12,opt,name=redacted,proto3" json:"redacted,omitempty"`
+	VerifiedVisit bool                   `protobuf:"varint,13,opt,name=verified_visit,json=verifiedVisit,proto3" json:"verified_visit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rating) Reset() {
+	*x = Rating{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rating) ProtoMessage() {}
+
+func (x *Rating) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rating.ProtoReflect.Descriptor instead.
+func (*Rating) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Rating) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Rating) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Rating) GetReview() string {
+	if x != nil {
+		return x.Review
+	}
+	return ""
+}
+
+func (x *Rating) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Rating) GetVenueId() string {
+	if x != nil {
+		return x.VenueId
+	}
+	return ""
+}
+
+func (x *Rating) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *Rating) GetAuthorName() string {
+	if x != nil {
+		return x.AuthorName
+	}
+	return ""
+}
+
+func (x *Rating) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Rating) GetUpvotes() int32 {
+	if x != nil {
+		return x.Upvotes
+	}
+	return 0
+}
+
+func (x *Rating) GetDownvotes() int32 {
+	if x != nil {
+		return x.Downvotes
+	}
+	return 0
+}
+
+func (x *Rating) GetReactions() map[string]int32 {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *Rating) GetRedacted() bool {
+	if x != nil {
+		return x.Redacted
+	}
+	return false
+}
+
+func (x *Rating) GetVerifiedVisit() bool {
+	if x != nil {
+		return x.VerifiedVisit
+	}
+	return false
+}
+
+// Page mirrors the paging fields of model.PaginatedResponse.
+type Page struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Page) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Page) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Page) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Page) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type SearchSchoolsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Control       string                 `protobuf:"bytes,4,opt,name=control,proto3" json:"control,omitempty"`
+	Iclevel       int32                  `protobuf:"varint,5,opt,name=iclevel,proto3" json:"iclevel,omitempty"`
+	Sort          string                 `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`
+	Page          int32                  `protobuf:"varint,7,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSchoolsRequest) Reset() {
+	*x = SearchSchoolsRequest{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSchoolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSchoolsRequest) ProtoMessage() {}
+
+func (x *SearchSchoolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSchoolsRequest.ProtoReflect.Descriptor instead.
+func (*SearchSchoolsRequest) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchSchoolsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchSchoolsRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SearchSchoolsRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SearchSchoolsRequest) GetControl() string {
+	if x != nil {
+		return x.Control
+	}
+	return ""
+}
+
+func (x *SearchSchoolsRequest) GetIclevel() int32 {
+	if x != nil {
+		return x.Iclevel
+	}
+	return 0
+}
+
+func (x *SearchSchoolsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *SearchSchoolsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchSchoolsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchSchoolsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schools       []*School              `protobuf:"bytes,1,rep,name=schools,proto3" json:"schools,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSchoolsResponse) Reset() {
+	*x = SearchSchoolsResponse{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSchoolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSchoolsResponse) ProtoMessage() {}
+
+func (x *SearchSchoolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSchoolsResponse.ProtoReflect.Descriptor instead.
+func (*SearchSchoolsResponse) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SearchSchoolsResponse) GetSchools() []*School {
+	if x != nil {
+		return x.Schools
+	}
+	return nil
+}
+
+func (x *SearchSchoolsResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type GetSchoolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSchoolRequest) Reset() {
+	*x = GetSchoolRequest{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchoolRequest) ProtoMessage() {}
+
+func (x *GetSchoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchoolRequest.ProtoReflect.Descriptor instead.
+func (*GetSchoolRequest) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetSchoolRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetVenueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVenueRequest) Reset() {
+	*x = GetVenueRequest{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVenueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVenueRequest) ProtoMessage() {}
+
+func (x *GetVenueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVenueRequest.ProtoReflect.Descriptor instead.
+func (*GetVenueRequest) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetVenueRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListSchoolVenuesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchoolId      string                 `protobuf:"bytes,1,opt,name=school_id,json=schoolId,proto3" json:"school_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchoolVenuesRequest) Reset() {
+	*x = ListSchoolVenuesRequest{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchoolVenuesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchoolVenuesRequest) ProtoMessage() {}
+
+func (x *ListSchoolVenuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchoolVenuesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchoolVenuesRequest) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListSchoolVenuesRequest) GetSchoolId() string {
+	if x != nil {
+		return x.SchoolId
+	}
+	return ""
+}
+
+func (x *ListSchoolVenuesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSchoolVenuesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListSchoolVenuesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Venues        []*Venue               `protobuf:"bytes,1,rep,name=venues,proto3" json:"venues,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchoolVenuesResponse) Reset() {
+	*x = ListSchoolVenuesResponse{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchoolVenuesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchoolVenuesResponse) ProtoMessage() {}
+
+func (x *ListSchoolVenuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchoolVenuesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchoolVenuesResponse) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListSchoolVenuesResponse) GetVenues() []*Venue {
+	if x != nil {
+		return x.Venues
+	}
+	return nil
+}
+
+func (x *ListSchoolVenuesResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListVenueRatingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VenueId       string                 `protobuf:"bytes,1,opt,name=venue_id,json=venueId,proto3" json:"venue_id,omitempty"`
+	Sort          string                 `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"` // "helpful", "most_reacted" or empty for newest
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVenueRatingsRequest) Reset() {
+	*x = ListVenueRatingsRequest{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVenueRatingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVenueRatingsRequest) ProtoMessage() {}
+
+func (x *ListVenueRatingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVenueRatingsRequest.ProtoReflect.Descriptor instead.
+func (*ListVenueRatingsRequest) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListVenueRatingsRequest) GetVenueId() string {
+	if x != nil {
+		return x.VenueId
+	}
+	return ""
+}
+
+func (x *ListVenueRatingsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListVenueRatingsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListVenueRatingsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListVenueRatingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ratings       []*Rating              `protobuf:"bytes,1,rep,name=ratings,proto3" json:"ratings,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVenueRatingsResponse) Reset() {
+	*x = ListVenueRatingsResponse{}
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVenueRatingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVenueRatingsResponse) ProtoMessage() {}
+
+func (x *ListVenueRatingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ratemybars_v1_ratemybars_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVenueRatingsResponse.ProtoReflect.Descriptor instead.
+func (*ListVenueRatingsResponse) Descriptor() ([]byte, []int) {
+	return file_ratemybars_v1_ratemybars_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListVenueRatingsResponse) GetRatings() []*Rating {
+	if x != nil {
+		return x.Ratings
+	}
+	return nil
+}
+
+func (x *ListVenueRatingsResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+var File_ratemybars_v1_ratemybars_proto protoreflect.FileDescriptor
+
+const file_ratemybars_v1_ratemybars_proto_rawDesc = "" +
+	"\n" +
+	"\x1eratemybars/v1/ratemybars.proto\x12\rratemybars.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfb\x05\n" +
+	"\x06School\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06unitid\x18\x02 \x01(\x05R\x06unitid\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"alias_name\x18\x04 \x01(\tR\taliasName\x12\x18\n" +
+	"\aaddress\x18\x05 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04city\x18\x06 \x01(\tR\x04city\x12\x14\n" +
+	"\x05state\x18\a \x01(\tR\x05state\x12\x10\n" +
+	"\x03zip\x18\b \x01(\tR\x03zip\x12\x18\n" +
+	"\acountry\x18\t \x01(\tR\acountry\x12\x18\n" +
+	"\acontrol\x18\n" +
+	" \x01(\tR\acontrol\x12\x18\n" +
+	"\aiclevel\x18\v \x01(\x05R\aiclevel\x12\x18\n" +
+	"\awebsite\x18\f \x01(\tR\awebsite\x12\x1a\n" +
+	"\blatitude\x18\r \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x0e \x01(\x01R\tlongitude\x12\x16\n" +
+	"\x06county\x18\x0f \x01(\tR\x06county\x12\x16\n" +
+	"\x06locale\x18\x10 \x01(\x05R\x06locale\x12\x12\n" +
+	"\x04hbcu\x18\x11 \x01(\bR\x04hbcu\x12\x1b\n" +
+	"\tis_online\x18\x12 \x01(\bR\bisOnline\x12\x1a\n" +
+	"\binstsize\x18\x13 \x01(\x05R\binstsize\x12\x1b\n" +
+	"\tis_tribal\x18\x14 \x01(\bR\bisTribal\x12!\n" +
+	"\fis_religious\x18\x15 \x01(\bR\visReligious\x120\n" +
+	"\x14is_community_college\x18\x16 \x01(\bR\x12isCommunityCollege\x12&\n" +
+	"\x0fis_liberal_arts\x18\x17 \x01(\bR\risLiberalArts\x12(\n" +
+	"\x10is_graduate_only\x18\x18 \x01(\bR\x0eisGraduateOnly\x12\x1f\n" +
+	"\vvenue_count\x18\x19 \x01(\x05R\n" +
+	"venueCount\x12\x1d\n" +
+	"\n" +
+	"frat_count\x18\x1a \x01(\x05R\tfratCount\x12\x1d\n" +
+	"\n" +
+	"avg_rating\x18\x1b \x01(\x01R\tavgRating\"J\n" +
+	"\fOpeningHours\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x05R\x03day\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\tR\x04open\x12\x14\n" +
+	"\x05close\x18\x03 \x01(\tR\x05close\"\xe5\x04\n" +
+	"\x05Venue\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x18\n" +
+	"\aaddress\x18\x05 \x01(\tR\aaddress\x12\x1a\n" +
+	"\blatitude\x18\x06 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\a \x01(\x01R\tlongitude\x12\x1b\n" +
+	"\tschool_id\x18\b \x01(\tR\bschoolId\x12\x1f\n" +
+	"\vschool_name\x18\t \x01(\tR\n" +
+	"schoolName\x12\"\n" +
+	"\rcreated_by_id\x18\n" +
+	" \x01(\tR\vcreatedById\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1a\n" +
+	"\bverified\x18\f \x01(\bR\bverified\x121\n" +
+	"\x05hours\x18\r \x03(\v2\x1b.ratemybars.v1.OpeningHoursR\x05hours\x12\x1d\n" +
+	"\n" +
+	"avg_rating\x18\x0e \x01(\x01R\tavgRating\x12!\n" +
+	"\frating_count\x18\x0f \x01(\x05R\vratingCount\x12\x1b\n" +
+	"\tthumbs_up\x18\x10 \x01(\x05R\bthumbsUp\x12\x1f\n" +
+	"\vthumbs_down\x18\x11 \x01(\x05R\n" +
+	"thumbsDown\x12(\n" +
+	"\rrecommend_pct\x18\x12 \x01(\x05H\x00R\frecommendPct\x88\x01\x01B\x10\n" +
+	"\x0e_recommend_pct\"\xeb\x03\n" +
+	"\x06Rating\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x02R\x05score\x12\x16\n" +
+	"\x06review\x18\x03 \x01(\tR\x06review\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x19\n" +
+	"\bvenue_id\x18\x05 \x01(\tR\avenueId\x12\x1b\n" +
+	"\tauthor_id\x18\x06 \x01(\tR\bauthorId\x12\x1f\n" +
+	"\vauthor_name\x18\a \x01(\tR\n" +
+	"authorName\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x18\n" +
+	"\aupvotes\x18\t \x01(\x05R\aupvotes\x12\x1c\n" +
+	"\tdownvotes\x18\n" +
+	" \x01(\x05R\tdownvotes\x12B\n" +
+	"\treactions\x18\v \x03(\v2$.ratemybars.v1.Rating.ReactionsEntryR\treactions\x12\x1a\n" +
+	"\bredacted\x18\f \x01(\bR\bredacted\x12%\n" +
+	"\x0everified_visit\x18\r \x01(\bR\rverifiedVisit\x1a<\n" +
+	"\x0eReactionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"g\n" +
+	"\x04Page\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
+	"totalPages\"\xce\x01\n" +
+	"\x14SearchSchoolsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x18\n" +
+	"\acontrol\x18\x04 \x01(\tR\acontrol\x12\x18\n" +
+	"\aiclevel\x18\x05 \x01(\x05R\aiclevel\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\x12\x12\n" +
+	"\x04page\x18\a \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\b \x01(\x05R\x05limit\"q\n" +
+	"\x15SearchSchoolsResponse\x12/\n" +
+	"\aschools\x18\x01 \x03(\v2\x15.ratemybars.v1.SchoolR\aschools\x12'\n" +
+	"\x04page\x18\x02 \x01(\v2\x13.ratemybars.v1.PageR\x04page\"\"\n" +
+	"\x10GetSchoolRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"!\n" +
+	"\x0fGetVenueRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"`\n" +
+	"\x17ListSchoolVenuesRequest\x12\x1b\n" +
+	"\tschool_id\x18\x01 \x01(\tR\bschoolId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"q\n" +
+	"\x18ListSchoolVenuesResponse\x12,\n" +
+	"\x06venues\x18\x01 \x03(\v2\x14.ratemybars.v1.VenueR\x06venues\x12'\n" +
+	"\x04page\x18\x02 \x01(\v2\x13.ratemybars.v1.PageR\x04page\"r\n" +
+	"\x17ListVenueRatingsRequest\x12\x19\n" +
+	"\bvenue_id\x18\x01 \x01(\tR\avenueId\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"t\n" +
+	"\x18ListVenueRatingsResponse\x12/\n" +
+	"\aratings\x18\x01 \x03(\v2\x15.ratemybars.v1.RatingR\aratings\x12'\n" +
+	"\x04page\x18\x02 \x01(\v2\x13.ratemybars.v1.PageR\x04page2\xb0\x01\n" +
+	"\rSchoolService\x12Z\n" +
+	"\rSearchSchools\x12#.ratemybars.v1.SearchSchoolsRequest\x1a$.ratemybars.v1.SearchSchoolsResponse\x12C\n" +
+	"\tGetSchool\x12\x1f.ratemybars.v1.GetSchoolRequest\x1a\x15.ratemybars.v1.School2\xb5\x01\n" +
+	"\fVenueService\x12@\n" +
+	"\bGetVenue\x12\x1e.ratemybars.v1.GetVenueRequest\x1a\x14.ratemybars.v1.Venue\x12c\n" +
+	"\x10ListSchoolVenues\x12&.ratemybars.v1.ListSchoolVenuesRequest\x1a'.ratemybars.v1.ListSchoolVenuesResponse2t\n" +
+	"\rRatingService\x12c\n" +
+	"\x10ListVenueRatings\x12&.ratemybars.v1.ListVenueRatingsRequest\x1a'.ratemybars.v1.ListVenueRatingsResponseB8Z6github.com/ratemybars/backend/internal/pb/ratemybarsv1b\x06proto3"
+
+var (
+	file_ratemybars_v1_ratemybars_proto_rawDescOnce sync.Once
+	file_ratemybars_v1_ratemybars_proto_rawDescData []byte
+)
+
+func file_ratemybars_v1_ratemybars_proto_rawDescGZIP() []byte {
+	file_ratemybars_v1_ratemybars_proto_rawDescOnce.Do(func() {
+		file_ratemybars_v1_ratemybars_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ratemybars_v1_ratemybars_proto_rawDesc), len(file_ratemybars_v1_ratemybars_proto_rawDesc)))
+	})
+	return file_ratemybars_v1_ratemybars_proto_rawDescData
+}
+
+var file_ratemybars_v1_ratemybars_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_ratemybars_v1_ratemybars_proto_goTypes = []any{
+	(*School)(nil),                   // 0: ratemybars.v1.School
+	(*OpeningHours)(nil),             // 1: ratemybars.v1.OpeningHours
+	(*Venue)(nil),                    // 2: ratemybars.v1.Venue
+	(*Rating)(nil),                   // 3: ratemybars.v1.Rating
+	(*Page)(nil),                     // 4: ratemybars.v1.Page
+	(*SearchSchoolsRequest)(nil),     // 5: ratemybars.v1.SearchSchoolsRequest
+	(*SearchSchoolsResponse)(nil),    // 6: ratemybars.v1.SearchSchoolsResponse
+	(*GetSchoolRequest)(nil),         // 7: ratemybars.v1.GetSchoolRequest
+	(*GetVenueRequest)(nil),          // 8: ratemybars.v1.GetVenueRequest
+	(*ListSchoolVenuesRequest)(nil),  // 9: ratemybars.v1.ListSchoolVenuesRequest
+	(*ListSchoolVenuesResponse)(nil), // 10: ratemybars.v1.ListSchoolVenuesResponse
+	(*ListVenueRatingsRequest)(nil),  // 11: ratemybars.v1.ListVenueRatingsRequest
+	(*ListVenueRatingsResponse)(nil), // 12: ratemybars.v1.ListVenueRatingsResponse
+	nil,                              // 13: ratemybars.v1.Rating.ReactionsEntry
+	(*timestamppb.Timestamp)(nil),    // 14: google.protobuf.Timestamp
+}
+var file_ratemybars_v1_ratemybars_proto_depIdxs = []int32{
+	14, // 0: ratemybars.v1.Venue.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: ratemybars.v1.Venue.hours:type_name -> ratemybars.v1.OpeningHours
+	14, // 2: ratemybars.v1.Rating.created_at:type_name -> google.protobuf.Timestamp
+	13, // 3: ratemybars.v1.Rating.reactions:type_name -> ratemybars.v1.Rating.ReactionsEntry
+	0,  // 4: ratemybars.v1.SearchSchoolsResponse.schools:type_name -> ratemybars.v1.School
+	4,  // 5: ratemybars.v1.SearchSchoolsResponse.page:type_name -> ratemybars.v1.Page
+	2,  // 6: ratemybars.v1.ListSchoolVenuesResponse.venues:type_name -> ratemybars.v1.Venue
+	4,  // 7: ratemybars.v1.ListSchoolVenuesResponse.page:type_name -> ratemybars.v1.Page
+	3,  // 8: ratemybars.v1.ListVenueRatingsResponse.ratings:type_name -> ratemybars.v1.Rating
+	4,  // 9: ratemybars.v1.ListVenueRatingsResponse.page:type_name -> ratemybars.v1.Page
+	5,  // 10: ratemybars.v1.SchoolService.SearchSchools:input_type -> ratemybars.v1.SearchSchoolsRequest
+	7,  // 11: ratemybars.v1.SchoolService.GetSchool:input_type -> ratemybars.v1.GetSchoolRequest
+	8,  // 12: ratemybars.v1.VenueService.GetVenue:input_type -> ratemybars.v1.GetVenueRequest
+	9,  // 13: ratemybars.v1.VenueService.ListSchoolVenues:input_type -> ratemybars.v1.ListSchoolVenuesRequest
+	11, // 14: ratemybars.v1.RatingService.ListVenueRatings:input_type -> ratemybars.v1.ListVenueRatingsRequest
+	6,  // 15: ratemybars.v1.SchoolService.SearchSchools:output_type -> ratemybars.v1.SearchSchoolsResponse
+	0,  // 16: ratemybars.v1.SchoolService.GetSchool:output_type -> ratemybars.v1.School
+	2,  // 17: ratemybars.v1.VenueService.GetVenue:output_type -> ratemybars.v1.Venue
+	10, // 18: ratemybars.v1.VenueService.ListSchoolVenues:output_type -> ratemybars.v1.ListSchoolVenuesResponse
+	12, // 19: ratemybars.v1.RatingService.ListVenueRatings:output_type -> ratemybars.v1.ListVenueRatingsResponse
+	15, // [15:20] is the sub-list for method output_type
+	10, // [10:15] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_ratemybars_v1_ratemybars_proto_init() }
+func file_ratemybars_v1_ratemybars_proto_init() {
+	if File_ratemybars_v1_ratemybars_proto != nil {
+		return
+	}
+	file_ratemybars_v1_ratemybars_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ratemybars_v1_ratemybars_proto_rawDesc), len(file_ratemybars_v1_ratemybars_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_ratemybars_v1_ratemybars_proto_goTypes,
+		DependencyIndexes: file_ratemybars_v1_ratemybars_proto_depIdxs,
+		MessageInfos:      file_ratemybars_v1_ratemybars_proto_msgTypes,
+	}.Build()
+	File_ratemybars_v1_ratemybars_proto = out.File
+	file_ratemybars_v1_ratemybars_proto_goTypes = nil
+	file_ratemybars_v1_ratemybars_proto_depIdxs = nil
+}
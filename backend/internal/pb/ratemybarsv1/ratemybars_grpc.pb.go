@@ -0,0 +1,405 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v5.29.3
+// source: ratemybars/v1/ratemybars.proto
+
+// Read-side API for internal tooling and the mobile backend-for-frontend.
+// Messages mirror the JSON models in internal/model; field names match their
+// JSON tags. Regenerate the Go code with `go generate ./internal/pb/...`.
+
+package ratemybarsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SchoolService_SearchSchools_FullMethodName = "/ratemybars.v1.SchoolService/SearchSchools"
+	SchoolService_GetSchool_FullMethodName     = "/ratemybars.v1.SchoolService/GetSchool"
+)
+
+// SchoolServiceClient is the client API for SchoolService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SchoolServiceClient interface {
+	SearchSchools(ctx context.Context, in *SearchSchoolsRequest, opts ...grpc.CallOption) (*SearchSchoolsResponse, error)
+	GetSchool(ctx context.Context, in *GetSchoolRequest, opts ...grpc.CallOption) (*School, error)
+}
+
+type schoolServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSchoolServiceClient(cc grpc.ClientConnInterface) SchoolServiceClient {
+	return &schoolServiceClient{cc}
+}
+
+func (c *schoolServiceClient) SearchSchools(ctx context.Context, in *SearchSchoolsRequest, opts ...grpc.CallOption) (*SearchSchoolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSchoolsResponse)
+	err := c.cc.Invoke(ctx, SchoolService_SearchSchools_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schoolServiceClient) GetSchool(ctx context.Context, in *GetSchoolRequest, opts ...grpc.CallOption) (*School, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(School)
+	err := c.cc.Invoke(ctx, SchoolService_GetSchool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SchoolServiceServer is the server API for SchoolService service.
+// All implementations must embed UnimplementedSchoolServiceServer
+// for forward compatibility.
+type SchoolServiceServer interface {
+	SearchSchools(context.Context, *SearchSchoolsRequest) (*SearchSchoolsResponse, error)
+	GetSchool(context.Context, *GetSchoolRequest) (*School, error)
+	mustEmbedUnimplementedSchoolServiceServer()
+}
+
+// UnimplementedSchoolServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSchoolServiceServer struct{}
+
+func (UnimplementedSchoolServiceServer) SearchSchools(context.Context, *SearchSchoolsRequest) (*SearchSchoolsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchSchools not implemented")
+}
+func (UnimplementedSchoolServiceServer) GetSchool(context.Context, *GetSchoolRequest) (*School, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSchool not implemented")
+}
+func (UnimplementedSchoolServiceServer) mustEmbedUnimplementedSchoolServiceServer() {}
+func (UnimplementedSchoolServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSchoolServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SchoolServiceServer will
+// result in compilation errors.
+type UnsafeSchoolServiceServer interface {
+	mustEmbedUnimplementedSchoolServiceServer()
+}
+
+func RegisterSchoolServiceServer(s grpc.ServiceRegistrar, srv SchoolServiceServer) {
+	// If the following call panics, it indicates UnimplementedSchoolServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SchoolService_ServiceDesc, srv)
+}
+
+func _SchoolService_SearchSchools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchSchoolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchoolServiceServer).SearchSchools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchoolService_SearchSchools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchoolServiceServer).SearchSchools(ctx, req.(*SearchSchoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchoolService_GetSchool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchoolServiceServer).GetSchool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchoolService_GetSchool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchoolServiceServer).GetSchool(ctx, req.(*GetSchoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SchoolService_ServiceDesc is the grpc.ServiceDesc for SchoolService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SchoolService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ratemybars.v1.SchoolService",
+	HandlerType: (*SchoolServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchSchools",
+			Handler:    _SchoolService_SearchSchools_Handler,
+		},
+		{
+			MethodName: "GetSchool",
+			Handler:    _SchoolService_GetSchool_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ratemybars/v1/ratemybars.proto",
+}
+
+const (
+	VenueService_GetVenue_FullMethodName         = "/ratemybars.v1.VenueService/GetVenue"
+	VenueService_ListSchoolVenues_FullMethodName = "/ratemybars.v1.VenueService/ListSchoolVenues"
+)
+
+// VenueServiceClient is the client API for VenueService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VenueServiceClient interface {
+	GetVenue(ctx context.Context, in *GetVenueRequest, opts ...grpc.CallOption) (*Venue, error)
+	ListSchoolVenues(ctx context.Context, in *ListSchoolVenuesRequest, opts ...grpc.CallOption) (*ListSchoolVenuesResponse, error)
+}
+
+type venueServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVenueServiceClient(cc grpc.ClientConnInterface) VenueServiceClient {
+	return &venueServiceClient{cc}
+}
+
+func (c *venueServiceClient) GetVenue(ctx context.Context, in *GetVenueRequest, opts ...grpc.CallOption) (*Venue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Venue)
+	err := c.cc.Invoke(ctx, VenueService_GetVenue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *venueServiceClient) ListSchoolVenues(ctx context.Context, in *ListSchoolVenuesRequest, opts ...grpc.CallOption) (*ListSchoolVenuesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSchoolVenuesResponse)
+	err := c.cc.Invoke(ctx, VenueService_ListSchoolVenues_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VenueServiceServer is the server API for VenueService service.
+// All implementations must embed UnimplementedVenueServiceServer
+// for forward compatibility.
+type VenueServiceServer interface {
+	GetVenue(context.Context, *GetVenueRequest) (*Venue, error)
+	ListSchoolVenues(context.Context, *ListSchoolVenuesRequest) (*ListSchoolVenuesResponse, error)
+	mustEmbedUnimplementedVenueServiceServer()
+}
+
+// UnimplementedVenueServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVenueServiceServer struct{}
+
+func (UnimplementedVenueServiceServer) GetVenue(context.Context, *GetVenueRequest) (*Venue, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetVenue not implemented")
+}
+func (UnimplementedVenueServiceServer) ListSchoolVenues(context.Context, *ListSchoolVenuesRequest) (*ListSchoolVenuesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSchoolVenues not implemented")
+}
+func (UnimplementedVenueServiceServer) mustEmbedUnimplementedVenueServiceServer() {}
+func (UnimplementedVenueServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeVenueServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VenueServiceServer will
+// result in compilation errors.
+type UnsafeVenueServiceServer interface {
+	mustEmbedUnimplementedVenueServiceServer()
+}
+
+func RegisterVenueServiceServer(s grpc.ServiceRegistrar, srv VenueServiceServer) {
+	// If the following call panics, it indicates UnimplementedVenueServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VenueService_ServiceDesc, srv)
+}
+
+func _VenueService_GetVenue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVenueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VenueServiceServer).GetVenue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VenueService_GetVenue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VenueServiceServer).GetVenue(ctx, req.(*GetVenueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VenueService_ListSchoolVenues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchoolVenuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VenueServiceServer).ListSchoolVenues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VenueService_ListSchoolVenues_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VenueServiceServer).ListSchoolVenues(ctx, req.(*ListSchoolVenuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VenueService_ServiceDesc is the grpc.ServiceDesc for VenueService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VenueService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ratemybars.v1.VenueService",
+	HandlerType: (*VenueServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetVenue",
+			Handler:    _VenueService_GetVenue_Handler,
+		},
+		{
+			MethodName: "ListSchoolVenues",
+			Handler:    _VenueService_ListSchoolVenues_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ratemybars/v1/ratemybars.proto",
+}
+
+const (
+	RatingService_ListVenueRatings_FullMethodName = "/ratemybars.v1.RatingService/ListVenueRatings"
+)
+
+// RatingServiceClient is the client API for RatingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RatingServiceClient interface {
+	ListVenueRatings(ctx context.Context, in *ListVenueRatingsRequest, opts ...grpc.CallOption) (*ListVenueRatingsResponse, error)
+}
+
+type ratingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRatingServiceClient(cc grpc.ClientConnInterface) RatingServiceClient {
+	return &ratingServiceClient{cc}
+}
+
+func (c *ratingServiceClient) ListVenueRatings(ctx context.Context, in *ListVenueRatingsRequest, opts ...grpc.CallOption) (*ListVenueRatingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVenueRatingsResponse)
+	err := c.cc.Invoke(ctx, RatingService_ListVenueRatings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RatingServiceServer is the server API for RatingService service.
+// All implementations must embed UnimplementedRatingServiceServer
+// for forward compatibility.
+type RatingServiceServer interface {
+	ListVenueRatings(context.Context, *ListVenueRatingsRequest) (*ListVenueRatingsResponse, error)
+	mustEmbedUnimplementedRatingServiceServer()
+}
+
+// UnimplementedRatingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRatingServiceServer struct{}
+
+func (UnimplementedRatingServiceServer) ListVenueRatings(context.Context, *ListVenueRatingsRequest) (*ListVenueRatingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVenueRatings not implemented")
+}
+func (UnimplementedRatingServiceServer) mustEmbedUnimplementedRatingServiceServer() {}
+func (UnimplementedRatingServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeRatingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RatingServiceServer will
+// result in compilation errors.
+type UnsafeRatingServiceServer interface {
+	mustEmbedUnimplementedRatingServiceServer()
+}
+
+func RegisterRatingServiceServer(s grpc.ServiceRegistrar, srv RatingServiceServer) {
+	// If the following call panics, it indicates UnimplementedRatingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RatingService_ServiceDesc, srv)
+}
+
+func _RatingService_ListVenueRatings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVenueRatingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).ListVenueRatings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_ListVenueRatings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).ListVenueRatings(ctx, req.(*ListVenueRatingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RatingService_ServiceDesc is the grpc.ServiceDesc for RatingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RatingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ratemybars.v1.RatingService",
+	HandlerType: (*RatingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListVenueRatings",
+			Handler:    _RatingService_ListVenueRatings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ratemybars/v1/ratemybars.proto",
+}
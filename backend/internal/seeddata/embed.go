@@ -7,3 +7,6 @@ var SchoolsJSON []byte
 
 //go:embed fraternities.json
 var FraternitiesJSON []byte
+
+//go:embed conferences.json
+var ConferencesJSON []byte
@@ -0,0 +1,26 @@
+package service
+
+// AccountDeletionService carries out a user's request to be forgotten: the
+// account is deleted and everything it wrote or submitted is detached from
+// it, so reviews and venues stay up without an author.
+type AccountDeletionService struct {
+	auth        *AuthService
+	ratings     *RatingService
+	fratRatings *FratRatingService
+	venues      *VenueService
+}
+
+func NewAccountDeletionService(auth *AuthService, ratings *RatingService, fratRatings *FratRatingService, venues *VenueService) *AccountDeletionService {
+	return &AccountDeletionService{auth: auth, ratings: ratings, fratRatings: fratRatings, venues: venues}
+}
+
+// Delete removes the account and anonymizes its content.
+func (s *AccountDeletionService) Delete(userID string) error {
+	if err := s.auth.DeleteAccount(userID); err != nil {
+		return err
+	}
+	s.ratings.DeleteAuthor(userID)
+	s.fratRatings.DeleteAuthor(userID)
+	s.venues.DeleteCreator(userID)
+	return nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// AccountMergeService folds a duplicate account into another one belonging to
+// the same person, e.g. after signing up once by email and once through
+// OAuth. The older account is kept; the newer one's content moves to it and
+// the newer account is deleted.
+type AccountMergeService struct {
+	auth        *AuthService
+	ratings     *RatingService
+	fratRatings *FratRatingService
+	venues      *VenueService
+	follows     *FollowService
+	lists       *VenueListService
+	photos      *PhotoService
+	recompute   *RecomputeService
+}
+
+func NewAccountMergeService(auth *AuthService, ratings *RatingService, fratRatings *FratRatingService, venues *VenueService,
+	follows *FollowService, lists *VenueListService, photos *PhotoService, recompute *RecomputeService) *AccountMergeService {
+	return &AccountMergeService{
+		auth:        auth,
+		ratings:     ratings,
+		fratRatings: fratRatings,
+		venues:      venues,
+		follows:     follows,
+		lists:       lists,
+		photos:      photos,
+		recompute:   recompute,
+	}
+}
+
+// Merge merges two accounts, keeping whichever was created first. It returns
+// the kept account, the ID of the merged (deleted) one and the affected
+// record IDs by kind. With dryRun nothing changes.
+func (s *AccountMergeService) Merge(userA, userB string, dryRun bool) (*model.User, string, map[string][]string, error) {
+	if userA == userB {
+		return nil, "", nil, fmt.Errorf("cannot merge an account with itself")
+	}
+	keep, err := s.auth.GetUser(userA)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	merge, err := s.auth.GetUser(userB)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if merge.CreatedAt.Before(keep.CreatedAt) {
+		keep, merge = merge, keep
+	}
+
+	changes := s.ratings.MergeAuthor(merge.ID, keep.ID, keep.Username, dryRun)
+	for kind, ids := range s.fratRatings.MergeAuthor(merge.ID, keep.ID, keep.Username, dryRun) {
+		changes[kind] = ids
+	}
+	changes["venues"] = s.venues.MergeCreator(merge.ID, keep.ID, dryRun)
+	changes["follows"] = s.follows.MergeUser(merge.ID, keep.ID, dryRun)
+	changes["lists"] = s.lists.MergeOwner(merge.ID, keep.ID, keep.Username, dryRun)
+	changes["photos"] = s.photos.MergeUploader(merge.ID, keep.ID, dryRun)
+	changes["identities"] = s.auth.MergeIdentities(merge.ID, keep.ID, dryRun)
+	changes["users_deleted"] = []string{merge.ID}
+	if dryRun {
+		return keep, merge.ID, changes, nil
+	}
+
+	if err := s.auth.DeleteAccount(merge.ID); err != nil {
+		log.Printf("WARNING: Merged user %s into %s but failed to delete it: %v", merge.ID, keep.ID, err)
+		return nil, "", nil, err
+	}
+	// Dropped duplicate ratings change venue and school averages.
+	if len(changes["ratings_dropped"]) > 0 && s.recompute != nil {
+		s.recompute.RebuildAggregates()
+	}
+	return keep, merge.ID, changes, nil
+}
@@ -0,0 +1,411 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	// defaultAmbassadorVenueQuota is how many venues an ambassador may add
+	// without review in any 7 days.
+	defaultAmbassadorVenueQuota = 10
+
+	maxPinnedTitleLength = 100
+	maxPinnedBodyLength  = 1000
+
+	ambassadorVenueSeeded = "venue_seeded"
+	ambassadorPostPinned  = "post_pinned"
+)
+
+// LoadAmbassadorVenueQuota reads AMBASSADOR_VENUE_QUOTA, falling back to
+// defaultAmbassadorVenueQuota.
+func LoadAmbassadorVenueQuota() int {
+	if v, err := strconv.Atoi(os.Getenv("AMBASSADOR_VENUE_QUOTA")); err == nil && v >= 0 {
+		return v
+	}
+	return defaultAmbassadorVenueQuota
+}
+
+// VenueAutoApprover decides whether a new venue skips the review queue. It
+// is called once per venue, so it may count the venue against a quota.
+type VenueAutoApprover func(userID, schoolID, venueID string) bool
+
+type ambassadorActivity struct {
+	UserID    string
+	SchoolID  string
+	Kind      string
+	TargetID  string
+	CreatedAt time.Time
+}
+
+// AmbassadorService manages campus ambassadors: users an admin appointed to
+// a school, who may add venues there without review (up to a weekly quota)
+// and pin one post a week to the school's page.
+type AmbassadorService struct {
+	mu          sync.RWMutex
+	pool        *pgxpool.Pool
+	quota       int
+	ambassadors map[string]model.Ambassador // user ID -> appointment
+	activity    []ambassadorActivity
+	pins        map[string]model.PinnedPost // school ID -> latest pin
+
+	auth    *AuthService
+	schools *SchoolService
+}
+
+func NewAmbassadorService(pool *pgxpool.Pool, quota int, auth *AuthService, schools *SchoolService) *AmbassadorService {
+	svc := &AmbassadorService{
+		pool:        pool,
+		quota:       quota,
+		ambassadors: make(map[string]model.Ambassador),
+		pins:        make(map[string]model.PinnedPost),
+		auth:        auth,
+		schools:     schools,
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *AmbassadorService) loadFromDB() {
+	ctx := context.Background()
+	rows, err := s.pool.Query(ctx, `SELECT user_id, school_id, appointed_by, appointed_at FROM ambassadors`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load ambassadors from DB: %v", err)
+		return
+	}
+	for rows.Next() {
+		var a model.Ambassador
+		if err := rows.Scan(&a.UserID, &a.SchoolID, &a.AppointedBy, &a.AppointedAt); err != nil {
+			log.Printf("WARNING: Failed to scan ambassador row: %v", err)
+			continue
+		}
+		s.ambassadors[a.UserID] = a
+	}
+	rows.Close()
+
+	rows, err = s.pool.Query(ctx,
+		`SELECT user_id, school_id, kind, target_id, created_at FROM ambassador_activity ORDER BY created_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load ambassador activity from DB: %v", err)
+		return
+	}
+	for rows.Next() {
+		var a ambassadorActivity
+		if err := rows.Scan(&a.UserID, &a.SchoolID, &a.Kind, &a.TargetID, &a.CreatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan ambassador activity row: %v", err)
+			continue
+		}
+		s.activity = append(s.activity, a)
+	}
+	rows.Close()
+
+	rows, err = s.pool.Query(ctx,
+		`SELECT DISTINCT ON (school_id) id, school_id, author_id, title, body, week_start, created_at
+		 FROM pinned_posts ORDER BY school_id, week_start DESC`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load pinned posts from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var p model.PinnedPost
+		if err := rows.Scan(&p.ID, &p.SchoolID, &p.AuthorID, &p.Title, &p.Body, &p.WeekStart, &p.CreatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan pinned post row: %v", err)
+			continue
+		}
+		s.pins[p.SchoolID] = p
+	}
+	log.Printf("Loaded %d ambassadors and %d pinned posts from DB", len(s.ambassadors), len(s.pins))
+}
+
+// Appoint makes a user the ambassador for a school, replacing any earlier
+// appointment. Plain users get the "ambassador" role; owners and admins keep
+// theirs.
+func (s *AmbassadorService) Appoint(ctx context.Context, userID, schoolID string) (*model.Ambassador, error) {
+	user, err := s.auth.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.schools.GetByID(ctx, schoolID); err != nil {
+		return nil, fmt.Errorf("school not found")
+	}
+	if user.Role == "user" || user.Role == "" {
+		if err := s.auth.UpdateUserRole(userID, "ambassador"); err != nil {
+			return nil, err
+		}
+	}
+
+	a := model.Ambassador{
+		UserID:      userID,
+		Username:    user.Username,
+		SchoolID:    schoolID,
+		AppointedBy: middleware.GetUserID(ctx),
+		AppointedAt: time.Now(),
+	}
+	s.mu.Lock()
+	s.ambassadors[userID] = a
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO ambassadors (user_id, school_id, appointed_by, appointed_at) VALUES ($1, $2, $3, $4)
+			 ON CONFLICT (user_id) DO UPDATE SET school_id = EXCLUDED.school_id, appointed_by = EXCLUDED.appointed_by, appointed_at = EXCLUDED.appointed_at`,
+			a.UserID, a.SchoolID, a.AppointedBy, a.AppointedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist ambassador: %v", err)
+		}
+	}
+	return &a, nil
+}
+
+// Remove ends a user's appointment. Their activity stays on record.
+func (s *AmbassadorService) Remove(userID string) (*model.Ambassador, error) {
+	s.mu.Lock()
+	a, ok := s.ambassadors[userID]
+	delete(s.ambassadors, userID)
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("ambassador not found")
+	}
+
+	if user, err := s.auth.GetUser(userID); err == nil && user.Role == "ambassador" {
+		if err := s.auth.UpdateUserRole(userID, "user"); err != nil {
+			log.Printf("WARNING: Failed to reset ambassador role: %v", err)
+		}
+	}
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(), `DELETE FROM ambassadors WHERE user_id = $1`, userID); err != nil {
+			log.Printf("WARNING: Failed to delete ambassador from DB: %v", err)
+		}
+	}
+	return &a, nil
+}
+
+// recordLocked appends an activity entry. Caller holds s.mu.
+func (s *AmbassadorService) recordLocked(a ambassadorActivity) {
+	s.activity = append(s.activity, a)
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO ambassador_activity (id, user_id, school_id, kind, target_id, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+			generateID(), a.UserID, a.SchoolID, a.Kind, a.TargetID, a.CreatedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist ambassador activity: %v", err)
+		}
+	}
+}
+
+// seededSinceLocked counts venues the user added without review since t.
+// Caller holds s.mu.
+func (s *AmbassadorService) seededSinceLocked(userID string, t time.Time) int {
+	n := 0
+	for _, a := range s.activity {
+		if a.UserID == userID && a.Kind == ambassadorVenueSeeded && !a.CreatedAt.Before(t) {
+			n++
+		}
+	}
+	return n
+}
+
+// AutoApproveVenue approves venues an ambassador adds at their own school
+// while they're under quota; it matches VenueAutoApprover.
+func (s *AmbassadorService) AutoApproveVenue(userID, schoolID, venueID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.ambassadors[userID]
+	if !ok || a.SchoolID != schoolID {
+		return false
+	}
+	now := time.Now()
+	if s.seededSinceLocked(userID, now.AddDate(0, 0, -7)) >= s.quota {
+		return false
+	}
+	s.recordLocked(ambassadorActivity{
+		UserID: userID, SchoolID: schoolID, Kind: ambassadorVenueSeeded, TargetID: venueID, CreatedAt: now,
+	})
+	return true
+}
+
+// currentPinLocked returns the school's pin for this week. Caller holds s.mu.
+func (s *AmbassadorService) currentPinLocked(schoolID string, now time.Time) *model.PinnedPost {
+	p, ok := s.pins[schoolID]
+	if !ok || !p.WeekStart.Equal(weekStart(now)) {
+		return nil
+	}
+	return &p
+}
+
+// Status describes the caller's appointment and what's left of their quota.
+func (s *AmbassadorService) Status(userID string) (*model.AmbassadorStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	a, ok := s.ambassadors[userID]
+	if !ok {
+		return nil, fmt.Errorf("you are not an ambassador")
+	}
+	now := time.Now()
+	used := s.seededSinceLocked(userID, now.AddDate(0, 0, -7))
+	return &model.AmbassadorStatus{
+		Ambassador:      a,
+		VenueQuota:      s.quota,
+		VenuesRemaining: max(s.quota-used, 0),
+		Pinned:          s.currentPinLocked(a.SchoolID, now),
+	}, nil
+}
+
+// Pin sets this week's pinned post on the ambassador's school page. Pinning
+// again in the same week replaces the post.
+func (s *AmbassadorService) Pin(ctx context.Context, req model.PinPostRequest) (*model.PinnedPost, error) {
+	userID := middleware.GetUserID(ctx)
+	title := strings.TrimSpace(middleware.SanitizeString(req.Title))
+	body := strings.TrimSpace(middleware.SanitizeString(req.Body))
+	if title == "" || body == "" {
+		return nil, fmt.Errorf("title and body are required")
+	}
+	if len(title) > maxPinnedTitleLength {
+		return nil, fmt.Errorf("title must be at most %d characters", maxPinnedTitleLength)
+	}
+	if len(body) > maxPinnedBodyLength {
+		return nil, fmt.Errorf("body must be at most %d characters", maxPinnedBodyLength)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.ambassadors[userID]
+	if !ok {
+		return nil, fmt.Errorf("you are not an ambassador")
+	}
+	now := time.Now()
+	p := model.PinnedPost{
+		ID:         generateID(),
+		SchoolID:   a.SchoolID,
+		AuthorID:   userID,
+		AuthorName: middleware.GetUsername(ctx),
+		Title:      title,
+		Body:       body,
+		WeekStart:  weekStart(now),
+		CreatedAt:  now,
+	}
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO pinned_posts (id, school_id, author_id, title, body, week_start, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 ON CONFLICT (school_id, week_start) DO UPDATE SET id = EXCLUDED.id, author_id = EXCLUDED.author_id,
+			   title = EXCLUDED.title, body = EXCLUDED.body, created_at = EXCLUDED.created_at`,
+			p.ID, p.SchoolID, p.AuthorID, p.Title, p.Body, p.WeekStart, p.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pin post: %w", err)
+		}
+	}
+	s.pins[a.SchoolID] = p
+	s.recordLocked(ambassadorActivity{
+		UserID: userID, SchoolID: a.SchoolID, Kind: ambassadorPostPinned, TargetID: p.ID, CreatedAt: now,
+	})
+	return &p, nil
+}
+
+// Unpin removes this week's pinned post from the ambassador's school.
+func (s *AmbassadorService) Unpin(userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.ambassadors[userID]
+	if !ok {
+		return fmt.Errorf("you are not an ambassador")
+	}
+	p := s.currentPinLocked(a.SchoolID, time.Now())
+	if p == nil {
+		return fmt.Errorf("nothing is pinned this week")
+	}
+	delete(s.pins, a.SchoolID)
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(), `DELETE FROM pinned_posts WHERE id = $1`, p.ID); err != nil {
+			log.Printf("WARNING: Failed to delete pinned post from DB: %v", err)
+		}
+	}
+	return nil
+}
+
+// PinnedFor returns the school's pinned post for this week, or nil.
+func (s *AmbassadorService) PinnedFor(schoolID string) *model.PinnedPost {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p := s.currentPinLocked(schoolID, time.Now())
+	if p != nil && p.AuthorName == "" {
+		if u, err := s.auth.GetUser(p.AuthorID); err == nil {
+			p.AuthorName = u.Username
+		}
+	}
+	return p
+}
+
+// Activity summarizes ambassador work per school for the admin dashboard,
+// busiest schools first. Schools whose ambassadors were all removed still
+// show up while they have recorded activity.
+func (s *AmbassadorService) Activity() []model.AmbassadorSchoolActivity {
+	s.mu.RLock()
+	bySchool := make(map[string]*model.AmbassadorSchoolActivity)
+	entry := func(schoolID string) *model.AmbassadorSchoolActivity {
+		e, ok := bySchool[schoolID]
+		if !ok {
+			e = &model.AmbassadorSchoolActivity{SchoolID: schoolID, Ambassadors: []model.Ambassador{}}
+			bySchool[schoolID] = e
+		}
+		return e
+	}
+	for _, a := range s.ambassadors {
+		e := entry(a.SchoolID)
+		e.Ambassadors = append(e.Ambassadors, a)
+	}
+	weekAgo := time.Now().AddDate(0, 0, -7)
+	for _, a := range s.activity {
+		e := entry(a.SchoolID)
+		switch a.Kind {
+		case ambassadorVenueSeeded:
+			e.VenuesSeeded++
+			if !a.CreatedAt.Before(weekAgo) {
+				e.VenuesSeeded7d++
+			}
+		case ambassadorPostPinned:
+			e.PostsPinned++
+		}
+		if e.LastActiveAt == nil || a.CreatedAt.After(*e.LastActiveAt) {
+			at := a.CreatedAt
+			e.LastActiveAt = &at
+		}
+	}
+	s.mu.RUnlock()
+
+	out := make([]model.AmbassadorSchoolActivity, 0, len(bySchool))
+	for _, e := range bySchool {
+		if school, err := s.schools.Summary(e.SchoolID); err == nil {
+			e.SchoolName = school.Name
+		}
+		for i := range e.Ambassadors {
+			if u, err := s.auth.GetUser(e.Ambassadors[i].UserID); err == nil {
+				e.Ambassadors[i].Username = u.Username
+			}
+		}
+		sort.Slice(e.Ambassadors, func(i, j int) bool {
+			return e.Ambassadors[i].AppointedAt.Before(e.Ambassadors[j].AppointedAt)
+		})
+		out = append(out, *e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].VenuesSeeded7d != out[j].VenuesSeeded7d {
+			return out[i].VenuesSeeded7d > out[j].VenuesSeeded7d
+		}
+		return out[i].SchoolID < out[j].SchoolID
+	})
+	return out
+}
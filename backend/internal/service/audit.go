@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// AuditService keeps an append-only record of moderation and admin actions.
+type AuditService struct {
+	mu      sync.RWMutex
+	pool    *pgxpool.Pool
+	entries []model.AuditEntry
+}
+
+func NewAuditService(pool *pgxpool.Pool) *AuditService {
+	svc := &AuditService{
+		pool:    pool,
+		entries: []model.AuditEntry{},
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *AuditService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, actor_id, COALESCE(actor_name,''), action, target_type, target_id, details, created_at
+		 FROM audit_log ORDER BY created_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load audit log from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e model.AuditEntry
+		var details []byte
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.ActorName, &e.Action, &e.TargetType, &e.TargetID, &details, &e.CreatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan audit log row: %v", err)
+			continue
+		}
+		if len(details) > 0 {
+			json.Unmarshal(details, &e.Details)
+		}
+		s.entries = append(s.entries, e)
+	}
+	log.Printf("Loaded %d audit log entries from DB", len(s.entries))
+}
+
+// Record appends an entry attributed to the current user.
+func (s *AuditService) Record(ctx context.Context, action, targetType, targetID string, details map[string]interface{}) model.AuditEntry {
+	e := model.AuditEntry{
+		ID:         "audit_" + generateID()[:16],
+		ActorID:    middleware.GetUserID(ctx),
+		ActorName:  middleware.GetUsername(ctx),
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+		CreatedAt:  time.Now(),
+	}
+
+	s.mu.Lock()
+	s.entries = append(s.entries, e)
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		var payload []byte
+		if len(details) > 0 {
+			payload, _ = json.Marshal(details)
+		}
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO audit_log (id, actor_id, actor_name, action, target_type, target_id, details, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			e.ID, e.ActorID, e.ActorName, e.Action, e.TargetType, e.TargetID, payload, e.CreatedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist audit log entry: %v", err)
+		}
+	}
+	return e
+}
+
+// List returns the entries matching f, newest first.
+func (s *AuditService) List(f model.AuditFilter) []model.AuditEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := []model.AuditEntry{}
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		e := s.entries[i]
+		switch {
+		case f.ActorID != "" && e.ActorID != f.ActorID,
+			f.TargetType != "" && e.TargetType != f.TargetType,
+			f.TargetID != "" && e.TargetID != f.TargetID,
+			!f.Since.IsZero() && e.CreatedAt.Before(f.Since),
+			!f.Until.IsZero() && !e.CreatedAt.Before(f.Until):
+			continue
+		}
+		if f.Action != "" {
+			if strings.HasSuffix(f.Action, ".") {
+				if !strings.HasPrefix(e.Action, f.Action) {
+					continue
+				}
+			} else if e.Action != f.Action {
+				continue
+			}
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// ForTarget returns the entries about one target, oldest first.
+func (s *AuditService) ForTarget(targetType, targetID string) []model.AuditEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := []model.AuditEntry{}
+	for _, e := range s.entries {
+		if e.TargetType == targetType && e.TargetID == targetID {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -5,7 +5,9 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"log"
 	"os"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -13,35 +15,148 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
 	"github.com/ratemybars/backend/internal/model"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // AuthService handles user registration and authentication.
 // Supports PostgreSQL for persistent storage or in-memory fallback.
 type AuthService struct {
-	pool *pgxpool.Pool // nil = in-memory mode
+	pool    *pgxpool.Pool // nil = in-memory mode
+	queries *Queries      // prepared hot-path queries; nil without pool
 
 	// In-memory fallback fields
 	mu    sync.RWMutex
 	users map[string]*userRecord
+
+	// termsCache maps user ID -> accepted terms version so the terms gate
+	// doesn't hit the database on every write.
+	termsCache sync.Map
+
+	// tokenCutoffs maps user ID -> tokenCutoff: JWTs issued before a
+	// password change are rejected.
+	tokenCutoffs sync.Map
+
+	// revoked holds the IDs of JWTs signed out before they expired.
+	revoked tokenDenylist
+
+	// bans maps user ID -> banState; the source of truth in in-memory mode
+	// and a cache otherwise.
+	bans sync.Map
+
+	reads    *ReadReplica    // lag-tolerant reads; nil reads from pool
+	schools  *SchoolService  // validates home school IDs; optional
+	sessions *SessionService // set when AUTH_SESSIONS=server
+	invites  *InviteService  // credits invite codes used at signup; optional
+	captcha  *CaptchaService // checks signups and repeatedly failing logins; optional
+	google   *GoogleVerifier // set when GOOGLE_CLIENT_ID is
+
+	lockouts *loginLimiter // failed logins per email and IP; not shared between instances
+	hashing  PasswordHashConfig
+
+	resets    map[string]passwordReset // token hash -> reset (in-memory mode)
+	sendReset PasswordResetSendFunc
+
+	students      map[string]string              // user ID -> verified school ID
+	studentTokens map[string]studentVerification // token hash -> pending (in-memory mode)
+	sendStudent   StudentVerificationSendFunc
+
+	emailChanges      map[string]emailChange // token hash -> pending (in-memory mode)
+	sendEmailChange   EmailChangeSendFunc
+	noticeEmailChange EmailChangedNoticeFunc
+
+	logins map[string][]model.LoginRecord // user ID -> recent logins, oldest first (in-memory mode)
+
+	identities    map[string]*model.Identity // provider:subject -> identity (in-memory mode)
+	identityLinks map[string]identityLink    // token hash -> pending link (in-memory mode)
+
+	snapshotMu    sync.Mutex
+	snapshotSaved []byte // last snapshot written to AUTH_SNAPSHOT_PATH
 }
 
 type userRecord struct {
 	User         model.User
 	Email        string
 	PasswordHash string
+	Anonymized   bool
+	Preferences  *model.UserPreferences // nil until first changed
 }
 
 // NewAuthService creates an auth service backed by PostgreSQL.
 func NewAuthService(pool *pgxpool.Pool) *AuthService {
-	return &AuthService{pool: pool}
+	return &AuthService{pool: pool, queries: NewQueries(pool), students: make(map[string]string),
+		lockouts: newLoginLimiter(defaultLockoutConfig), hashing: defaultPasswordHashConfig}
 }
 
 // NewAuthServiceInMemory creates an auth service with in-memory storage (no persistence).
 func NewAuthServiceInMemory() *AuthService {
 	return &AuthService{
-		users: make(map[string]*userRecord),
+		users:         make(map[string]*userRecord),
+		resets:        make(map[string]passwordReset),
+		students:      make(map[string]string),
+		studentTokens: make(map[string]studentVerification),
+		emailChanges:  make(map[string]emailChange),
+		logins:        make(map[string][]model.LoginRecord),
+		identities:    make(map[string]*model.Identity),
+		identityLinks: make(map[string]identityLink),
+		lockouts:      newLoginLimiter(defaultLockoutConfig),
+		hashing:       defaultPasswordHashConfig,
+	}
+}
+
+// SetSchools lets the service validate home school IDs during onboarding.
+func (s *AuthService) SetSchools(schools *SchoolService) {
+	s.schools = schools
+}
+
+// SetInvites lets signups name the invite code that brought them in.
+func (s *AuthService) SetInvites(invites *InviteService) {
+	s.invites = invites
+}
+
+// SetCaptcha requires a CAPTCHA token at signup and on logins for an email
+// or IP with repeated failures.
+func (s *AuthService) SetCaptcha(captcha *CaptchaService) {
+	s.captcha = captcha
+}
+
+// CaptchaConfig describes the CAPTCHA widget signup and login forms need.
+func (s *AuthService) CaptchaConfig() model.CaptchaConfig {
+	return s.captcha.Config()
+}
+
+// SetSessions switches login from stateless JWTs to revocable server-side
+// sessions.
+func (s *AuthService) SetSessions(sessions *SessionService) {
+	s.sessions = sessions
+}
+
+// issueToken starts a session when server-side sessions are enabled and
+// signs a JWT otherwise.
+func (s *AuthService) issueToken(user model.User, client model.SessionClient) (string, error) {
+	if s.sessions != nil {
+		return s.sessions.Create(user, client)
+	}
+	return generateToken(user)
+}
+
+// Logout ends the session behind token, or adds a JWT to the denylist
+// until it expires.
+func (s *AuthService) Logout(token string) {
+	if s.sessions != nil && strings.HasPrefix(token, SessionTokenPrefix) {
+		s.sessions.RevokeToken(token)
+		return
+	}
+	s.revokeToken(token)
+}
+
+// revokeSessions signs a user out everywhere, e.g. after a password reset.
+func (s *AuthService) revokeSessions(userID string) {
+	if s.sessions == nil {
+		return
+	}
+	if _, err := s.sessions.RevokeAll(userID, ""); err != nil {
+		log.Printf("WARNING: Failed to revoke sessions: %v", err)
 	}
 }
 
@@ -49,6 +164,20 @@ func (s *AuthService) persistent() bool {
 	return s.pool != nil
 }
 
+// SetReadReplica sends reads that tolerate replication lag (public profiles,
+// signup availability checks, the retention scan) to a read replica.
+func (s *AuthService) SetReadReplica(reads *ReadReplica) {
+	s.reads = reads
+}
+
+// reader returns where lag-tolerant reads should go.
+func (s *AuthService) reader() dbReader {
+	if s.reads != nil {
+		return s.reads
+	}
+	return s.pool
+}
+
 // Migrate creates the users table if it doesn't exist.
 func (s *AuthService) Migrate(ctx context.Context) error {
 	if !s.persistent() {
@@ -69,7 +198,93 @@ func (s *AuthService) Migrate(ctx context.Context) error {
 	}
 	// Add role column if table already existed without it
 	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'user'`)
-	return nil
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS age_confirmed_at TIMESTAMPTZ`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS age_jurisdiction TEXT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS terms_version TEXT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS terms_accepted_at TIMESTAMPTZ`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS home_school_id TEXT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS grad_year INT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMPTZ`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS school_id TEXT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS student_email TEXT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS student_verified_at TIMESTAMPTZ`)
+	_, _ = s.pool.Exec(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS idx_users_student_email ON users (student_email)`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS display_name TEXT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_url TEXT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS bio TEXT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS tokens_valid_after TIMESTAMPTZ`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS banned_until TIMESTAMPTZ`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS ban_reason TEXT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMPTZ`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_ip TEXT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_user_agent TEXT`)
+	_, _ = s.pool.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS preferences JSONB NOT NULL DEFAULT '{}'`)
+
+	_, err := s.pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS password_resets (
+			token_hash TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			expires_at TIMESTAMPTZ NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		CREATE INDEX IF NOT EXISTS idx_password_resets_user ON password_resets (user_id);
+		CREATE TABLE IF NOT EXISTS student_verifications (
+			token_hash TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			email      TEXT NOT NULL,
+			school_id  TEXT NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		CREATE INDEX IF NOT EXISTS idx_student_verifications_user ON student_verifications (user_id);
+		CREATE TABLE IF NOT EXISTS email_changes (
+			token_hash TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			new_email  TEXT NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		CREATE INDEX IF NOT EXISTS idx_email_changes_user ON email_changes (user_id);
+		CREATE TABLE IF NOT EXISTS user_logins (
+			id         TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			ip         TEXT NOT NULL DEFAULT '',
+			user_agent TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		CREATE INDEX IF NOT EXISTS idx_user_logins_user ON user_logins (user_id, created_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_user_logins_created ON user_logins (created_at);
+		CREATE TABLE IF NOT EXISTS revoked_tokens (
+			jti        TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			expires_at TIMESTAMPTZ NOT NULL,
+			revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		CREATE INDEX IF NOT EXISTS idx_revoked_tokens_revoked ON revoked_tokens (revoked_at);
+		CREATE TABLE IF NOT EXISTS user_identities (
+			id         TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			provider   TEXT NOT NULL,
+			subject    TEXT NOT NULL,
+			email      TEXT,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			UNIQUE (provider, subject)
+		);
+		CREATE INDEX IF NOT EXISTS idx_user_identities_user ON user_identities (user_id);
+		CREATE TABLE IF NOT EXISTS identity_links (
+			token_hash TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			provider   TEXT NOT NULL,
+			subject    TEXT NOT NULL,
+			email      TEXT NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+	`)
+	if err != nil {
+		return err
+	}
+	return s.loadStudents(ctx)
 }
 
 // isAdminEmail checks if the given email is in the ADMIN_EMAILS env var.
@@ -92,14 +307,17 @@ func (s *AuthService) Register(req model.RegisterRequest) (*model.AuthResponse,
 	if req.Email == "" || req.Password == "" || req.Username == "" {
 		return nil, fmt.Errorf("email, password, and username are required")
 	}
-	if len(req.Password) < 8 {
-		return nil, fmt.Errorf("password must be at least 8 characters")
+	if err := validateUsername(req.Username); err != nil {
+		return nil, err
+	}
+	if err := checkPasswordStrength(req.Password, req.Username, req.Email); err != nil {
+		return nil, err
 	}
-	if len(req.Username) < 3 || len(req.Username) > 30 {
-		return nil, fmt.Errorf("username must be between 3 and 30 characters")
+	if err := s.checkSignup(req.InviteCode, req.CaptchaToken, req.Client); err != nil {
+		return nil, err
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hash, err := s.hashPassword(req.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
@@ -112,11 +330,14 @@ func (s *AuthService) Register(req model.RegisterRequest) (*model.AuthResponse,
 	}
 
 	if s.persistent() {
-		err = s.registerDB(userID, req.Email, req.Username, string(hash), role, now)
+		err = s.registerDB(userID, req.Email, req.Username, hash, role, now)
 	} else {
-		err = s.registerMemory(userID, req.Email, req.Username, string(hash), role, now)
+		err = s.registerMemory(userID, req.Email, req.Username, hash, role, now)
 	}
 	if err != nil {
+		if err.Error() == "email already registered" && s.externalOnly(req.Email) {
+			return nil, fmt.Errorf("this email belongs to an account that signs in with Google; sign in with Google, then add a password to it")
+		}
 		return nil, err
 	}
 
@@ -126,8 +347,18 @@ func (s *AuthService) Register(req model.RegisterRequest) (*model.AuthResponse,
 		Role:      role,
 		CreatedAt: now,
 	}
+	if req.InviteCode != "" {
+		if err := s.invites.Redeem(req.InviteCode, userID); err != nil {
+			log.Printf("WARNING: Failed to credit invite code: %v", err)
+		}
+	}
+	if req.TermsVersion != "" && req.TermsVersion == CurrentTermsVersion() {
+		if accepted, err := s.AcceptTerms(userID, req.TermsVersion); err == nil {
+			user = *accepted
+		}
+	}
 
-	token, err := generateToken(user)
+	token, err := s.issueToken(user, req.Client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
@@ -138,6 +369,80 @@ func (s *AuthService) Register(req model.RegisterRequest) (*model.AuthResponse,
 	}, nil
 }
 
+// checkSignup runs the checks every new account goes through, however it
+// signs up.
+func (s *AuthService) checkSignup(inviteCode, captchaToken string, client model.SessionClient) error {
+	if inviteCode != "" && (s.invites == nil || s.invites.Owner(inviteCode) == "") {
+		return fmt.Errorf("invalid invite code")
+	}
+	return s.captcha.Verify(context.Background(), captchaToken, client.IP)
+}
+
+func validateUsername(username string) error {
+	if len(username) < 3 || len(username) > 30 {
+		return fmt.Errorf("username must be between 3 and 30 characters")
+	}
+	return nil
+}
+
+// UsernameAvailable reports whether a valid username is free to register.
+// Invalid usernames return the same error Register would.
+func (s *AuthService) UsernameAvailable(username string) (bool, error) {
+	if username == "" {
+		return false, fmt.Errorf("username is required")
+	}
+	if err := validateUsername(username); err != nil {
+		return false, err
+	}
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var taken bool
+		err := s.reader().QueryRow(ctx,
+			`SELECT EXISTS(SELECT 1 FROM users WHERE username = $1)`, username,
+		).Scan(&taken)
+		if err != nil {
+			return false, fmt.Errorf("failed to check username: %w", err)
+		}
+		return !taken, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rec := range s.users {
+		if rec.User.Username == username {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// EmailAvailable reports whether no account is registered with email.
+func (s *AuthService) EmailAvailable(email string) (bool, error) {
+	if email == "" {
+		return false, fmt.Errorf("email is required")
+	}
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var taken bool
+		err := s.reader().QueryRow(ctx,
+			`SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`, email,
+		).Scan(&taken)
+		if err != nil {
+			return false, fmt.Errorf("failed to check email: %w", err)
+		}
+		return !taken, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, taken := s.users[email]
+	return !taken, nil
+}
+
 func (s *AuthService) registerDB(id, email, username, hash, role string, now time.Time) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -192,6 +497,25 @@ func (s *AuthService) Login(req model.LoginRequest) (*model.AuthResponse, error)
 		return nil, fmt.Errorf("email and password are required")
 	}
 
+	// Unknown emails count as failures too, so lockouts don't reveal which
+	// addresses are registered.
+	keys := []string{lockoutEmailKey(req.Email)}
+	if req.Client.IP != "" {
+		keys = append(keys, lockoutIPKey(req.Client.IP))
+	}
+	if lerr := s.lockouts.check(keys, time.Now()); lerr != nil {
+		return nil, lerr
+	}
+	needCaptcha := s.captcha != nil && s.lockouts.failures(keys) >= s.captcha.loginAfter
+	if needCaptcha {
+		if err := s.captcha.Verify(context.Background(), req.CaptchaToken, req.Client.IP); err != nil {
+			if strings.HasPrefix(err.Error(), "failed to") {
+				return nil, err
+			}
+			return nil, &LoginError{Message: err.Error(), RemainingAttempts: s.lockouts.remaining(keys), CaptchaRequired: true}
+		}
+	}
+
 	var user model.User
 	var passwordHash string
 	var err error
@@ -201,18 +525,25 @@ func (s *AuthService) Login(req model.LoginRequest) (*model.AuthResponse, error)
 	} else {
 		user, passwordHash, err = s.loginMemory(req.Email)
 	}
-	if err != nil {
+	if err != nil && err.Error() != "invalid email or password" {
 		return nil, err
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(req.Password)); err != nil {
-		return nil, fmt.Errorf("invalid email or password")
+	if err != nil || !checkPassword(passwordHash, req.Password) {
+		lerr := s.lockouts.fail(keys, time.Now())
+		lerr.CaptchaRequired = s.captcha != nil && !lerr.Locked && s.lockouts.failures(keys) >= s.captcha.loginAfter
+		return nil, lerr
+	}
+	s.lockouts.succeed(keys[0])
+	if s.needsRehash(passwordHash) {
+		s.rehashPassword(req.Email, user.ID, passwordHash, req.Password)
 	}
 
-	token, err := generateToken(user)
+	token, err := s.issueToken(user, req.Client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
+	s.recordLogin(user.ID, req.Client, time.Now())
 
 	return &model.AuthResponse{
 		Token: token,
@@ -227,10 +558,7 @@ func (s *AuthService) loginDB(email string) (model.User, string, error) {
 	var user model.User
 	var passwordHash string
 
-	err := s.pool.QueryRow(ctx,
-		`SELECT id, username, role, password_hash, created_at FROM users WHERE email = $1`,
-		email,
-	).Scan(&user.ID, &user.Username, &user.Role, &passwordHash, &user.CreatedAt)
+	err := s.queries.QueryRow(ctx, "user_login", email).Scan(&user.ID, &user.Username, &user.Role, &passwordHash, &user.CreatedAt)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -278,10 +606,11 @@ func (s *AuthService) getUserDB(userID string) (*model.User, error) {
 	defer cancel()
 
 	var user model.User
-	err := s.pool.QueryRow(ctx,
-		`SELECT id, username, role, created_at FROM users WHERE id = $1`,
-		userID,
-	).Scan(&user.ID, &user.Username, &user.Role, &user.CreatedAt)
+	err := s.queries.QueryRow(ctx, "user_by_id", userID).Scan(&user.ID, &user.Username, &user.Role, &user.CreatedAt, &user.AgeConfirmedAt, &user.AgeJurisdiction,
+		&user.TermsVersion, &user.TermsAcceptedAt, &user.HomeSchoolID, &user.GradYear,
+		&user.SchoolID, &user.StudentEmail, &user.StudentVerifiedAt,
+		&user.DisplayName, &user.AvatarURL, &user.Bio,
+		&user.BannedUntil, &user.BanReason)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -289,6 +618,18 @@ func (s *AuthService) getUserDB(userID string) (*model.User, error) {
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
+	user.AgeConfirmed = user.AgeConfirmedAt != nil
+	user.TermsCurrent = user.TermsVersion == CurrentTermsVersion()
+	user.VerifiedStudent = user.StudentVerifiedAt != nil
+	s.termsCache.Store(user.ID, user.TermsVersion)
+	ban := banState{reason: user.BanReason, checked: time.Now()}
+	if user.BannedUntil != nil {
+		ban.until = *user.BannedUntil
+	}
+	s.bans.Store(user.ID, ban)
+	if user.Banned = ban.active(time.Now()); !user.Banned {
+		user.BannedUntil, user.BanReason = nil, ""
+	}
 
 	return &user, nil
 }
@@ -300,6 +641,8 @@ func (s *AuthService) getUserMemory(userID string) (*model.User, error) {
 	for _, rec := range s.users {
 		if rec.User.ID == userID {
 			u := rec.User
+			u.TermsCurrent = u.TermsVersion == CurrentTermsVersion()
+			s.applyBan(&u)
 			return &u, nil
 		}
 	}
@@ -313,6 +656,33 @@ type UserInfo struct {
 	Username  string    `json:"username"`
 	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
+	Banned    bool      `json:"banned,omitempty"`
+}
+
+// AccountEmail returns a user's sign-in email (admin only).
+func (s *AuthService) AccountEmail(userID string) (string, error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var email string
+		err := s.pool.QueryRow(ctx, `SELECT email FROM users WHERE id = $1`, userID).Scan(&email)
+		if err == pgx.ErrNoRows {
+			return "", fmt.Errorf("user not found")
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get user: %w", err)
+		}
+		return email, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rec := range s.users {
+		if rec.User.ID == userID {
+			return rec.Email, nil
+		}
+	}
+	return "", fmt.Errorf("user not found")
 }
 
 // ListUsers returns all users (admin only).
@@ -328,7 +698,9 @@ func (s *AuthService) listUsersDB() ([]UserInfo, error) {
 	defer cancel()
 
 	rows, err := s.pool.Query(ctx,
-		`SELECT id, email, username, role, created_at FROM users ORDER BY created_at DESC`)
+		`SELECT id, email, username, role, created_at,
+		        COALESCE(ban_reason,'') <> '' AND (banned_until IS NULL OR banned_until > NOW())
+		 FROM users ORDER BY created_at DESC`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
@@ -337,7 +709,7 @@ func (s *AuthService) listUsersDB() ([]UserInfo, error) {
 	var users []UserInfo
 	for rows.Next() {
 		var u UserInfo
-		if err := rows.Scan(&u.ID, &u.Email, &u.Username, &u.Role, &u.CreatedAt); err != nil {
+		if err := rows.Scan(&u.ID, &u.Email, &u.Username, &u.Role, &u.CreatedAt, &u.Banned); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		users = append(users, u)
@@ -357,20 +729,218 @@ func (s *AuthService) listUsersMemory() []UserInfo {
 			Username:  rec.User.Username,
 			Role:      rec.User.Role,
 			CreatedAt: rec.User.CreatedAt,
+			Banned:    s.banState(rec.User.ID).active(time.Now()),
 		})
 	}
 	return users
 }
 
+// AdminUserIDs returns the IDs of all admin accounts, e.g. to page them.
+func (s *AuthService) AdminUserIDs() ([]string, error) {
+	users, err := s.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, u := range users {
+		if u.Role == "admin" {
+			ids = append(ids, u.ID)
+		}
+	}
+	return ids, nil
+}
+
+// AnonymousUsername is shown in place of an anonymized account's name.
+const AnonymousUsername = "former member"
+
+// DeletedAuthorID replaces the author of content whose account was deleted.
+const DeletedAuthorID = "deleted"
+
+// AccountsCreatedBefore returns the IDs of non-admin accounts created before
+// cutoff that haven't been anonymized yet.
+func (s *AuthService) AccountsCreatedBefore(cutoff time.Time) ([]string, error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		rows, err := s.reader().Query(ctx,
+			`SELECT id FROM users WHERE created_at < $1 AND anonymized_at IS NULL AND role <> 'admin'`, cutoff)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list accounts: %w", err)
+		}
+		defer rows.Close()
+
+		var ids []string
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return nil, fmt.Errorf("failed to scan account: %w", err)
+			}
+			ids = append(ids, id)
+		}
+		return ids, rows.Err()
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ids []string
+	for _, rec := range s.users {
+		if !rec.Anonymized && rec.User.Role != "admin" && rec.User.CreatedAt.Before(cutoff) {
+			ids = append(ids, rec.User.ID)
+		}
+	}
+	return ids, nil
+}
+
+// Anonymize strips an account of everything that identifies its owner: the
+// email and username are replaced, the password can no longer be used, and
+// profile fields are cleared. The account ID stays so content keeps its
+// (now anonymous) author.
+func (s *AuthService) Anonymize(userID string) error {
+	username := "anon-" + userID[:min(8, len(userID))]
+	email := userID + "@anonymized.invalid"
+	now := time.Now()
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		tag, err := s.pool.Exec(ctx,
+			`UPDATE users SET email = $1, username = $2, password_hash = '!', role = 'user',
+			        age_jurisdiction = NULL, home_school_id = NULL, grad_year = NULL, anonymized_at = $3,
+			        school_id = NULL, student_email = NULL, student_verified_at = NULL,
+			        display_name = NULL, avatar_url = NULL, bio = NULL,
+			        last_login_ip = NULL, last_login_user_agent = NULL, preferences = '{}',
+			        tokens_valid_after = $3
+			 WHERE id = $4`,
+			email, username, now, userID)
+		if err != nil {
+			return fmt.Errorf("failed to anonymize account: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("user not found")
+		}
+		if _, err := s.pool.Exec(ctx, `DELETE FROM user_logins WHERE user_id = $1`, userID); err != nil {
+			log.Printf("WARNING: Failed to delete login history for anonymized account: %v", err)
+		}
+		s.deleteIdentities(userID)
+		s.mu.Lock()
+		delete(s.students, userID)
+		s.mu.Unlock()
+		s.invalidateTokens(userID, now)
+		return nil
+	}
+
+	s.deleteIdentities(userID)
+	s.mu.Lock()
+	delete(s.students, userID)
+	delete(s.logins, userID)
+	found := false
+	for key, rec := range s.users {
+		if rec.User.ID != userID {
+			continue
+		}
+		delete(s.users, key)
+		rec.Email = email
+		rec.PasswordHash = "!"
+		rec.Anonymized = true
+		rec.User = model.User{ID: userID, Username: username, Role: "user", CreatedAt: rec.User.CreatedAt}
+		rec.Preferences = nil
+		s.users[email] = rec
+		found = true
+		break
+	}
+	s.mu.Unlock()
+	if !found {
+		return fmt.Errorf("user not found")
+	}
+	// Old JWTs still carry the real username.
+	s.invalidateTokens(userID, now)
+	return nil
+}
+
+// DeleteAccount removes a user and their pending tokens and sessions. Their
+// content is detached separately (see AccountDeletionService).
+func (s *AuthService) DeleteAccount(userID string) error {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		tag, err := s.pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+		if err != nil {
+			return fmt.Errorf("failed to delete account: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("user not found")
+		}
+		for _, table := range []string{"password_resets", "student_verifications", "email_changes", "user_logins"} {
+			if _, err := s.pool.Exec(ctx, `DELETE FROM `+table+` WHERE user_id = $1`, userID); err != nil {
+				log.Printf("WARNING: Failed to delete %s for deleted account: %v", table, err)
+			}
+		}
+	} else {
+		s.mu.Lock()
+		found := false
+		for key, rec := range s.users {
+			if rec.User.ID == userID {
+				delete(s.users, key)
+				found = true
+				break
+			}
+		}
+		for h, r := range s.resets {
+			if r.UserID == userID {
+				delete(s.resets, h)
+			}
+		}
+		for h, v := range s.studentTokens {
+			if v.UserID == userID {
+				delete(s.studentTokens, h)
+			}
+		}
+		for h, c := range s.emailChanges {
+			if c.UserID == userID {
+				delete(s.emailChanges, h)
+			}
+		}
+		delete(s.logins, userID)
+		s.mu.Unlock()
+		if !found {
+			return fmt.Errorf("user not found")
+		}
+		s.deleteIdentities(userID)
+	}
+
+	s.mu.Lock()
+	delete(s.students, userID)
+	s.mu.Unlock()
+	s.termsCache.Delete(userID)
+	// No JWT of a deleted account is good, even one issued this second.
+	// Other instances see the users row is gone when they recheck.
+	now := time.Now()
+	s.tokenCutoffs.Store(userID, tokenCutoff{after: now, checked: now, deleted: true})
+	s.revokeSessions(userID)
+	if s.invites != nil {
+		s.invites.DeleteUser(userID)
+	}
+	return nil
+}
+
 // UpdateUserRole changes a user's role. Returns an error if the user is not found.
 func (s *AuthService) UpdateUserRole(userID, role string) error {
-	if role != "user" && role != "admin" {
-		return fmt.Errorf("invalid role: must be 'user' or 'admin'")
+	if role != "user" && role != "owner" && role != "ambassador" && role != "moderator" && role != "admin" {
+		return fmt.Errorf("invalid role: must be 'user', 'owner', 'ambassador', 'moderator', or 'admin'")
 	}
+	var err error
 	if s.persistent() {
-		return s.updateUserRoleDB(userID, role)
+		err = s.updateUserRoleDB(userID, role)
+	} else {
+		err = s.updateUserRoleMemory(userID, role)
 	}
-	return s.updateUserRoleMemory(userID, role)
+	if err == nil && s.sessions != nil {
+		s.sessions.UpdateRole(userID, role)
+	}
+	return err
 }
 
 func (s *AuthService) updateUserRoleDB(userID, role string) error {
@@ -400,18 +970,183 @@ func (s *AuthService) updateUserRoleMemory(userID, role string) error {
 	return fmt.Errorf("user not found")
 }
 
-func generateToken(user model.User) (string, error) {
-	signingKey := os.Getenv("AUTH_SIGNING_KEY")
-	if signingKey == "" {
-		signingKey = "dev-signing-key-change-in-production"
+// GrantOwnerRole upgrades a regular user to the "owner" role after a verified
+// venue claim and returns a fresh token carrying the new role. Admins keep
+// their role.
+func (s *AuthService) GrantOwnerRole(userID string) (*model.AuthResponse, error) {
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
 	}
+	if user.Role == "user" || user.Role == "" {
+		if err := s.UpdateUserRole(userID, "owner"); err != nil {
+			return nil, err
+		}
+		user.Role = "owner"
+	}
+
+	token, err := s.issueToken(*user, model.SessionClient{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	return &model.AuthResponse{Token: token, User: user}, nil
+}
+
+var jurisdictionPattern = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
 
+// ConfirmAge records that a user confirmed they're of legal drinking age in a
+// jurisdiction (ISO 3166 country or subdivision code, e.g. "US" or "US-WI").
+func (s *AuthService) ConfirmAge(userID, jurisdiction string) (*model.User, error) {
+	jurisdiction = strings.ToUpper(strings.TrimSpace(jurisdiction))
+	if !jurisdictionPattern.MatchString(jurisdiction) {
+		return nil, fmt.Errorf("jurisdiction must be a country or subdivision code like 'US-WI'")
+	}
+	now := time.Now()
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		tag, err := s.pool.Exec(ctx,
+			`UPDATE users SET age_confirmed_at = $1, age_jurisdiction = $2 WHERE id = $3`,
+			now, jurisdiction, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record age confirmation: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, fmt.Errorf("user not found")
+		}
+		return s.GetUser(userID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rec := range s.users {
+		if rec.User.ID == userID {
+			rec.User.AgeConfirmed = true
+			rec.User.AgeConfirmedAt = &now
+			rec.User.AgeJurisdiction = jurisdiction
+			u := rec.User
+			return &u, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+// CurrentTermsVersion returns the Terms of Service / Privacy Policy version
+// users must have accepted, from TERMS_VERSION. Bumping it requires everyone
+// to re-accept before posting content.
+func CurrentTermsVersion() string {
+	if v := os.Getenv("TERMS_VERSION"); v != "" {
+		return v
+	}
+	return "2026-10-01"
+}
+
+// AcceptTerms records that a user accepted the given terms version, which must be current.
+func (s *AuthService) AcceptTerms(userID, version string) (*model.User, error) {
+	if version != CurrentTermsVersion() {
+		return nil, fmt.Errorf("terms version %q is not current (current is %q)", version, CurrentTermsVersion())
+	}
+	now := time.Now()
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		tag, err := s.pool.Exec(ctx,
+			`UPDATE users SET terms_version = $1, terms_accepted_at = $2 WHERE id = $3`,
+			version, now, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record terms acceptance: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, fmt.Errorf("user not found")
+		}
+		s.termsCache.Store(userID, version)
+		return s.GetUser(userID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rec := range s.users {
+		if rec.User.ID == userID {
+			rec.User.TermsVersion = version
+			rec.User.TermsAcceptedAt = &now
+			u := rec.User
+			u.TermsCurrent = true
+			return &u, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+// SetHomeSchool records a user's home school and graduation year. Either may
+// be empty/zero to clear it.
+func (s *AuthService) SetHomeSchool(userID, schoolID string, gradYear int) (*model.User, error) {
+	schoolID = strings.TrimSpace(schoolID)
+	if schoolID != "" && s.schools != nil {
+		if _, err := s.schools.GetByID(context.Background(), schoolID); err != nil {
+			return nil, fmt.Errorf("school not found")
+		}
+	}
+	if gradYear != 0 {
+		thisYear := time.Now().Year()
+		if gradYear < 1950 || gradYear > thisYear+8 {
+			return nil, fmt.Errorf("grad_year must be between 1950 and %d", thisYear+8)
+		}
+	}
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		tag, err := s.pool.Exec(ctx,
+			`UPDATE users SET home_school_id = NULLIF($1, ''), grad_year = NULLIF($2, 0) WHERE id = $3`,
+			schoolID, gradYear, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set home school: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, fmt.Errorf("user not found")
+		}
+		return s.GetUser(userID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rec := range s.users {
+		if rec.User.ID == userID {
+			rec.User.HomeSchoolID = schoolID
+			rec.User.GradYear = gradYear
+			u := rec.User
+			u.TermsCurrent = u.TermsVersion == CurrentTermsVersion()
+			return &u, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+// AcceptedTermsVersion returns the terms version a user last accepted, or "".
+func (s *AuthService) AcceptedTermsVersion(userID string) string {
+	if v, ok := s.termsCache.Load(userID); ok {
+		return v.(string)
+	}
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return ""
+	}
+	return user.TermsVersion
+}
+
+func generateToken(user model.User) (string, error) {
 	role := user.Role
 	if role == "" {
 		role = "user"
 	}
 
 	claims := jwt.MapClaims{
+		"jti":      generateID(),
 		"sub":      user.ID,
 		"username": user.Username,
 		"role":     role,
@@ -419,8 +1154,7 @@ func generateToken(user model.User) (string, error) {
 		"exp":      time.Now().Add(24 * time.Hour).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(signingKey))
+	return middleware.SignToken(claims)
 }
 
 func generateID() string {
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// authSnapshotVersion is bumped when the snapshot layout changes
+// incompatibly.
+const authSnapshotVersion = 1
+
+// AuthSnapshotConfig enables file-backed persistence for the in-memory
+// AuthService, so deployments without a database keep their accounts across
+// restarts.
+type AuthSnapshotConfig struct {
+	Path     string // empty disables snapshots
+	Interval time.Duration
+}
+
+// LoadAuthSnapshotConfig reads AUTH_SNAPSHOT_PATH (unset disables snapshots)
+// and AUTH_SNAPSHOT_INTERVAL_SECONDS (default 60). Ignored with DATABASE_URL.
+func LoadAuthSnapshotConfig() AuthSnapshotConfig {
+	cfg := AuthSnapshotConfig{Path: os.Getenv("AUTH_SNAPSHOT_PATH"), Interval: time.Minute}
+	if v, err := strconv.Atoi(os.Getenv("AUTH_SNAPSHOT_INTERVAL_SECONDS")); err == nil && v > 0 {
+		cfg.Interval = time.Duration(v) * time.Second
+	}
+	return cfg
+}
+
+// authSnapshot is what's written to AUTH_SNAPSHOT_PATH: accounts and the
+// state that lives only in memory without a database. Pending reset, .edu,
+// email change and account link tokens are short-lived and left out.
+type authSnapshot struct {
+	Version       int                            `json:"version"`
+	Users         []snapshotUser                 `json:"users"`
+	Students      map[string]string              `json:"students,omitempty"`      // user ID -> verified school ID
+	Bans          map[string]snapshotBan         `json:"bans,omitempty"`          // user ID -> ban
+	TokenCutoffs  map[string]time.Time           `json:"token_cutoffs,omitempty"` // user ID -> tokens_valid_after
+	RevokedTokens map[string]time.Time           `json:"revoked_tokens,omitempty"`
+	Logins        map[string][]model.LoginRecord `json:"logins,omitempty"`
+	Identities    []snapshotIdentity             `json:"identities,omitempty"`
+}
+
+type snapshotUser struct {
+	User         model.User `json:"user"`
+	Email        string     `json:"email"`
+	PasswordHash string     `json:"password_hash"`
+	Anonymized   bool       `json:"anonymized,omitempty"`
+
+	Preferences *model.UserPreferences `json:"preferences,omitempty"`
+}
+
+type snapshotIdentity struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type snapshotBan struct {
+	Until  *time.Time `json:"until,omitempty"`
+	Reason string     `json:"reason"`
+}
+
+// LoadSnapshot restores accounts saved by SaveSnapshot. A missing file is
+// not an error: it's the first boot.
+func (s *AuthService) LoadSnapshot(path string) error {
+	if s.persistent() {
+		return fmt.Errorf("auth snapshots are only for in-memory mode")
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read auth snapshot: %w", err)
+	}
+	var snap authSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("failed to parse auth snapshot %s: %w", path, err)
+	}
+	if snap.Version != authSnapshotVersion {
+		return fmt.Errorf("auth snapshot %s has version %d, want %d", path, snap.Version, authSnapshotVersion)
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	for _, u := range snap.Users {
+		// Ban fields are filled in from the ban state when a user is read.
+		u.User.Banned, u.User.BannedUntil, u.User.BanReason = false, nil, ""
+		s.users[u.Email] = &userRecord{User: u.User, Email: u.Email, PasswordHash: u.PasswordHash, Anonymized: u.Anonymized,
+			Preferences: u.Preferences}
+		s.termsCache.Store(u.User.ID, u.User.TermsVersion)
+	}
+	for userID, schoolID := range snap.Students {
+		s.students[userID] = schoolID
+	}
+	for userID, logins := range snap.Logins {
+		s.logins[userID] = logins
+	}
+	for _, id := range snap.Identities {
+		s.identities[identityKey(id.Provider, id.Subject)] = &model.Identity{ID: id.ID, UserID: id.UserID, Provider: id.Provider,
+			Subject: id.Subject, Email: id.Email, CreatedAt: id.CreatedAt}
+	}
+	s.mu.Unlock()
+
+	for userID, b := range snap.Bans {
+		state := banState{reason: b.Reason, checked: now}
+		if b.Until != nil {
+			state.until = *b.Until
+		}
+		s.bans.Store(userID, state)
+	}
+	for userID, after := range snap.TokenCutoffs {
+		s.tokenCutoffs.Store(userID, tokenCutoff{after: after, checked: now})
+	}
+	for jti, exp := range snap.RevokedTokens {
+		if now.Before(exp) {
+			s.revoked.add(jti, exp)
+		}
+	}
+	log.Printf("Loaded %d accounts from auth snapshot %s", len(snap.Users), path)
+	return nil
+}
+
+// SaveSnapshot writes the in-memory accounts to path, replacing the file
+// atomically. It skips the write when nothing changed since the last save.
+func (s *AuthService) SaveSnapshot(path string) error {
+	if s.persistent() {
+		return fmt.Errorf("auth snapshots are only for in-memory mode")
+	}
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	snap := authSnapshot{
+		Version:       authSnapshotVersion,
+		Users:         []snapshotUser{},
+		Students:      map[string]string{},
+		Bans:          map[string]snapshotBan{},
+		TokenCutoffs:  map[string]time.Time{},
+		RevokedTokens: map[string]time.Time{},
+		Logins:        map[string][]model.LoginRecord{},
+	}
+
+	s.mu.RLock()
+	for _, rec := range s.users {
+		snap.Users = append(snap.Users, snapshotUser{User: rec.User, Email: rec.Email, PasswordHash: rec.PasswordHash, Anonymized: rec.Anonymized,
+			Preferences: rec.Preferences})
+	}
+	for userID, schoolID := range s.students {
+		snap.Students[userID] = schoolID
+	}
+	for userID, logins := range s.logins {
+		snap.Logins[userID] = logins
+	}
+	for _, id := range s.identities {
+		snap.Identities = append(snap.Identities, snapshotIdentity{ID: id.ID, UserID: id.UserID, Provider: id.Provider,
+			Subject: id.Subject, Email: id.Email, CreatedAt: id.CreatedAt})
+	}
+	s.mu.RUnlock()
+
+	s.bans.Range(func(k, v any) bool {
+		if b := v.(banState); b.reason != "" {
+			sb := snapshotBan{Reason: b.reason}
+			if !b.until.IsZero() {
+				until := b.until
+				sb.Until = &until
+			}
+			snap.Bans[k.(string)] = sb
+		}
+		return true
+	})
+	s.tokenCutoffs.Range(func(k, v any) bool {
+		if c := v.(tokenCutoff); !c.after.IsZero() {
+			snap.TokenCutoffs[k.(string)] = c.after
+		}
+		return true
+	})
+	s.revoked.mu.RLock()
+	for jti, exp := range s.revoked.ids {
+		snap.RevokedTokens[jti] = exp
+	}
+	s.revoked.mu.RUnlock()
+
+	// Map keys are encoded sorted; sort users and identities too so an
+	// unchanged store encodes to the same bytes.
+	sort.Slice(snap.Users, func(i, j int) bool { return snap.Users[i].User.ID < snap.Users[j].User.ID })
+	sort.Slice(snap.Identities, func(i, j int) bool { return snap.Identities[i].ID < snap.Identities[j].ID })
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode auth snapshot: %w", err)
+	}
+
+	if bytes.Equal(data, s.snapshotSaved) {
+		return nil
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write auth snapshot: %w", err)
+	}
+	s.snapshotSaved = data
+	return nil
+}
+
+// StartSnapshots saves the accounts to cfg.Path every cfg.Interval.
+func (s *AuthService) StartSnapshots(cfg AuthSnapshotConfig) {
+	go func() {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := s.SaveSnapshot(cfg.Path); err != nil {
+				log.Printf("WARNING: %v", err)
+			}
+		}
+	}()
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it over path, so a crash mid-write never leaves a truncated snapshot. The
+// file holds password hashes, so only the owner can read it.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"log"
+	"net/http"
+
+	"github.com/ratemybars/backend/internal/model"
+	"github.com/ratemybars/backend/internal/storage"
+)
+
+// avatarSize is the width and height avatars are stored at.
+const avatarSize = 256
+
+// AvatarService turns uploaded images into square JPEG avatars, stores them
+// and saves the URL on the user.
+type AvatarService struct {
+	auth      *AuthService
+	store     storage.Store
+	maxBytes  int64
+	maxPixels int
+}
+
+// NewAvatarService applies the photo upload limits to avatars too.
+func NewAvatarService(auth *AuthService, store storage.Store, cfg PhotoConfig) *AvatarService {
+	return &AvatarService{auth: auth, store: store, maxBytes: cfg.MaxBytes, maxPixels: cfg.MaxPixels}
+}
+
+// MaxBytes is the largest accepted upload.
+func (s *AvatarService) MaxBytes() int64 {
+	return s.maxBytes
+}
+
+// Upload replaces userID's avatar. The image is center-cropped, resized and
+// re-encoded, which also strips EXIF and any other metadata.
+func (s *AvatarService) Upload(ctx context.Context, userID string, data []byte) (*model.User, error) {
+	if int64(len(data)) > s.maxBytes {
+		return nil, fmt.Errorf("avatar exceeds %d bytes", s.maxBytes)
+	}
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+	default:
+		return nil, fmt.Errorf("unsupported image type: %s", contentType)
+	}
+	conf, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image")
+	}
+	if conf.Width*conf.Height > s.maxPixels {
+		return nil, fmt.Errorf("image dimensions too large")
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image")
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, squareThumbnail(img, avatarSize), &jpeg.Options{Quality: 85}); err != nil {
+		return nil, fmt.Errorf("failed to encode avatar: %w", err)
+	}
+
+	previous, err := s.auth.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("avatars/%s/%s.jpg", userID, generateID()[:16])
+	url, err := s.store.Put(ctx, key, "image/jpeg", buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.auth.SetAvatarURL(userID, url)
+	if err != nil {
+		s.remove(ctx, key)
+		return nil, err
+	}
+	// Only avatars we stored are ours to delete; pasted URLs point elsewhere.
+	if old := s.store.KeyFromURL(previous.AvatarURL); old != "" {
+		s.remove(ctx, old)
+	}
+	return user, nil
+}
+
+func (s *AvatarService) remove(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil {
+		log.Printf("WARNING: Failed to delete avatar %s: %v", key, err)
+	}
+}
+
+// squareThumbnail center-crops img to a square and box-filters it down to
+// size x size (smaller images are scaled up the same way). Each output pixel
+// averages at most 4x4 samples, which keeps large uploads cheap. Transparent
+// areas are flattened onto white since JPEG has no alpha channel.
+func squareThumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	step := max(side/size/4, 1)
+	for dy := 0; dy < size; dy++ {
+		sy0, sy1 := y0+dy*side/size, y0+max((dy+1)*side/size, dy*side/size+1)
+		for dx := 0; dx < size; dx++ {
+			sx0, sx1 := x0+dx*side/size, x0+max((dx+1)*side/size, dx*side/size+1)
+			var r, g, bl, a, n uint32
+			for y := sy0; y < sy1; y += step {
+				for x := sx0; x < sx1; x += step {
+					cr, cg, cb, ca := img.At(x, y).RGBA()
+					r, g, bl, a, n = r+cr, g+cg, bl+cb, a+ca, n+1
+				}
+			}
+			r, g, bl, a = r/n, g/n, bl/n, a/n
+			white := 0xffff - a // colors are alpha-premultiplied
+			dst.SetRGBA(dx, dy, color.RGBA{
+				R: uint8((r + white) >> 8), G: uint8((g + white) >> 8), B: uint8((bl + white) >> 8), A: 0xff,
+			})
+		}
+	}
+	return dst
+}
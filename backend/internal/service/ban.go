@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	maxBanReasonLength = 500
+	maxBanHours        = 24 * 365
+)
+
+// banState is a user's ban as cached by AuthService.bans. A ban is active
+// while reason is set and until is zero (permanent) or in the future.
+type banState struct {
+	until   time.Time
+	reason  string
+	checked time.Time
+}
+
+func (b banState) active(now time.Time) bool {
+	return b.reason != "" && (b.until.IsZero() || now.Before(b.until))
+}
+
+// BanStatus reports whether a user is banned, until when (zero for a
+// permanent ban) and why. In database mode the answer is cached for up to
+// tokenCutoffRecheck, like token cutoffs.
+func (s *AuthService) BanStatus(userID string) (time.Time, string, bool) {
+	b := s.banState(userID)
+	if !b.active(time.Now()) {
+		return time.Time{}, "", false
+	}
+	return b.until, b.reason, true
+}
+
+func (s *AuthService) banState(userID string) banState {
+	v, cached := s.bans.Load(userID)
+	if cached && (!s.persistent() || time.Since(v.(banState).checked) < tokenCutoffRecheck) {
+		return v.(banState)
+	}
+	if !s.persistent() {
+		return banState{}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var until *time.Time
+	var reason string
+	err := s.queries.QueryRow(ctx, "user_ban", userID).Scan(&until, &reason)
+	if err != nil && err != pgx.ErrNoRows {
+		log.Printf("WARNING: Failed to check ban: %v", err)
+		if cached {
+			return v.(banState)
+		}
+		return banState{}
+	}
+	b := banState{reason: reason, checked: time.Now()}
+	if until != nil {
+		b.until = *until
+	}
+	s.bans.Store(userID, b)
+	return b
+}
+
+// applyBan fills in a user's ban fields.
+func (s *AuthService) applyBan(user *model.User) {
+	if until, reason, banned := s.BanStatus(user.ID); banned {
+		user.Banned = true
+		user.BanReason = reason
+		if !until.IsZero() {
+			user.BannedUntil = &until
+		}
+	}
+}
+
+// BanUser suspends a user for req.DurationHours, or permanently when it is
+// zero. Banned users can still sign in and read but every write is refused.
+// Admins can't be banned.
+func (s *AuthService) BanUser(userID string, req model.BanUserRequest) (*model.UserBan, error) {
+	reason := strings.TrimSpace(middleware.SanitizeString(req.Reason))
+	if reason == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+	if len(reason) > maxBanReasonLength {
+		return nil, fmt.Errorf("reason must be at most %d characters", maxBanReasonLength)
+	}
+	if req.DurationHours < 0 || req.DurationHours > maxBanHours {
+		return nil, fmt.Errorf("duration_hours must be between 0 (permanent) and %d", maxBanHours)
+	}
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == "admin" {
+		return nil, fmt.Errorf("cannot ban an admin")
+	}
+
+	ban := &model.UserBan{UserID: userID, Reason: reason, Permanent: req.DurationHours == 0}
+	now := time.Now()
+	var until *time.Time
+	if !ban.Permanent {
+		t := now.Add(time.Duration(req.DurationHours) * time.Hour)
+		until = &t
+		ban.BannedUntil = until
+	}
+	// Existing tokens are revoked too, so a ban also signs out whoever may
+	// have taken over the account.
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := s.pool.Exec(ctx,
+			`UPDATE users SET banned_until = $1, ban_reason = $2, tokens_valid_after = $3 WHERE id = $4`,
+			until, reason, now, userID); err != nil {
+			return nil, fmt.Errorf("failed to ban user: %w", err)
+		}
+	}
+	s.invalidateTokens(userID, now)
+
+	b := banState{reason: reason, checked: time.Now()}
+	if until != nil {
+		b.until = *until
+	}
+	s.bans.Store(userID, b)
+	return ban, nil
+}
+
+// UnbanUser lifts a user's ban.
+func (s *AuthService) UnbanUser(userID string) error {
+	if _, _, banned := s.BanStatus(userID); !banned {
+		if _, err := s.GetUser(userID); err != nil {
+			return err
+		}
+		return fmt.Errorf("user is not banned")
+	}
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := s.pool.Exec(ctx,
+			`UPDATE users SET banned_until = NULL, ban_reason = NULL WHERE id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to unban user: %w", err)
+		}
+	}
+	s.bans.Store(userID, banState{checked: time.Now()})
+	return nil
+}
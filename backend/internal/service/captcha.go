@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const defaultCaptchaLoginAfter = 3
+
+// CaptchaConfig selects the CAPTCHA provider checked at signup and on logins
+// after repeated failures. The feature is off unless Provider is set.
+type CaptchaConfig struct {
+	Provider   string // "turnstile" or "hcaptcha"
+	SiteKey    string // public key the frontend renders the widget with
+	Secret     string
+	VerifyURL  string // overrides the provider's siteverify URL
+	LoginAfter int    // failed logins (per email or IP) before logins need a token
+}
+
+// LoadCaptchaConfig reads CAPTCHA_PROVIDER (turnstile or hcaptcha; unset
+// disables CAPTCHAs), CAPTCHA_SITE_KEY, CAPTCHA_SECRET, CAPTCHA_VERIFY_URL
+// and CAPTCHA_LOGIN_AFTER (default 3).
+func LoadCaptchaConfig() CaptchaConfig {
+	cfg := CaptchaConfig{
+		Provider:   strings.ToLower(strings.TrimSpace(os.Getenv("CAPTCHA_PROVIDER"))),
+		SiteKey:    os.Getenv("CAPTCHA_SITE_KEY"),
+		Secret:     os.Getenv("CAPTCHA_SECRET"),
+		VerifyURL:  os.Getenv("CAPTCHA_VERIFY_URL"),
+		LoginAfter: defaultCaptchaLoginAfter,
+	}
+	if v, err := strconv.Atoi(os.Getenv("CAPTCHA_LOGIN_AFTER")); err == nil && v >= 0 {
+		cfg.LoginAfter = v
+	}
+	return cfg
+}
+
+// CaptchaService verifies tokens from a Turnstile or hCaptcha widget. Both
+// providers share the siteverify protocol. A nil service accepts everything.
+type CaptchaService struct {
+	provider   string
+	siteKey    string
+	secret     string
+	verifyURL  string
+	loginAfter int
+	client     *http.Client
+}
+
+// NewCaptchaService returns nil when no provider is configured or the
+// configuration is unusable.
+func NewCaptchaService(cfg CaptchaConfig) *CaptchaService {
+	verifyURL := cfg.VerifyURL
+	switch cfg.Provider {
+	case "":
+		return nil
+	case "turnstile":
+		if verifyURL == "" {
+			verifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+		}
+	case "hcaptcha":
+		if verifyURL == "" {
+			verifyURL = "https://api.hcaptcha.com/siteverify"
+		}
+	default:
+		log.Printf("WARNING: Unknown CAPTCHA_PROVIDER %q, CAPTCHA disabled", cfg.Provider)
+		return nil
+	}
+	if cfg.Secret == "" {
+		log.Printf("WARNING: CAPTCHA_SECRET not set, CAPTCHA disabled")
+		return nil
+	}
+	return &CaptchaService{
+		provider:   cfg.Provider,
+		siteKey:    cfg.SiteKey,
+		secret:     cfg.Secret,
+		verifyURL:  verifyURL,
+		loginAfter: cfg.LoginAfter,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Config is what the frontend needs to render the widget.
+func (s *CaptchaService) Config() model.CaptchaConfig {
+	if s == nil {
+		return model.CaptchaConfig{}
+	}
+	return model.CaptchaConfig{
+		Enabled:    true,
+		Provider:   s.provider,
+		SiteKey:    s.siteKey,
+		LoginAfter: s.loginAfter,
+	}
+}
+
+// Verify checks a widget token with the provider. remoteIP is optional.
+func (s *CaptchaService) Verify(ctx context.Context, token, remoteIP string) error {
+	if s == nil {
+		return nil
+	}
+	if token == "" {
+		return fmt.Errorf("captcha_token is required")
+	}
+	form := url.Values{"secret": {s.secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to verify captcha: unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	if !body.Success {
+		if len(body.ErrorCodes) > 0 {
+			log.Printf("Captcha rejected: %s", strings.Join(body.ErrorCodes, ", "))
+		}
+		return fmt.Errorf("captcha verification failed")
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	maxChangelogTitleLength   = 120
+	maxChangelogBodyLength    = 4000
+	maxChangelogVersionLength = 40
+)
+
+// ChangelogService keeps the admin-written "what's new" list.
+type ChangelogService struct {
+	mu      sync.RWMutex
+	pool    *pgxpool.Pool
+	entries []model.ChangelogEntry
+}
+
+func NewChangelogService(pool *pgxpool.Pool) *ChangelogService {
+	svc := &ChangelogService{
+		pool:    pool,
+		entries: []model.ChangelogEntry{},
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *ChangelogService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, title, COALESCE(body,''), COALESCE(version,''), published_at, created_by
+		 FROM changelog_entries`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load changelog from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e model.ChangelogEntry
+		if err := rows.Scan(&e.ID, &e.Title, &e.Body, &e.Version, &e.PublishedAt, &e.CreatedByID); err != nil {
+			log.Printf("WARNING: Failed to scan changelog row: %v", err)
+			continue
+		}
+		s.entries = append(s.entries, e)
+	}
+	log.Printf("Loaded %d changelog entries from DB", len(s.entries))
+}
+
+func validateChangelog(req *model.ChangelogRequest) error {
+	req.Title = strings.TrimSpace(middleware.SanitizeString(req.Title))
+	req.Body = strings.TrimSpace(middleware.SanitizeString(req.Body))
+	req.Version = strings.TrimSpace(middleware.SanitizeString(req.Version))
+	if req.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if len(req.Title) > maxChangelogTitleLength {
+		return fmt.Errorf("title must be at most %d characters", maxChangelogTitleLength)
+	}
+	if len(req.Body) > maxChangelogBodyLength {
+		return fmt.Errorf("body must be at most %d characters", maxChangelogBodyLength)
+	}
+	if len(req.Version) > maxChangelogVersionLength {
+		return fmt.Errorf("version must be at most %d characters", maxChangelogVersionLength)
+	}
+	return nil
+}
+
+// Create adds an entry (admin only). A future published_at keeps it out of
+// the public list until then.
+func (s *ChangelogService) Create(ctx context.Context, req model.ChangelogRequest) (*model.ChangelogEntry, error) {
+	if err := validateChangelog(&req); err != nil {
+		return nil, err
+	}
+	e := model.ChangelogEntry{
+		ID:          "change_" + generateID()[:16],
+		Title:       req.Title,
+		Body:        req.Body,
+		Version:     req.Version,
+		PublishedAt: time.Now(),
+		CreatedByID: middleware.GetUserID(ctx),
+	}
+	if req.PublishedAt != nil {
+		e.PublishedAt = *req.PublishedAt
+	}
+
+	s.mu.Lock()
+	s.entries = append(s.entries, e)
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO changelog_entries (id, title, body, version, published_at, created_by)
+			 VALUES ($1, $2, $3, $4, $5, $6)`,
+			e.ID, e.Title, e.Body, e.Version, e.PublishedAt, e.CreatedByID)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist changelog entry: %v", err)
+		}
+	}
+	return &e, nil
+}
+
+// Update replaces an entry's text (admin only). published_at is kept unless
+// given.
+func (s *ChangelogService) Update(id string, req model.ChangelogRequest) (*model.ChangelogEntry, error) {
+	if err := validateChangelog(&req); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	var e *model.ChangelogEntry
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			e = &s.entries[i]
+			break
+		}
+	}
+	if e == nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("changelog entry not found")
+	}
+	e.Title, e.Body, e.Version = req.Title, req.Body, req.Version
+	if req.PublishedAt != nil {
+		e.PublishedAt = *req.PublishedAt
+	}
+	out := *e
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`UPDATE changelog_entries SET title = $1, body = $2, version = $3, published_at = $4 WHERE id = $5`,
+			out.Title, out.Body, out.Version, out.PublishedAt, id)
+		if err != nil {
+			log.Printf("WARNING: Failed to update changelog entry: %v", err)
+		}
+	}
+	return &out, nil
+}
+
+// Delete removes an entry (admin only).
+func (s *ChangelogService) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			if s.pool != nil {
+				if _, err := s.pool.Exec(context.Background(), `DELETE FROM changelog_entries WHERE id=$1`, id); err != nil {
+					log.Printf("WARNING: Failed to delete changelog entry from DB: %v", err)
+				}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("changelog entry not found")
+}
+
+// List returns up to limit entries, newest first. Entries scheduled for
+// later, and who wrote each entry, are only included with all (the admin
+// view).
+func (s *ChangelogService) List(limit int, all bool, now time.Time) []model.ChangelogEntry {
+	s.mu.RLock()
+	out := []model.ChangelogEntry{}
+	for _, e := range s.entries {
+		if all {
+			out = append(out, e)
+		} else if !now.Before(e.PublishedAt) {
+			e.CreatedByID = ""
+			out = append(out, e)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].PublishedAt.After(out[j].PublishedAt) })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
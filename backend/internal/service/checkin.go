@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// checkInCooldown limits how often one user can check in at the same venue.
+const checkInCooldown = time.Hour
+
+// VisitConfig controls geofenced check-ins and the "verified visit" badge
+// they give to ratings.
+type VisitConfig struct {
+	GeofenceMeters float64       // how close to the venue a check-in must be to count as verified
+	Window         time.Duration // how long after a verified check-in a rating is badged
+	Weight         float64       // weight of a verified rating in venue averages (others weigh 1)
+}
+
+// LoadVisitConfig reads CHECKIN_GEOFENCE_METERS, VERIFIED_VISIT_WINDOW_HOURS
+// and VERIFIED_VISIT_WEIGHT.
+func LoadVisitConfig() VisitConfig {
+	cfg := VisitConfig{
+		GeofenceMeters: 150,
+		Window:         12 * time.Hour,
+		Weight:         2,
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("CHECKIN_GEOFENCE_METERS"), 64); err == nil && v > 0 {
+		cfg.GeofenceMeters = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("VERIFIED_VISIT_WINDOW_HOURS")); err == nil && v > 0 {
+		cfg.Window = time.Duration(v) * time.Hour
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("VERIFIED_VISIT_WEIGHT"), 64); err == nil && v >= 1 {
+		cfg.Weight = v
+	}
+	return cfg
+}
+
+// CheckInService records users checking in at venues.
+type CheckInService struct {
+	mu       sync.RWMutex
+	pool     *pgxpool.Pool
+	cfg      VisitConfig
+	checkIns []model.CheckIn
+	notify   CheckInNotifyFunc
+}
+
+// CheckInNotifyFunc is called after every new check-in.
+type CheckInNotifyFunc func(c model.CheckIn)
+
+func NewCheckInService(pool *pgxpool.Pool, cfg VisitConfig) *CheckInService {
+	svc := &CheckInService{
+		pool:     pool,
+		cfg:      cfg,
+		checkIns: []model.CheckIn{},
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *CheckInService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, venue_id, user_id, created_at, verified FROM check_ins ORDER BY created_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load check-ins from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c model.CheckIn
+		if err := rows.Scan(&c.ID, &c.VenueID, &c.UserID, &c.CreatedAt, &c.Verified); err != nil {
+			log.Printf("WARNING: Failed to scan check-in row: %v", err)
+			continue
+		}
+		s.checkIns = append(s.checkIns, c)
+	}
+	log.Printf("Loaded %d check-ins from DB", len(s.checkIns))
+}
+
+// SetNotifier registers a hook called after each check-in (e.g. to schedule
+// a rating reminder).
+func (s *CheckInService) SetNotifier(fn CheckInNotifyFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notify = fn
+}
+
+// Config returns the verified-visit settings in effect.
+func (s *CheckInService) Config() VisitConfig {
+	return s.cfg
+}
+
+// InGeofence reports whether a location is close enough to a venue for a
+// check-in there to be verified.
+func (s *CheckInService) InGeofence(venue *model.Venue, lat, lng float64) bool {
+	if venue.Latitude == 0 && venue.Longitude == 0 {
+		return false
+	}
+	return haversineKm(lat, lng, venue.Latitude, venue.Longitude)*1000 <= s.cfg.GeofenceMeters
+}
+
+// CheckIn records the current user at a venue. verified marks a check-in
+// whose location was confirmed inside the venue's geofence.
+func (s *CheckInService) CheckIn(ctx context.Context, venueID string, verified bool) (*model.CheckIn, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	for i := len(s.checkIns) - 1; i >= 0; i-- {
+		c := s.checkIns[i]
+		if now.Sub(c.CreatedAt) > checkInCooldown {
+			break
+		}
+		if c.UserID == userID && c.VenueID == venueID {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("you already checked in here recently")
+		}
+	}
+	c := model.CheckIn{
+		ID:        "checkin_" + generateID()[:16],
+		VenueID:   venueID,
+		UserID:    userID,
+		CreatedAt: now,
+		Verified:  verified,
+	}
+	s.checkIns = append(s.checkIns, c)
+	notify := s.notify
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO check_ins (id, venue_id, user_id, created_at, verified) VALUES ($1, $2, $3, $4, $5)`,
+			c.ID, c.VenueID, c.UserID, c.CreatedAt, c.Verified)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist check-in: %v", err)
+		}
+	}
+	if notify != nil {
+		notify(c)
+	}
+	return &c, nil
+}
+
+// HasVerifiedVisit reports whether a user had a verified check-in at a venue
+// within the verified-visit window before at.
+func (s *CheckInService) HasVerifiedVisit(userID, venueID string, at time.Time) bool {
+	since := at.Add(-s.cfg.Window)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i := len(s.checkIns) - 1; i >= 0; i-- {
+		c := s.checkIns[i]
+		if c.CreatedAt.Before(since) {
+			break
+		}
+		if c.Verified && c.UserID == userID && c.VenueID == venueID && !c.CreatedAt.After(at) {
+			return true
+		}
+	}
+	return false
+}
+
+// LastActiveByUser returns when each user last checked in anywhere.
+func (s *CheckInService) LastActiveByUser() map[string]time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]time.Time)
+	for _, c := range s.checkIns {
+		if c.CreatedAt.After(out[c.UserID]) {
+			out[c.UserID] = c.CreatedAt
+		}
+	}
+	return out
+}
+
+// CountSince returns how many check-ins a venue has had since the given time.
+func (s *CheckInService) CountSince(venueID string, since time.Time) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n := 0
+	for i := len(s.checkIns) - 1; i >= 0; i-- {
+		if s.checkIns[i].CreatedAt.Before(since) {
+			break
+		}
+		if s.checkIns[i].VenueID == venueID {
+			n++
+		}
+	}
+	return n
+}
+
+// CountsByVenue returns each venue's number of check-ins since the given time.
+func (s *CheckInService) CountsByVenue(since time.Time) map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]int)
+	for i := len(s.checkIns) - 1; i >= 0; i-- {
+		if s.checkIns[i].CreatedAt.Before(since) {
+			break
+		}
+		out[s.checkIns[i].VenueID]++
+	}
+	return out
+}
+
+// Since returns all check-ins at the given venues created at or after since.
+func (s *CheckInService) Since(venueIDs []string, since time.Time) []model.CheckIn {
+	idSet := make(map[string]bool, len(venueIDs))
+	for _, id := range venueIDs {
+		idSet[id] = true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []model.CheckIn
+	for i := len(s.checkIns) - 1; i >= 0; i-- {
+		if s.checkIns[i].CreatedAt.Before(since) {
+			break
+		}
+		if idSet[s.checkIns[i].VenueID] {
+			out = append(out, s.checkIns[i])
+		}
+	}
+	return out
+}
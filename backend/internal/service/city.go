@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// cityLeaderboardSize is how many venues a city page ranks.
+const cityLeaderboardSize = 10
+
+// cityAbbreviations expands the leading words schools and addresses spell
+// inconsistently ("St. Paul", "ST PAUL", "Saint Paul").
+var cityAbbreviations = map[string]string{
+	"st":  "saint",
+	"ste": "sainte",
+	"ft":  "fort",
+	"mt":  "mount",
+	"pt":  "port",
+}
+
+// NormalizeCity turns a city name into the slug used in city URLs:
+// "St. Louis" and "SAINT LOUIS" both become "saint-louis".
+func NormalizeCity(name string) string {
+	name = strings.NewReplacer(".", "", "'", "", "’", "").Replace(strings.ToLower(name))
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		if full, ok := cityAbbreviations[w]; ok {
+			words[i] = full
+		}
+	}
+	return strings.Join(words, "-")
+}
+
+// addressCity picks the city out of a "street, City, ST 12345" address, or
+// returns "" if the address doesn't have that shape.
+func addressCity(address, state string) string {
+	parts := strings.Split(address, ",")
+	for i := 1; i < len(parts); i++ {
+		fields := strings.Fields(parts[i])
+		if len(fields) > 0 && strings.EqualFold(fields[0], state) {
+			return strings.TrimSpace(parts[i-1])
+		}
+	}
+	return ""
+}
+
+// CityService aggregates the schools and venues of a city, so metro areas
+// with several campuses get one page.
+type CityService struct {
+	schools *SchoolService
+	venues  *VenueService
+}
+
+func NewCityService(schools *SchoolService, venues *VenueService) *CityService {
+	return &CityService{schools: schools, venues: venues}
+}
+
+// Get returns the city page for a state and city name or slug.
+func (s *CityService) Get(state, city string) (*model.CityPage, error) {
+	state = strings.ToUpper(strings.TrimSpace(state))
+	slug := NormalizeCity(city)
+	if state == "" || slug == "" {
+		return nil, fmt.Errorf("city not found")
+	}
+
+	page := &model.CityPage{
+		State:       state,
+		Slug:        slug,
+		Schools:     s.schools.InCity(state, slug),
+		Venues:      []model.Venue{},
+		Leaderboard: []model.Venue{},
+	}
+	spellings := make(map[string]int)
+	for _, school := range page.Schools {
+		spellings[school.City]++
+	}
+
+	// Venues belong to the city in their address, falling back to their
+	// school's city, so a bar across the line from campus lands where it is.
+	for _, v := range s.venues.GetAllVenues() {
+		school, err := s.schools.GetByID(context.Background(), v.SchoolID)
+		if err != nil || school.State != state {
+			continue
+		}
+		name := addressCity(v.Address, state)
+		if name == "" {
+			name = school.City
+		}
+		if NormalizeCity(name) != slug {
+			continue
+		}
+		spellings[name]++
+		page.Venues = append(page.Venues, v)
+	}
+	if len(page.Schools) == 0 && len(page.Venues) == 0 {
+		return nil, fmt.Errorf("city not found")
+	}
+	page.Name = displayCityName(spellings)
+
+	var total float64
+	for _, v := range page.Venues {
+		if v.RatingCount > 0 {
+			total += v.AvgRating * float64(v.RatingCount)
+			page.RatingCount += v.RatingCount
+			page.Leaderboard = append(page.Leaderboard, v)
+		}
+	}
+	if page.RatingCount > 0 {
+		page.AvgRating = RoundAverage(total / float64(page.RatingCount))
+	}
+	sort.Slice(page.Venues, func(i, j int) bool { return page.Venues[i].Name < page.Venues[j].Name })
+	sort.SliceStable(page.Leaderboard, func(i, j int) bool {
+		a, b := page.Leaderboard[i], page.Leaderboard[j]
+		if a.AvgRating != b.AvgRating {
+			return a.AvgRating > b.AvgRating
+		}
+		return a.RatingCount > b.RatingCount
+	})
+	if len(page.Leaderboard) > cityLeaderboardSize {
+		page.Leaderboard = page.Leaderboard[:cityLeaderboardSize]
+	}
+	return page, nil
+}
+
+// displayCityName picks the most common spelling, preferring mixed case over
+// all-caps data entry.
+func displayCityName(spellings map[string]int) string {
+	best, bestN := "", 0
+	for name, n := range spellings {
+		if name == strings.ToUpper(name) {
+			n = 0 // only used if nothing better exists
+		}
+		if best == "" || n > bestN || n == bestN && name < best {
+			best, bestN = name, n
+		}
+	}
+	return best
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	claimCodeTTL         = 30 * 24 * time.Hour
+	maxClaimCodeAttempts = 5
+)
+
+const claimCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+type claimRecord struct {
+	model.VenueClaim
+	codeHash string
+}
+
+// ClaimService manages venue ownership claims. An admin issues a one-time code
+// for a pending claim and communicates it offline (phone call, postcard to the
+// venue's address); the claimant proves they're on premises by entering it.
+type ClaimService struct {
+	mu     sync.RWMutex
+	pool   *pgxpool.Pool
+	claims []*claimRecord
+}
+
+func NewClaimService(pool *pgxpool.Pool) *ClaimService {
+	svc := &ClaimService{pool: pool}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *ClaimService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, venue_id, user_id, COALESCE(username,''), status, COALESCE(message,''), COALESCE(code_hash,''),
+		        created_at, code_issued_at, verified_at, attempts
+		 FROM venue_claims ORDER BY created_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load venue claims from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c claimRecord
+		if err := rows.Scan(&c.ID, &c.VenueID, &c.UserID, &c.Username, &c.Status, &c.Message, &c.codeHash,
+			&c.CreatedAt, &c.CodeIssuedAt, &c.VerifiedAt, &c.Attempts); err != nil {
+			log.Printf("WARNING: Failed to scan venue claim row: %v", err)
+			continue
+		}
+		s.claims = append(s.claims, &c)
+	}
+	log.Printf("Loaded %d venue claims from DB", len(s.claims))
+}
+
+// Request files a claim on a venue for the current user.
+func (s *ClaimService) Request(ctx context.Context, venueID, message string) (*model.VenueClaim, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.claims {
+		if c.VenueID != venueID {
+			continue
+		}
+		if c.Status == "verified" {
+			return nil, fmt.Errorf("venue has already been claimed")
+		}
+		if c.UserID == userID && c.Status != "rejected" {
+			return nil, fmt.Errorf("you already have an open claim on this venue")
+		}
+	}
+
+	c := &claimRecord{VenueClaim: model.VenueClaim{
+		ID:        "claim_" + generateID()[:16],
+		VenueID:   venueID,
+		UserID:    userID,
+		Username:  middleware.GetUsername(ctx),
+		Status:    "pending",
+		Message:   middleware.SanitizeString(message),
+		CreatedAt: time.Now(),
+	}}
+	s.claims = append(s.claims, c)
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO venue_claims (id, venue_id, user_id, username, status, message, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			c.ID, c.VenueID, c.UserID, c.Username, c.Status, c.Message, c.CreatedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist venue claim: %v", err)
+		}
+	}
+
+	out := c.VenueClaim
+	return &out, nil
+}
+
+// ListOpen returns claims awaiting a code or verification (admin only).
+func (s *ClaimService) ListOpen() []model.VenueClaim {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := []model.VenueClaim{}
+	for _, c := range s.claims {
+		if c.Status == "pending" || c.Status == "code_issued" {
+			out = append(out, c.VenueClaim)
+		}
+	}
+	return out
+}
+
+// IssueCode generates a fresh one-time verification code for a claim and
+// returns it in plain text. Only its hash is stored.
+func (s *ClaimService) IssueCode(claimID string) (string, *model.VenueClaim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.find(claimID)
+	if c == nil {
+		return "", nil, fmt.Errorf("claim not found")
+	}
+	if c.Status != "pending" && c.Status != "code_issued" {
+		return "", nil, fmt.Errorf("claim is already %s", c.Status)
+	}
+
+	code := newClaimCode()
+	now := time.Now()
+	c.codeHash = hashClaimCode(code)
+	c.Status = "code_issued"
+	c.CodeIssuedAt = &now
+	c.Attempts = 0
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`UPDATE venue_claims SET status=$1, code_hash=$2, code_issued_at=$3, attempts=0 WHERE id=$4`,
+			c.Status, c.codeHash, now, c.ID)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist claim code: %v", err)
+		}
+	}
+
+	out := c.VenueClaim
+	return code, &out, nil
+}
+
+// Reject closes a claim without granting ownership (admin only).
+func (s *ClaimService) Reject(claimID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.find(claimID)
+	if c == nil {
+		return fmt.Errorf("claim not found")
+	}
+	if c.Status == "verified" {
+		return fmt.Errorf("claim is already verified")
+	}
+	c.Status = "rejected"
+	c.codeHash = ""
+	s.persistStatus(c)
+	return nil
+}
+
+// Verify checks a code entered by the claimant. On success the claim is
+// marked verified and the caller should grant the owner role.
+func (s *ClaimService) Verify(ctx context.Context, venueID, code string) (*model.VenueClaim, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var c *claimRecord
+	for _, rec := range s.claims {
+		if rec.VenueID == venueID && rec.UserID == userID && rec.Status == "code_issued" {
+			c = rec
+			break
+		}
+	}
+	if c == nil {
+		return nil, fmt.Errorf("no verification code has been issued for your claim")
+	}
+	if c.CodeIssuedAt != nil && time.Since(*c.CodeIssuedAt) > claimCodeTTL {
+		return nil, fmt.Errorf("verification code has expired, please contact support for a new one")
+	}
+	if c.Attempts >= maxClaimCodeAttempts {
+		return nil, fmt.Errorf("too many incorrect attempts, please contact support for a new code")
+	}
+
+	given := hashClaimCode(code)
+	if subtle.ConstantTimeCompare([]byte(given), []byte(c.codeHash)) != 1 {
+		c.Attempts++
+		if s.pool != nil {
+			_, err := s.pool.Exec(context.Background(),
+				`UPDATE venue_claims SET attempts=$1 WHERE id=$2`, c.Attempts, c.ID)
+			if err != nil {
+				log.Printf("WARNING: Failed to persist claim attempt: %v", err)
+			}
+		}
+		return nil, fmt.Errorf("incorrect verification code")
+	}
+
+	now := time.Now()
+	c.Status = "verified"
+	c.VerifiedAt = &now
+	c.codeHash = ""
+	s.persistStatus(c)
+
+	// Any other open claims on this venue are now moot.
+	for _, other := range s.claims {
+		if other != c && other.VenueID == venueID && (other.Status == "pending" || other.Status == "code_issued") {
+			other.Status = "rejected"
+			other.codeHash = ""
+			s.persistStatus(other)
+		}
+	}
+
+	out := c.VenueClaim
+	return &out, nil
+}
+
+// IsOwner reports whether the user holds a verified claim on the venue.
+func (s *ClaimService) IsOwner(userID, venueID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, c := range s.claims {
+		if c.VenueID == venueID && c.UserID == userID && c.Status == "verified" {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnedVenues returns the IDs of venues a user has verified ownership of.
+func (s *ClaimService) OwnedVenues(userID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ids []string
+	for _, c := range s.claims {
+		if c.UserID == userID && c.Status == "verified" {
+			ids = append(ids, c.VenueID)
+		}
+	}
+	return ids
+}
+
+// find returns the claim with the given ID. Caller must hold s.mu.
+func (s *ClaimService) find(claimID string) *claimRecord {
+	for _, c := range s.claims {
+		if c.ID == claimID {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *ClaimService) persistStatus(c *claimRecord) {
+	if s.pool == nil {
+		return
+	}
+	_, err := s.pool.Exec(context.Background(),
+		`UPDATE venue_claims SET status=$1, code_hash=NULLIF($2,''), verified_at=$3 WHERE id=$4`,
+		c.Status, c.codeHash, c.VerifiedAt, c.ID)
+	if err != nil {
+		log.Printf("WARNING: Failed to persist claim status: %v", err)
+	}
+}
+
+// newClaimCode returns a human-friendly code like "K7QX-2MPA".
+func newClaimCode() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	for i := range b {
+		b[i] = claimCodeAlphabet[int(b[i])%len(claimCodeAlphabet)]
+	}
+	return string(b[:4]) + "-" + string(b[4:])
+}
+
+func hashClaimCode(code string) string {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
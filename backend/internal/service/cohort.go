@@ -0,0 +1,44 @@
+package service
+
+import "time"
+
+// Class-year cohorts, youngest first.
+const (
+	CohortFreshman  = "freshman"
+	CohortSophomore = "sophomore"
+	CohortJunior    = "junior"
+	CohortSenior    = "senior"
+	CohortAlumni    = "alumni"
+)
+
+var cohortOrder = []string{CohortFreshman, CohortSophomore, CohortJunior, CohortSenior, CohortAlumni}
+
+// minCohortRatings is how many ratings a cohort needs before its average is
+// shown, so a single reviewer's score can't be singled out.
+const minCohortRatings = 3
+
+// CohortFor returns the class-year cohort of someone graduating in gradYear as
+// of t, or "" if unknown or not yet enrolled. Academic years start in August.
+func CohortFor(gradYear int, t time.Time) string {
+	if gradYear == 0 {
+		return ""
+	}
+	academicEnd := t.Year()
+	if t.Month() >= time.August {
+		academicEnd++
+	}
+	switch gradYear - academicEnd {
+	case 0:
+		return CohortSenior
+	case 1:
+		return CohortJunior
+	case 2:
+		return CohortSophomore
+	case 3:
+		return CohortFreshman
+	}
+	if gradYear < academicEnd {
+		return CohortAlumni
+	}
+	return ""
+}
@@ -0,0 +1,564 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	maxCrawlStops       = 15
+	maxCrawlTitleLength = 100
+	maxCrawlDescLength  = 1000
+	maxUpcomingCrawls   = 10
+	// crawlMaxLeadTime is how far ahead a crawl can be planned.
+	crawlMaxLeadTime = 90 * 24 * time.Hour
+	// crawlLength is how long after it starts a crawl still counts as
+	// upcoming and takes RSVPs.
+	crawlLength = 6 * time.Hour
+)
+
+// CrawlService manages planned bar crawls and their RSVPs.
+type CrawlService struct {
+	mu     sync.RWMutex
+	pool   *pgxpool.Pool
+	crawls []model.Crawl
+}
+
+func NewCrawlService(pool *pgxpool.Pool) *CrawlService {
+	svc := &CrawlService{
+		pool:   pool,
+		crawls: []model.Crawl{},
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *CrawlService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, title, COALESCE(description,''), school_id, owner_id, COALESCE(owner_name,''),
+		        starts_at, visibility, invite_code, created_at, updated_at
+		 FROM crawls ORDER BY created_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load crawls from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	idx := make(map[string]int)
+	for rows.Next() {
+		var c model.Crawl
+		if err := rows.Scan(&c.ID, &c.Title, &c.Description, &c.SchoolID, &c.OwnerID, &c.OwnerName,
+			&c.StartsAt, &c.Visibility, &c.InviteCode, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan crawl row: %v", err)
+			continue
+		}
+		c.Stops = []model.CrawlStop{}
+		c.RSVPs = []model.CrawlRSVP{}
+		idx[c.ID] = len(s.crawls)
+		s.crawls = append(s.crawls, c)
+	}
+	rows.Close()
+
+	stopRows, err := s.pool.Query(context.Background(),
+		`SELECT crawl_id, rank, venue_id, COALESCE(note,'') FROM crawl_stops ORDER BY crawl_id, rank`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load crawl stops from DB: %v", err)
+		return
+	}
+	for stopRows.Next() {
+		var crawlID string
+		var stop model.CrawlStop
+		if err := stopRows.Scan(&crawlID, &stop.Rank, &stop.VenueID, &stop.Note); err != nil {
+			log.Printf("WARNING: Failed to scan crawl stop row: %v", err)
+			continue
+		}
+		if i, ok := idx[crawlID]; ok {
+			s.crawls[i].Stops = append(s.crawls[i].Stops, stop)
+		}
+	}
+	stopRows.Close()
+
+	rsvpRows, err := s.pool.Query(context.Background(),
+		`SELECT crawl_id, user_id, COALESCE(username,''), status, updated_at FROM crawl_rsvps ORDER BY updated_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load crawl RSVPs from DB: %v", err)
+		return
+	}
+	defer rsvpRows.Close()
+	for rsvpRows.Next() {
+		var crawlID string
+		var rsvp model.CrawlRSVP
+		if err := rsvpRows.Scan(&crawlID, &rsvp.UserID, &rsvp.Username, &rsvp.Status, &rsvp.UpdatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan crawl RSVP row: %v", err)
+			continue
+		}
+		if i, ok := idx[crawlID]; ok {
+			s.crawls[i].RSVPs = append(s.crawls[i].RSVPs, rsvp)
+		}
+	}
+	log.Printf("Loaded %d crawls from DB", len(s.crawls))
+}
+
+// validateCrawlRequest checks and normalizes a create/update request.
+func validateCrawlRequest(req *model.CrawlRequest, now time.Time) error {
+	req.Title = strings.TrimSpace(middleware.SanitizeString(req.Title))
+	req.Description = middleware.SanitizeString(req.Description)
+	if req.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if len(req.Title) > maxCrawlTitleLength {
+		return fmt.Errorf("title must be at most %d characters", maxCrawlTitleLength)
+	}
+	if len(req.Description) > maxCrawlDescLength {
+		return fmt.Errorf("description must be at most %d characters", maxCrawlDescLength)
+	}
+	if req.SchoolID == "" {
+		return fmt.Errorf("school_id is required")
+	}
+	if req.StartsAt.IsZero() {
+		return fmt.Errorf("starts_at is required")
+	}
+	if !req.StartsAt.After(now) {
+		return fmt.Errorf("starts_at must be in the future")
+	}
+	if req.StartsAt.After(now.Add(crawlMaxLeadTime)) {
+		return fmt.Errorf("crawls can be planned at most %d days ahead", int(crawlMaxLeadTime.Hours()/24))
+	}
+	if req.Visibility == "" {
+		req.Visibility = "public"
+	}
+	if req.Visibility != "public" && req.Visibility != "private" {
+		return fmt.Errorf("visibility must be 'public' or 'private'")
+	}
+	if len(req.Stops) == 0 {
+		return fmt.Errorf("a crawl needs at least one stop")
+	}
+	if len(req.Stops) > maxCrawlStops {
+		return fmt.Errorf("a crawl can have at most %d stops", maxCrawlStops)
+	}
+	seen := make(map[string]bool, len(req.Stops))
+	for i := range req.Stops {
+		if req.Stops[i].VenueID == "" {
+			return fmt.Errorf("venue_id is required for every stop")
+		}
+		if seen[req.Stops[i].VenueID] {
+			return fmt.Errorf("venue %s appears more than once", req.Stops[i].VenueID)
+		}
+		seen[req.Stops[i].VenueID] = true
+		req.Stops[i].Note = middleware.SanitizeString(req.Stops[i].Note)
+		if len(req.Stops[i].Note) > maxListNoteLength {
+			return fmt.Errorf("notes must be at most %d characters", maxListNoteLength)
+		}
+	}
+	return nil
+}
+
+func stopsFromRequest(req model.CrawlRequest) []model.CrawlStop {
+	stops := make([]model.CrawlStop, len(req.Stops))
+	for i, st := range req.Stops {
+		stops[i] = model.CrawlStop{Rank: i + 1, VenueID: st.VenueID, Note: st.Note}
+	}
+	return stops
+}
+
+func crawlOver(c model.Crawl, now time.Time) bool {
+	return !now.Before(c.StartsAt.Add(crawlLength))
+}
+
+// canSeeCrawl reports whether a user may view a crawl.
+func canSeeCrawl(c model.Crawl, userID, inviteCode string) bool {
+	if c.Visibility == "public" || (userID != "" && c.OwnerID == userID) {
+		return true
+	}
+	if inviteCode != "" && inviteCode == c.InviteCode {
+		return true
+	}
+	for _, r := range c.RSVPs {
+		if userID != "" && r.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// crawlView returns a copy of a crawl as userID sees it: counts and their own
+// RSVP filled in, and the invite code only for the host.
+func crawlView(c model.Crawl, userID string) model.Crawl {
+	out := c
+	out.Stops = append([]model.CrawlStop{}, c.Stops...)
+	out.RSVPs = append([]model.CrawlRSVP{}, c.RSVPs...)
+	out.Going, out.Maybe, out.MyRSVP = 0, 0, ""
+	for _, r := range c.RSVPs {
+		switch r.Status {
+		case "going":
+			out.Going++
+		case "maybe":
+			out.Maybe++
+		}
+		if userID != "" && r.UserID == userID {
+			out.MyRSVP = r.Status
+		}
+	}
+	if userID == "" || c.OwnerID != userID {
+		out.InviteCode = ""
+	}
+	return out
+}
+
+func (s *CrawlService) find(id string) int {
+	for i := range s.crawls {
+		if s.crawls[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Create publishes a crawl hosted by the current user, who is put down as
+// going.
+func (s *CrawlService) Create(ctx context.Context, req model.CrawlRequest) (*model.Crawl, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	now := time.Now()
+	if err := validateCrawlRequest(&req, now); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	upcoming := 0
+	for _, c := range s.crawls {
+		if c.OwnerID == userID && !crawlOver(c, now) {
+			upcoming++
+		}
+	}
+	if upcoming >= maxUpcomingCrawls {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("you can host at most %d upcoming crawls", maxUpcomingCrawls)
+	}
+	crawl := model.Crawl{
+		ID:          generateID(),
+		Title:       req.Title,
+		Description: req.Description,
+		SchoolID:    req.SchoolID,
+		OwnerID:     userID,
+		OwnerName:   middleware.GetUsername(ctx),
+		StartsAt:    req.StartsAt,
+		Visibility:  req.Visibility,
+		InviteCode:  generateID(),
+		Stops:       stopsFromRequest(req),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	crawl.RSVPs = []model.CrawlRSVP{{UserID: userID, Username: crawl.OwnerName, Status: "going", UpdatedAt: now}}
+	s.crawls = append(s.crawls, crawl)
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO crawls (id, title, description, school_id, owner_id, owner_name, starts_at, visibility, invite_code, created_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			crawl.ID, crawl.Title, crawl.Description, crawl.SchoolID, crawl.OwnerID, crawl.OwnerName,
+			crawl.StartsAt, crawl.Visibility, crawl.InviteCode, crawl.CreatedAt, crawl.UpdatedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist crawl: %v", err)
+		}
+		s.persistStops(crawl.ID, crawl.Stops)
+		s.persistRSVP(crawl.ID, crawl.RSVPs[0])
+	}
+
+	out := crawlView(crawl, userID)
+	return &out, nil
+}
+
+func (s *CrawlService) persistStops(crawlID string, stops []model.CrawlStop) {
+	ctx := context.Background()
+	if _, err := s.pool.Exec(ctx, `DELETE FROM crawl_stops WHERE crawl_id=$1`, crawlID); err != nil {
+		log.Printf("WARNING: Failed to clear crawl stops: %v", err)
+		return
+	}
+	for _, st := range stops {
+		_, err := s.pool.Exec(ctx,
+			`INSERT INTO crawl_stops (crawl_id, rank, venue_id, note) VALUES ($1, $2, $3, $4)`,
+			crawlID, st.Rank, st.VenueID, st.Note)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist crawl stop: %v", err)
+		}
+	}
+}
+
+func (s *CrawlService) persistRSVP(crawlID string, r model.CrawlRSVP) {
+	_, err := s.pool.Exec(context.Background(),
+		`INSERT INTO crawl_rsvps (crawl_id, user_id, username, status, updated_at) VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (crawl_id, user_id) DO UPDATE SET username = EXCLUDED.username, status = EXCLUDED.status, updated_at = EXCLUDED.updated_at`,
+		crawlID, r.UserID, r.Username, r.Status, r.UpdatedAt)
+	if err != nil {
+		log.Printf("WARNING: Failed to persist crawl RSVP: %v", err)
+	}
+}
+
+// Get returns a crawl by ID. Private crawls are reported as not found unless
+// the viewer is the host, has RSVP'd, or passes the invite code.
+func (s *CrawlService) Get(ctx context.Context, id, inviteCode string) (*model.Crawl, error) {
+	userID := middleware.GetUserID(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.find(id)
+	if i < 0 || !canSeeCrawl(s.crawls[i], userID, inviteCode) {
+		return nil, fmt.Errorf("crawl not found")
+	}
+	out := crawlView(s.crawls[i], userID)
+	return &out, nil
+}
+
+// Update replaces a crawl's details and route. Host only, and only before
+// the crawl is over.
+func (s *CrawlService) Update(ctx context.Context, id string, req model.CrawlRequest) (*model.Crawl, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	now := time.Now()
+	if err := validateCrawlRequest(&req, now); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(id)
+	if i < 0 || !canSeeCrawl(s.crawls[i], userID, "") {
+		return nil, fmt.Errorf("crawl not found")
+	}
+	c := &s.crawls[i]
+	if c.OwnerID != userID {
+		return nil, fmt.Errorf("you can only edit your own crawls")
+	}
+	if crawlOver(*c, now) {
+		return nil, fmt.Errorf("this crawl is already over")
+	}
+	c.Title = req.Title
+	c.Description = req.Description
+	c.SchoolID = req.SchoolID
+	c.StartsAt = req.StartsAt
+	c.Visibility = req.Visibility
+	c.Stops = stopsFromRequest(req)
+	c.UpdatedAt = now
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`UPDATE crawls SET title=$1, description=$2, school_id=$3, starts_at=$4, visibility=$5, updated_at=$6 WHERE id=$7`,
+			c.Title, c.Description, c.SchoolID, c.StartsAt, c.Visibility, c.UpdatedAt, id)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist crawl update: %v", err)
+		}
+		s.persistStops(id, c.Stops)
+	}
+
+	out := crawlView(*c, userID)
+	return &out, nil
+}
+
+// Delete cancels a crawl. Hosts can delete their own crawls; admins can
+// delete any.
+func (s *CrawlService) Delete(ctx context.Context, id string) error {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return fmt.Errorf("authentication required")
+	}
+	isAdmin := middleware.GetUserRole(ctx) == "admin"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(id)
+	if i < 0 || (!isAdmin && !canSeeCrawl(s.crawls[i], userID, "")) {
+		return fmt.Errorf("crawl not found")
+	}
+	if s.crawls[i].OwnerID != userID && !isAdmin {
+		return fmt.Errorf("you can only delete your own crawls")
+	}
+	s.crawls = append(s.crawls[:i], s.crawls[i+1:]...)
+
+	if s.pool != nil {
+		ctx := context.Background()
+		for _, table := range []string{"crawl_stops", "crawl_rsvps"} {
+			if _, err := s.pool.Exec(ctx, `DELETE FROM `+table+` WHERE crawl_id=$1`, id); err != nil {
+				log.Printf("WARNING: Failed to delete %s from DB: %v", table, err)
+			}
+		}
+		if _, err := s.pool.Exec(ctx, `DELETE FROM crawls WHERE id=$1`, id); err != nil {
+			log.Printf("WARNING: Failed to delete crawl from DB: %v", err)
+		}
+	}
+	return nil
+}
+
+// RSVP records the current user as going or maybe. Private crawls need the
+// invite code the first time.
+func (s *CrawlService) RSVP(ctx context.Context, id string, req model.CrawlRSVPRequest) (*model.Crawl, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	if req.Status != "going" && req.Status != "maybe" {
+		return nil, fmt.Errorf("status must be 'going' or 'maybe'")
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(id)
+	if i < 0 || !canSeeCrawl(s.crawls[i], userID, req.InviteCode) {
+		return nil, fmt.Errorf("crawl not found")
+	}
+	c := &s.crawls[i]
+	if crawlOver(*c, now) {
+		return nil, fmt.Errorf("this crawl is already over")
+	}
+	rsvp := model.CrawlRSVP{UserID: userID, Username: middleware.GetUsername(ctx), Status: req.Status, UpdatedAt: now}
+	found := false
+	for j := range c.RSVPs {
+		if c.RSVPs[j].UserID == userID {
+			c.RSVPs[j] = rsvp
+			found = true
+			break
+		}
+	}
+	if !found {
+		c.RSVPs = append(c.RSVPs, rsvp)
+	}
+	if s.pool != nil {
+		s.persistRSVP(id, rsvp)
+	}
+
+	out := crawlView(*c, userID)
+	return &out, nil
+}
+
+// CancelRSVP takes the current user off a crawl's RSVP list.
+func (s *CrawlService) CancelRSVP(ctx context.Context, id string) error {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return fmt.Errorf("authentication required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(id)
+	if i < 0 || !canSeeCrawl(s.crawls[i], userID, "") {
+		return fmt.Errorf("crawl not found")
+	}
+	c := &s.crawls[i]
+	for j := range c.RSVPs {
+		if c.RSVPs[j].UserID != userID {
+			continue
+		}
+		c.RSVPs = append(c.RSVPs[:j], c.RSVPs[j+1:]...)
+		if s.pool != nil {
+			if _, err := s.pool.Exec(context.Background(),
+				`DELETE FROM crawl_rsvps WHERE crawl_id=$1 AND user_id=$2`, id, userID); err != nil {
+				return fmt.Errorf("failed to cancel RSVP: %w", err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("you have not RSVP'd to this crawl")
+}
+
+// RotateInvite replaces a crawl's invite code, so old invite links stop
+// working. People who already RSVP'd keep access. Host only.
+func (s *CrawlService) RotateInvite(ctx context.Context, id string) (*model.Crawl, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(id)
+	if i < 0 || !canSeeCrawl(s.crawls[i], userID, "") {
+		return nil, fmt.Errorf("crawl not found")
+	}
+	c := &s.crawls[i]
+	if c.OwnerID != userID {
+		return nil, fmt.Errorf("you can only edit your own crawls")
+	}
+	c.InviteCode = generateID()
+	c.UpdatedAt = time.Now()
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`UPDATE crawls SET invite_code=$1, updated_at=$2 WHERE id=$3`, c.InviteCode, c.UpdatedAt, id); err != nil {
+			return nil, fmt.Errorf("failed to rotate invite code: %w", err)
+		}
+	}
+
+	out := crawlView(*c, userID)
+	return &out, nil
+}
+
+// ListForUser returns the upcoming crawls a user hosts or has RSVP'd to,
+// soonest first.
+func (s *CrawlService) ListForUser(userID string, now time.Time) []model.Crawl {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := []model.Crawl{}
+	for _, c := range s.crawls {
+		if crawlOver(c, now) || !crawlInvolves(c, userID) {
+			continue
+		}
+		out = append(out, crawlView(c, userID))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].StartsAt.Before(out[j].StartsAt) })
+	return out
+}
+
+func crawlInvolves(c model.Crawl, userID string) bool {
+	if c.OwnerID == userID {
+		return true
+	}
+	for _, r := range c.RSVPs {
+		if r.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// UpcomingBySchool returns a school's public crawls that haven't ended and
+// start within the given window (zero for no limit), soonest first.
+func (s *CrawlService) UpcomingBySchool(schoolID string, now time.Time, within time.Duration) []model.Crawl {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := []model.Crawl{}
+	for _, c := range s.crawls {
+		if c.SchoolID != schoolID || c.Visibility != "public" || crawlOver(c, now) {
+			continue
+		}
+		if within > 0 && c.StartsAt.After(now.Add(within)) {
+			continue
+		}
+		out = append(out, crawlView(c, ""))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].StartsAt.Before(out[j].StartsAt) })
+	return out
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// Reviewer credibility is a 0-100 score built from account history. It only
+// ever influences the order of "top" reviews and is never returned by the API.
+const (
+	credibilityAgeWeight     = 30 // full marks after a year
+	credibilityHelpfulWeight = 50 // full marks at credibilityHelpfulCap net votes
+	credibilityStudentWeight = 20
+	credibilityHelpfulCap    = 50
+
+	// credibilityRunHour is the UTC hour the nightly recompute runs at.
+	credibilityRunHour = 9 // ~4-5am US Eastern, after the late-night rush
+)
+
+// StudentVerifier reports whether a user has proven they're a student.
+type StudentVerifier func(userID string) bool
+
+// CredibilityService computes and stores a credibility score per reviewer.
+type CredibilityService struct {
+	mu         sync.RWMutex
+	pool       *pgxpool.Pool
+	scores     map[string]float64
+	computedAt time.Time
+
+	auth      *AuthService
+	ratings   *RatingService
+	isStudent StudentVerifier // optional
+}
+
+func NewCredibilityService(pool *pgxpool.Pool, auth *AuthService, ratings *RatingService) *CredibilityService {
+	svc := &CredibilityService{
+		pool:    pool,
+		scores:  make(map[string]float64),
+		auth:    auth,
+		ratings: ratings,
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *CredibilityService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT user_id, score, computed_at FROM user_credibility`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load credibility scores from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var score float64
+		var at time.Time
+		if err := rows.Scan(&userID, &score, &at); err != nil {
+			log.Printf("WARNING: Failed to scan credibility row: %v", err)
+			continue
+		}
+		s.scores[userID] = score
+		if at.After(s.computedAt) {
+			s.computedAt = at
+		}
+	}
+	log.Printf("Loaded %d credibility scores from DB", len(s.scores))
+}
+
+// SetStudentVerifier supplies verified student status to the score.
+func (s *CredibilityService) SetStudentVerifier(fn StudentVerifier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.isStudent = fn
+}
+
+// Score returns a user's credibility, or 0 if it hasn't been computed.
+func (s *CredibilityService) Score(userID string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scores[userID]
+}
+
+// Recompute rebuilds every reviewer's score from account age, net helpful
+// votes received and verified student status, and persists the result.
+func (s *CredibilityService) Recompute(ctx context.Context) error {
+	users, err := s.auth.ListUsers()
+	if err != nil {
+		return err
+	}
+	helpful := s.ratings.HelpfulVotesByAuthor()
+
+	s.mu.RLock()
+	isStudent := s.isStudent
+	s.mu.RUnlock()
+
+	now := time.Now()
+	scores := make(map[string]float64, len(users))
+	for _, u := range users {
+		ageYears := now.Sub(u.CreatedAt).Hours() / (24 * 365)
+		score := min(ageYears, 1) * credibilityAgeWeight
+		if votes := helpful[u.ID]; votes > 0 {
+			score += min(math.Log1p(float64(votes))/math.Log1p(credibilityHelpfulCap), 1) * credibilityHelpfulWeight
+		}
+		if isStudent != nil && isStudent(u.ID) {
+			score += credibilityStudentWeight
+		}
+		scores[u.ID] = math.Round(score*10) / 10
+	}
+
+	s.mu.Lock()
+	s.scores = scores
+	s.computedAt = now
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		for userID, score := range scores {
+			_, err := s.pool.Exec(ctx,
+				`INSERT INTO user_credibility (user_id, score, computed_at) VALUES ($1, $2, $3)
+				 ON CONFLICT (user_id) DO UPDATE SET score = EXCLUDED.score, computed_at = EXCLUDED.computed_at`,
+				userID, score, now)
+			if err != nil {
+				log.Printf("WARNING: Failed to persist credibility score: %v", err)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Start recomputes scores now if they're more than a day old, then nightly.
+func (s *CredibilityService) Start() {
+	s.mu.RLock()
+	stale := time.Since(s.computedAt) > 24*time.Hour
+	s.mu.RUnlock()
+
+	run := func() {
+		if err := s.Recompute(context.Background()); err != nil {
+			log.Printf("WARNING: Failed to recompute credibility scores: %v", err)
+		}
+	}
+	go func() {
+		if stale {
+			run()
+		}
+		for {
+			time.Sleep(time.Until(nextCredibilityRun(time.Now())))
+			run()
+		}
+	}()
+}
+
+func nextCredibilityRun(now time.Time) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), credibilityRunHour, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// SortTop orders ratings for the "top" view: net helpful votes, scaled by
+// the author's credibility (0.5x for a brand new account up to 1.5x), with
+// newer reviews first on ties.
+func (s *CredibilityService) SortTop(ratings []model.Rating) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rank := func(r model.Rating) float64 {
+		return float64(1+max(r.Upvotes-r.Downvotes, 0)) * (0.5 + s.scores[r.AuthorID]/100)
+	}
+	sort.SliceStable(ratings, func(i, j int) bool {
+		ri, rj := rank(ratings[i]), rank(ratings[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return ratings[i].CreatedAt.After(ratings[j].CreatedAt)
+	})
+}
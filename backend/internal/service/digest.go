@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// DigestNotifyFunc delivers a freshly generated digest (e.g. by email to followers).
+type DigestNotifyFunc func(digest model.SchoolDigest)
+
+// DigestService assembles weekly per-school highlights from venue and rating data.
+// Digests are generated by a background job and the latest one per school is kept
+// in memory (and in the school_digests table when a database is configured).
+type DigestService struct {
+	mu     sync.RWMutex
+	pool   *pgxpool.Pool
+	latest map[string]*model.SchoolDigest
+	notify DigestNotifyFunc
+
+	schoolSvc *SchoolService
+	venueSvc  *VenueService
+	ratingSvc *RatingService
+}
+
+func NewDigestService(pool *pgxpool.Pool, schoolSvc *SchoolService, venueSvc *VenueService, ratingSvc *RatingService) *DigestService {
+	svc := &DigestService{
+		pool:      pool,
+		latest:    make(map[string]*model.SchoolDigest),
+		schoolSvc: schoolSvc,
+		venueSvc:  venueSvc,
+		ratingSvc: ratingSvc,
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *DigestService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT DISTINCT ON (school_id) payload FROM school_digests ORDER BY school_id, week_start DESC`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load digests from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var payload []byte
+		if err := rows.Scan(&payload); err != nil {
+			log.Printf("WARNING: Failed to scan digest row: %v", err)
+			continue
+		}
+		var d model.SchoolDigest
+		if err := json.Unmarshal(payload, &d); err != nil {
+			continue
+		}
+		s.latest[d.SchoolID] = &d
+	}
+	log.Printf("Loaded %d school digests from DB", len(s.latest))
+}
+
+// SetNotifier registers a delivery hook called for every newly generated digest.
+func (s *DigestService) SetNotifier(fn DigestNotifyFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notify = fn
+}
+
+// Start runs the digest job immediately if no digest exists for the current
+// week, then again every interval.
+func (s *DigestService) Start(interval time.Duration) {
+	go func() {
+		if s.needsRun(time.Now()) {
+			s.GenerateAll(time.Now())
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if s.needsRun(now) {
+				s.GenerateAll(now)
+			}
+		}
+	}()
+}
+
+func (s *DigestService) needsRun(now time.Time) bool {
+	week := weekStart(now)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.latest) == 0 {
+		return true
+	}
+	for _, d := range s.latest {
+		if d.WeekStart.Before(week) {
+			return true
+		}
+	}
+	return false
+}
+
+// weekStart returns the Monday 00:00 UTC that begins the week containing t.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// GenerateAll builds digests for the previous full week for every school on the leaderboard.
+func (s *DigestService) GenerateAll(now time.Time) int {
+	ranks := s.leaderboardRanks()
+
+	n := 0
+	for schoolID := range ranks {
+		if _, err := s.generate(schoolID, now, ranks); err == nil {
+			n++
+		}
+	}
+	log.Printf("Generated %d weekly school digests", n)
+	return n
+}
+
+// Latest returns the most recent stored digest for a school. Digests are only
+// generated by the background job.
+func (s *DigestService) Latest(schoolID string) (*model.SchoolDigest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	d, ok := s.latest[schoolID]
+	if !ok {
+		return nil, fmt.Errorf("no digest for this school yet")
+	}
+	out := *d
+	return &out, nil
+}
+
+func (s *DigestService) leaderboardRanks() map[string]int {
+	ranks := make(map[string]int)
+	for _, entry := range s.schoolSvc.GetTopSchools(0, "", "") {
+		id, _ := entry["id"].(string)
+		rank, _ := entry["rank"].(int)
+		ranks[id] = rank
+	}
+	return ranks
+}
+
+func (s *DigestService) generate(schoolID string, now time.Time, ranks map[string]int) (*model.SchoolDigest, error) {
+	school, err := s.schoolSvc.GetByID(context.Background(), schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Digest covers the last complete week.
+	end := weekStart(now)
+	start := end.AddDate(0, 0, -7)
+
+	var venues []model.Venue
+	for _, v := range s.venueSvc.GetAllVenues() {
+		if v.SchoolID == schoolID {
+			venues = append(venues, v)
+		}
+	}
+	if len(venues) == 0 {
+		return nil, fmt.Errorf("no venues at school %s", schoolID)
+	}
+
+	ids := make([]string, len(venues))
+	for i, v := range venues {
+		ids[i] = v.ID
+	}
+	weekly := make(map[string]int)
+	digest := model.SchoolDigest{
+		SchoolID:    schoolID,
+		SchoolName:  school.Name,
+		WeekStart:   start,
+		WeekEnd:     end,
+		GeneratedAt: time.Now(),
+		Rank:        ranks[schoolID],
+	}
+	for _, r := range s.ratingSvc.ListByVenues(ids) {
+		if !r.CreatedAt.Before(start) && r.CreatedAt.Before(end) {
+			weekly[r.VenueID]++
+			digest.NewRatings++
+		}
+	}
+
+	var top, reviewed *model.Venue
+	for i := range venues {
+		v := &venues[i]
+		if !v.CreatedAt.Before(start) && v.CreatedAt.Before(end) {
+			digest.NewVenues++
+		}
+		if v.RatingCount > 0 && (top == nil || v.AvgRating > top.AvgRating ||
+			v.AvgRating == top.AvgRating && v.RatingCount > top.RatingCount) {
+			top = v
+		}
+		if v.Category == "bar" && weekly[v.ID] > 0 && (reviewed == nil || weekly[v.ID] > weekly[reviewed.ID]) {
+			reviewed = v
+		}
+	}
+	if top != nil {
+		digest.TopRated = toDigestVenue(*top, weekly[top.ID])
+	}
+	if reviewed != nil {
+		digest.MostReviewed = toDigestVenue(*reviewed, weekly[reviewed.ID])
+	}
+
+	s.mu.Lock()
+	prev := s.latest[schoolID]
+	if prev != nil && !prev.WeekStart.Equal(start) {
+		digest.PreviousRank = prev.Rank
+		if prev.Rank > 0 && digest.Rank > 0 {
+			digest.RankChange = prev.Rank - digest.Rank
+		}
+		digest.TopRatedIsNew = digest.TopRated != nil &&
+			(prev.TopRated == nil || prev.TopRated.ID != digest.TopRated.ID)
+	} else if prev != nil {
+		// Regenerating the same week: keep the comparison against the week before.
+		digest.PreviousRank = prev.PreviousRank
+		if digest.PreviousRank > 0 && digest.Rank > 0 {
+			digest.RankChange = digest.PreviousRank - digest.Rank
+		}
+		digest.TopRatedIsNew = prev.TopRatedIsNew
+	}
+	isNew := prev == nil || !prev.WeekStart.Equal(start)
+	s.latest[schoolID] = &digest
+	notify := s.notify
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		payload, _ := json.Marshal(digest)
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO school_digests (school_id, week_start, payload, generated_at) VALUES ($1, $2, $3, $4)
+			 ON CONFLICT (school_id, week_start) DO UPDATE SET payload = EXCLUDED.payload, generated_at = EXCLUDED.generated_at`,
+			schoolID, start, payload, digest.GeneratedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist school digest: %v", err)
+		}
+	}
+
+	if notify != nil && isNew {
+		notify(digest)
+	}
+
+	return &digest, nil
+}
+
+// DigestEmail renders a digest as a plain-text email. schoolURL links to the
+// school's page.
+func DigestEmail(d model.SchoolDigest, schoolURL string) (subject, body string) {
+	subject = fmt.Sprintf("This week at %s on RateMyBars", d.SchoolName)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Week of %s–%s at %s\n\n", d.WeekStart.Format("Jan 2"), d.WeekEnd.AddDate(0, 0, -1).Format("Jan 2"), d.SchoolName)
+	fmt.Fprintf(&b, "%d new ratings, %d new venues\n", d.NewRatings, d.NewVenues)
+	if d.TopRated != nil {
+		fmt.Fprintf(&b, "Top rated: %s (%.1f from %d ratings)", d.TopRated.Name, d.TopRated.AvgRating, d.TopRated.RatingCount)
+		if d.TopRatedIsNew {
+			b.WriteString(", new at #1")
+		}
+		b.WriteString("\n")
+	}
+	if d.MostReviewed != nil {
+		fmt.Fprintf(&b, "Most reviewed bar: %s (%d ratings this week)\n", d.MostReviewed.Name, d.MostReviewed.WeeklyRatings)
+	}
+	if d.Rank > 0 {
+		fmt.Fprintf(&b, "Leaderboard rank: #%d", d.Rank)
+		switch {
+		case d.RankChange > 0:
+			fmt.Fprintf(&b, " (up %d)", d.RankChange)
+		case d.RankChange < 0:
+			fmt.Fprintf(&b, " (down %d)", -d.RankChange)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "\nSee more: %s\n\nYou're getting this because you follow a venue at %s. Turn digests off under notification preferences.", schoolURL, d.SchoolName)
+	return subject, b.String()
+}
+
+func toDigestVenue(v model.Venue, weeklyRatings int) *model.DigestVenue {
+	return &model.DigestVenue{
+		ID:            v.ID,
+		Name:          v.Name,
+		Category:      v.Category,
+		AvgRating:     v.AvgRating,
+		RatingCount:   v.RatingCount,
+		ThumbsUp:      v.ThumbsUp,
+		ThumbsDown:    v.ThumbsDown,
+		RecommendPct:  v.RecommendPct,
+		WeeklyRatings: weeklyRatings,
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	// draftTTL is how long an untouched draft is kept.
+	draftTTL = 7 * 24 * time.Hour
+
+	maxDraftReviewLen = 2000
+)
+
+type draftKey struct {
+	UserID  string
+	VenueID string
+}
+
+// DraftService autosaves in-progress reviews, one per user per venue, so a
+// user who gets interrupted can pick up where they left off.
+type DraftService struct {
+	mu     sync.RWMutex
+	pool   *pgxpool.Pool
+	drafts map[draftKey]model.RatingDraft
+
+	venueSvc *VenueService
+}
+
+func NewDraftService(pool *pgxpool.Pool, venueSvc *VenueService) *DraftService {
+	svc := &DraftService{
+		pool:     pool,
+		drafts:   make(map[draftKey]model.RatingDraft),
+		venueSvc: venueSvc,
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *DraftService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT user_id, venue_id, score, COALESCE(review,''), tags, would_recommend, updated_at
+		 FROM rating_drafts WHERE updated_at > $1`, time.Now().Add(-draftTTL))
+	if err != nil {
+		log.Printf("WARNING: Failed to load rating drafts from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var k draftKey
+		var d model.RatingDraft
+		if err := rows.Scan(&k.UserID, &k.VenueID, &d.Score, &d.Review, &d.Tags, &d.WouldRecommend, &d.UpdatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan rating draft row: %v", err)
+			continue
+		}
+		d.VenueID = k.VenueID
+		d.ExpiresAt = d.UpdatedAt.Add(draftTTL)
+		s.drafts[k] = d
+	}
+	log.Printf("Loaded %d rating drafts from DB", len(s.drafts))
+}
+
+// Save stores the current user's draft for a venue, replacing any earlier one.
+func (s *DraftService) Save(ctx context.Context, draft model.RatingDraft) (*model.RatingDraft, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	if draft.VenueID == "" {
+		return nil, fmt.Errorf("venue_id is required")
+	}
+	if _, err := s.venueSvc.GetByID(ctx, draft.VenueID); err != nil {
+		return nil, fmt.Errorf("venue not found")
+	}
+	if draft.Score != 0 {
+		if err := ValidateScore(draft.Score); err != nil {
+			return nil, err
+		}
+	}
+	if len([]rune(draft.Review)) > maxDraftReviewLen {
+		return nil, fmt.Errorf("review must be at most %d characters", maxDraftReviewLen)
+	}
+
+	now := time.Now()
+	draft.Review = middleware.SanitizeString(draft.Review)
+	draft.UpdatedAt = now
+	draft.ExpiresAt = now.Add(draftTTL)
+
+	s.mu.Lock()
+	s.drafts[draftKey{UserID: userID, VenueID: draft.VenueID}] = draft
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO rating_drafts (user_id, venue_id, score, review, tags, would_recommend, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 ON CONFLICT (user_id, venue_id) DO UPDATE
+			 SET score = $3, review = $4, tags = $5, would_recommend = $6, updated_at = $7`,
+			userID, draft.VenueID, draft.Score, draft.Review, draft.Tags, draft.WouldRecommend, now)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist rating draft: %v", err)
+		}
+	}
+	return &draft, nil
+}
+
+// Get returns the current user's unexpired draft for a venue.
+func (s *DraftService) Get(ctx context.Context, venueID string) (*model.RatingDraft, error) {
+	userID := middleware.GetUserID(ctx)
+	s.mu.RLock()
+	d, ok := s.drafts[draftKey{UserID: userID, VenueID: venueID}]
+	s.mu.RUnlock()
+	if !ok || time.Now().After(d.ExpiresAt) {
+		return nil, fmt.Errorf("draft not found")
+	}
+	return &d, nil
+}
+
+// ListMine returns the current user's unexpired drafts, most recent first.
+func (s *DraftService) ListMine(ctx context.Context) []model.RatingDraft {
+	userID := middleware.GetUserID(ctx)
+	now := time.Now()
+
+	s.mu.RLock()
+	out := []model.RatingDraft{}
+	for k, d := range s.drafts {
+		if k.UserID == userID && now.Before(d.ExpiresAt) {
+			out = append(out, d)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].UpdatedAt.After(out[j].UpdatedAt) })
+	return out
+}
+
+// Delete discards a user's draft for a venue, e.g. once the rating is posted.
+func (s *DraftService) Delete(userID, venueID string) {
+	s.mu.Lock()
+	delete(s.drafts, draftKey{UserID: userID, VenueID: venueID})
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`DELETE FROM rating_drafts WHERE user_id = $1 AND venue_id = $2`, userID, venueID); err != nil {
+			log.Printf("WARNING: Failed to delete rating draft: %v", err)
+		}
+	}
+}
+
+// Start purges expired drafts every interval.
+func (s *DraftService) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			s.purgeExpired(now)
+		}
+	}()
+}
+
+func (s *DraftService) purgeExpired(now time.Time) {
+	s.mu.Lock()
+	for k, d := range s.drafts {
+		if now.After(d.ExpiresAt) {
+			delete(s.drafts, k)
+		}
+	}
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`DELETE FROM rating_drafts WHERE updated_at < $1`, now.Add(-draftTTL)); err != nil {
+			log.Printf("WARNING: Failed to purge expired rating drafts: %v", err)
+		}
+	}
+}
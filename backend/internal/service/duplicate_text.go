@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// Text cluster statuses.
+const (
+	TextClusterOpen      = "open"
+	TextClusterDismissed = "dismissed"
+)
+
+const (
+	// duplicateTextMinWords skips short reviews ("great bar, cheap drinks"),
+	// which different people legitimately write word for word.
+	duplicateTextMinWords = 8
+	// duplicateTextDistance is the largest simhash distance treated as the
+	// same text; it survives small edits like a changed word or punctuation,
+	// while unrelated reviews sit 12 or more bits apart.
+	duplicateTextDistance = 4
+	// simhashBands splits hashes into 8-bit bands for lookup. Two hashes
+	// fewer than simhashBands bits apart always share at least one band.
+	simhashBands      = 8
+	clusterExcerptLen = 140
+)
+
+type textRecord struct {
+	member model.TextClusterMember
+	hash   uint64
+}
+
+type textCluster struct {
+	id          string
+	ratingIDs   []string
+	status      string
+	detectedAt  time.Time
+	dismissedAt *time.Time
+}
+
+// DuplicateTextService detects review text copy-pasted across accounts. Each
+// review is reduced to a simhash of its word shingles; reviews by different
+// authors whose hashes are within a few bits are grouped into a cluster for
+// admins to review.
+type DuplicateTextService struct {
+	mu        sync.Mutex
+	pool      *pgxpool.Pool
+	records   map[string]*textRecord // by rating ID
+	bands     [simhashBands]map[uint8][]string
+	clusters  map[string]*textCluster
+	clusterOf map[string]string // rating ID -> cluster ID
+	notify    func(model.TextCluster)
+}
+
+func NewDuplicateTextService(pool *pgxpool.Pool) *DuplicateTextService {
+	svc := &DuplicateTextService{
+		pool:      pool,
+		records:   make(map[string]*textRecord),
+		clusters:  make(map[string]*textCluster),
+		clusterOf: make(map[string]string),
+	}
+	for i := range svc.bands {
+		svc.bands[i] = make(map[uint8][]string)
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *DuplicateTextService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, rating_ids, status, detected_at, dismissed_at FROM text_clusters`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load text clusters from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		c := &textCluster{}
+		if err := rows.Scan(&c.id, &c.ratingIDs, &c.status, &c.detectedAt, &c.dismissedAt); err != nil {
+			log.Printf("WARNING: Failed to scan text cluster row: %v", err)
+			continue
+		}
+		s.clusters[c.id] = c
+		for _, id := range c.ratingIDs {
+			s.clusterOf[id] = c.id
+		}
+	}
+	log.Printf("Loaded %d text clusters from DB", len(s.clusters))
+}
+
+// SetNotifier sets the callback for new and reopened clusters.
+func (s *DuplicateTextService) SetNotifier(fn func(model.TextCluster)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notify = fn
+}
+
+// Index adds existing ratings without notifying, e.g. at startup. Clusters
+// found among them are still recorded.
+func (s *DuplicateTextService) Index(ratings []model.Rating) {
+	for _, r := range ratings {
+		s.observe(r, false)
+	}
+}
+
+// Observe checks a new or edited rating against every indexed review.
+func (s *DuplicateTextService) Observe(r model.Rating) {
+	s.observe(r, true)
+}
+
+func (s *DuplicateTextService) observe(r model.Rating, notify bool) {
+	hash, ok := simhash(r.Review)
+
+	s.mu.Lock()
+	s.removeLocked(r.ID)
+	if !ok || r.AuthorID == DeletedAuthorID {
+		s.mu.Unlock()
+		return
+	}
+	rec := &textRecord{
+		member: model.TextClusterMember{
+			RatingID:   r.ID,
+			VenueID:    r.VenueID,
+			AuthorID:   r.AuthorID,
+			AuthorName: r.AuthorName,
+			Excerpt:    excerpt(r.Review, clusterExcerptLen),
+			CreatedAt:  r.CreatedAt,
+		},
+		hash: hash,
+	}
+	matches := s.matchesLocked(rec)
+	s.addLocked(rec)
+	if len(matches) == 0 {
+		s.mu.Unlock()
+		return
+	}
+
+	c, merged, alert := s.joinLocked(r.ID, matches)
+	out, snapshot := s.toModelLocked(c), c.snapshot()
+	fn := s.notify
+	s.mu.Unlock()
+
+	for _, id := range merged {
+		s.deleteCluster(id)
+	}
+	s.persist(snapshot)
+	if alert {
+		log.Printf("Duplicate review text: cluster %s spans %d accounts", out.ID, out.Authors)
+		if notify && fn != nil {
+			fn(out)
+		}
+	}
+}
+
+// matchesLocked returns the IDs of other authors' ratings with near-identical
+// text. Caller holds s.mu.
+func (s *DuplicateTextService) matchesLocked(rec *textRecord) []string {
+	seen := make(map[string]bool)
+	var matches []string
+	for i := range s.bands {
+		for _, id := range s.bands[i][band(rec.hash, i)] {
+			other := s.records[id]
+			if seen[id] || other.member.AuthorID == rec.member.AuthorID {
+				continue
+			}
+			seen[id] = true
+			if hashDistance(rec.hash, other.hash) <= duplicateTextDistance {
+				matches = append(matches, id)
+			}
+		}
+	}
+	return matches
+}
+
+func (s *DuplicateTextService) addLocked(rec *textRecord) {
+	id := rec.member.RatingID
+	s.records[id] = rec
+	for i := range s.bands {
+		b := band(rec.hash, i)
+		s.bands[i][b] = append(s.bands[i][b], id)
+	}
+}
+
+// removeLocked drops a rating's previous text from the index. Its cluster
+// membership is kept: an edit doesn't clear earlier copy-pasting.
+func (s *DuplicateTextService) removeLocked(ratingID string) {
+	rec, ok := s.records[ratingID]
+	if !ok {
+		return
+	}
+	delete(s.records, ratingID)
+	for i := range s.bands {
+		b := band(rec.hash, i)
+		ids := s.bands[i][b]
+		for j, id := range ids {
+			if id == ratingID {
+				s.bands[i][b] = append(ids[:j], ids[j+1:]...)
+				break
+			}
+		}
+		if len(s.bands[i][b]) == 0 {
+			delete(s.bands[i], b)
+		}
+	}
+}
+
+// joinLocked puts ratingID and its matches into one cluster, merging any
+// clusters they already belong to into the oldest. It reports the IDs of
+// merged-away clusters and whether admins should be alerted: for a new
+// cluster, or a dismissed one that gained an author. Caller holds s.mu.
+func (s *DuplicateTextService) joinLocked(ratingID string, matches []string) (*textCluster, []string, bool) {
+	ids := append([]string{ratingID}, matches...)
+	var existing []*textCluster
+	for _, id := range ids {
+		if cid, ok := s.clusterOf[id]; ok {
+			if c := s.clusters[cid]; !containsCluster(existing, c) {
+				existing = append(existing, c)
+			}
+		}
+	}
+
+	if len(existing) == 0 {
+		c := &textCluster{
+			id:         "tcluster_" + generateID()[:16],
+			status:     TextClusterOpen,
+			detectedAt: time.Now(),
+		}
+		s.clusters[c.id] = c
+		for _, id := range ids {
+			c.ratingIDs = append(c.ratingIDs, id)
+			s.clusterOf[id] = c.id
+		}
+		return c, nil, true
+	}
+
+	sort.Slice(existing, func(i, j int) bool { return existing[i].detectedAt.Before(existing[j].detectedAt) })
+	c := existing[0]
+	authorsBefore := s.authorsLocked(c)
+	var merged []string
+	for _, other := range existing[1:] {
+		ids = append(ids, other.ratingIDs...)
+		if other.status == TextClusterOpen {
+			c.status = TextClusterOpen
+			c.dismissedAt = nil
+		}
+		delete(s.clusters, other.id)
+		merged = append(merged, other.id)
+	}
+	for _, id := range ids {
+		if s.clusterOf[id] != c.id {
+			c.ratingIDs = append(c.ratingIDs, id)
+			s.clusterOf[id] = c.id
+		}
+	}
+
+	alert := false
+	if c.status == TextClusterDismissed && s.authorsLocked(c) > authorsBefore {
+		c.status = TextClusterOpen
+		c.dismissedAt = nil
+		alert = true
+	}
+	return c, merged, alert
+}
+
+func containsCluster(list []*textCluster, c *textCluster) bool {
+	for _, x := range list {
+		if x == c {
+			return true
+		}
+	}
+	return false
+}
+
+// authorsLocked counts the distinct accounts in a cluster. Caller holds s.mu.
+func (s *DuplicateTextService) authorsLocked(c *textCluster) int {
+	authors := make(map[string]bool)
+	for _, id := range c.ratingIDs {
+		if rec, ok := s.records[id]; ok {
+			authors[rec.member.AuthorID] = true
+		}
+	}
+	return len(authors)
+}
+
+// toModelLocked lists a cluster's indexed members, oldest first. Caller
+// holds s.mu.
+func (s *DuplicateTextService) toModelLocked(c *textCluster) model.TextCluster {
+	out := model.TextCluster{
+		ID:          c.id,
+		Status:      c.status,
+		Authors:     s.authorsLocked(c),
+		Members:     []model.TextClusterMember{},
+		DetectedAt:  c.detectedAt,
+		DismissedAt: c.dismissedAt,
+	}
+	for _, id := range c.ratingIDs {
+		if rec, ok := s.records[id]; ok {
+			out.Members = append(out.Members, rec.member)
+		}
+	}
+	sort.Slice(out.Members, func(i, j int) bool { return out.Members[i].CreatedAt.Before(out.Members[j].CreatedAt) })
+	return out
+}
+
+// Clusters returns clusters spanning at least two accounts, newest first. An
+// empty status returns all of them.
+func (s *DuplicateTextService) Clusters(status string) []model.TextCluster {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []model.TextCluster{}
+	for _, c := range s.clusters {
+		if status != "" && c.status != status {
+			continue
+		}
+		if m := s.toModelLocked(c); m.Authors >= 2 {
+			out = append(out, m)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].DetectedAt.After(out[j].DetectedAt) })
+	return out
+}
+
+// Dismiss marks a cluster as reviewed. It reopens if another account posts
+// the same text.
+func (s *DuplicateTextService) Dismiss(clusterID string) (*model.TextCluster, error) {
+	s.mu.Lock()
+	c, ok := s.clusters[clusterID]
+	if !ok {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("cluster not found")
+	}
+	now := time.Now()
+	c.status = TextClusterDismissed
+	c.dismissedAt = &now
+	out, snapshot := s.toModelLocked(c), c.snapshot()
+	s.mu.Unlock()
+
+	s.persist(snapshot)
+	return &out, nil
+}
+
+func (c *textCluster) snapshot() textCluster {
+	out := *c
+	out.ratingIDs = append([]string{}, c.ratingIDs...)
+	return out
+}
+
+func (s *DuplicateTextService) persist(c textCluster) {
+	if s.pool == nil {
+		return
+	}
+	_, err := s.pool.Exec(context.Background(),
+		`INSERT INTO text_clusters (id, rating_ids, status, detected_at, dismissed_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (id) DO UPDATE SET rating_ids = EXCLUDED.rating_ids, status = EXCLUDED.status, dismissed_at = EXCLUDED.dismissed_at`,
+		c.id, c.ratingIDs, c.status, c.detectedAt, c.dismissedAt)
+	if err != nil {
+		log.Printf("WARNING: Failed to persist text cluster: %v", err)
+	}
+}
+
+func (s *DuplicateTextService) deleteCluster(id string) {
+	if s.pool == nil {
+		return
+	}
+	if _, err := s.pool.Exec(context.Background(), `DELETE FROM text_clusters WHERE id = $1`, id); err != nil {
+		log.Printf("WARNING: Failed to delete merged text cluster: %v", err)
+	}
+}
+
+// simhash fingerprints text from its lowercased words and word pairs, so
+// punctuation, case and a word or two of changes barely move the hash. It
+// reports false for text too short to fingerprint reliably.
+func simhash(text string) (uint64, bool) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) < duplicateTextMinWords {
+		return 0, false
+	}
+
+	var weights [64]int
+	for n := 1; n <= 2; n++ {
+		for i := 0; i+n <= len(words); i++ {
+			h := fnv.New64a()
+			h.Write([]byte(strings.Join(words[i:i+n], " ")))
+			v := h.Sum64()
+			for b := 0; b < 64; b++ {
+				if v>>b&1 == 1 {
+					weights[b]++
+				} else {
+					weights[b]--
+				}
+			}
+		}
+	}
+	var hash uint64
+	for b, w := range weights {
+		if w > 0 {
+			hash |= 1 << b
+		}
+	}
+	return hash, true
+}
+
+func band(hash uint64, i int) uint8 {
+	return uint8(hash >> (8 * i))
+}
+
+// excerpt shortens text to at most n runes.
+func excerpt(text string, n int) string {
+	if utf8.RuneCountInString(text) <= n {
+		return text
+	}
+	return string([]rune(text)[:n-1]) + "…"
+}
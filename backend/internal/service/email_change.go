@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// emailChangeTTL is how long an emailed address-change link stays valid.
+const emailChangeTTL = 24 * time.Hour
+
+// EmailChangeSendFunc delivers a confirmation token to the new address.
+type EmailChangeSendFunc func(newEmail, token string, expiresAt time.Time) error
+
+// EmailChangedNoticeFunc tells the old address that the account moved.
+type EmailChangedNoticeFunc func(oldEmail, newEmail string) error
+
+// emailChange is a pending address change. Only the token's hash is stored.
+type emailChange struct {
+	UserID    string
+	NewEmail  string
+	ExpiresAt time.Time
+}
+
+// SetEmailChangeSender sets the hook that emails confirmation tokens to the
+// new address, and optionally one that notifies the old address afterwards.
+func (s *AuthService) SetEmailChangeSender(fn EmailChangeSendFunc, notice EmailChangedNoticeFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sendEmailChange = fn
+	s.noticeEmailChange = notice
+}
+
+// RequestEmailChange checks the user's password and emails a confirmation
+// link to the new address. The email column only changes once the link is
+// used.
+func (s *AuthService) RequestEmailChange(userID string, req model.ChangeEmailRequest) error {
+	newEmail := strings.TrimSpace(req.NewEmail)
+	if newEmail == "" || req.Password == "" {
+		return fmt.Errorf("new_email and password are required")
+	}
+	local, domain, ok := strings.Cut(newEmail, "@")
+	if !ok || local == "" || !strings.Contains(domain, ".") || strings.ContainsAny(newEmail, " \t\r\n") {
+		return fmt.Errorf("invalid email address")
+	}
+
+	current, hash, err := s.credentials(userID)
+	if err != nil {
+		return err
+	}
+	if !checkPassword(hash, req.Password) {
+		return fmt.Errorf("password is incorrect")
+	}
+	if newEmail == current {
+		return fmt.Errorf("new email must be different from the current one")
+	}
+	taken, err := s.emailTaken(newEmail)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("email already registered")
+	}
+
+	token := generateID() + generateID()
+	expiresAt := time.Now().Add(emailChangeTTL)
+	if err := s.storeEmailChange(hashResetToken(token), emailChange{
+		UserID: userID, NewEmail: newEmail, ExpiresAt: expiresAt,
+	}); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	send := s.sendEmailChange
+	s.mu.RUnlock()
+	if send == nil {
+		log.Printf("WARNING: Email change requested but no delivery hook is configured")
+		return nil
+	}
+	if err := send(newEmail, token, expiresAt); err != nil {
+		log.Printf("WARNING: Failed to deliver email change confirmation: %v", err)
+	}
+	return nil
+}
+
+// credentials returns a live user's email and password hash.
+func (s *AuthService) credentials(userID string) (email, hash string, err error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := s.pool.QueryRow(ctx,
+			`SELECT email, password_hash FROM users WHERE id = $1 AND anonymized_at IS NULL`, userID).Scan(&email, &hash)
+		if err == pgx.ErrNoRows {
+			return "", "", fmt.Errorf("user not found")
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("failed to look up account: %w", err)
+		}
+		return email, hash, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rec := range s.users {
+		if rec.User.ID == userID && !rec.Anonymized {
+			return rec.Email, rec.PasswordHash, nil
+		}
+	}
+	return "", "", fmt.Errorf("user not found")
+}
+
+// emailTaken reports whether any account, including anonymized ones, holds
+// the address.
+func (s *AuthService) emailTaken(email string) (bool, error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var taken bool
+		if err := s.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE email = $1)`, email).Scan(&taken); err != nil {
+			return false, fmt.Errorf("failed to look up account: %w", err)
+		}
+		return taken, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, taken := s.users[email]
+	return taken, nil
+}
+
+// storeEmailChange saves a new token, replacing any earlier pending one for
+// the user.
+func (s *AuthService) storeEmailChange(tokenHash string, c emailChange) error {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := s.pool.Exec(ctx, `DELETE FROM email_changes WHERE user_id = $1`, c.UserID); err != nil {
+			return fmt.Errorf("failed to start email change: %w", err)
+		}
+		_, err := s.pool.Exec(ctx,
+			`INSERT INTO email_changes (token_hash, user_id, new_email, expires_at) VALUES ($1, $2, $3, $4)`,
+			tokenHash, c.UserID, c.NewEmail, c.ExpiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to start email change: %w", err)
+		}
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for h, p := range s.emailChanges {
+		if p.UserID == c.UserID {
+			delete(s.emailChanges, h)
+		}
+	}
+	s.emailChanges[tokenHash] = c
+	return nil
+}
+
+// ConfirmEmailChange finishes an address change with an emailed token.
+// Tokens work once. If another account took the address in the meantime the
+// change fails with "email already registered" and the old address stays.
+func (s *AuthService) ConfirmEmailChange(token string) (*model.User, error) {
+	if token == "" {
+		return nil, fmt.Errorf("invalid or expired email change token")
+	}
+	tokenHash := hashResetToken(token)
+	now := time.Now()
+	var oldEmail string
+	var c emailChange
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// Deleting the row claims the token, so it can't be used twice.
+		err := s.pool.QueryRow(ctx,
+			`DELETE FROM email_changes WHERE token_hash = $1 RETURNING user_id, new_email, expires_at`,
+			tokenHash).Scan(&c.UserID, &c.NewEmail, &c.ExpiresAt)
+		if err == pgx.ErrNoRows || err == nil && !now.Before(c.ExpiresAt) {
+			return nil, fmt.Errorf("invalid or expired email change token")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to change email: %w", err)
+		}
+
+		// The old address is read in the same statement that replaces it.
+		err = s.pool.QueryRow(ctx,
+			`UPDATE users u SET email = $1 FROM users old
+			 WHERE u.id = $2 AND old.id = u.id AND u.anonymized_at IS NULL
+			 RETURNING old.email`,
+			c.NewEmail, c.UserID).Scan(&oldEmail)
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("invalid or expired email change token")
+		}
+		if err != nil {
+			errMsg := err.Error()
+			if contains(errMsg, "users_email_key") || contains(errMsg, "unique") && contains(errMsg, "email") {
+				return nil, fmt.Errorf("email already registered")
+			}
+			return nil, fmt.Errorf("failed to change email: %w", err)
+		}
+	} else {
+		s.mu.Lock()
+		var ok bool
+		c, ok = s.emailChanges[tokenHash]
+		if !ok || !now.Before(c.ExpiresAt) {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("invalid or expired email change token")
+		}
+		delete(s.emailChanges, tokenHash)
+		if _, taken := s.users[c.NewEmail]; taken {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("email already registered")
+		}
+		var rec *userRecord
+		for _, r := range s.users {
+			if r.User.ID == c.UserID && !r.Anonymized {
+				rec = r
+				break
+			}
+		}
+		if rec == nil {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("invalid or expired email change token")
+		}
+		oldEmail = rec.Email
+		delete(s.users, rec.Email)
+		rec.Email = c.NewEmail
+		s.users[c.NewEmail] = rec
+		s.mu.Unlock()
+	}
+
+	s.mu.RLock()
+	notice := s.noticeEmailChange
+	s.mu.RUnlock()
+	if notice != nil {
+		if err := notice(oldEmail, c.NewEmail); err != nil {
+			log.Printf("WARNING: Failed to notify old address of email change: %v", err)
+		}
+	}
+	return s.GetUser(c.UserID)
+}
+
+// PurgeExpiredEmailChanges deletes address-change tokens that expired before
+// cutoff.
+func (s *AuthService) PurgeExpiredEmailChanges(cutoff time.Time, dryRun bool) (int, error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if dryRun {
+			var n int
+			err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM email_changes WHERE expires_at < $1`, cutoff).Scan(&n)
+			return n, err
+		}
+		tag, err := s.pool.Exec(ctx, `DELETE FROM email_changes WHERE expires_at < $1`, cutoff)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge email change tokens: %w", err)
+		}
+		return int(tag.RowsAffected()), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for h, c := range s.emailChanges {
+		if c.ExpiresAt.Before(cutoff) {
+			if !dryRun {
+				delete(s.emailChanges, h)
+			}
+			n++
+		}
+	}
+	return n, nil
+}
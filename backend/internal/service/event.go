@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	maxEventTitleLength = 100
+	maxEventDuration    = 24 * time.Hour
+	maxEventBatch       = 100
+)
+
+// EventNotifyFunc is called for every newly posted event or special (e.g. to push followers).
+type EventNotifyFunc func(event model.VenueEvent)
+
+// EventService manages events and drink specials posted by venue owners.
+// Items with a publish_at stay hidden until then.
+type EventService struct {
+	mu        sync.RWMutex
+	pool      *pgxpool.Pool
+	events    []model.VenueEvent
+	scheduled map[string]bool // IDs of events whose followers haven't been notified yet
+	notify    EventNotifyFunc
+}
+
+func NewEventService(pool *pgxpool.Pool) *EventService {
+	svc := &EventService{
+		pool:      pool,
+		events:    []model.VenueEvent{},
+		scheduled: make(map[string]bool),
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *EventService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, venue_id, kind, title, COALESCE(description,''), starts_at, ends_at, weekly, created_by, created_at,
+		        publish_at, announced
+		 FROM venue_events ORDER BY starts_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load venue events from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e model.VenueEvent
+		var announced bool
+		if err := rows.Scan(&e.ID, &e.VenueID, &e.Kind, &e.Title, &e.Description, &e.StartsAt, &e.EndsAt,
+			&e.Weekly, &e.CreatedByID, &e.CreatedAt, &e.PublishAt, &announced); err != nil {
+			log.Printf("WARNING: Failed to scan venue event row: %v", err)
+			continue
+		}
+		s.events = append(s.events, e)
+		if !announced && e.PublishAt != nil {
+			s.scheduled[e.ID] = true
+		}
+	}
+	log.Printf("Loaded %d venue events from DB", len(s.events))
+}
+
+// SetNotifier registers a hook called for every newly created event or special.
+func (s *EventService) SetNotifier(fn EventNotifyFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notify = fn
+}
+
+// Create posts an event or special for a venue. Callers check venue ownership.
+// With a future publish_at it stays hidden, and followers aren't notified,
+// until the scheduler publishes it.
+func (s *EventService) Create(ctx context.Context, venueID string, req model.CreateVenueEventRequest) (*model.VenueEvent, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	e, err := newVenueEvent(venueID, userID, req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	s.add([]model.VenueEvent{e})
+	return &e, nil
+}
+
+// CreateBatch posts up to maxEventBatch events and specials at once, e.g. a
+// semester of theme nights with staggered publish_at times. Nothing is
+// created unless every item is valid.
+func (s *EventService) CreateBatch(ctx context.Context, venueID string, reqs []model.CreateVenueEventRequest) ([]model.VenueEvent, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("events are required")
+	}
+	if len(reqs) > maxEventBatch {
+		return nil, fmt.Errorf("at most %d events can be created at once", maxEventBatch)
+	}
+	now := time.Now()
+	events := make([]model.VenueEvent, 0, len(reqs))
+	for i, req := range reqs {
+		e, err := newVenueEvent(venueID, userID, req, now)
+		if err != nil {
+			return nil, fmt.Errorf("events[%d]: %w", i, err)
+		}
+		events = append(events, e)
+	}
+	s.add(events)
+	return events, nil
+}
+
+// newVenueEvent validates a request. A publish_at that has already passed
+// publishes immediately.
+func newVenueEvent(venueID, userID string, req model.CreateVenueEventRequest, now time.Time) (model.VenueEvent, error) {
+	if req.Kind != "event" && req.Kind != "special" {
+		return model.VenueEvent{}, fmt.Errorf("kind must be 'event' or 'special'")
+	}
+	req.Title = strings.TrimSpace(middleware.SanitizeString(req.Title))
+	if req.Title == "" {
+		return model.VenueEvent{}, fmt.Errorf("title is required")
+	}
+	if len(req.Title) > maxEventTitleLength {
+		return model.VenueEvent{}, fmt.Errorf("title must be at most %d characters", maxEventTitleLength)
+	}
+	if req.StartsAt.IsZero() || req.EndsAt.IsZero() {
+		return model.VenueEvent{}, fmt.Errorf("starts_at and ends_at are required")
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return model.VenueEvent{}, fmt.Errorf("ends_at must be after starts_at")
+	}
+	if req.EndsAt.Sub(req.StartsAt) > maxEventDuration {
+		return model.VenueEvent{}, fmt.Errorf("events can last at most 24 hours (use weekly for recurring specials)")
+	}
+	if !req.Weekly && req.EndsAt.Before(now) {
+		return model.VenueEvent{}, fmt.Errorf("event has already ended")
+	}
+	if req.PublishAt != nil {
+		if !req.PublishAt.After(now) {
+			req.PublishAt = nil
+		} else if !req.Weekly && !req.PublishAt.Before(req.EndsAt) {
+			return model.VenueEvent{}, fmt.Errorf("publish_at must be before ends_at")
+		}
+	}
+
+	return model.VenueEvent{
+		ID:          "event_" + generateID()[:16],
+		VenueID:     venueID,
+		Kind:        req.Kind,
+		Title:       req.Title,
+		Description: middleware.SanitizeString(req.Description),
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Weekly:      req.Weekly,
+		PublishAt:   req.PublishAt,
+		CreatedByID: userID,
+		CreatedAt:   now,
+	}, nil
+}
+
+// add stores new events, queues scheduled ones and notifies followers of
+// the rest.
+func (s *EventService) add(events []model.VenueEvent) {
+	var live []model.VenueEvent
+	s.mu.Lock()
+	for _, e := range events {
+		s.events = append(s.events, e)
+		if e.PublishAt != nil {
+			s.scheduled[e.ID] = true
+		} else {
+			live = append(live, e)
+		}
+	}
+	notify := s.notify
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		for _, e := range events {
+			_, err := s.pool.Exec(context.Background(),
+				`INSERT INTO venue_events (id, venue_id, kind, title, description, starts_at, ends_at, weekly, created_by, created_at, publish_at, announced)
+				 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+				e.ID, e.VenueID, e.Kind, e.Title, e.Description, e.StartsAt, e.EndsAt, e.Weekly, e.CreatedByID, e.CreatedAt,
+				e.PublishAt, e.PublishAt == nil)
+			if err != nil {
+				log.Printf("WARNING: Failed to persist venue event: %v", err)
+			}
+		}
+	}
+
+	if notify != nil {
+		for _, e := range live {
+			notify(e)
+		}
+	}
+}
+
+// Start publishes scheduled events every interval.
+func (s *EventService) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if n := s.PublishDue(now); n > 0 {
+				log.Printf("Published %d scheduled venue events", n)
+			}
+		}
+	}()
+}
+
+// PublishDue announces every scheduled event whose publish_at has passed and
+// returns how many there were. Events that ended before they were announced
+// (the server was down) are published silently.
+func (s *EventService) PublishDue(now time.Time) int {
+	var due []model.VenueEvent
+	s.mu.Lock()
+	for _, e := range s.events {
+		if s.scheduled[e.ID] && !now.Before(*e.PublishAt) {
+			delete(s.scheduled, e.ID)
+			due = append(due, e)
+		}
+	}
+	notify := s.notify
+	s.mu.Unlock()
+
+	for _, e := range due {
+		if s.pool != nil {
+			if _, err := s.pool.Exec(context.Background(),
+				`UPDATE venue_events SET announced = TRUE WHERE id = $1`, e.ID); err != nil {
+				log.Printf("WARNING: Failed to mark venue event announced: %v", err)
+			}
+		}
+		if _, ok := nextOccurrence(e, now, time.UTC); ok && notify != nil {
+			notify(e)
+		}
+	}
+	return len(due)
+}
+
+// Scheduled returns a venue's events and specials that aren't published
+// yet, soonest publish_at first.
+func (s *EventService) Scheduled(venueID string, now time.Time) []model.VenueEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := []model.VenueEvent{}
+	for _, e := range s.events {
+		if e.VenueID == venueID && !published(e, now) {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PublishAt.Before(*out[j].PublishAt) })
+	return out
+}
+
+// published reports whether an event is visible at now.
+func published(e model.VenueEvent, now time.Time) bool {
+	return e.PublishAt == nil || !now.Before(*e.PublishAt)
+}
+
+// Get returns an event by ID.
+func (s *EventService) Get(id string) (*model.VenueEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, e := range s.events {
+		if e.ID == id {
+			out := e
+			return &out, nil
+		}
+	}
+	return nil, fmt.Errorf("event not found")
+}
+
+// Delete removes an event. Callers check venue ownership.
+func (s *EventService) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.events {
+		if s.events[i].ID == id {
+			s.events = append(s.events[:i], s.events[i+1:]...)
+			delete(s.scheduled, id)
+			if s.pool != nil {
+				if _, err := s.pool.Exec(context.Background(), `DELETE FROM venue_events WHERE id=$1`, id); err != nil {
+					log.Printf("WARNING: Failed to delete venue event from DB: %v", err)
+				}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("event not found")
+}
+
+// Upcoming returns a venue's published current and future events and
+// specials, with weekly items shifted to their next occurrence, soonest first.
+func (s *EventService) Upcoming(venueID string, now time.Time, loc *time.Location) []model.VenueEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := []model.VenueEvent{}
+	for _, e := range s.events {
+		if e.VenueID != venueID || !published(e, now) {
+			continue
+		}
+		if occ, ok := nextOccurrence(e, now, loc); ok {
+			out = append(out, occ)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartsAt.Before(out[j].StartsAt) })
+	return out
+}
+
+// Between returns a venue's events and specials with an occurrence overlapping [from, to).
+func (s *EventService) Between(venueID string, from, to time.Time, loc *time.Location) []model.VenueEvent {
+	var out []model.VenueEvent
+	for _, e := range s.Upcoming(venueID, from, loc) {
+		if e.StartsAt.Before(to) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// nextOccurrence returns the event as it occurs at or after now (the current
+// occurrence if it's in progress). Weekly items repeat on the same local
+// weekday and wall-clock time, so DST changes don't shift them.
+func nextOccurrence(e model.VenueEvent, now time.Time, loc *time.Location) (model.VenueEvent, bool) {
+	if now.Before(e.EndsAt) {
+		return e, true
+	}
+	if !e.Weekly {
+		return e, false
+	}
+	duration := e.EndsAt.Sub(e.StartsAt)
+	start := e.StartsAt.In(loc)
+	weeks := int(now.Sub(start).Hours() / (24 * 7))
+	start = start.AddDate(0, 0, 7*weeks)
+	for !now.Before(start.Add(duration)) {
+		start = start.AddDate(0, 0, 7)
+	}
+	e.StartsAt = start
+	e.EndsAt = start.Add(duration)
+	return e, true
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// EXIF tags needed to read a photo's GPS position.
+const (
+	exifGPSIFDPointer = 0x8825
+	gpsLatitudeRef    = 0x0001
+	gpsLatitude       = 0x0002
+	gpsLongitudeRef   = 0x0003
+	gpsLongitude      = 0x0004
+	exifTypeRational  = 5
+)
+
+// exifGPS extracts the GPS position from a JPEG's EXIF block. ok is false
+// when the image isn't a JPEG, has no EXIF, or has no usable GPS tags.
+func exifGPS(data []byte) (lat, lng float64, ok bool) {
+	tiff := jpegExif(data)
+	if len(tiff) < 8 {
+		return 0, 0, false
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, 0, false
+	}
+
+	ifd0 := order.Uint32(tiff[4:8])
+	gpsOffset, found := exifIFDValue(tiff, order, ifd0, exifGPSIFDPointer)
+	if !found {
+		return 0, 0, false
+	}
+
+	entries := exifIFDEntries(tiff, order, gpsOffset)
+	latRef, lngRef := entries[gpsLatitudeRef], entries[gpsLongitudeRef]
+	latVal, lngVal := entries[gpsLatitude], entries[gpsLongitude]
+	if latVal == nil || lngVal == nil {
+		return 0, 0, false
+	}
+	lat, okLat := exifDegrees(tiff, order, latVal)
+	lng, okLng := exifDegrees(tiff, order, lngVal)
+	if !okLat || !okLng || lat > 90 || lng > 180 {
+		return 0, 0, false
+	}
+	if latRef != nil && latRef[8] == 'S' {
+		lat = -lat
+	}
+	if lngRef != nil && lngRef[8] == 'W' {
+		lng = -lng
+	}
+	return lat, lng, true
+}
+
+// jpegExif returns the TIFF payload of a JPEG's APP1 Exif segment.
+func jpegExif(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 { // start of scan / end of image
+			return nil
+		}
+		size := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if size < 2 || i+2+size > len(data) {
+			return nil
+		}
+		segment := data[i+4 : i+2+size]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		i += 2 + size
+	}
+	return nil
+}
+
+// exifIFDEntries returns the raw 12-byte entries of an IFD keyed by tag.
+func exifIFDEntries(tiff []byte, order binary.ByteOrder, offset uint32) map[uint16][]byte {
+	entries := make(map[uint16][]byte)
+	if int(offset)+2 > len(tiff) {
+		return entries
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	start := int(offset) + 2
+	for i := 0; i < count; i++ {
+		pos := start + i*12
+		if pos+12 > len(tiff) {
+			break
+		}
+		entry := tiff[pos : pos+12]
+		entries[order.Uint16(entry)] = entry
+	}
+	return entries
+}
+
+// exifIFDValue returns the 32-bit value field of a tag in an IFD.
+func exifIFDValue(tiff []byte, order binary.ByteOrder, offset uint32, tag uint16) (uint32, bool) {
+	entry, ok := exifIFDEntries(tiff, order, offset)[tag]
+	if !ok {
+		return 0, false
+	}
+	return order.Uint32(entry[8:12]), true
+}
+
+// exifDegrees converts a degrees/minutes/seconds rational triple to decimal degrees.
+func exifDegrees(tiff []byte, order binary.ByteOrder, entry []byte) (float64, bool) {
+	if order.Uint16(entry[2:4]) != exifTypeRational || order.Uint32(entry[4:8]) != 3 {
+		return 0, false
+	}
+	offset := int(order.Uint32(entry[8:12]))
+	if offset+24 > len(tiff) {
+		return 0, false
+	}
+
+	var parts [3]float64
+	for i := range parts {
+		num := order.Uint32(tiff[offset+i*8:])
+		den := order.Uint32(tiff[offset+i*8+4:])
+		if den == 0 {
+			return 0, false
+		}
+		parts[i] = float64(num) / float64(den)
+	}
+	return parts[0] + parts[1]/60 + parts[2]/3600, true
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// defaultFeatures are the client feature flags and their default state.
+var defaultFeatures = map[string]bool{
+	"photos":          true,
+	"check_ins":       true,
+	"verified_visits": true,
+	"venue_lists":     true,
+	"push":            true,
+	"events":          true,
+}
+
+// FeatureFlags holds on/off switches the apps read at startup to show or hide
+// features without a release.
+type FeatureFlags struct {
+	flags map[string]bool
+}
+
+// LoadFeatureFlags starts from the defaults and applies FEATURE_FLAGS, e.g.
+// FEATURE_FLAGS="photos=false,beta_map=true". Unknown names are allowed so
+// new client flags can be turned on before the server knows about them.
+func LoadFeatureFlags() *FeatureFlags {
+	flags := make(map[string]bool, len(defaultFeatures))
+	for name, on := range defaultFeatures {
+		flags[name] = on
+	}
+	for _, part := range strings.Split(os.Getenv("FEATURE_FLAGS"), ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || name == "" {
+			continue
+		}
+		on, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf("WARNING: Ignoring feature flag %q: %v", part, err)
+			continue
+		}
+		flags[name] = on
+	}
+	return &FeatureFlags{flags: flags}
+}
+
+// Enabled reports whether a flag is on.
+func (f *FeatureFlags) Enabled(name string) bool {
+	return f.flags[name]
+}
+
+// All returns a copy of every flag.
+func (f *FeatureFlags) All() map[string]bool {
+	out := make(map[string]bool, len(f.flags))
+	for name, on := range f.flags {
+		out[name] = on
+	}
+	return out
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+)
+
+type followKey struct {
+	UserID  string
+	VenueID string
+}
+
+// FollowService tracks which users follow which venues.
+type FollowService struct {
+	mu      sync.RWMutex
+	pool    *pgxpool.Pool
+	follows map[followKey]time.Time
+}
+
+func NewFollowService(pool *pgxpool.Pool) *FollowService {
+	svc := &FollowService{
+		pool:    pool,
+		follows: make(map[followKey]time.Time),
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *FollowService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT user_id, venue_id, created_at FROM venue_follows`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load venue follows from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var k followKey
+		var at time.Time
+		if err := rows.Scan(&k.UserID, &k.VenueID, &at); err != nil {
+			log.Printf("WARNING: Failed to scan venue follow row: %v", err)
+			continue
+		}
+		s.follows[k] = at
+	}
+	log.Printf("Loaded %d venue follows from DB", len(s.follows))
+}
+
+// Follow subscribes the current user to updates from a venue. Following twice is a no-op.
+func (s *FollowService) Follow(ctx context.Context, venueID string) error {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return fmt.Errorf("authentication required")
+	}
+
+	key := followKey{UserID: userID, VenueID: venueID}
+	now := time.Now()
+
+	s.mu.Lock()
+	if _, ok := s.follows[key]; ok {
+		s.mu.Unlock()
+		return nil
+	}
+	s.follows[key] = now
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO venue_follows (user_id, venue_id, created_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+			userID, venueID, now)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist venue follow: %v", err)
+		}
+	}
+	return nil
+}
+
+// Unfollow removes the current user's follow of a venue.
+func (s *FollowService) Unfollow(ctx context.Context, venueID string) error {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return fmt.Errorf("authentication required")
+	}
+
+	s.mu.Lock()
+	delete(s.follows, followKey{UserID: userID, VenueID: venueID})
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`DELETE FROM venue_follows WHERE user_id=$1 AND venue_id=$2`, userID, venueID)
+		if err != nil {
+			log.Printf("WARNING: Failed to delete venue follow from DB: %v", err)
+		}
+	}
+	return nil
+}
+
+// MergeUser moves fromID's follows to toID and returns the venue IDs; with
+// dryRun nothing changes.
+func (s *FollowService) MergeUser(fromID, toID string, dryRun bool) []string {
+	s.mu.Lock()
+	ids := []string{}
+	for k, at := range s.follows {
+		if k.UserID != fromID {
+			continue
+		}
+		ids = append(ids, k.VenueID)
+		if dryRun {
+			continue
+		}
+		delete(s.follows, k)
+		to := followKey{UserID: toID, VenueID: k.VenueID}
+		if existing, ok := s.follows[to]; !ok || at.Before(existing) {
+			s.follows[to] = at
+		}
+	}
+	s.mu.Unlock()
+
+	if !dryRun && s.pool != nil {
+		ctx := context.Background()
+		if _, err := s.pool.Exec(ctx,
+			`INSERT INTO venue_follows (user_id, venue_id, created_at)
+			 SELECT $1, venue_id, created_at FROM venue_follows WHERE user_id = $2
+			 ON CONFLICT (user_id, venue_id) DO UPDATE SET created_at = LEAST(venue_follows.created_at, EXCLUDED.created_at)`,
+			toID, fromID); err != nil {
+			log.Printf("WARNING: Failed to move venue follows while merging users: %v", err)
+		}
+		if _, err := s.pool.Exec(ctx, `DELETE FROM venue_follows WHERE user_id = $1`, fromID); err != nil {
+			log.Printf("WARNING: Failed to delete merged venue follows: %v", err)
+		}
+	}
+	return ids
+}
+
+// IsFollowing reports whether a user follows a venue.
+func (s *FollowService) IsFollowing(userID, venueID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.follows[followKey{UserID: userID, VenueID: venueID}]
+	return ok
+}
+
+// FollowersOf returns the IDs of users following a venue.
+func (s *FollowService) FollowersOf(venueID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ids []string
+	for k := range s.follows {
+		if k.VenueID == venueID {
+			ids = append(ids, k.UserID)
+		}
+	}
+	return ids
+}
+
+// FollowersOfAny returns the IDs of users following at least one of the
+// venues, each once.
+func (s *FollowService) FollowersOfAny(venueIDs []string) []string {
+	venues := toSet(venueIDs)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var ids []string
+	for k := range s.follows {
+		if _, ok := venues[k.VenueID]; ok && !seen[k.UserID] {
+			seen[k.UserID] = true
+			ids = append(ids, k.UserID)
+		}
+	}
+	return ids
+}
+
+// FollowedBy returns the IDs of venues a user follows.
+func (s *FollowService) FollowedBy(userID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ids []string
+	for k := range s.follows {
+		if k.UserID == userID {
+			ids = append(ids, k.VenueID)
+		}
+	}
+	return ids
+}
+
+// FollowerCount returns how many users follow a venue.
+func (s *FollowService) FollowerCount(venueID string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n := 0
+	for k := range s.follows {
+		if k.VenueID == venueID {
+			n++
+		}
+	}
+	return n
+}
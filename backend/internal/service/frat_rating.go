@@ -25,7 +25,13 @@ type FratRatingService struct {
 	ratings []model.FratRating
 	nextID  int
 
-	userDailyCounts map[string]*dailyCount
+	quota    *QuotaService
+	velocity *VelocityService   // optional
+	locks    *RatingLockService // optional
+
+	// fraternities hides chapter ratings at schools that opted out of Greek
+	// life and refuses ratings for banned chapters; optional.
+	fraternities *FraternityService
 }
 
 func NewFratRatingService(pool *pgxpool.Pool) *FratRatingService {
@@ -33,7 +39,7 @@ func NewFratRatingService(pool *pgxpool.Pool) *FratRatingService {
 		pool:            pool,
 		ratings:         []model.FratRating{},
 		nextID:          1,
-		userDailyCounts: make(map[string]*dailyCount),
+		quota:           NewQuotaService(nil, maxRatingsPerDay),
 	}
 	if pool != nil {
 		svc.loadFromDB()
@@ -41,6 +47,40 @@ func NewFratRatingService(pool *pgxpool.Pool) *FratRatingService {
 	return svc
 }
 
+// SetQuota shares a daily rating budget with other rating types.
+func (s *FratRatingService) SetQuota(quota *QuotaService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quota = quota
+}
+
+// SetVelocity enables rating spike detection and freezes.
+func (s *FratRatingService) SetVelocity(velocity *VelocityService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.velocity = velocity
+}
+
+// SetLocks makes admin rating locks refuse new ratings.
+func (s *FratRatingService) SetLocks(locks *RatingLockService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locks = locks
+}
+
+// SetFraternities hides ratings at schools that opted out of Greek life.
+func (s *FratRatingService) SetFraternities(fraternities *FraternityService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fraternities = fraternities
+}
+
+// hiddenLocked reports whether a school's chapter ratings are hidden. Caller
+// holds s.mu.
+func (s *FratRatingService) hiddenLocked(schoolID string) bool {
+	return s.fraternities != nil && s.fraternities.OptedOut(schoolID)
+}
+
 func (s *FratRatingService) loadFromDB() {
 	rows, err := s.pool.Query(context.Background(),
 		`SELECT id, frat_name, school_id, score, author_id, COALESCE(author_name,''), created_at FROM frat_ratings ORDER BY created_at`)
@@ -69,8 +109,8 @@ func (s *FratRatingService) Create(ctx context.Context, req model.CreateFratRati
 		return nil, fmt.Errorf("authentication required")
 	}
 
-	if req.Score < 1 || req.Score > 5 {
-		return nil, fmt.Errorf("score must be between 1 and 5")
+	if err := ValidateScore(req.Score); err != nil {
+		return nil, err
 	}
 	if req.FratName == "" {
 		return nil, fmt.Errorf("frat_name is required")
@@ -82,10 +122,11 @@ func (s *FratRatingService) Create(ctx context.Context, req model.CreateFratRati
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	today := time.Now().Format("2006-01-02")
-	dc, exists := s.userDailyCounts[userID]
-	if exists && dc.date == today && dc.count >= maxRatingsPerDay {
-		return nil, fmt.Errorf("daily rating limit reached (%d per day)", maxRatingsPerDay)
+	if s.hiddenLocked(req.SchoolID) {
+		return nil, fmt.Errorf("fraternity ratings are not available at this school")
+	}
+	if s.fraternities != nil && s.fraternities.Status(req.SchoolID, req.FratName) == ChapterBanned {
+		return nil, fmt.Errorf("this chapter is banned and no longer accepts ratings")
 	}
 
 	for _, r := range s.ratings {
@@ -94,6 +135,24 @@ func (s *FratRatingService) Create(ctx context.Context, req model.CreateFratRati
 		}
 	}
 
+	target := FratTargetID(req.SchoolID, req.FratName)
+	if s.locks != nil {
+		if err := s.locks.Check(RatingTargetFrat, target); err != nil {
+			return nil, err
+		}
+	}
+	if s.velocity != nil {
+		if err := s.velocity.CheckFrozen(RatingTargetFrat, target); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.quota.Consume(userID); err != nil {
+		return nil, err
+	}
+	if s.velocity != nil {
+		s.velocity.Observe(RatingTargetFrat, target, time.Now())
+	}
+
 	rating := model.FratRating{
 		ID:         fmt.Sprintf("fratrating_%d", s.nextID),
 		FratName:   req.FratName,
@@ -106,17 +165,11 @@ func (s *FratRatingService) Create(ctx context.Context, req model.CreateFratRati
 	s.nextID++
 	s.ratings = append(s.ratings, rating)
 
-	if !exists || dc.date != today {
-		s.userDailyCounts[userID] = &dailyCount{count: 1, date: today}
-	} else {
-		dc.count++
-	}
-
 	if s.pool != nil {
 		_, err := s.pool.Exec(context.Background(),
 			`INSERT INTO frat_ratings (id, frat_name, school_id, score, author_id, author_name, created_at)
 			 VALUES ($1, $2, $3, $4, $5, $6, $7)
-			 ON CONFLICT (frat_name, school_id, author_id) DO NOTHING`,
+			 ON CONFLICT (frat_name, school_id, author_id) WHERE author_id <> 'deleted' DO NOTHING`,
 			rating.ID, rating.FratName, rating.SchoolID, rating.Score, rating.AuthorID, rating.AuthorName, rating.CreatedAt)
 		if err != nil {
 			log.Printf("WARNING: Failed to persist frat rating: %v", err)
@@ -139,7 +192,7 @@ func (s *FratRatingService) GetStats(schoolID, fratName string) (avgRating float
 		}
 	}
 	if count > 0 {
-		avgRating = total / float64(count)
+		avgRating = RoundAverage(total / float64(count))
 	}
 	return
 }
@@ -171,7 +224,7 @@ func (s *FratRatingService) GetSchoolStats(schoolID string) map[string]model.Fra
 	for name, a := range m {
 		result[name] = model.FratWithRating{
 			Name:        name,
-			AvgRating:   a.total / float64(a.count),
+			AvgRating:   RoundAverage(a.total / float64(a.count)),
 			RatingCount: a.count,
 		}
 	}
@@ -187,13 +240,174 @@ func (s *FratRatingService) GetRecent(limit int) []model.FratRating {
 	if limit <= 0 || limit > n {
 		limit = n
 	}
-	result := make([]model.FratRating, limit)
-	for i := 0; i < limit; i++ {
-		result[i] = s.ratings[n-1-i]
+	result := make([]model.FratRating, 0, limit)
+	for i := n - 1; i >= 0 && len(result) < limit; i-- {
+		if !s.hiddenLocked(s.ratings[i].SchoolID) {
+			result = append(result, s.ratings[i])
+		}
+	}
+	return result
+}
+
+// GetRecentForSchool returns the N most recent frat ratings at one school.
+func (s *FratRatingService) GetRecentForSchool(schoolID string, limit int) []model.FratRating {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.hiddenLocked(schoolID) {
+		return nil
+	}
+	var result []model.FratRating
+	for i := len(s.ratings) - 1; i >= 0 && len(result) < limit; i-- {
+		if s.ratings[i].SchoolID == schoolID {
+			result = append(result, s.ratings[i])
+		}
 	}
 	return result
 }
 
+// ListByAuthor returns up to limit of a user's chapter ratings, newest first,
+// and how many they have in total. Ratings at schools that opted out of
+// Greek listings are left out.
+func (s *FratRatingService) ListByAuthor(userID string, limit int) ([]model.FratRating, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []model.FratRating
+	total := 0
+	for i := len(s.ratings) - 1; i >= 0; i-- {
+		if s.ratings[i].AuthorID != userID || s.hiddenLocked(s.ratings[i].SchoolID) {
+			continue
+		}
+		total++
+		if len(out) < limit {
+			out = append(out, s.ratings[i])
+		}
+	}
+	return out, total
+}
+
+// LastActiveByUser returns when each author last rated a chapter.
+func (s *FratRatingService) LastActiveByUser() map[string]time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]time.Time)
+	for _, r := range s.ratings {
+		if r.CreatedAt.After(out[r.AuthorID]) {
+			out[r.AuthorID] = r.CreatedAt
+		}
+	}
+	return out
+}
+
+// AnonymizeAuthor replaces the author name on all of a user's chapter ratings.
+func (s *FratRatingService) AnonymizeAuthor(userID string) {
+	s.mu.Lock()
+	for i := range s.ratings {
+		if s.ratings[i].AuthorID == userID {
+			s.ratings[i].AuthorName = AnonymousUsername
+		}
+	}
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`UPDATE frat_ratings SET author_name = $1 WHERE author_id = $2`, AnonymousUsername, userID); err != nil {
+			log.Printf("WARNING: Failed to anonymize frat ratings: %v", err)
+		}
+	}
+}
+
+// DeleteAuthor detaches a deleted account from its chapter ratings.
+func (s *FratRatingService) DeleteAuthor(userID string) {
+	s.mu.Lock()
+	for i := range s.ratings {
+		if s.ratings[i].AuthorID == userID {
+			s.ratings[i].AuthorID = DeletedAuthorID
+			s.ratings[i].AuthorName = ""
+		}
+	}
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`UPDATE frat_ratings SET author_id = $1, author_name = NULL WHERE author_id = $2`, DeletedAuthorID, userID); err != nil {
+			log.Printf("WARNING: Failed to detach deleted author from frat ratings: %v", err)
+		}
+	}
+}
+
+// MergeAuthor moves fromID's chapter ratings to toID, deleting any for a
+// chapter toID already rated. It returns the affected IDs by kind
+// ("frat_ratings", "frat_ratings_dropped"); with dryRun nothing changes.
+func (s *FratRatingService) MergeAuthor(fromID, toID, toName string, dryRun bool) map[string][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rated := make(map[string]bool)
+	for _, r := range s.ratings {
+		if r.AuthorID == toID {
+			rated[FratTargetID(r.SchoolID, r.FratName)] = true
+		}
+	}
+	out := map[string][]string{"frat_ratings": {}, "frat_ratings_dropped": {}}
+	dropped := make(map[string]bool)
+	for _, r := range s.ratings {
+		if r.AuthorID != fromID {
+			continue
+		}
+		if target := FratTargetID(r.SchoolID, r.FratName); rated[target] {
+			dropped[r.ID] = true
+			out["frat_ratings_dropped"] = append(out["frat_ratings_dropped"], r.ID)
+		} else {
+			rated[target] = true
+			out["frat_ratings"] = append(out["frat_ratings"], r.ID)
+		}
+	}
+	if dryRun {
+		return out
+	}
+
+	kept := s.ratings[:0]
+	for _, r := range s.ratings {
+		if dropped[r.ID] {
+			continue
+		}
+		if r.AuthorID == fromID {
+			r.AuthorID, r.AuthorName = toID, toName
+		}
+		kept = append(kept, r)
+	}
+	s.ratings = kept
+
+	if s.pool != nil {
+		ctx := context.Background()
+		if _, err := s.pool.Exec(ctx, `DELETE FROM frat_ratings WHERE id = ANY($1)`, out["frat_ratings_dropped"]); err != nil {
+			log.Printf("WARNING: Failed to delete duplicate frat ratings while merging users: %v", err)
+		}
+		if _, err := s.pool.Exec(ctx,
+			`UPDATE frat_ratings SET author_id = $1, author_name = $2 WHERE author_id = $3`, toID, toName, fromID); err != nil {
+			log.Printf("WARNING: Failed to move frat ratings while merging users: %v", err)
+		}
+	}
+	return out
+}
+
+// IDsByChapter returns the ratings of one chapter.
+func (s *FratRatingService) IDsByChapter(schoolID, fratName string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := []string{}
+	for _, r := range s.ratings {
+		if r.SchoolID == schoolID && r.FratName == fratName {
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids
+}
+
 // Count returns the total number of frat ratings.
 func (s *FratRatingService) Count() int {
 	s.mu.RLock()
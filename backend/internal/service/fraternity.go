@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"slices"
 	"sort"
 	"sync"
 
@@ -12,9 +13,30 @@ import (
 	"github.com/ratemybars/backend/internal/model"
 )
 
+// Chapter statuses. Banned chapters keep their rating history but accept no
+// new ratings.
+const (
+	ChapterActive    = "active"
+	ChapterSuspended = "suspended"
+	ChapterBanned    = "banned"
+)
+
+func validChapterStatus(status string) bool {
+	return status == ChapterActive || status == ChapterSuspended || status == ChapterBanned
+}
+
 type fratEntry struct {
-	Name     string `json:"name"`
-	SchoolID string `json:"school_id"`
+	Name        string `json:"name"`
+	SchoolID    string `json:"school_id"`
+	Chapter     string `json:"chapter,omitempty"`
+	FoundedYear int    `json:"founded_year,omitempty"`
+	Status      string `json:"status,omitempty"` // defaults to active
+}
+
+// chapterMeta is optional chapter data from the importer.
+type chapterMeta struct {
+	Chapter     string
+	FoundedYear int
 }
 
 // StatsFunc returns rating stats for all frats at a school.
@@ -28,6 +50,14 @@ type FraternityService struct {
 	byName   map[string][]string // frat_name -> school IDs
 	allNames []string            // sorted unique frat names
 	statsFn  StatsFunc
+
+	// optedOut holds schools that asked for Greek life to be removed from
+	// their campus. Their chapters are hidden everywhere.
+	optedOut map[string]bool
+
+	// status holds non-active chapters, from the seed data or admin edits.
+	status map[fratKey]string
+	meta   map[fratKey]chapterMeta
 }
 
 func NewFraternityService(pool *pgxpool.Pool) *FraternityService {
@@ -35,6 +65,9 @@ func NewFraternityService(pool *pgxpool.Pool) *FraternityService {
 		pool:     pool,
 		bySchool: make(map[string][]string),
 		byName:   make(map[string][]string),
+		optedOut: make(map[string]bool),
+		status:   make(map[fratKey]string),
+		meta:     make(map[fratKey]chapterMeta),
 	}
 }
 
@@ -59,6 +92,12 @@ func (s *FraternityService) Load(data []byte) error {
 	nameSet := make(map[string]bool, 120)
 
 	for _, e := range entries {
+		if e.Chapter != "" || e.FoundedYear != 0 {
+			s.meta[fratKey{e.SchoolID, e.Name}] = chapterMeta{Chapter: e.Chapter, FoundedYear: e.FoundedYear}
+		}
+		if e.Status != "" && e.Status != ChapterActive && validChapterStatus(e.Status) {
+			s.status[fratKey{e.SchoolID, e.Name}] = e.Status
+		}
 		s.bySchool[e.SchoolID] = append(s.bySchool[e.SchoolID], e.Name)
 		s.byName[e.Name] = append(s.byName[e.Name], e.SchoolID)
 		nameSet[e.Name] = true
@@ -96,6 +135,41 @@ func (s *FraternityService) Load(data []byte) error {
 		sort.Strings(s.bySchool[schoolID])
 	}
 
+	if s.pool != nil {
+		rows, err := s.pool.Query(context.Background(), `SELECT school_id FROM greek_opt_outs`)
+		if err != nil {
+			log.Printf("WARNING: Failed to load Greek life opt-outs from DB: %v", err)
+		} else {
+			defer rows.Close()
+			for rows.Next() {
+				var schoolID string
+				if err := rows.Scan(&schoolID); err != nil {
+					continue
+				}
+				s.optedOut[schoolID] = true
+			}
+		}
+
+		// Admin edits override the seed data.
+		rows, err = s.pool.Query(context.Background(), `SELECT school_id, frat_name, status FROM chapter_statuses`)
+		if err != nil {
+			log.Printf("WARNING: Failed to load chapter statuses from DB: %v", err)
+		} else {
+			defer rows.Close()
+			for rows.Next() {
+				var schoolID, fratName, status string
+				if err := rows.Scan(&schoolID, &fratName, &status); err != nil {
+					continue
+				}
+				if status == ChapterActive {
+					delete(s.status, fratKey{schoolID, fratName})
+				} else if validChapterStatus(status) {
+					s.status[fratKey{schoolID, fratName}] = status
+				}
+			}
+		}
+	}
+
 	s.allNames = make([]string, 0, len(nameSet))
 	for name := range nameSet {
 		s.allNames = append(s.allNames, name)
@@ -111,19 +185,86 @@ func (s *FraternityService) ListAll() []string {
 	return s.allNames
 }
 
+// Search returns organization names matching a full or partial name, Greek
+// letters ("ΣΑΕ"), an abbreviation ("SAE") or a nickname ("Fiji"). Exact
+// matches come first.
+func (s *FraternityService) Search(query string) []string {
+	q := foldGreek(query)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	exact, partial := []string{}, []string{}
+	for _, name := range s.allNames {
+		if fratNameEquals(q, name) {
+			exact = append(exact, name)
+		} else if FratNameMatches(query, name) {
+			partial = append(partial, name)
+		}
+	}
+	return append(exact, partial...)
+}
+
+// SchoolsForQuery returns the schools hosting a chapter of the organization
+// the query names exactly (e.g. "SAE"), for the global school search.
+func (s *FraternityService) SchoolsForQuery(query string) map[string]bool {
+	q := foldGreek(query)
+	if q == "" {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ids map[string]bool
+	for _, name := range s.allNames {
+		if !fratNameEquals(q, name) {
+			continue
+		}
+		if ids == nil {
+			ids = make(map[string]bool)
+		}
+		for _, id := range s.byName[name] {
+			if !s.optedOut[id] {
+				ids[id] = true
+			}
+		}
+	}
+	return ids
+}
+
 func (s *FraternityService) GetSchoolsByFrat(fratName string) []string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.byName[fratName]
+	if len(s.optedOut) == 0 {
+		return s.byName[fratName]
+	}
+	var ids []string
+	for _, id := range s.byName[fratName] {
+		if !s.optedOut[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
 }
 
 func (s *FraternityService) GetBySchool(schoolID string) []model.FratWithRating {
 	s.mu.RLock()
 	names := s.bySchool[schoolID]
 	statsFn := s.statsFn
+	optedOut := s.optedOut[schoolID]
+	statuses := make(map[string]string)
+	metas := make(map[string]chapterMeta)
+	for _, name := range names {
+		key := fratKey{schoolID, name}
+		if st, ok := s.status[key]; ok {
+			statuses[name] = st
+		}
+		if m, ok := s.meta[key]; ok {
+			metas[name] = m
+		}
+	}
 	s.mu.RUnlock()
 
-	if len(names) == 0 {
+	if len(names) == 0 || optedOut {
 		return nil
 	}
 
@@ -134,11 +275,16 @@ func (s *FraternityService) GetBySchool(schoolID string) []model.FratWithRating
 
 	result := make([]model.FratWithRating, 0, len(names))
 	for _, name := range names {
-		if fwr, ok := stats[name]; ok {
-			result = append(result, fwr)
-		} else {
-			result = append(result, model.FratWithRating{Name: name})
+		fwr, ok := stats[name]
+		if !ok {
+			fwr = model.FratWithRating{Name: name}
+		}
+		fwr.Chapter, fwr.FoundedYear = metas[name].Chapter, metas[name].FoundedYear
+		fwr.Status = ChapterActive
+		if st, ok := statuses[name]; ok {
+			fwr.Status = st
 		}
+		result = append(result, fwr)
 	}
 
 	sort.SliceStable(result, func(i, j int) bool {
@@ -242,9 +388,112 @@ func (s *FraternityService) RemoveFromSchool(fratName, schoolID string) bool {
 func (s *FraternityService) Count(schoolID string) int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	if s.optedOut[schoolID] {
+		return 0
+	}
 	return len(s.bySchool[schoolID])
 }
 
+// OptedOut reports whether a school has opted out of Greek life listings.
+func (s *FraternityService) OptedOut(schoolID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.optedOut[schoolID]
+}
+
+// OptedOutSchools returns the IDs of schools that opted out, sorted.
+func (s *FraternityService) OptedOutSchools() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.optedOut))
+	for id := range s.optedOut {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// SetOptOut hides (or restores) a school's fraternities and chapter ratings.
+// Chapter data is kept so opting back in restores it unchanged.
+func (s *FraternityService) SetOptOut(schoolID string, optOut bool) {
+	s.mu.Lock()
+	if optOut {
+		s.optedOut[schoolID] = true
+	} else {
+		delete(s.optedOut, schoolID)
+	}
+	s.mu.Unlock()
+
+	if s.pool == nil {
+		return
+	}
+	var err error
+	if optOut {
+		_, err = s.pool.Exec(context.Background(),
+			`INSERT INTO greek_opt_outs (school_id) VALUES ($1) ON CONFLICT DO NOTHING`, schoolID)
+	} else {
+		_, err = s.pool.Exec(context.Background(), `DELETE FROM greek_opt_outs WHERE school_id = $1`, schoolID)
+	}
+	if err != nil {
+		log.Printf("WARNING: Failed to persist Greek life opt-out: %v", err)
+	}
+}
+
+// HasChapter reports whether a frat is listed at a school.
+func (s *FraternityService) HasChapter(schoolID, fratName string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Contains(s.bySchool[schoolID], fratName)
+}
+
+// Status returns a chapter's status; chapters without one are active.
+func (s *FraternityService) Status(schoolID, fratName string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if st, ok := s.status[fratKey{schoolID, fratName}]; ok {
+		return st
+	}
+	return ChapterActive
+}
+
+// SetStatus changes a listed chapter's status (admin only).
+func (s *FraternityService) SetStatus(schoolID, fratName, status string) error {
+	if !validChapterStatus(status) {
+		return fmt.Errorf("status must be active, suspended or banned")
+	}
+
+	s.mu.Lock()
+	listed := false
+	for _, n := range s.bySchool[schoolID] {
+		if n == fratName {
+			listed = true
+			break
+		}
+	}
+	if !listed {
+		s.mu.Unlock()
+		return fmt.Errorf("fraternity not found at this school")
+	}
+	key := fratKey{schoolID, fratName}
+	if status == ChapterActive {
+		delete(s.status, key)
+	} else {
+		s.status[key] = status
+	}
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO chapter_statuses (school_id, frat_name, status, updated_at) VALUES ($1, $2, $3, NOW())
+			 ON CONFLICT (school_id, frat_name) DO UPDATE SET status = EXCLUDED.status, updated_at = NOW()`,
+			schoolID, fratName, status)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist chapter status: %v", err)
+		}
+	}
+	return nil
+}
+
 func (s *FraternityService) SchoolCount() int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -0,0 +1,571 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	// A home game makes the whole day a game day, from gameDayStartHour local
+	// time until tonightEndHour the next morning.
+	gameDayStartHour = 6
+
+	maxGameOpponentLength = 100
+	maxGameLocationLength = 200
+	maxGamesPerImport     = 100
+
+	// sportsBarTag marks venues reviewers say are good for watching games; a
+	// venue needs sportsBarMinTags such reviews to count as a sports bar.
+	sportsBarTag     = "sports"
+	sportsBarMinTags = 2
+)
+
+// GameDayService keeps each school's football schedule and answers whether
+// it's a home-game day at a school, so tonight rankings can favor sports bars.
+type GameDayService struct {
+	mu    sync.RWMutex
+	pool  *pgxpool.Pool
+	games map[string][]model.FootballGame // school ID -> games by kickoff
+
+	schools    *SchoolService
+	sportsBars *taggedVenues
+}
+
+func NewGameDayService(pool *pgxpool.Pool, schools *SchoolService, ratings *RatingService) *GameDayService {
+	svc := &GameDayService{
+		pool:       pool,
+		games:      make(map[string][]model.FootballGame),
+		schools:    schools,
+		sportsBars: newTaggedVenues(ratings, sportsBarTag, sportsBarMinTags),
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *GameDayService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, school_id, opponent, kickoff, home, location, created_at FROM football_games ORDER BY kickoff`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load football games from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var g model.FootballGame
+		if err := rows.Scan(&g.ID, &g.SchoolID, &g.Opponent, &g.Kickoff, &g.Home, &g.Location, &g.CreatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan football game row: %v", err)
+			continue
+		}
+		s.games[g.SchoolID] = append(s.games[g.SchoolID], g)
+		n++
+	}
+	log.Printf("Loaded %d football games from DB", n)
+}
+
+// gameDayWindow returns when the game day around a kickoff starts and ends.
+func gameDayWindow(kickoff time.Time, loc *time.Location) (time.Time, time.Time) {
+	k := kickoff.In(loc)
+	start := time.Date(k.Year(), k.Month(), k.Day(), gameDayStartHour, 0, 0, 0, loc)
+	if k.Before(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start, tonightEnd(k)
+}
+
+// HomeGameAt returns the home game whose game day includes t, or nil.
+func (s *GameDayService) HomeGameAt(schoolID string, t time.Time) *model.FootballGame {
+	if s == nil {
+		return nil
+	}
+	loc := s.schools.Location(schoolID)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, g := range s.games[schoolID] {
+		if !g.Home {
+			continue
+		}
+		start, end := gameDayWindow(g.Kickoff, loc)
+		if !t.Before(start) && t.Before(end) {
+			game := g
+			return &game
+		}
+	}
+	return nil
+}
+
+// IsSportsBar reports whether enough reviewers tagged the venue for sports.
+func (s *GameDayService) IsSportsBar(venueID string) bool {
+	if s == nil {
+		return false
+	}
+	return s.sportsBars.Has(venueID)
+}
+
+// List returns a school's games, by kickoff. With upcoming set, games whose
+// game day is already over are left out.
+func (s *GameDayService) List(schoolID string, upcoming bool, now time.Time) []model.FootballGame {
+	loc := s.schools.Location(schoolID)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := []model.FootballGame{}
+	for _, g := range s.games[schoolID] {
+		if upcoming {
+			if _, end := gameDayWindow(g.Kickoff, loc); !now.Before(end) {
+				continue
+			}
+		}
+		out = append(out, g)
+	}
+	return out
+}
+
+// validateGame checks a game's fields and normalizes them in place.
+func validateGame(g *model.FootballGame) error {
+	g.Opponent = strings.TrimSpace(middleware.SanitizeString(g.Opponent))
+	g.Location = strings.TrimSpace(middleware.SanitizeString(g.Location))
+	if g.Opponent == "" {
+		return fmt.Errorf("opponent is required")
+	}
+	if len(g.Opponent) > maxGameOpponentLength {
+		return fmt.Errorf("opponent must be at most %d characters", maxGameOpponentLength)
+	}
+	if len(g.Location) > maxGameLocationLength {
+		return fmt.Errorf("location must be at most %d characters", maxGameLocationLength)
+	}
+	if g.Kickoff.IsZero() {
+		return fmt.Errorf("kickoff is required")
+	}
+	return nil
+}
+
+// Create adds a game to a school's schedule.
+func (s *GameDayService) Create(ctx context.Context, schoolID string, req model.FootballGameRequest) (*model.FootballGame, error) {
+	if _, err := s.schools.GetByID(ctx, schoolID); err != nil {
+		return nil, fmt.Errorf("school not found")
+	}
+	g := model.FootballGame{
+		ID:        generateID(),
+		SchoolID:  schoolID,
+		Opponent:  req.Opponent,
+		Kickoff:   req.Kickoff,
+		Home:      req.Home == nil || *req.Home,
+		Location:  req.Location,
+		CreatedAt: time.Now(),
+	}
+	if err := validateGame(&g); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.findLocked(schoolID, g.Kickoff) >= 0 {
+		return nil, fmt.Errorf("a game is already scheduled at that kickoff")
+	}
+	if err := s.insertLocked(g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// Update edits a game. Omitted fields are left unchanged.
+func (s *GameDayService) Update(id string, req model.FootballGameRequest) (*model.FootballGame, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schoolID, i := s.indexLocked(id)
+	if i < 0 {
+		return nil, fmt.Errorf("game not found")
+	}
+	g := s.games[schoolID][i]
+	if req.Opponent != "" {
+		g.Opponent = req.Opponent
+	}
+	if !req.Kickoff.IsZero() {
+		g.Kickoff = req.Kickoff
+	}
+	if req.Home != nil {
+		g.Home = *req.Home
+	}
+	if req.Location != "" {
+		g.Location = req.Location
+	}
+	if err := validateGame(&g); err != nil {
+		return nil, err
+	}
+	if j := s.findLocked(schoolID, g.Kickoff); j >= 0 && j != i {
+		return nil, fmt.Errorf("a game is already scheduled at that kickoff")
+	}
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`UPDATE football_games SET opponent = $2, kickoff = $3, home = $4, location = $5 WHERE id = $1`,
+			g.ID, g.Opponent, g.Kickoff, g.Home, g.Location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update game: %w", err)
+		}
+	}
+	s.games[schoolID][i] = g
+	s.sortLocked(schoolID)
+	return &g, nil
+}
+
+// Delete removes a game from its school's schedule.
+func (s *GameDayService) Delete(id string) (*model.FootballGame, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schoolID, i := s.indexLocked(id)
+	if i < 0 {
+		return nil, fmt.Errorf("game not found")
+	}
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(), `DELETE FROM football_games WHERE id = $1`, id); err != nil {
+			return nil, fmt.Errorf("failed to delete game: %w", err)
+		}
+	}
+	g := s.games[schoolID][i]
+	s.games[schoolID] = append(s.games[schoolID][:i:i], s.games[schoolID][i+1:]...)
+	return &g, nil
+}
+
+// Import loads a schedule from CSV or iCalendar data. Games are matched to
+// existing ones by kickoff, so re-importing an updated feed edits games in
+// place instead of duplicating them.
+func (s *GameDayService) Import(ctx context.Context, schoolID, format string, data []byte) (*model.GameImportResult, error) {
+	if _, err := s.schools.GetByID(ctx, schoolID); err != nil {
+		return nil, fmt.Errorf("school not found")
+	}
+	loc := s.schools.Location(schoolID)
+
+	var games []model.FootballGame
+	var errs []string
+	var err error
+	switch format {
+	case "csv":
+		games, errs, err = parseScheduleCSV(data, loc)
+	case "ics":
+		games, errs, err = parseScheduleICS(data, loc)
+	default:
+		return nil, fmt.Errorf("format must be csv or ics")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(games) > maxGamesPerImport {
+		return nil, fmt.Errorf("at most %d games per import", maxGamesPerImport)
+	}
+
+	result := &model.GameImportResult{Errors: errs}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, g := range games {
+		g.SchoolID = schoolID
+		if err := validateGame(&g); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", g.Kickoff.Format(time.RFC3339), err))
+			continue
+		}
+
+		i := s.findLocked(schoolID, g.Kickoff)
+		if i < 0 {
+			g.ID = generateID()
+			g.CreatedAt = time.Now()
+			if err := s.insertLocked(g); err != nil {
+				return nil, err
+			}
+			result.Created++
+			continue
+		}
+
+		existing := s.games[schoolID][i]
+		if existing.Opponent == g.Opponent && existing.Home == g.Home && existing.Location == g.Location {
+			result.Unchanged++
+			continue
+		}
+		existing.Opponent, existing.Home, existing.Location = g.Opponent, g.Home, g.Location
+		if s.pool != nil {
+			_, err := s.pool.Exec(context.Background(),
+				`UPDATE football_games SET opponent = $2, home = $3, location = $4 WHERE id = $1`,
+				existing.ID, existing.Opponent, existing.Home, existing.Location)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update game: %w", err)
+			}
+		}
+		s.games[schoolID][i] = existing
+		result.Updated++
+	}
+	if result.Errors == nil {
+		result.Errors = []string{}
+	}
+	return result, nil
+}
+
+// insertLocked saves a new game. Caller holds s.mu.
+func (s *GameDayService) insertLocked(g model.FootballGame) error {
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO football_games (id, school_id, opponent, kickoff, home, location, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			g.ID, g.SchoolID, g.Opponent, g.Kickoff, g.Home, g.Location, g.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save game: %w", err)
+		}
+	}
+	s.games[g.SchoolID] = append(s.games[g.SchoolID], g)
+	s.sortLocked(g.SchoolID)
+	return nil
+}
+
+func (s *GameDayService) sortLocked(schoolID string) {
+	games := s.games[schoolID]
+	sort.SliceStable(games, func(i, j int) bool { return games[i].Kickoff.Before(games[j].Kickoff) })
+}
+
+// findLocked returns the index of the school's game at kickoff, or -1.
+func (s *GameDayService) findLocked(schoolID string, kickoff time.Time) int {
+	for i, g := range s.games[schoolID] {
+		if g.Kickoff.Equal(kickoff) {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexLocked finds a game by ID.
+func (s *GameDayService) indexLocked(id string) (string, int) {
+	for schoolID, games := range s.games {
+		for i, g := range games {
+			if g.ID == id {
+				return schoolID, i
+			}
+		}
+	}
+	return "", -1
+}
+
+// parseScheduleCSV reads a schedule with a header row. Recognized columns are
+// date (YYYY-MM-DD or MM/DD/YYYY), time (e.g. "19:30" or "7:30 PM"; blank or
+// TBA means noon), opponent, home_away ("home"/"away", "H"/"A", "vs"/"@") and
+// location. Times are in the school's time zone.
+func parseScheduleCSV(data []byte, loc *time.Location) ([]model.FootballGame, []string, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("csv has no header row")
+	}
+	cols := map[string]int{}
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	if _, ok := cols["date"]; !ok {
+		return nil, nil, fmt.Errorf("csv must have a date column")
+	}
+	if _, ok := cols["opponent"]; !ok {
+		return nil, nil, fmt.Errorf("csv must have an opponent column")
+	}
+	field := func(rec []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	games := []model.FootballGame{}
+	errs := []string{}
+	for line := 2; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("csv line %d: %v", line, err)
+		}
+		kickoff, err := parseScheduleDateTime(field(rec, "date"), field(rec, "time"), loc)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		home := true
+		switch strings.ToLower(field(rec, "home_away")) {
+		case "", "home", "h", "vs", "vs.":
+		case "away", "a", "at", "@":
+			home = false
+		default:
+			errs = append(errs, fmt.Sprintf("line %d: home_away must be home or away", line))
+			continue
+		}
+		games = append(games, model.FootballGame{
+			Opponent: field(rec, "opponent"),
+			Kickoff:  kickoff,
+			Home:     home,
+			Location: field(rec, "location"),
+		})
+	}
+	return games, errs, nil
+}
+
+func parseScheduleDateTime(date, clock string, loc *time.Location) (time.Time, error) {
+	var day time.Time
+	var err error
+	for _, layout := range []string{"2006-01-02", "01/02/2006", "1/2/2006"} {
+		if day, err = time.ParseInLocation(layout, date, loc); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q is not YYYY-MM-DD or MM/DD/YYYY", date)
+	}
+
+	hour, minute := 12, 0
+	if c := strings.ToUpper(strings.TrimSpace(clock)); c != "" && c != "TBA" && c != "TBD" {
+		var t time.Time
+		for _, layout := range []string{"15:04", "3:04 PM", "3:04PM", "3 PM", "3PM"} {
+			if t, err = time.Parse(layout, c); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return time.Time{}, fmt.Errorf("time %q is not understood", clock)
+		}
+		hour, minute = t.Hour(), t.Minute()
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc), nil
+}
+
+// parseScheduleICS reads the VEVENTs of an iCalendar feed. SUMMARY is read as
+// "<team> vs <opponent>" for home games and "<team> at <opponent>" (or "@")
+// for away games; a summary with neither is taken as a home game against
+// whoever it names. Floating and date-only DTSTARTs are in the school's time
+// zone, date-only ones at noon.
+func parseScheduleICS(data []byte, loc *time.Location) ([]model.FootballGame, []string, error) {
+	if !bytes.Contains(data, []byte("BEGIN:VCALENDAR")) {
+		return nil, nil, fmt.Errorf("not an iCalendar file")
+	}
+
+	// Unfold continuation lines (RFC 5545 3.1).
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read calendar: %v", err)
+	}
+
+	games := []model.FootballGame{}
+	errs := []string{}
+	var summary, location, dtstart string
+	inEvent := false
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		prop, params, _ := strings.Cut(name, ";")
+		switch strings.ToUpper(prop) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent = true
+				summary, location, dtstart = "", "", ""
+			}
+		case "SUMMARY":
+			summary = icsUnescape(value)
+		case "LOCATION":
+			location = icsUnescape(value)
+		case "DTSTART":
+			dtstart = params + ":" + value
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			kickoff, err := parseICSTime(dtstart, loc)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%q: %v", summary, err))
+				continue
+			}
+			opponent, home := splitGameSummary(summary)
+			games = append(games, model.FootballGame{Opponent: opponent, Kickoff: kickoff, Home: home, Location: location})
+		}
+	}
+	return games, errs, nil
+}
+
+func icsUnescape(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
+
+// parseICSTime parses a DTSTART given as "<params>:<value>".
+func parseICSTime(dtstart string, loc *time.Location) (time.Time, error) {
+	params, value, _ := strings.Cut(dtstart, ":")
+	if value == "" {
+		return time.Time{}, fmt.Errorf("missing DTSTART")
+	}
+	for _, p := range strings.Split(params, ";") {
+		if k, v, ok := strings.Cut(p, "="); ok && strings.EqualFold(k, "TZID") {
+			if l, err := time.LoadLocation(strings.Trim(v, `"`)); err == nil {
+				loc = l
+			}
+		}
+	}
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse("20060102T150405Z", value)
+	case len(value) == 8:
+		day, err := time.ParseInLocation("20060102", value, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("bad DTSTART %q", value)
+		}
+		return day.Add(12 * time.Hour), nil
+	default:
+		t, err := time.ParseInLocation("20060102T150405", value, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("bad DTSTART %q", value)
+		}
+		return t, nil
+	}
+}
+
+// splitGameSummary pulls the opponent out of a summary like
+// "Wisconsin vs. Iowa" or "Wisconsin at Iowa".
+func splitGameSummary(summary string) (string, bool) {
+	lower := strings.ToLower(summary)
+	for _, sep := range []struct {
+		text string
+		home bool
+	}{{" vs. ", true}, {" vs ", true}, {" v. ", true}, {" at ", false}, {" @ ", false}} {
+		if i := strings.Index(lower, sep.text); i >= 0 {
+			return strings.TrimSpace(summary[i+len(sep.text):]), sep.home
+		}
+	}
+	for _, prefix := range []struct {
+		text string
+		home bool
+	}{{"vs. ", true}, {"vs ", true}, {"at ", false}, {"@ ", false}} {
+		if strings.HasPrefix(lower, prefix.text) {
+			return strings.TrimSpace(summary[len(prefix.text):]), prefix.home
+		}
+	}
+	return strings.TrimSpace(summary), true
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"math"
+	"strconv"
+)
+
+const earthRadiusKm = 6371.0
+
+// haversineKm returns the great-circle distance between two coordinates in kilometers.
+func haversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// geoCellDeg is the cell size of the spatial index, in degrees (~55 km of
+// latitude).
+const geoCellDeg = 0.5
+
+// geoCell is one square of the lat/lng grid used as a spatial index.
+type geoCell struct{ lat, lng int }
+
+func cellOf(lat, lng float64) geoCell {
+	return geoCell{int(math.Floor(lat / geoCellDeg)), int(math.Floor(lng / geoCellDeg))}
+}
+
+// cellsWithin returns the grid cells that may hold points within radiusKm of
+// a point. It doesn't wrap around the antimeridian.
+func cellsWithin(lat, lng, radiusKm float64) []geoCell {
+	kmPerDeg := earthRadiusKm * math.Pi / 180
+	dLat := radiusKm / kmPerDeg
+	// Longitude degrees shrink toward the poles, so size the box for the
+	// latitude farthest from the equator.
+	dLng := 180.0
+	if cos := math.Cos(math.Min(math.Abs(lat)+dLat, 90) * math.Pi / 180); cos > 0.01 {
+		dLng = math.Min(radiusKm/(kmPerDeg*cos), 180)
+	}
+	lo, hi := cellOf(lat-dLat, lng-dLng), cellOf(lat+dLat, lng+dLng)
+	cells := make([]geoCell, 0, (hi.lat-lo.lat+1)*(hi.lng-lo.lng+1))
+	for la := lo.lat; la <= hi.lat; la++ {
+		for ln := lo.lng; ln <= hi.lng; ln++ {
+			cells = append(cells, geoCell{la, ln})
+		}
+	}
+	return cells
+}
+
+// formatCoord renders a coordinate with ~1m precision for use in URLs.
+func formatCoord(deg float64) string {
+	return strconv.FormatFloat(deg, 'f', 6, 64)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// googleKeysTTL is how long Google's signing keys are cached. Google rotates
+// them every few weeks and publishes the next one well ahead of use; an
+// unknown key ID triggers a refetch anyway.
+const googleKeysTTL = time.Hour
+
+// GoogleAuthConfig enables "Sign in with Google". The frontend gets an ID
+// token from Google Identity Services and posts it to /api/auth/google.
+type GoogleAuthConfig struct {
+	ClientID string // empty disables Google sign-in
+	KeysURL  string // overrides Google's JWKS URL
+}
+
+// LoadGoogleAuthConfig reads GOOGLE_CLIENT_ID (unset disables Google
+// sign-in) and GOOGLE_JWKS_URL.
+func LoadGoogleAuthConfig() GoogleAuthConfig {
+	return GoogleAuthConfig{
+		ClientID: strings.TrimSpace(os.Getenv("GOOGLE_CLIENT_ID")),
+		KeysURL:  os.Getenv("GOOGLE_JWKS_URL"),
+	}
+}
+
+// googleIdentity is what a verified Google ID token says about its user.
+type googleIdentity struct {
+	Subject string
+	Email   string // always verified
+}
+
+// GoogleVerifier checks Google ID tokens against Google's published keys.
+type GoogleVerifier struct {
+	clientID string
+	keysURL  string
+	client   *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey // by kid
+	fetchedAt time.Time
+}
+
+// NewGoogleVerifier returns nil when no client ID is configured.
+func NewGoogleVerifier(cfg GoogleAuthConfig) *GoogleVerifier {
+	if cfg.ClientID == "" {
+		return nil
+	}
+	keysURL := cfg.KeysURL
+	if keysURL == "" {
+		keysURL = "https://www.googleapis.com/oauth2/v3/certs"
+	}
+	return &GoogleVerifier{clientID: cfg.ClientID, keysURL: keysURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Verify checks an ID token's signature, audience, issuer and expiry, and
+// that its email is verified.
+func (v *GoogleVerifier) Verify(ctx context.Context, idToken string) (googleIdentity, error) {
+	if idToken == "" {
+		return googleIdentity{}, fmt.Errorf("id_token is required")
+	}
+	var claims struct {
+		jwt.RegisteredClaims
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	_, err := jwt.ParseWithClaims(idToken, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.key(ctx, kid)
+	}, jwt.WithValidMethods([]string{"RS256"}), jwt.WithAudience(v.clientID), jwt.WithExpirationRequired())
+	if err != nil {
+		if strings.Contains(err.Error(), "failed to fetch") {
+			return googleIdentity{}, fmt.Errorf("failed to verify Google token: %w", err)
+		}
+		return googleIdentity{}, fmt.Errorf("invalid Google token")
+	}
+	if claims.Issuer != "accounts.google.com" && claims.Issuer != "https://accounts.google.com" {
+		return googleIdentity{}, fmt.Errorf("invalid Google token")
+	}
+	if claims.Subject == "" {
+		return googleIdentity{}, fmt.Errorf("invalid Google token")
+	}
+	// Accounts are matched and linked by email, so an address Google hasn't
+	// verified can't be trusted to belong to whoever holds the token.
+	email := strings.TrimSpace(claims.Email)
+	if email == "" || !claims.EmailVerified {
+		return googleIdentity{}, fmt.Errorf("Google account has no verified email")
+	}
+	return googleIdentity{Subject: claims.Subject, Email: email}, nil
+}
+
+// key returns the signing key with this ID, refetching the key set when it's
+// stale or doesn't have it (at most once a minute for unknown IDs).
+func (v *GoogleVerifier) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	age := time.Since(v.fetchedAt)
+	if k, ok := v.keys[kid]; ok && age < googleKeysTTL {
+		return k, nil
+	}
+	if v.keys == nil || age >= time.Minute {
+		keys, err := v.fetchKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+		v.keys, v.fetchedAt = keys, time.Now()
+	}
+	if k, ok := v.keys[kid]; ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (v *GoogleVerifier) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.keysURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Google keys: %w", err)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Google keys: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch Google keys: unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to fetch Google keys: %w", err)
+	}
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
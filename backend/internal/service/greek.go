@@ -0,0 +1,156 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+)
+
+// greekLetter is one letter of the Greek alphabet with the Latin initials
+// used for it in chapter abbreviations (e.g. "SAE", "PDT", "SX").
+type greekLetter struct {
+	Name     string
+	Chars    string
+	Initials string
+}
+
+var greekLetters = []greekLetter{
+	{"alpha", "Αα", "a"},
+	{"beta", "Ββ", "b"},
+	{"gamma", "Γγ", "g"},
+	{"delta", "Δδ", "d"},
+	{"epsilon", "Εε", "e"},
+	{"zeta", "Ζζ", "z"},
+	{"eta", "Ηη", "he"},
+	{"theta", "Θθ", "t"},
+	{"iota", "Ιι", "i"},
+	{"kappa", "Κκ", "k"},
+	{"lambda", "Λλ", "l"},
+	{"mu", "Μμ", "m"},
+	{"nu", "Νν", "n"},
+	{"xi", "Ξξ", "x"},
+	{"omicron", "Οο", "o"},
+	{"pi", "Ππ", "p"},
+	{"rho", "Ρρ", "r"},
+	{"sigma", "Σσς", "s"},
+	{"tau", "Ττ", "t"},
+	{"upsilon", "Υυ", "u"},
+	{"phi", "Φφ", "pf"},
+	{"chi", "Χχ", "xc"},
+	{"psi", "Ψψ", "py"},
+	{"omega", "Ωω", "ow"},
+}
+
+var (
+	greekByChar = make(map[rune]string)
+	greekByName = make(map[string]greekLetter)
+)
+
+func init() {
+	for _, l := range greekLetters {
+		for _, c := range l.Chars {
+			greekByChar[c] = l.Name
+		}
+		greekByName[l.Name] = l
+	}
+}
+
+// fratNicknames maps common chapter nicknames (folded, no spaces) to the
+// organization's full name.
+var fratNicknames = map[string]string{
+	"aepi":      "Alpha Epsilon Pi",
+	"betas":     "Beta Theta Pi",
+	"deke":      "Delta Kappa Epsilon",
+	"delts":     "Delta Tau Delta",
+	"fiji":      "Phi Gamma Delta",
+	"kappasig":  "Kappa Sigma",
+	"lambdachi": "Lambda Chi Alpha",
+	"phidelt":   "Phi Delta Theta",
+	"phipsi":    "Phi Kappa Psi",
+	"phitau":    "Phi Kappa Tau",
+	"pikapp":    "Pi Kappa Phi",
+	"pike":      "Pi Kappa Alpha",
+	"sammy":     "Sigma Alpha Mu",
+	"sigep":     "Sigma Phi Epsilon",
+	"sigchi":    "Sigma Chi",
+	"teke":      "Tau Kappa Epsilon",
+}
+
+// foldGreek lower-cases s, spells out Greek letters ("ΣΑΕ" -> "sigma alpha
+// epsilon") and collapses punctuation to single spaces.
+func foldGreek(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if name, ok := greekByChar[r]; ok {
+			b.WriteString(" " + name + " ")
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		} else if r != '\'' && r != '’' {
+			b.WriteByte(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// fratAbbreviations returns the Latin-letter abbreviations of an
+// organization's name, e.g. "sae" for Sigma Alpha Epsilon, and both "sc" and
+// "sx" for Sigma Chi. Non-letter words ("Order") are optional.
+func fratAbbreviations(name string) []string {
+	keys := []string{""}
+	words := strings.Fields(foldGreek(name))
+	for _, w := range words {
+		l, ok := greekByName[w]
+		if !ok {
+			// "Kappa Alpha Order" is known as both KA and KAO.
+			n := len(keys)
+			for i := 0; i < n; i++ {
+				keys = append(keys, keys[i]+w[:1])
+			}
+			continue
+		}
+		next := make([]string, 0, len(keys)*len(l.Initials))
+		for _, k := range keys {
+			for _, c := range l.Initials {
+				next = append(next, k+string(c))
+			}
+		}
+		keys = next
+	}
+
+	out := keys[:0]
+	for _, k := range keys {
+		if len(k) >= 2 {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// FratNameMatches reports whether query refers to the organization by full
+// name, Greek letters, abbreviation or nickname. Partial names match too.
+func FratNameMatches(query, name string) bool {
+	q := foldGreek(query)
+	if q == "" {
+		return true
+	}
+	return strings.Contains(foldGreek(name), q) || fratNameEquals(q, name)
+}
+
+// fratNameEquals reports whether a folded query names exactly this
+// organization.
+func fratNameEquals(q, name string) bool {
+	if q == foldGreek(name) {
+		return true
+	}
+	compact := strings.ReplaceAll(q, " ", "")
+	if nick, ok := fratNicknames[compact]; ok && strings.EqualFold(nick, name) {
+		return true
+	}
+	for _, k := range fratAbbreviations(name) {
+		if k == compact {
+			return true
+		}
+	}
+	return false
+}
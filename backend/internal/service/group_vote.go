@@ -0,0 +1,398 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	groupSessionTTL        = 3 * time.Hour
+	groupCodeLength        = 6
+	groupCodeAlphabet      = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // no 0/O or 1/I
+	minGroupCandidates     = 2
+	maxGroupCandidates     = 10
+	defaultGroupCandidates = 5
+	maxGroupMembers        = 20
+	maxOpenGroupsPerHost   = 3
+)
+
+type groupSession struct {
+	id         string
+	code       string
+	hostID     string
+	schoolID   string
+	closed     bool
+	candidates []string
+	members    []model.GroupMember
+	votes      map[string]map[string]bool // user ID -> venue ID -> yes
+	createdAt  time.Time
+	expiresAt  time.Time
+
+	// watchers are signalled whenever the session changes and closed when it
+	// expires.
+	watchers map[chan struct{}]struct{}
+}
+
+func (g *groupSession) isMember(userID string) bool {
+	for _, m := range g.members {
+		if m.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupVoteService runs short-lived group votes on where to go. Sessions live
+// in memory only and disappear groupSessionTTL after they start.
+type GroupVoteService struct {
+	mu       sync.Mutex
+	sessions map[string]*groupSession
+	byCode   map[string]string // code -> session ID
+	venues   *VenueService
+}
+
+func NewGroupVoteService(venues *VenueService) *GroupVoteService {
+	return &GroupVoteService{
+		sessions: make(map[string]*groupSession),
+		byCode:   make(map[string]string),
+		venues:   venues,
+	}
+}
+
+// sweep drops expired sessions and ends their streams. Caller must hold s.mu.
+func (s *GroupVoteService) sweep(now time.Time) {
+	for id, g := range s.sessions {
+		if now.Before(g.expiresAt) {
+			continue
+		}
+		for ch := range g.watchers {
+			close(ch)
+		}
+		delete(s.byCode, g.code)
+		delete(s.sessions, id)
+	}
+}
+
+// newCode generates an unused join code. Caller must hold s.mu.
+func (s *GroupVoteService) newCode() string {
+	b := make([]byte, groupCodeLength)
+	for {
+		rand.Read(b)
+		for i := range b {
+			b[i] = groupCodeAlphabet[int(b[i])%len(groupCodeAlphabet)]
+		}
+		if _, taken := s.byCode[string(b)]; !taken {
+			return string(b)
+		}
+	}
+}
+
+// candidates validates the requested venues, or picks the school's top-rated
+// ones when none are given.
+func (s *GroupVoteService) candidates(ctx context.Context, req model.GroupSessionRequest) ([]string, error) {
+	if len(req.VenueIDs) == 0 {
+		var top []model.Venue
+		for _, v := range s.venues.GetAllVenues() {
+			if v.SchoolID == req.SchoolID && v.Verified {
+				top = append(top, v)
+			}
+		}
+		sort.SliceStable(top, func(i, j int) bool {
+			if top[i].AvgRating != top[j].AvgRating {
+				return top[i].AvgRating > top[j].AvgRating
+			}
+			return top[i].RatingCount > top[j].RatingCount
+		})
+		if len(top) > defaultGroupCandidates {
+			top = top[:defaultGroupCandidates]
+		}
+		if len(top) < minGroupCandidates {
+			return nil, fmt.Errorf("not enough venues at this school to vote on")
+		}
+		ids := make([]string, len(top))
+		for i, v := range top {
+			ids[i] = v.ID
+		}
+		return ids, nil
+	}
+
+	if len(req.VenueIDs) < minGroupCandidates || len(req.VenueIDs) > maxGroupCandidates {
+		return nil, fmt.Errorf("pick between %d and %d venues", minGroupCandidates, maxGroupCandidates)
+	}
+	seen := make(map[string]bool, len(req.VenueIDs))
+	for _, id := range req.VenueIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("venue %s appears more than once", id)
+		}
+		seen[id] = true
+		v, err := s.venues.GetByID(ctx, id)
+		if err != nil || !v.Verified {
+			return nil, fmt.Errorf("venue not found: %s", id)
+		}
+		if v.SchoolID != req.SchoolID {
+			return nil, fmt.Errorf("venue %s is not at this school", id)
+		}
+	}
+	return append([]string(nil), req.VenueIDs...), nil
+}
+
+// Create starts a session hosted by the current user.
+func (s *GroupVoteService) Create(ctx context.Context, req model.GroupSessionRequest) (*model.GroupSession, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	if req.SchoolID == "" {
+		return nil, fmt.Errorf("school_id is required")
+	}
+	candidates, err := s.candidates(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+
+	open := 0
+	for _, g := range s.sessions {
+		if g.hostID == userID && !g.closed {
+			open++
+		}
+	}
+	if open >= maxOpenGroupsPerHost {
+		return nil, fmt.Errorf("you can host at most %d open group votes", maxOpenGroupsPerHost)
+	}
+
+	g := &groupSession{
+		id:         generateID(),
+		code:       s.newCode(),
+		hostID:     userID,
+		schoolID:   req.SchoolID,
+		candidates: candidates,
+		members:    []model.GroupMember{{UserID: userID, Username: middleware.GetUsername(ctx)}},
+		votes:      make(map[string]map[string]bool),
+		createdAt:  now,
+		expiresAt:  now.Add(groupSessionTTL),
+		watchers:   make(map[chan struct{}]struct{}),
+	}
+	s.sessions[g.id] = g
+	s.byCode[g.code] = g.id
+	return groupView(g, userID), nil
+}
+
+// Join adds the current user to the session with the given code.
+func (s *GroupVoteService) Join(ctx context.Context, code string) (*model.GroupSession, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(time.Now())
+
+	g, ok := s.sessions[s.byCode[code]]
+	if !ok {
+		return nil, fmt.Errorf("group session not found")
+	}
+	if !g.isMember(userID) {
+		if g.closed {
+			return nil, fmt.Errorf("voting has closed")
+		}
+		if len(g.members) >= maxGroupMembers {
+			return nil, fmt.Errorf("this group is full")
+		}
+		g.members = append(g.members, model.GroupMember{UserID: userID, Username: middleware.GetUsername(ctx)})
+		s.notify(g)
+	}
+	return groupView(g, userID), nil
+}
+
+// lookup returns a live session the user belongs to. Caller must hold s.mu.
+func (s *GroupVoteService) lookup(id, userID string) (*groupSession, error) {
+	s.sweep(time.Now())
+	g, ok := s.sessions[id]
+	if !ok || !g.isMember(userID) {
+		return nil, fmt.Errorf("group session not found")
+	}
+	return g, nil
+}
+
+// Get returns a session as the current user, who must be a member, sees it.
+func (s *GroupVoteService) Get(ctx context.Context, id string) (*model.GroupSession, error) {
+	userID := middleware.GetUserID(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, err := s.lookup(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return groupView(g, userID), nil
+}
+
+// Vote records the current user's yes or no on a candidate; voting again
+// changes it.
+func (s *GroupVoteService) Vote(ctx context.Context, id string, req model.GroupVoteRequest) (*model.GroupSession, error) {
+	userID := middleware.GetUserID(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, err := s.lookup(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if g.closed {
+		return nil, fmt.Errorf("voting has closed")
+	}
+	candidate := false
+	for _, c := range g.candidates {
+		if c == req.VenueID {
+			candidate = true
+			break
+		}
+	}
+	if !candidate {
+		return nil, fmt.Errorf("venue is not a candidate in this vote")
+	}
+	if g.votes[userID] == nil {
+		g.votes[userID] = make(map[string]bool)
+	}
+	g.votes[userID][req.VenueID] = req.Yes
+	s.notify(g)
+	return groupView(g, userID), nil
+}
+
+// Close ends voting and fixes the pick. Host only.
+func (s *GroupVoteService) Close(ctx context.Context, id string) (*model.GroupSession, error) {
+	userID := middleware.GetUserID(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, err := s.lookup(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if g.hostID != userID {
+		return nil, fmt.Errorf("only the host can close voting")
+	}
+	if !g.closed {
+		g.closed = true
+		s.notify(g)
+	}
+	return groupView(g, userID), nil
+}
+
+// Watch subscribes a member to changes in a session. The channel receives a
+// value after each change and is closed when the session expires; call the
+// returned function to unsubscribe.
+func (s *GroupVoteService) Watch(ctx context.Context, id string) (<-chan struct{}, func(), error) {
+	userID := middleware.GetUserID(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, err := s.lookup(id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ch := make(chan struct{}, 1)
+	g.watchers[ch] = struct{}{}
+	stop := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(g.watchers, ch)
+	}
+	return ch, stop, nil
+}
+
+// notify wakes every watcher without blocking; a watcher that hasn't caught
+// up yet already has a wake-up pending. Caller must hold s.mu.
+func (s *GroupVoteService) notify(g *groupSession) {
+	for ch := range g.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// groupView builds the response for userID: tallies, the current pick and
+// the user's own votes. Individual votes of others stay private. The pick is
+// the candidate with the most yes votes (fewest no votes, then list order,
+// breaking ties); it is decided once the host closes voting or every member
+// has voted on every candidate.
+func groupView(g *groupSession, userID string) *model.GroupSession {
+	out := &model.GroupSession{
+		ID:         g.id,
+		Code:       g.code,
+		HostID:     g.hostID,
+		SchoolID:   g.schoolID,
+		Status:     "open",
+		Candidates: make([]model.GroupCandidate, len(g.candidates)),
+		Members:    make([]model.GroupMember, len(g.members)),
+		MyVotes:    map[string]bool{},
+		CreatedAt:  g.createdAt,
+		ExpiresAt:  g.expiresAt,
+	}
+	if g.closed {
+		out.Status = "closed"
+	}
+	for i, id := range g.candidates {
+		out.Candidates[i].VenueID = id
+	}
+
+	allVoted := true
+	for i, m := range g.members {
+		votes := g.votes[m.UserID]
+		m.Voted = len(votes)
+		out.Members[i] = m
+		if m.Voted < len(g.candidates) {
+			allVoted = false
+		}
+		for j, id := range g.candidates {
+			yes, ok := votes[id]
+			switch {
+			case !ok:
+			case yes:
+				out.Candidates[j].Yes++
+			default:
+				out.Candidates[j].No++
+			}
+		}
+	}
+	for id, yes := range g.votes[userID] {
+		out.MyVotes[id] = yes
+	}
+
+	best := -1
+	for i, c := range out.Candidates {
+		if c.Yes == 0 {
+			continue
+		}
+		if best < 0 || c.Yes > out.Candidates[best].Yes ||
+			(c.Yes == out.Candidates[best].Yes && c.No < out.Candidates[best].No) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		pick := out.Candidates[best]
+		out.Pick = &pick
+	}
+	out.Decided = g.closed || allVoted
+	return out
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// Heatmap metrics.
+const (
+	HeatmapRatings  = "ratings"
+	HeatmapCheckIns = "checkins"
+)
+
+const (
+	heatmapCacheTTL       = 5 * time.Minute
+	heatmapCheckInWindow  = 30 * 24 * time.Hour // check-in heat reflects recent activity
+	heatmapMinZoom        = 2
+	heatmapMaxZoom        = 14
+	heatmapCellsPerTile   = 16 // grid cells across one map tile
+	heatmapZoomBucketSize = 2  // zoom levels that share a cached grid
+)
+
+type heatKey struct {
+	metric string
+	zoom   int
+}
+
+type heatCell struct{ x, y int }
+
+// heatGrid is one cached world grid of intensity values.
+type heatGrid struct {
+	cellDeg float64
+	cells   map[heatCell]int
+	builtAt time.Time
+}
+
+// HeatmapService grids rating and check-in activity for map heat layers.
+// Each metric and zoom bucket is computed once for the whole map and cached;
+// requests cut their bounding box out of the cached grid.
+type HeatmapService struct {
+	mu       sync.Mutex
+	cache    map[heatKey]*heatGrid
+	venues   *VenueService
+	checkIns *CheckInService
+}
+
+func NewHeatmapService(venues *VenueService, checkIns *CheckInService) *HeatmapService {
+	return &HeatmapService{
+		cache:    make(map[heatKey]*heatGrid),
+		venues:   venues,
+		checkIns: checkIns,
+	}
+}
+
+// Invalidate drops every cached grid so the next request rebuilds it.
+func (s *HeatmapService) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = make(map[heatKey]*heatGrid)
+}
+
+// heatmapZoomBucket clamps a map zoom and rounds it down to its bucket.
+func heatmapZoomBucket(zoom int) int {
+	zoom = min(max(zoom, heatmapMinZoom), heatmapMaxZoom)
+	return zoom - zoom%heatmapZoomBucketSize
+}
+
+// Get returns the non-empty cells inside the box for a metric at a zoom.
+func (s *HeatmapService) Get(metric string, zoom int, minLat, minLng, maxLat, maxLng float64) (*model.Heatmap, error) {
+	if metric != HeatmapRatings && metric != HeatmapCheckIns {
+		return nil, fmt.Errorf("metric must be ratings or checkins")
+	}
+	if minLat >= maxLat || minLng >= maxLng || minLat < -90 || maxLat > 90 || minLng < -180 || maxLng > 180 {
+		return nil, fmt.Errorf("bbox must be min_lng,min_lat,max_lng,max_lat")
+	}
+
+	grid := s.grid(heatKey{metric: metric, zoom: heatmapZoomBucket(zoom)})
+	out := &model.Heatmap{
+		Metric:  metric,
+		Zoom:    heatmapZoomBucket(zoom),
+		CellDeg: grid.cellDeg,
+		Cells:   []model.HeatCell{},
+	}
+	for c, v := range grid.cells {
+		lat := (float64(c.y) + 0.5) * grid.cellDeg
+		lng := (float64(c.x) + 0.5) * grid.cellDeg
+		if lat < minLat || lat > maxLat || lng < minLng || lng > maxLng {
+			continue
+		}
+		out.Cells = append(out.Cells, model.HeatCell{Lat: lat, Lng: lng, Value: v})
+		out.Max = max(out.Max, v)
+	}
+	sort.Slice(out.Cells, func(i, j int) bool {
+		if out.Cells[i].Lat != out.Cells[j].Lat {
+			return out.Cells[i].Lat < out.Cells[j].Lat
+		}
+		return out.Cells[i].Lng < out.Cells[j].Lng
+	})
+	for i := range out.Cells {
+		out.Cells[i].Intensity = math.Round(float64(out.Cells[i].Value)/float64(out.Max)*1000) / 1000
+	}
+	return out, nil
+}
+
+// grid returns the cached grid for key, rebuilding it once it's stale.
+func (s *HeatmapService) grid(key heatKey) *heatGrid {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if g, ok := s.cache[key]; ok && time.Since(g.builtAt) < heatmapCacheTTL {
+		return g
+	}
+
+	var counts map[string]int
+	if key.metric == HeatmapCheckIns {
+		counts = s.checkIns.CountsByVenue(time.Now().Add(-heatmapCheckInWindow))
+	}
+	g := &heatGrid{
+		cellDeg: 360 / math.Exp2(float64(key.zoom)) / heatmapCellsPerTile,
+		cells:   make(map[heatCell]int),
+		builtAt: time.Now(),
+	}
+	for _, v := range s.venues.GetAllVenues() {
+		if v.Latitude == 0 && v.Longitude == 0 {
+			continue
+		}
+		n := v.RatingCount
+		if counts != nil {
+			n = counts[v.ID]
+		}
+		if n == 0 {
+			continue
+		}
+		c := heatCell{x: int(math.Floor(v.Longitude / g.cellDeg)), y: int(math.Floor(v.Latitude / g.cellDeg))}
+		g.cells[c] += n
+	}
+	s.cache[key] = g
+	return g
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const maxHoursEntries = 21
+
+// parseClock parses "HH:MM" into minutes after midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func validateHours(hours []model.OpeningHours) error {
+	if len(hours) > maxHoursEntries {
+		return fmt.Errorf("at most %d opening windows are allowed", maxHoursEntries)
+	}
+	for _, h := range hours {
+		if h.Day < 0 || h.Day > 6 {
+			return fmt.Errorf("day must be 0 (Sunday) through 6 (Saturday)")
+		}
+		if _, err := parseClock(h.Open); err != nil {
+			return err
+		}
+		if _, err := parseClock(h.Close); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsOpenAt reports whether a venue is open at the given local time. It returns
+// nil when the venue has no hours on file.
+func IsOpenAt(hours []model.OpeningHours, local time.Time) *bool {
+	if len(hours) == 0 {
+		return nil
+	}
+	day := int(local.Weekday())
+	yesterday := (day + 6) % 7
+	now := local.Hour()*60 + local.Minute()
+
+	open := false
+	for _, h := range hours {
+		start, err1 := parseClock(h.Open)
+		end, err2 := parseClock(h.Close)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		overnight := end <= start
+		switch {
+		case h.Day == day && !overnight && now >= start && now < end:
+			open = true
+		case h.Day == day && overnight && now >= start:
+			open = true
+		case h.Day == yesterday && overnight && now < end:
+			open = true
+		}
+		if open {
+			break
+		}
+	}
+	return &open
+}
+
+// ClosingSoonMinutes is how close to closing a venue is left out of "where
+// to go now" results when the caller asks to skip venues about to close.
+const ClosingSoonMinutes = 30
+
+// openWindowEnd returns when the latest-closing window open at local ends,
+// or the zero time if none is.
+func openWindowEnd(hours []model.OpeningHours, local time.Time) time.Time {
+	day := int(local.Weekday())
+	yesterday := (day + 6) % 7
+	now := local.Hour()*60 + local.Minute()
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+
+	var latest time.Time
+	for _, h := range hours {
+		start, err1 := parseClock(h.Open)
+		end, err2 := parseClock(h.Close)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		overnight := end <= start
+		var closes time.Time
+		switch {
+		case h.Day == day && !overnight && now >= start && now < end:
+			closes = midnight.Add(time.Duration(end) * time.Minute)
+		case h.Day == day && overnight && now >= start:
+			closes = midnight.AddDate(0, 0, 1).Add(time.Duration(end) * time.Minute)
+		case h.Day == yesterday && overnight && now < end:
+			closes = midnight.Add(time.Duration(end) * time.Minute)
+		default:
+			continue
+		}
+		if closes.After(latest) {
+			latest = closes
+		}
+	}
+	return latest
+}
+
+// ClosesInMinutes returns how long until a venue that is open at local
+// closes, following back-to-back windows (e.g. 20:00-24:00 then 00:00-02:00).
+// It returns nil when the venue is closed or has no hours on file.
+func ClosesInMinutes(hours []model.OpeningHours, local time.Time) *int {
+	closes := openWindowEnd(hours, local)
+	if closes.IsZero() {
+		return nil
+	}
+	// A venue open around the clock would chain forever; a week is plenty.
+	for range 7 * maxHoursEntries {
+		next := openWindowEnd(hours, closes)
+		if next.IsZero() || !next.After(closes) {
+			break
+		}
+		closes = next
+		if closes.Sub(local) > 7*24*time.Hour {
+			break
+		}
+	}
+	minutes := int(closes.Sub(local) / time.Minute)
+	return &minutes
+}
@@ -0,0 +1,659 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// IdentityGoogle is the provider name for Google sign-in.
+const IdentityGoogle = "google"
+
+// identityLinkTTL is how long a user has to confirm linking a new sign-in to
+// the existing account with the same email.
+const identityLinkTTL = 15 * time.Minute
+
+// noPassword is the password hash of accounts created through Google, which
+// can't sign in with a password.
+const noPassword = "!"
+
+// identityLink is a pending link of an external sign-in to an existing
+// account. Only the token's hash is stored.
+type identityLink struct {
+	UserID    string
+	Provider  string
+	Subject   string
+	Email     string
+	ExpiresAt time.Time
+}
+
+func identityKey(provider, subject string) string {
+	return provider + ":" + subject
+}
+
+// SetGoogle enables Google sign-in.
+func (s *AuthService) SetGoogle(google *GoogleVerifier) {
+	s.google = google
+}
+
+// GoogleLogin signs in with a Google ID token. A token already linked to an
+// account signs into it; one whose verified email matches an existing
+// account returns a link request to confirm with that account's password
+// instead of signing in; otherwise a new account without a password is
+// created, subject to the same invite code and CAPTCHA checks as Register.
+func (s *AuthService) GoogleLogin(ctx context.Context, req model.GoogleLoginRequest) (*model.AuthResponse, *model.LinkRequiredResponse, error) {
+	if s.google == nil {
+		return nil, nil, fmt.Errorf("Google sign-in is not enabled")
+	}
+	id, err := s.google.Verify(ctx, req.IDToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userID, err := s.identityOwner(IdentityGoogle, id.Subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	if userID == "" {
+		existing, err := s.userIDByEmail(id.Email)
+		if err != nil {
+			return nil, nil, err
+		}
+		if existing != "" {
+			link, err := s.startLink(existing, IdentityGoogle, id)
+			return nil, link, err
+		}
+		if userID, err = s.registerExternal(req, id); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	token, err := s.issueToken(*user, req.Client)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	s.recordLogin(user.ID, req.Client, time.Now())
+	return &model.AuthResponse{Token: token, User: user}, nil, nil
+}
+
+// ConfirmLink attaches a pending sign-in to its account once the account's
+// password checks out, then signs in. Wrong passwords count towards the
+// email's login lockout.
+func (s *AuthService) ConfirmLink(req model.ConfirmLinkRequest) (*model.AuthResponse, error) {
+	if req.LinkToken == "" || req.Password == "" {
+		return nil, fmt.Errorf("link_token and password are required")
+	}
+	tokenHash := hashResetToken(req.LinkToken)
+	link, err := s.pendingLink(tokenHash)
+	if err != nil {
+		return nil, err
+	}
+
+	email, hash, err := s.credentials(link.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAccountPassword(email, hash, req.Password, req.Client, "password is incorrect"); err != nil {
+		return nil, err
+	}
+
+	if err := s.addIdentity(link.UserID, link.Provider, link.Subject, link.Email); err != nil {
+		return nil, err
+	}
+	s.deleteLink(tokenHash)
+
+	user, err := s.GetUser(link.UserID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.issueToken(*user, req.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	s.recordLogin(user.ID, req.Client, time.Now())
+	return &model.AuthResponse{Token: token, User: user}, nil
+}
+
+// LinkGoogle attaches a Google account to a signed-in user. Accounts with a
+// password must confirm it; wrong passwords count towards the login lockout.
+func (s *AuthService) LinkGoogle(ctx context.Context, userID string, req model.LinkIdentityRequest) (*model.IdentitiesResponse, error) {
+	if s.google == nil {
+		return nil, fmt.Errorf("Google sign-in is not enabled")
+	}
+	email, hash, err := s.credentials(userID)
+	if err != nil {
+		return nil, err
+	}
+	if hash != noPassword {
+		if err := s.checkAccountPassword(email, hash, req.Password, req.Client, "password is incorrect"); err != nil {
+			return nil, err
+		}
+	}
+	id, err := s.google.Verify(ctx, req.IDToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.addIdentity(userID, IdentityGoogle, id.Subject, id.Email); err != nil {
+		return nil, err
+	}
+	return s.Identities(userID)
+}
+
+// SetPassword adds a password to an account created through Google, so it
+// can also sign in with its email. The request must carry a fresh ID token
+// for a Google account linked to the user. Accounts that already have a
+// password use ChangePassword.
+func (s *AuthService) SetPassword(ctx context.Context, userID string, req model.SetPasswordRequest) (*model.IdentitiesResponse, error) {
+	if s.google == nil {
+		return nil, fmt.Errorf("Google sign-in is not enabled")
+	}
+	if req.IDToken == "" || req.Password == "" {
+		return nil, fmt.Errorf("id_token and password are required")
+	}
+	email, hash, err := s.credentials(userID)
+	if err != nil {
+		return nil, err
+	}
+	if hash != noPassword {
+		return nil, fmt.Errorf("account already has a password")
+	}
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPasswordStrength(req.Password, user.Username, email); err != nil {
+		return nil, err
+	}
+	id, err := s.google.Verify(ctx, req.IDToken)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := s.identityOwner(IdentityGoogle, id.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if owner != userID {
+		return nil, fmt.Errorf("sign in with a Google account linked to this account")
+	}
+	newHash, err := s.hashPassword(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		tag, err := s.pool.Exec(ctx,
+			`UPDATE users SET password_hash = $1 WHERE id = $2 AND password_hash = $3 AND anonymized_at IS NULL`,
+			newHash, userID, noPassword)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set password: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, fmt.Errorf("account already has a password")
+		}
+	} else {
+		s.mu.Lock()
+		set := false
+		for _, rec := range s.users {
+			if rec.User.ID == userID && !rec.Anonymized && rec.PasswordHash == noPassword {
+				rec.PasswordHash = newHash
+				set = true
+				break
+			}
+		}
+		s.mu.Unlock()
+		if !set {
+			return nil, fmt.Errorf("account already has a password")
+		}
+	}
+	return s.Identities(userID)
+}
+
+// Identities lists how a user can sign in.
+func (s *AuthService) Identities(userID string) (*model.IdentitiesResponse, error) {
+	_, hash, err := s.credentials(userID)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := s.userIdentities(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.IdentitiesResponse{Password: hash != noPassword, Identities: ids}, nil
+}
+
+// UnlinkIdentity removes an external sign-in, unless it's the account's
+// only way to sign in.
+func (s *AuthService) UnlinkIdentity(userID, identityID string) error {
+	current, err := s.Identities(userID)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, id := range current.Identities {
+		found = found || id.ID == identityID
+	}
+	if !found {
+		return fmt.Errorf("identity not found")
+	}
+	if !current.Password && len(current.Identities) == 1 {
+		return fmt.Errorf("set a password before removing your only sign-in method")
+	}
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := s.pool.Exec(ctx, `DELETE FROM user_identities WHERE id = $1 AND user_id = $2`, identityID, userID); err != nil {
+			return fmt.Errorf("failed to unlink identity: %w", err)
+		}
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, id := range s.identities {
+		if id.ID == identityID && id.UserID == userID {
+			delete(s.identities, key)
+		}
+	}
+	return nil
+}
+
+// MergeIdentities moves a merged account's sign-ins to the account it's
+// merged into and returns their IDs. With dryRun it only lists them.
+func (s *AuthService) MergeIdentities(fromID, toID string, dryRun bool) []string {
+	moved := []string{}
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		query := `UPDATE user_identities SET user_id = $2 WHERE user_id = $1 RETURNING id`
+		args := []interface{}{fromID, toID}
+		if dryRun {
+			query, args = `SELECT id FROM user_identities WHERE user_id = $1`, args[:1]
+		}
+		rows, err := s.pool.Query(ctx, query, args...)
+		if err != nil {
+			log.Printf("WARNING: Failed to merge identities: %v", err)
+			return moved
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id string
+			if rows.Scan(&id) == nil {
+				moved = append(moved, id)
+			}
+		}
+		return moved
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range s.identities {
+		if id.UserID == fromID {
+			if !dryRun {
+				id.UserID = toID
+			}
+			moved = append(moved, id.ID)
+		}
+	}
+	return moved
+}
+
+// PurgeExpiredIdentityLinks deletes link requests that expired before
+// cutoff.
+func (s *AuthService) PurgeExpiredIdentityLinks(cutoff time.Time, dryRun bool) (int, error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if dryRun {
+			var n int
+			err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM identity_links WHERE expires_at < $1`, cutoff).Scan(&n)
+			return n, err
+		}
+		tag, err := s.pool.Exec(ctx, `DELETE FROM identity_links WHERE expires_at < $1`, cutoff)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge identity links: %w", err)
+		}
+		return int(tag.RowsAffected()), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for h, l := range s.identityLinks {
+		if l.ExpiresAt.Before(cutoff) {
+			if !dryRun {
+				delete(s.identityLinks, h)
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+// identityOwner returns the ID of the user a sign-in belongs to, or "".
+func (s *AuthService) identityOwner(provider, subject string) (string, error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var userID string
+		err := s.pool.QueryRow(ctx,
+			`SELECT user_id FROM user_identities WHERE provider = $1 AND subject = $2`, provider, subject).Scan(&userID)
+		if err == pgx.ErrNoRows {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to look up identity: %w", err)
+		}
+		return userID, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if id, ok := s.identities[identityKey(provider, subject)]; ok {
+		return id.UserID, nil
+	}
+	return "", nil
+}
+
+func (s *AuthService) userIdentities(userID string) ([]model.Identity, error) {
+	out := []model.Identity{}
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		rows, err := s.pool.Query(ctx,
+			`SELECT id, provider, subject, COALESCE(email,''), created_at FROM user_identities
+			 WHERE user_id = $1 ORDER BY created_at`, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list identities: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			id := model.Identity{UserID: userID}
+			if err := rows.Scan(&id.ID, &id.Provider, &id.Subject, &id.Email, &id.CreatedAt); err != nil {
+				return nil, fmt.Errorf("failed to list identities: %w", err)
+			}
+			out = append(out, id)
+		}
+		return out, rows.Err()
+	}
+
+	s.mu.RLock()
+	for _, id := range s.identities {
+		if id.UserID == userID {
+			out = append(out, *id)
+		}
+	}
+	s.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+// addIdentity links a sign-in to a user. Linking one that's already on the
+// same user is a no-op.
+func (s *AuthService) addIdentity(userID, provider, subject, email string) error {
+	id := &model.Identity{
+		ID:        "ident_" + generateID()[:16],
+		Provider:  provider,
+		Subject:   subject,
+		UserID:    userID,
+		Email:     email,
+		CreatedAt: time.Now(),
+	}
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var owner string
+		err := s.pool.QueryRow(ctx,
+			`INSERT INTO user_identities (id, user_id, provider, subject, email, created_at)
+			 VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+			 ON CONFLICT (provider, subject) DO UPDATE SET provider = EXCLUDED.provider
+			 RETURNING user_id`,
+			id.ID, userID, provider, subject, email, id.CreatedAt).Scan(&owner)
+		if err != nil {
+			return fmt.Errorf("failed to link identity: %w", err)
+		}
+		if owner != userID {
+			return fmt.Errorf("this %s account is already linked to another user", providerName(provider))
+		}
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := identityKey(provider, subject)
+	if existing, ok := s.identities[key]; ok {
+		if existing.UserID != userID {
+			return fmt.Errorf("this %s account is already linked to another user", providerName(provider))
+		}
+		return nil
+	}
+	s.identities[key] = id
+	return nil
+}
+
+// deleteIdentities unlinks every sign-in from a user, e.g. when the account
+// is anonymized.
+func (s *AuthService) deleteIdentities(userID string) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for _, table := range []string{"user_identities", "identity_links"} {
+			if _, err := s.pool.Exec(ctx, `DELETE FROM `+table+` WHERE user_id = $1`, userID); err != nil {
+				log.Printf("WARNING: Failed to delete %s: %v", table, err)
+			}
+		}
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, id := range s.identities {
+		if id.UserID == userID {
+			delete(s.identities, key)
+		}
+	}
+	for h, l := range s.identityLinks {
+		if l.UserID == userID {
+			delete(s.identityLinks, h)
+		}
+	}
+}
+
+func providerName(provider string) string {
+	if provider == IdentityGoogle {
+		return "Google"
+	}
+	return provider
+}
+
+// userIDByEmail returns the ID of the active account with this email, or "".
+func (s *AuthService) userIDByEmail(email string) (string, error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var userID string
+		err := s.pool.QueryRow(ctx, `SELECT id FROM users WHERE email = $1 AND anonymized_at IS NULL`, email).Scan(&userID)
+		if err == pgx.ErrNoRows {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to look up account: %w", err)
+		}
+		return userID, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if rec, ok := s.users[email]; ok && !rec.Anonymized {
+		return rec.User.ID, nil
+	}
+	return "", nil
+}
+
+// startLink records a pending link and returns what the client needs to
+// confirm it.
+func (s *AuthService) startLink(userID, provider string, id googleIdentity) (*model.LinkRequiredResponse, error) {
+	token := generateID() + generateID()
+	link := identityLink{UserID: userID, Provider: provider, Subject: id.Subject, Email: id.Email,
+		ExpiresAt: time.Now().Add(identityLinkTTL)}
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err := s.pool.Exec(ctx,
+			`INSERT INTO identity_links (token_hash, user_id, provider, subject, email, expires_at)
+			 VALUES ($1, $2, $3, $4, $5, $6)`,
+			hashResetToken(token), link.UserID, link.Provider, link.Subject, link.Email, link.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start account link: %w", err)
+		}
+	} else {
+		s.mu.Lock()
+		s.identityLinks[hashResetToken(token)] = link
+		s.mu.Unlock()
+	}
+
+	return &model.LinkRequiredResponse{
+		Error:     "link_required",
+		Message:   fmt.Sprintf("An account with this email already exists. Enter its password to link your %s sign-in to it.", providerName(provider)),
+		Provider:  provider,
+		Email:     id.Email,
+		LinkToken: token,
+		ExpiresAt: link.ExpiresAt,
+	}, nil
+}
+
+func (s *AuthService) pendingLink(tokenHash string) (identityLink, error) {
+	var link identityLink
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := s.pool.QueryRow(ctx,
+			`SELECT user_id, provider, subject, email, expires_at FROM identity_links WHERE token_hash = $1`, tokenHash,
+		).Scan(&link.UserID, &link.Provider, &link.Subject, &link.Email, &link.ExpiresAt)
+		if err != nil && err != pgx.ErrNoRows {
+			return link, fmt.Errorf("failed to look up link: %w", err)
+		}
+	} else {
+		s.mu.RLock()
+		link = s.identityLinks[tokenHash]
+		s.mu.RUnlock()
+	}
+	if link.UserID == "" || !time.Now().Before(link.ExpiresAt) {
+		return link, fmt.Errorf("link token is invalid or expired")
+	}
+	return link, nil
+}
+
+func (s *AuthService) deleteLink(tokenHash string) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := s.pool.Exec(ctx, `DELETE FROM identity_links WHERE token_hash = $1`, tokenHash); err != nil {
+			log.Printf("WARNING: Failed to delete used link token: %v", err)
+		}
+		return
+	}
+	s.mu.Lock()
+	delete(s.identityLinks, tokenHash)
+	s.mu.Unlock()
+}
+
+// registerExternal creates a password-less account for a new external
+// sign-in. Without a requested username one is derived from the email.
+func (s *AuthService) registerExternal(req model.GoogleLoginRequest, id googleIdentity) (string, error) {
+	username := req.Username
+	derived := username == ""
+	if derived {
+		username = usernameFromEmail(id.Email)
+	}
+	if err := validateUsername(username); err != nil {
+		return "", err
+	}
+
+	if err := s.checkSignup(req.InviteCode, req.CaptchaToken, req.Client); err != nil {
+		return "", err
+	}
+
+	// ADMIN_EMAILS only applies to password accounts, whose owners proved
+	// they control the address when they set up the server.
+	userID := generateID()
+	now := time.Now()
+	role := "user"
+	base := username
+	for attempt := 0; ; attempt++ {
+		var err error
+		if s.persistent() {
+			err = s.registerDB(userID, id.Email, username, noPassword, role, now)
+		} else {
+			err = s.registerMemory(userID, id.Email, username, noPassword, role, now)
+		}
+		if err == nil {
+			break
+		}
+		if !derived || err.Error() != "username already taken" || attempt == 4 {
+			return "", err
+		}
+		n, _ := strconv.ParseUint(generateID()[:4], 16, 32)
+		username = fmt.Sprintf("%s%04d", base, n%10000)
+	}
+
+	if err := s.addIdentity(userID, IdentityGoogle, id.Subject, id.Email); err != nil {
+		return "", err
+	}
+	if req.InviteCode != "" {
+		if err := s.invites.Redeem(req.InviteCode, userID); err != nil {
+			log.Printf("WARNING: Failed to credit invite code: %v", err)
+		}
+	}
+	if req.TermsVersion != "" && req.TermsVersion == CurrentTermsVersion() {
+		if _, err := s.AcceptTerms(userID, req.TermsVersion); err != nil {
+			log.Printf("WARNING: Failed to record terms acceptance for new Google account: %v", err)
+		}
+	}
+	return userID, nil
+}
+
+// externalOnly reports whether the active account with this email can only
+// sign in through an external provider.
+func (s *AuthService) externalOnly(email string) bool {
+	userID, err := s.userIDByEmail(email)
+	if err != nil || userID == "" {
+		return false
+	}
+	_, hash, err := s.credentials(userID)
+	return err == nil && hash == noPassword
+}
+
+// usernameFromEmail turns "Jane.Doe+bars@gmail.com" into "jane.doe".
+func usernameFromEmail(email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	local, _, _ = strings.Cut(local, "+")
+	var b strings.Builder
+	for _, r := range strings.ToLower(local) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	if len(name) > 24 {
+		name = name[:24]
+	}
+	for len(name) < 3 {
+		name += "_"
+	}
+	return name
+}
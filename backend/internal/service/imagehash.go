@@ -0,0 +1,67 @@
+package service
+
+import (
+	"image"
+	"math/bits"
+)
+
+// dHash computes a 64-bit difference hash: the image is shrunk to 9x8
+// grayscale cells and each bit records whether a cell is brighter than its
+// right-hand neighbour. Re-encoded, resized or lightly edited copies of the
+// same photo end up within a few bits of each other.
+func dHash(img image.Image) uint64 {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w == 0 || h == 0 {
+		return 0
+	}
+
+	var cells [8][9]float64
+	for cy := 0; cy < 8; cy++ {
+		y0, y1 := b.Min.Y+cy*h/8, b.Min.Y+(cy+1)*h/8
+		for cx := 0; cx < 9; cx++ {
+			x0, x1 := b.Min.X+cx*w/9, b.Min.X+(cx+1)*w/9
+			cells[cy][cx] = meanLuma(img, x0, y0, x1, y1)
+		}
+	}
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			if cells[y][x] > cells[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// meanLuma averages the luminance of up to 16x16 evenly spaced samples in a
+// region, which is plenty for hashing and keeps large photos cheap.
+func meanLuma(img image.Image, x0, y0, x1, y1 int) float64 {
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+	stepX := (x1 - x0 + 15) / 16
+	stepY := (y1 - y0 + 15) / 16
+
+	var sum float64
+	n := 0
+	for y := y0; y < y1; y += stepY {
+		for x := x0; x < x1; x += stepX {
+			r, g, bl, _ := img.At(x, y).RGBA()
+			sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)
+			n++
+		}
+	}
+	return sum / float64(n)
+}
+
+// hashDistance is the number of differing bits between two hashes.
+func hashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// inviteCodeAlphabet leaves out characters that are easy to misread when a
+// code is typed from a screenshot (0/O, 1/I/L).
+const (
+	inviteCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	inviteCodeLength   = 8
+)
+
+// referralBadges are earned by bringing in new members, in ascending order.
+var referralBadges = []model.Badge{
+	{ID: "recruiter", Name: "Recruiter", Referrals: 1},
+	{ID: "connector", Name: "Connector", Referrals: 5},
+	{ID: "party_starter", Name: "Party Starter", Referrals: 25},
+}
+
+type referral struct {
+	ReferrerID string
+	CreatedAt  time.Time
+}
+
+// InviteService hands every user a personal invite code and tracks who
+// signed up with it.
+type InviteService struct {
+	mu        sync.RWMutex
+	pool      *pgxpool.Pool
+	codes     map[string]string   // code -> owner ID
+	byOwner   map[string]string   // owner ID -> code
+	referrals map[string]referral // referee ID -> referral
+
+	auth *AuthService
+}
+
+func NewInviteService(pool *pgxpool.Pool, auth *AuthService) *InviteService {
+	svc := &InviteService{
+		pool:      pool,
+		codes:     make(map[string]string),
+		byOwner:   make(map[string]string),
+		referrals: make(map[string]referral),
+		auth:      auth,
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *InviteService) loadFromDB() {
+	ctx := context.Background()
+	rows, err := s.pool.Query(ctx, `SELECT code, user_id FROM invite_codes`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load invite codes from DB: %v", err)
+		return
+	}
+	for rows.Next() {
+		var code, userID string
+		if err := rows.Scan(&code, &userID); err != nil {
+			log.Printf("WARNING: Failed to scan invite code row: %v", err)
+			continue
+		}
+		s.codes[code] = userID
+		s.byOwner[userID] = code
+	}
+	rows.Close()
+
+	rows, err = s.pool.Query(ctx, `SELECT referee_id, referrer_id, created_at FROM referrals`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load referrals from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var refereeID string
+		var r referral
+		if err := rows.Scan(&refereeID, &r.ReferrerID, &r.CreatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan referral row: %v", err)
+			continue
+		}
+		s.referrals[refereeID] = r
+	}
+	log.Printf("Loaded %d invite codes and %d referrals from DB", len(s.codes), len(s.referrals))
+}
+
+func newInviteCode() string {
+	b := make([]byte, inviteCodeLength)
+	rand.Read(b)
+	for i := range b {
+		b[i] = inviteCodeAlphabet[int(b[i])%len(inviteCodeAlphabet)]
+	}
+	return string(b)
+}
+
+// normalizeInviteCode accepts codes typed in lower case or with spaces.
+func normalizeInviteCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+}
+
+// CodeFor returns the user's invite code, creating it on first use.
+func (s *InviteService) CodeFor(userID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if code, ok := s.byOwner[userID]; ok {
+		return code, nil
+	}
+
+	code := newInviteCode()
+	for s.codes[code] != "" {
+		code = newInviteCode()
+	}
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO invite_codes (code, user_id, created_at) VALUES ($1, $2, $3)`, code, userID, time.Now())
+		if err != nil {
+			return "", fmt.Errorf("failed to create invite code: %w", err)
+		}
+	}
+	s.codes[code] = userID
+	s.byOwner[userID] = code
+	return code, nil
+}
+
+// Owner returns the user an invite code belongs to, or "".
+func (s *InviteService) Owner(code string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.codes[normalizeInviteCode(code)]
+}
+
+// Redeem credits the code's owner with a new signup. A user can only be
+// referred once, and never by themselves.
+func (s *InviteService) Redeem(code, refereeID string) error {
+	code = normalizeInviteCode(code)
+	now := time.Now()
+
+	s.mu.Lock()
+	referrerID, ok := s.codes[code]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("invalid invite code")
+	}
+	if referrerID == refereeID {
+		s.mu.Unlock()
+		return fmt.Errorf("you can't use your own invite code")
+	}
+	if _, done := s.referrals[refereeID]; done {
+		s.mu.Unlock()
+		return nil
+	}
+	s.referrals[refereeID] = referral{ReferrerID: referrerID, CreatedAt: now}
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO referrals (referee_id, referrer_id, code, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
+			refereeID, referrerID, code, now)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist referral: %v", err)
+		}
+	}
+	return nil
+}
+
+// Summary returns the user's code, how many people signed up with it and the
+// badges that count has earned.
+func (s *InviteService) Summary(userID string) (*model.InviteSummary, error) {
+	code, err := s.CodeFor(userID)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	n := 0
+	for _, r := range s.referrals {
+		if r.ReferrerID == userID {
+			n++
+		}
+	}
+	s.mu.RUnlock()
+
+	summary := &model.InviteSummary{Code: code, Referrals: n, Badges: earnedReferralBadges(n)}
+	for _, b := range referralBadges {
+		if b.Referrals > n {
+			next := b
+			summary.NextBadge = &next
+			break
+		}
+	}
+	return summary, nil
+}
+
+func earnedReferralBadges(n int) []model.Badge {
+	badges := []model.Badge{}
+	for _, b := range referralBadges {
+		if n >= b.Referrals {
+			badges = append(badges, b)
+		}
+	}
+	return badges
+}
+
+// Leaderboard ranks users by how many signups they referred. Ties go to
+// whoever reached the count first.
+func (s *InviteService) Leaderboard(limit int) []model.ReferralLeader {
+	s.mu.RLock()
+	counts := make(map[string]int)
+	latest := make(map[string]time.Time)
+	for _, r := range s.referrals {
+		counts[r.ReferrerID]++
+		if r.CreatedAt.After(latest[r.ReferrerID]) {
+			latest[r.ReferrerID] = r.CreatedAt
+		}
+	}
+	s.mu.RUnlock()
+
+	ids := make([]string, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if counts[ids[i]] != counts[ids[j]] {
+			return counts[ids[i]] > counts[ids[j]]
+		}
+		return latest[ids[i]].Before(latest[ids[j]])
+	})
+
+	leaders := []model.ReferralLeader{}
+	for _, id := range ids {
+		if limit > 0 && len(leaders) >= limit {
+			break
+		}
+		user, err := s.auth.GetUser(id)
+		if err != nil {
+			continue
+		}
+		leaders = append(leaders, model.ReferralLeader{
+			UserID:    id,
+			Username:  user.Username,
+			Referrals: counts[id],
+			Badges:    earnedReferralBadges(counts[id]),
+		})
+	}
+	return leaders
+}
+
+// DeleteUser forgets a deleted account's code and referrals. In the database
+// the rows go with the user.
+func (s *InviteService) DeleteUser(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if code, ok := s.byOwner[userID]; ok {
+		delete(s.codes, code)
+		delete(s.byOwner, userID)
+	}
+	delete(s.referrals, userID)
+	for id, r := range s.referrals {
+		if r.ReferrerID == userID {
+			delete(s.referrals, id)
+		}
+	}
+}
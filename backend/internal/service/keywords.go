@@ -0,0 +1,230 @@
+package service
+
+import (
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	// keywordMinReviews is how many reviews with text a venue needs before
+	// it gets keywords; below that the "top terms" are just one review.
+	keywordMinReviews = 3
+	// keywordMinMentions drops terms only one reviewer used.
+	keywordMinMentions = 2
+	keywordsPerVenue   = 8
+	// keywordBigramBoost favors phrases ("long line") over their words.
+	keywordBigramBoost = 1.5
+)
+
+// keywordStopwords are words that say nothing about a venue on their own.
+// Generic praise ("great", "fun") is listed too: it's dropped as a single
+// word but kept inside a phrase such as "great dj".
+var keywordStopwords = toSet(strings.Fields(`
+	a about after again all also always am an and any are around as at be
+	because been before being but by can could did do does doing don't down
+	during each even ever every few for from get gets getting got had has have
+	having he her here him his how i i'm i've if in into is isn't it it's its
+	just like me more most much my never no nor not now of off on once one only
+	or other our out over own pretty really same she should so some still such
+	than that that's the their them then there there's these they they're this
+	those through to too under until up us very was wasn't we we're went were
+	what when where which while who why will with would you you're your
+	bar bars place places spot time times night nights go going come came
+	definitely probably actually lot lots thing things way back ok okay
+	good great nice fun best bad love loved awesome amazing cool better worst
+	`))
+
+func toSet(words []string) map[string]struct{} {
+	out := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		out[w] = struct{}{}
+	}
+	return out
+}
+
+// KeywordService extracts the terms a venue's reviews keep coming back to,
+// for a quick read on its vibe. Terms are scored by how many of the venue's
+// reviews use them, weighted down when most venues' reviews do (TF-IDF with
+// venues as documents). The whole table is rebuilt periodically rather
+// than on every review.
+type KeywordService struct {
+	mu       sync.RWMutex
+	ratings  *RatingService
+	keywords map[string][]model.VenueKeyword // by venue ID
+}
+
+func NewKeywordService(ratings *RatingService) *KeywordService {
+	return &KeywordService{ratings: ratings, keywords: make(map[string][]model.VenueKeyword)}
+}
+
+// ForVenue returns a venue's keywords from the last rebuild, most
+// distinctive first.
+func (s *KeywordService) ForVenue(venueID string) []model.VenueKeyword {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keywords[venueID]
+}
+
+// Rebuild recomputes every venue's keywords and returns how many venues
+// have some.
+func (s *KeywordService) Rebuild() int {
+	// Per venue: term -> number of reviews using it.
+	mentions := make(map[string]map[string]int)
+	// term -> number of venues whose reviews use it.
+	venuesUsing := make(map[string]int)
+	for venueID, reviews := range s.ratings.ReviewTextsByVenue() {
+		if len(reviews) < keywordMinReviews {
+			continue
+		}
+		counts := make(map[string]int)
+		for _, review := range reviews {
+			for term := range reviewTerms(review.Text) {
+				counts[term]++
+			}
+		}
+		mentions[venueID] = counts
+		for term := range counts {
+			venuesUsing[term]++
+		}
+	}
+
+	out := make(map[string][]model.VenueKeyword, len(mentions))
+	for venueID, counts := range mentions {
+		if kw := topKeywords(counts, venuesUsing, len(mentions)); len(kw) > 0 {
+			out[venueID] = kw
+		}
+	}
+
+	s.mu.Lock()
+	s.keywords = out
+	s.mu.Unlock()
+	return len(out)
+}
+
+// Start rebuilds the keywords now and then every interval.
+func (s *KeywordService) Start(interval time.Duration) {
+	go func() {
+		for {
+			n := s.Rebuild()
+			log.Printf("Extracted review keywords for %d venues", n)
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// reviewTerms returns the distinct words and two-word phrases in a review
+// that aren't stopwords. Phrases don't span sentences or commas.
+func reviewTerms(review string) map[string]struct{} {
+	terms := make(map[string]struct{})
+	clauses := strings.FieldsFunc(review, func(r rune) bool {
+		return strings.ContainsRune(".!?;,:\n()\"", r)
+	})
+	for _, clause := range clauses {
+		var words []string
+		for _, w := range tokenize(clause) {
+			w = strings.TrimSuffix(strings.Trim(w, "'"), "'s")
+			words = append(words, w)
+		}
+		for i, w := range words {
+			if keywordWord(w) {
+				if _, stop := keywordStopwords[w]; !stop {
+					terms[w] = struct{}{}
+				}
+			}
+			if i > 0 && phraseWord(words[i-1]) && phraseWord(w) {
+				terms[words[i-1]+" "+w] = struct{}{}
+			}
+		}
+	}
+	return terms
+}
+
+// keywordWord reports whether w can be a keyword: at least two letters and
+// not a number ("$5", "2am" and "21" say little out of context).
+func keywordWord(w string) bool {
+	letters := 0
+	for _, r := range w {
+		if unicode.IsDigit(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 2
+}
+
+// phraseWord reports whether w can start or end a phrase. Generic praise
+// can ("great dj"); function words can't ("the line").
+func phraseWord(w string) bool {
+	if !keywordWord(w) {
+		return false
+	}
+	switch w {
+	case "good", "great", "nice", "fun", "best", "bad", "cheap", "long", "loud", "awesome", "amazing", "cool", "worst":
+		return true
+	}
+	_, stop := keywordStopwords[w]
+	return !stop
+}
+
+func topKeywords(counts, venuesUsing map[string]int, venues int) []model.VenueKeyword {
+	type scored struct {
+		term  string
+		count int
+		score float64
+	}
+	var cands []scored
+	for term, n := range counts {
+		if n < keywordMinMentions {
+			continue
+		}
+		idf := math.Log(1 + float64(venues)/float64(venuesUsing[term]))
+		score := float64(n) * idf
+		if strings.Contains(term, " ") {
+			score *= keywordBigramBoost
+		}
+		cands = append(cands, scored{term, n, score})
+	}
+	sort.Slice(cands, func(i, j int) bool {
+		if cands[i].score != cands[j].score {
+			return cands[i].score > cands[j].score
+		}
+		return cands[i].term < cands[j].term
+	})
+
+	out := []model.VenueKeyword{}
+	for _, c := range cands {
+		if len(out) == keywordsPerVenue {
+			break
+		}
+		if coveredByPhrase(c.term, out) {
+			continue
+		}
+		out = append(out, model.VenueKeyword{Term: c.term, Reviews: c.count})
+	}
+	return out
+}
+
+// coveredByPhrase reports whether a single word is already part of a
+// chosen phrase, so "line" isn't listed after "long line".
+func coveredByPhrase(term string, chosen []model.VenueKeyword) bool {
+	if strings.Contains(term, " ") {
+		return false
+	}
+	for _, k := range chosen {
+		for _, w := range strings.Fields(k.Term) {
+			if w == term {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const maxLockReasonLength = 280
+
+// RatingLockService lets admins lock ratings on a venue or frat chapter.
+// Locked targets refuse new submissions with the lock's reason.
+type RatingLockService struct {
+	mu    sync.RWMutex
+	pool  *pgxpool.Pool
+	locks []model.RatingLock
+
+	venueSvc *VenueService
+}
+
+func NewRatingLockService(pool *pgxpool.Pool, venueSvc *VenueService) *RatingLockService {
+	svc := &RatingLockService{
+		pool:     pool,
+		locks:    []model.RatingLock{},
+		venueSvc: venueSvc,
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *RatingLockService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, target_type, target_id, COALESCE(target_name,''), reason, expires_at, created_by, created_at
+		 FROM rating_locks WHERE expires_at IS NULL OR expires_at > NOW() ORDER BY created_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load rating locks from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l model.RatingLock
+		if err := rows.Scan(&l.ID, &l.TargetType, &l.TargetID, &l.TargetName, &l.Reason, &l.ExpiresAt, &l.CreatedByID, &l.CreatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan rating lock row: %v", err)
+			continue
+		}
+		s.locks = append(s.locks, l)
+	}
+	log.Printf("Loaded %d rating locks from DB", len(s.locks))
+}
+
+func lockActive(l model.RatingLock, now time.Time) bool {
+	return l.ExpiresAt == nil || now.Before(*l.ExpiresAt)
+}
+
+// Check returns an error carrying the lock's reason if the target is locked.
+func (s *RatingLockService) Check(targetType, targetID string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	for _, l := range s.locks {
+		if l.TargetType == targetType && l.TargetID == targetID && lockActive(l, now) {
+			return fmt.Errorf("ratings on this %s are locked: %s", targetType, l.Reason)
+		}
+	}
+	return nil
+}
+
+// Active returns current locks, newest first.
+func (s *RatingLockService) Active() []model.RatingLock {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	out := []model.RatingLock{}
+	for _, l := range s.locks {
+		if lockActive(l, now) {
+			out = append(out, l)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out
+}
+
+// Create locks a venue or frat chapter (admin only).
+func (s *RatingLockService) Create(ctx context.Context, req model.CreateRatingLockRequest) (*model.RatingLock, error) {
+	reason := strings.TrimSpace(middleware.SanitizeString(req.Reason))
+	if reason == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+	if len([]rune(reason)) > maxLockReasonLength {
+		return nil, fmt.Errorf("reason must be at most %d characters", maxLockReasonLength)
+	}
+	if req.Hours < 0 {
+		return nil, fmt.Errorf("hours must not be negative")
+	}
+
+	lock := model.RatingLock{
+		ID:          "rlock_" + generateID()[:16],
+		TargetType:  req.TargetType,
+		Reason:      reason,
+		CreatedByID: middleware.GetUserID(ctx),
+		CreatedAt:   time.Now(),
+	}
+	switch req.TargetType {
+	case RatingTargetVenue:
+		v, err := s.venueSvc.GetByID(ctx, req.VenueID)
+		if err != nil {
+			return nil, fmt.Errorf("venue not found")
+		}
+		lock.TargetID, lock.TargetName = v.ID, v.Name
+	case RatingTargetFrat:
+		if req.SchoolID == "" || req.FratName == "" {
+			return nil, fmt.Errorf("school_id and frat_name are required")
+		}
+		lock.TargetID, lock.TargetName = FratTargetID(req.SchoolID, req.FratName), req.FratName
+	default:
+		return nil, fmt.Errorf("target_type must be venue or frat")
+	}
+	if req.Hours > 0 {
+		expires := lock.CreatedAt.Add(time.Duration(req.Hours) * time.Hour)
+		lock.ExpiresAt = &expires
+	}
+
+	if err := s.Check(lock.TargetType, lock.TargetID); err != nil {
+		return nil, fmt.Errorf("ratings on this %s are already locked", lock.TargetType)
+	}
+
+	s.mu.Lock()
+	s.locks = append(s.locks, lock)
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO rating_locks (id, target_type, target_id, target_name, reason, expires_at, created_by, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			lock.ID, lock.TargetType, lock.TargetID, lock.TargetName, lock.Reason, lock.ExpiresAt, lock.CreatedByID, lock.CreatedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist rating lock: %v", err)
+		}
+	}
+	return &lock, nil
+}
+
+// Delete lifts a lock.
+func (s *RatingLockService) Delete(id string) (*model.RatingLock, error) {
+	s.mu.Lock()
+	var removed *model.RatingLock
+	for i, l := range s.locks {
+		if l.ID == id {
+			removed = &l
+			s.locks = append(s.locks[:i], s.locks[i+1:]...)
+			break
+		}
+	}
+	s.mu.Unlock()
+	if removed == nil {
+		return nil, fmt.Errorf("lock not found")
+	}
+
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(), `DELETE FROM rating_locks WHERE id = $1`, id); err != nil {
+			log.Printf("WARNING: Failed to delete rating lock: %v", err)
+		}
+	}
+	return removed, nil
+}
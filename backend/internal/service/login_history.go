@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// maxLoginHistory is how many logins are listed per user. The database keeps
+// every login until the ip_device_data retention policy purges it; memory
+// keeps the newest maxLoginHistory per user.
+const maxLoginHistory = 100
+
+// recordLogin notes a successful password login: the user's last login
+// columns and a row in their history. Failures are logged, not returned, so
+// a history hiccup never blocks signing in.
+func (s *AuthService) recordLogin(userID string, client model.SessionClient, at time.Time) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if _, err := s.pool.Exec(ctx,
+			`UPDATE users SET last_login_at = $1, last_login_ip = $2, last_login_user_agent = $3 WHERE id = $4`,
+			at, client.IP, client.UserAgent, userID); err != nil {
+			log.Printf("WARNING: Failed to record last login for %s: %v", userID, err)
+		}
+		if _, err := s.pool.Exec(ctx,
+			`INSERT INTO user_logins (id, user_id, ip, user_agent, created_at) VALUES ($1, $2, $3, $4, $5)`,
+			generateID(), userID, client.IP, client.UserAgent, at); err != nil {
+			log.Printf("WARNING: Failed to record login for %s: %v", userID, err)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	logins := append(s.logins[userID], model.LoginRecord{IP: client.IP, UserAgent: client.UserAgent, CreatedAt: at})
+	if len(logins) > maxLoginHistory {
+		logins = logins[len(logins)-maxLoginHistory:]
+	}
+	s.logins[userID] = logins
+}
+
+// RecentLogins returns up to limit of a user's logins, newest first. A limit
+// of zero or less, or over maxLoginHistory, returns maxLoginHistory.
+func (s *AuthService) RecentLogins(userID string, limit int) ([]model.LoginRecord, error) {
+	if limit <= 0 || limit > maxLoginHistory {
+		limit = maxLoginHistory
+	}
+	out := []model.LoginRecord{}
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		rows, err := s.reader().Query(ctx,
+			`SELECT ip, user_agent, created_at FROM user_logins
+			 WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2`, userID, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list logins: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var l model.LoginRecord
+			if err := rows.Scan(&l.IP, &l.UserAgent, &l.CreatedAt); err != nil {
+				return nil, fmt.Errorf("failed to list logins: %w", err)
+			}
+			out = append(out, l)
+		}
+		return out, rows.Err()
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	logins := s.logins[userID]
+	for i := len(logins) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, logins[i])
+	}
+	return out, nil
+}
+
+// PurgeLoginHistory deletes logins recorded before cutoff and clears the
+// last login IP and user agent of users who haven't signed in since.
+func (s *AuthService) PurgeLoginHistory(cutoff time.Time, dryRun bool) (int, error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if dryRun {
+			var n int
+			err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM user_logins WHERE created_at < $1`, cutoff).Scan(&n)
+			return n, err
+		}
+		tag, err := s.pool.Exec(ctx, `DELETE FROM user_logins WHERE created_at < $1`, cutoff)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge login history: %w", err)
+		}
+		if _, err := s.pool.Exec(ctx,
+			`UPDATE users SET last_login_ip = NULL, last_login_user_agent = NULL
+			 WHERE last_login_at < $1 AND (last_login_ip IS NOT NULL OR last_login_user_agent IS NOT NULL)`, cutoff); err != nil {
+			return 0, fmt.Errorf("failed to purge login history: %w", err)
+		}
+		return int(tag.RowsAffected()), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for userID, logins := range s.logins {
+		var keep []model.LoginRecord
+		for _, l := range logins {
+			if l.CreatedAt.Before(cutoff) {
+				n++
+				continue
+			}
+			keep = append(keep, l)
+		}
+		if dryRun {
+			continue
+		}
+		if len(keep) == 0 {
+			delete(s.logins, userID)
+		} else {
+			s.logins[userID] = keep
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// Failed logins are throttled per email and per client IP. Every failure
+// delays the next attempt (1s, 2s, 4s, ... capped at loginBackoffMax), and
+// reaching the failure limit locks the key out; each further lockout of the
+// same key lasts twice as long as the one before.
+const (
+	loginBackoffBase = time.Second
+	loginBackoffMax  = 30 * time.Second
+	loginLockoutMax  = 24 * time.Hour
+
+	// loginFailureTTL is how long a key's failures are remembered after the
+	// last one, when it isn't locked.
+	loginFailureTTL = time.Hour
+)
+
+// LockoutConfig controls login throttling.
+type LockoutConfig struct {
+	MaxEmailFailures int           // failures before an email is locked out
+	MaxIPFailures    int           // failures before a client IP is locked out
+	Duration         time.Duration // first lockout; doubles on repeats
+}
+
+var defaultLockoutConfig = LockoutConfig{MaxEmailFailures: 5, MaxIPFailures: 20, Duration: 15 * time.Minute}
+
+// LoadLockoutConfig reads LOGIN_MAX_FAILURES (default 5), LOGIN_MAX_IP_FAILURES
+// (default 20) and LOGIN_LOCKOUT_MINUTES (default 15).
+func LoadLockoutConfig() LockoutConfig {
+	cfg := defaultLockoutConfig
+	if v, err := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILURES")); err == nil && v > 0 {
+		cfg.MaxEmailFailures = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("LOGIN_MAX_IP_FAILURES")); err == nil && v > 0 {
+		cfg.MaxIPFailures = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("LOGIN_LOCKOUT_MINUTES")); err == nil && v > 0 {
+		cfg.Duration = time.Duration(v) * time.Minute
+	}
+	return cfg
+}
+
+// LoginError is returned by Login when credentials are wrong or the caller
+// is being throttled. RemainingAttempts is how many more failures the email
+// (or IP, whichever is closer) may have before it is locked; RetryAfter is
+// set when the attempt was refused without checking the password.
+type LoginError struct {
+	Message           string
+	RemainingAttempts int
+	RetryAfter        time.Duration
+	Locked            bool
+	CaptchaRequired   bool // the next attempt must include a CAPTCHA token
+}
+
+func (e *LoginError) Error() string { return e.Message }
+
+type loginFailures struct {
+	count       int
+	lockouts    int
+	last        time.Time
+	retryAt     time.Time // no attempts before this
+	lockedUntil time.Time
+}
+
+// loginLimiter tracks failed logins per key ("email:..." or "ip:...").
+type loginLimiter struct {
+	mu      sync.Mutex
+	cfg     LockoutConfig
+	entries map[string]*loginFailures
+	pruned  time.Time
+}
+
+func newLoginLimiter(cfg LockoutConfig) *loginLimiter {
+	return &loginLimiter{cfg: cfg, entries: make(map[string]*loginFailures)}
+}
+
+func lockoutEmailKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func lockoutIPKey(ip string) string {
+	return "ip:" + ip
+}
+
+func (l *loginLimiter) limitFor(key string) int {
+	if strings.HasPrefix(key, "ip:") {
+		return l.cfg.MaxIPFailures
+	}
+	return l.cfg.MaxEmailFailures
+}
+
+// loginFailuresStale reports whether an entry can be forgotten: it isn't
+// locked, its last failure is old, and any earlier lockout is old enough not
+// to escalate the next one.
+func loginFailuresStale(f *loginFailures, now time.Time) bool {
+	if now.Before(f.lockedUntil) || now.Sub(f.last) <= loginFailureTTL {
+		return false
+	}
+	return f.lockouts == 0 || now.Sub(f.last) > loginLockoutMax
+}
+
+// check refuses an attempt while any of the keys is locked or backing off.
+func (l *loginLimiter) check(keys []string, now time.Time) *LoginError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var wait time.Duration
+	locked := false
+	for _, k := range keys {
+		f, ok := l.entries[k]
+		if !ok {
+			continue
+		}
+		if now.Before(f.lockedUntil) {
+			locked = true
+			wait = max(wait, f.lockedUntil.Sub(now))
+		} else if now.Before(f.retryAt) {
+			wait = max(wait, f.retryAt.Sub(now))
+		}
+	}
+	if wait == 0 {
+		return nil
+	}
+	if locked {
+		return &LoginError{
+			Message:    fmt.Sprintf("too many failed logins; try again in %s", formatWait(wait)),
+			RetryAfter: wait,
+			Locked:     true,
+		}
+	}
+	return &LoginError{
+		Message:           fmt.Sprintf("too many attempts; wait %s before trying again", formatWait(wait)),
+		RemainingAttempts: l.remainingLocked(keys),
+		RetryAfter:        wait,
+	}
+}
+
+// fail records a failed attempt on every key and returns the error to show.
+func (l *loginLimiter) fail(keys []string, now time.Time) *LoginError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pruneLocked(now)
+
+	locked := false
+	var wait time.Duration
+	for _, k := range keys {
+		f, ok := l.entries[k]
+		if !ok || loginFailuresStale(f, now) {
+			f = &loginFailures{}
+			l.entries[k] = f
+		}
+		f.count++
+		f.last = now
+		f.retryAt = now.Add(min(loginBackoffBase<<min(f.count-1, 16), loginBackoffMax))
+		if f.count >= l.limitFor(k) {
+			f.lockedUntil = now.Add(min(l.cfg.Duration<<min(f.lockouts, 16), loginLockoutMax))
+			f.lockouts++
+			f.count = 0
+			locked = true
+			wait = max(wait, f.lockedUntil.Sub(now))
+		}
+	}
+	if locked {
+		return &LoginError{
+			Message:    fmt.Sprintf("too many failed logins; try again in %s", formatWait(wait)),
+			RetryAfter: wait,
+			Locked:     true,
+		}
+	}
+	return &LoginError{
+		Message:           "invalid email or password",
+		RemainingAttempts: l.remainingLocked(keys),
+	}
+}
+
+// remainingLocked returns the fewest failures left before any key locks.
+// Caller holds l.mu.
+func (l *loginLimiter) remainingLocked(keys []string) int {
+	remaining := -1
+	for _, k := range keys {
+		n := l.limitFor(k)
+		if f, ok := l.entries[k]; ok {
+			n -= f.count
+		}
+		if remaining < 0 || n < remaining {
+			remaining = n
+		}
+	}
+	return max(remaining, 0)
+}
+
+// remaining is remainingLocked for callers outside the limiter.
+func (l *loginLimiter) remaining(keys []string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.remainingLocked(keys)
+}
+
+// failures returns the most recent failures counted against any of the
+// keys. A key that has been locked before counts as over every threshold.
+func (l *loginLimiter) failures(keys []string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	n := 0
+	for _, k := range keys {
+		f, ok := l.entries[k]
+		if !ok || loginFailuresStale(f, now) {
+			continue
+		}
+		if f.lockouts > 0 {
+			return math.MaxInt
+		}
+		n = max(n, f.count)
+	}
+	return n
+}
+
+// succeed clears an email's failures after a correct password. The IP's
+// count stays, so logging into one account doesn't reset guessing at others.
+func (l *loginLimiter) succeed(emailKey string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.entries, emailKey)
+}
+
+// pruneLocked drops stale entries at most once a minute. Caller holds l.mu.
+func (l *loginLimiter) pruneLocked(now time.Time) {
+	if now.Sub(l.pruned) < time.Minute {
+		return
+	}
+	l.pruned = now
+	for k, f := range l.entries {
+		if loginFailuresStale(f, now) {
+			delete(l.entries, k)
+		}
+	}
+}
+
+// checkAccountPassword confirms the password of a known account for
+// something other than signing in, such as linking a sign-in. Failures count
+// against the same email and IP keys as Login, so a stolen session can't be
+// used to guess the password, and wrong is the message for a failure that
+// doesn't lock anything.
+func (s *AuthService) checkAccountPassword(email, hash, password string, client model.SessionClient, wrong string) error {
+	keys := []string{lockoutEmailKey(email)}
+	if client.IP != "" {
+		keys = append(keys, lockoutIPKey(client.IP))
+	}
+	if lerr := s.lockouts.check(keys, time.Now()); lerr != nil {
+		return lerr
+	}
+	if hash == noPassword || !checkPassword(hash, password) {
+		lerr := s.lockouts.fail(keys, time.Now())
+		if !lerr.Locked {
+			lerr.Message = wrong
+		}
+		return lerr
+	}
+	s.lockouts.succeed(keys[0])
+	return nil
+}
+
+func formatWait(d time.Duration) string {
+	n, unit := int((d+time.Second-1)/time.Second), "second"
+	if d > time.Minute {
+		n, unit = int((d+time.Minute-1)/time.Minute), "minute"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", n, unit)
+}
+
+// SetLockoutConfig replaces the login throttling limits. Recorded failures
+// are kept.
+func (s *AuthService) SetLockoutConfig(cfg LockoutConfig) {
+	s.lockouts.mu.Lock()
+	defer s.lockouts.mu.Unlock()
+	s.lockouts.cfg = cfg
+}
+
+// LoginLockouts lists emails and IPs that are locked out or backing off,
+// longest wait first.
+func (s *AuthService) LoginLockouts() []model.LoginLockout {
+	l := s.lockouts
+	now := time.Now()
+	l.mu.Lock()
+	out := []model.LoginLockout{}
+	for k, f := range l.entries {
+		until := f.lockedUntil
+		if !now.Before(until) {
+			until = f.retryAt
+		}
+		if !now.Before(until) {
+			continue
+		}
+		kind, value, _ := strings.Cut(k, ":")
+		out = append(out, model.LoginLockout{
+			Kind:     kind,
+			Value:    value,
+			Failures: f.count,
+			Lockouts: f.lockouts,
+			Locked:   now.Before(f.lockedUntil),
+			Until:    until,
+		})
+	}
+	l.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Until.After(out[j].Until) })
+	return out
+}
+
+// ClearLoginLockout forgets the failures of an email and/or IP, lifting any
+// lockout. It reports how many keys were cleared.
+func (s *AuthService) ClearLoginLockout(email, ip string) (int, error) {
+	if strings.TrimSpace(email) == "" && strings.TrimSpace(ip) == "" {
+		return 0, fmt.Errorf("email or ip is required")
+	}
+	var keys []string
+	if strings.TrimSpace(email) != "" {
+		keys = append(keys, lockoutEmailKey(email))
+	}
+	if strings.TrimSpace(ip) != "" {
+		keys = append(keys, lockoutIPKey(strings.TrimSpace(ip)))
+	}
+
+	l := s.lockouts
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for _, k := range keys {
+		if _, ok := l.entries[k]; ok {
+			delete(l.entries, k)
+			n++
+		}
+	}
+	return n, nil
+}
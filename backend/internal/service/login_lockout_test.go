@@ -0,0 +1,119 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+var lockoutTestStart = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestLoginLimiterBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		wait     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{5, 16 * time.Second},
+		{6, loginBackoffMax},
+		{10, loginBackoffMax},
+	}
+	for _, tt := range tests {
+		l := newLoginLimiter(LockoutConfig{MaxEmailFailures: 100, MaxIPFailures: 100, Duration: time.Hour})
+		keys := []string{lockoutEmailKey("a@b.edu")}
+		for i := 0; i < tt.failures; i++ {
+			if lerr := l.fail(keys, lockoutTestStart); lerr.Locked {
+				t.Fatalf("%d failures: locked before the limit", tt.failures)
+			}
+		}
+
+		lerr := l.check(keys, lockoutTestStart)
+		if lerr == nil || lerr.Locked || lerr.RetryAfter != tt.wait {
+			t.Errorf("%d failures: check = %+v, want a %s backoff", tt.failures, lerr, tt.wait)
+		}
+		if lerr := l.check(keys, lockoutTestStart.Add(tt.wait)); lerr != nil {
+			t.Errorf("%d failures: still refused once the backoff passed: %v", tt.failures, lerr)
+		}
+	}
+}
+
+func TestLoginLimiterLockout(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		want     []time.Duration // length of each successive lockout
+	}{
+		{"doubles on repeats", 15 * time.Minute, []time.Duration{15 * time.Minute, 30 * time.Minute, time.Hour}},
+		{"capped at a day", 12 * time.Hour, []time.Duration{12 * time.Hour, loginLockoutMax, loginLockoutMax}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLoginLimiter(LockoutConfig{MaxEmailFailures: 3, MaxIPFailures: 100, Duration: tt.duration})
+			keys := []string{lockoutEmailKey("a@b.edu")}
+			now := lockoutTestStart
+			for i, want := range tt.want {
+				var lerr *LoginError
+				for n := 0; n < 3; n++ {
+					lerr = l.fail(keys, now)
+				}
+				if !lerr.Locked || lerr.RetryAfter != want {
+					t.Fatalf("lockout %d: fail = %+v, want locked for %s", i+1, lerr, want)
+				}
+				if lerr := l.check(keys, now.Add(want-time.Second)); lerr == nil || !lerr.Locked {
+					t.Fatalf("lockout %d: check before it ends = %+v, want locked", i+1, lerr)
+				}
+				now = now.Add(want)
+				if lerr := l.check(keys, now); lerr != nil {
+					t.Fatalf("lockout %d: check once it ends = %v, want allowed", i+1, lerr)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginLimiterIPLockout(t *testing.T) {
+	l := newLoginLimiter(LockoutConfig{MaxEmailFailures: 5, MaxIPFailures: 3, Duration: 15 * time.Minute})
+	ip := lockoutIPKey("203.0.113.7")
+	for i, email := range []string{"a@b.edu", "c@b.edu", "d@b.edu"} {
+		lerr := l.fail([]string{lockoutEmailKey(email), ip}, lockoutTestStart)
+		if locked := i == 2; lerr.Locked != locked {
+			t.Fatalf("failure %d: locked = %v, want %v", i+1, lerr.Locked, locked)
+		}
+	}
+	if lerr := l.check([]string{lockoutEmailKey("new@b.edu"), ip}, lockoutTestStart); lerr == nil || !lerr.Locked {
+		t.Errorf("check for another email from the IP = %+v, want locked", lerr)
+	}
+}
+
+func TestLoginLimiterExpiry(t *testing.T) {
+	email, ip := lockoutEmailKey("a@b.edu"), lockoutIPKey("203.0.113.7")
+	keys := []string{email, ip}
+	tests := []struct {
+		name          string
+		then          func(l *loginLimiter) time.Time // returns when the next failure happens
+		wantRemaining int
+	}{
+		{"failures add up within the TTL", func(l *loginLimiter) time.Time {
+			return lockoutTestStart.Add(loginFailureTTL)
+		}, 2},
+		{"failures are forgotten after the TTL", func(l *loginLimiter) time.Time {
+			return lockoutTestStart.Add(loginFailureTTL + time.Second)
+		}, 4},
+		{"success clears the email but not the IP", func(l *loginLimiter) time.Time {
+			l.succeed(email)
+			return lockoutTestStart.Add(time.Minute)
+		}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLoginLimiter(LockoutConfig{MaxEmailFailures: 5, MaxIPFailures: 6, Duration: 15 * time.Minute})
+			l.fail(keys, lockoutTestStart)
+			l.fail(keys, lockoutTestStart)
+			lerr := l.fail(keys, tt.then(l))
+			if lerr.Locked || lerr.RemainingAttempts != tt.wantRemaining {
+				t.Errorf("fail = %+v, want %d remaining", lerr, tt.wantRemaining)
+			}
+		})
+	}
+}
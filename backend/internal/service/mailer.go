@@ -0,0 +1,72 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+)
+
+// Mailer sends transactional email through SMTP_HOST. Without SMTP_HOST it
+// logs messages instead, so local setups can follow reset links from the
+// server log.
+type Mailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewMailer reads SMTP_HOST, SMTP_PORT (default 587), SMTP_USERNAME,
+// SMTP_PASSWORD and MAIL_FROM.
+func NewMailer() *Mailer {
+	m := &Mailer{from: os.Getenv("MAIL_FROM")}
+	if m.from == "" {
+		m.from = "RateMyBars <no-reply@ratemybars.com>"
+	}
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return m
+	}
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	m.addr = host + ":" + port
+	if user := os.Getenv("SMTP_USERNAME"); user != "" {
+		m.auth = smtp.PlainAuth("", user, os.Getenv("SMTP_PASSWORD"), host)
+	}
+	return m
+}
+
+// Send delivers a plain-text message.
+func (m *Mailer) Send(to, subject, body string) error {
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return fmt.Errorf("invalid email header")
+	}
+	if m.addr == "" {
+		log.Printf("Email to %s (SMTP not configured): %s\n%s", to, subject, body)
+		return nil
+	}
+
+	msg := "From: " + m.from + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"Date: " + time.Now().Format(time.RFC1123Z) + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n\r\n" +
+		strings.ReplaceAll(body, "\n", "\r\n")
+	if err := smtp.SendMail(m.addr, m.auth, mailAddress(m.from), []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// mailAddress extracts the bare address from "Name <addr>".
+func mailAddress(from string) string {
+	if i := strings.LastIndex(from, "<"); i >= 0 {
+		return strings.TrimSuffix(from[i+1:], ">")
+	}
+	return from
+}
@@ -15,6 +15,17 @@ func RunMigrations(ctx context.Context, pool *pgxpool.Pool) error {
 			frat_name TEXT NOT NULL,
 			PRIMARY KEY (school_id, frat_name)
 		)`,
+		`CREATE TABLE IF NOT EXISTS greek_opt_outs (
+			school_id  TEXT PRIMARY KEY,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS chapter_statuses (
+			school_id  TEXT NOT NULL,
+			frat_name  TEXT NOT NULL,
+			status     TEXT NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (school_id, frat_name)
+		)`,
 		`CREATE TABLE IF NOT EXISTS frat_ratings (
 			id          TEXT PRIMARY KEY,
 			frat_name   TEXT NOT NULL,
@@ -22,8 +33,7 @@ func RunMigrations(ctx context.Context, pool *pgxpool.Pool) error {
 			score       REAL NOT NULL,
 			author_id   TEXT NOT NULL,
 			author_name TEXT,
-			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-			UNIQUE (frat_name, school_id, author_id)
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
 		)`,
 		`CREATE TABLE IF NOT EXISTS venues (
 			id          TEXT PRIMARY KEY,
@@ -47,8 +57,7 @@ func RunMigrations(ctx context.Context, pool *pgxpool.Pool) error {
 			author_name TEXT,
 			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			upvotes     INT NOT NULL DEFAULT 0,
-			downvotes   INT NOT NULL DEFAULT 0,
-			UNIQUE (venue_id, author_id)
+			downvotes   INT NOT NULL DEFAULT 0
 		)`,
 		`CREATE TABLE IF NOT EXISTS review_votes (
 			rating_id TEXT NOT NULL,
@@ -56,6 +65,356 @@ func RunMigrations(ctx context.Context, pool *pgxpool.Pool) error {
 			direction TEXT NOT NULL,
 			PRIMARY KEY (rating_id, user_id)
 		)`,
+		`CREATE TABLE IF NOT EXISTS review_reactions (
+			rating_id TEXT NOT NULL,
+			user_id   TEXT NOT NULL,
+			reaction  TEXT NOT NULL,
+			PRIMARY KEY (rating_id, user_id, reaction)
+		)`,
+		`CREATE TABLE IF NOT EXISTS venue_lists (
+			id          TEXT PRIMARY KEY,
+			title       TEXT NOT NULL,
+			description TEXT,
+			school_id   TEXT,
+			owner_id    TEXT NOT NULL,
+			owner_name  TEXT,
+			visibility  TEXT NOT NULL DEFAULT 'public',
+			view_count  INT NOT NULL DEFAULT 0,
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS venue_list_items (
+			list_id  TEXT NOT NULL,
+			rank     INT NOT NULL,
+			venue_id TEXT NOT NULL,
+			note     TEXT,
+			PRIMARY KEY (list_id, rank)
+		)`,
+		`CREATE TABLE IF NOT EXISTS school_digests (
+			school_id    TEXT NOT NULL,
+			week_start   TIMESTAMPTZ NOT NULL,
+			payload      JSONB NOT NULL,
+			generated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (school_id, week_start)
+		)`,
+		`CREATE TABLE IF NOT EXISTS venue_follows (
+			user_id    TEXT NOT NULL,
+			venue_id   TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (user_id, venue_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS vapid_keys (
+			id          INT PRIMARY KEY,
+			private_key TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS push_subscriptions (
+			endpoint   TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL,
+			p256dh     TEXT NOT NULL,
+			auth       TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS push_opt_ins (
+			user_id TEXT PRIMARY KEY,
+			enabled BOOLEAN NOT NULL DEFAULT TRUE
+		)`,
+		`CREATE TABLE IF NOT EXISTS share_links (
+			code            TEXT PRIMARY KEY,
+			target_type     TEXT NOT NULL,
+			target_id       TEXT NOT NULL,
+			utm_source      TEXT NOT NULL,
+			utm_medium      TEXT NOT NULL,
+			utm_campaign    TEXT,
+			created_by      TEXT NOT NULL,
+			created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			clicks          INT NOT NULL DEFAULT 0,
+			last_clicked_at TIMESTAMPTZ
+		)`,
+		`CREATE TABLE IF NOT EXISTS venue_claims (
+			id             TEXT PRIMARY KEY,
+			venue_id       TEXT NOT NULL,
+			user_id        TEXT NOT NULL,
+			username       TEXT,
+			status         TEXT NOT NULL DEFAULT 'pending',
+			message        TEXT,
+			code_hash      TEXT,
+			created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			code_issued_at TIMESTAMPTZ,
+			verified_at    TIMESTAMPTZ,
+			attempts       INT NOT NULL DEFAULT 0
+		)`,
+		`CREATE TABLE IF NOT EXISTS promotions (
+			id         TEXT PRIMARY KEY,
+			venue_id   TEXT NOT NULL,
+			school_id  TEXT NOT NULL,
+			starts_at  TIMESTAMPTZ NOT NULL,
+			ends_at    TIMESTAMPTZ NOT NULL,
+			note       TEXT,
+			created_by TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS venue_events (
+			id          TEXT PRIMARY KEY,
+			venue_id    TEXT NOT NULL,
+			kind        TEXT NOT NULL,
+			title       TEXT NOT NULL,
+			description TEXT,
+			starts_at   TIMESTAMPTZ NOT NULL,
+			ends_at     TIMESTAMPTZ NOT NULL,
+			weekly      BOOLEAN NOT NULL DEFAULT FALSE,
+			created_by  TEXT NOT NULL,
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS audit_log (
+			id          TEXT PRIMARY KEY,
+			actor_id    TEXT NOT NULL,
+			actor_name  TEXT,
+			action      TEXT NOT NULL,
+			target_type TEXT NOT NULL,
+			target_id   TEXT NOT NULL,
+			details     JSONB,
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS taxonomy_terms (
+			kind       TEXT NOT NULL,
+			slug       TEXT NOT NULL,
+			label      TEXT NOT NULL,
+			position   INT NOT NULL DEFAULT 0,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (kind, slug)
+		)`,
+		`CREATE TABLE IF NOT EXISTS photos (
+			id           TEXT PRIMARY KEY,
+			venue_id     TEXT NOT NULL REFERENCES venues(id),
+			rating_id    TEXT,
+			uploader_id  TEXT NOT NULL REFERENCES users(id),
+			status       TEXT NOT NULL DEFAULT 'processing',
+			flags        TEXT[],
+			nsfw_score   REAL NOT NULL DEFAULT 0,
+			duplicate_of TEXT,
+			content_type TEXT NOT NULL,
+			width        INT NOT NULL DEFAULT 0,
+			height       INT NOT NULL DEFAULT 0,
+			size         INT NOT NULL DEFAULT 0,
+			dhash        BIGINT NOT NULL DEFAULT 0,
+			data         BYTEA,
+			created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			reviewed_at  TIMESTAMPTZ,
+			reviewed_by  TEXT
+		)`,
+		`CREATE TABLE IF NOT EXISTS pending_ratings (
+			id          TEXT PRIMARY KEY,
+			score       REAL NOT NULL,
+			review      TEXT,
+			tags        TEXT[],
+			venue_id    TEXT NOT NULL,
+			author_id   TEXT NOT NULL,
+			author_name TEXT,
+			pii         JSONB,
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS rating_locks (
+			id          TEXT PRIMARY KEY,
+			target_type TEXT NOT NULL,
+			target_id   TEXT NOT NULL,
+			target_name TEXT,
+			reason      TEXT NOT NULL,
+			expires_at  TIMESTAMPTZ,
+			created_by  TEXT NOT NULL,
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS rating_alerts (
+			id             TEXT PRIMARY KEY,
+			target_type    TEXT NOT NULL,
+			target_id      TEXT NOT NULL,
+			target_name    TEXT,
+			count          INT NOT NULL,
+			window_minutes INT NOT NULL,
+			detected_at    TIMESTAMPTZ NOT NULL,
+			frozen_until   TIMESTAMPTZ
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_credibility (
+			user_id     TEXT PRIMARY KEY,
+			score       REAL NOT NULL,
+			computed_at TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS rating_quotas (
+			user_id TEXT NOT NULL,
+			day     DATE NOT NULL,
+			count   INT NOT NULL DEFAULT 0,
+			PRIMARY KEY (user_id, day)
+		)`,
+		`CREATE TABLE IF NOT EXISTS rating_drafts (
+			user_id         TEXT NOT NULL,
+			venue_id        TEXT NOT NULL,
+			score           REAL NOT NULL DEFAULT 0,
+			review          TEXT,
+			tags            TEXT[],
+			would_recommend BOOLEAN,
+			updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (user_id, venue_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS check_ins (
+			id         TEXT PRIMARY KEY,
+			venue_id   TEXT NOT NULL,
+			user_id    TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS api_usage (
+			hour       TIMESTAMPTZ NOT NULL,
+			dimension  TEXT NOT NULL,
+			value      TEXT NOT NULL,
+			requests   INT NOT NULL,
+			throttled  INT NOT NULL,
+			PRIMARY KEY (hour, dimension, value)
+		)`,
+		`CREATE TABLE IF NOT EXISTS rating_reminders (
+			id         TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL,
+			venue_id   TEXT NOT NULL,
+			venue_name TEXT,
+			due_at     TIMESTAMPTZ NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS sessions (
+			id           TEXT PRIMARY KEY,
+			token_hash   TEXT NOT NULL UNIQUE,
+			user_id      TEXT NOT NULL,
+			username     TEXT NOT NULL,
+			role         TEXT NOT NULL DEFAULT 'user',
+			user_agent   TEXT,
+			ip           TEXT,
+			created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			expires_at   TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS text_clusters (
+			id           TEXT PRIMARY KEY,
+			rating_ids   TEXT[] NOT NULL,
+			status       TEXT NOT NULL DEFAULT 'open',
+			detected_at  TIMESTAMPTZ NOT NULL,
+			dismissed_at TIMESTAMPTZ
+		)`,
+		`CREATE TABLE IF NOT EXISTS invite_codes (
+			code       TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS referrals (
+			referee_id  TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+			referrer_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			code        TEXT NOT NULL,
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS ambassadors (
+			user_id      TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+			school_id    TEXT NOT NULL,
+			appointed_by TEXT NOT NULL DEFAULT '',
+			appointed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS ambassador_activity (
+			id         TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL,
+			school_id  TEXT NOT NULL,
+			kind       TEXT NOT NULL,
+			target_id  TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS pinned_posts (
+			id         TEXT PRIMARY KEY,
+			school_id  TEXT NOT NULL,
+			author_id  TEXT NOT NULL,
+			title      TEXT NOT NULL,
+			body       TEXT NOT NULL,
+			week_start DATE NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			UNIQUE (school_id, week_start)
+		)`,
+		`CREATE TABLE IF NOT EXISTS school_links (
+			id         TEXT PRIMARY KEY,
+			school_id  TEXT NOT NULL,
+			kind       TEXT NOT NULL,
+			label      TEXT NOT NULL DEFAULT '',
+			url        TEXT NOT NULL,
+			created_by TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS football_games (
+			id         TEXT PRIMARY KEY,
+			school_id  TEXT NOT NULL,
+			opponent   TEXT NOT NULL,
+			kickoff    TIMESTAMPTZ NOT NULL,
+			home       BOOLEAN NOT NULL DEFAULT TRUE,
+			location   TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			UNIQUE (school_id, kickoff)
+		)`,
+		`CREATE TABLE IF NOT EXISTS crawls (
+			id          TEXT PRIMARY KEY,
+			title       TEXT NOT NULL,
+			description TEXT,
+			school_id   TEXT NOT NULL,
+			owner_id    TEXT NOT NULL,
+			owner_name  TEXT,
+			starts_at   TIMESTAMPTZ NOT NULL,
+			visibility  TEXT NOT NULL DEFAULT 'public',
+			invite_code TEXT NOT NULL,
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS crawl_stops (
+			crawl_id TEXT NOT NULL,
+			rank     INT NOT NULL,
+			venue_id TEXT NOT NULL,
+			note     TEXT,
+			PRIMARY KEY (crawl_id, rank)
+		)`,
+		`CREATE TABLE IF NOT EXISTS crawl_rsvps (
+			crawl_id   TEXT NOT NULL,
+			user_id    TEXT NOT NULL,
+			username   TEXT,
+			status     TEXT NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (crawl_id, user_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS venue_statuses (
+			venue_id   TEXT PRIMARY KEY,
+			crowd      TEXT,
+			live_music BOOLEAN NOT NULL DEFAULT FALSE,
+			cover      TEXT,
+			note       TEXT,
+			posted_by  TEXT NOT NULL,
+			posted_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			expires_at TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS venue_archive (
+			id            TEXT PRIMARY KEY,
+			name          TEXT NOT NULL,
+			category      TEXT NOT NULL,
+			address       TEXT,
+			latitude      REAL,
+			longitude     REAL,
+			school_id     TEXT NOT NULL,
+			avg_rating    DOUBLE PRECISION NOT NULL DEFAULT 0,
+			rating_count  INT NOT NULL DEFAULT 0,
+			thumbs_up     INT NOT NULL DEFAULT 0,
+			thumbs_down   INT NOT NULL DEFAULT 0,
+			recommend_pct INT,
+			listed_at     TIMESTAMPTZ NOT NULL,
+			last_rated_at TIMESTAMPTZ,
+			closed_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			reason        TEXT,
+			closed_by     TEXT
+		)`,
+		`CREATE TABLE IF NOT EXISTS changelog_entries (
+			id           TEXT PRIMARY KEY,
+			title        TEXT NOT NULL,
+			body         TEXT,
+			version      TEXT,
+			published_at TIMESTAMPTZ NOT NULL,
+			created_by   TEXT NOT NULL,
+			created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
 	}
 
 	for _, ddl := range tables {
@@ -68,6 +427,28 @@ func RunMigrations(ctx context.Context, pool *pgxpool.Pool) error {
 	alters := []string{
 		`ALTER TABLE ratings ADD COLUMN IF NOT EXISTS upvotes INT NOT NULL DEFAULT 0`,
 		`ALTER TABLE ratings ADD COLUMN IF NOT EXISTS downvotes INT NOT NULL DEFAULT 0`,
+		`ALTER TABLE ratings ADD COLUMN IF NOT EXISTS tags TEXT[]`,
+		`ALTER TABLE venues ADD COLUMN IF NOT EXISTS hours JSONB`,
+		`ALTER TABLE ratings ADD COLUMN IF NOT EXISTS redacted BOOLEAN NOT NULL DEFAULT FALSE`,
+		`ALTER TABLE photos ADD COLUMN IF NOT EXISTS geotag_distance_km REAL`,
+		`ALTER TABLE check_ins ADD COLUMN IF NOT EXISTS verified BOOLEAN NOT NULL DEFAULT FALSE`,
+		`ALTER TABLE ratings ADD COLUMN IF NOT EXISTS verified_visit BOOLEAN NOT NULL DEFAULT FALSE`,
+		`ALTER TABLE pending_ratings ADD COLUMN IF NOT EXISTS verified_visit BOOLEAN NOT NULL DEFAULT FALSE`,
+		`ALTER TABLE ratings ADD COLUMN IF NOT EXISTS would_recommend BOOLEAN`,
+		`ALTER TABLE pending_ratings ADD COLUMN IF NOT EXISTS would_recommend BOOLEAN`,
+		`ALTER TABLE ratings ADD COLUMN IF NOT EXISTS author_grad_year INT`,
+		`ALTER TABLE pending_ratings ADD COLUMN IF NOT EXISTS author_grad_year INT`,
+		`ALTER TABLE ratings ADD COLUMN IF NOT EXISTS edited_at TIMESTAMPTZ`,
+		`ALTER TABLE ratings ADD COLUMN IF NOT EXISTS imported BOOLEAN NOT NULL DEFAULT FALSE`,
+		`ALTER TABLE pending_ratings ADD COLUMN IF NOT EXISTS imported BOOLEAN NOT NULL DEFAULT FALSE`,
+		`ALTER TABLE venue_events ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ`,
+		`ALTER TABLE venue_events ADD COLUMN IF NOT EXISTS announced BOOLEAN NOT NULL DEFAULT TRUE`,
+		// One rating per author, except that deleted accounts all share the
+		// author ID 'deleted'
+		`ALTER TABLE ratings DROP CONSTRAINT IF EXISTS ratings_venue_id_author_id_key`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS ratings_venue_author_idx ON ratings (venue_id, author_id) WHERE author_id <> 'deleted'`,
+		`ALTER TABLE frat_ratings DROP CONSTRAINT IF EXISTS frat_ratings_frat_name_school_id_author_id_key`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS frat_ratings_author_idx ON frat_ratings (frat_name, school_id, author_id) WHERE author_id <> 'deleted'`,
 	}
 	for _, alt := range alters {
 		if _, err := pool.Exec(ctx, alt); err != nil {
@@ -75,5 +456,18 @@ func RunMigrations(ctx context.Context, pool *pgxpool.Pool) error {
 		}
 	}
 
+	// Scores used to accept any value in 1-5 (e.g. 3.7). They're now limited
+	// to half-star steps, so snap older rows to the nearest half star. This is
+	// a no-op once every row conforms.
+	for _, table := range []string{"ratings", "pending_ratings", "frat_ratings"} {
+		tag, err := pool.Exec(ctx,
+			`UPDATE `+table+` SET score = ROUND(score * 2) / 2 WHERE score * 2 <> ROUND(score * 2)`)
+		if err != nil {
+			log.Printf("Score backfill warning (non-fatal) on %s: %v", table, err)
+		} else if n := tag.RowsAffected(); n > 0 {
+			log.Printf("Snapped %d %s scores to half-star steps", n, table)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// tokenCutoffRecheck bounds how long an instance keeps accepting a user's
+// old JWTs after their password was changed on another instance.
+const tokenCutoffRecheck = time.Minute
+
+type tokenCutoff struct {
+	after   time.Time
+	checked time.Time
+	deleted bool // the account no longer exists, so no JWT of it is good
+}
+
+// ChangePassword sets a new password after checking the current one. Wrong
+// current passwords count towards the login lockout of the account's email
+// and the caller's IP. Every existing session and JWT of the user stops
+// working; the returned token keeps the device that made the change signed
+// in.
+func (s *AuthService) ChangePassword(userID string, req model.ChangePasswordRequest) (*model.AuthResponse, error) {
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return nil, fmt.Errorf("current_password and new_password are required")
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return nil, fmt.Errorf("new password must be different from the current one")
+	}
+	email, current, err := s.credentials(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAccountPassword(email, current, req.CurrentPassword, req.Client, "current password is incorrect"); err != nil {
+		return nil, err
+	}
+	account, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPasswordStrength(req.NewPassword, account.Username); err != nil {
+		return nil, err
+	}
+	hash, err := s.hashPassword(req.NewPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	now := time.Now()
+
+	// Only replace the hash that was checked, in case the password changed
+	// in the meantime.
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		tag, err := s.pool.Exec(ctx,
+			`UPDATE users SET password_hash = $1, tokens_valid_after = $2
+			 WHERE id = $3 AND password_hash = $4 AND anonymized_at IS NULL`, hash, now, userID, current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to change password: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, fmt.Errorf("current password is incorrect")
+		}
+	} else {
+		s.mu.Lock()
+		var rec *userRecord
+		for _, r := range s.users {
+			if r.User.ID == userID && !r.Anonymized {
+				rec = r
+				break
+			}
+		}
+		if rec == nil || rec.PasswordHash != current {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("current password is incorrect")
+		}
+		rec.PasswordHash = hash
+		s.mu.Unlock()
+	}
+	s.invalidateTokens(userID, now)
+
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.issueToken(*user, req.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	return &model.AuthResponse{Token: token, User: user}, nil
+}
+
+// invalidateTokens ends every session of a user and rejects JWTs issued
+// before at. The caller has already stored at as tokens_valid_after.
+func (s *AuthService) invalidateTokens(userID string, at time.Time) {
+	s.tokenCutoffs.Store(userID, tokenCutoff{after: at, checked: time.Now()})
+	s.revokeSessions(userID)
+}
+
+// TokenIssuedValid reports whether a JWT issued at issuedAt is still good,
+// i.e. not from before the user's last password change, ban or
+// anonymization, and not for a deleted account. JWT timestamps have second
+// precision, so the cutoff is too.
+func (s *AuthService) TokenIssuedValid(userID string, issuedAt time.Time) bool {
+	cutoff := s.tokenCutoff(userID)
+	if cutoff.deleted {
+		return false
+	}
+	return cutoff.after.IsZero() || !issuedAt.Before(cutoff.after.Truncate(time.Second))
+}
+
+func (s *AuthService) tokenCutoff(userID string) tokenCutoff {
+	v, cached := s.tokenCutoffs.Load(userID)
+	if cached && (!s.persistent() || v.(tokenCutoff).deleted || time.Since(v.(tokenCutoff).checked) < tokenCutoffRecheck) {
+		return v.(tokenCutoff)
+	}
+	if !s.persistent() {
+		return tokenCutoff{}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var after *time.Time
+	err := s.queries.QueryRow(ctx, "user_token_cutoff", userID).Scan(&after)
+	if err != nil && err != pgx.ErrNoRows {
+		log.Printf("WARNING: Failed to check token cutoff: %v", err)
+		if cached {
+			return v.(tokenCutoff)
+		}
+		return tokenCutoff{}
+	}
+	// A missing row means the account was deleted, possibly by another
+	// instance, after the token was issued.
+	c := tokenCutoff{checked: time.Now(), deleted: err == pgx.ErrNoRows}
+	if after != nil {
+		c.after = *after
+	}
+	s.tokenCutoffs.Store(userID, c)
+	return c
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+func TestTokenIssuedValid(t *testing.T) {
+	// JWT iat has second precision, so a token issued in the same second as
+	// the cutoff (like the one handed back by ChangePassword) stays valid.
+	cutoff := time.Date(2026, 10, 1, 12, 0, 0, 600_000_000, time.UTC)
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		want     bool
+	}{
+		{"second before the cutoff", cutoff.Truncate(time.Second).Add(-time.Second), false},
+		{"an hour before the cutoff", cutoff.Add(-time.Hour), false},
+		{"same second as the cutoff", cutoff.Truncate(time.Second), true},
+		{"second after the cutoff", cutoff.Truncate(time.Second).Add(time.Second), true},
+	}
+	s := NewAuthServiceInMemory()
+	s.invalidateTokens("user_1", cutoff)
+	for _, tt := range tests {
+		if got := s.TokenIssuedValid("user_1", tt.issuedAt); got != tt.want {
+			t.Errorf("%s: TokenIssuedValid = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if !s.TokenIssuedValid("user_2", cutoff.Add(-time.Hour)) {
+		t.Error("a user without a cutoff had a token refused")
+	}
+}
+
+func TestTokenIssuedValidDeletedAccount(t *testing.T) {
+	s := NewAuthServiceInMemory()
+	now := time.Now()
+	if err := s.registerMemory("user_1", "a@b.edu", "alice", noPassword, "user", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteAccount("user_1"); err != nil {
+		t.Fatal(err)
+	}
+	if s.TokenIssuedValid("user_1", time.Now().Add(time.Second)) {
+		t.Error("a deleted account's token was accepted")
+	}
+}
+
+func TestTokenValidAfterLogout(t *testing.T) {
+	s := NewAuthServiceInMemory()
+	user := model.User{ID: "user_1", Username: "alice"}
+	loggedOut, err := generateToken(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := generateToken(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Logout(loggedOut)
+
+	for _, tt := range []struct {
+		name  string
+		token string
+		want  bool
+	}{
+		{"logged out", loggedOut, false},
+		{"other device", other, true},
+	} {
+		jti, userID, _, ok := middleware.TokenClaims(tt.token)
+		if !ok {
+			t.Fatalf("%s: token doesn't parse", tt.name)
+		}
+		if got := s.TokenValid(userID, jti, time.Now()); got != tt.want {
+			t.Errorf("%s: TokenValid = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordHashConfig picks how new password hashes are made. Stored hashes
+// in any supported scheme still verify; ones that don't match the config are
+// replaced the next time their owner logs in.
+type PasswordHashConfig struct {
+	Scheme     string // "bcrypt" or "argon2id"
+	BcryptCost int
+
+	// argon2id parameters
+	ArgonTime    uint32 // passes
+	ArgonMemory  uint32 // KiB
+	ArgonThreads uint8
+}
+
+const (
+	argonSaltLen = 16
+	argonKeyLen  = 32
+)
+
+// The argon2id defaults are OWASP's minimum recommendation (19 MiB, 2 passes).
+var defaultPasswordHashConfig = PasswordHashConfig{
+	Scheme:       "bcrypt",
+	BcryptCost:   bcrypt.DefaultCost,
+	ArgonTime:    2,
+	ArgonMemory:  19 * 1024,
+	ArgonThreads: 1,
+}
+
+// LoadPasswordHashConfig reads PASSWORD_HASH (bcrypt or argon2id, default
+// bcrypt), BCRYPT_COST (default 10), ARGON2_TIME (default 2),
+// ARGON2_MEMORY_KB (default 19456) and ARGON2_THREADS (default 1).
+func LoadPasswordHashConfig() (PasswordHashConfig, error) {
+	cfg := defaultPasswordHashConfig
+	switch scheme := strings.ToLower(os.Getenv("PASSWORD_HASH")); scheme {
+	case "":
+	case "bcrypt", "argon2id":
+		cfg.Scheme = scheme
+	default:
+		return cfg, fmt.Errorf("PASSWORD_HASH must be bcrypt or argon2id")
+	}
+	if v := os.Getenv("BCRYPT_COST"); v != "" {
+		cost, err := strconv.Atoi(v)
+		if err != nil || cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+			return cfg, fmt.Errorf("BCRYPT_COST must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		cfg.BcryptCost = cost
+	}
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_TIME"), 10, 32); err == nil && v > 0 {
+		cfg.ArgonTime = uint32(v)
+	}
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_MEMORY_KB"), 10, 32); err == nil && v >= 8 {
+		cfg.ArgonMemory = uint32(v)
+	}
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_THREADS"), 10, 8); err == nil && v > 0 {
+		cfg.ArgonThreads = uint8(v)
+	}
+	return cfg, nil
+}
+
+// SetPasswordHashConfig changes how new passwords are hashed.
+func (s *AuthService) SetPasswordHashConfig(cfg PasswordHashConfig) {
+	s.hashing = cfg
+}
+
+// hashPassword hashes a password with the configured scheme.
+func (s *AuthService) hashPassword(password string) (string, error) {
+	cfg := s.hashing
+	if cfg.Scheme == "argon2id" {
+		salt := make([]byte, argonSaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		key := argon2.IDKey([]byte(password), salt, cfg.ArgonTime, cfg.ArgonMemory, cfg.ArgonThreads, argonKeyLen)
+		return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version,
+			cfg.ArgonMemory, cfg.ArgonTime, cfg.ArgonThreads,
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cfg.BcryptCost)
+	return string(hash), err
+}
+
+// checkPassword reports whether password matches hash, whichever scheme made it.
+func checkPassword(hash, password string) bool {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		p, err := parseArgon2Hash(hash)
+		if err != nil {
+			return false
+		}
+		key := argon2.IDKey([]byte(password), p.salt, p.time, p.memory, p.threads, uint32(len(p.key)))
+		return subtle.ConstantTimeCompare(key, p.key) == 1
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// needsRehash reports whether a stored hash was made with a different scheme
+// or parameters than the current config.
+func (s *AuthService) needsRehash(hash string) bool {
+	cfg := s.hashing
+	if strings.HasPrefix(hash, "$argon2id$") {
+		if cfg.Scheme != "argon2id" {
+			return true
+		}
+		p, err := parseArgon2Hash(hash)
+		return err != nil || p.time != cfg.ArgonTime || p.memory != cfg.ArgonMemory || p.threads != cfg.ArgonThreads
+	}
+	if cfg.Scheme != "bcrypt" {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != cfg.BcryptCost
+}
+
+// rehashPassword replaces an outdated hash after a successful login. A
+// concurrent password change wins: the update only applies to the old hash.
+func (s *AuthService) rehashPassword(email, userID, oldHash, password string) {
+	hash, err := s.hashPassword(password)
+	if err != nil {
+		log.Printf("WARNING: Failed to rehash password: %v", err)
+		return
+	}
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := s.pool.Exec(ctx,
+			`UPDATE users SET password_hash = $1 WHERE id = $2 AND password_hash = $3`, hash, userID, oldHash); err != nil {
+			log.Printf("WARNING: Failed to store rehashed password: %v", err)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	if rec, ok := s.users[email]; ok && rec.PasswordHash == oldHash {
+		rec.PasswordHash = hash
+	}
+	s.mu.Unlock()
+}
+
+type argon2Hash struct {
+	time, memory uint32
+	threads      uint8
+	salt, key    []byte
+}
+
+// parseArgon2Hash decodes "$argon2id$v=19$m=...,t=...,p=...$salt$key".
+func parseArgon2Hash(hash string) (argon2Hash, error) {
+	var p argon2Hash
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return p, fmt.Errorf("malformed argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, fmt.Errorf("unsupported argon2 version")
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return p, fmt.Errorf("malformed argon2id parameters")
+	}
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return p, fmt.Errorf("malformed argon2id salt")
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(p.key) == 0 {
+		return p, fmt.Errorf("malformed argon2id key")
+	}
+	return p, nil
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// passwordResetTTL is how long an emailed reset link stays valid.
+const passwordResetTTL = time.Hour
+
+// PasswordResetSendFunc delivers a reset token to the account's email
+// address (e.g. as a link to the frontend's reset page).
+type PasswordResetSendFunc func(email, token string, expiresAt time.Time) error
+
+// passwordReset is an issued, unused reset token. Only the token's hash is
+// stored.
+type passwordReset struct {
+	UserID    string
+	ExpiresAt time.Time
+}
+
+// SetPasswordResetSender sets the hook that emails reset tokens.
+func (s *AuthService) SetPasswordResetSender(fn PasswordResetSendFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sendReset = fn
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RequestPasswordReset issues a reset token for the account with this email
+// and hands it to the delivery hook. Unknown emails are ignored without an
+// error so callers can't probe which addresses are registered.
+func (s *AuthService) RequestPasswordReset(email string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return fmt.Errorf("email is required")
+	}
+
+	var userID string
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := s.pool.QueryRow(ctx, `SELECT id FROM users WHERE email = $1 AND anonymized_at IS NULL`, email).Scan(&userID)
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to look up account: %w", err)
+		}
+	} else {
+		s.mu.RLock()
+		if rec, ok := s.users[email]; ok && !rec.Anonymized {
+			userID = rec.User.ID
+		}
+		s.mu.RUnlock()
+		if userID == "" {
+			return nil
+		}
+	}
+
+	token := generateID() + generateID()
+	expiresAt := time.Now().Add(passwordResetTTL)
+	if err := s.storeReset(userID, hashResetToken(token), expiresAt); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	send := s.sendReset
+	s.mu.RUnlock()
+	if send == nil {
+		log.Printf("WARNING: Password reset requested but no delivery hook is configured")
+		return nil
+	}
+	if err := send(email, token, expiresAt); err != nil {
+		log.Printf("WARNING: Failed to deliver password reset email: %v", err)
+	}
+	return nil
+}
+
+// storeReset saves a new token, replacing any earlier unused one for the user.
+func (s *AuthService) storeReset(userID, tokenHash string, expiresAt time.Time) error {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := s.pool.Exec(ctx, `DELETE FROM password_resets WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to issue reset token: %w", err)
+		}
+		_, err := s.pool.Exec(ctx,
+			`INSERT INTO password_resets (token_hash, user_id, expires_at) VALUES ($1, $2, $3)`,
+			tokenHash, userID, expiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to issue reset token: %w", err)
+		}
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for h, r := range s.resets {
+		if r.UserID == userID {
+			delete(s.resets, h)
+		}
+	}
+	s.resets[tokenHash] = passwordReset{UserID: userID, ExpiresAt: expiresAt}
+	return nil
+}
+
+// ResetPassword sets a new password using an emailed token. Tokens work once.
+func (s *AuthService) ResetPassword(token, newPassword string) error {
+	if token == "" {
+		return fmt.Errorf("invalid or expired reset token")
+	}
+	if err := checkPasswordStrength(newPassword); err != nil {
+		return err
+	}
+	hash, err := s.hashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	tokenHash := hashResetToken(token)
+	now := time.Now()
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// Deleting the row claims the token, so it can't be used twice.
+		var userID string
+		var expiresAt time.Time
+		err := s.pool.QueryRow(ctx,
+			`DELETE FROM password_resets WHERE token_hash = $1 RETURNING user_id, expires_at`,
+			tokenHash).Scan(&userID, &expiresAt)
+		if err == pgx.ErrNoRows || err == nil && !now.Before(expiresAt) {
+			return fmt.Errorf("invalid or expired reset token")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to reset password: %w", err)
+		}
+		tag, err := s.pool.Exec(ctx,
+			`UPDATE users SET password_hash = $1, tokens_valid_after = $2 WHERE id = $3 AND anonymized_at IS NULL`,
+			hash, now, userID)
+		if err != nil {
+			return fmt.Errorf("failed to reset password: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("invalid or expired reset token")
+		}
+		s.invalidateTokens(userID, now)
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.resets[tokenHash]
+	if !ok || !now.Before(r.ExpiresAt) {
+		return fmt.Errorf("invalid or expired reset token")
+	}
+	delete(s.resets, tokenHash)
+	for _, rec := range s.users {
+		if rec.User.ID == r.UserID && !rec.Anonymized {
+			rec.PasswordHash = hash
+			s.invalidateTokens(r.UserID, now)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid or expired reset token")
+}
+
+// PurgeExpiredResets deletes reset tokens that expired before cutoff.
+func (s *AuthService) PurgeExpiredResets(cutoff time.Time, dryRun bool) (int, error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if dryRun {
+			var n int
+			err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM password_resets WHERE expires_at < $1`, cutoff).Scan(&n)
+			return n, err
+		}
+		tag, err := s.pool.Exec(ctx, `DELETE FROM password_resets WHERE expires_at < $1`, cutoff)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge reset tokens: %w", err)
+		}
+		return int(tag.RowsAffected()), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for h, r := range s.resets {
+		if r.ExpiresAt.Before(cutoff) {
+			if !dryRun {
+				delete(s.resets, h)
+			}
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// minPasswordScore is the weakest estimated strength (0–4) a new password
+// may have. 2 is roughly 8 random lowercase letters.
+const minPasswordScore = 2
+
+// PasswordError is returned when a new password is too weak. Score is the
+// estimated strength from 0 (trivial) to 4 (strong).
+type PasswordError struct {
+	Score       int
+	Issues      []model.PasswordIssue
+	Suggestions []string
+}
+
+func (e *PasswordError) Error() string { return e.Issues[0].Message }
+
+// commonPasswords are the most used passwords of 8 or more characters from
+// public breach lists, plus a few obvious local ones. Entries are lowercase;
+// candidates are compared after lowercasing, undoing common character
+// substitutions and stripping trailing digits and symbols.
+var commonPasswords = map[string]bool{}
+
+func init() {
+	for _, p := range strings.Fields(`
+		password password1 password12 password123 passw0rd passwords
+		12345678 123456789 1234567890 0123456789 87654321 11111111 00000000
+		qwertyui qwertyuiop qwerty123 asdfghjk asdfghjkl zxcvbnm zxcvbnmm
+		1qaz2wsx qazwsxedc iloveyou iloveyou1 sunshine princess football
+		baseball basketball superman batman spiderman starwars trustno1
+		whatever welcome welcome1 letmein letmein1 abc12345 abcd1234 abcdefgh
+		aa123456 monkey123 dragon123 master123 computer internet michelle
+		jennifer jordan23 charlie1 chocolate liverpool chelsea arsenal
+		football1 baseball1 maverick mustang1 shadow12 freedom1 changeme
+		secret123 admin123 administrator qwerty12 q1w2e3r4 q1w2e3r4t5 1q2w3e4r
+		zaq12wsx access14 michael1 jessica1 hunter22 ashley12 babygirl
+		lovely123 loveyou1 princess1 sunshine1 summer123 spring123 winter123
+		partytime partyhard party123 beerpong drinking collegeparty
+		ratemybars ratemycollegeparty greeklife fraternity sorority
+	`) {
+		commonPasswords[p] = true
+	}
+}
+
+// leetReplacer undoes the substitutions people use to dress up a word.
+var leetReplacer = strings.NewReplacer("0", "o", "1", "l", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s", "!", "i")
+
+// checkPasswordStrength returns a *PasswordError if password is too short,
+// common, built from the given personal details (username, email) or too
+// predictable to guess slowly.
+func checkPasswordStrength(password string, personal ...string) error {
+	if len(password) < 8 {
+		return &PasswordError{Issues: []model.PasswordIssue{{Code: "too_short", Message: "password must be at least 8 characters"}},
+			Suggestions: []string{"Use at least 8 characters; a few unrelated words work well"}}
+	}
+
+	e := &PasswordError{Score: passwordScore(password)}
+	lower := strings.ToLower(password)
+	if isCommonPassword(lower) {
+		e.Score = 0
+		e.Issues = append(e.Issues, model.PasswordIssue{Code: "common", Message: "password is too common"})
+		e.Suggestions = append(e.Suggestions, "Avoid well-known passwords, even with numbers or symbols added")
+	}
+	for _, p := range personal {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if at := strings.IndexByte(p, '@'); at >= 0 {
+			p = p[:at]
+		}
+		if len(p) >= 3 && strings.Contains(lower, p) {
+			e.Issues = append(e.Issues, model.PasswordIssue{Code: "personal_info", Message: "password must not contain your username or email"})
+			e.Suggestions = append(e.Suggestions, "Leave your username and email out of your password")
+			break
+		}
+	}
+	if effectiveLength(password)*2 <= len([]rune(password)) {
+		e.Issues = append(e.Issues, model.PasswordIssue{Code: "predictable", Message: "password is mostly repeated or sequential characters"})
+		e.Suggestions = append(e.Suggestions, "Avoid runs like aaaa or 1234")
+	}
+	if len(e.Issues) == 0 && e.Score < minPasswordScore {
+		e.Issues = append(e.Issues, model.PasswordIssue{Code: "weak", Message: "password is too easy to guess"})
+		e.Suggestions = append(e.Suggestions, "Make it longer or mix in uppercase letters, digits and symbols")
+	}
+	if len(e.Issues) == 0 {
+		return nil
+	}
+	return e
+}
+
+// isCommonPassword checks a lowercased password against the blocklist, also
+// after stripping trailing digits and symbols ("football2024!").
+func isCommonPassword(lower string) bool {
+	if commonPasswords[lower] {
+		return true
+	}
+	base := strings.TrimRightFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) })
+	return commonPasswords[base] || commonPasswords[leetReplacer.Replace(lower)] || commonPasswords[leetReplacer.Replace(base)]
+}
+
+// effectiveLength counts the characters that don't repeat or continue a
+// run (aaa, abc, 321) from the one before.
+func effectiveLength(password string) int {
+	n := 0
+	var prev rune
+	for i, r := range []rune(password) {
+		if i == 0 || (r != prev && r != prev+1 && r != prev-1) {
+			n++
+		}
+		prev = r
+	}
+	return n
+}
+
+// passwordScore is a rough zxcvbn-style estimate from 0 to 4 of how long
+// the password would survive guessing: entropy of its non-run characters
+// over the character classes it uses.
+func passwordScore(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	pool := 0
+	for _, c := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {other, 33}} {
+		if c.used {
+			pool += c.size
+		}
+	}
+	bits := float64(effectiveLength(password)) * math.Log2(float64(pool))
+	switch {
+	case bits < 28:
+		return 0
+	case bits < 36:
+		return 1
+	case bits < 60:
+		return 2
+	case bits < 80:
+		return 3
+	}
+	return 4
+}
@@ -0,0 +1,612 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif" // decoder registration
+	"image/jpeg"
+	"image/png"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// Photo moderation statuses. Only approved photos are publicly visible.
+const (
+	PhotoProcessing  = "processing"
+	PhotoQuarantined = "quarantined"
+	PhotoApproved    = "approved"
+	PhotoRejected    = "rejected"
+)
+
+// duplicateDistance is the largest dHash distance treated as the same photo.
+const duplicateDistance = 5
+
+// NSFWClassifier scores how likely an image is to be explicit, from 0 to 1.
+type NSFWClassifier interface {
+	Classify(img image.Image) (float64, error)
+}
+
+// noopClassifier is used until a real classifier is configured; it lets the
+// rest of the pipeline (and admin review) run without one.
+type noopClassifier struct{}
+
+func (noopClassifier) Classify(image.Image) (float64, error) { return 0, nil }
+
+// PhotoConfig holds upload limits and moderation thresholds.
+type PhotoConfig struct {
+	MaxBytes        int64   // largest accepted upload
+	MaxPixels       int     // largest accepted width*height, guards against decompression bombs
+	NSFWThreshold   float64 // classifier score at or above which a photo is quarantined
+	RequireApproval bool    // quarantine every photo, not just flagged ones
+	GeotagMaxKm     float64 // GPS EXIF farther than this from the venue is flagged
+}
+
+// LoadPhotoConfig reads PHOTO_MAX_BYTES, PHOTO_MAX_PIXELS, PHOTO_NSFW_THRESHOLD,
+// PHOTO_REQUIRE_APPROVAL and PHOTO_GEOTAG_MAX_KM.
+func LoadPhotoConfig() PhotoConfig {
+	cfg := PhotoConfig{
+		MaxBytes:      5 << 20,
+		MaxPixels:     40_000_000,
+		NSFWThreshold: 0.8,
+		GeotagMaxKm:   2,
+	}
+	if v, err := strconv.ParseInt(os.Getenv("PHOTO_MAX_BYTES"), 10, 64); err == nil && v > 0 {
+		cfg.MaxBytes = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("PHOTO_MAX_PIXELS")); err == nil && v > 0 {
+		cfg.MaxPixels = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("PHOTO_NSFW_THRESHOLD"), 64); err == nil && v > 0 && v <= 1 {
+		cfg.NSFWThreshold = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("PHOTO_GEOTAG_MAX_KM"), 64); err == nil && v > 0 {
+		cfg.GeotagMaxKm = v
+	}
+	cfg.RequireApproval = os.Getenv("PHOTO_REQUIRE_APPROVAL") == "true"
+	return cfg
+}
+
+type photoRecord struct {
+	photo model.Photo
+	hash  uint64
+	data  []byte // nil once persisted to the DB; fetched on demand
+}
+
+// PhotoService stores venue and review photos and runs each upload through
+// an async moderation pipeline before it becomes public.
+type PhotoService struct {
+	mu         sync.RWMutex
+	pool       *pgxpool.Pool
+	cfg        PhotoConfig
+	classifier NSFWClassifier
+	venueSvc   *VenueService
+	photos     map[string]*photoRecord
+	queue      chan string
+}
+
+func NewPhotoService(pool *pgxpool.Pool, cfg PhotoConfig) *PhotoService {
+	svc := &PhotoService{
+		pool:       pool,
+		cfg:        cfg,
+		classifier: noopClassifier{},
+		photos:     make(map[string]*photoRecord),
+		queue:      make(chan string, 256),
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *PhotoService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, venue_id, COALESCE(rating_id,''), uploader_id, status, COALESCE(flags,'{}'), nsfw_score,
+		        COALESCE(duplicate_of,''), geotag_distance_km, content_type, width, height, size, dhash, created_at, reviewed_at, COALESCE(reviewed_by,'')
+		 FROM photos WHERE status <> 'rejected'`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load photos from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rec photoRecord
+		p := &rec.photo
+		var hash int64
+		if err := rows.Scan(&p.ID, &p.VenueID, &p.RatingID, &p.UploaderID, &p.Status, &p.Flags, &p.NSFWScore,
+			&p.DuplicateOf, &p.GeotagDistanceKm, &p.ContentType, &p.Width, &p.Height, &p.Size, &hash, &p.CreatedAt, &p.ReviewedAt, &p.ReviewedByID); err != nil {
+			log.Printf("WARNING: Failed to scan photo row: %v", err)
+			continue
+		}
+		rec.hash = uint64(hash)
+		s.setURL(p)
+		s.photos[p.ID] = &rec
+	}
+	log.Printf("Loaded %d photos from DB", len(s.photos))
+}
+
+// SetClassifier plugs in an NSFW classifier.
+func (s *PhotoService) SetClassifier(c NSFWClassifier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.classifier = c
+}
+
+// SetVenues lets the pipeline compare photo geotags with venue locations.
+func (s *PhotoService) SetVenues(venueSvc *VenueService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.venueSvc = venueSvc
+}
+
+// Config returns the upload limits in effect.
+func (s *PhotoService) Config() PhotoConfig {
+	return s.cfg
+}
+
+// Start launches the moderation workers and requeues photos that were still
+// processing when the server last stopped.
+func (s *PhotoService) Start(workers int) {
+	for i := 0; i < workers; i++ {
+		go func() {
+			for id := range s.queue {
+				s.process(id)
+			}
+		}()
+	}
+
+	s.mu.RLock()
+	var stale []string
+	for id, rec := range s.photos {
+		if rec.photo.Status == PhotoProcessing {
+			stale = append(stale, id)
+		}
+	}
+	s.mu.RUnlock()
+	go func() {
+		for _, id := range stale {
+			s.queue <- id
+		}
+	}()
+}
+
+// Upload validates a photo and queues it for moderation. It is not visible
+// until the pipeline (or an admin) approves it.
+func (s *PhotoService) Upload(ctx context.Context, venueID, ratingID string, data []byte) (*model.Photo, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	if int64(len(data)) > s.cfg.MaxBytes {
+		return nil, fmt.Errorf("photo exceeds %d bytes", s.cfg.MaxBytes)
+	}
+
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+	default:
+		return nil, fmt.Errorf("unsupported image type: %s", contentType)
+	}
+	conf, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image")
+	}
+	if conf.Width*conf.Height > s.cfg.MaxPixels {
+		return nil, fmt.Errorf("image dimensions too large")
+	}
+
+	rec := &photoRecord{
+		photo: model.Photo{
+			ID:          "photo_" + generateID()[:16],
+			VenueID:     venueID,
+			RatingID:    ratingID,
+			UploaderID:  userID,
+			Status:      PhotoProcessing,
+			ContentType: contentType,
+			Width:       conf.Width,
+			Height:      conf.Height,
+			Size:        len(data),
+			CreatedAt:   time.Now(),
+		},
+		data: data,
+	}
+
+	// Store the photo before queueing it so a worker never picks up an ID
+	// it can't find.
+	s.mu.Lock()
+	s.photos[rec.photo.ID] = rec
+	p := rec.photo
+	s.mu.Unlock()
+	s.persist(rec)
+
+	select {
+	case s.queue <- p.ID:
+	default:
+		s.remove(p.ID)
+		return nil, fmt.Errorf("photo queue is full, try again later")
+	}
+	return &p, nil
+}
+
+// remove drops a photo that never made it into the moderation queue.
+func (s *PhotoService) remove(id string) {
+	s.mu.Lock()
+	delete(s.photos, id)
+	s.mu.Unlock()
+	if s.pool == nil {
+		return
+	}
+	if _, err := s.pool.Exec(context.Background(), `DELETE FROM photos WHERE id = $1`, id); err != nil {
+		log.Printf("WARNING: Failed to delete photo from DB: %v", err)
+	}
+}
+
+// fail rejects a photo the pipeline couldn't handle, so it doesn't sit in
+// processing forever.
+func (s *PhotoService) fail(id, flag string) {
+	s.mu.Lock()
+	rec, ok := s.photos[id]
+	if ok {
+		rec.photo.Status = PhotoRejected
+		rec.photo.Flags = append(rec.photo.Flags, flag)
+		rec.data = nil
+		s.setURL(&rec.photo)
+	}
+	s.mu.Unlock()
+	if ok {
+		s.persistStatus(id)
+	}
+}
+
+// process re-encodes a photo (dropping EXIF and any other metadata), checks
+// it for duplicates, explicit content and a geotag far from the venue, then
+// approves or quarantines it.
+func (s *PhotoService) process(id string) {
+	data, err := s.imageData(id)
+	if err != nil {
+		log.Printf("WARNING: Failed to load photo %s for processing: %v", id, err)
+		s.fail(id, "missing_data")
+		return
+	}
+	geotagKm := s.geotagDistance(id, data)
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		s.fail(id, "undecodable")
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := "image/jpeg"
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	} else {
+		contentType = "image/png"
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		log.Printf("WARNING: Failed to re-encode photo %s: %v", id, err)
+		s.fail(id, "reencode_failed")
+		return
+	}
+
+	s.mu.RLock()
+	classifier := s.classifier
+	s.mu.RUnlock()
+	score, err := classifier.Classify(img)
+	if err != nil {
+		log.Printf("WARNING: NSFW classifier failed for photo %s: %v", id, err)
+	}
+	hash := dHash(img)
+
+	s.mu.Lock()
+	rec, ok := s.photos[id]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	p := &rec.photo
+	p.ContentType = contentType
+	p.Size = buf.Len()
+	p.NSFWScore = score
+	p.GeotagDistanceKm = geotagKm
+	rec.hash = hash
+	rec.data = buf.Bytes()
+
+	if err != nil {
+		p.Flags = append(p.Flags, "classifier_error")
+	} else if score >= s.cfg.NSFWThreshold {
+		p.Flags = append(p.Flags, "nsfw")
+	}
+	if geotagKm != nil && *geotagKm > s.cfg.GeotagMaxKm {
+		p.Flags = append(p.Flags, "geotag_mismatch")
+	}
+	if dup := s.findDuplicateLocked(id, hash); dup != "" {
+		p.DuplicateOf = dup
+		p.Flags = append(p.Flags, "duplicate")
+	}
+
+	if len(p.Flags) > 0 || s.cfg.RequireApproval {
+		p.Status = PhotoQuarantined
+	} else {
+		p.Status = PhotoApproved
+	}
+	s.setURL(p)
+	s.mu.Unlock()
+
+	s.persist(rec)
+}
+
+// geotagDistance returns the distance in km between a photo's GPS EXIF
+// position and its venue, or nil if either is unknown.
+func (s *PhotoService) geotagDistance(id string, data []byte) *float64 {
+	lat, lng, ok := exifGPS(data)
+	if !ok || (lat == 0 && lng == 0) {
+		return nil
+	}
+
+	s.mu.RLock()
+	venueSvc := s.venueSvc
+	rec, found := s.photos[id]
+	var venueID string
+	if found {
+		venueID = rec.photo.VenueID
+	}
+	s.mu.RUnlock()
+	if venueSvc == nil || !found {
+		return nil
+	}
+
+	venue, err := venueSvc.GetByID(context.Background(), venueID)
+	if err != nil || (venue.Latitude == 0 && venue.Longitude == 0) {
+		return nil
+	}
+	km := math.Round(haversineKm(lat, lng, venue.Latitude, venue.Longitude)*100) / 100
+	return &km
+}
+
+// findDuplicateLocked returns the oldest other photo whose hash is within
+// duplicateDistance. Callers must hold s.mu.
+func (s *PhotoService) findDuplicateLocked(id string, hash uint64) string {
+	var match *model.Photo
+	for otherID, other := range s.photos {
+		if otherID == id || other.photo.Status == PhotoProcessing || other.photo.Status == PhotoRejected {
+			continue
+		}
+		if hashDistance(hash, other.hash) > duplicateDistance {
+			continue
+		}
+		if match == nil || other.photo.CreatedAt.Before(match.CreatedAt) {
+			match = &other.photo
+		}
+	}
+	if match == nil {
+		return ""
+	}
+	return match.ID
+}
+
+func (s *PhotoService) setURL(p *model.Photo) {
+	p.URL = ""
+	if p.Status == PhotoApproved {
+		p.URL = "/api/photos/" + p.ID
+	}
+}
+
+// persist upserts a photo including its image bytes, which are then dropped
+// from memory when a DB is available.
+func (s *PhotoService) persist(rec *photoRecord) {
+	if s.pool == nil {
+		return
+	}
+	s.mu.RLock()
+	p := rec.photo
+	hash := rec.hash
+	data := rec.data
+	s.mu.RUnlock()
+
+	_, err := s.pool.Exec(context.Background(),
+		`INSERT INTO photos (id, venue_id, rating_id, uploader_id, status, flags, nsfw_score, duplicate_of, geotag_distance_km, content_type, width, height, size, dhash, data, created_at)
+		 VALUES ($1, $2, NULLIF($3,''), $4, $5, $6, $7, NULLIF($8,''), $9, $10, $11, $12, $13, $14, $15, $16)
+		 ON CONFLICT (id) DO UPDATE SET status = EXCLUDED.status, flags = EXCLUDED.flags, nsfw_score = EXCLUDED.nsfw_score,
+		   duplicate_of = EXCLUDED.duplicate_of, geotag_distance_km = EXCLUDED.geotag_distance_km,
+		   content_type = EXCLUDED.content_type, size = EXCLUDED.size, dhash = EXCLUDED.dhash, data = EXCLUDED.data`,
+		p.ID, p.VenueID, p.RatingID, p.UploaderID, p.Status, p.Flags, p.NSFWScore, p.DuplicateOf, p.GeotagDistanceKm,
+		p.ContentType, p.Width, p.Height, p.Size, int64(hash), data, p.CreatedAt)
+	if err != nil {
+		log.Printf("WARNING: Failed to persist photo to DB: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	if bytes.Equal(rec.data, data) {
+		rec.data = nil
+	}
+	s.mu.Unlock()
+}
+
+// persistStatus writes moderation fields only.
+func (s *PhotoService) persistStatus(id string) {
+	if s.pool == nil {
+		return
+	}
+	s.mu.RLock()
+	rec, ok := s.photos[id]
+	if !ok {
+		s.mu.RUnlock()
+		return
+	}
+	p := rec.photo
+	s.mu.RUnlock()
+
+	_, err := s.pool.Exec(context.Background(),
+		`UPDATE photos SET status = $2, flags = $3, reviewed_at = $4, reviewed_by = NULLIF($5,''),
+		   data = CASE WHEN $2 = 'rejected' THEN NULL ELSE data END
+		 WHERE id = $1`,
+		p.ID, p.Status, p.Flags, p.ReviewedAt, p.ReviewedByID)
+	if err != nil {
+		log.Printf("WARNING: Failed to update photo status in DB: %v", err)
+	}
+}
+
+// Get returns a photo's metadata.
+func (s *PhotoService) Get(id string) (*model.Photo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rec, ok := s.photos[id]
+	if !ok {
+		return nil, fmt.Errorf("photo not found")
+	}
+	p := rec.photo
+	return &p, nil
+}
+
+// Image returns a photo's bytes and content type. Unless includeUnapproved
+// is set, only approved photos are returned. Photos still being processed
+// are never returned, since their original bytes may carry EXIF metadata.
+func (s *PhotoService) Image(id string, includeUnapproved bool) ([]byte, string, error) {
+	s.mu.RLock()
+	rec, ok := s.photos[id]
+	if !ok || rec.photo.Status == PhotoRejected || rec.photo.Status == PhotoProcessing ||
+		(!includeUnapproved && rec.photo.Status != PhotoApproved) {
+		s.mu.RUnlock()
+		return nil, "", fmt.Errorf("photo not found")
+	}
+	contentType := rec.photo.ContentType
+	s.mu.RUnlock()
+
+	data, err := s.imageData(id)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, contentType, nil
+}
+
+// imageData returns a photo's stored bytes, loading them from the DB if
+// they're no longer held in memory.
+func (s *PhotoService) imageData(id string) ([]byte, error) {
+	s.mu.RLock()
+	rec, ok := s.photos[id]
+	var data []byte
+	if ok {
+		data = rec.data
+	}
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("photo not found")
+	}
+
+	if data == nil && s.pool != nil {
+		if err := s.pool.QueryRow(context.Background(),
+			`SELECT data FROM photos WHERE id = $1`, id).Scan(&data); err != nil || data == nil {
+			return nil, fmt.Errorf("photo not found")
+		}
+	}
+	if data == nil {
+		return nil, fmt.Errorf("photo not found")
+	}
+	return data, nil
+}
+
+// ListByVenue returns a venue's approved photos, newest first.
+func (s *PhotoService) ListByVenue(venueID string) []model.Photo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := []model.Photo{}
+	for _, rec := range s.photos {
+		if rec.photo.VenueID == venueID && rec.photo.Status == PhotoApproved {
+			out = append(out, rec.photo)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out
+}
+
+// ListQuarantined returns photos awaiting admin review, oldest first.
+func (s *PhotoService) ListQuarantined() []model.Photo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := []model.Photo{}
+	for _, rec := range s.photos {
+		if rec.photo.Status == PhotoQuarantined {
+			out = append(out, rec.photo)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
+
+// MergeUploader credits fromID's photos to toID and returns their IDs; with
+// dryRun nothing changes.
+func (s *PhotoService) MergeUploader(fromID, toID string, dryRun bool) []string {
+	s.mu.Lock()
+	ids := []string{}
+	for id, rec := range s.photos {
+		if rec.photo.UploaderID == fromID {
+			ids = append(ids, id)
+			if !dryRun {
+				rec.photo.UploaderID = toID
+			}
+		}
+	}
+	s.mu.Unlock()
+	sort.Strings(ids)
+
+	if !dryRun && s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`UPDATE photos SET uploader_id = $1 WHERE uploader_id = $2`, toID, fromID); err != nil {
+			log.Printf("WARNING: Failed to move photos while merging users: %v", err)
+		}
+	}
+	return ids
+}
+
+// Approve publishes a quarantined photo (admin only).
+func (s *PhotoService) Approve(ctx context.Context, id string) (*model.Photo, error) {
+	return s.review(ctx, id, PhotoApproved)
+}
+
+// Reject removes a photo and discards its image data (admin only).
+func (s *PhotoService) Reject(ctx context.Context, id string) (*model.Photo, error) {
+	return s.review(ctx, id, PhotoRejected)
+}
+
+func (s *PhotoService) review(ctx context.Context, id, status string) (*model.Photo, error) {
+	s.mu.Lock()
+	rec, ok := s.photos[id]
+	if !ok || rec.photo.Status == PhotoRejected {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("photo not found")
+	}
+	if rec.photo.Status == PhotoProcessing {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("photo is still processing")
+	}
+	now := time.Now()
+	rec.photo.Status = status
+	rec.photo.ReviewedAt = &now
+	rec.photo.ReviewedByID = middleware.GetUserID(ctx)
+	s.setURL(&rec.photo)
+	if status == PhotoRejected {
+		rec.data = nil
+	}
+	p := rec.photo
+	s.mu.Unlock()
+
+	s.persistStatus(id)
+	return &p, nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// PII actions, from least to most restrictive.
+const (
+	PIIActionWarn   = "warn"
+	PIIActionRedact = "redact"
+	PIIActionQueue  = "queue"
+)
+
+var piiActionRank = map[string]int{PIIActionWarn: 1, PIIActionRedact: 2, PIIActionQueue: 3}
+
+// PIIPolicy maps a finding kind ("phone", "email", "name") to the action taken
+// when it shows up in a review.
+type PIIPolicy map[string]string
+
+// DefaultPIIPolicy redacts contact details outright and warns about names,
+// which have more false positives.
+var DefaultPIIPolicy = PIIPolicy{
+	"phone": PIIActionRedact,
+	"email": PIIActionRedact,
+	"name":  PIIActionWarn,
+}
+
+// LoadPIIPolicy returns the default policy overridden by PII_ACTIONS,
+// e.g. "phone=redact,email=queue,name=warn".
+func LoadPIIPolicy() PIIPolicy {
+	policy := PIIPolicy{}
+	for k, v := range DefaultPIIPolicy {
+		policy[k] = v
+	}
+	for _, pair := range strings.Split(os.Getenv("PII_ACTIONS"), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kind, action, ok := strings.Cut(pair, "=")
+		kind, action = strings.TrimSpace(kind), strings.TrimSpace(action)
+		if _, known := DefaultPIIPolicy[kind]; !ok || !known || piiActionRank[action] == 0 {
+			log.Printf("WARNING: Ignoring invalid PII_ACTIONS entry %q", pair)
+			continue
+		}
+		policy[kind] = action
+	}
+	return policy
+}
+
+var (
+	piiEmailPattern = regexp.MustCompile(`(?i)\b[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}\b`)
+	piiPhonePattern = regexp.MustCompile(`(?:\+?1[\s.-]?)?\(?\b\d{3}\)?[\s.-]?\d{3}[\s.-]?\d{4}\b`)
+	piiWordPattern  = regexp.MustCompile(`\b[A-Z][a-z]+(?:-[A-Z][a-z]+)?\b`)
+)
+
+// commonFirstNames gates full-name detection: a capitalized pair only counts as
+// a name if the first word is a common first name, so "Mill Ave" and
+// "Happy Hour" don't trip the detector.
+var commonFirstNames = map[string]bool{}
+
+func init() {
+	for _, n := range strings.Fields(`
+		aaron adam alex alexander alexis alyssa amanda amber amy andrea andrew angela anna anthony ashley austin
+		benjamin beth brandon brian brittany brooke caleb cameron carlos caroline catherine charles chloe chris
+		christian christina christopher cody cole connor courtney daniel danielle david derek dylan elizabeth
+		emily emma eric ethan evan gabriel grace hailey hannah heather isaac isabella jack jackson jacob jake
+		james jared jasmine jason jeffrey jennifer jeremy jessica john jonathan jordan jose joseph joshua julia
+		justin kaitlyn katelyn katherine kathryn kayla kelly kevin kyle laura lauren leah logan lucas luis madison
+		marcus maria mark matthew megan melissa michael michelle morgan natalie nathan nicholas nicole noah olivia
+		patrick paul rachel rebecca ryan samantha samuel sarah sean sophia stephanie steven taylor thomas timothy
+		tyler victoria william zachary zoe`) {
+		commonFirstNames[n] = true
+	}
+}
+
+// DetectPII finds phone numbers, email addresses, and likely full names in text.
+func DetectPII(text string) []model.PIIFinding {
+	var findings []model.PIIFinding
+	seen := make(map[string]bool)
+	add := func(kind, match string) {
+		if seen[kind+"\x00"+match] {
+			return
+		}
+		seen[kind+"\x00"+match] = true
+		findings = append(findings, model.PIIFinding{Kind: kind, Text: match})
+	}
+
+	for _, m := range piiEmailPattern.FindAllString(text, -1) {
+		add("email", m)
+	}
+	for _, m := range piiPhonePattern.FindAllString(text, -1) {
+		add("phone", m)
+	}
+	words := piiWordPattern.FindAllStringIndex(text, -1)
+	for i := 0; i+1 < len(words); i++ {
+		first, last := words[i], words[i+1]
+		if strings.TrimSpace(text[first[1]:last[0]]) != "" {
+			continue
+		}
+		if commonFirstNames[strings.ToLower(text[first[0]:first[1]])] {
+			add("name", text[first[0]:last[1]])
+		}
+	}
+	return findings
+}
+
+// applyPIIPolicy assigns each finding its action, redacts review text where the
+// policy says to, and returns the most restrictive action taken ("" if none).
+func applyPIIPolicy(policy PIIPolicy, review string, findings []model.PIIFinding) (string, string) {
+	strongest := ""
+	for i := range findings {
+		action := policy[findings[i].Kind]
+		if action == "" {
+			action = PIIActionWarn
+		}
+		findings[i].Action = action
+		if action == PIIActionRedact {
+			review = strings.ReplaceAll(review, findings[i].Text, blackout(findings[i].Text))
+		}
+		if piiActionRank[action] > piiActionRank[strongest] {
+			strongest = action
+		}
+	}
+	return review, strongest
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// DefaultPreferences are what a user who never changed anything gets: a
+// weekly digest and reply notifications, with marketing mail allowed until
+// they opt out.
+func DefaultPreferences() model.UserPreferences {
+	return model.UserPreferences{EmailDigest: model.DigestWeekly, ReplyNotifications: true}
+}
+
+// checkedPreferences fixes up a stored blob: "daily", which used to be
+// accepted though digests only go out weekly, reads back as weekly.
+func checkedPreferences(prefs model.UserPreferences) model.UserPreferences {
+	if prefs.EmailDigest != model.DigestOff {
+		prefs.EmailDigest = model.DigestWeekly
+	}
+	return prefs
+}
+
+// Preferences returns a user's notification preferences.
+func (s *AuthService) Preferences(userID string) (model.UserPreferences, error) {
+	prefs := DefaultPreferences()
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var raw []byte
+		err := s.pool.QueryRow(ctx,
+			`SELECT preferences FROM users WHERE id = $1 AND anonymized_at IS NULL`, userID).Scan(&raw)
+		if err == pgx.ErrNoRows {
+			return prefs, fmt.Errorf("user not found")
+		}
+		if err != nil {
+			return prefs, fmt.Errorf("failed to get preferences: %w", err)
+		}
+		if err := json.Unmarshal(raw, &prefs); err != nil {
+			return DefaultPreferences(), fmt.Errorf("failed to decode preferences: %w", err)
+		}
+		return checkedPreferences(prefs), nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rec := range s.users {
+		if rec.User.ID == userID && !rec.Anonymized {
+			if rec.Preferences != nil {
+				prefs = *rec.Preferences
+			}
+			return checkedPreferences(prefs), nil
+		}
+	}
+	return prefs, fmt.Errorf("user not found")
+}
+
+// UpdatePreferences changes the given preferences and returns the result.
+func (s *AuthService) UpdatePreferences(userID string, req model.UpdatePreferencesRequest) (model.UserPreferences, error) {
+	if req.EmailDigest != nil {
+		switch *req.EmailDigest {
+		case model.DigestOff, model.DigestWeekly:
+		default:
+			return model.UserPreferences{}, fmt.Errorf("email_digest must be off or weekly")
+		}
+	}
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// Merging only the given keys keeps concurrent updates to different
+		// preferences from overwriting each other.
+		patch, err := json.Marshal(req)
+		if err != nil {
+			return model.UserPreferences{}, fmt.Errorf("failed to encode preferences: %w", err)
+		}
+		var raw []byte
+		err = s.pool.QueryRow(ctx,
+			`UPDATE users SET preferences = preferences || $1::jsonb
+			 WHERE id = $2 AND anonymized_at IS NULL RETURNING preferences`, patch, userID).Scan(&raw)
+		if err == pgx.ErrNoRows {
+			return model.UserPreferences{}, fmt.Errorf("user not found")
+		}
+		if err != nil {
+			return model.UserPreferences{}, fmt.Errorf("failed to update preferences: %w", err)
+		}
+		prefs := DefaultPreferences()
+		if err := json.Unmarshal(raw, &prefs); err != nil {
+			return model.UserPreferences{}, fmt.Errorf("failed to decode preferences: %w", err)
+		}
+		return checkedPreferences(prefs), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rec := range s.users {
+		if rec.User.ID != userID || rec.Anonymized {
+			continue
+		}
+		prefs := DefaultPreferences()
+		if rec.Preferences != nil {
+			prefs = *rec.Preferences
+		}
+		if req.EmailDigest != nil {
+			prefs.EmailDigest = *req.EmailDigest
+		}
+		if req.ReplyNotifications != nil {
+			prefs.ReplyNotifications = *req.ReplyNotifications
+		}
+		if req.MarketingOptOut != nil {
+			prefs.MarketingOptOut = *req.MarketingOptOut
+		}
+		prefs = checkedPreferences(prefs)
+		rec.Preferences = &prefs
+		return prefs, nil
+	}
+	return model.UserPreferences{}, fmt.Errorf("user not found")
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// Profile field limits.
+const (
+	maxDisplayNameLen = 50
+	maxBioLen         = 280
+	maxAvatarURLLen   = 500
+)
+
+// UpdateProfile changes a user's display name, avatar URL and bio. Omitted
+// fields are left alone; empty strings clear them. Callers sanitize
+// display_name and bio for HTML first.
+func (s *AuthService) UpdateProfile(userID string, req model.UpdateProfileRequest) (*model.User, error) {
+	var sets []string
+	var args []interface{}
+	if req.DisplayName != nil {
+		name := strings.Join(strings.Fields(*req.DisplayName), " ")
+		if utf8.RuneCountInString(name) > maxDisplayNameLen {
+			return nil, fmt.Errorf("display_name must be at most %d characters", maxDisplayNameLen)
+		}
+		req.DisplayName = &name
+		args = append(args, name)
+		sets = append(sets, fmt.Sprintf("display_name = NULLIF($%d, '')", len(args)))
+	}
+	if req.AvatarURL != nil {
+		avatar := strings.TrimSpace(*req.AvatarURL)
+		if avatar != "" {
+			u, err := url.Parse(avatar)
+			if err != nil || u.Scheme != "https" || u.Host == "" || len(avatar) > maxAvatarURLLen || strings.ContainsAny(avatar, " \t\r\n") {
+				return nil, fmt.Errorf("avatar_url must be an https URL of at most %d characters", maxAvatarURLLen)
+			}
+		}
+		req.AvatarURL = &avatar
+		args = append(args, avatar)
+		sets = append(sets, fmt.Sprintf("avatar_url = NULLIF($%d, '')", len(args)))
+	}
+	if req.Bio != nil {
+		bio := strings.TrimSpace(*req.Bio)
+		if utf8.RuneCountInString(bio) > maxBioLen {
+			return nil, fmt.Errorf("bio must be at most %d characters", maxBioLen)
+		}
+		req.Bio = &bio
+		args = append(args, bio)
+		sets = append(sets, fmt.Sprintf("bio = NULLIF($%d, '')", len(args)))
+	}
+	if len(sets) == 0 {
+		return s.GetUser(userID)
+	}
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		args = append(args, userID)
+		tag, err := s.pool.Exec(ctx,
+			fmt.Sprintf(`UPDATE users SET %s WHERE id = $%d AND anonymized_at IS NULL`, strings.Join(sets, ", "), len(args)),
+			args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update profile: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, fmt.Errorf("user not found")
+		}
+		return s.GetUser(userID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rec := range s.users {
+		if rec.User.ID == userID && !rec.Anonymized {
+			if req.DisplayName != nil {
+				rec.User.DisplayName = *req.DisplayName
+			}
+			if req.AvatarURL != nil {
+				rec.User.AvatarURL = *req.AvatarURL
+			}
+			if req.Bio != nil {
+				rec.User.Bio = *req.Bio
+			}
+			u := rec.User
+			u.TermsCurrent = u.TermsVersion == CurrentTermsVersion()
+			return &u, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+// PublicProfile returns the public fields of a user's profile. Anonymized
+// accounts are reported as not found. Review counts and recent reviews are
+// filled in by the caller.
+func (s *AuthService) PublicProfile(userID string) (*model.PublicProfile, error) {
+	p := &model.PublicProfile{ID: userID, RecentReviews: []model.ProfileReview{}}
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var verifiedAt *time.Time
+		err := s.reader().QueryRow(ctx,
+			`SELECT username, COALESCE(display_name,''), COALESCE(avatar_url,''), COALESCE(bio,''), created_at, student_verified_at
+			 FROM users WHERE id = $1 AND anonymized_at IS NULL`, userID,
+		).Scan(&p.Username, &p.DisplayName, &p.AvatarURL, &p.Bio, &p.MemberSince, &verifiedAt)
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("user not found")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		p.VerifiedStudent = verifiedAt != nil
+		return p, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rec := range s.users {
+		if rec.User.ID == userID && !rec.Anonymized {
+			p.Username = rec.User.Username
+			p.DisplayName = rec.User.DisplayName
+			p.AvatarURL = rec.User.AvatarURL
+			p.Bio = rec.User.Bio
+			p.MemberSince = rec.User.CreatedAt
+			p.VerifiedStudent = rec.User.StudentVerifiedAt != nil
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+// SetAvatarURL saves the URL of an avatar this server stored, skipping the
+// https check UpdateProfile applies to user-supplied URLs (local storage in
+// development serves over plain http).
+func (s *AuthService) SetAvatarURL(userID, avatarURL string) (*model.User, error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		tag, err := s.pool.Exec(ctx,
+			`UPDATE users SET avatar_url = $1 WHERE id = $2 AND anonymized_at IS NULL`, avatarURL, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update profile: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, fmt.Errorf("user not found")
+		}
+		return s.GetUser(userID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rec := range s.users {
+		if rec.User.ID == userID && !rec.Anonymized {
+			rec.User.AvatarURL = avatarURL
+			u := rec.User
+			u.TermsCurrent = u.TermsVersion == CurrentTermsVersion()
+			return &u, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found")
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// PromotionService manages admin-created sponsored venue slots.
+type PromotionService struct {
+	mu         sync.RWMutex
+	pool       *pgxpool.Pool
+	promotions []model.Promotion
+}
+
+func NewPromotionService(pool *pgxpool.Pool) *PromotionService {
+	svc := &PromotionService{
+		pool:       pool,
+		promotions: []model.Promotion{},
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *PromotionService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, venue_id, school_id, starts_at, ends_at, COALESCE(note,''), created_by, created_at
+		 FROM promotions ORDER BY starts_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load promotions from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p model.Promotion
+		if err := rows.Scan(&p.ID, &p.VenueID, &p.SchoolID, &p.StartsAt, &p.EndsAt, &p.Note, &p.CreatedByID, &p.CreatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan promotion row: %v", err)
+			continue
+		}
+		s.promotions = append(s.promotions, p)
+	}
+	log.Printf("Loaded %d promotions from DB", len(s.promotions))
+}
+
+// Create schedules a sponsored slot for a venue at its school (admin only).
+func (s *PromotionService) Create(ctx context.Context, schoolID string, req model.CreatePromotionRequest) (*model.Promotion, error) {
+	if req.VenueID == "" {
+		return nil, fmt.Errorf("venue_id is required")
+	}
+	if req.StartsAt.IsZero() || req.EndsAt.IsZero() {
+		return nil, fmt.Errorf("starts_at and ends_at are required")
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, fmt.Errorf("ends_at must be after starts_at")
+	}
+
+	p := model.Promotion{
+		ID:          "promo_" + generateID()[:16],
+		VenueID:     req.VenueID,
+		SchoolID:    schoolID,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Note:        middleware.SanitizeString(req.Note),
+		CreatedByID: middleware.GetUserID(ctx),
+		CreatedAt:   time.Now(),
+	}
+
+	s.mu.Lock()
+	for _, existing := range s.promotions {
+		if existing.VenueID == p.VenueID && existing.StartsAt.Before(p.EndsAt) && p.StartsAt.Before(existing.EndsAt) {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("venue already has a promotion overlapping that date range")
+		}
+	}
+	s.promotions = append(s.promotions, p)
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO promotions (id, venue_id, school_id, starts_at, ends_at, note, created_by, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			p.ID, p.VenueID, p.SchoolID, p.StartsAt, p.EndsAt, p.Note, p.CreatedByID, p.CreatedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist promotion: %v", err)
+		}
+	}
+	return &p, nil
+}
+
+// Delete removes a promotion (admin only).
+func (s *PromotionService) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.promotions {
+		if s.promotions[i].ID == id {
+			s.promotions = append(s.promotions[:i], s.promotions[i+1:]...)
+			if s.pool != nil {
+				if _, err := s.pool.Exec(context.Background(), `DELETE FROM promotions WHERE id=$1`, id); err != nil {
+					log.Printf("WARNING: Failed to delete promotion from DB: %v", err)
+				}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("promotion not found")
+}
+
+// List returns all promotions, newest start date first (admin only).
+func (s *PromotionService) List() []model.Promotion {
+	s.mu.RLock()
+	out := append([]model.Promotion{}, s.promotions...)
+	s.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].StartsAt.After(out[j].StartsAt) })
+	return out
+}
+
+// ActiveVenueIDs returns the IDs of venues sponsored at a school right now.
+func (s *PromotionService) ActiveVenueIDs(schoolID string, now time.Time) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ids []string
+	for _, p := range s.promotions {
+		if p.SchoolID == schoolID && !now.Before(p.StartsAt) && now.Before(p.EndsAt) {
+			ids = append(ids, p.VenueID)
+		}
+	}
+	return ids
+}
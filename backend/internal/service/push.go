@@ -0,0 +1,481 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// PushService stores Web Push subscriptions and delivers notifications to them
+// using VAPID-authenticated, aes128gcm-encrypted requests (RFC 8291/8292).
+type PushService struct {
+	mu     sync.RWMutex
+	pool   *pgxpool.Pool
+	subs   map[string]model.PushSubscription // endpoint -> subscription
+	optIn  map[string]bool                   // user_id -> opted in
+	client *http.Client
+
+	vapidKey     *ecdsa.PrivateKey
+	vapidPublic  string // base64url, uncompressed point
+	vapidSubject string
+}
+
+func NewPushService(pool *pgxpool.Pool) (*PushService, error) {
+	svc := &PushService{
+		pool:  pool,
+		subs:  make(map[string]model.PushSubscription),
+		optIn: make(map[string]bool),
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			// Push services answer directly; following a redirect would
+			// leave the allowed hosts.
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}
+
+	svc.vapidSubject = os.Getenv("VAPID_SUBJECT")
+	if svc.vapidSubject == "" {
+		svc.vapidSubject = "mailto:admin@ratemybars.com"
+	}
+	if err := svc.loadVAPIDKey(); err != nil {
+		return nil, err
+	}
+
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc, nil
+}
+
+// loadVAPIDKey reads the VAPID key pair from VAPID_PRIVATE_KEY (base64url raw
+// P-256 scalar). Without it, a key is loaded from or generated into the database;
+// in-memory deployments get a fresh key per boot, which invalidates subscriptions
+// on restart.
+func (s *PushService) loadVAPIDKey() error {
+	raw := os.Getenv("VAPID_PRIVATE_KEY")
+	if raw == "" && s.pool != nil {
+		err := s.pool.QueryRow(context.Background(),
+			`SELECT private_key FROM vapid_keys WHERE id = 1`).Scan(&raw)
+		if err != nil && err != pgx.ErrNoRows {
+			return fmt.Errorf("failed to load VAPID key: %w", err)
+		}
+	}
+
+	if raw != "" {
+		b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(raw, "="))
+		if err != nil {
+			return fmt.Errorf("invalid VAPID private key encoding: %w", err)
+		}
+		key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), b)
+		if err != nil {
+			return fmt.Errorf("invalid VAPID private key: %w", err)
+		}
+		return s.setVAPIDKey(key)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate VAPID key: %w", err)
+	}
+	if err := s.setVAPIDKey(key); err != nil {
+		return err
+	}
+
+	if s.pool != nil {
+		priv, _ := key.Bytes()
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO vapid_keys (id, private_key) VALUES (1, $1) ON CONFLICT (id) DO NOTHING`,
+			base64.RawURLEncoding.EncodeToString(priv))
+		if err != nil {
+			log.Printf("WARNING: Failed to persist generated VAPID key: %v", err)
+		}
+		log.Println("Generated and stored a new VAPID key pair")
+	} else {
+		log.Println("WARNING: VAPID_PRIVATE_KEY not set, using an ephemeral key (push subscriptions will not survive restarts)")
+	}
+	return nil
+}
+
+func (s *PushService) setVAPIDKey(key *ecdsa.PrivateKey) error {
+	pub, err := key.PublicKey.Bytes()
+	if err != nil {
+		return fmt.Errorf("invalid VAPID public key: %w", err)
+	}
+	s.vapidKey = key
+	s.vapidPublic = base64.RawURLEncoding.EncodeToString(pub)
+	return nil
+}
+
+func (s *PushService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT endpoint, user_id, p256dh, auth, created_at FROM push_subscriptions`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load push subscriptions from DB: %v", err)
+		return
+	}
+	for rows.Next() {
+		var sub model.PushSubscription
+		if err := rows.Scan(&sub.Endpoint, &sub.UserID, &sub.Keys.P256dh, &sub.Keys.Auth, &sub.CreatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan push subscription row: %v", err)
+			continue
+		}
+		s.subs[sub.Endpoint] = sub
+	}
+	rows.Close()
+
+	optRows, err := s.pool.Query(context.Background(), `SELECT user_id, enabled FROM push_opt_ins`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load push opt-ins from DB: %v", err)
+		return
+	}
+	defer optRows.Close()
+	for optRows.Next() {
+		var userID string
+		var enabled bool
+		if err := optRows.Scan(&userID, &enabled); err != nil {
+			continue
+		}
+		s.optIn[userID] = enabled
+	}
+	log.Printf("Loaded %d push subscriptions from DB", len(s.subs))
+}
+
+// PublicKey returns the VAPID application server key for PushManager.subscribe.
+func (s *PushService) PublicKey() string {
+	return s.vapidPublic
+}
+
+// pushHosts are the browser push services subscriptions may point at, as
+// exact hosts or (with a leading dot) domain suffixes. The server POSTs to
+// whatever endpoint is stored, so anything else could be used to make it
+// send requests to internal or arbitrary hosts.
+var pushHosts = []string{
+	"fcm.googleapis.com", "android.googleapis.com", // Chrome, Edge, Opera
+	".push.services.mozilla.com", // Firefox
+	".push.apple.com",            // Safari
+	".notify.windows.com",        // legacy Edge (WNS)
+}
+
+// checkPushEndpoint accepts only https URLs on a known push service's
+// default port.
+func checkPushEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return fmt.Errorf("endpoint must be an https URL")
+	}
+	if port := u.Port(); port != "" && port != "443" {
+		return fmt.Errorf("endpoint must be a known push service")
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, h := range pushHosts {
+		if host == h || strings.HasPrefix(h, ".") && strings.HasSuffix(host, h) {
+			return nil
+		}
+	}
+	return fmt.Errorf("endpoint must be a known push service")
+}
+
+// Subscribe stores a browser push subscription for the current user and opts them in.
+func (s *PushService) Subscribe(ctx context.Context, sub model.PushSubscription) error {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return fmt.Errorf("authentication required")
+	}
+
+	if err := checkPushEndpoint(sub.Endpoint); err != nil {
+		return err
+	}
+	p256dh, err := decodeB64(sub.Keys.P256dh)
+	if err != nil || len(p256dh) != 65 {
+		return fmt.Errorf("keys.p256dh must be an uncompressed P-256 public key")
+	}
+	auth, err := decodeB64(sub.Keys.Auth)
+	if err != nil || len(auth) != 16 {
+		return fmt.Errorf("keys.auth must be a 16-byte secret")
+	}
+
+	sub.UserID = userID
+	sub.CreatedAt = time.Now()
+
+	s.mu.Lock()
+	s.subs[sub.Endpoint] = sub
+	s.optIn[userID] = true
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO push_subscriptions (endpoint, user_id, p256dh, auth, created_at) VALUES ($1, $2, $3, $4, $5)
+			 ON CONFLICT (endpoint) DO UPDATE SET user_id = EXCLUDED.user_id, p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth`,
+			sub.Endpoint, sub.UserID, sub.Keys.P256dh, sub.Keys.Auth, sub.CreatedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist push subscription: %v", err)
+		}
+		s.persistOptIn(userID, true)
+	}
+	return nil
+}
+
+// Unsubscribe removes one of the current user's subscriptions.
+func (s *PushService) Unsubscribe(ctx context.Context, endpoint string) error {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return fmt.Errorf("authentication required")
+	}
+
+	s.mu.Lock()
+	sub, ok := s.subs[endpoint]
+	if !ok || sub.UserID != userID {
+		s.mu.Unlock()
+		return fmt.Errorf("subscription not found")
+	}
+	delete(s.subs, endpoint)
+	s.mu.Unlock()
+
+	s.deleteSubscriptionDB(endpoint)
+	return nil
+}
+
+// SetOptIn toggles whether the current user receives push notifications at all.
+func (s *PushService) SetOptIn(ctx context.Context, enabled bool) error {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return fmt.Errorf("authentication required")
+	}
+
+	s.mu.Lock()
+	s.optIn[userID] = enabled
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		s.persistOptIn(userID, enabled)
+	}
+	return nil
+}
+
+// Status returns the current user's opt-in flag and subscription count.
+func (s *PushService) Status(userID string) (enabled bool, subscriptions int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sub := range s.subs {
+		if sub.UserID == userID {
+			subscriptions++
+		}
+	}
+	return s.optIn[userID], subscriptions
+}
+
+func (s *PushService) persistOptIn(userID string, enabled bool) {
+	_, err := s.pool.Exec(context.Background(),
+		`INSERT INTO push_opt_ins (user_id, enabled) VALUES ($1, $2)
+		 ON CONFLICT (user_id) DO UPDATE SET enabled = EXCLUDED.enabled`,
+		userID, enabled)
+	if err != nil {
+		log.Printf("WARNING: Failed to persist push opt-in: %v", err)
+	}
+}
+
+func (s *PushService) deleteSubscriptionDB(endpoint string) {
+	if s.pool == nil {
+		return
+	}
+	_, err := s.pool.Exec(context.Background(),
+		`DELETE FROM push_subscriptions WHERE endpoint=$1`, endpoint)
+	if err != nil {
+		log.Printf("WARNING: Failed to delete push subscription from DB: %v", err)
+	}
+}
+
+// NotifyUsers sends a notification to every subscription of the given users
+// who have opted in. Delivery happens in the background.
+func (s *PushService) NotifyUsers(userIDs []string, msg model.PushMessage) {
+	want := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		want[id] = true
+	}
+
+	s.mu.RLock()
+	var targets []model.PushSubscription
+	for _, sub := range s.subs {
+		if want[sub.UserID] && s.optIn[sub.UserID] {
+			targets = append(targets, sub)
+		}
+	}
+	s.mu.RUnlock()
+
+	if len(targets) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	go func() {
+		for _, sub := range targets {
+			if err := s.send(sub, payload); err != nil {
+				log.Printf("WARNING: Web push to %s failed: %v", sub.UserID, err)
+			}
+		}
+	}()
+}
+
+// send delivers one encrypted message. Subscriptions the push service reports
+// as gone (404/410) are removed.
+func (s *PushService) send(sub model.PushSubscription, payload []byte) error {
+	// Subscriptions stored before endpoints were checked are skipped too.
+	if err := checkPushEndpoint(sub.Endpoint); err != nil {
+		return err
+	}
+	body, err := encryptPushPayload(sub, payload)
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(sub.Endpoint)
+	if err != nil {
+		return err
+	}
+	claims := jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": s.vapidSubject,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(s.vapidKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", "86400")
+	req.Header.Set("Authorization", "vapid t="+token+", k="+s.vapidPublic)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		s.mu.Lock()
+		delete(s.subs, sub.Endpoint)
+		s.mu.Unlock()
+		s.deleteSubscriptionDB(sub.Endpoint)
+		return nil
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("push service returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// encryptPushPayload implements the aes128gcm content encoding for Web Push (RFC 8291).
+func encryptPushPayload(sub model.PushSubscription, plaintext []byte) ([]byte, error) {
+	uaPublicBytes, err := decodeB64(sub.Keys.P256dh)
+	if err != nil {
+		return nil, err
+	}
+	authSecret, err := decodeB64(sub.Keys.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	curve := ecdh.P256()
+	uaPublic, err := curve.NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+	asPrivate, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, string(keyInfo), 32)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Single record: payload followed by the 0x02 last-record delimiter.
+	record := append(append([]byte{}, plaintext...), 0x02)
+	ciphertext := gcm.Seal(nil, nonce, record, nil)
+
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, 4096)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	return append(header, ciphertext...), nil
+}
+
+func decodeB64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	if b, err := base64.RawURLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return base64.RawStdEncoding.DecodeString(s)
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// preparedQueries are the statements run on (nearly) every request. They are
+// prepared by name on each connection the first time it runs them.
+var preparedQueries = map[string]string{
+	"session_touch":     `UPDATE sessions SET last_seen_at = $2 WHERE token_hash = $1 RETURNING ` + sessionColumns,
+	"user_ban":          `SELECT banned_until, COALESCE(ban_reason,'') FROM users WHERE id = $1`,
+	"user_token_cutoff": `SELECT tokens_valid_after FROM users WHERE id = $1`,
+	"user_login":        `SELECT id, username, role, password_hash, created_at FROM users WHERE email = $1`,
+	"user_by_id": `SELECT id, username, role, created_at, age_confirmed_at, COALESCE(age_jurisdiction,''),
+		        COALESCE(terms_version,''), terms_accepted_at, COALESCE(home_school_id,''), COALESCE(grad_year,0),
+		        COALESCE(school_id,''), COALESCE(student_email,''), student_verified_at,
+		        COALESCE(display_name,''), COALESCE(avatar_url,''), COALESCE(bio,''),
+		        banned_until, COALESCE(ban_reason,'')
+		 FROM users WHERE id = $1`,
+}
+
+const defaultSlowQuery = 200 * time.Millisecond
+
+// LoadSlowQueryThreshold reads SLOW_QUERY_MS (default 200).
+func LoadSlowQueryThreshold() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("SLOW_QUERY_MS")); err == nil && v > 0 {
+		return time.Duration(v) * time.Millisecond
+	}
+	return defaultSlowQuery
+}
+
+// queryStat accumulates latency for one named query.
+type queryStat struct {
+	calls  int64
+	errors int64
+	slow   int64
+	total  time.Duration
+	max    time.Duration
+}
+
+// queryMetrics is shared by every Queries in the process, so the admin view
+// covers all services.
+var queryMetrics = struct {
+	mu    sync.Mutex
+	slow  time.Duration
+	stats map[string]*queryStat
+}{slow: defaultSlowQuery, stats: make(map[string]*queryStat)}
+
+// SetSlowQueryThreshold sets how long a named query may take before it is
+// logged.
+func SetSlowQueryThreshold(d time.Duration) {
+	queryMetrics.mu.Lock()
+	queryMetrics.slow = d
+	queryMetrics.mu.Unlock()
+}
+
+func recordQuery(name string, d time.Duration, err error) {
+	queryMetrics.mu.Lock()
+	st := queryMetrics.stats[name]
+	if st == nil {
+		st = &queryStat{}
+		queryMetrics.stats[name] = st
+	}
+	st.calls++
+	st.total += d
+	st.max = max(st.max, d)
+	if err != nil && err != pgx.ErrNoRows {
+		st.errors++
+	}
+	slow := d >= queryMetrics.slow
+	if slow {
+		st.slow++
+	}
+	queryMetrics.mu.Unlock()
+
+	if slow {
+		log.Printf("WARNING: Slow query %s took %s", name, d.Round(time.Millisecond))
+	}
+}
+
+// QueryStats returns per-query latency since startup, slowest in total first.
+func QueryStats() model.QueryStats {
+	queryMetrics.mu.Lock()
+	defer queryMetrics.mu.Unlock()
+
+	out := model.QueryStats{
+		SlowThresholdMs: queryMetrics.slow.Milliseconds(),
+		Queries:         []model.QueryStat{},
+	}
+	for name, st := range queryMetrics.stats {
+		out.Queries = append(out.Queries, model.QueryStat{
+			Name:    name,
+			Calls:   st.calls,
+			Errors:  st.errors,
+			Slow:    st.slow,
+			TotalMs: float64(st.total.Microseconds()) / 1000,
+			AvgMs:   float64(st.total.Microseconds()) / 1000 / float64(st.calls),
+			MaxMs:   float64(st.max.Microseconds()) / 1000,
+		})
+	}
+	sort.Slice(out.Queries, func(i, j int) bool { return out.Queries[i].TotalMs > out.Queries[j].TotalMs })
+	return out
+}
+
+// Queries runs preparedQueries by name on a pool, timing each call.
+type Queries struct {
+	pool *pgxpool.Pool
+}
+
+// NewQueries returns nil without a pool, matching in-memory services.
+func NewQueries(pool *pgxpool.Pool) *Queries {
+	if pool == nil {
+		return nil
+	}
+	return &Queries{pool: pool}
+}
+
+// acquire checks out a connection with the named statement prepared on it.
+func (q *Queries) acquire(ctx context.Context, name string) (*pgxpool.Conn, error) {
+	sql, ok := preparedQueries[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown query %q", name)
+	}
+	conn, err := q.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// A no-op once this connection has it
+	if _, err := conn.Conn().Prepare(ctx, name, sql); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("failed to prepare %s: %w", name, err)
+	}
+	return conn, nil
+}
+
+// QueryRow runs a named query; the connection is returned on Scan.
+func (q *Queries) QueryRow(ctx context.Context, name string, args ...any) pgx.Row {
+	start := time.Now()
+	conn, err := q.acquire(ctx, name)
+	if err != nil {
+		recordQuery(name, time.Since(start), err)
+		return errRow{err}
+	}
+	return &preparedRow{name: name, start: start, conn: conn, row: conn.QueryRow(ctx, name, args...)}
+}
+
+type preparedRow struct {
+	name  string
+	start time.Time
+	conn  *pgxpool.Conn
+	row   pgx.Row
+}
+
+func (r *preparedRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	r.conn.Release()
+	recordQuery(r.name, time.Since(r.start), err)
+	return err
+}
+
+type errRow struct{ err error }
+
+func (r errRow) Scan(...any) error { return r.err }
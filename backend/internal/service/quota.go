@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LoadRatingDailyLimit reads the combined per-user daily rating budget from
+// RATING_DAILY_LIMIT, falling back to maxRatingsPerDay.
+func LoadRatingDailyLimit() int {
+	if v, err := strconv.Atoi(os.Getenv("RATING_DAILY_LIMIT")); err == nil && v > 0 {
+		return v
+	}
+	return maxRatingsPerDay
+}
+
+type dailyCount struct {
+	count int
+	date  string // YYYY-MM-DD
+}
+
+// QuotaService enforces a single daily rating budget per user across every
+// rating type (venues, fraternities), so spreading submissions over several
+// endpoints doesn't multiply the cap. Counts are persisted so a restart
+// doesn't reset anyone's budget mid-day.
+type QuotaService struct {
+	mu     sync.Mutex
+	pool   *pgxpool.Pool
+	limit  int
+	counts map[string]*dailyCount
+}
+
+func NewQuotaService(pool *pgxpool.Pool, limit int) *QuotaService {
+	if limit <= 0 {
+		limit = maxRatingsPerDay
+	}
+	svc := &QuotaService{
+		pool:   pool,
+		limit:  limit,
+		counts: make(map[string]*dailyCount),
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *QuotaService) loadFromDB() {
+	today := quotaDay(time.Now())
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT user_id, count FROM rating_quotas WHERE day = $1`, today)
+	if err != nil {
+		log.Printf("WARNING: Failed to load rating quotas from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			log.Printf("WARNING: Failed to scan rating quota row: %v", err)
+			continue
+		}
+		s.counts[userID] = &dailyCount{count: count, date: today}
+	}
+	log.Printf("Loaded %d rating quotas from DB", len(s.counts))
+}
+
+func quotaDay(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// Limit returns the combined daily budget.
+func (s *QuotaService) Limit() int {
+	return s.limit
+}
+
+// Remaining returns how many more ratings the user may submit today.
+func (s *QuotaService) Remaining(userID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dc, ok := s.counts[userID]
+	if !ok || dc.date != quotaDay(time.Now()) {
+		return s.limit
+	}
+	return max(s.limit-dc.count, 0)
+}
+
+// Consume takes one rating from the user's budget for today, or returns an
+// error if it is already spent. Callers should run all other validation
+// first so rejected submissions don't use up the budget.
+func (s *QuotaService) Consume(userID string) error {
+	today := quotaDay(time.Now())
+
+	s.mu.Lock()
+	dc, ok := s.counts[userID]
+	if !ok || dc.date != today {
+		dc = &dailyCount{date: today}
+		s.counts[userID] = dc
+	}
+	if dc.count >= s.limit {
+		s.mu.Unlock()
+		return fmt.Errorf("daily rating limit reached (%d per day)", s.limit)
+	}
+	dc.count++
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO rating_quotas (user_id, day, count) VALUES ($1, $2, 1)
+			 ON CONFLICT (user_id, day) DO UPDATE SET count = rating_quotas.count + 1`,
+			userID, today)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist rating quota: %v", err)
+		}
+	}
+	return nil
+}
+
+// Start periodically drops counters from previous days.
+func (s *QuotaService) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.purge()
+		}
+	}()
+}
+
+func (s *QuotaService) purge() {
+	today := quotaDay(time.Now())
+	s.mu.Lock()
+	for userID, dc := range s.counts {
+		if dc.date != today {
+			delete(s.counts, userID)
+		}
+	}
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`DELETE FROM rating_quotas WHERE day < $1`, today); err != nil {
+			log.Printf("WARNING: Failed to purge rating quotas: %v", err)
+		}
+	}
+}
@@ -2,8 +2,15 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,6 +21,23 @@ import (
 
 const maxRatingsPerDay = 20
 
+// defaultRatingEditWindow is how long after posting a rating its author may
+// update it by re-submitting.
+const defaultRatingEditWindow = 48 * time.Hour
+
+// ErrEditWindowClosed is returned when an author re-submits a rating after
+// its edit window.
+var ErrEditWindowClosed = errors.New("edit window has closed for this rating")
+
+// LoadRatingEditWindow reads RATING_EDIT_WINDOW_HOURS (default 48). Zero
+// disables edits.
+func LoadRatingEditWindow() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("RATING_EDIT_WINDOW_HOURS")); err == nil && v >= 0 {
+		return time.Duration(v) * time.Hour
+	}
+	return defaultRatingEditWindow
+}
+
 // RatingService manages rating CRUD with spam prevention.
 type RatingService struct {
 	mu      sync.RWMutex
@@ -21,31 +45,125 @@ type RatingService struct {
 	ratings []model.Rating
 	nextID  int
 
-	userDailyCounts map[string]*dailyCount
+	quota     *QuotaService
+	reactions map[reactionKey]bool
+
+	// pending holds ratings queued for moderation by the PII policy.
+	pending   []model.Rating
+	piiPolicy PIIPolicy
+
+	taxonomy *TaxonomyService
+
+	thumbs ThumbsConfig
+
+	// checkIns badges ratings made shortly after a geofenced check-in.
+	checkIns       *CheckInService
+	verifiedWeight float64
+
+	// profiles supplies authors' graduation year for cohort breakdowns.
+	profiles *AuthService
+
+	// editWindow is how long authors may update a rating by re-submitting.
+	editWindow time.Duration
+
+	// credibility orders "top" reviews; optional.
+	credibility *CredibilityService
+	// isStudent flags reviews by verified students; optional.
+	isStudent StudentVerifier
+
+	// velocity watches for review bombing and freezes targets; optional.
+	velocity *VelocityService
+
+	// locks holds admin locks on venues; optional.
+	locks *RatingLockService
+
+	// duplicates flags review text copy-pasted across accounts; optional.
+	duplicates *DuplicateTextService
+
+	// sentiment scores review text as it's published; optional.
+	sentiment *SentimentService
+
+	index *reviewIndex
+
+	// cache replaces ratings and index when RATING_CACHE_VENUES is set: only
+	// venue aggregates and recently used venues' ratings stay in memory.
+	cache *ratingCache
 }
 
-type dailyCount struct {
-	count int
-	date  string // YYYY-MM-DD
+// reactionKey identifies a single user's reaction on a review.
+type reactionKey struct {
+	RatingID string
+	UserID   string
+	Reaction string
 }
 
-func NewRatingService(pool *pgxpool.Pool) *RatingService {
+// validReactions maps reaction names to the emoji clients may also submit.
+var validReactions = map[string]string{
+	"fire":  "🔥",
+	"skull": "💀",
+	"beers": "🍻",
+}
+
+const maxTagsPerRating = 5
+
+// normalizeTags lowercases, dedupes, and validates review tags against the
+// tag taxonomy.
+func normalizeTags(tags []string, taxonomy *TaxonomyService) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	seen := make(map[string]bool, len(tags))
+	var out []string
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		if !taxonomy.Valid(TaxonomyTag, t) {
+			return nil, fmt.Errorf("invalid tag: %s", t)
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	if len(out) > maxTagsPerRating {
+		return nil, fmt.Errorf("at most %d tags per rating", maxTagsPerRating)
+	}
+	return out, nil
+}
+
+// NewRatingService loads ratings from pool, if set. With cacheVenues > 0 (and
+// a pool) only that many venues' ratings are kept in memory at a time.
+func NewRatingService(pool *pgxpool.Pool, cacheVenues int) *RatingService {
 	svc := &RatingService{
-		pool:            pool,
-		ratings:         []model.Rating{},
-		nextID:          1,
-		userDailyCounts: make(map[string]*dailyCount),
+		pool:       pool,
+		ratings:    []model.Rating{},
+		nextID:     1,
+		quota:      NewQuotaService(nil, maxRatingsPerDay),
+		reactions:  make(map[reactionKey]bool),
+		piiPolicy:  DefaultPIIPolicy,
+		thumbs:     DefaultThumbsConfig,
+		editWindow: defaultRatingEditWindow,
+		index:      newReviewIndex(),
 	}
-	if pool != nil {
+	if pool != nil && cacheVenues > 0 {
+		svc.cache = newRatingCache(cacheVenues)
+		maxID := svc.loadAggregates()
+		svc.loadPendingFromDB()
+		for _, r := range svc.pending {
+			maxID = max(maxID, ratingIDNumber(r.ID))
+		}
+		svc.nextID = maxID + 1
+	} else if pool != nil {
 		svc.loadFromDB()
+		svc.loadReactionsFromDB()
+		svc.loadPendingFromDB()
 	}
 	return svc
 }
 
 func (s *RatingService) loadFromDB() {
 	rows, err := s.pool.Query(context.Background(),
-		`SELECT id, score, COALESCE(review,''), venue_id, author_id, COALESCE(author_name,''), created_at, upvotes, downvotes
-		 FROM ratings ORDER BY created_at`)
+		`SELECT `+ratingColumns+` FROM ratings ORDER BY created_at`)
 	if err != nil {
 		log.Printf("WARNING: Failed to load ratings from DB: %v", err)
 		return
@@ -53,77 +171,522 @@ func (s *RatingService) loadFromDB() {
 	defer rows.Close()
 
 	for rows.Next() {
-		var r model.Rating
-		if err := rows.Scan(&r.ID, &r.Score, &r.Review, &r.VenueID, &r.AuthorID, &r.AuthorName, &r.CreatedAt, &r.Upvotes, &r.Downvotes); err != nil {
+		r, err := scanRating(rows)
+		if err != nil {
 			log.Printf("WARNING: Failed to scan rating row: %v", err)
 			continue
 		}
 		s.ratings = append(s.ratings, r)
+		s.index.add(r)
 		s.nextID++
 	}
 	log.Printf("Loaded %d ratings from DB", len(s.ratings))
 }
 
-// Create adds a new rating with spam prevention.
+func (s *RatingService) loadReactionsFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT rating_id, user_id, reaction FROM review_reactions`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load review reactions from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	idx := make(map[string]int, len(s.ratings))
+	for i := range s.ratings {
+		idx[s.ratings[i].ID] = i
+	}
+
+	for rows.Next() {
+		var k reactionKey
+		if err := rows.Scan(&k.RatingID, &k.UserID, &k.Reaction); err != nil {
+			log.Printf("WARNING: Failed to scan review reaction row: %v", err)
+			continue
+		}
+		i, ok := idx[k.RatingID]
+		if !ok {
+			continue
+		}
+		s.reactions[k] = true
+		if s.ratings[i].Reactions == nil {
+			s.ratings[i].Reactions = make(map[string]int)
+		}
+		s.ratings[i].Reactions[k.Reaction]++
+	}
+}
+
+func (s *RatingService) loadPendingFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, score, COALESCE(review,''), COALESCE(tags,'{}'), venue_id, author_id, COALESCE(author_name,''), pii, created_at, verified_visit, would_recommend, COALESCE(author_grad_year, 0), imported
+		 FROM pending_ratings ORDER BY created_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load pending ratings from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r model.Rating
+		var pii []byte
+		if err := rows.Scan(&r.ID, &r.Score, &r.Review, &r.Tags, &r.VenueID, &r.AuthorID, &r.AuthorName, &pii, &r.CreatedAt, &r.VerifiedVisit, &r.WouldRecommend, &r.AuthorGradYear, &r.Imported); err != nil {
+			log.Printf("WARNING: Failed to scan pending rating row: %v", err)
+			continue
+		}
+		if len(pii) > 0 {
+			json.Unmarshal(pii, &r.PII)
+		}
+		r.PendingReview = true
+		s.pending = append(s.pending, r)
+		s.nextID++
+	}
+}
+
+// SetPIIPolicy configures what happens when a review contains personal information.
+func (s *RatingService) SetPIIPolicy(policy PIIPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.piiPolicy = policy
+}
+
+// SetTaxonomy sets where valid review tags are looked up.
+func (s *RatingService) SetTaxonomy(taxonomy *TaxonomyService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.taxonomy = taxonomy
+}
+
+// SetThumbsConfig sets the score thresholds for thumbs up and down.
+func (s *RatingService) SetThumbsConfig(cfg ThumbsConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.thumbs = cfg
+}
+
+// SetProfiles lets the service snapshot authors' class year onto ratings for
+// cohort breakdowns.
+func (s *RatingService) SetProfiles(profiles *AuthService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles = profiles
+}
+
+// SetQuota shares a daily rating budget with other rating types.
+func (s *RatingService) SetQuota(quota *QuotaService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quota = quota
+}
+
+// SetCredibility enables the "top" review ordering.
+func (s *RatingService) SetCredibility(credibility *CredibilityService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.credibility = credibility
+}
+
+// SetStudentVerifier flags listed ratings whose author is a verified student.
+func (s *RatingService) SetStudentVerifier(fn StudentVerifier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.isStudent = fn
+}
+
+// markStudent sets VerifiedStudent on a listed copy. Callers hold s.mu.
+func (s *RatingService) markStudent(r *model.Rating) {
+	if s.isStudent != nil {
+		r.VerifiedStudent = s.isStudent(r.AuthorID)
+	}
+}
+
+// SetVelocity enables rating spike detection and freezes.
+func (s *RatingService) SetVelocity(velocity *VelocityService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.velocity = velocity
+}
+
+// SetDuplicates checks published review text for copies by other accounts.
+func (s *RatingService) SetDuplicates(duplicates *DuplicateTextService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.duplicates = duplicates
+}
+
+// SetSentiment scores published and edited reviews as they come in.
+func (s *RatingService) SetSentiment(sentiment *SentimentService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sentiment = sentiment
+}
+
+// observeTextLocked passes published review text to duplicate detection and
+// sentiment scoring. Caller holds s.mu.
+func (s *RatingService) observeTextLocked(rating model.Rating) {
+	if s.duplicates != nil {
+		s.duplicates.Observe(rating)
+	}
+	if s.sentiment != nil {
+		s.sentiment.Observe(rating)
+	}
+}
+
+// SetLocks makes admin rating locks refuse new ratings.
+func (s *RatingService) SetLocks(locks *RatingLockService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locks = locks
+}
+
+// SetEditWindow sets how long after posting a rating its author may update it.
+func (s *RatingService) SetEditWindow(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.editWindow = d
+}
+
+// SetCheckIns enables "verified visit" badges for ratings made shortly after
+// a geofenced check-in, and their extra weight in venue averages.
+func (s *RatingService) SetCheckIns(checkIns *CheckInService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkIns = checkIns
+	s.verifiedWeight = checkIns.Config().Weight
+}
+
+// weight is how much a rating counts toward venue averages. Callers must
+// hold s.mu.
+func (s *RatingService) weight(r model.Rating) float64 {
+	if r.VerifiedVisit && s.verifiedWeight > 1 {
+		return s.verifiedWeight
+	}
+	return 1
+}
+
+// Create adds a new rating with spam prevention. If the author has already
+// rated the venue, it updates that rating instead while the edit window is
+// open; imported ratings never overwrite one.
 func (s *RatingService) Create(ctx context.Context, req model.CreateRatingRequest) (*model.Rating, error) {
 	userID := middleware.GetUserID(ctx)
 	if userID == "" {
 		return nil, fmt.Errorf("authentication required")
 	}
 
-	if req.Score < 1 || req.Score > 5 {
-		return nil, fmt.Errorf("score must be between 1 and 5")
+	if err := ValidateScore(req.Score); err != nil {
+		return nil, err
 	}
 
 	if req.VenueID == "" {
 		return nil, fmt.Errorf("venue_id is required")
 	}
 
+	s.mu.RLock()
+	taxonomy, checkIns, profiles, velocity, locks := s.taxonomy, s.checkIns, s.profiles, s.velocity, s.locks
+	s.mu.RUnlock()
+	if locks != nil {
+		if err := locks.Check(RatingTargetVenue, req.VenueID); err != nil {
+			return nil, err
+		}
+	}
+	if velocity != nil {
+		if err := velocity.CheckFrozen(RatingTargetVenue, req.VenueID); err != nil {
+			return nil, err
+		}
+	}
+	tags, err := normalizeTags(req.Tags, taxonomy)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	verified := checkIns != nil && checkIns.HasVerifiedVisit(userID, req.VenueID, now)
+	gradYear := 0
+	if profiles != nil {
+		if user, err := profiles.GetUser(userID); err == nil {
+			gradYear = user.GradYear
+		}
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	today := time.Now().Format("2006-01-02")
-	dc, exists := s.userDailyCounts[userID]
-	if exists && dc.date == today && dc.count >= maxRatingsPerDay {
-		return nil, fmt.Errorf("daily rating limit reached (%d per day)", maxRatingsPerDay)
+	for _, r := range s.scopeLocked(req.VenueID) {
+		if r.VenueID == req.VenueID && r.AuthorID == userID {
+			if req.Imported {
+				return nil, fmt.Errorf("you have already rated this venue")
+			}
+			return s.updateLocked(r, req, tags, verified, now)
+		}
 	}
 
-	for _, r := range s.ratings {
+	for _, r := range s.pending {
 		if r.VenueID == req.VenueID && r.AuthorID == userID {
-			return nil, fmt.Errorf("you have already rated this venue")
+			return nil, fmt.Errorf("your rating for this venue is awaiting moderation")
 		}
 	}
 
+	if !req.Imported {
+		if err := s.quota.Consume(userID); err != nil {
+			return nil, err
+		}
+	}
+	if velocity != nil {
+		velocity.Observe(RatingTargetVenue, req.VenueID, now)
+	}
+
 	rating := model.Rating{
 		ID:         fmt.Sprintf("rating_%d", s.nextID),
 		Score:      req.Score,
 		Review:     middleware.SanitizeString(req.Review),
+		Tags:       tags,
 		VenueID:    req.VenueID,
 		AuthorID:   userID,
 		AuthorName: middleware.GetUsername(ctx),
-		CreatedAt:  time.Now(),
+		CreatedAt:  now,
+
+		VerifiedVisit:  verified,
+		WouldRecommend: req.WouldRecommend,
+		AuthorGradYear: gradYear,
+		Imported:       req.Imported,
 	}
 	s.nextID++
-	s.ratings = append(s.ratings, rating)
 
-	if !exists || dc.date != today {
-		s.userDailyCounts[userID] = &dailyCount{count: 1, date: today}
-	} else {
-		dc.count++
+	findings := DetectPII(rating.Review)
+	review, action := applyPIIPolicy(s.piiPolicy, rating.Review, findings)
+	if review != rating.Review {
+		rating.Review = review
+		rating.Redacted = true
+	}
+
+	if action == PIIActionQueue {
+		rating.PII = findings
+		rating.PendingReview = true
+		s.pending = append(s.pending, rating)
+		if s.pool != nil {
+			pii, _ := json.Marshal(findings)
+			_, err := s.pool.Exec(context.Background(),
+				`INSERT INTO pending_ratings (id, score, review, tags, venue_id, author_id, author_name, pii, created_at, verified_visit, would_recommend, author_grad_year, imported)
+				 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, 0), $13)`,
+				rating.ID, rating.Score, rating.Review, rating.Tags, rating.VenueID, rating.AuthorID, rating.AuthorName, pii, rating.CreatedAt, rating.VerifiedVisit, rating.WouldRecommend, rating.AuthorGradYear, rating.Imported)
+			if err != nil {
+				log.Printf("WARNING: Failed to persist pending rating: %v", err)
+			}
+		}
+		return &rating, nil
+	}
+
+	s.addLocked(rating)
+	s.persistRating(rating)
+	s.observeTextLocked(rating)
+
+	// Tell the author what was detected; the stored rating doesn't carry it.
+	rating.PII = findings
+	return &rating, nil
+}
+
+// updateLocked applies a re-submitted rating to the author's existing one.
+// Edits don't count towards the daily limit. Caller holds s.mu.
+func (s *RatingService) updateLocked(existing model.Rating, req model.CreateRatingRequest, tags []string, verified bool, now time.Time) (*model.Rating, error) {
+	if s.editWindow <= 0 || now.Sub(existing.CreatedAt) > s.editWindow {
+		return nil, ErrEditWindowClosed
 	}
 
+	review := middleware.SanitizeString(req.Review)
+	findings := DetectPII(review)
+	cleaned, action := applyPIIPolicy(s.piiPolicy, review, findings)
+	if action == PIIActionQueue {
+		// Published ratings aren't pulled back into the moderation queue.
+		return nil, fmt.Errorf("your edit includes personal details; remove them and try again")
+	}
+
+	updated := existing
+	updated.Score = req.Score
+	updated.Review = cleaned
+	updated.Redacted = cleaned != review
+	updated.Tags = tags
+	updated.WouldRecommend = req.WouldRecommend
+	updated.VerifiedVisit = existing.VerifiedVisit || verified
+	updated.EditedAt = &now
+
+	s.replaceLocked(existing, updated)
+	s.observeTextLocked(updated)
+
 	if s.pool != nil {
 		_, err := s.pool.Exec(context.Background(),
-			`INSERT INTO ratings (id, score, review, venue_id, author_id, author_name, created_at, upvotes, downvotes)
-			 VALUES ($1, $2, $3, $4, $5, $6, $7, 0, 0)
-			 ON CONFLICT (venue_id, author_id) DO NOTHING`,
-			rating.ID, rating.Score, rating.Review, rating.VenueID, rating.AuthorID, rating.AuthorName, rating.CreatedAt)
+			`UPDATE ratings SET score=$1, review=$2, tags=$3, redacted=$4, verified_visit=$5, would_recommend=$6, edited_at=$7
+			 WHERE id=$8`,
+			updated.Score, updated.Review, updated.Tags, updated.Redacted, updated.VerifiedVisit, updated.WouldRecommend, now, updated.ID)
 		if err != nil {
-			log.Printf("WARNING: Failed to persist rating: %v", err)
+			log.Printf("WARNING: Failed to persist rating edit: %v", err)
 		}
 	}
 
-	return &rating, nil
+	updated.PII = findings
+	return &updated, nil
+}
+
+// ListPending returns ratings held for moderation, oldest first (admin only).
+func (s *RatingService) ListPending() []model.Rating {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]model.Rating{}, s.pending...)
+}
+
+// ApprovePending publishes a held rating. Moderators may pass replacement
+// review text (e.g. with the flagged details removed); empty keeps it as is.
+func (s *RatingService) ApprovePending(ratingID, review string) (*model.Rating, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.pending {
+		if s.pending[i].ID != ratingID {
+			continue
+		}
+		rating := s.pending[i]
+		s.pending = append(s.pending[:i], s.pending[i+1:]...)
+		rating.PII = nil
+		rating.PendingReview = false
+		if review != "" && review != rating.Review {
+			rating.Review = middleware.SanitizeString(review)
+			rating.Redacted = true
+		}
+		s.addLocked(rating)
+		s.deletePending(ratingID)
+		s.persistRating(rating)
+		s.observeTextLocked(rating)
+		return &rating, nil
+	}
+	return nil, fmt.Errorf("rating not found")
+}
+
+// RejectPending discards a held rating.
+func (s *RatingService) RejectPending(ratingID string) (*model.Rating, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.pending {
+		if s.pending[i].ID == ratingID {
+			rating := s.pending[i]
+			s.pending = append(s.pending[:i], s.pending[i+1:]...)
+			s.deletePending(ratingID)
+			return &rating, nil
+		}
+	}
+	return nil, fmt.Errorf("rating not found")
+}
+
+func (s *RatingService) deletePending(ratingID string) {
+	if s.pool == nil {
+		return
+	}
+	if _, err := s.pool.Exec(context.Background(), `DELETE FROM pending_ratings WHERE id=$1`, ratingID); err != nil {
+		log.Printf("WARNING: Failed to delete pending rating from DB: %v", err)
+	}
+}
+
+// findLocked returns a published rating by ID. Caller holds s.mu.
+func (s *RatingService) findLocked(ratingID string) (model.Rating, bool) {
+	if s.cache != nil {
+		rs, err := s.queryRatings(`WHERE id = $1`, ratingID)
+		if err != nil {
+			log.Printf("WARNING: Failed to load rating %s: %v", ratingID, err)
+		}
+		if len(rs) == 0 {
+			return model.Rating{}, false
+		}
+		return rs[0], true
+	}
+	for _, r := range s.ratings {
+		if r.ID == ratingID {
+			return r, true
+		}
+	}
+	return model.Rating{}, false
+}
+
+// addLocked publishes a rating. Caller holds s.mu for writing.
+func (s *RatingService) addLocked(rating model.Rating) {
+	if s.cache != nil {
+		s.cache.add(rating)
+		return
+	}
+	s.ratings = append(s.ratings, rating)
+	s.index.add(rating)
+}
+
+// replaceLocked swaps a published rating for an updated copy. Caller holds
+// s.mu for writing.
+func (s *RatingService) replaceLocked(old, updated model.Rating) {
+	if s.cache != nil {
+		s.cache.replace(old, updated)
+		return
+	}
+	for i := range s.ratings {
+		if s.ratings[i].ID == old.ID {
+			s.index.remove(s.ratings[i])
+			s.ratings[i] = updated
+			s.index.add(updated)
+			return
+		}
+	}
+}
+
+// hasReactedLocked reports whether a user already left a reaction. Caller
+// holds s.mu.
+func (s *RatingService) hasReactedLocked(key reactionKey) bool {
+	if s.cache == nil {
+		return s.reactions[key]
+	}
+	var exists bool
+	err := s.pool.QueryRow(context.Background(),
+		`SELECT EXISTS (SELECT 1 FROM review_reactions WHERE rating_id=$1 AND user_id=$2 AND reaction=$3)`,
+		key.RatingID, key.UserID, key.Reaction).Scan(&exists)
+	if err != nil {
+		log.Printf("WARNING: Failed to check review reaction: %v", err)
+	}
+	return exists
+}
+
+func (s *RatingService) persistRating(rating model.Rating) {
+	if s.pool == nil {
+		return
+	}
+	_, err := s.pool.Exec(context.Background(),
+		`INSERT INTO ratings (id, score, review, tags, venue_id, author_id, author_name, created_at, upvotes, downvotes, redacted, verified_visit, would_recommend, author_grad_year, imported)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 0, 0, $9, $10, $11, NULLIF($12, 0), $13)
+		 ON CONFLICT (venue_id, author_id) WHERE author_id <> 'deleted' DO NOTHING`,
+		rating.ID, rating.Score, rating.Review, rating.Tags, rating.VenueID, rating.AuthorID, rating.AuthorName, rating.CreatedAt, rating.Redacted, rating.VerifiedVisit, rating.WouldRecommend, rating.AuthorGradYear, rating.Imported)
+	if err != nil {
+		log.Printf("WARNING: Failed to persist rating: %v", err)
+	}
+}
+
+// Redact blacks out every occurrence of the given words or names in a review,
+// leaving the rest intact. It returns the original review text (for the audit
+// log) and the updated rating.
+func (s *RatingService) Redact(ratingID string, terms []string) (string, *model.Rating, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.findLocked(ratingID)
+	if !ok {
+		return "", nil, fmt.Errorf("rating not found")
+	}
+	redacted, n := redactTerms(existing.Review, terms)
+	if n == 0 {
+		return "", nil, fmt.Errorf("none of the terms appear in the review")
+	}
+	r := existing
+	r.Review = redacted
+	r.Redacted = true
+	s.replaceLocked(existing, r)
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`UPDATE ratings SET review=$1, redacted=TRUE WHERE id=$2`, redacted, ratingID)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist redacted review: %v", err)
+		}
+	}
+	return existing.Review, &r, nil
 }
 
 // Vote allows a user to upvote or downvote a review. Toggle semantics:
@@ -140,18 +703,13 @@ func (s *RatingService) Vote(ctx context.Context, ratingID, direction string) (u
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	idx := -1
-	for i := range s.ratings {
-		if s.ratings[i].ID == ratingID {
-			idx = i
-			break
-		}
-	}
-	if idx == -1 {
+	existing, ok := s.findLocked(ratingID)
+	if !ok {
 		return 0, 0, fmt.Errorf("rating not found")
 	}
+	rating := existing
 
-	if s.ratings[idx].AuthorID == userID {
+	if rating.AuthorID == userID {
 		return 0, 0, fmt.Errorf("cannot vote on your own review")
 	}
 
@@ -168,9 +726,9 @@ func (s *RatingService) Vote(ctx context.Context, ratingID, direction string) (u
 				_, _ = s.pool.Exec(context.Background(),
 					`DELETE FROM review_votes WHERE rating_id=$1 AND user_id=$2`, ratingID, userID)
 				if direction == "up" {
-					s.ratings[idx].Upvotes--
+					rating.Upvotes--
 				} else {
-					s.ratings[idx].Downvotes--
+					rating.Downvotes--
 				}
 			} else {
 				// Different direction: switch vote
@@ -178,11 +736,11 @@ func (s *RatingService) Vote(ctx context.Context, ratingID, direction string) (u
 					`UPDATE review_votes SET direction=$1 WHERE rating_id=$2 AND user_id=$3`,
 					direction, ratingID, userID)
 				if direction == "up" {
-					s.ratings[idx].Upvotes++
-					s.ratings[idx].Downvotes--
+					rating.Upvotes++
+					rating.Downvotes--
 				} else {
-					s.ratings[idx].Downvotes++
-					s.ratings[idx].Upvotes--
+					rating.Downvotes++
+					rating.Upvotes--
 				}
 			}
 		} else {
@@ -191,46 +749,294 @@ func (s *RatingService) Vote(ctx context.Context, ratingID, direction string) (u
 				`INSERT INTO review_votes (rating_id, user_id, direction) VALUES ($1, $2, $3)`,
 				ratingID, userID, direction)
 			if direction == "up" {
-				s.ratings[idx].Upvotes++
+				rating.Upvotes++
 			} else {
-				s.ratings[idx].Downvotes++
+				rating.Downvotes++
 			}
 		}
 
 		// Sync counts back to ratings table
 		_, _ = s.pool.Exec(context.Background(),
 			`UPDATE ratings SET upvotes=$1, downvotes=$2 WHERE id=$3`,
-			s.ratings[idx].Upvotes, s.ratings[idx].Downvotes, ratingID)
+			rating.Upvotes, rating.Downvotes, ratingID)
 	} else {
 		// In-memory only: simple toggle (no per-user tracking without DB)
 		if direction == "up" {
-			s.ratings[idx].Upvotes++
+			rating.Upvotes++
 		} else {
-			s.ratings[idx].Downvotes++
+			rating.Downvotes++
 		}
 	}
 
-	return s.ratings[idx].Upvotes, s.ratings[idx].Downvotes, nil
+	s.replaceLocked(existing, rating)
+	return rating.Upvotes, rating.Downvotes, nil
+}
+
+// VotesByUser lists the helpful votes a user has cast, up to limit. Votes
+// are only tracked per user with a database; in-memory mode returns none.
+func (s *RatingService) VotesByUser(userID string, limit int) []model.UserVote {
+	out := []model.UserVote{}
+	if s.pool == nil {
+		return out
+	}
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT v.rating_id, v.direction, COALESCE(r.venue_id, '') FROM review_votes v
+		 LEFT JOIN ratings r ON r.id = v.rating_id WHERE v.user_id = $1 ORDER BY v.rating_id LIMIT $2`, userID, limit)
+	if err != nil {
+		log.Printf("WARNING: Failed to list votes by user: %v", err)
+		return out
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var v model.UserVote
+		if rows.Scan(&v.RatingID, &v.Direction, &v.VenueID) == nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// React toggles an emoji-style reaction on a review. Each user can leave each
+// reaction at most once per review; reacting again removes it.
+func (s *RatingService) React(ctx context.Context, ratingID, reaction string) (map[string]int, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	name, ok := normalizeReaction(reaction)
+	if !ok {
+		return nil, fmt.Errorf("reaction must be one of 'fire', 'skull', or 'beers'")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.findLocked(ratingID)
+	if !ok {
+		return nil, fmt.Errorf("rating not found")
+	}
+
+	key := reactionKey{RatingID: ratingID, UserID: userID, Reaction: name}
+
+	// Copy-on-write so ratings already handed to readers are never mutated.
+	counts := make(map[string]int, len(existing.Reactions)+1)
+	for k, v := range existing.Reactions {
+		counts[k] = v
+	}
+
+	if s.hasReactedLocked(key) {
+		delete(s.reactions, key)
+		counts[name]--
+		if counts[name] <= 0 {
+			delete(counts, name)
+		}
+		if s.pool != nil {
+			_, err := s.pool.Exec(context.Background(),
+				`DELETE FROM review_reactions WHERE rating_id=$1 AND user_id=$2 AND reaction=$3`,
+				ratingID, userID, name)
+			if err != nil {
+				log.Printf("WARNING: Failed to delete review reaction: %v", err)
+			}
+		}
+	} else {
+		if s.cache == nil {
+			s.reactions[key] = true
+		}
+		counts[name]++
+		if s.pool != nil {
+			_, err := s.pool.Exec(context.Background(),
+				`INSERT INTO review_reactions (rating_id, user_id, reaction) VALUES ($1, $2, $3)
+				 ON CONFLICT DO NOTHING`,
+				ratingID, userID, name)
+			if err != nil {
+				log.Printf("WARNING: Failed to persist review reaction: %v", err)
+			}
+		}
+	}
+
+	updated := existing
+	updated.Reactions = counts
+	s.replaceLocked(existing, updated)
+	return counts, nil
+}
+
+// normalizeReaction accepts either a reaction name or its emoji.
+func normalizeReaction(reaction string) (string, bool) {
+	if _, ok := validReactions[reaction]; ok {
+		return reaction, true
+	}
+	for name, emoji := range validReactions {
+		if emoji == reaction {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Sort orders ratings in place like SortRatings, plus "top": helpful votes
+// weighted by reviewer credibility, when a CredibilityService is set.
+func (s *RatingService) Sort(ratings []model.Rating, mode string) {
+	s.mu.RLock()
+	credibility := s.credibility
+	s.mu.RUnlock()
+
+	if mode == "top" {
+		if credibility != nil {
+			credibility.SortTop(ratings)
+			return
+		}
+		mode = "helpful"
+	}
+	SortRatings(ratings, mode)
+}
+
+// SortRatings orders ratings in place. Supported modes are "recent" (default),
+// "helpful" (net upvotes), "most_reacted", "highest", and "lowest".
+func SortRatings(ratings []model.Rating, mode string) {
+	switch mode {
+	case "helpful":
+		sort.SliceStable(ratings, func(i, j int) bool {
+			return ratings[i].Upvotes-ratings[i].Downvotes > ratings[j].Upvotes-ratings[j].Downvotes
+		})
+	case "most_reacted":
+		sort.SliceStable(ratings, func(i, j int) bool {
+			return totalReactions(ratings[i]) > totalReactions(ratings[j])
+		})
+	case "highest":
+		sort.SliceStable(ratings, func(i, j int) bool {
+			return ratings[i].Score > ratings[j].Score
+		})
+	case "lowest":
+		sort.SliceStable(ratings, func(i, j int) bool {
+			return ratings[i].Score < ratings[j].Score
+		})
+	default:
+		sort.SliceStable(ratings, func(i, j int) bool {
+			return ratings[i].CreatedAt.After(ratings[j].CreatedAt)
+		})
+	}
+}
+
+func totalReactions(r model.Rating) int {
+	n := 0
+	for _, c := range r.Reactions {
+		n += c
+	}
+	return n
+}
+
+// ListByVenue returns all ratings for a venue.
+func (s *RatingService) ListByVenue(_ context.Context, venueID string) ([]model.Rating, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var results []model.Rating
+	for _, r := range s.scopeLocked(venueID) {
+		if r.VenueID == venueID {
+			s.markStudent(&r)
+			results = append(results, r)
+		}
+	}
+	return results, nil
+}
+
+// IDsByTag returns the published and pending ratings carrying a tag.
+func (s *RatingService) IDsByTag(tag string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := []string{}
+	if s.cache != nil {
+		ids = append(ids, s.ratingIDsByTagDB(tag)...)
+	}
+	for _, list := range [][]model.Rating{s.ratings, s.pending} {
+		for _, r := range list {
+			if slices.Contains(r.Tags, tag) {
+				ids = append(ids, r.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// VenuesWithTag returns the venues with at least minCount published ratings
+// carrying tag.
+func (s *RatingService) VenuesWithTag(tag string, minCount int) map[string]bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, r := range s.ratings {
+		if slices.Contains(r.Tags, tag) {
+			counts[r.VenueID]++
+		}
+	}
+	if s.cache != nil {
+		for id, a := range s.cache.aggs {
+			if n := a.tags[tag]; n > 0 {
+				counts[id] = n
+			}
+		}
+	}
+	venues := make(map[string]bool)
+	for id, n := range counts {
+		if n >= minCount {
+			venues[id] = true
+		}
+	}
+	return venues
+}
+
+// taggedVenuesRefresh is how often a taggedVenues set is recomputed.
+const taggedVenuesRefresh = 10 * time.Minute
+
+// taggedVenues caches VenuesWithTag for features that look up one venue at a
+// time, like patio weather and game-day boosts.
+type taggedVenues struct {
+	mu       sync.Mutex
+	ratings  *RatingService
+	tag      string
+	minCount int
+	venues   map[string]bool
+	at       time.Time
 }
 
-// ListByVenue returns all ratings for a venue.
-func (s *RatingService) ListByVenue(_ context.Context, venueID string) ([]model.Rating, error) {
+func newTaggedVenues(ratings *RatingService, tag string, minCount int) *taggedVenues {
+	return &taggedVenues{ratings: ratings, tag: tag, minCount: minCount}
+}
+
+// Has reports whether the venue has enough ratings with the tag.
+func (t *taggedVenues) Has(venueID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if time.Since(t.at) >= taggedVenuesRefresh {
+		t.venues, t.at = t.ratings.VenuesWithTag(t.tag, t.minCount), time.Now()
+	}
+	return t.venues[venueID]
+}
+
+// GetByID returns a published rating.
+func (s *RatingService) GetByID(ratingID string) (*model.Rating, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var results []model.Rating
-	for _, r := range s.ratings {
-		if r.VenueID == venueID {
-			results = append(results, r)
-		}
+	if rating, ok := s.findLocked(ratingID); ok {
+		return &rating, nil
 	}
-	return results, nil
+	return nil, fmt.Errorf("rating not found")
 }
 
 // Count returns the total number of ratings.
 func (s *RatingService) Count() int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	if s.cache != nil {
+		n := 0
+		for _, a := range s.cache.aggs {
+			n += a.count
+		}
+		return n
+	}
 	return len(s.ratings)
 }
 
@@ -239,8 +1045,9 @@ func (s *RatingService) LoadSeedData(venues []struct{ ID string }) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Skip seed data if we already loaded real data from DB
-	if len(s.ratings) > 0 {
+	// Skip seed data if we already loaded real data from DB. Demo ratings
+	// aren't persisted, so there's nothing to page in with the cache.
+	if len(s.ratings) > 0 || s.cache != nil {
 		return
 	}
 
@@ -287,6 +1094,7 @@ func (s *RatingService) LoadSeedData(venues []struct{ ID string }) {
 			}
 			s.nextID++
 			s.ratings = append(s.ratings, rating)
+			s.index.add(rating)
 			reviewIdx++
 		}
 	}
@@ -303,18 +1111,357 @@ func (s *RatingService) ListByVenues(venueIDs []string) []model.Rating {
 	}
 
 	var results []model.Rating
-	for _, r := range s.ratings {
+	for _, r := range s.scopeLocked(venueIDs...) {
 		if idSet[r.VenueID] {
+			s.markStudent(&r)
 			results = append(results, r)
 		}
 	}
 	return results
 }
 
+// HasRated reports whether a user has rated a venue, including ratings
+// awaiting moderation.
+func (s *RatingService) HasRated(userID, venueID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cache != nil && s.hasRatedDB(userID, venueID) {
+		return true
+	}
+	for _, r := range s.ratings {
+		if r.VenueID == venueID && r.AuthorID == userID {
+			return true
+		}
+	}
+	for _, r := range s.pending {
+		if r.VenueID == venueID && r.AuthorID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// LastActiveByUser returns when each author last posted a rating.
+func (s *RatingService) LastActiveByUser() map[string]time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cache != nil {
+		return s.lastActiveDB()
+	}
+
+	out := make(map[string]time.Time)
+	for _, r := range s.ratings {
+		if r.CreatedAt.After(out[r.AuthorID]) {
+			out[r.AuthorID] = r.CreatedAt
+		}
+	}
+	return out
+}
+
+// HelpfulVotesByAuthor returns the net helpful votes (upvotes minus
+// downvotes) each author has received across their published ratings.
+func (s *RatingService) HelpfulVotesByAuthor() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cache != nil {
+		return s.helpfulVotesDB()
+	}
+
+	out := make(map[string]int)
+	for _, r := range s.ratings {
+		out[r.AuthorID] += r.Upvotes - r.Downvotes
+	}
+	return out
+}
+
+// ReviewText is a published review's text and score, for the text
+// analysis jobs.
+type ReviewText struct {
+	RatingID string
+	Score    float32
+	Text     string
+}
+
+// ReviewTextsByVenue returns every published review with text, grouped by
+// venue.
+func (s *RatingService) ReviewTextsByVenue() map[string][]ReviewText {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cache != nil {
+		return s.reviewTextsDB()
+	}
+
+	out := make(map[string][]ReviewText)
+	for _, r := range s.ratings {
+		if r.Review != "" {
+			out[r.VenueID] = append(out[r.VenueID], ReviewText{RatingID: r.ID, Score: r.Score, Text: r.Review})
+		}
+	}
+	return out
+}
+
+// AnonymizeAuthor replaces the author name on all of a user's ratings.
+func (s *RatingService) AnonymizeAuthor(userID string) {
+	s.mu.Lock()
+	for i := range s.ratings {
+		if s.ratings[i].AuthorID == userID {
+			s.ratings[i].AuthorName = AnonymousUsername
+		}
+	}
+	for i := range s.pending {
+		if s.pending[i].AuthorID == userID {
+			s.pending[i].AuthorName = AnonymousUsername
+		}
+	}
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		for _, table := range []string{"ratings", "pending_ratings"} {
+			if _, err := s.pool.Exec(context.Background(),
+				`UPDATE `+table+` SET author_name = $1 WHERE author_id = $2`, AnonymousUsername, userID); err != nil {
+				log.Printf("WARNING: Failed to anonymize %s: %v", table, err)
+			}
+		}
+	}
+
+	// Cached venues are dropped once the database has the change, so a
+	// concurrent reload can't bring back the old author.
+	if s.cache != nil {
+		s.mu.Lock()
+		s.cache.dropWhere(func(r model.Rating) bool { return r.AuthorID == userID })
+		s.mu.Unlock()
+	}
+}
+
+// DeleteAuthor detaches a deleted account from its ratings: the author ID
+// becomes DeletedAuthorID and the name is cleared, so the reviews stay but
+// can't be traced back.
+func (s *RatingService) DeleteAuthor(userID string) {
+	s.mu.Lock()
+	for i := range s.ratings {
+		if s.ratings[i].AuthorID == userID {
+			s.ratings[i].AuthorID = DeletedAuthorID
+			s.ratings[i].AuthorName = ""
+		}
+	}
+	for i := range s.pending {
+		if s.pending[i].AuthorID == userID {
+			s.pending[i].AuthorID = DeletedAuthorID
+			s.pending[i].AuthorName = ""
+		}
+	}
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		for _, table := range []string{"ratings", "pending_ratings"} {
+			if _, err := s.pool.Exec(context.Background(),
+				`UPDATE `+table+` SET author_id = $1, author_name = NULL WHERE author_id = $2`, DeletedAuthorID, userID); err != nil {
+				log.Printf("WARNING: Failed to detach deleted author from %s: %v", table, err)
+			}
+		}
+	}
+
+	// Cached venues are dropped once the database has the change, so a
+	// concurrent reload can't bring back the old author.
+	if s.cache != nil {
+		s.mu.Lock()
+		s.cache.dropWhere(func(r model.Rating) bool { return r.AuthorID == userID })
+		s.mu.Unlock()
+	}
+}
+
+// MergeAuthor moves fromID's ratings, pending ratings, reactions and helpful
+// votes to toID. Where both accounts rated the same venue, toID's rating is
+// kept and fromID's is deleted; duplicate votes and reactions are dropped
+// the same way. It returns the affected rating IDs by kind ("ratings",
+// "ratings_dropped", "reactions", "votes"); with dryRun nothing changes.
+// Votes are only tracked per user with a database.
+func (s *RatingService) MergeAuthor(fromID, toID, toName string, dryRun bool) map[string][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := slices.Concat(s.authorRatingsLocked(fromID, toID), s.pending)
+	rated := make(map[string]bool)
+	for _, r := range all {
+		if r.AuthorID == toID {
+			rated[r.VenueID] = true
+		}
+	}
+	out := map[string][]string{"ratings": {}, "ratings_dropped": {}, "reactions": {}, "votes": {}}
+	dropped := make(map[string]bool)
+	for _, r := range all {
+		if r.AuthorID != fromID {
+			continue
+		}
+		if rated[r.VenueID] {
+			dropped[r.ID] = true
+			out["ratings_dropped"] = append(out["ratings_dropped"], r.ID)
+		} else {
+			rated[r.VenueID] = true
+			out["ratings"] = append(out["ratings"], r.ID)
+		}
+	}
+	for k := range s.reactions {
+		if k.UserID == fromID {
+			out["reactions"] = append(out["reactions"], k.RatingID)
+		}
+	}
+	if s.cache != nil {
+		rows, err := s.pool.Query(context.Background(), `SELECT rating_id FROM review_reactions WHERE user_id = $1`, fromID)
+		if err != nil {
+			log.Printf("WARNING: Failed to list reactions to merge: %v", err)
+		} else {
+			for rows.Next() {
+				var id string
+				if rows.Scan(&id) == nil {
+					out["reactions"] = append(out["reactions"], id)
+				}
+			}
+			rows.Close()
+		}
+	}
+	if s.pool != nil {
+		rows, err := s.pool.Query(context.Background(), `SELECT rating_id FROM review_votes WHERE user_id = $1`, fromID)
+		if err != nil {
+			log.Printf("WARNING: Failed to list votes to merge: %v", err)
+		} else {
+			for rows.Next() {
+				var id string
+				if rows.Scan(&id) == nil {
+					out["votes"] = append(out["votes"], id)
+				}
+			}
+			rows.Close()
+		}
+	}
+	if dryRun {
+		return out
+	}
+
+	kept := s.ratings[:0]
+	for _, r := range s.ratings {
+		if dropped[r.ID] {
+			s.index.remove(r)
+			continue
+		}
+		if r.AuthorID == fromID {
+			r.AuthorID, r.AuthorName = toID, toName
+		}
+		kept = append(kept, r)
+	}
+	s.ratings = kept
+	keptPending := s.pending[:0]
+	for _, r := range s.pending {
+		if dropped[r.ID] {
+			continue
+		}
+		if r.AuthorID == fromID {
+			r.AuthorID, r.AuthorName = toID, toName
+		}
+		keptPending = append(keptPending, r)
+	}
+	s.pending = keptPending
+	for k := range s.reactions {
+		if k.UserID == fromID {
+			delete(s.reactions, k)
+			if !dropped[k.RatingID] {
+				s.reactions[reactionKey{RatingID: k.RatingID, UserID: toID, Reaction: k.Reaction}] = true
+			}
+		}
+	}
+
+	if s.pool != nil {
+		s.mergeAuthorDB(fromID, toID, toName, out["ratings_dropped"])
+	}
+	if s.cache != nil {
+		for _, r := range all {
+			if dropped[r.ID] && !r.PendingReview {
+				s.cache.remove(r)
+			}
+		}
+		s.cache.dropWhere(func(r model.Rating) bool { return r.AuthorID == fromID })
+	}
+	return out
+}
+
+// mergeAuthorDB persists MergeAuthor. Caller holds s.mu.
+func (s *RatingService) mergeAuthorDB(fromID, toID, toName string, dropped []string) {
+	ctx := context.Background()
+	for _, q := range []string{
+		`DELETE FROM review_votes WHERE rating_id = ANY($1)`,
+		`DELETE FROM review_reactions WHERE rating_id = ANY($1)`,
+		`DELETE FROM ratings WHERE id = ANY($1)`,
+		`DELETE FROM pending_ratings WHERE id = ANY($1)`,
+	} {
+		if _, err := s.pool.Exec(ctx, q, dropped); err != nil {
+			log.Printf("WARNING: Failed to delete duplicate ratings while merging users: %v", err)
+		}
+	}
+	for _, table := range []string{"ratings", "pending_ratings"} {
+		if _, err := s.pool.Exec(ctx,
+			`UPDATE `+table+` SET author_id = $1, author_name = $2 WHERE author_id = $3`, toID, toName, fromID); err != nil {
+			log.Printf("WARNING: Failed to move %s while merging users: %v", table, err)
+		}
+	}
+	if _, err := s.pool.Exec(ctx,
+		`INSERT INTO review_reactions (rating_id, user_id, reaction)
+		 SELECT rating_id, $1, reaction FROM review_reactions WHERE user_id = $2
+		 ON CONFLICT DO NOTHING`, toID, fromID); err != nil {
+		log.Printf("WARNING: Failed to move reactions while merging users: %v", err)
+	}
+	if _, err := s.pool.Exec(ctx, `DELETE FROM review_reactions WHERE user_id = $1`, fromID); err != nil {
+		log.Printf("WARNING: Failed to delete merged reactions: %v", err)
+	}
+
+	// Votes toID already cast, and votes on toID's own (now merged) ratings,
+	// are dropped and taken off the rating's counts.
+	if _, err := s.pool.Exec(ctx,
+		`UPDATE review_votes v SET user_id = $1
+		 WHERE v.user_id = $2
+		   AND NOT EXISTS (SELECT 1 FROM review_votes o WHERE o.rating_id = v.rating_id AND o.user_id = $1)
+		   AND NOT EXISTS (SELECT 1 FROM ratings r WHERE r.id = v.rating_id AND r.author_id = $1)`, toID, fromID); err != nil {
+		log.Printf("WARNING: Failed to move votes while merging users: %v", err)
+	}
+	rows, err := s.pool.Query(ctx, `DELETE FROM review_votes WHERE user_id = $1 RETURNING rating_id, direction`, fromID)
+	if err != nil {
+		log.Printf("WARNING: Failed to delete merged votes: %v", err)
+		return
+	}
+	type vote struct{ ratingID, direction string }
+	var removed []vote
+	for rows.Next() {
+		var v vote
+		if rows.Scan(&v.ratingID, &v.direction) == nil {
+			removed = append(removed, v)
+		}
+	}
+	rows.Close()
+	for _, v := range removed {
+		existing, ok := s.findLocked(v.ratingID)
+		if !ok {
+			continue
+		}
+		r := existing
+		if v.direction == "up" {
+			r.Upvotes--
+		} else {
+			r.Downvotes--
+		}
+		s.replaceLocked(existing, r)
+		if _, err := s.pool.Exec(ctx, `UPDATE ratings SET upvotes=$1, downvotes=$2 WHERE id=$3`,
+			r.Upvotes, r.Downvotes, v.ratingID); err != nil {
+			log.Printf("WARNING: Failed to update vote counts while merging users: %v", err)
+		}
+	}
+}
+
 // GetTopContributors returns users with the most ratings.
 func (s *RatingService) GetTopContributors(limit int) []map[string]interface{} {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	if s.cache != nil {
+		return s.topContributorsDB(limit)
+	}
 
 	type userInfo struct {
 		id    string
@@ -324,6 +1471,9 @@ func (s *RatingService) GetTopContributors(limit int) []map[string]interface{} {
 
 	byUser := make(map[string]*userInfo)
 	for _, r := range s.ratings {
+		if r.AuthorID == DeletedAuthorID {
+			continue
+		}
 		u, ok := byUser[r.AuthorID]
 		if !ok {
 			u = &userInfo{id: r.AuthorID, name: r.AuthorName}
@@ -365,6 +1515,18 @@ func (s *RatingService) GetRecent(limit int) []model.Rating {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	if s.cache != nil {
+		clause, args := `ORDER BY created_at DESC`, []any{}
+		if limit > 0 {
+			clause, args = clause+` LIMIT $1`, append(args, limit)
+		}
+		rs, err := s.queryRatings(clause, args...)
+		if err != nil {
+			log.Printf("WARNING: Failed to load recent ratings: %v", err)
+		}
+		return rs
+	}
+
 	n := len(s.ratings)
 	if limit <= 0 || limit > n {
 		limit = n
@@ -376,16 +1538,49 @@ func (s *RatingService) GetRecent(limit int) []model.Rating {
 	return result
 }
 
+// ListByAuthor returns up to limit of a user's published ratings, newest
+// first, and how many they have in total.
+func (s *RatingService) ListByAuthor(userID string, limit int) ([]model.Rating, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []model.Rating
+	total := 0
+	if s.cache != nil {
+		rs := s.authorRatingsLocked(userID)
+		for i := len(rs) - 1; i >= 0 && len(out) < limit; i-- {
+			s.markStudent(&rs[i])
+			out = append(out, rs[i])
+		}
+		return out, len(rs)
+	}
+	for i := len(s.ratings) - 1; i >= 0; i-- {
+		if s.ratings[i].AuthorID != userID {
+			continue
+		}
+		total++
+		if len(out) < limit {
+			r := s.ratings[i]
+			s.markStudent(&r)
+			out = append(out, r)
+		}
+	}
+	return out, total
+}
+
 // GetVenueThumbs returns thumbs up and thumbs down counts for a venue.
 func (s *RatingService) GetVenueThumbs(venueID string) (up, down int) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	if s.cache != nil {
+		return s.venueThumbsCached(venueID)
+	}
 
 	for _, r := range s.ratings {
 		if r.VenueID == venueID {
-			if r.Score >= 4 {
+			if r.Score >= s.thumbs.UpMin {
 				up++
-			} else if r.Score <= 2 {
+			} else if r.Score <= s.thumbs.DownMax {
 				down++
 			}
 		}
@@ -393,20 +1588,213 @@ func (s *RatingService) GetVenueThumbs(venueID string) (up, down int) {
 	return
 }
 
-// GetVenueStats returns average rating and count for a venue.
+// RecommendCount tallies would-recommend answers.
+type RecommendCount struct {
+	Yes      int
+	Answered int
+}
+
+// Pct is the whole percentage of "yes" answers, or nil if nobody answered.
+func (c RecommendCount) Pct() *int {
+	return RecommendPct(c.Yes, c.Answered)
+}
+
+// RecommendCountsByVenue tallies would-recommend answers per venue.
+func (s *RatingService) RecommendCountsByVenue() map[string]RecommendCount {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]RecommendCount)
+	if s.cache != nil {
+		for id, a := range s.cache.aggs {
+			if a.recommend.Answered > 0 {
+				out[id] = a.recommend
+			}
+		}
+	}
+	for _, r := range s.ratings {
+		if r.WouldRecommend == nil {
+			continue
+		}
+		c := out[r.VenueID]
+		c.Answered++
+		if *r.WouldRecommend {
+			c.Yes++
+		}
+		out[r.VenueID] = c
+	}
+	return out
+}
+
+// RecommendCountFor tallies would-recommend answers across a set of venues,
+// e.g. all of a school's venues.
+func (s *RatingService) RecommendCountFor(venueIDs []string) RecommendCount {
+	idSet := make(map[string]bool, len(venueIDs))
+	for _, id := range venueIDs {
+		idSet[id] = true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var c RecommendCount
+	if s.cache != nil {
+		for id := range idSet {
+			if a := s.cache.aggs[id]; a != nil {
+				c.Yes += a.recommend.Yes
+				c.Answered += a.recommend.Answered
+			}
+		}
+	}
+	for _, r := range s.ratings {
+		if r.WouldRecommend == nil || !idSet[r.VenueID] {
+			continue
+		}
+		c.Answered++
+		if *r.WouldRecommend {
+			c.Yes++
+		}
+	}
+	return c
+}
+
+// GetVenueStats returns the average rating and count for a venue. Verified
+// visits count extra toward the average.
 func (s *RatingService) GetVenueStats(venueID string) (avgRating float64, count int) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	if s.cache != nil {
+		return s.venueStatsCached(venueID)
+	}
 
-	var total float64
+	var total, weights float64
 	for _, r := range s.ratings {
 		if r.VenueID == venueID {
-			total += float64(r.Score)
+			w := s.weight(r)
+			total += float64(r.Score) * w
+			weights += w
 			count++
 		}
 	}
 	if count > 0 {
-		avgRating = total / float64(count)
+		avgRating = total / weights
 	}
 	return
 }
+
+// GetVenueCohorts returns a venue's weighted average rating per class-year
+// cohort, youngest first. The cohort is the author's as of when they rated;
+// cohorts with fewer than minCohortRatings ratings are omitted.
+func (s *RatingService) GetVenueCohorts(venueID string) []model.CohortStat {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type acc struct {
+		total, weights float64
+		count          int
+	}
+	byCohort := make(map[string]*acc)
+	for _, r := range s.scopeLocked(venueID) {
+		if r.VenueID != venueID {
+			continue
+		}
+		cohort := CohortFor(r.AuthorGradYear, r.CreatedAt)
+		if cohort == "" {
+			continue
+		}
+		a := byCohort[cohort]
+		if a == nil {
+			a = &acc{}
+			byCohort[cohort] = a
+		}
+		w := s.weight(r)
+		a.total += float64(r.Score) * w
+		a.weights += w
+		a.count++
+	}
+
+	var out []model.CohortStat
+	for _, cohort := range cohortOrder {
+		if a := byCohort[cohort]; a != nil && a.count >= minCohortRatings {
+			out = append(out, model.CohortStat{Cohort: cohort, AvgRating: RoundAverage(a.total / a.weights), RatingCount: a.count})
+		}
+	}
+	return out
+}
+
+// GetVenueTrend returns a weekly rating time series for a venue covering the
+// last `weeks` weeks (oldest first). CumulativeAvg is the all-time average as
+// of the end of each bucket.
+func (s *RatingService) GetVenueTrend(venueID string, weeks int) []model.TrendPoint {
+	if weeks <= 0 {
+		weeks = 12
+	}
+	end := weekStart(time.Now()).AddDate(0, 0, 7)
+	start := end.AddDate(0, 0, -7*weeks)
+
+	points := make([]model.TrendPoint, weeks)
+	sums := make([]float64, weeks)
+	weights := make([]float64, weeks)
+	for i := range points {
+		points[i].PeriodStart = start.AddDate(0, 0, 7*i)
+	}
+
+	s.mu.RLock()
+	var priorTotal, priorWeight float64
+	for _, r := range s.scopeLocked(venueID) {
+		if r.VenueID != venueID {
+			continue
+		}
+		w := s.weight(r)
+		if r.CreatedAt.Before(start) {
+			priorTotal += float64(r.Score) * w
+			priorWeight += w
+			continue
+		}
+		i := int(r.CreatedAt.Sub(start) / (7 * 24 * time.Hour))
+		if i >= weeks {
+			continue
+		}
+		points[i].Count++
+		sums[i] += float64(r.Score) * w
+		weights[i] += w
+	}
+	s.mu.RUnlock()
+
+	runTotal, runWeight := priorTotal, priorWeight
+	for i := range points {
+		if points[i].Count > 0 {
+			points[i].AvgScore = sums[i] / weights[i]
+		}
+		runTotal += sums[i]
+		runWeight += weights[i]
+		if runWeight > 0 {
+			points[i].CumulativeAvg = runTotal / runWeight
+		}
+	}
+	return points
+}
+
+// GetVenueTagCounts returns how often each review tag was used on a venue.
+func (s *RatingService) GetVenueTagCounts(venueID string) map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	if s.cache != nil {
+		if a := s.cache.aggs[venueID]; a != nil {
+			for t, n := range a.tags {
+				counts[t] = n
+			}
+		}
+		return counts
+	}
+	for _, r := range s.ratings {
+		if r.VenueID == venueID {
+			for _, t := range r.Tags {
+				counts[t]++
+			}
+		}
+	}
+	return counts
+}
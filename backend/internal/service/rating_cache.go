@@ -0,0 +1,554 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// LoadRatingCacheSize reads RATING_CACHE_VENUES: with a database, only this
+// many venues' ratings are kept in memory (least recently used are evicted)
+// and everything else is read on demand. 0, the default, loads every rating
+// at startup.
+func LoadRatingCacheSize() int {
+	if v, err := strconv.Atoi(os.Getenv("RATING_CACHE_VENUES")); err == nil && v > 0 {
+		return v
+	}
+	return 0
+}
+
+const ratingColumns = `id, score, COALESCE(review,''), COALESCE(tags,'{}'), venue_id, author_id, COALESCE(author_name,''), created_at, upvotes, downvotes, redacted, verified_visit, would_recommend,
+		        COALESCE(author_grad_year, 0), edited_at, imported`
+
+func scanRating(row pgx.Row) (model.Rating, error) {
+	var r model.Rating
+	err := row.Scan(&r.ID, &r.Score, &r.Review, &r.Tags, &r.VenueID, &r.AuthorID, &r.AuthorName, &r.CreatedAt, &r.Upvotes, &r.Downvotes, &r.Redacted, &r.VerifiedVisit, &r.WouldRecommend, &r.AuthorGradYear, &r.EditedAt, &r.Imported)
+	return r, err
+}
+
+// ratingSize roughly estimates the memory a rating holds, for store stats.
+func ratingSize(r model.Rating) int64 {
+	n := int64(unsafe.Sizeof(r)) + int64(len(r.ID)+len(r.Review)+len(r.VenueID)+len(r.AuthorID)+len(r.AuthorName))
+	for _, t := range r.Tags {
+		n += int64(unsafe.Sizeof(t)) + int64(len(t))
+	}
+	// Map buckets are hard to size; this is close for the few reactions a
+	// review gets.
+	n += int64(len(r.Reactions)) * 48
+	return n
+}
+
+// venueAggregate is what the venue-level stats need, kept resident for every
+// venue so they don't have to load ratings. Scores are kept as a histogram
+// since thumbs thresholds and the verified-visit weight are configured after
+// loading.
+type venueAggregate struct {
+	count         int
+	verified      int     // ratings from verified visits
+	total         float64 // score sum of unverified ratings
+	verifiedTotal float64 // score sum of verified ratings
+	scores        map[float32]int
+	tags          map[string]int
+	recommend     RecommendCount
+}
+
+func (a *venueAggregate) apply(r model.Rating, delta int) {
+	a.count += delta
+	if r.VerifiedVisit {
+		a.verified += delta
+		a.verifiedTotal += float64(r.Score) * float64(delta)
+	} else {
+		a.total += float64(r.Score) * float64(delta)
+	}
+	a.scores[r.Score] += delta
+	if a.scores[r.Score] <= 0 {
+		delete(a.scores, r.Score)
+	}
+	for _, t := range r.Tags {
+		a.tags[t] += delta
+		if a.tags[t] <= 0 {
+			delete(a.tags, t)
+		}
+	}
+	if r.WouldRecommend != nil {
+		a.recommend.Answered += delta
+		if *r.WouldRecommend {
+			a.recommend.Yes += delta
+		}
+	}
+}
+
+// cachedVenue is one venue's published ratings, oldest first.
+type cachedVenue struct {
+	venueID string
+	ratings []model.Rating
+}
+
+// ratingCache keeps aggregates for every venue and full ratings for the most
+// recently used ones. Reads and writes go through RatingService, which holds
+// s.mu (read or write) around every call; mu only guards the LRU order and
+// counters, which change on reads too.
+type ratingCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // *cachedVenue, most recently used first
+	venues   map[string]*list.Element
+	aggs     map[string]*venueAggregate
+
+	hits, misses, evictions int64
+}
+
+func newRatingCache(capacity int) *ratingCache {
+	return &ratingCache{
+		capacity: capacity,
+		order:    list.New(),
+		venues:   make(map[string]*list.Element),
+		aggs:     make(map[string]*venueAggregate),
+	}
+}
+
+// get returns a venue's cached ratings. Callers must copy before handing
+// them out.
+func (c *ratingCache) get(venueID string) ([]model.Rating, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.venues[venueID]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(el)
+	return el.Value.(*cachedVenue).ratings, true
+}
+
+func (c *ratingCache) put(venueID string, ratings []model.Rating) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.venues[venueID]; ok {
+		el.Value.(*cachedVenue).ratings = ratings
+		c.order.MoveToFront(el)
+		return
+	}
+	c.venues[venueID] = c.order.PushFront(&cachedVenue{venueID: venueID, ratings: ratings})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.venues, oldest.Value.(*cachedVenue).venueID)
+		c.evictions++
+	}
+}
+
+// update applies fn to a venue's cached ratings, if it is cached.
+func (c *ratingCache) update(venueID string, fn func([]model.Rating) []model.Rating) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.venues[venueID]; ok {
+		cv := el.Value.(*cachedVenue)
+		cv.ratings = fn(cv.ratings)
+	}
+}
+
+// dropWhere evicts every cached venue holding a rating that matches.
+func (c *ratingCache) dropWhere(match func(model.Rating) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, el := range c.venues {
+		for _, r := range el.Value.(*cachedVenue).ratings {
+			if match(r) {
+				c.order.Remove(el)
+				delete(c.venues, id)
+				break
+			}
+		}
+	}
+}
+
+func (c *ratingCache) aggregate(r model.Rating, delta int) {
+	a := c.aggs[r.VenueID]
+	if a == nil {
+		a = &venueAggregate{scores: make(map[float32]int), tags: make(map[string]int)}
+		c.aggs[r.VenueID] = a
+	}
+	a.apply(r, delta)
+	if a.count <= 0 {
+		delete(c.aggs, r.VenueID)
+	}
+}
+
+// add publishes a new rating.
+func (c *ratingCache) add(r model.Rating) {
+	c.aggregate(r, 1)
+	c.update(r.VenueID, func(rs []model.Rating) []model.Rating { return append(rs, r) })
+}
+
+// replace swaps in an edited copy of a published rating.
+func (c *ratingCache) replace(old, updated model.Rating) {
+	c.aggregate(old, -1)
+	c.aggregate(updated, 1)
+	c.update(old.VenueID, func(rs []model.Rating) []model.Rating {
+		for i := range rs {
+			if rs[i].ID == old.ID {
+				rs[i] = updated
+			}
+		}
+		return rs
+	})
+}
+
+// remove takes a published rating out.
+func (c *ratingCache) remove(r model.Rating) {
+	c.aggregate(r, -1)
+	c.update(r.VenueID, func(rs []model.Rating) []model.Rating {
+		return slices.DeleteFunc(rs, func(x model.Rating) bool { return x.ID == r.ID })
+	})
+}
+
+func (c *ratingCache) stats() model.RatingStoreStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st := model.RatingStoreStats{
+		Mode:             "lru",
+		CachedVenues:     c.order.Len(),
+		CacheCapacity:    c.capacity,
+		AggregatedVenues: len(c.aggs),
+		CacheHits:        c.hits,
+		CacheMisses:      c.misses,
+		CacheEvictions:   c.evictions,
+	}
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		for _, r := range el.Value.(*cachedVenue).ratings {
+			st.ResidentRatings++
+			st.ResidentBytes += ratingSize(r)
+		}
+	}
+	return st
+}
+
+// loadAggregates streams every rating once at startup, keeping only venue
+// aggregates, and returns the highest rating ID number seen.
+func (s *RatingService) loadAggregates() int {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, venue_id, score, verified_visit, would_recommend, COALESCE(tags,'{}') FROM ratings`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load rating aggregates from DB: %v", err)
+		return 0
+	}
+	defer rows.Close()
+
+	maxID, n := 0, 0
+	for rows.Next() {
+		var r model.Rating
+		if err := rows.Scan(&r.ID, &r.VenueID, &r.Score, &r.VerifiedVisit, &r.WouldRecommend, &r.Tags); err != nil {
+			log.Printf("WARNING: Failed to scan rating row: %v", err)
+			continue
+		}
+		s.cache.aggregate(r, 1)
+		maxID = max(maxID, ratingIDNumber(r.ID))
+		n++
+	}
+	log.Printf("Loaded aggregates for %d ratings across %d venues from DB", n, len(s.cache.aggs))
+	return maxID
+}
+
+// ratingIDNumber returns N for "rating_N", or 0.
+func ratingIDNumber(id string) int {
+	var n int
+	if _, err := fmt.Sscanf(id, "rating_%d", &n); err != nil {
+		return 0
+	}
+	return n
+}
+
+// queryRatings loads published ratings, with their reaction counts, using
+// the given WHERE/ORDER BY/LIMIT clause.
+func (s *RatingService) queryRatings(clause string, args ...any) ([]model.Rating, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `SELECT `+ratingColumns+` FROM ratings `+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	var out []model.Rating
+	for rows.Next() {
+		r, err := scanRating(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil || len(out) == 0 {
+		return out, err
+	}
+
+	ids := make([]string, len(out))
+	byID := make(map[string]*model.Rating, len(out))
+	for i := range out {
+		ids[i] = out[i].ID
+		byID[out[i].ID] = &out[i]
+	}
+	rows, err = s.pool.Query(ctx,
+		`SELECT rating_id, reaction, COUNT(*) FROM review_reactions WHERE rating_id = ANY($1) GROUP BY rating_id, reaction`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, reaction string
+		var n int
+		if err := rows.Scan(&id, &reaction, &n); err != nil {
+			return nil, err
+		}
+		r := byID[id]
+		if r.Reactions == nil {
+			r.Reactions = make(map[string]int)
+		}
+		r.Reactions[reaction] = n
+	}
+	return out, rows.Err()
+}
+
+// venueRatingsLocked returns a venue's published ratings from the cache,
+// loading them on a miss. The slice is shared; copy before returning it.
+// Caller holds s.mu.
+func (s *RatingService) venueRatingsLocked(venueID string) []model.Rating {
+	if rs, ok := s.cache.get(venueID); ok {
+		return rs
+	}
+	rs, err := s.queryRatings(`WHERE venue_id = $1 ORDER BY created_at`, venueID)
+	if err != nil {
+		log.Printf("WARNING: Failed to load ratings for venue %s: %v", venueID, err)
+		return nil
+	}
+	s.cache.put(venueID, rs)
+	return rs
+}
+
+// StoreStats reports how many ratings are held in memory and roughly how
+// much space they take.
+func (s *RatingService) StoreStats() model.RatingStoreStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var st model.RatingStoreStats
+	if s.cache != nil {
+		st = s.cache.stats()
+	} else {
+		st.Mode = "resident"
+		st.ResidentRatings = len(s.ratings)
+		for _, r := range s.ratings {
+			st.ResidentBytes += ratingSize(r)
+		}
+	}
+	st.PendingRatings = len(s.pending)
+	return st
+}
+
+// scopeLocked returns ratings covering the given venues: everything in
+// resident mode, or just those venues' (loaded on demand) with the cache.
+// Callers still filter by venue and must not modify or keep the slice.
+// Caller holds s.mu.
+func (s *RatingService) scopeLocked(venueIDs ...string) []model.Rating {
+	if s.cache == nil {
+		return s.ratings
+	}
+	if len(venueIDs) == 1 {
+		return s.venueRatingsLocked(venueIDs[0])
+	}
+	var out []model.Rating
+	for _, id := range venueIDs {
+		out = append(out, s.venueRatingsLocked(id)...)
+	}
+	return out
+}
+
+// venueStatsCached is GetVenueStats from the venue's aggregate.
+func (s *RatingService) venueStatsCached(venueID string) (avgRating float64, count int) {
+	a := s.cache.aggs[venueID]
+	if a == nil {
+		return 0, 0
+	}
+	w := s.weight(model.Rating{VerifiedVisit: true})
+	weights := float64(a.count-a.verified) + float64(a.verified)*w
+	return (a.total + a.verifiedTotal*w) / weights, a.count
+}
+
+// venueThumbsCached is GetVenueThumbs from the venue's aggregate.
+func (s *RatingService) venueThumbsCached(venueID string) (up, down int) {
+	a := s.cache.aggs[venueID]
+	if a == nil {
+		return 0, 0
+	}
+	for score, n := range a.scores {
+		if score >= s.thumbs.UpMin {
+			up += n
+		} else if score <= s.thumbs.DownMax {
+			down += n
+		}
+	}
+	return up, down
+}
+
+// ratingIDsByTagDB returns the published ratings carrying a tag.
+func (s *RatingService) ratingIDsByTagDB(tag string) []string {
+	rows, err := s.pool.Query(context.Background(), `SELECT id FROM ratings WHERE $1 = ANY(tags)`, tag)
+	if err != nil {
+		log.Printf("WARNING: Failed to list ratings by tag: %v", err)
+		return nil
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if rows.Scan(&id) == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// hasRatedDB reports whether a user has a published rating for a venue.
+func (s *RatingService) hasRatedDB(userID, venueID string) bool {
+	var exists bool
+	err := s.pool.QueryRow(context.Background(),
+		`SELECT EXISTS (SELECT 1 FROM ratings WHERE venue_id = $1 AND author_id = $2)`, venueID, userID).Scan(&exists)
+	if err != nil {
+		log.Printf("WARNING: Failed to check existing rating: %v", err)
+	}
+	return exists
+}
+
+// lastActiveDB is LastActiveByUser as a query.
+func (s *RatingService) lastActiveDB() map[string]time.Time {
+	out := make(map[string]time.Time)
+	rows, err := s.pool.Query(context.Background(), `SELECT author_id, MAX(created_at) FROM ratings GROUP BY author_id`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load last rating times: %v", err)
+		return out
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var at time.Time
+		if rows.Scan(&id, &at) == nil {
+			out[id] = at
+		}
+	}
+	return out
+}
+
+// helpfulVotesDB is HelpfulVotesByAuthor as a query.
+func (s *RatingService) helpfulVotesDB() map[string]int {
+	out := make(map[string]int)
+	rows, err := s.pool.Query(context.Background(), `SELECT author_id, SUM(upvotes - downvotes) FROM ratings GROUP BY author_id`)
+	if err != nil {
+		log.Printf("WARNING: Failed to total helpful votes: %v", err)
+		return out
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var n int
+		if rows.Scan(&id, &n) == nil {
+			out[id] = n
+		}
+	}
+	return out
+}
+
+// reviewTextsDB is ReviewTextsByVenue as a query.
+func (s *RatingService) reviewTextsDB() map[string][]ReviewText {
+	out := make(map[string][]ReviewText)
+	rows, err := s.pool.Query(context.Background(), `SELECT id, venue_id, score, review FROM ratings WHERE COALESCE(review,'') <> ''`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load review texts: %v", err)
+		return out
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var venueID string
+		var r ReviewText
+		if rows.Scan(&r.RatingID, &venueID, &r.Score, &r.Text) == nil {
+			out[venueID] = append(out[venueID], r)
+		}
+	}
+	return out
+}
+
+// topContributorsDB is GetTopContributors as a query.
+func (s *RatingService) topContributorsDB(limit int) []map[string]interface{} {
+	query := `SELECT (ARRAY_AGG(author_name ORDER BY created_at))[1], COUNT(*) FROM ratings
+		 WHERE author_id <> $1 GROUP BY author_id ORDER BY COUNT(*) DESC`
+	args := []any{DeletedAuthorID}
+	if limit > 0 {
+		query += ` LIMIT $2`
+		args = append(args, limit)
+	}
+	results := []map[string]interface{}{}
+	rows, err := s.pool.Query(context.Background(), query, args...)
+	if err != nil {
+		log.Printf("WARNING: Failed to list top contributors: %v", err)
+		return results
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name *string
+		var count int
+		if err := rows.Scan(&name, &count); err != nil {
+			continue
+		}
+		username := ""
+		if name != nil {
+			username = *name
+		}
+		results = append(results, map[string]interface{}{
+			"rank":         len(results) + 1,
+			"username":     username,
+			"rating_count": count,
+		})
+	}
+	return results
+}
+
+// authorRatingsLocked returns the published ratings by any of the given
+// authors (everything in resident mode; callers filter). Caller holds s.mu.
+func (s *RatingService) authorRatingsLocked(authorIDs ...string) []model.Rating {
+	if s.cache == nil {
+		return s.ratings
+	}
+	rs, err := s.queryRatings(`WHERE author_id = ANY($1) ORDER BY created_at`, authorIDs)
+	if err != nil {
+		log.Printf("WARNING: Failed to load ratings by author: %v", err)
+	}
+	return rs
+}
+
+// hasAllTerms reports whether review contains every search term as a word,
+// for searches without the resident index.
+func hasAllTerms(review string, terms []string) bool {
+	words := make(map[string]bool)
+	for _, w := range tokenize(review) {
+		words[w] = true
+	}
+	for _, t := range terms {
+		if !words[t] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+type recomputeStep struct {
+	name string
+	run  func(ctx context.Context) (int, error)
+}
+
+// RecomputeService rebuilds everything derived from venues and ratings:
+// venue stats, school venue counts, averages and recommend percentages
+// (which the leaderboards rank by), credibility scores and computed caches.
+// It runs at startup and on demand after imports, merges or bulk deletions.
+type RecomputeService struct {
+	schools *SchoolService
+	venues  *VenueService
+	ratings *RatingService
+
+	credibility *CredibilityService
+	heatmap     *HeatmapService
+	seasonality *SeasonalityService
+	keywords    *KeywordService
+	sentiment   *SentimentService
+
+	mu     sync.Mutex
+	status model.RecomputeStatus
+}
+
+func NewRecomputeService(schools *SchoolService, venues *VenueService, ratings *RatingService) *RecomputeService {
+	return &RecomputeService{
+		schools: schools,
+		venues:  venues,
+		ratings: ratings,
+		status:  model.RecomputeStatus{State: "idle", Steps: []model.RecomputeStep{}},
+	}
+}
+
+// SetCredibility includes credibility scores in full recomputes.
+func (s *RecomputeService) SetCredibility(credibility *CredibilityService) {
+	s.credibility = credibility
+}
+
+// SetCaches lets full recomputes drop computed caches.
+func (s *RecomputeService) SetCaches(heatmap *HeatmapService, seasonality *SeasonalityService) {
+	s.heatmap = heatmap
+	s.seasonality = seasonality
+}
+
+// SetKeywords lets full recomputes re-extract review keywords.
+func (s *RecomputeService) SetKeywords(keywords *KeywordService) {
+	s.keywords = keywords
+}
+
+// SetSentiment lets full recomputes refresh review sentiment and vibe
+// indexes.
+func (s *RecomputeService) SetSentiment(sentiment *SentimentService) {
+	s.sentiment = sentiment
+}
+
+// RebuildAggregates recomputes venue stats and the school aggregates built on
+// them, synchronously.
+func (s *RecomputeService) RebuildAggregates() {
+	ctx := context.Background()
+	for _, step := range s.aggregateSteps() {
+		n, _ := step.run(ctx)
+		log.Printf("Recomputed %s (%d updated)", step.name, n)
+	}
+}
+
+func (s *RecomputeService) aggregateSteps() []recomputeStep {
+	return []recomputeStep{
+		{"venue_stats", func(context.Context) (int, error) {
+			s.venues.UpdateRatingStats(s.ratings.GetVenueStats, s.ratings.GetVenueThumbs)
+			return len(s.venues.GetAllVenues()), nil
+		}},
+		{"school_venue_counts", func(context.Context) (int, error) {
+			counts := make(map[string]int)
+			for _, v := range s.venues.GetAllVenues() {
+				counts[v.SchoolID]++
+			}
+			s.schools.UpdateVenueCounts(counts)
+			return len(counts), nil
+		}},
+		{"school_ratings", func(context.Context) (int, error) {
+			// A school's average is the mean of its rated venues' averages.
+			sums := make(map[string]float64)
+			rated := make(map[string]int)
+			for _, v := range s.venues.GetAllVenues() {
+				if v.AvgRating > 0 {
+					sums[v.SchoolID] += v.AvgRating
+					rated[v.SchoolID]++
+				}
+			}
+			avgs := make(map[string]float64, len(sums))
+			for sid, sum := range sums {
+				avgs[sid] = sum / float64(rated[sid])
+			}
+			s.schools.UpdateSchoolRatings(avgs)
+			return len(avgs), nil
+		}},
+		{"school_recommend", func(context.Context) (int, error) {
+			byVenue := s.ratings.RecommendCountsByVenue()
+			bySchool := make(map[string]RecommendCount)
+			for _, v := range s.venues.GetAllVenues() {
+				if c, ok := byVenue[v.ID]; ok {
+					sc := bySchool[v.SchoolID]
+					sc.Yes += c.Yes
+					sc.Answered += c.Answered
+					bySchool[v.SchoolID] = sc
+				}
+			}
+			pcts := make(map[string]*int, len(bySchool))
+			for sid, c := range bySchool {
+				pcts[sid] = c.Pct()
+			}
+			s.schools.UpdateSchoolRecommend(pcts)
+			return len(pcts), nil
+		}},
+	}
+}
+
+func (s *RecomputeService) steps() []recomputeStep {
+	steps := s.aggregateSteps()
+	if s.credibility != nil {
+		steps = append(steps, recomputeStep{"credibility", func(ctx context.Context) (int, error) {
+			return 0, s.credibility.Recompute(ctx)
+		}})
+	}
+	if s.keywords != nil {
+		steps = append(steps, recomputeStep{"keywords", func(context.Context) (int, error) {
+			return s.keywords.Rebuild(), nil
+		}})
+	}
+	if s.sentiment != nil {
+		steps = append(steps, recomputeStep{"sentiment", s.sentiment.Rebuild})
+	}
+	steps = append(steps, recomputeStep{"caches", func(context.Context) (int, error) {
+		n := 0
+		if s.heatmap != nil {
+			s.heatmap.Invalidate()
+			n++
+		}
+		if s.seasonality != nil {
+			s.seasonality.Invalidate()
+			n++
+		}
+		return n, nil
+	}})
+	return steps
+}
+
+// Start begins a full recompute in the background and returns its initial
+// status. Only one runs at a time.
+func (s *RecomputeService) Start() (model.RecomputeStatus, error) {
+	steps := s.steps()
+	now := time.Now()
+
+	s.mu.Lock()
+	if s.status.State == "running" {
+		s.mu.Unlock()
+		return model.RecomputeStatus{}, fmt.Errorf("a recompute is already running")
+	}
+	s.status = model.RecomputeStatus{
+		State:     "running",
+		StartedAt: &now,
+		Total:     len(steps),
+		Steps:     make([]model.RecomputeStep, len(steps)),
+	}
+	for i, step := range steps {
+		s.status.Steps[i] = model.RecomputeStep{Name: step.name, State: "pending"}
+	}
+	s.mu.Unlock()
+
+	go s.run(steps)
+	return s.Status(), nil
+}
+
+func (s *RecomputeService) run(steps []recomputeStep) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	for i, step := range steps {
+		s.mu.Lock()
+		s.status.Steps[i].State = "running"
+		s.mu.Unlock()
+
+		began := time.Now()
+		n, err := step.run(ctx)
+
+		s.mu.Lock()
+		st := &s.status.Steps[i]
+		st.Updated = n
+		st.DurationMs = time.Since(began).Milliseconds()
+		if err != nil {
+			st.State = "failed"
+			st.Error = err.Error()
+			s.finish("failed", fmt.Sprintf("%s: %v", step.name, err))
+			s.mu.Unlock()
+			log.Printf("WARNING: Recompute failed at %s: %v", step.name, err)
+			return
+		}
+		st.State = "done"
+		s.status.Completed = i + 1
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	s.finish("done", "")
+	s.mu.Unlock()
+	log.Printf("Recompute finished (%d steps)", len(steps))
+}
+
+// finish must be called with s.mu held.
+func (s *RecomputeService) finish(state, errMsg string) {
+	now := time.Now()
+	s.status.State = state
+	s.status.FinishedAt = &now
+	s.status.Error = errMsg
+}
+
+// Status returns the progress of the current or last recompute.
+func (s *RecomputeService) Status() model.RecomputeStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := s.status
+	status.Steps = append([]model.RecomputeStep{}, s.status.Steps...)
+	return status
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// redactionMark replaces each character of a redacted word.
+const redactionMark = "█"
+
+// redactTerms replaces whole-word, case-insensitive matches of each term with
+// redaction marks of the same length, and returns the number of replacements.
+func redactTerms(text string, terms []string) (string, int) {
+	n := 0
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		pattern := regexp.QuoteMeta(term)
+		if isWordByte(term[0]) {
+			pattern = `\b` + pattern
+		}
+		if isWordByte(term[len(term)-1]) {
+			pattern += `\b`
+		}
+		re, err := regexp.Compile(`(?i)` + pattern)
+		if err != nil {
+			continue
+		}
+		text = re.ReplaceAllStringFunc(text, func(match string) string {
+			n++
+			return blackout(match)
+		})
+	}
+	return text, n
+}
+
+// blackout replaces every non-space character with the redaction mark.
+func blackout(s string) string {
+	var b strings.Builder
+	b.Grow(utf8.RuneCountInString(s) * len(redactionMark))
+	for _, r := range s {
+		if r == ' ' {
+			b.WriteRune(r)
+		} else {
+			b.WriteString(redactionMark)
+		}
+	}
+	return b.String()
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
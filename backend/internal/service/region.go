@@ -0,0 +1,89 @@
+package service
+
+import (
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// regions lists every region the platform knows how to serve. Codes are
+// ISO 3166-1 alpha-2 country codes; DataFile is the school dataset produced
+// by the region's importer under scripts/.
+var regions = []struct {
+	model.Region
+	DataFile string
+}{
+	{model.Region{Code: "US", Name: "United States"}, "schools.json"},
+	{model.Region{Code: "CA", Name: "Canada"}, "schools_ca.json"},
+}
+
+// defaultRegion is assigned to schools whose dataset has no country field
+// (the original IPEDS export).
+const defaultRegion = "US"
+
+// EnabledRegions returns the region codes this deployment serves, read from
+// REGIONS (e.g. "US,CA"). Defaults to every known region.
+func EnabledRegions() []string {
+	spec := os.Getenv("REGIONS")
+	if spec == "" {
+		codes := make([]string, len(regions))
+		for i, r := range regions {
+			codes[i] = r.Code
+		}
+		return codes
+	}
+
+	var codes []string
+	for _, code := range strings.Split(spec, ",") {
+		code = NormalizeRegion(code)
+		if code == "" {
+			continue
+		}
+		if RegionDataFile(code) == "" {
+			log.Printf("WARNING: Ignoring unknown region %q", code)
+			continue
+		}
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// RegionDataFile returns the school dataset file name for a region, or ""
+// if the region is unknown.
+func RegionDataFile(code string) string {
+	for _, r := range regions {
+		if r.Code == code {
+			return r.DataFile
+		}
+	}
+	return ""
+}
+
+// NormalizeRegion upper-cases and trims a region code from user input.
+func NormalizeRegion(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Regions returns the loaded regions with their school counts.
+func (s *SchoolService) Regions() []model.Region {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, school := range s.schools {
+		counts[school.Country]++
+	}
+
+	out := []model.Region{}
+	for _, r := range regions {
+		if counts[r.Code] == 0 {
+			continue
+		}
+		region := r.Region
+		region.SchoolCount = counts[r.Code]
+		out = append(out, region)
+	}
+	return out
+}
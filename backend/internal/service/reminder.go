@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// LoadReminderHour reads RATING_REMINDER_HOUR, the local hour (0-23) on the
+// day after a check-in when the rating reminder goes out. Default 14; -1
+// disables reminders.
+func LoadReminderHour() int {
+	if v, err := strconv.Atoi(os.Getenv("RATING_REMINDER_HOUR")); err == nil && v >= -1 && v <= 23 {
+		return v
+	}
+	return 14
+}
+
+// ReminderNotifyFunc delivers a due rating reminder (e.g. by push).
+type ReminderNotifyFunc func(reminder model.RatingReminder)
+
+// ReminderService nudges users who checked in at a venue to rate it the next
+// day. Reminders are dropped if the user rates the venue first.
+type ReminderService struct {
+	mu      sync.Mutex
+	pool    *pgxpool.Pool
+	hour    int
+	pending []model.RatingReminder
+	notify  ReminderNotifyFunc
+
+	venueSvc  *VenueService
+	schoolSvc *SchoolService
+	ratingSvc *RatingService
+}
+
+func NewReminderService(pool *pgxpool.Pool, hour int, venueSvc *VenueService, schoolSvc *SchoolService, ratingSvc *RatingService) *ReminderService {
+	svc := &ReminderService{
+		pool:      pool,
+		hour:      hour,
+		pending:   []model.RatingReminder{},
+		venueSvc:  venueSvc,
+		schoolSvc: schoolSvc,
+		ratingSvc: ratingSvc,
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *ReminderService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, user_id, venue_id, COALESCE(venue_name,''), due_at, created_at FROM rating_reminders ORDER BY due_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load rating reminders from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r model.RatingReminder
+		if err := rows.Scan(&r.ID, &r.UserID, &r.VenueID, &r.VenueName, &r.DueAt, &r.CreatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan rating reminder row: %v", err)
+			continue
+		}
+		s.pending = append(s.pending, r)
+	}
+	log.Printf("Loaded %d pending rating reminders from DB", len(s.pending))
+}
+
+// SetNotifier sets the delivery hook for due reminders.
+func (s *ReminderService) SetNotifier(fn ReminderNotifyFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notify = fn
+}
+
+// Enqueue schedules a reminder for a check-in unless the user already rated
+// the venue or has a reminder pending for it.
+func (s *ReminderService) Enqueue(c model.CheckIn) {
+	if s.hour < 0 || s.ratingSvc.HasRated(c.UserID, c.VenueID) {
+		return
+	}
+	venue, err := s.venueSvc.GetByID(context.Background(), c.VenueID)
+	if err != nil {
+		return
+	}
+
+	// The next day at the reminder hour, in the venue's local time.
+	loc := s.schoolSvc.Location(venue.SchoolID)
+	local := c.CreatedAt.In(loc)
+	due := time.Date(local.Year(), local.Month(), local.Day()+1, s.hour, 0, 0, 0, loc)
+
+	r := model.RatingReminder{
+		ID:        "reminder_" + generateID()[:16],
+		UserID:    c.UserID,
+		VenueID:   c.VenueID,
+		VenueName: venue.Name,
+		DueAt:     due,
+		CreatedAt: c.CreatedAt,
+	}
+
+	s.mu.Lock()
+	for _, p := range s.pending {
+		if p.UserID == r.UserID && p.VenueID == r.VenueID {
+			s.mu.Unlock()
+			return
+		}
+	}
+	s.pending = append(s.pending, r)
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO rating_reminders (id, user_id, venue_id, venue_name, due_at, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+			r.ID, r.UserID, r.VenueID, r.VenueName, r.DueAt, r.CreatedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist rating reminder: %v", err)
+		}
+	}
+}
+
+// Start delivers due reminders every interval.
+func (s *ReminderService) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if n := s.Dispatch(time.Now()); n > 0 {
+				log.Printf("Sent %d rating reminders", n)
+			}
+		}
+	}()
+}
+
+// Dispatch sends every reminder due by now whose venue is still unrated by
+// the user, and returns how many were sent. Due reminders are removed either
+// way.
+func (s *ReminderService) Dispatch(now time.Time) int {
+	s.mu.Lock()
+	var due []model.RatingReminder
+	kept := s.pending[:0]
+	for _, r := range s.pending {
+		if r.DueAt.After(now) {
+			kept = append(kept, r)
+		} else {
+			due = append(due, r)
+		}
+	}
+	s.pending = kept
+	notify := s.notify
+	s.mu.Unlock()
+
+	sent := 0
+	for _, r := range due {
+		if notify != nil && !s.ratingSvc.HasRated(r.UserID, r.VenueID) {
+			notify(r)
+			sent++
+		}
+		if s.pool != nil {
+			if _, err := s.pool.Exec(context.Background(), `DELETE FROM rating_reminders WHERE id = $1`, r.ID); err != nil {
+				log.Printf("WARNING: Failed to delete rating reminder: %v", err)
+			}
+		}
+	}
+	return sent
+}
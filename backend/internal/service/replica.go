@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// dbReader is the read side of a pool: *pgxpool.Pool or a *ReadReplica.
+type dbReader interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// ReadReplica sends read-only queries to a replica (DATABASE_READ_URL) and
+// everything else to the primary. While the replica is unreachable reads fall
+// back to the primary; a background ping brings it back once it recovers.
+// Only reads that tolerate a little replication lag should go through it.
+type ReadReplica struct {
+	primary *pgxpool.Pool
+	replica *pgxpool.Pool
+	down    atomic.Bool
+}
+
+func NewReadReplica(primary, replica *pgxpool.Pool) *ReadReplica {
+	return &ReadReplica{primary: primary, replica: replica}
+}
+
+// pool returns where the next read should go.
+func (r *ReadReplica) pool() *pgxpool.Pool {
+	if r.replica == nil || r.down.Load() {
+		return r.primary
+	}
+	return r.replica
+}
+
+// markDown stops using the replica until the health check sees it again.
+func (r *ReadReplica) markDown(err error) {
+	if r.down.CompareAndSwap(false, true) {
+		log.Printf("WARNING: Read replica failed, reading from primary: %v", err)
+	}
+}
+
+// replicaFault reports whether err means the replica itself is unusable, as
+// opposed to the query being wrong or finding nothing.
+func replicaFault(err error) bool {
+	if err == nil || err == pgx.ErrNoRows {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	return !errors.As(err, &pgErr)
+}
+
+func (r *ReadReplica) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	p := r.pool()
+	rows, err := p.Query(ctx, sql, args...)
+	if p != r.primary && replicaFault(err) {
+		r.markDown(err)
+		return r.primary.Query(ctx, sql, args...)
+	}
+	return rows, err
+}
+
+func (r *ReadReplica) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	p := r.pool()
+	if p == r.primary {
+		return p.QueryRow(ctx, sql, args...)
+	}
+	return &fallbackRow{r: r, ctx: ctx, sql: sql, args: args}
+}
+
+// fallbackRow runs its query on the replica when scanned and retries on the
+// primary if the replica fails.
+type fallbackRow struct {
+	r    *ReadReplica
+	ctx  context.Context
+	sql  string
+	args []any
+}
+
+func (f *fallbackRow) Scan(dest ...any) error {
+	err := f.r.replica.QueryRow(f.ctx, f.sql, f.args...).Scan(dest...)
+	if replicaFault(err) {
+		f.r.markDown(err)
+		return f.r.primary.QueryRow(f.ctx, f.sql, f.args...).Scan(dest...)
+	}
+	return err
+}
+
+// Start checks the replica now and then every interval, taking it out of
+// rotation while it fails and putting it back once it answers.
+func (r *ReadReplica) Start(interval time.Duration) {
+	if r.replica == nil {
+		return
+	}
+	r.check()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			r.check()
+		}
+	}()
+}
+
+func (r *ReadReplica) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := r.replica.Ping(ctx); err != nil {
+		r.markDown(err)
+	} else if r.down.CompareAndSwap(true, false) {
+		log.Println("Read replica is back, routing reads to it")
+	}
+}
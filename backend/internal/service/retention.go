@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// Retention policies. Each job belongs to one; the policy decides its cutoff.
+const (
+	RetentionIPData             = "ip_device_data"
+	RetentionInactiveAccounts   = "inactive_accounts"
+	RetentionExpiredCredentials = "expired_credentials"
+)
+
+// RetentionConfig controls how long personal data is kept. A zero period
+// disables that policy.
+type RetentionConfig struct {
+	IPDataDays    int           `json:"ip_data_days"`
+	InactiveYears int           `json:"inactive_years"`
+	Interval      time.Duration `json:"-"`
+}
+
+// LoadRetentionConfig reads RETENTION_IP_DAYS (default 30),
+// RETENTION_INACTIVE_YEARS (default 3) and RETENTION_INTERVAL_HOURS (default 24).
+func LoadRetentionConfig() RetentionConfig {
+	cfg := RetentionConfig{IPDataDays: 30, InactiveYears: 3, Interval: 24 * time.Hour}
+	if v, err := strconv.Atoi(os.Getenv("RETENTION_IP_DAYS")); err == nil && v >= 0 {
+		cfg.IPDataDays = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("RETENTION_INACTIVE_YEARS")); err == nil && v >= 0 {
+		cfg.InactiveYears = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("RETENTION_INTERVAL_HOURS")); err == nil && v > 0 {
+		cfg.Interval = time.Duration(v) * time.Hour
+	}
+	return cfg
+}
+
+// cutoff returns the instant before which a policy's data is due, or false if
+// the policy is disabled.
+func (c RetentionConfig) cutoff(policy string, now time.Time) (time.Time, bool) {
+	switch policy {
+	case RetentionIPData:
+		return now.AddDate(0, 0, -c.IPDataDays), c.IPDataDays > 0
+	case RetentionInactiveAccounts:
+		return now.AddDate(-c.InactiveYears, 0, 0), c.InactiveYears > 0
+	case RetentionExpiredCredentials:
+		return now, true
+	}
+	return time.Time{}, false
+}
+
+// RetentionJob purges or anonymizes one kind of data older than cutoff and
+// returns how many records it touched. With dryRun it only counts them.
+type RetentionJob struct {
+	Policy string
+	Name   string
+	Run    func(ctx context.Context, cutoff time.Time, dryRun bool) (int, error)
+}
+
+// RetentionService runs the registered retention jobs on a schedule and on
+// demand from the admin panel.
+type RetentionService struct {
+	cfg RetentionConfig
+
+	mu   sync.Mutex
+	jobs []RetentionJob
+	last *model.RetentionReport
+}
+
+func NewRetentionService(cfg RetentionConfig) *RetentionService {
+	return &RetentionService{cfg: cfg}
+}
+
+// Config returns the active retention periods.
+func (s *RetentionService) Config() RetentionConfig {
+	return s.cfg
+}
+
+// Register adds a job. Services that store personal data register one for
+// each kind they hold.
+func (s *RetentionService) Register(job RetentionJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, job)
+}
+
+// Run executes every job whose policy is enabled. A dry run reports what
+// would be affected without changing anything.
+func (s *RetentionService) Run(ctx context.Context, dryRun bool) model.RetentionReport {
+	s.mu.Lock()
+	jobs := append([]RetentionJob(nil), s.jobs...)
+	s.mu.Unlock()
+
+	now := time.Now()
+	report := model.RetentionReport{DryRun: dryRun, RanAt: now, Jobs: []model.RetentionJobResult{}}
+	for _, job := range jobs {
+		cutoff, enabled := s.cfg.cutoff(job.Policy, now)
+		if !enabled {
+			continue
+		}
+		n, err := job.Run(ctx, cutoff, dryRun)
+		result := model.RetentionJobResult{Policy: job.Policy, Job: job.Name, Cutoff: cutoff, Affected: n}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		report.Jobs = append(report.Jobs, result)
+	}
+
+	if !dryRun {
+		s.mu.Lock()
+		s.last = &report
+		s.mu.Unlock()
+	}
+	return report
+}
+
+// Jobs returns the names of the registered jobs, keyed by policy.
+func (s *RetentionService) Jobs() map[string][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := map[string][]string{
+		RetentionIPData:             {},
+		RetentionInactiveAccounts:   {},
+		RetentionExpiredCredentials: {},
+	}
+	for _, job := range s.jobs {
+		out[job.Policy] = append(out[job.Policy], job.Name)
+	}
+	return out
+}
+
+// LastReport returns the most recent real (non-dry) run, or nil.
+func (s *RetentionService) LastReport() *model.RetentionReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
+
+// Start runs the jobs every cfg.Interval in the background.
+func (s *RetentionService) Start() {
+	go func() {
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			report := s.Run(context.Background(), false)
+			for _, job := range report.Jobs {
+				if job.Error != "" {
+					log.Printf("WARNING: Retention job %s failed: %s", job.Job, job.Error)
+				} else if job.Affected > 0 {
+					log.Printf("Retention job %s: %d records", job.Job, job.Affected)
+				}
+			}
+		}
+	}()
+}
+
+// ExpiredResetTokensJob deletes password reset tokens past their expiry.
+func ExpiredResetTokensJob(auth *AuthService) RetentionJob {
+	return RetentionJob{
+		Policy: RetentionExpiredCredentials,
+		Name:   "purge_expired_reset_tokens",
+		Run: func(ctx context.Context, cutoff time.Time, dryRun bool) (int, error) {
+			return auth.PurgeExpiredResets(cutoff, dryRun)
+		},
+	}
+}
+
+// ExpiredStudentVerificationsJob deletes unused .edu confirmation tokens.
+func ExpiredStudentVerificationsJob(auth *AuthService) RetentionJob {
+	return RetentionJob{
+		Policy: RetentionExpiredCredentials,
+		Name:   "purge_expired_student_verifications",
+		Run: func(ctx context.Context, cutoff time.Time, dryRun bool) (int, error) {
+			return auth.PurgeExpiredStudentVerifications(cutoff, dryRun)
+		},
+	}
+}
+
+// ExpiredEmailChangesJob deletes unused address-change tokens.
+func ExpiredEmailChangesJob(auth *AuthService) RetentionJob {
+	return RetentionJob{
+		Policy: RetentionExpiredCredentials,
+		Name:   "purge_expired_email_changes",
+		Run: func(ctx context.Context, cutoff time.Time, dryRun bool) (int, error) {
+			return auth.PurgeExpiredEmailChanges(cutoff, dryRun)
+		},
+	}
+}
+
+// ExpiredRevokedTokensJob forgets logged-out JWTs once they have expired.
+func ExpiredRevokedTokensJob(auth *AuthService) RetentionJob {
+	return RetentionJob{
+		Policy: RetentionExpiredCredentials,
+		Name:   "purge_expired_revoked_tokens",
+		Run: func(ctx context.Context, cutoff time.Time, dryRun bool) (int, error) {
+			return auth.PurgeRevokedTokens(cutoff, dryRun)
+		},
+	}
+}
+
+// ExpiredIdentityLinksJob deletes unconfirmed requests to link a Google
+// sign-in to an existing account.
+func ExpiredIdentityLinksJob(auth *AuthService) RetentionJob {
+	return RetentionJob{
+		Policy: RetentionExpiredCredentials,
+		Name:   "purge_expired_identity_links",
+		Run: func(ctx context.Context, cutoff time.Time, dryRun bool) (int, error) {
+			return auth.PurgeExpiredIdentityLinks(cutoff, dryRun)
+		},
+	}
+}
+
+// LoginHistoryJob deletes recorded logins (IP and user agent) older than the
+// IP data cutoff.
+func LoginHistoryJob(auth *AuthService) RetentionJob {
+	return RetentionJob{
+		Policy: RetentionIPData,
+		Name:   "purge_login_history",
+		Run: func(ctx context.Context, cutoff time.Time, dryRun bool) (int, error) {
+			return auth.PurgeLoginHistory(cutoff, dryRun)
+		},
+	}
+}
+
+// ExpiredSessionsJob deletes server-side sessions that have expired.
+func ExpiredSessionsJob(sessions *SessionService) RetentionJob {
+	return RetentionJob{
+		Policy: RetentionExpiredCredentials,
+		Name:   "purge_expired_sessions",
+		Run: func(ctx context.Context, cutoff time.Time, dryRun bool) (int, error) {
+			return sessions.PurgeExpired(cutoff, dryRun)
+		},
+	}
+}
+
+// InactiveAccountsJob anonymizes accounts with no ratings, chapter ratings or
+// check-ins since the cutoff (and created before it).
+func InactiveAccountsJob(auth *AuthService, ratings *RatingService, fratRatings *FratRatingService, checkIns *CheckInService) RetentionJob {
+	return RetentionJob{
+		Policy: RetentionInactiveAccounts,
+		Name:   "anonymize_inactive_accounts",
+		Run: func(ctx context.Context, cutoff time.Time, dryRun bool) (int, error) {
+			candidates, err := auth.AccountsCreatedBefore(cutoff)
+			if err != nil {
+				return 0, err
+			}
+			activity := []map[string]time.Time{
+				ratings.LastActiveByUser(),
+				fratRatings.LastActiveByUser(),
+				checkIns.LastActiveByUser(),
+			}
+
+			n := 0
+			for _, userID := range candidates {
+				active := false
+				for _, last := range activity {
+					if last[userID].After(cutoff) {
+						active = true
+						break
+					}
+				}
+				if active {
+					continue
+				}
+				if !dryRun {
+					if err := auth.Anonymize(userID); err != nil {
+						return n, err
+					}
+					ratings.AnonymizeAuthor(userID)
+					fratRatings.AnonymizeAuthor(userID)
+				}
+				n++
+			}
+			return n, nil
+		},
+	}
+}
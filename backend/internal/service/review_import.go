@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	// maxImportedReviews caps how many reviews one takeout import may hold.
+	maxImportedReviews = 500
+	// importMatchRadiusKm is how far a Google place may be from a venue's
+	// pin and still match it.
+	importMatchRadiusKm = 0.15
+)
+
+// takeoutReview is one review from a Google Maps takeout file, normalized
+// across the current GeoJSON layout and the older capitalized one.
+type takeoutReview struct {
+	name     string
+	lat, lng float64
+	stars    int
+	text     string
+}
+
+// takeoutCoord accepts a coordinate written as a number or, as some older
+// exports do, a string.
+type takeoutCoord float64
+
+func (c *takeoutCoord) UnmarshalJSON(b []byte) error {
+	f, err := strconv.ParseFloat(strings.Trim(string(b), `"`), 64)
+	if err != nil {
+		return err
+	}
+	*c = takeoutCoord(f)
+	return nil
+}
+
+// takeoutFile covers both takeout layouts of "Reviews.json": a GeoJSON
+// FeatureCollection whose properties are snake_case (current) or
+// "Title Case" keys (older exports).
+type takeoutFile struct {
+	Features []struct {
+		Geometry struct {
+			Coordinates []float64 `json:"coordinates"` // lng, lat
+		} `json:"geometry"`
+		Properties struct {
+			Location struct {
+				Name string `json:"name"`
+			} `json:"location"`
+			Stars int    `json:"five_star_rating_published"`
+			Text  string `json:"review_text_published"`
+
+			OldLocation struct {
+				Name   string `json:"Business Name"`
+				Coords struct {
+					Lat takeoutCoord `json:"Latitude"`
+					Lng takeoutCoord `json:"Longitude"`
+				} `json:"Geo Coordinates"`
+			} `json:"Location"`
+			OldStars int    `json:"Star Rating"`
+			OldText  string `json:"Review Comment"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+func parseTakeout(data []byte) ([]takeoutReview, error) {
+	var f takeoutFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("not a Google Maps reviews file: %v", err)
+	}
+	out := make([]takeoutReview, 0, len(f.Features))
+	for _, ft := range f.Features {
+		p := ft.Properties
+		r := takeoutReview{name: p.Location.Name, stars: p.Stars, text: p.Text}
+		if len(ft.Geometry.Coordinates) == 2 {
+			r.lng, r.lat = ft.Geometry.Coordinates[0], ft.Geometry.Coordinates[1]
+		}
+		if r.name == "" {
+			r.name = p.OldLocation.Name
+		}
+		if r.lat == 0 && r.lng == 0 {
+			r.lat, r.lng = float64(p.OldLocation.Coords.Lat), float64(p.OldLocation.Coords.Lng)
+		}
+		if r.stars == 0 {
+			r.stars = p.OldStars
+		}
+		if r.text == "" {
+			r.text = p.OldText
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// importNameKey lowercases a place name and keeps only letters and digits,
+// dropping a leading "the", so "The Kollege Klub" matches "Kollege Klub".
+func importNameKey(name string) string {
+	name = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), "the ")
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func importNamesMatch(a, b string) bool {
+	a, b = importNameKey(a), importNameKey(b)
+	if a == "" || b == "" {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	// "Nitty Gritty" vs "The Nitty Gritty Madison"
+	return min(len(a), len(b)) >= 5 && (strings.Contains(a, b) || strings.Contains(b, a))
+}
+
+// ReviewImportService brings a user's own reviews from elsewhere into
+// ratings on matching venues.
+type ReviewImportService struct {
+	ratings *RatingService
+	venues  *VenueService
+}
+
+func NewReviewImportService(ratings *RatingService, venues *VenueService) *ReviewImportService {
+	return &ReviewImportService{ratings: ratings, venues: venues}
+}
+
+// matchVenue returns the closest approved venue near the place whose name
+// matches it.
+func (s *ReviewImportService) matchVenue(r takeoutReview) *model.Venue {
+	var best *model.Venue
+	bestDist := math.Inf(1)
+	for _, v := range s.venues.GetNearby(r.lat, r.lng, importMatchRadiusKm, "") {
+		if !importNamesMatch(r.name, v.Name) {
+			continue
+		}
+		if d := haversineKm(r.lat, r.lng, v.Latitude, v.Longitude); d < bestDist {
+			v := v
+			best, bestDist = &v, d
+		}
+	}
+	return best
+}
+
+// ImportGoogleTakeout rates the current user's matching venues from a Google
+// Maps takeout "Reviews.json". Places are matched by name within 150 m of a
+// venue; unmatched places, venues the user already rated and reviews without
+// stars are skipped. With dryRun nothing is saved.
+func (s *ReviewImportService) ImportGoogleTakeout(ctx context.Context, data []byte, dryRun bool) (*model.ReviewImportResult, error) {
+	reviews, err := parseTakeout(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviews) > maxImportedReviews {
+		return nil, fmt.Errorf("at most %d reviews per import", maxImportedReviews)
+	}
+
+	result := &model.ReviewImportResult{
+		DryRun:   dryRun,
+		Imported: []model.ReviewImportItem{},
+		Skipped:  []model.ReviewImportItem{},
+	}
+	userID := middleware.GetUserID(ctx)
+	planned := map[string]bool{} // venue IDs a dry run would rate
+	skip := func(item model.ReviewImportItem, reason string) {
+		item.Reason = reason
+		result.Skipped = append(result.Skipped, item)
+	}
+
+	for _, r := range reviews {
+		item := model.ReviewImportItem{Place: r.name, Score: float32(r.stars)}
+		if r.stars < 1 || r.stars > 5 {
+			skip(item, "no star rating")
+			continue
+		}
+		if r.lat == 0 && r.lng == 0 {
+			skip(item, "no location")
+			continue
+		}
+		v := s.matchVenue(r)
+		if v == nil {
+			skip(item, "no matching venue")
+			continue
+		}
+		item.VenueID, item.VenueName = v.ID, v.Name
+
+		if dryRun {
+			if planned[v.ID] || s.ratings.HasRated(userID, v.ID) {
+				skip(item, "you have already rated this venue")
+				continue
+			}
+			planned[v.ID] = true
+			result.Imported = append(result.Imported, item)
+			continue
+		}
+
+		rating, err := s.ratings.Create(ctx, model.CreateRatingRequest{
+			Score:    item.Score,
+			Review:   r.text,
+			VenueID:  v.ID,
+			Imported: true,
+		})
+		if err != nil {
+			skip(item, err.Error())
+			continue
+		}
+		item.RatingID = rating.ID
+		item.Pending = rating.PendingReview
+		result.Imported = append(result.Imported, item)
+	}
+	return result, nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	reviewSnippetRadius = 60
+	maxSearchTerms      = 8
+)
+
+// reviewIndex is an inverted index from lowercased review words to rating IDs.
+// Callers hold RatingService.mu.
+type reviewIndex struct {
+	postings map[string]map[string]struct{}
+}
+
+func newReviewIndex() *reviewIndex {
+	return &reviewIndex{postings: make(map[string]map[string]struct{})}
+}
+
+// tokenize splits text into lowercased words of letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+}
+
+func (ix *reviewIndex) add(r model.Rating) {
+	for _, tok := range tokenize(r.Review) {
+		ids, ok := ix.postings[tok]
+		if !ok {
+			ids = make(map[string]struct{})
+			ix.postings[tok] = ids
+		}
+		ids[r.ID] = struct{}{}
+	}
+}
+
+func (ix *reviewIndex) remove(r model.Rating) {
+	for _, tok := range tokenize(r.Review) {
+		if ids, ok := ix.postings[tok]; ok {
+			delete(ids, r.ID)
+			if len(ids) == 0 {
+				delete(ix.postings, tok)
+			}
+		}
+	}
+}
+
+// lookup returns the IDs of ratings containing every term.
+func (ix *reviewIndex) lookup(terms []string) map[string]struct{} {
+	var out map[string]struct{}
+	for _, t := range terms {
+		ids := ix.postings[t]
+		if len(ids) == 0 {
+			return nil
+		}
+		if out == nil {
+			out = make(map[string]struct{}, len(ids))
+			for id := range ids {
+				out[id] = struct{}{}
+			}
+			continue
+		}
+		for id := range out {
+			if _, ok := ids[id]; !ok {
+				delete(out, id)
+			}
+		}
+	}
+	return out
+}
+
+// SearchReviews returns ratings at the given venues whose review contains every
+// word of the query, with an HTML-escaped snippet highlighting the matches in
+// <mark> tags. Exact phrase matches rank first, then newest.
+func (s *RatingService) SearchReviews(query string, venueIDs []string, limit int) ([]model.ReviewSearchResult, error) {
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("q is required")
+	}
+	if len(terms) > maxSearchTerms {
+		return nil, fmt.Errorf("search at most %d words", maxSearchTerms)
+	}
+
+	inScope := make(map[string]bool, len(venueIDs))
+	for _, id := range venueIDs {
+		inScope[id] = true
+	}
+	phrase := " " + strings.Join(terms, " ") + " "
+
+	s.mu.RLock()
+	matches := s.index.lookup(terms)
+	var results []model.ReviewSearchResult
+	for _, r := range s.scopeLocked(venueIDs...) {
+		if !inScope[r.VenueID] {
+			continue
+		}
+		if s.cache != nil {
+			if !hasAllTerms(r.Review, terms) {
+				continue
+			}
+		} else if _, ok := matches[r.ID]; !ok {
+			continue
+		}
+		results = append(results, model.ReviewSearchResult{
+			Rating:      r,
+			Snippet:     highlightSnippet(r.Review, terms),
+			PhraseMatch: strings.Contains(" "+strings.Join(tokenize(r.Review), " ")+" ", phrase),
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].PhraseMatch != results[j].PhraseMatch {
+			return results[i].PhraseMatch
+		}
+		return results[i].Rating.CreatedAt.After(results[j].Rating.CreatedAt)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	if results == nil {
+		results = []model.ReviewSearchResult{}
+	}
+	return results, nil
+}
+
+// highlightSnippet cuts a window of text around the first matching term and
+// wraps every whole-word match in <mark>. Everything else is HTML-escaped.
+func highlightSnippet(text string, terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = regexp.QuoteMeta(t)
+	}
+	re := regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+
+	start, end := 0, len(text)
+	if loc := re.FindStringIndex(text); loc != nil {
+		start = max(0, loc[0]-reviewSnippetRadius)
+		end = min(len(text), loc[1]+reviewSnippetRadius)
+	} else if end > 2*reviewSnippetRadius {
+		end = 2 * reviewSnippetRadius
+	}
+	// Don't cut through a multi-byte character.
+	for start > 0 && !isRuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !isRuneStart(text[end]) {
+		end++
+	}
+	window := text[start:end]
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	last := 0
+	for _, loc := range re.FindAllStringIndex(window, -1) {
+		b.WriteString(html.EscapeString(window[last:loc[0]]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(window[loc[0]:loc[1]]))
+		b.WriteString("</mark>")
+		last = loc[1]
+	}
+	b.WriteString(html.EscapeString(window[last:]))
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// RideshareConfig controls the "get a ride here" deep links added to venue details.
+type RideshareConfig struct {
+	Enabled      bool
+	UberClientID string
+	LyftClientID string
+}
+
+// LoadRideshareConfig reads RIDESHARE_LINKS_ENABLED (default true), UBER_CLIENT_ID,
+// and LYFT_CLIENT_ID from the environment.
+func LoadRideshareConfig() RideshareConfig {
+	enabled := true
+	switch strings.ToLower(os.Getenv("RIDESHARE_LINKS_ENABLED")) {
+	case "0", "false", "no", "off":
+		enabled = false
+	}
+	return RideshareConfig{
+		Enabled:      enabled,
+		UberClientID: os.Getenv("UBER_CLIENT_ID"),
+		LyftClientID: os.Getenv("LYFT_CLIENT_ID"),
+	}
+}
+
+// Links returns Uber and Lyft universal links with the venue prefilled as the
+// destination. Venues without coordinates get no links.
+func (c RideshareConfig) Links(v model.Venue) []model.RideshareLink {
+	if !c.Enabled || (v.Latitude == 0 && v.Longitude == 0) {
+		return nil
+	}
+	lat := formatCoord(v.Latitude)
+	lng := formatCoord(v.Longitude)
+
+	uber := url.Values{}
+	uber.Set("action", "setPickup")
+	uber.Set("pickup", "my_location")
+	uber.Set("dropoff[latitude]", lat)
+	uber.Set("dropoff[longitude]", lng)
+	uber.Set("dropoff[nickname]", v.Name)
+	if v.Address != "" {
+		uber.Set("dropoff[formatted_address]", v.Address)
+	}
+	if c.UberClientID != "" {
+		uber.Set("client_id", c.UberClientID)
+	}
+
+	lyft := url.Values{}
+	lyft.Set("id", "lyft")
+	lyft.Set("destination[latitude]", lat)
+	lyft.Set("destination[longitude]", lng)
+	if c.LyftClientID != "" {
+		lyft.Set("partner", c.LyftClientID)
+	}
+
+	return []model.RideshareLink{
+		{Provider: "uber", Label: "Ride there with Uber", URL: "https://m.uber.com/ul/?" + uber.Encode()},
+		{Provider: "lyft", Label: "Ride there with Lyft", URL: "https://lyft.com/ride?" + lyft.Encode()},
+	}
+}
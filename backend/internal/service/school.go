@@ -1,11 +1,17 @@
 package service
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
@@ -21,6 +27,12 @@ type SchoolService struct {
 	schools []model.School
 	byID    map[string]*model.School
 	byState map[string][]*model.School
+	grid    map[geoCell][]*model.School // spatial index; schools without coordinates are left out
+
+	// fratSearch finds schools hosting the fraternity a query names; optional.
+	fratSearch func(query string) map[string]bool
+
+	datasets []model.SchoolDataset // one per loaded file, for /api/status
 }
 
 func NewSchoolService() *SchoolService {
@@ -30,69 +42,91 @@ func NewSchoolService() *SchoolService {
 	}
 }
 
-// LoadFromBytes loads school data from raw JSON bytes.
-func (s *SchoolService) LoadFromBytes(data []byte) error {
-	return s.loadData(data)
+// rawSchool is one entry of a schools JSON file.
+type rawSchool struct {
+	ID             string  `json:"id"`
+	UnitID         int     `json:"unitid"`
+	Name           string  `json:"name"`
+	Alias          string  `json:"alias"`
+	Address        string  `json:"address"`
+	City           string  `json:"city"`
+	State          string  `json:"state"`
+	Zip            string  `json:"zip"`
+	Country        string  `json:"country"`
+	Control        string  `json:"control"`
+	ICLevel        int     `json:"iclevel"`
+	Website        string  `json:"website"`
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	County         string  `json:"county"`
+	Locale         int     `json:"locale"`
+	HBCU           bool    `json:"hbcu"`
+	Sector         int     `json:"sector"`
+	InstSize       int     `json:"instsize"`
+	IsOnline       bool    `json:"is_online"`
+	IsTribal       bool    `json:"is_tribal"`
+	IsReligious    bool    `json:"is_religious"`
+	IsCommunityCol bool    `json:"is_community_college"`
+	IsLiberalArts  bool    `json:"is_liberal_arts"`
+	IsGraduateOnly bool    `json:"is_graduate_only"`
+}
+
+// LoadFromBytes loads a region's school data from raw JSON bytes, adding it
+// to any regions already loaded.
+func (s *SchoolService) LoadFromBytes(data []byte, region string) error {
+	return s.loadData(bytes.NewReader(data), region, "embedded")
 }
 
-// LoadFromJSON loads school data from the JSON file.
-func (s *SchoolService) LoadFromJSON(path string) error {
-	data, err := os.ReadFile(path)
+// LoadFromJSON loads a region's school data from a JSON file, adding it to
+// any regions already loaded. The file is decoded one school at a time
+// rather than read into memory whole.
+func (s *SchoolService) LoadFromJSON(path, region string) error {
+	f, err := os.Open(path)
 	if err != nil {
 		return fmt.Errorf("failed to read schools file: %w", err)
 	}
-	return s.loadData(data)
-}
-
-func (s *SchoolService) loadData(data []byte) error {
-
-	var rawSchools []struct {
-		UnitID         int     `json:"unitid"`
-		Name           string  `json:"name"`
-		Alias          string  `json:"alias"`
-		Address        string  `json:"address"`
-		City           string  `json:"city"`
-		State          string  `json:"state"`
-		Zip            string  `json:"zip"`
-		Control        string  `json:"control"`
-		ICLevel        int     `json:"iclevel"`
-		Website        string  `json:"website"`
-		Latitude       float64 `json:"latitude"`
-		Longitude      float64 `json:"longitude"`
-		County         string  `json:"county"`
-		Locale         int     `json:"locale"`
-		HBCU           bool    `json:"hbcu"`
-		Sector         int     `json:"sector"`
-		InstSize       int     `json:"instsize"`
-		IsOnline       bool    `json:"is_online"`
-		IsTribal       bool    `json:"is_tribal"`
-		IsReligious    bool    `json:"is_religious"`
-		IsCommunityCol bool    `json:"is_community_college"`
-		IsLiberalArts  bool    `json:"is_liberal_arts"`
-		IsGraduateOnly bool    `json:"is_graduate_only"`
-	}
-
-	if err := json.Unmarshal(data, &rawSchools); err != nil {
-		return fmt.Errorf("failed to parse schools JSON: %w", err)
-	}
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	defer f.Close()
+	return s.loadData(bufio.NewReader(f), region, filepath.Base(path))
+}
 
-	s.schools = make([]model.School, 0, len(rawSchools))
-	s.byID = make(map[string]*model.School, len(rawSchools))
-	s.byState = make(map[string][]*model.School)
+// loadData decodes a schools array, recording the source's SHA-256 so
+// operators can tell which data a deploy is serving.
+func (s *SchoolService) loadData(r io.Reader, region, source string) error {
+	hash := sha256.New()
+	r = io.TeeReader(r, hash)
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return fmt.Errorf("failed to parse schools JSON: expected an array")
+	}
+	var loaded []model.School
+	for dec.More() {
+		var rs rawSchool
+		if err := dec.Decode(&rs); err != nil {
+			return fmt.Errorf("failed to parse schools JSON: %w", err)
+		}
+		id := rs.ID
+		if id == "" {
+			id = fmt.Sprintf("%d", rs.UnitID)
+		}
+		country := NormalizeRegion(rs.Country)
+		if country == "" {
+			country = region
+		}
+		if country != region {
+			continue
+		}
 
-	for _, rs := range rawSchools {
-		school := model.School{
-			ID:             fmt.Sprintf("%d", rs.UnitID),
+		loaded = append(loaded, model.School{
+			ID:             id,
 			UnitID:         rs.UnitID,
 			Name:           rs.Name,
 			AliasName:      rs.Alias,
 			Address:        rs.Address,
 			City:           rs.City,
+			CitySlug:       NormalizeCity(rs.City),
 			State:          rs.State,
 			Zip:            rs.Zip,
+			Country:        country,
 			Control:        rs.Control,
 			ICLevel:        rs.ICLevel,
 			Website:        rs.Website,
@@ -108,12 +142,43 @@ func (s *SchoolService) loadData(data []byte) error {
 			IsCommunityCol: rs.IsCommunityCol,
 			IsLiberalArts:  rs.IsLiberalArts,
 			IsGraduateOnly: rs.IsGraduateOnly,
-		}
+		})
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to parse schools JSON: %w", err)
+	}
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return fmt.Errorf("failed to read schools JSON: %w", err)
+	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.datasets = append(s.datasets, model.SchoolDataset{
+		Region:  region,
+		Source:  source,
+		Schools: len(loaded),
+		SHA256:  hex.EncodeToString(hash.Sum(nil)),
+	})
+
+	for _, school := range loaded {
+		if _, dup := s.byID[school.ID]; dup {
+			continue
+		}
 		s.schools = append(s.schools, school)
-		ptr := &s.schools[len(s.schools)-1]
-		s.byID[school.ID] = ptr
-		s.byState[school.State] = append(s.byState[school.State], ptr)
+	}
+
+	// Appending may have moved the backing array, so rebuild the indexes.
+	s.byID = make(map[string]*model.School, len(s.schools))
+	s.byState = make(map[string][]*model.School)
+	s.grid = make(map[geoCell][]*model.School)
+	for i := range s.schools {
+		ptr := &s.schools[i]
+		s.byID[ptr.ID] = ptr
+		s.byState[ptr.State] = append(s.byState[ptr.State], ptr)
+		if ptr.Latitude != 0 || ptr.Longitude != 0 {
+			cell := cellOf(ptr.Latitude, ptr.Longitude)
+			s.grid[cell] = append(s.grid[cell], ptr)
+		}
 	}
 
 	return nil
@@ -127,9 +192,6 @@ func (s *SchoolService) Search(_ context.Context, params model.SchoolSearchParam
 	if params.Limit <= 0 {
 		params.Limit = 50
 	}
-	if params.Limit > 200 {
-		params.Limit = 200
-	}
 	if params.Page <= 0 {
 		params.Page = 1
 	}
@@ -147,9 +209,19 @@ func (s *SchoolService) Search(_ context.Context, params model.SchoolSearchParam
 		}
 	}
 
-	query := strings.ToLower(strings.TrimSpace(params.Query))
+	query := foldSearchText(params.Query)
+	country := NormalizeRegion(params.Country)
+	var fratHits map[string]bool
+	if query != "" && s.fratSearch != nil {
+		fratHits = s.fratSearch(params.Query)
+	}
 
 	for _, school := range candidates {
+		// Region filter
+		if country != "" && school.Country != country {
+			continue
+		}
+
 		// Control filter
 		if params.Control != "" && school.Control != params.Control {
 			continue
@@ -170,12 +242,13 @@ func (s *SchoolService) Search(_ context.Context, params model.SchoolSearchParam
 
 		// Text search filter
 		if query != "" {
-			name := strings.ToLower(school.Name)
-			alias := strings.ToLower(school.AliasName)
-			city := strings.ToLower(school.City)
+			name := foldSearchText(school.Name)
+			alias := foldSearchText(school.AliasName)
+			city := foldSearchText(school.City)
 			if !strings.Contains(name, query) &&
 				!strings.Contains(alias, query) &&
-				!strings.Contains(city, query) {
+				!strings.Contains(city, query) &&
+				!fratHits[school.ID] {
 				continue
 			}
 		}
@@ -221,6 +294,13 @@ func (s *SchoolService) Search(_ context.Context, params model.SchoolSearchParam
 	}, nil
 }
 
+// foldSearchText lower-cases s and drops apostrophes so "queens" matches
+// "Queen's University".
+func foldSearchText(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.NewReplacer("'", "", "’", "").Replace(s)
+}
+
 // GetByID retrieves a school by its UNITID.
 func (s *SchoolService) GetByID(_ context.Context, id string) (*model.School, error) {
 	s.mu.RLock()
@@ -233,13 +313,206 @@ func (s *SchoolService) GetByID(_ context.Context, id string) (*model.School, er
 	return school, nil
 }
 
-// GetGeo returns all schools within a bounding box for map display.
-func (s *SchoolService) GetGeo(_ context.Context, minLat, maxLat, minLng, maxLng float64) ([]model.School, error) {
+// Summary returns the compact form of a school.
+func (s *SchoolService) Summary(id string) (*model.SchoolSummary, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	school, ok := s.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("school not found: %s", id)
+	}
+	summary := schoolSummary(school)
+	return &summary, nil
+}
+
+// Nearby returns up to limit schools within radiusKm of a point, closest first.
+func (s *SchoolService) Nearby(lat, lng, radiusKm float64, limit int) []model.SchoolSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.nearby(lat, lng, radiusKm, limit, "")
+}
+
+// NearbySchool returns up to limit other schools within radiusKm of a
+// school, closest first.
+func (s *SchoolService) NearbySchool(id string, radiusKm float64, limit int) ([]model.SchoolSummary, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	school, ok := s.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("school not found: %s", id)
+	}
+	if school.Latitude == 0 && school.Longitude == 0 {
+		return []model.SchoolSummary{}, nil
+	}
+	return s.nearby(school.Latitude, school.Longitude, radiusKm, limit, id), nil
+}
+
+// nearby searches the spatial index. Callers hold s.mu.
+func (s *SchoolService) nearby(lat, lng, radiusKm float64, limit int, excludeID string) []model.SchoolSummary {
+	out := []model.SchoolSummary{}
+	for _, cell := range cellsWithin(lat, lng, radiusKm) {
+		for _, school := range s.grid[cell] {
+			if school.ID == excludeID {
+				continue
+			}
+			d := haversineKm(lat, lng, school.Latitude, school.Longitude)
+			if d > radiusKm {
+				continue
+			}
+			summary := schoolSummary(school)
+			d = math.Round(d*10) / 10
+			summary.DistanceKm = &d
+			out = append(out, summary)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if *out[i].DistanceKm != *out[j].DistanceKm {
+			return *out[i].DistanceKm < *out[j].DistanceKm
+		}
+		return out[i].ID < out[j].ID
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// InCity returns the schools in a city (by NormalizeCity slug), highest
+// party score first.
+func (s *SchoolService) InCity(state, slug string) []model.SchoolSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := []model.SchoolSummary{}
+	for _, school := range s.byState[state] {
+		if school.CitySlug == slug {
+			out = append(out, schoolSummary(school))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].PartyScore != out[j].PartyScore {
+			return out[i].PartyScore > out[j].PartyScore
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// websiteDomain reduces a school website to its .edu domain
+// ("www.asu.edu/" -> "asu.edu"), or "" for non-.edu sites.
+func websiteDomain(website string) string {
+	host := strings.ToLower(strings.TrimSpace(website))
+	host = strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 || labels[len(labels)-1] != "edu" {
+		return ""
+	}
+	return strings.Join(labels[len(labels)-2:], ".")
+}
+
+// ForEmailDomain returns the schools whose website shares a .edu email
+// domain. Subdomains match too ("mail.asu.edu" finds asu.edu schools).
+func (s *SchoolService) ForEmailDomain(domain string) []model.SchoolSummary {
+	d := websiteDomain(domain)
+	if d == "" {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []model.SchoolSummary
+	for i := range s.schools {
+		if websiteDomain(s.schools[i].Website) == d {
+			out = append(out, schoolSummary(&s.schools[i]))
+		}
+	}
+	return out
+}
+
+func schoolSummary(school *model.School) model.SchoolSummary {
+	return model.SchoolSummary{
+		ID:         school.ID,
+		Name:       school.Name,
+		City:       school.City,
+		State:      school.State,
+		Country:    school.Country,
+		Latitude:   school.Latitude,
+		Longitude:  school.Longitude,
+		VenueCount: school.VenueCount,
+		AvgRating:  school.AvgRating,
+		PartyScore: int(partyScore(school)),
+		Conference: school.Conference,
+		Links:      school.Links,
+	}
+}
+
+// partyScore ranks schools on the leaderboard: up to 60 points for having
+// venues (capped at 5) and up to 40 for their average rating.
+func partyScore(school *model.School) float64 {
+	venueScore := float64(school.VenueCount)
+	if venueScore > 5 {
+		venueScore = 5
+	}
+	return (venueScore / 5 * 60) + (school.AvgRating / 5 * 40)
+}
+
+// LoadConferences assigns athletic conferences from JSON mapping a conference
+// name to the IDs of its member schools. Unknown IDs are ignored.
+func (s *SchoolService) LoadConferences(data []byte) (int, error) {
+	var members map[string][]string
+	if err := json.Unmarshal(data, &members); err != nil {
+		return 0, fmt.Errorf("failed to parse conferences JSON: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for conference, ids := range members {
+		for _, id := range ids {
+			if school, ok := s.byID[id]; ok {
+				school.Conference = conference
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+// Conferences returns the names of all conferences with at least one loaded
+// school, sorted.
+func (s *SchoolService) Conferences() []string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	seen := make(map[string]bool)
+	out := []string{}
+	for i := range s.schools {
+		c := s.schools[i].Conference
+		if c != "" && !seen[c] {
+			seen[c] = true
+			out = append(out, c)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// GetGeo returns all schools within a bounding box for map display,
+// optionally limited to one region.
+func (s *SchoolService) GetGeo(_ context.Context, country string, minLat, maxLat, minLng, maxLng float64) ([]model.School, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	country = NormalizeRegion(country)
 	var results []model.School
 	for _, school := range s.schools {
+		if country != "" && school.Country != country {
+			continue
+		}
 		if school.Latitude >= minLat && school.Latitude <= maxLat &&
 			school.Longitude >= minLng && school.Longitude <= maxLng {
 			results = append(results, school)
@@ -248,19 +521,25 @@ func (s *SchoolService) GetGeo(_ context.Context, minLat, maxLat, minLng, maxLng
 	return results, nil
 }
 
-// GetAllForMap returns minimal data for all schools (for initial map load).
-func (s *SchoolService) GetAllForMap(_ context.Context) ([]map[string]interface{}, error) {
+// GetAllForMap returns minimal data for all schools (for initial map load),
+// optionally limited to one region.
+func (s *SchoolService) GetAllForMap(_ context.Context, country string) ([]map[string]interface{}, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	country = NormalizeRegion(country)
 	results := make([]map[string]interface{}, 0, len(s.schools))
 	for _, school := range s.schools {
+		if country != "" && school.Country != country {
+			continue
+		}
 		results = append(results, map[string]interface{}{
 			"id":                   school.ID,
 			"name":                 school.Name,
 			"latitude":             school.Latitude,
 			"longitude":            school.Longitude,
 			"state":                school.State,
+			"country":              school.Country,
 			"control":              school.Control,
 			"iclevel":              school.ICLevel,
 			"venue_count":          school.VenueCount,
@@ -275,17 +554,27 @@ func (s *SchoolService) GetAllForMap(_ context.Context) ([]map[string]interface{
 			"is_liberal_arts":      school.IsLiberalArts,
 			"is_graduate_only":     school.IsGraduateOnly,
 		})
+		if school.GreekOptOut {
+			last := results[len(results)-1]
+			delete(last, "frat_count")
+			last["greek_opt_out"] = true
+		}
 	}
 	return results, nil
 }
 
-// GetStates returns all unique states.
-func (s *SchoolService) GetStates(_ context.Context) []string {
+// GetStates returns all unique states (provinces outside the US), optionally
+// limited to one region.
+func (s *SchoolService) GetStates(_ context.Context, country string) []string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	country = NormalizeRegion(country)
 	states := make([]string, 0, len(s.byState))
-	for state := range s.byState {
+	for state, schools := range s.byState {
+		if country != "" && schools[0].Country != country {
+			continue
+		}
 		states = append(states, state)
 	}
 	return states
@@ -298,27 +587,32 @@ func (s *SchoolService) Count() int {
 	return len(s.schools)
 }
 
-// UpdateVenueCounts updates each school's VenueCount based on venue data.
+// Datasets describes the school files loaded at startup, in load order.
+func (s *SchoolService) Datasets() []model.SchoolDataset {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]model.SchoolDataset{}, s.datasets...)
+}
+
+// UpdateVenueCounts sets each school's VenueCount; schools missing from the
+// map have none.
 func (s *SchoolService) UpdateVenueCounts(venueCounts map[string]int) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	for i := range s.schools {
-		if count, ok := venueCounts[s.schools[i].ID]; ok {
-			s.schools[i].VenueCount = count
-		}
+		s.schools[i].VenueCount = venueCounts[s.schools[i].ID]
 	}
 }
 
-// UpdateSchoolRatings updates venue avg ratings via a callback.
+// UpdateSchoolRatings sets each school's average venue rating; schools
+// missing from the map are unrated.
 func (s *SchoolService) UpdateSchoolRatings(schoolAvgs map[string]float64) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	for i := range s.schools {
-		if avg, ok := schoolAvgs[s.schools[i].ID]; ok {
-			s.schools[i].AvgRating = avg
-		}
+		s.schools[i].AvgRating = RoundAverage(schoolAvgs[s.schools[i].ID])
 	}
 }
 
@@ -329,14 +623,39 @@ func (s *SchoolService) UpdateSingleSchoolRating(schoolID string, avgRating floa
 
 	for i := range s.schools {
 		if s.schools[i].ID == schoolID {
-			s.schools[i].AvgRating = avgRating
+			s.schools[i].AvgRating = RoundAverage(avgRating)
 			return
 		}
 	}
 }
 
-// GetTopSchools returns schools sorted by party score for the leaderboard.
-func (s *SchoolService) GetTopSchools(limit int) []map[string]interface{} {
+// UpdateSchoolRecommend sets each school's would-recommend percentage;
+// schools missing from the map have none.
+func (s *SchoolService) UpdateSchoolRecommend(pcts map[string]*int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.schools {
+		s.schools[i].RecommendPct = pcts[s.schools[i].ID]
+	}
+}
+
+// UpdateSingleSchoolRecommend sets the would-recommend percentage for one school.
+func (s *SchoolService) UpdateSingleSchoolRecommend(schoolID string, pct *int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.schools {
+		if s.schools[i].ID == schoolID {
+			s.schools[i].RecommendPct = pct
+			return
+		}
+	}
+}
+
+// GetTopSchools returns schools sorted by party score for the leaderboard,
+// optionally limited to a region and/or athletic conference.
+func (s *SchoolService) GetTopSchools(limit int, country, conference string) []map[string]interface{} {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -345,17 +664,19 @@ func (s *SchoolService) GetTopSchools(limit int) []map[string]interface{} {
 		score  float64
 	}
 
+	country = NormalizeRegion(country)
 	var list []scored
 	for _, school := range s.schools {
 		if school.VenueCount == 0 && school.AvgRating == 0 {
 			continue
 		}
-		venueScore := float64(school.VenueCount)
-		if venueScore > 5 {
-			venueScore = 5
+		if country != "" && school.Country != country {
+			continue
+		}
+		if conference != "" && !strings.EqualFold(school.Conference, conference) {
+			continue
 		}
-		score := (venueScore / 5 * 60) + (school.AvgRating / 5 * 40)
-		list = append(list, scored{school: school, score: score})
+		list = append(list, scored{school: school, score: partyScore(&school)})
 	}
 
 	// Sort by score desc
@@ -378,16 +699,64 @@ func (s *SchoolService) GetTopSchools(limit int) []map[string]interface{} {
 			"id":          item.school.ID,
 			"name":        item.school.Name,
 			"state":       item.school.State,
+			"country":     item.school.Country,
 			"control":     item.school.Control,
 			"venue_count": item.school.VenueCount,
 			"avg_rating":  item.school.AvgRating,
 			"frat_count":  item.school.FratCount,
 			"party_score": int(item.score),
 		}
+		if item.school.Conference != "" {
+			results[i]["conference"] = item.school.Conference
+		}
+		if item.school.GreekOptOut {
+			delete(results[i], "frat_count")
+		}
+		if item.school.RecommendPct != nil {
+			results[i]["recommend_pct"] = *item.school.RecommendPct
+		}
 	}
 	return results
 }
 
+// SetGreekOptOut flags a school as having opted out of Greek life listings
+// and refreshes its frat count. It returns false if the school doesn't exist.
+func (s *SchoolService) SetGreekOptOut(schoolID string, optOut bool, fratCount int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.schools {
+		if s.schools[i].ID == schoolID {
+			s.schools[i].GreekOptOut = optOut
+			s.schools[i].FratCount = fratCount
+			return true
+		}
+	}
+	return false
+}
+
+// SetLinks replaces a school's nightlife links. It returns false if the
+// school doesn't exist.
+func (s *SchoolService) SetLinks(schoolID string, links []model.SchoolLink) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	school, ok := s.byID[schoolID]
+	if !ok {
+		return false
+	}
+	school.Links = links
+	return true
+}
+
+// SetFratSearch lets text search also match schools by fraternity, so "SAE"
+// finds schools with a Sigma Alpha Epsilon chapter.
+func (s *SchoolService) SetFratSearch(fn func(query string) map[string]bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fratSearch = fn
+}
+
 // UpdateFratCounts updates each school's FratCount using a lookup function.
 func (s *SchoolService) UpdateFratCounts(countFn func(string) int) {
 	s.mu.Lock()
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	maxSchoolLinks         = 10
+	maxSchoolLinkLabel     = 60
+	maxSchoolLinkURLLength = 500
+)
+
+// schoolLinkHosts lists the sites each link kind may point at. "website"
+// accepts any host.
+var schoolLinkHosts = map[string][]string{
+	"instagram": {"instagram.com"},
+	"tiktok":    {"tiktok.com"},
+	"x":         {"x.com", "twitter.com"},
+	"barstool":  {"barstoolsports.com"},
+	"website":   nil,
+}
+
+// SchoolLinkService manages the nightlife links (Instagram accounts,
+// Barstool affiliates, ...) moderators attach to schools. Links are copied
+// onto the schools so they come back with every school and summary.
+type SchoolLinkService struct {
+	mu    sync.Mutex
+	pool  *pgxpool.Pool
+	links map[string][]model.SchoolLink // school ID -> links, oldest first
+
+	schools *SchoolService
+}
+
+func NewSchoolLinkService(pool *pgxpool.Pool, schools *SchoolService) *SchoolLinkService {
+	svc := &SchoolLinkService{
+		pool:    pool,
+		links:   make(map[string][]model.SchoolLink),
+		schools: schools,
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *SchoolLinkService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, school_id, kind, label, url FROM school_links ORDER BY created_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load school links from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var l model.SchoolLink
+		var schoolID string
+		if err := rows.Scan(&l.ID, &schoolID, &l.Kind, &l.Label, &l.URL); err != nil {
+			log.Printf("WARNING: Failed to scan school link row: %v", err)
+			continue
+		}
+		s.links[schoolID] = append(s.links[schoolID], l)
+		n++
+	}
+	for schoolID, links := range s.links {
+		s.schools.SetLinks(schoolID, links)
+	}
+	log.Printf("Loaded %d school links from DB", n)
+}
+
+// normalizeSchoolLinkURL checks that raw is an https URL on a host allowed
+// for kind and returns it without credentials or fragment.
+func normalizeSchoolLinkURL(kind, raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("url is required")
+	}
+	if len(raw) > maxSchoolLinkURLLength {
+		return "", fmt.Errorf("url must be at most %d characters", maxSchoolLinkURLLength)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("url is not valid")
+	}
+	if u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+	if u.Scheme != "https" {
+		return "", fmt.Errorf("url must use https")
+	}
+	if u.User != nil {
+		return "", fmt.Errorf("url must not contain credentials")
+	}
+	host := strings.ToLower(u.Hostname())
+	if u.Port() != "" {
+		return "", fmt.Errorf("url must not specify a port")
+	}
+
+	if allowed := schoolLinkHosts[kind]; allowed != nil {
+		ok := false
+		for _, h := range allowed {
+			if host == h || strings.HasSuffix(host, "."+h) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return "", fmt.Errorf("%s links must point to %s", kind, strings.Join(allowed, " or "))
+		}
+	} else if !strings.Contains(host, ".") {
+		return "", fmt.Errorf("url is not valid")
+	}
+
+	u.Host = host
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String(), nil
+}
+
+// Add attaches a link to a school.
+func (s *SchoolLinkService) Add(ctx context.Context, schoolID string, req model.CreateSchoolLinkRequest) (*model.SchoolLink, error) {
+	if _, err := s.schools.GetByID(ctx, schoolID); err != nil {
+		return nil, fmt.Errorf("school not found")
+	}
+	kind := strings.ToLower(strings.TrimSpace(req.Kind))
+	if _, ok := schoolLinkHosts[kind]; !ok {
+		return nil, fmt.Errorf("kind must be one of instagram, tiktok, x, barstool, website")
+	}
+	label := strings.TrimSpace(middleware.SanitizeString(req.Label))
+	if len(label) > maxSchoolLinkLabel {
+		return nil, fmt.Errorf("label must be at most %d characters", maxSchoolLinkLabel)
+	}
+	link, err := normalizeSchoolLinkURL(kind, req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing := s.links[schoolID]
+	if len(existing) >= maxSchoolLinks {
+		return nil, fmt.Errorf("a school can have at most %d links", maxSchoolLinks)
+	}
+	for _, l := range existing {
+		if l.URL == link {
+			return nil, fmt.Errorf("this link is already listed")
+		}
+	}
+
+	l := model.SchoolLink{ID: generateID(), Kind: kind, Label: label, URL: link}
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO school_links (id, school_id, kind, label, url, created_by, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			l.ID, schoolID, l.Kind, l.Label, l.URL, middleware.GetUserID(ctx), time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("failed to save link: %w", err)
+		}
+	}
+	links := append(append([]model.SchoolLink{}, existing...), l)
+	s.links[schoolID] = links
+	s.schools.SetLinks(schoolID, links)
+	return &l, nil
+}
+
+// Remove deletes one of a school's links.
+func (s *SchoolLinkService) Remove(schoolID, linkID string) (*model.SchoolLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing := s.links[schoolID]
+	links := make([]model.SchoolLink, 0, len(existing))
+	var removed *model.SchoolLink
+	for i := range existing {
+		if existing[i].ID == linkID {
+			removed = &existing[i]
+			continue
+		}
+		links = append(links, existing[i])
+	}
+	if removed == nil {
+		return nil, fmt.Errorf("link not found")
+	}
+
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(), `DELETE FROM school_links WHERE id = $1`, linkID); err != nil {
+			return nil, fmt.Errorf("failed to delete link: %w", err)
+		}
+	}
+	s.links[schoolID] = links
+	s.schools.SetLinks(schoolID, links)
+	return removed, nil
+}
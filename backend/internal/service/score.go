@@ -0,0 +1,41 @@
+package service
+
+import (
+	"fmt"
+	"math"
+)
+
+// Scores are 1-5 stars in half-star steps. Anything finer (3.7) is rejected
+// rather than silently stored, since the UI can't show it and it would skew
+// thumbs and cohort breakdowns.
+const (
+	minScore  = 1
+	maxScore  = 5
+	scoreStep = 0.5
+)
+
+// ValidateScore checks that score is between 1 and 5 in half-star steps.
+func ValidateScore(score float32) error {
+	if score < minScore || score > maxScore {
+		return fmt.Errorf("score must be between 1 and 5")
+	}
+	if steps := float64(score) / scoreStep; steps != math.Trunc(steps) {
+		return fmt.Errorf("score must be a whole or half star (e.g. 3 or 3.5)")
+	}
+	return nil
+}
+
+// RoundAverage rounds an aggregate rating to one decimal place, which is what
+// clients display. Aggregates are rounded when they're stored for display;
+// per-rating math (weights, thumbs) always uses the raw scores.
+func RoundAverage(avg float64) float64 {
+	return math.Round(avg*10) / 10
+}
+
+// FormatScore renders a single score for display: "4" or "3.5".
+func FormatScore(score float32) string {
+	if score == float32(math.Trunc(float64(score))) {
+		return fmt.Sprintf("%.0f", score)
+	}
+	return fmt.Sprintf("%.1f", score)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// seasonalityTTL is how long a venue's seasonality profile is reused. Month
+// buckets barely move from one rating to the next.
+const seasonalityTTL = time.Hour
+
+// SeasonalityService buckets venue ratings and check-ins by calendar month in
+// the school's local time ("dead in summer, packed in October").
+type SeasonalityService struct {
+	venueSvc   *VenueService
+	schoolSvc  *SchoolService
+	ratingSvc  *RatingService
+	checkInSvc *CheckInService
+
+	mu    sync.Mutex
+	cache map[string]*model.Seasonality
+}
+
+func NewSeasonalityService(venueSvc *VenueService, schoolSvc *SchoolService, ratingSvc *RatingService, checkInSvc *CheckInService) *SeasonalityService {
+	return &SeasonalityService{
+		venueSvc:   venueSvc,
+		schoolSvc:  schoolSvc,
+		ratingSvc:  ratingSvc,
+		checkInSvc: checkInSvc,
+		cache:      make(map[string]*model.Seasonality),
+	}
+}
+
+// ForVenue returns a venue's seasonality profile, computing it at most once
+// per seasonalityTTL.
+func (s *SeasonalityService) ForVenue(venueID string) (*model.Seasonality, error) {
+	venue, err := s.venueSvc.GetByID(context.Background(), venueID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	cached, ok := s.cache[venueID]
+	s.mu.Unlock()
+	if ok && time.Since(cached.GeneratedAt) < seasonalityTTL {
+		return cached, nil
+	}
+
+	result := s.compute(venue, time.Now())
+
+	s.mu.Lock()
+	s.cache[venueID] = result
+	s.mu.Unlock()
+	return result, nil
+}
+
+// Invalidate drops every cached profile so the next request recomputes it.
+func (s *SeasonalityService) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = make(map[string]*model.Seasonality)
+}
+
+func (s *SeasonalityService) compute(venue *model.Venue, now time.Time) *model.Seasonality {
+	loc := s.schoolSvc.Location(venue.SchoolID)
+
+	months := make([]model.MonthStat, 12)
+	sums := make([]float64, 12)
+	for i := range months {
+		months[i].Month = i + 1
+	}
+
+	ratings, _ := s.ratingSvc.ListByVenue(context.Background(), venue.ID)
+	for _, r := range ratings {
+		i := int(r.CreatedAt.In(loc).Month()) - 1
+		months[i].Ratings++
+		sums[i] += float64(r.Score)
+	}
+	for _, c := range s.checkInSvc.Since([]string{venue.ID}, time.Time{}) {
+		months[int(c.CreatedAt.In(loc).Month())-1].CheckIns++
+	}
+
+	result := &model.Seasonality{VenueID: venue.ID, Months: months, GeneratedAt: now}
+
+	peak, quiet := 0, -1
+	for i := range months {
+		if months[i].Ratings > 0 {
+			months[i].AvgRating = RoundAverage(sums[i] / float64(months[i].Ratings))
+		}
+		total := months[i].Ratings + months[i].CheckIns
+		if total > peak {
+			peak, result.PeakMonth = total, i+1
+		}
+		if quiet < 0 || total < quiet {
+			quiet, result.QuietMonth = total, i+1
+		}
+	}
+	if peak == 0 {
+		result.PeakMonth, result.QuietMonth = 0, 0
+		return result
+	}
+	for i := range months {
+		months[i].Activity = (months[i].Ratings + months[i].CheckIns) * 100 / peak
+	}
+	return result
+}
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	// sentimentMinReviews is how many reviews with text a venue needs for a
+	// vibe index.
+	sentimentMinReviews = 3
+	// sentimentMismatch is how many points apart the vibe and star indexes
+	// must be to flag a mismatch.
+	sentimentMismatch = 25
+	// sentimentBatch caps how many reviews go to the analyzer per call.
+	sentimentBatch = 100
+	// sentimentQueueSize is how many published reviews can wait for live
+	// scoring.
+	sentimentQueueSize = 1024
+	// sentimentLiveTimeout bounds one live scoring call.
+	sentimentLiveTimeout = 10 * time.Second
+)
+
+// SentimentAnalyzer scores review texts from -1 (negative) to 1 (positive),
+// one score per text in order.
+type SentimentAnalyzer interface {
+	Analyze(ctx context.Context, texts []string) ([]float64, error)
+}
+
+// SentimentConfig selects the analyzer.
+type SentimentConfig struct {
+	URL string // external model endpoint; empty uses the built-in lexicon
+}
+
+// LoadSentimentConfig reads SENTIMENT_URL. When set, reviews are scored by
+// POSTing {"texts": [...]} to it, expecting {"scores": [...]} back in the
+// same order; otherwise the built-in lexicon is used.
+func LoadSentimentConfig() SentimentConfig {
+	return SentimentConfig{URL: os.Getenv("SENTIMENT_URL")}
+}
+
+// HTTPSentiment calls an external sentiment model.
+type HTTPSentiment struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSentiment(url string) *HTTPSentiment {
+	return &HTTPSentiment{url: url, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Analyze sends texts to the model. Scores outside -1..1 are clamped.
+func (a *HTTPSentiment) Analyze(ctx context.Context, texts []string) ([]float64, error) {
+	body, err := json.Marshal(map[string][]string{"texts": texts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to score sentiment: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to score sentiment: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to score sentiment: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to score sentiment: unexpected status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Scores []float64 `json:"scores"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to score sentiment: %w", err)
+	}
+	if len(out.Scores) != len(texts) {
+		return nil, fmt.Errorf("failed to score sentiment: got %d scores for %d texts", len(out.Scores), len(texts))
+	}
+	for i, v := range out.Scores {
+		out.Scores[i] = max(-1, min(1, v))
+	}
+	return out.Scores, nil
+}
+
+type sentimentScore struct {
+	venueID  string
+	stars    float32
+	textHash uint64 // rescored when the review is edited or redacted
+	score    float64
+	scoredAt time.Time
+}
+
+// SentimentService scores each review's text and rolls the scores up into a
+// per-venue vibe index. New and edited reviews are scored as they're
+// published; the periodic rebuild recomputes every vibe and scores anything
+// the live path missed (reviews from before a restart, redactions, analyzer
+// outages).
+type SentimentService struct {
+	mu       sync.RWMutex
+	analyzer SentimentAnalyzer
+	ratings  *RatingService
+	scores   map[string]sentimentScore // by rating ID
+	vibes    map[string]model.VenueVibe
+	queue    chan model.Rating
+}
+
+// NewSentimentService uses the external model from cfg if one is set and
+// the lexicon otherwise.
+func NewSentimentService(cfg SentimentConfig, ratings *RatingService) *SentimentService {
+	var analyzer SentimentAnalyzer = LexiconSentiment{}
+	if cfg.URL != "" {
+		analyzer = NewHTTPSentiment(cfg.URL)
+	}
+	return &SentimentService{
+		analyzer: analyzer,
+		ratings:  ratings,
+		scores:   make(map[string]sentimentScore),
+		vibes:    make(map[string]model.VenueVibe),
+		queue:    make(chan model.Rating, sentimentQueueSize),
+	}
+}
+
+func sentimentHash(text string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(text))
+	return h.Sum64()
+}
+
+// Observe queues a newly published or edited rating for scoring. It never
+// blocks the rating path: when the queue is full the review waits for the
+// next rebuild.
+func (s *SentimentService) Observe(r model.Rating) {
+	select {
+	case s.queue <- r:
+	default:
+	}
+}
+
+// scoreLive scores queued ratings and refreshes their venues' vibes.
+func (s *SentimentService) scoreLive(batch []model.Rating) {
+	var texts []string
+	var scored []model.Rating
+	for _, r := range batch {
+		if r.Review != "" {
+			texts = append(texts, r.Review)
+			scored = append(scored, r)
+		}
+	}
+	var scores []float64
+	if len(texts) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), sentimentLiveTimeout)
+		defer cancel()
+		var err error
+		if scores, err = s.analyzer.Analyze(ctx, texts); err != nil {
+			log.Printf("WARNING: Sentiment scoring failed, retrying at next rebuild: %v", err)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	venues := make(map[string]bool)
+	for _, r := range batch {
+		// A review edited down to stars only no longer has a score.
+		delete(s.scores, r.ID)
+		venues[r.VenueID] = true
+	}
+	for i, r := range scored {
+		s.scores[r.ID] = sentimentScore{venueID: r.VenueID, stars: r.Score,
+			textHash: sentimentHash(r.Review), score: scores[i], scoredAt: now}
+	}
+	for venueID := range venues {
+		var venueScores []sentimentScore
+		for _, sc := range s.scores {
+			if sc.venueID == venueID {
+				venueScores = append(venueScores, sc)
+			}
+		}
+		if v, ok := venueVibe(venueScores); ok {
+			s.vibes[venueID] = v
+		} else {
+			delete(s.vibes, venueID)
+		}
+	}
+}
+
+// venueVibe rolls up one venue's review scores, if it has enough.
+func venueVibe(scores []sentimentScore) (model.VenueVibe, bool) {
+	if len(scores) < sentimentMinReviews {
+		return model.VenueVibe{}, false
+	}
+	var textSum, starSum float64
+	for _, sc := range scores {
+		textSum += sc.score
+		starSum += float64(sc.stars)
+	}
+	n := float64(len(scores))
+	v := model.VenueVibe{
+		Index:     int(math.Round((textSum/n + 1) * 50)),
+		StarIndex: int(math.Round((starSum/n - 1) * 25)),
+		Reviews:   len(scores),
+	}
+	v.Mismatch = math.Abs(float64(v.Index-v.StarIndex)) >= sentimentMismatch
+	return v, true
+}
+
+// Rebuild scores reviews that are missing or stale and recomputes every
+// venue's vibe. It returns how many venues have one. If the analyzer fails,
+// reviews it didn't score are left out until next time.
+func (s *SentimentService) Rebuild(ctx context.Context) (int, error) {
+	started := time.Now()
+	byVenue := s.ratings.ReviewTextsByVenue()
+
+	s.mu.RLock()
+	var pending []ReviewText
+	for _, reviews := range byVenue {
+		for _, r := range reviews {
+			if cached, ok := s.scores[r.RatingID]; !ok || cached.textHash != sentimentHash(r.Text) {
+				pending = append(pending, r)
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	fresh := make(map[string]float64, len(pending))
+	var analyzeErr error
+	for start := 0; start < len(pending); start += sentimentBatch {
+		batch := pending[start:min(start+sentimentBatch, len(pending))]
+		texts := make([]string, len(batch))
+		for i, r := range batch {
+			texts[i] = r.Text
+		}
+		scores, err := s.analyzer.Analyze(ctx, texts)
+		if err != nil {
+			analyzeErr = err
+			break
+		}
+		for i, r := range batch {
+			fresh[r.RatingID] = scores[i]
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scores := make(map[string]sentimentScore, len(s.scores)+len(fresh))
+	for venueID, reviews := range byVenue {
+		for _, r := range reviews {
+			sc, ok := s.scores[r.RatingID]
+			if v, scoredNow := fresh[r.RatingID]; scoredNow && (!ok || sc.scoredAt.Before(started)) {
+				sc, ok = sentimentScore{textHash: sentimentHash(r.Text), score: v, scoredAt: started}, true
+			}
+			if !ok || sc.textHash != sentimentHash(r.Text) {
+				continue
+			}
+			sc.venueID, sc.stars = venueID, r.Score
+			scores[r.RatingID] = sc
+		}
+	}
+	// Keep what the live path scored while this rebuild was reading.
+	for id, sc := range s.scores {
+		if _, ok := scores[id]; !ok && sc.scoredAt.After(started) {
+			scores[id] = sc
+		}
+	}
+
+	byVenueScores := make(map[string][]sentimentScore)
+	for _, sc := range scores {
+		byVenueScores[sc.venueID] = append(byVenueScores[sc.venueID], sc)
+	}
+	vibes := make(map[string]model.VenueVibe)
+	for venueID, venueScores := range byVenueScores {
+		if v, ok := venueVibe(venueScores); ok {
+			vibes[venueID] = v
+		}
+	}
+	s.scores = scores
+	s.vibes = vibes
+	return len(vibes), analyzeErr
+}
+
+// Start scores published reviews as they arrive, and rebuilds now and then
+// every interval.
+func (s *SentimentService) Start(interval time.Duration) {
+	go func() {
+		for r := range s.queue {
+			batch := []model.Rating{r}
+		drain:
+			for len(batch) < sentimentBatch {
+				select {
+				case next := <-s.queue:
+					batch = append(batch, next)
+				default:
+					break drain
+				}
+			}
+			s.scoreLive(batch)
+		}
+	}()
+	go func() {
+		for {
+			n, err := s.Rebuild(context.Background())
+			if err != nil {
+				log.Printf("WARNING: Sentiment scoring incomplete: %v", err)
+			}
+			log.Printf("Computed review vibe for %d venues", n)
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// Vibe returns a venue's vibe index from the last rebuild, or nil.
+func (s *SentimentService) Vibe(venueID string) *model.VenueVibe {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if v, ok := s.vibes[venueID]; ok {
+		return &v
+	}
+	return nil
+}
+
+// Annotate fills in Sentiment on ratings whose current text has been
+// scored, rounded to two decimals.
+func (s *SentimentService) Annotate(ratings []model.Rating) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i := range ratings {
+		r := &ratings[i]
+		if r.Review == "" {
+			continue
+		}
+		if sc, ok := s.scores[r.ID]; ok && sc.textHash == sentimentHash(r.Review) {
+			v := math.Round(sc.score*100) / 100
+			r.Sentiment = &v
+		}
+	}
+}
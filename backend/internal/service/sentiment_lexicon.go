@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strings"
+)
+
+const (
+	// sentimentNormAlpha squashes a review's summed word weights into
+	// (-1, 1); a review needs a handful of strong words to approach either
+	// end.
+	sentimentNormAlpha = 15
+	// sentimentNegationWindow is how many words after "not" are flipped.
+	sentimentNegationWindow = 3
+	sentimentNegationFactor = -0.75
+	sentimentBoost          = 1.3
+)
+
+// sentimentLexicon weighs words from -3 (very negative) to 3 (very
+// positive), tuned for bar and party reviews: "cheap" and "lit" are good,
+// "dead" and "sticky" aren't.
+var sentimentLexicon = map[string]float64{
+	// positive
+	"amazing": 3, "awesome": 3, "best": 3, "excellent": 3, "fantastic": 3, "favorite": 3, "goated": 3,
+	"great": 3, "incredible": 3, "love": 3, "loved": 3, "perfect": 3, "wonderful": 3, "delicious": 3,
+	"attentive": 2, "banger": 2, "bangers": 2, "chill": 2, "cozy": 2, "dope": 2, "enjoy": 2, "enjoyed": 2,
+	"fire": 2, "friendly": 2, "fun": 2, "generous": 2, "good": 2, "happy": 2, "helpful": 2, "legit": 2,
+	"lit": 2, "lively": 2, "nice": 2, "recommend": 2, "solid": 2, "tasty": 2, "welcoming": 2, "worth": 2,
+	"cheap": 1, "clean": 1, "cool": 1, "decent": 1, "fast": 1, "strong": 1, "vibe": 1, "vibes": 1,
+
+	// negative
+	"avoid": -3, "awful": -3, "creepy": -3, "gross": -3, "hate": -3, "hated": -3, "ripoff": -3, "rude": -3,
+	"scam": -3, "sucked": -3, "sucks": -3, "terrible": -3, "trash": -3, "unsafe": -3, "worst": -3,
+	"aggressive": -2, "bad": -2, "boring": -2, "dead": -2, "dirty": -2, "disappointed": -2,
+	"disappointing": -2, "fights": -2, "fight": -2, "lame": -2, "overpriced": -2, "shady": -2,
+	"sketchy": -2, "smelly": -2, "unfriendly": -2, "watered": -2,
+	"crowded": -1, "expensive": -1, "flat": -1, "loud": -1, "meh": -1, "mid": -1, "slow": -1,
+	"smells": -1, "sticky": -1, "weak": -1,
+}
+
+// sentimentNegators flip the words that follow ("not good").
+var sentimentNegators = toSet(strings.Fields(`
+	not no never nothing hardly barely cannot can't don't doesn't didn't isn't
+	wasn't aren't weren't won't wouldn't ain't
+	`))
+
+// sentimentBoosters strengthen the next word ("super friendly").
+var sentimentBoosters = toSet(strings.Fields(`
+	very really super so extremely insanely incredibly hella too absolutely
+	`))
+
+// LexiconSentiment scores text with a built-in word list, handling negation
+// ("not worth it"), boosters ("super friendly") and "but" (what follows it
+// counts for more). It needs no network and is the default analyzer.
+type LexiconSentiment struct{}
+
+// Analyze scores each text from -1 to 1; texts without any scored word are
+// 0.
+func (LexiconSentiment) Analyze(_ context.Context, texts []string) ([]float64, error) {
+	out := make([]float64, len(texts))
+	for i, t := range texts {
+		out[i] = lexiconScore(t)
+	}
+	return out, nil
+}
+
+func lexiconScore(text string) float64 {
+	words := tokenize(text)
+	sum := 0.0
+	negated := 0 // words left in the current negation window
+	boost := 1.0
+	butAt := -1
+	weights := make([]float64, len(words))
+	for i, w := range words {
+		if w == "but" {
+			butAt = i
+			negated = 0
+			continue
+		}
+		if _, ok := sentimentNegators[w]; ok {
+			negated = sentimentNegationWindow
+			continue
+		}
+		if _, ok := sentimentBoosters[w]; ok {
+			boost = sentimentBoost
+			continue
+		}
+		v := sentimentLexicon[w] * boost
+		boost = 1
+		if negated > 0 {
+			v *= sentimentNegationFactor
+			negated--
+		}
+		weights[i] = v
+	}
+	for i, v := range weights {
+		switch {
+		case butAt < 0:
+		case i < butAt:
+			v *= 0.5
+		default:
+			v *= 1.5
+		}
+		sum += v
+	}
+	return sum / math.Sqrt(sum*sum+sentimentNormAlpha)
+}
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// SessionTokenPrefix marks opaque session tokens so they're never mistaken
+// for JWTs.
+const SessionTokenPrefix = "s."
+
+// sessionRecheck is how long a cached session is trusted before it's checked
+// against the database again, so revocations on other instances take effect.
+const sessionRecheck = time.Minute
+
+// SessionConfig selects server-side sessions instead of stateless JWTs.
+type SessionConfig struct {
+	Enabled bool
+	TTL     time.Duration
+}
+
+// LoadSessionConfig reads AUTH_SESSIONS ("server" enables server-side
+// sessions) and SESSION_TTL_HOURS (default 720, i.e. 30 days).
+func LoadSessionConfig() SessionConfig {
+	cfg := SessionConfig{
+		Enabled: strings.EqualFold(os.Getenv("AUTH_SESSIONS"), "server"),
+		TTL:     30 * 24 * time.Hour,
+	}
+	if v, err := strconv.Atoi(os.Getenv("SESSION_TTL_HOURS")); err == nil && v > 0 {
+		cfg.TTL = time.Duration(v) * time.Hour
+	}
+	return cfg
+}
+
+type sessionEntry struct {
+	model.Session
+	tokenHash string
+	checkedAt time.Time
+}
+
+// SessionService stores login sessions keyed by a random ID so they can be
+// listed and revoked. Tokens are random and stored only as SHA-256 hashes.
+type SessionService struct {
+	mu       sync.RWMutex
+	pool     *pgxpool.Pool
+	queries  *Queries
+	ttl      time.Duration
+	sessions map[string]*sessionEntry // token hash -> session
+}
+
+func NewSessionService(pool *pgxpool.Pool, cfg SessionConfig) *SessionService {
+	svc := &SessionService{
+		pool:     pool,
+		queries:  NewQueries(pool),
+		ttl:      cfg.TTL,
+		sessions: make(map[string]*sessionEntry),
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+const sessionColumns = `id, token_hash, user_id, username, role, COALESCE(user_agent,''), COALESCE(ip,''), created_at, last_seen_at, expires_at`
+
+func scanSession(row pgx.Row) (*sessionEntry, error) {
+	e := &sessionEntry{checkedAt: time.Now()}
+	err := row.Scan(&e.ID, &e.tokenHash, &e.UserID, &e.Username, &e.Role, &e.UserAgent, &e.IP,
+		&e.CreatedAt, &e.LastSeenAt, &e.ExpiresAt)
+	return e, err
+}
+
+func (s *SessionService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT `+sessionColumns+` FROM sessions WHERE expires_at > NOW()`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load sessions from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e, err := scanSession(rows)
+		if err != nil {
+			log.Printf("WARNING: Failed to scan session row: %v", err)
+			continue
+		}
+		s.sessions[e.tokenHash] = e
+	}
+	log.Printf("Loaded %d active sessions from DB", len(s.sessions))
+}
+
+// Create starts a session for user and returns its bearer token.
+func (s *SessionService) Create(user model.User, client model.SessionClient) (string, error) {
+	token := SessionTokenPrefix + generateID() + generateID()
+	now := time.Now()
+	role := user.Role
+	if role == "" {
+		role = "user"
+	}
+	userAgent := client.UserAgent
+	if len(userAgent) > 300 {
+		userAgent = userAgent[:300]
+	}
+	e := &sessionEntry{
+		Session: model.Session{
+			ID:         "sess_" + generateID()[:16],
+			UserID:     user.ID,
+			Username:   user.Username,
+			Role:       role,
+			UserAgent:  userAgent,
+			IP:         client.IP,
+			CreatedAt:  now,
+			LastSeenAt: now,
+			ExpiresAt:  now.Add(s.ttl),
+		},
+		tokenHash: hashResetToken(token),
+		checkedAt: now,
+	}
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO sessions (id, token_hash, user_id, username, role, user_agent, ip, created_at, last_seen_at, expires_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8, $9)`,
+			e.ID, e.tokenHash, e.UserID, e.Username, e.Role, e.UserAgent, e.IP, now, e.ExpiresAt)
+		if err != nil {
+			return "", fmt.Errorf("failed to create session: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.sessions[e.tokenHash] = e
+	s.mu.Unlock()
+	return token, nil
+}
+
+// Lookup resolves a session token; it matches middleware.SessionLookupFunc.
+func (s *SessionService) Lookup(token string) (userID, username, role, sessionID string, ok bool) {
+	if !strings.HasPrefix(token, SessionTokenPrefix) {
+		return "", "", "", "", false
+	}
+	tokenHash := hashResetToken(token)
+	now := time.Now()
+
+	s.mu.RLock()
+	e, cached := s.sessions[tokenHash]
+	fresh := cached && now.Sub(e.checkedAt) < sessionRecheck
+	var current model.Session
+	if cached {
+		current = e.Session
+	}
+	s.mu.RUnlock()
+
+	if !fresh && s.pool != nil {
+		// Another instance may have created or revoked it; the database decides.
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		loaded, err := scanSession(s.queries.QueryRow(ctx, "session_touch", tokenHash, now))
+		s.mu.Lock()
+		if err != nil {
+			delete(s.sessions, tokenHash)
+			s.mu.Unlock()
+			return "", "", "", "", false
+		}
+		s.sessions[tokenHash] = loaded
+		s.mu.Unlock()
+		current, cached = loaded.Session, true
+	} else if cached && !fresh {
+		s.mu.Lock()
+		e.checkedAt, e.LastSeenAt = now, now
+		s.mu.Unlock()
+	}
+
+	if !cached || !now.Before(current.ExpiresAt) {
+		return "", "", "", "", false
+	}
+	return current.UserID, current.Username, current.Role, current.ID, true
+}
+
+// List returns a user's active sessions, most recently used first. An empty
+// userID lists everyone's (for admins).
+func (s *SessionService) List(userID string) []model.Session {
+	if s.pool != nil {
+		return s.listDB(userID)
+	}
+
+	now := time.Now()
+	s.mu.RLock()
+	out := []model.Session{}
+	for _, e := range s.sessions {
+		if (userID == "" || e.UserID == userID) && now.Before(e.ExpiresAt) {
+			out = append(out, e.Session)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].LastSeenAt.After(out[j].LastSeenAt) })
+	return out
+}
+
+func (s *SessionService) listDB(userID string) []model.Session {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	out := []model.Session{}
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+sessionColumns+` FROM sessions
+		 WHERE ($1 = '' OR user_id = $1) AND expires_at > NOW()
+		 ORDER BY last_seen_at DESC`, userID)
+	if err != nil {
+		log.Printf("WARNING: Failed to list sessions: %v", err)
+		return out
+	}
+	defer rows.Close()
+	for rows.Next() {
+		e, err := scanSession(rows)
+		if err != nil {
+			log.Printf("WARNING: Failed to scan session row: %v", err)
+			continue
+		}
+		out = append(out, e.Session)
+	}
+	return out
+}
+
+// Revoke ends a session. With a non-empty userID only that user's session
+// can be revoked.
+func (s *SessionService) Revoke(sessionID, userID string) error {
+	if s.pool != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var hash string
+		err := s.pool.QueryRow(ctx,
+			`DELETE FROM sessions WHERE id = $1 AND ($2 = '' OR user_id = $2) RETURNING token_hash`,
+			sessionID, userID).Scan(&hash)
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("session not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+		s.mu.Lock()
+		delete(s.sessions, hash)
+		s.mu.Unlock()
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for h, e := range s.sessions {
+		if e.ID == sessionID && (userID == "" || e.UserID == userID) {
+			delete(s.sessions, h)
+			return nil
+		}
+	}
+	return fmt.Errorf("session not found")
+}
+
+// RevokeToken ends the session a token belongs to, if any.
+func (s *SessionService) RevokeToken(token string) {
+	hash := hashResetToken(token)
+	s.mu.Lock()
+	delete(s.sessions, hash)
+	s.mu.Unlock()
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(), `DELETE FROM sessions WHERE token_hash = $1`, hash); err != nil {
+			log.Printf("WARNING: Failed to revoke session: %v", err)
+		}
+	}
+}
+
+// RevokeAll ends every session of a user except keepID and returns how many
+// were ended.
+func (s *SessionService) RevokeAll(userID, keepID string) (int, error) {
+	s.mu.Lock()
+	n := 0
+	for h, e := range s.sessions {
+		if e.UserID == userID && e.ID != keepID {
+			delete(s.sessions, h)
+			n++
+		}
+	}
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		tag, err := s.pool.Exec(context.Background(),
+			`DELETE FROM sessions WHERE user_id = $1 AND id <> $2`, userID, keepID)
+		if err != nil {
+			return n, fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+		n = int(tag.RowsAffected())
+	}
+	return n, nil
+}
+
+// UpdateRole applies a role change to a user's live sessions.
+func (s *SessionService) UpdateRole(userID, role string) {
+	s.mu.Lock()
+	for _, e := range s.sessions {
+		if e.UserID == userID {
+			e.Role = role
+		}
+	}
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(), `UPDATE sessions SET role = $1 WHERE user_id = $2`, role, userID); err != nil {
+			log.Printf("WARNING: Failed to update session roles: %v", err)
+		}
+	}
+}
+
+// PurgeExpired deletes sessions that expired before cutoff.
+func (s *SessionService) PurgeExpired(cutoff time.Time, dryRun bool) (int, error) {
+	if s.pool != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if dryRun {
+			var n int
+			err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM sessions WHERE expires_at < $1`, cutoff).Scan(&n)
+			return n, err
+		}
+		s.dropExpired(cutoff)
+		tag, err := s.pool.Exec(ctx, `DELETE FROM sessions WHERE expires_at < $1`, cutoff)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge sessions: %w", err)
+		}
+		return int(tag.RowsAffected()), nil
+	}
+
+	if dryRun {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		n := 0
+		for _, e := range s.sessions {
+			if e.ExpiresAt.Before(cutoff) {
+				n++
+			}
+		}
+		return n, nil
+	}
+	return s.dropExpired(cutoff), nil
+}
+
+func (s *SessionService) dropExpired(cutoff time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for h, e := range s.sessions {
+		if e.ExpiresAt.Before(cutoff) {
+			delete(s.sessions, h)
+			n++
+		}
+	}
+	return n
+}
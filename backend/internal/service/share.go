@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const shareCodeLength = 7
+
+const shareCodeAlphabet = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+var utmValueRe = regexp.MustCompile(`^[a-zA-Z0-9_\-.]{1,64}$`)
+
+// ShareService creates short links to frontend pages and counts their clicks.
+type ShareService struct {
+	mu          sync.RWMutex
+	pool        *pgxpool.Pool
+	frontendURL string
+	links       map[string]*model.ShareLink
+}
+
+func NewShareService(pool *pgxpool.Pool, frontendURL string) *ShareService {
+	svc := &ShareService{
+		pool:        pool,
+		frontendURL: strings.TrimRight(frontendURL, "/"),
+		links:       make(map[string]*model.ShareLink),
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *ShareService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT code, target_type, target_id, utm_source, utm_medium, COALESCE(utm_campaign,''),
+		        created_by, created_at, clicks, last_clicked_at
+		 FROM share_links`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load share links from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l model.ShareLink
+		if err := rows.Scan(&l.Code, &l.TargetType, &l.TargetID, &l.UTMSource, &l.UTMMedium, &l.UTMCampaign,
+			&l.CreatedByID, &l.CreatedAt, &l.Clicks, &l.LastClickedAt); err != nil {
+			log.Printf("WARNING: Failed to scan share link row: %v", err)
+			continue
+		}
+		s.links[l.Code] = &l
+	}
+	log.Printf("Loaded %d share links from DB", len(s.links))
+}
+
+// Create returns a short link for the target. Requesting the same target and
+// UTM tags again returns the existing code so printed QR codes stay stable.
+func (s *ShareService) Create(ctx context.Context, req model.CreateShareLinkRequest) (*model.ShareLink, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	if req.TargetType != "venue" && req.TargetType != "school" {
+		return nil, fmt.Errorf("target_type must be 'venue' or 'school'")
+	}
+	if req.TargetID == "" {
+		return nil, fmt.Errorf("target_id is required")
+	}
+	if req.UTMSource == "" {
+		req.UTMSource = "share"
+	}
+	if req.UTMMedium == "" {
+		req.UTMMedium = "link"
+	}
+	for _, v := range []string{req.UTMSource, req.UTMMedium} {
+		if !utmValueRe.MatchString(v) {
+			return nil, fmt.Errorf("utm values may only contain letters, digits, '-', '_' and '.'")
+		}
+	}
+	if req.UTMCampaign != "" && !utmValueRe.MatchString(req.UTMCampaign) {
+		return nil, fmt.Errorf("utm values may only contain letters, digits, '-', '_' and '.'")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, l := range s.links {
+		if l.TargetType == req.TargetType && l.TargetID == req.TargetID &&
+			l.UTMSource == req.UTMSource && l.UTMMedium == req.UTMMedium && l.UTMCampaign == req.UTMCampaign {
+			out := *l
+			return &out, nil
+		}
+	}
+
+	code := s.newCode()
+	link := &model.ShareLink{
+		Code:        code,
+		TargetType:  req.TargetType,
+		TargetID:    req.TargetID,
+		UTMSource:   req.UTMSource,
+		UTMMedium:   req.UTMMedium,
+		UTMCampaign: req.UTMCampaign,
+		CreatedByID: userID,
+		CreatedAt:   time.Now(),
+	}
+	s.links[code] = link
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO share_links (code, target_type, target_id, utm_source, utm_medium, utm_campaign, created_by, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			link.Code, link.TargetType, link.TargetID, link.UTMSource, link.UTMMedium, link.UTMCampaign, link.CreatedByID, link.CreatedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist share link: %v", err)
+		}
+	}
+
+	out := *link
+	return &out, nil
+}
+
+// newCode generates an unused short code. Caller must hold s.mu.
+func (s *ShareService) newCode() string {
+	b := make([]byte, shareCodeLength)
+	for {
+		rand.Read(b)
+		for i := range b {
+			b[i] = shareCodeAlphabet[int(b[i])%len(shareCodeAlphabet)]
+		}
+		if _, taken := s.links[string(b)]; !taken {
+			return string(b)
+		}
+	}
+}
+
+// Resolve records a click and returns the frontend URL (with UTM tags) for a code.
+func (s *ShareService) Resolve(code string) (string, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	link, ok := s.links[code]
+	if !ok {
+		s.mu.Unlock()
+		return "", fmt.Errorf("link not found")
+	}
+	link.Clicks++
+	link.LastClickedAt = &now
+	target := s.targetURL(*link)
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`UPDATE share_links SET clicks = clicks + 1, last_clicked_at = $1 WHERE code = $2`, now, code)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist share link click: %v", err)
+		}
+	}
+	return target, nil
+}
+
+func (s *ShareService) targetURL(l model.ShareLink) string {
+	q := url.Values{}
+	q.Set("utm_source", l.UTMSource)
+	q.Set("utm_medium", l.UTMMedium)
+	if l.UTMCampaign != "" {
+		q.Set("utm_campaign", l.UTMCampaign)
+	}
+	return fmt.Sprintf("%s/%s/%s?%s", s.frontendURL, l.TargetType, url.PathEscape(l.TargetID), q.Encode())
+}
+
+// List returns all share links, most clicked first (admin only).
+func (s *ShareService) List() []model.ShareLink {
+	s.mu.RLock()
+	out := make([]model.ShareLink, 0, len(s.links))
+	for _, l := range s.links {
+		out = append(out, *l)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Clicks != out[j].Clicks {
+			return out[i].Clicks > out[j].Clicks
+		}
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	return out
+}
+
+// ClicksForTarget returns the total clicks across all links to a target.
+func (s *ShareService) ClicksForTarget(targetType, targetID string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n := 0
+	for _, l := range s.links {
+		if l.TargetType == targetType && l.TargetID == targetID {
+			n += l.Clicks
+		}
+	}
+	return n
+}
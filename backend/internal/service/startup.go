@@ -0,0 +1,52 @@
+package service
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// StartupTimings records how long each startup load took so /health can
+// show what is slowing readiness. Loads may be tracked concurrently.
+type StartupTimings struct {
+	mu      sync.Mutex
+	started time.Time
+	total   time.Duration
+	loads   []model.LoadTiming
+}
+
+func NewStartupTimings() *StartupTimings {
+	return &StartupTimings{started: time.Now()}
+}
+
+// Track runs fn and records its duration under name.
+func (t *StartupTimings) Track(name string, fn func()) {
+	start := time.Now()
+	fn()
+	took := time.Since(start)
+	log.Printf("Startup: loaded %s in %s", name, took.Round(time.Millisecond))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.loads = append(t.loads, model.LoadTiming{Name: name, Ms: took.Milliseconds()})
+}
+
+// Done marks startup as finished.
+func (t *StartupTimings) Done() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total = time.Since(t.started)
+	log.Printf("Startup: data loaded in %s", t.total.Round(time.Millisecond))
+}
+
+// Report returns the recorded timings, in the order loads finished.
+func (t *StartupTimings) Report() model.StartupReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return model.StartupReport{
+		TotalMs: t.total.Milliseconds(),
+		Loads:   append([]model.LoadTiming(nil), t.loads...),
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// statusChangelog is how many changelog entries /api/status includes.
+const statusChangelog = 5
+
+// BuildInfo identifies the running binary.
+type BuildInfo struct {
+	Version   string
+	GitSHA    string
+	BuildTime string
+}
+
+// LoadBuildInfo reads APP_VERSION and GIT_SHA (set by the Docker build),
+// falling back to the VCS stamp Go embeds when building from a checkout.
+func LoadBuildInfo() BuildInfo {
+	info := BuildInfo{Version: os.Getenv("APP_VERSION"), GitSHA: os.Getenv("GIT_SHA")}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.GitSHA == "" {
+					info.GitSHA = s.Value
+				}
+			case "vcs.time":
+				info.BuildTime = s.Value
+			case "vcs.modified":
+				if s.Value == "true" && info.GitSHA != "" && os.Getenv("GIT_SHA") == "" {
+					info.GitSHA += "-dirty"
+				}
+			}
+		}
+	}
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	return info
+}
+
+// StatusService reports what this instance is running and serving.
+type StatusService struct {
+	build     BuildInfo
+	storage   model.StorageInfo
+	started   time.Time
+	schools   *SchoolService
+	venues    int
+	changelog *ChangelogService
+}
+
+// NewStatusService records the start time; seedVenues is the number of
+// built-in venues seeded at startup.
+func NewStatusService(build BuildInfo, storage model.StorageInfo, schools *SchoolService, seedVenues int,
+	changelog *ChangelogService) *StatusService {
+	return &StatusService{build: build, storage: storage, started: time.Now(), schools: schools, venues: seedVenues,
+		changelog: changelog}
+}
+
+// Status returns the deploy's version, storage mode, data versions, uptime
+// and latest changelog entries.
+func (s *StatusService) Status(now time.Time) model.Status {
+	return model.Status{
+		Version:       s.build.Version,
+		GitSHA:        s.build.GitSHA,
+		BuildTime:     s.build.BuildTime,
+		StartedAt:     s.started,
+		UptimeSeconds: int64(now.Sub(s.started).Seconds()),
+		Storage:       s.storage,
+		Datasets: model.DatasetVersions{
+			Schools:      s.schools.Datasets(),
+			SeedVenues:   s.venues,
+			TermsVersion: CurrentTermsVersion(),
+		},
+		Changelog: s.changelog.List(statusChangelog, false, now),
+	}
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// studentVerificationTTL is how long an emailed .edu confirmation link stays
+// valid.
+const studentVerificationTTL = 24 * time.Hour
+
+// StudentVerificationSendFunc delivers a confirmation token to the .edu
+// address being verified.
+type StudentVerificationSendFunc func(email, schoolName, token string, expiresAt time.Time) error
+
+// studentVerification is a pending .edu confirmation. Only the token's hash
+// is stored.
+type studentVerification struct {
+	UserID    string
+	Email     string
+	SchoolID  string
+	ExpiresAt time.Time
+}
+
+// SetStudentVerificationSender sets the hook that emails .edu confirmation
+// tokens.
+func (s *AuthService) SetStudentVerificationSender(fn StudentVerificationSendFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sendStudent = fn
+}
+
+// loadStudents caches which users are verified students.
+func (s *AuthService) loadStudents(ctx context.Context) error {
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, school_id FROM users WHERE student_verified_at IS NOT NULL AND anonymized_at IS NULL`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for rows.Next() {
+		var userID, schoolID string
+		if err := rows.Scan(&userID, &schoolID); err != nil {
+			return err
+		}
+		s.students[userID] = schoolID
+	}
+	return rows.Err()
+}
+
+// IsVerifiedStudent reports whether the user has verified a .edu address; it
+// matches StudentVerifier.
+func (s *AuthService) IsVerifiedStudent(userID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.students[userID]
+	return ok
+}
+
+// StudentSchool returns the school a user verified as a student at, or "".
+func (s *AuthService) StudentSchool(userID string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.students[userID]
+}
+
+// RequestStudentVerification emails a confirmation link to a .edu address
+// and returns the school it will tie the account to. schoolID is only needed
+// when several schools share the domain and the user's home school isn't one
+// of them.
+func (s *AuthService) RequestStudentVerification(userID, email, schoolID string) (*model.SchoolSummary, error) {
+	if s.schools == nil {
+		return nil, fmt.Errorf("student verification is unavailable")
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	_, domain, ok := strings.Cut(email, "@")
+	if !ok || !strings.HasSuffix(domain, ".edu") || strings.ContainsAny(email, " \r\n") {
+		return nil, fmt.Errorf("a .edu email address is required")
+	}
+
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := s.schools.ForEmailDomain(domain)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no school uses this email domain")
+	}
+	var school *model.SchoolSummary
+	for i := range candidates {
+		c := &candidates[i]
+		if c.ID == schoolID || schoolID == "" && (len(candidates) == 1 || c.ID == user.HomeSchoolID) {
+			school = c
+			break
+		}
+	}
+	if school == nil {
+		if schoolID != "" {
+			return nil, fmt.Errorf("this email domain doesn't belong to that school")
+		}
+		return nil, fmt.Errorf("several schools use this email domain; choose yours")
+	}
+	if owner := s.studentEmailOwner(email); owner != "" && owner != userID {
+		return nil, fmt.Errorf("this email is already verified on another account")
+	}
+
+	token := generateID() + generateID()
+	expiresAt := time.Now().Add(studentVerificationTTL)
+	if err := s.storeStudentVerification(hashResetToken(token), studentVerification{
+		UserID: userID, Email: email, SchoolID: school.ID, ExpiresAt: expiresAt,
+	}); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	send := s.sendStudent
+	s.mu.RUnlock()
+	if send == nil {
+		log.Printf("WARNING: Student verification requested but no delivery hook is configured")
+		return school, nil
+	}
+	if err := send(email, school.Name, token, expiresAt); err != nil {
+		log.Printf("WARNING: Failed to deliver student verification email: %v", err)
+	}
+	return school, nil
+}
+
+// studentEmailOwner returns the user who already verified this address, or "".
+func (s *AuthService) studentEmailOwner(email string) string {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var userID string
+		err := s.pool.QueryRow(ctx, `SELECT id FROM users WHERE student_email = $1`, email).Scan(&userID)
+		if err != nil {
+			return ""
+		}
+		return userID
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rec := range s.users {
+		if rec.User.StudentEmail == email {
+			return rec.User.ID
+		}
+	}
+	return ""
+}
+
+// storeStudentVerification saves a new token, replacing any earlier pending
+// one for the user.
+func (s *AuthService) storeStudentVerification(tokenHash string, v studentVerification) error {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := s.pool.Exec(ctx, `DELETE FROM student_verifications WHERE user_id = $1`, v.UserID); err != nil {
+			return fmt.Errorf("failed to start verification: %w", err)
+		}
+		_, err := s.pool.Exec(ctx,
+			`INSERT INTO student_verifications (token_hash, user_id, email, school_id, expires_at) VALUES ($1, $2, $3, $4, $5)`,
+			tokenHash, v.UserID, v.Email, v.SchoolID, v.ExpiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to start verification: %w", err)
+		}
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for h, p := range s.studentTokens {
+		if p.UserID == v.UserID {
+			delete(s.studentTokens, h)
+		}
+	}
+	s.studentTokens[tokenHash] = v
+	return nil
+}
+
+// ConfirmStudent completes verification with an emailed token, tying the
+// account to the school. Tokens work once.
+func (s *AuthService) ConfirmStudent(token string) (*model.User, error) {
+	if token == "" {
+		return nil, fmt.Errorf("invalid or expired verification token")
+	}
+	tokenHash := hashResetToken(token)
+	now := time.Now()
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// Deleting the row claims the token, so it can't be used twice.
+		var v studentVerification
+		err := s.pool.QueryRow(ctx,
+			`DELETE FROM student_verifications WHERE token_hash = $1 RETURNING user_id, email, school_id, expires_at`,
+			tokenHash).Scan(&v.UserID, &v.Email, &v.SchoolID, &v.ExpiresAt)
+		if err == pgx.ErrNoRows || err == nil && !now.Before(v.ExpiresAt) {
+			return nil, fmt.Errorf("invalid or expired verification token")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify student email: %w", err)
+		}
+		if owner := s.studentEmailOwner(v.Email); owner != "" && owner != v.UserID {
+			return nil, fmt.Errorf("this email is already verified on another account")
+		}
+		tag, err := s.pool.Exec(ctx,
+			`UPDATE users SET school_id = $1, student_email = $2, student_verified_at = $3 WHERE id = $4 AND anonymized_at IS NULL`,
+			v.SchoolID, v.Email, now, v.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify student email: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, fmt.Errorf("invalid or expired verification token")
+		}
+		s.mu.Lock()
+		s.students[v.UserID] = v.SchoolID
+		s.mu.Unlock()
+		return s.GetUser(v.UserID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.studentTokens[tokenHash]
+	if !ok || !now.Before(v.ExpiresAt) {
+		return nil, fmt.Errorf("invalid or expired verification token")
+	}
+	delete(s.studentTokens, tokenHash)
+	for _, rec := range s.users {
+		if rec.User.StudentEmail == v.Email && rec.User.ID != v.UserID {
+			return nil, fmt.Errorf("this email is already verified on another account")
+		}
+	}
+	for _, rec := range s.users {
+		if rec.User.ID == v.UserID && !rec.Anonymized {
+			rec.User.SchoolID = v.SchoolID
+			rec.User.StudentEmail = v.Email
+			rec.User.StudentVerifiedAt = &now
+			rec.User.VerifiedStudent = true
+			s.students[v.UserID] = v.SchoolID
+			u := rec.User
+			u.TermsCurrent = u.TermsVersion == CurrentTermsVersion()
+			return &u, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid or expired verification token")
+}
+
+// PurgeExpiredStudentVerifications deletes confirmation tokens that expired
+// before cutoff.
+func (s *AuthService) PurgeExpiredStudentVerifications(cutoff time.Time, dryRun bool) (int, error) {
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if dryRun {
+			var n int
+			err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM student_verifications WHERE expires_at < $1`, cutoff).Scan(&n)
+			return n, err
+		}
+		tag, err := s.pool.Exec(ctx, `DELETE FROM student_verifications WHERE expires_at < $1`, cutoff)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge verification tokens: %w", err)
+		}
+		return int(tag.RowsAffected()), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for h, v := range s.studentTokens {
+		if v.ExpiresAt.Before(cutoff) {
+			if !dryRun {
+				delete(s.studentTokens, h)
+			}
+			n++
+		}
+	}
+	return n, nil
+}
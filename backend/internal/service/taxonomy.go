@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// Taxonomy kinds.
+const (
+	TaxonomyCategory = "category"
+	TaxonomyTag      = "tag"
+)
+
+// defaultTaxonomy seeds an empty taxonomy table with the values the site
+// launched with.
+var defaultTaxonomy = map[string][]model.TaxonomyTerm{
+	TaxonomyCategory: {
+		{Slug: "bar", Label: "Bar"},
+		{Slug: "nightclub", Label: "Nightclub"},
+		{Slug: "frat", Label: "Frat / Sorority"},
+		{Slug: "party_host", Label: "Party Host"},
+		{Slug: "other", Label: "Other"},
+	},
+	TaxonomyTag: {
+		{Slug: "cheap_drinks", Label: "Cheap Drinks"},
+		{Slug: "strong_drinks", Label: "Strong Drinks"},
+		{Slug: "no_cover", Label: "No Cover"},
+		{Slug: "live_music", Label: "Live Music"},
+		{Slug: "dance_floor", Label: "Dance Floor"},
+		{Slug: "outdoor_seating", Label: "Outdoor Seating"},
+		{Slug: "sports", Label: "Sports"},
+		{Slug: "karaoke", Label: "Karaoke"},
+		{Slug: "trivia", Label: "Trivia"},
+		{Slug: "late_night", Label: "Late Night"},
+		{Slug: "long_lines", Label: "Long Lines"},
+		{Slug: "dive", Label: "Dive"},
+	},
+}
+
+var taxonomySlugPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,31}$`)
+
+// TaxonomyService holds the admin-editable venue categories and review tags.
+// Lookups are served from memory; edits write through to the DB.
+type TaxonomyService struct {
+	mu    sync.RWMutex
+	pool  *pgxpool.Pool
+	terms map[string]map[string]model.TaxonomyTerm // kind -> slug -> term
+}
+
+func NewTaxonomyService(pool *pgxpool.Pool) *TaxonomyService {
+	svc := &TaxonomyService{
+		pool:  pool,
+		terms: map[string]map[string]model.TaxonomyTerm{TaxonomyCategory: {}, TaxonomyTag: {}},
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	for kind, defaults := range defaultTaxonomy {
+		if len(svc.terms[kind]) > 0 {
+			continue
+		}
+		for i, t := range defaults {
+			t.Kind = kind
+			t.Position = i
+			t.CreatedAt = time.Now()
+			svc.terms[kind][t.Slug] = t
+			svc.persist(t)
+		}
+	}
+	return svc
+}
+
+func (s *TaxonomyService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT kind, slug, label, position, created_at FROM taxonomy_terms`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load taxonomy terms from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var t model.TaxonomyTerm
+		if err := rows.Scan(&t.Kind, &t.Slug, &t.Label, &t.Position, &t.CreatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan taxonomy row: %v", err)
+			continue
+		}
+		if s.terms[t.Kind] == nil {
+			continue
+		}
+		s.terms[t.Kind][t.Slug] = t
+		count++
+	}
+	log.Printf("Loaded %d taxonomy terms from DB", count)
+}
+
+func (s *TaxonomyService) persist(t model.TaxonomyTerm) {
+	if s.pool == nil {
+		return
+	}
+	_, err := s.pool.Exec(context.Background(),
+		`INSERT INTO taxonomy_terms (kind, slug, label, position, created_at) VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (kind, slug) DO UPDATE SET label = EXCLUDED.label, position = EXCLUDED.position`,
+		t.Kind, t.Slug, t.Label, t.Position, t.CreatedAt)
+	if err != nil {
+		log.Printf("WARNING: Failed to persist taxonomy term to DB: %v", err)
+	}
+}
+
+// Valid reports whether slug is a current term of the given kind. A nil
+// service checks against the defaults.
+func (s *TaxonomyService) Valid(kind, slug string) bool {
+	if s == nil {
+		for _, t := range defaultTaxonomy[kind] {
+			if t.Slug == slug {
+				return true
+			}
+		}
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.terms[kind][slug]
+	return ok
+}
+
+// List returns the terms of a kind in display order.
+func (s *TaxonomyService) List(kind string) ([]model.TaxonomyTerm, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	terms, ok := s.terms[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown taxonomy: %s", kind)
+	}
+	out := make([]model.TaxonomyTerm, 0, len(terms))
+	for _, t := range terms {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Position != out[j].Position {
+			return out[i].Position < out[j].Position
+		}
+		return out[i].Slug < out[j].Slug
+	})
+	return out, nil
+}
+
+// Create adds a term (admin only). New terms are listed last unless a
+// position is given.
+func (s *TaxonomyService) Create(kind string, req model.TaxonomyTermRequest) (*model.TaxonomyTerm, error) {
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if !taxonomySlugPattern.MatchString(slug) {
+		return nil, fmt.Errorf("slug must be 2-32 lowercase letters, digits, or underscores")
+	}
+	label := middleware.SanitizeString(strings.TrimSpace(req.Label))
+	if label == "" {
+		return nil, fmt.Errorf("label is required")
+	}
+
+	s.mu.Lock()
+	terms, ok := s.terms[kind]
+	if !ok {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("unknown taxonomy: %s", kind)
+	}
+	if _, exists := terms[slug]; exists {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("term already exists")
+	}
+	t := model.TaxonomyTerm{Kind: kind, Slug: slug, Label: label, Position: len(terms), CreatedAt: time.Now()}
+	if req.Position != nil {
+		t.Position = *req.Position
+	}
+	terms[slug] = t
+	s.mu.Unlock()
+
+	s.persist(t)
+	return &t, nil
+}
+
+// Update relabels or reorders a term (admin only). Slugs are immutable since
+// venues and ratings store them.
+func (s *TaxonomyService) Update(kind, slug string, req model.TaxonomyTermRequest) (*model.TaxonomyTerm, error) {
+	s.mu.Lock()
+	t, ok := s.terms[kind][slug]
+	if !ok {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("term not found")
+	}
+	if label := middleware.SanitizeString(strings.TrimSpace(req.Label)); label != "" {
+		t.Label = label
+	}
+	if req.Position != nil {
+		t.Position = *req.Position
+	}
+	s.terms[kind][slug] = t
+	s.mu.Unlock()
+
+	s.persist(t)
+	return &t, nil
+}
+
+// Delete removes a term so it can no longer be chosen (admin only). Venues
+// and ratings already using it keep their stored value.
+func (s *TaxonomyService) Delete(kind, slug string) error {
+	s.mu.Lock()
+	if _, ok := s.terms[kind][slug]; !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("term not found")
+	}
+	delete(s.terms[kind], slug)
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`DELETE FROM taxonomy_terms WHERE kind = $1 AND slug = $2`, kind, slug); err != nil {
+			log.Printf("WARNING: Failed to delete taxonomy term from DB: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"log"
+	"math"
+	"os"
+	"strconv"
+)
+
+// ThumbsConfig decides which ratings count as a thumbs up or down. Scores
+// between the two thresholds count as neither.
+type ThumbsConfig struct {
+	UpMin   float32 // scores at or above this are a thumbs up
+	DownMax float32 // scores at or below this are a thumbs down
+}
+
+// DefaultThumbsConfig treats 4-5 as up and 1-2 as down.
+var DefaultThumbsConfig = ThumbsConfig{UpMin: 4, DownMax: 2}
+
+// LoadThumbsConfig reads THUMBS_UP_MIN and THUMBS_DOWN_MAX, falling back to
+// the defaults if the pair is out of range or overlaps.
+func LoadThumbsConfig() ThumbsConfig {
+	cfg := DefaultThumbsConfig
+	if v, err := strconv.ParseFloat(os.Getenv("THUMBS_UP_MIN"), 32); err == nil {
+		cfg.UpMin = float32(v)
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("THUMBS_DOWN_MAX"), 32); err == nil {
+		cfg.DownMax = float32(v)
+	}
+	if cfg.DownMax < 1 || cfg.UpMin > 5 || cfg.DownMax >= cfg.UpMin {
+		log.Printf("WARNING: Invalid thumbs thresholds (up >= %.1f, down <= %.1f), using defaults", cfg.UpMin, cfg.DownMax)
+		return DefaultThumbsConfig
+	}
+	return cfg
+}
+
+// RecommendPct is the share of ratings that are a thumbs up, as a whole
+// percentage, or nil for an unrated venue.
+func RecommendPct(thumbsUp, ratingCount int) *int {
+	if ratingCount <= 0 {
+		return nil
+	}
+	pct := int(math.Round(float64(thumbsUp) * 100 / float64(ratingCount)))
+	return &pct
+}
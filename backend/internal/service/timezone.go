@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+	_ "time/tzdata" // containers often ship without a zoneinfo database
+)
+
+// stateTimezones maps state/territory abbreviations to their predominant IANA zone.
+// Split states use the zone covering most of their campuses.
+var stateTimezones = map[string]string{
+	"AL": "America/Chicago", "AK": "America/Anchorage", "AZ": "America/Phoenix",
+	"AR": "America/Chicago", "CA": "America/Los_Angeles", "CO": "America/Denver",
+	"CT": "America/New_York", "DE": "America/New_York", "DC": "America/New_York",
+	"FL": "America/New_York", "GA": "America/New_York", "HI": "Pacific/Honolulu",
+	"ID": "America/Boise", "IL": "America/Chicago", "IN": "America/Indiana/Indianapolis",
+	"IA": "America/Chicago", "KS": "America/Chicago", "KY": "America/New_York",
+	"LA": "America/Chicago", "ME": "America/New_York", "MD": "America/New_York",
+	"MA": "America/New_York", "MI": "America/Detroit", "MN": "America/Chicago",
+	"MS": "America/Chicago", "MO": "America/Chicago", "MT": "America/Denver",
+	"NE": "America/Chicago", "NV": "America/Los_Angeles", "NH": "America/New_York",
+	"NJ": "America/New_York", "NM": "America/Denver", "NY": "America/New_York",
+	"NC": "America/New_York", "ND": "America/Chicago", "OH": "America/New_York",
+	"OK": "America/Chicago", "OR": "America/Los_Angeles", "PA": "America/New_York",
+	"RI": "America/New_York", "SC": "America/New_York", "SD": "America/Chicago",
+	"TN": "America/Chicago", "TX": "America/Chicago", "UT": "America/Denver",
+	"VT": "America/New_York", "VA": "America/New_York", "WA": "America/Los_Angeles",
+	"WV": "America/New_York", "WI": "America/Chicago", "WY": "America/Denver",
+	"PR": "America/Puerto_Rico", "GU": "Pacific/Guam", "VI": "America/St_Thomas",
+	"AS": "Pacific/Pago_Pago", "MP": "Pacific/Saipan",
+}
+
+// provinceTimezones does the same for Canadian provinces and territories.
+var provinceTimezones = map[string]string{
+	"AB": "America/Edmonton", "BC": "America/Vancouver", "MB": "America/Winnipeg",
+	"NB": "America/Moncton", "NL": "America/St_Johns", "NS": "America/Halifax",
+	"NT": "America/Yellowknife", "NU": "America/Iqaluit", "ON": "America/Toronto",
+	"PE": "America/Halifax", "QC": "America/Toronto", "SK": "America/Regina",
+	"YT": "America/Whitehorse",
+}
+
+var (
+	locationCache   sync.Map // zone name -> *time.Location
+	defaultLocation = mustLoadLocation("America/New_York")
+)
+
+// mustLoadLocation loads (and caches) a zone, falling back to UTC if it's unknown.
+func mustLoadLocation(name string) *time.Location {
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	locationCache.Store(name, loc)
+	return loc
+}
+
+// Location returns the local time zone for a school, defaulting to US Eastern.
+func (s *SchoolService) Location(schoolID string) *time.Location {
+	school, err := s.GetByID(context.Background(), schoolID)
+	if err != nil {
+		return defaultLocation
+	}
+	zones := stateTimezones
+	if school.Country == "CA" {
+		zones = provinceTimezones
+	}
+	name, ok := zones[school.State]
+	if !ok {
+		return defaultLocation
+	}
+	return mustLoadLocation(name)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ratemybars/backend/internal/middleware"
+)
+
+// tokenDenylist is the in-process copy of revoked_tokens. In database mode
+// it picks up revocations from other instances at most every
+// tokenCutoffRecheck.
+type tokenDenylist struct {
+	mu     sync.RWMutex
+	ids    map[string]time.Time // jti -> token expiry
+	synced time.Time
+}
+
+func (d *tokenDenylist) add(jti string, expiresAt time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.ids == nil {
+		d.ids = make(map[string]time.Time)
+	}
+	d.ids[jti] = expiresAt
+}
+
+func (d *tokenDenylist) has(jti string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.ids[jti]
+	return ok
+}
+
+// purge drops entries that expired before cutoff and returns how many there
+// were. With dryRun it only counts them.
+func (d *tokenDenylist) purge(cutoff time.Time, dryRun bool) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := 0
+	for jti, exp := range d.ids {
+		if exp.Before(cutoff) {
+			if !dryRun {
+				delete(d.ids, jti)
+			}
+			n++
+		}
+	}
+	return n
+}
+
+// revokeToken denylists a JWT until it expires. Tokens issued before JWTs
+// carried a jti can't be revoked one by one and simply expire.
+func (s *AuthService) revokeToken(token string) {
+	jti, userID, expiresAt, ok := middleware.TokenClaims(token)
+	if !ok || jti == "" || !time.Now().Before(expiresAt) {
+		return
+	}
+	s.revoked.add(jti, expiresAt)
+
+	if s.persistent() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := s.pool.Exec(ctx,
+			`INSERT INTO revoked_tokens (jti, user_id, expires_at) VALUES ($1, $2, $3) ON CONFLICT (jti) DO NOTHING`,
+			jti, userID, expiresAt); err != nil {
+			log.Printf("WARNING: Failed to persist revoked token: %v", err)
+		}
+	}
+}
+
+// TokenValid reports whether a JWT is still good: not revoked at logout and
+// not issued before the user's last password change or ban.
+func (s *AuthService) TokenValid(userID, tokenID string, issuedAt time.Time) bool {
+	return s.TokenIssuedValid(userID, issuedAt) && !s.tokenRevoked(tokenID)
+}
+
+func (s *AuthService) tokenRevoked(jti string) bool {
+	if jti == "" {
+		return false
+	}
+	if s.persistent() {
+		s.syncRevoked()
+	}
+	return s.revoked.has(jti)
+}
+
+// syncRevoked loads tokens revoked since the last sync (with a minute of
+// overlap for clock skew between instances). On a database error the local
+// copy keeps being used.
+func (s *AuthService) syncRevoked() {
+	d := &s.revoked
+	d.mu.Lock()
+	since := d.synced
+	if time.Since(since) < tokenCutoffRecheck {
+		d.mu.Unlock()
+		return
+	}
+	d.synced = time.Now()
+	d.mu.Unlock()
+	if !since.IsZero() {
+		since = since.Add(-time.Minute)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	rows, err := s.pool.Query(ctx,
+		`SELECT jti, expires_at FROM revoked_tokens WHERE revoked_at >= $1 AND expires_at > NOW()`, since)
+	if err != nil {
+		log.Printf("WARNING: Failed to load revoked tokens: %v", err)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var jti string
+		var expiresAt time.Time
+		if err := rows.Scan(&jti, &expiresAt); err != nil {
+			log.Printf("WARNING: Failed to scan revoked token: %v", err)
+			return
+		}
+		d.add(jti, expiresAt)
+	}
+}
+
+// PurgeRevokedTokens forgets revoked tokens that expired before cutoff; they
+// fail verification on their own by then.
+func (s *AuthService) PurgeRevokedTokens(cutoff time.Time, dryRun bool) (int, error) {
+	n := s.revoked.purge(cutoff, dryRun)
+	if !s.persistent() {
+		return n, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if dryRun {
+		err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM revoked_tokens WHERE expires_at < $1`, cutoff).Scan(&n)
+		return n, err
+	}
+	tag, err := s.pool.Exec(ctx, `DELETE FROM revoked_tokens WHERE expires_at < $1`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge revoked tokens: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
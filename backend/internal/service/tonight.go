@@ -0,0 +1,140 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	// tonightEndHour is the local hour at which "tonight" is over.
+	tonightEndHour = 4
+	// recentCheckInWindow is how far back check-ins count towards a venue's buzz.
+	recentCheckInWindow = 3 * time.Hour
+)
+
+// TonightService ranks nearby venues for "where to go tonight" by combining
+// ratings, opening hours, specials, events, and recent check-ins.
+type TonightService struct {
+	venueSvc   *VenueService
+	schoolSvc  *SchoolService
+	eventSvc   *EventService
+	checkInSvc *CheckInService
+	weather    *WeatherService
+	gameDays   *GameDayService
+	statuses   *VenueStatusService
+}
+
+func NewTonightService(venueSvc *VenueService, schoolSvc *SchoolService, eventSvc *EventService, checkInSvc *CheckInService) *TonightService {
+	return &TonightService{venueSvc: venueSvc, schoolSvc: schoolSvc, eventSvc: eventSvc, checkInSvc: checkInSvc}
+}
+
+// SetWeather enables the patio weather flag and its score bonus.
+func (s *TonightService) SetWeather(weather *WeatherService) {
+	s.weather = weather
+}
+
+// SetGameDays enables game-day flags and the sports bar boost on home-game
+// days.
+func (s *TonightService) SetGameDays(gameDays *GameDayService) {
+	s.gameDays = gameDays
+}
+
+// SetStatuses attaches owners' nightly venue statuses.
+func (s *TonightService) SetStatuses(statuses *VenueStatusService) {
+	s.statuses = statuses
+}
+
+// tonightEnd returns the next tonightEndHour after t in its location.
+func tonightEnd(t time.Time) time.Time {
+	end := time.Date(t.Year(), t.Month(), t.Day(), tonightEndHour, 0, 0, 0, t.Location())
+	if !t.Before(end) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// Rank returns venues within radiusKm of a point, best first. With
+// skipClosingSoon, venues closing within ClosingSoonMinutes are left out.
+func (s *TonightService) Rank(lat, lng, radiusKm float64, now time.Time, skipClosingSoon bool) []model.TonightVenue {
+	out := []model.TonightVenue{}
+	gameDay := map[string]bool{} // school ID -> home game today
+	for _, v := range s.venueSvc.GetNearby(lat, lng, radiusKm, "") {
+		loc := s.schoolSvc.Location(v.SchoolID)
+		local := now.In(loc)
+		end := tonightEnd(local)
+
+		closesIn := ClosesInMinutes(v.Hours, local)
+		if skipClosingSoon && closesIn != nil && *closesIn < ClosingSoonMinutes {
+			continue
+		}
+
+		tv := model.TonightVenue{
+			Venue:           v,
+			DistanceKm:      math.Round(haversineKm(lat, lng, v.Latitude, v.Longitude)*100) / 100,
+			OpenNow:         IsOpenAt(v.Hours, local),
+			ClosesInMinutes: closesIn,
+			Specials:        []model.VenueEvent{},
+			Events:          []model.VenueEvent{},
+			RecentCheckIns:  s.checkInSvc.CountSince(v.ID, now.Add(-recentCheckInWindow)),
+			PatioWeather:    s.weather.PatioWeather(v),
+			Status:          s.statuses.Current(v.ID, now),
+		}
+		isGameDay, ok := gameDay[v.SchoolID]
+		if !ok {
+			isGameDay = s.gameDays.HomeGameAt(v.SchoolID, now) != nil
+			gameDay[v.SchoolID] = isGameDay
+		}
+		tv.GameDay = isGameDay
+		for _, e := range s.eventSvc.Between(v.ID, now, end, loc) {
+			if e.Kind == "special" {
+				tv.Specials = append(tv.Specials, e)
+			} else {
+				tv.Events = append(tv.Events, e)
+			}
+		}
+		tv.Score = tonightScore(tv, now, isGameDay && s.gameDays.IsSportsBar(v.ID))
+		out = append(out, tv)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].DistanceKm < out[j].DistanceKm
+	})
+	return out
+}
+
+// tonightScore weighs quality, what's on, buzz, and distance. Unrated venues
+// start from a neutral 2.5; venues known to be closed sink to the bottom, a
+// patio on a nice evening gets a small boost, and sports bars get a bigger one
+// on home-game days.
+func tonightScore(tv model.TonightVenue, now time.Time, gameDaySportsBar bool) float64 {
+	score := 2.5
+	if tv.Venue.RatingCount > 0 {
+		score = tv.Venue.AvgRating
+	}
+	for _, e := range tv.Specials {
+		if e.StartsAt.After(now) {
+			score += 0.5
+		} else {
+			score += 1.0
+		}
+	}
+	score += 0.75 * float64(len(tv.Events))
+	score += 2 * math.Log1p(float64(tv.RecentCheckIns))
+	score -= 0.25 * tv.DistanceKm
+	if tv.OpenNow != nil && !*tv.OpenNow {
+		score -= 3
+	}
+	if tv.PatioWeather != nil && *tv.PatioWeather {
+		score += 0.5
+	}
+	if gameDaySportsBar {
+		score += 1.5
+	}
+	return math.Round(score*100) / 100
+}
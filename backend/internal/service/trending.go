@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	trendingWindow   = 7 * 24 * time.Hour
+	trendingHalfLife = 72 * time.Hour
+
+	trendingRatingWeight  = 1.0
+	trendingCheckInWeight = 0.5
+)
+
+// TrendingCurve weights activity by when it happened in the school's local
+// time, so a Thursday 11pm rating counts for more than a Tuesday 2pm one.
+// Activity before tonightEndHour belongs to the previous night's weekday.
+type TrendingCurve struct {
+	Hour [24]float64
+	Day  [7]float64 // 0 = Sunday
+}
+
+// DefaultTrendingCurve peaks late in the evening and towards the weekend.
+var DefaultTrendingCurve = TrendingCurve{
+	Hour: [24]float64{
+		1.6, 1.5, 1.2, 0.8, 0.4, 0.3, // 00-05
+		0.3, 0.3, 0.3, 0.3, 0.3, 0.4, // 06-11
+		0.4, 0.4, 0.4, 0.5, 0.6, 0.8, // 12-17
+		0.9, 1.0, 1.2, 1.4, 1.6, 1.8, // 18-23
+	},
+	Day: [7]float64{0.7, 0.5, 0.6, 0.9, 1.3, 1.5, 1.5},
+}
+
+// Weight returns the curve's weight for an instant in local time.
+func (c TrendingCurve) Weight(local time.Time) float64 {
+	night := local
+	if local.Hour() < tonightEndHour {
+		night = local.AddDate(0, 0, -1)
+	}
+	return c.Hour[local.Hour()] * c.Day[int(night.Weekday())]
+}
+
+// LoadTrendingCurve returns the default curve, overridden by TRENDING_HOUR_WEIGHTS
+// (24 comma-separated numbers, midnight first) and TRENDING_DAY_WEIGHTS (7, Sunday first).
+func LoadTrendingCurve() TrendingCurve {
+	curve := DefaultTrendingCurve
+	if v := os.Getenv("TRENDING_HOUR_WEIGHTS"); v != "" {
+		if w, err := parseWeights(v, 24); err != nil {
+			log.Printf("WARNING: Ignoring TRENDING_HOUR_WEIGHTS: %v", err)
+		} else {
+			copy(curve.Hour[:], w)
+		}
+	}
+	if v := os.Getenv("TRENDING_DAY_WEIGHTS"); v != "" {
+		if w, err := parseWeights(v, 7); err != nil {
+			log.Printf("WARNING: Ignoring TRENDING_DAY_WEIGHTS: %v", err)
+		} else {
+			copy(curve.Day[:], w)
+		}
+	}
+	return curve
+}
+
+func parseWeights(v string, n int) ([]float64, error) {
+	parts := strings.Split(v, ",")
+	if len(parts) != n {
+		return nil, fmt.Errorf("expected %d weights, got %d", n, len(parts))
+	}
+	out := make([]float64, n)
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil || f < 0 {
+			return nil, fmt.Errorf("invalid weight %q", p)
+		}
+		out[i] = f
+	}
+	return out, nil
+}
+
+// TrendingService ranks a school's venues by recent activity, weighted by
+// time of day in the school's local time and decayed by age.
+type TrendingService struct {
+	venueSvc   *VenueService
+	schoolSvc  *SchoolService
+	ratingSvc  *RatingService
+	checkInSvc *CheckInService
+	curve      TrendingCurve
+}
+
+func NewTrendingService(venueSvc *VenueService, schoolSvc *SchoolService, ratingSvc *RatingService,
+	checkInSvc *CheckInService, curve TrendingCurve) *TrendingService {
+	return &TrendingService{venueSvc: venueSvc, schoolSvc: schoolSvc, ratingSvc: ratingSvc, checkInSvc: checkInSvc, curve: curve}
+}
+
+// activityWeight combines the time-of-day curve with exponential decay by age.
+func (s *TrendingService) activityWeight(at, now time.Time, loc *time.Location) float64 {
+	age := now.Sub(at)
+	decay := math.Pow(0.5, age.Hours()/trendingHalfLife.Hours())
+	return s.curve.Weight(at.In(loc)) * decay
+}
+
+// BySchool returns the school's venues with activity in the last week, hottest first.
+func (s *TrendingService) BySchool(schoolID string, now time.Time, limit int) []model.TrendingVenue {
+	loc := s.schoolSvc.Location(schoolID)
+	since := now.Add(-trendingWindow)
+
+	ids := s.venueSvc.GetVenueIDsBySchool(schoolID)
+	byVenue := make(map[string]*model.TrendingVenue)
+	entry := func(venueID string) *model.TrendingVenue {
+		tv, ok := byVenue[venueID]
+		if !ok {
+			tv = &model.TrendingVenue{}
+			byVenue[venueID] = tv
+		}
+		return tv
+	}
+
+	for _, r := range s.ratingSvc.ListByVenues(ids) {
+		if r.CreatedAt.Before(since) || r.CreatedAt.After(now) {
+			continue
+		}
+		tv := entry(r.VenueID)
+		tv.Ratings++
+		tv.Score += trendingRatingWeight * s.activityWeight(r.CreatedAt, now, loc)
+	}
+	for _, c := range s.checkInSvc.Since(ids, since) {
+		tv := entry(c.VenueID)
+		tv.CheckIns++
+		tv.Score += trendingCheckInWeight * s.activityWeight(c.CreatedAt, now, loc)
+	}
+
+	out := []model.TrendingVenue{}
+	for venueID, tv := range byVenue {
+		v, err := s.venueSvc.GetByID(context.Background(), venueID)
+		if err != nil || !v.Verified {
+			continue
+		}
+		tv.Venue = *v
+		tv.Score = math.Round(tv.Score*100) / 100
+		out = append(out, *tv)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Venue.ID < out[j].Venue.ID
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
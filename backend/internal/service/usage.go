@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	usageHistory    = 7 * 24 * time.Hour
+	usageTopIPs     = 50    // IPs kept per persisted hour
+	usageMaxIPs     = 10000 // distinct IPs tracked in the current hour
+	usageOtherIPKey = "other"
+)
+
+// Usage report dimensions, also stored in api_usage.dimension.
+const (
+	usageDimTotal  = "total"
+	usageDimRoute  = "route"
+	usageDimAPIKey = "api_key"
+	usageDimIP     = "ip"
+)
+
+type usageCounter struct {
+	Requests  int
+	Throttled int
+}
+
+func (c *usageCounter) add(throttled bool) {
+	c.Requests++
+	if throttled {
+		c.Throttled++
+	}
+}
+
+// usageBucket aggregates one hour of traffic.
+type usageBucket struct {
+	Total     usageCounter
+	Routes    map[string]*usageCounter
+	APIKeys   map[string]*usageCounter
+	IPs       map[string]*usageCounter
+	persisted bool
+}
+
+func newUsageBucket() *usageBucket {
+	return &usageBucket{
+		Routes:  make(map[string]*usageCounter),
+		APIKeys: make(map[string]*usageCounter),
+		IPs:     make(map[string]*usageCounter),
+	}
+}
+
+func (b *usageBucket) dim(dimension string) map[string]*usageCounter {
+	switch dimension {
+	case usageDimRoute:
+		return b.Routes
+	case usageDimAPIKey:
+		return b.APIKeys
+	case usageDimIP:
+		return b.IPs
+	}
+	return nil
+}
+
+func bump(m map[string]*usageCounter, key string, throttled bool) {
+	c, ok := m[key]
+	if !ok {
+		c = &usageCounter{}
+		m[key] = c
+	}
+	c.add(throttled)
+}
+
+// UsageService aggregates API traffic per hour by route, API key and client
+// IP for the operators' usage report. Finished hours are written to the
+// api_usage table.
+type UsageService struct {
+	mu      sync.Mutex
+	pool    *pgxpool.Pool
+	buckets map[time.Time]*usageBucket // keyed by hour (UTC)
+}
+
+func NewUsageService(pool *pgxpool.Pool) *UsageService {
+	svc := &UsageService{
+		pool:    pool,
+		buckets: make(map[time.Time]*usageBucket),
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *UsageService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT hour, dimension, value, requests, throttled FROM api_usage WHERE hour > $1`,
+		time.Now().Add(-usageHistory))
+	if err != nil {
+		log.Printf("WARNING: Failed to load API usage from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hour time.Time
+		var dimension, value string
+		var c usageCounter
+		if err := rows.Scan(&hour, &dimension, &value, &c.Requests, &c.Throttled); err != nil {
+			log.Printf("WARNING: Failed to scan API usage row: %v", err)
+			continue
+		}
+		hour = hour.UTC()
+		b, ok := s.buckets[hour]
+		if !ok {
+			b = newUsageBucket()
+			b.persisted = true
+			s.buckets[hour] = b
+		}
+		if dimension == usageDimTotal {
+			b.Total = c
+		} else if m := b.dim(dimension); m != nil {
+			m[value] = &c
+		}
+	}
+	log.Printf("Loaded %d hours of API usage from DB", len(s.buckets))
+}
+
+// Record counts one request; it matches middleware.UsageRecordFunc.
+func (s *UsageService) Record(route, apiKey, ip string, status int, at time.Time) {
+	throttled := status == http.StatusTooManyRequests
+	hour := at.UTC().Truncate(time.Hour)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[hour]
+	if !ok {
+		b = newUsageBucket()
+		s.buckets[hour] = b
+	}
+	b.Total.add(throttled)
+	bump(b.Routes, route, throttled)
+	if apiKey != "" {
+		bump(b.APIKeys, apiKey, throttled)
+	}
+	if _, seen := b.IPs[ip]; !seen && len(b.IPs) >= usageMaxIPs {
+		ip = usageOtherIPKey
+	}
+	bump(b.IPs, ip, throttled)
+}
+
+// Start persists finished hours and drops old ones every interval.
+func (s *UsageService) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.flush(time.Now())
+		}
+	}()
+}
+
+// flush writes every finished, unpersisted hour and trims its IP list to the
+// top consumers.
+func (s *UsageService) flush(now time.Time) {
+	current := now.UTC().Truncate(time.Hour)
+	cutoff := now.Add(-usageHistory)
+
+	type pending struct {
+		hour time.Time
+		b    *usageBucket
+	}
+	var done []pending
+	s.mu.Lock()
+	for hour, b := range s.buckets {
+		if hour.Before(cutoff) {
+			delete(s.buckets, hour)
+			continue
+		}
+		if hour.Before(current) && !b.persisted {
+			b.IPs = topUsage(b.IPs, usageTopIPs)
+			b.persisted = true
+			done = append(done, pending{hour, b})
+		}
+	}
+	s.mu.Unlock()
+
+	if s.pool == nil {
+		return
+	}
+	// Finished buckets are no longer written to, so they can be read unlocked.
+	for _, p := range done {
+		s.persist(p.hour, p.b)
+	}
+	if _, err := s.pool.Exec(context.Background(), `DELETE FROM api_usage WHERE hour < $1`, cutoff); err != nil {
+		log.Printf("WARNING: Failed to prune API usage: %v", err)
+	}
+}
+
+func (s *UsageService) persist(hour time.Time, b *usageBucket) {
+	const upsert = `INSERT INTO api_usage (hour, dimension, value, requests, throttled) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (hour, dimension, value) DO UPDATE SET requests = EXCLUDED.requests, throttled = EXCLUDED.throttled`
+	ctx := context.Background()
+	if _, err := s.pool.Exec(ctx, upsert, hour, usageDimTotal, "", b.Total.Requests, b.Total.Throttled); err != nil {
+		log.Printf("WARNING: Failed to persist API usage: %v", err)
+		return
+	}
+	for _, dimension := range []string{usageDimRoute, usageDimAPIKey, usageDimIP} {
+		for value, c := range b.dim(dimension) {
+			if _, err := s.pool.Exec(ctx, upsert, hour, dimension, value, c.Requests, c.Throttled); err != nil {
+				log.Printf("WARNING: Failed to persist API usage: %v", err)
+				return
+			}
+		}
+	}
+}
+
+// topUsage keeps the n busiest keys, folding the rest into "other".
+func topUsage(m map[string]*usageCounter, n int) map[string]*usageCounter {
+	if len(m) <= n {
+		return m
+	}
+	ranked := sortedUsage(m)
+	out := make(map[string]*usageCounter, n+1)
+	other := &usageCounter{}
+	for i, u := range ranked {
+		if i < n && u.Key != usageOtherIPKey {
+			out[u.Key] = &usageCounter{Requests: u.Requests, Throttled: u.Throttled}
+		} else {
+			other.Requests += u.Requests
+			other.Throttled += u.Throttled
+		}
+	}
+	out[usageOtherIPKey] = other
+	return out
+}
+
+func sortedUsage(m map[string]*usageCounter) []model.UsageCount {
+	out := make([]model.UsageCount, 0, len(m))
+	for k, c := range m {
+		out = append(out, model.UsageCount{Key: k, Requests: c.Requests, Throttled: c.Throttled})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Requests != out[j].Requests {
+			return out[i].Requests > out[j].Requests
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// Summary reports traffic over the last window (rounded to whole hours,
+// including the current one).
+func (s *UsageService) Summary(window time.Duration, label string, limit int) model.UsageSummary {
+	now := time.Now().UTC()
+	to := now.Truncate(time.Hour)
+	from := to.Add(-window + time.Hour)
+
+	routes := make(map[string]*usageCounter)
+	keys := make(map[string]*usageCounter)
+	ips := make(map[string]*usageCounter)
+	summary := model.UsageSummary{Window: label, From: from, To: now, Hourly: []model.UsageHour{}}
+
+	s.mu.Lock()
+	for hour := from; !hour.After(to); hour = hour.Add(time.Hour) {
+		b, ok := s.buckets[hour]
+		if !ok {
+			summary.Hourly = append(summary.Hourly, model.UsageHour{Hour: hour})
+			continue
+		}
+		summary.Requests += b.Total.Requests
+		summary.Throttled += b.Total.Throttled
+		summary.Hourly = append(summary.Hourly, model.UsageHour{Hour: hour, Requests: b.Total.Requests, Throttled: b.Total.Throttled})
+		for _, pair := range []struct {
+			dst map[string]*usageCounter
+			src map[string]*usageCounter
+		}{{routes, b.Routes}, {keys, b.APIKeys}, {ips, b.IPs}} {
+			for k, c := range pair.src {
+				d, ok := pair.dst[k]
+				if !ok {
+					d = &usageCounter{}
+					pair.dst[k] = d
+				}
+				d.Requests += c.Requests
+				d.Throttled += c.Throttled
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	summary.Routes = sortedUsage(routes)
+	summary.APIKeys = sortedUsage(keys)
+	summary.TopIPs = sortedUsage(ips)
+	if len(summary.TopIPs) > limit {
+		summary.TopIPs = summary.TopIPs[:limit]
+	}
+	return summary
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// Overview list sizes.
+const (
+	overviewRatings = 50
+	overviewVotes   = 100
+	overviewLogins  = 50
+)
+
+// UserOverviewService gathers what support needs to look into a complaint
+// about an account without signing in as it.
+type UserOverviewService struct {
+	auth        *AuthService
+	ratings     *RatingService
+	fratRatings *FratRatingService
+	sessions    *SessionService // nil unless AUTH_SESSIONS=server
+	audit       *AuditService
+}
+
+func NewUserOverviewService(auth *AuthService, ratings *RatingService, fratRatings *FratRatingService, sessions *SessionService,
+	audit *AuditService) *UserOverviewService {
+	return &UserOverviewService{auth: auth, ratings: ratings, fratRatings: fratRatings, sessions: sessions, audit: audit}
+}
+
+// Overview returns a user's profile, recent ratings, votes, admin actions
+// against them and sign-in state.
+func (s *UserOverviewService) Overview(userID string) (*model.UserOverview, error) {
+	user, err := s.auth.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	email, err := s.auth.AccountEmail(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &model.UserOverview{User: user, Email: email}
+	out.Ratings, out.RatingCount = s.ratings.ListByAuthor(userID, overviewRatings)
+	if out.Ratings == nil {
+		out.Ratings = []model.Rating{}
+	}
+	// ListByAuthor caps the list; vote totals need every rating.
+	all, _ := s.ratings.ListByAuthor(userID, out.RatingCount)
+	for _, r := range all {
+		out.UpvotesReceived += r.Upvotes
+		out.DownvotesReceived += r.Downvotes
+	}
+	out.FratRatings, out.FratRatingCount = s.fratRatings.ListByAuthor(userID, overviewRatings)
+	if out.FratRatings == nil {
+		out.FratRatings = []model.FratRating{}
+	}
+	out.VotesCast = s.ratings.VotesByUser(userID, overviewVotes)
+	out.AdminActions = s.audit.List(model.AuditFilter{TargetType: "user", TargetID: userID})
+
+	if out.Logins, err = s.auth.RecentLogins(userID, overviewLogins); err != nil {
+		return nil, err
+	}
+	if s.sessions != nil {
+		out.Sessions = s.sessions.List(userID)
+	}
+	out.LoginLockouts = []model.LoginLockout{}
+	for _, l := range s.auth.LoginLockouts() {
+		if l.Kind == "email" && strings.EqualFold(l.Value, email) {
+			out.LoginLockouts = append(out.LoginLockouts, l)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+// Rating targets watched for velocity spikes.
+const (
+	RatingTargetVenue = "venue"
+	RatingTargetFrat  = "frat"
+)
+
+const ratingAlertHistory = 30 * 24 * time.Hour
+
+// VelocityConfig controls review-bombing detection. An alert fires when a
+// single target receives Threshold or more ratings within Window; with
+// AutoFreeze set, new ratings on it are refused for FreezeFor.
+type VelocityConfig struct {
+	Window     time.Duration
+	Threshold  int
+	AutoFreeze bool
+	FreezeFor  time.Duration
+}
+
+// LoadVelocityConfig reads RATING_SPIKE_WINDOW_MINUTES (default 60),
+// RATING_SPIKE_THRESHOLD (default 10), RATING_SPIKE_AUTO_FREEZE and
+// RATING_SPIKE_FREEZE_HOURS (default 24).
+func LoadVelocityConfig() VelocityConfig {
+	cfg := VelocityConfig{
+		Window:    time.Hour,
+		Threshold: 10,
+		FreezeFor: 24 * time.Hour,
+	}
+	if v, err := strconv.Atoi(os.Getenv("RATING_SPIKE_WINDOW_MINUTES")); err == nil && v > 0 {
+		cfg.Window = time.Duration(v) * time.Minute
+	}
+	if v, err := strconv.Atoi(os.Getenv("RATING_SPIKE_THRESHOLD")); err == nil && v > 1 {
+		cfg.Threshold = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("RATING_SPIKE_FREEZE_HOURS")); err == nil && v > 0 {
+		cfg.FreezeFor = time.Duration(v) * time.Hour
+	}
+	cfg.AutoFreeze = os.Getenv("RATING_SPIKE_AUTO_FREEZE") == "true"
+	return cfg
+}
+
+// FratTargetID identifies a frat chapter for velocity tracking.
+func FratTargetID(schoolID, fratName string) string {
+	return schoolID + ":" + fratName
+}
+
+// RatingAlertNotifyFunc is called for every new alert (e.g. to page admins).
+type RatingAlertNotifyFunc func(alert model.RatingAlert)
+
+type velocityKey struct {
+	Type string
+	ID   string
+}
+
+// VelocityService watches per-target rating volume for sudden spikes and
+// optionally freezes new ratings on a target while admins investigate.
+type VelocityService struct {
+	mu     sync.Mutex
+	pool   *pgxpool.Pool
+	cfg    VelocityConfig
+	recent map[velocityKey][]time.Time
+	alerts []model.RatingAlert
+	frozen map[velocityKey]time.Time // until
+	notify RatingAlertNotifyFunc
+
+	venueSvc *VenueService // names venue targets; optional
+}
+
+func NewVelocityService(pool *pgxpool.Pool, cfg VelocityConfig) *VelocityService {
+	svc := &VelocityService{
+		pool:   pool,
+		cfg:    cfg,
+		recent: make(map[velocityKey][]time.Time),
+		frozen: make(map[velocityKey]time.Time),
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *VelocityService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, target_type, target_id, COALESCE(target_name,''), count, window_minutes, detected_at, frozen_until
+		 FROM rating_alerts WHERE detected_at > $1 ORDER BY detected_at`, time.Now().Add(-ratingAlertHistory))
+	if err != nil {
+		log.Printf("WARNING: Failed to load rating alerts from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	for rows.Next() {
+		var a model.RatingAlert
+		if err := rows.Scan(&a.ID, &a.TargetType, &a.TargetID, &a.TargetName, &a.Count, &a.WindowMinutes, &a.DetectedAt, &a.FrozenUntil); err != nil {
+			log.Printf("WARNING: Failed to scan rating alert row: %v", err)
+			continue
+		}
+		s.alerts = append(s.alerts, a)
+		if a.FrozenUntil != nil && a.FrozenUntil.After(now) {
+			s.frozen[velocityKey{a.TargetType, a.TargetID}] = *a.FrozenUntil
+		}
+	}
+	log.Printf("Loaded %d rating alerts from DB", len(s.alerts))
+}
+
+// SetNotifier sets the callback for new alerts.
+func (s *VelocityService) SetNotifier(fn RatingAlertNotifyFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notify = fn
+}
+
+// SetVenues lets alerts carry the venue's name.
+func (s *VelocityService) SetVenues(venueSvc *VenueService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.venueSvc = venueSvc
+}
+
+// targetNameLocked returns a display name for a target. Caller holds s.mu.
+func (s *VelocityService) targetNameLocked(targetType, targetID string) string {
+	switch targetType {
+	case RatingTargetVenue:
+		if s.venueSvc != nil {
+			if v, err := s.venueSvc.GetByID(context.Background(), targetID); err == nil {
+				return v.Name
+			}
+		}
+	case RatingTargetFrat:
+		if _, name, ok := strings.Cut(targetID, ":"); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// CheckFrozen returns an error if new ratings on the target are frozen.
+func (s *VelocityService) CheckFrozen(targetType, targetID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	until, ok := s.frozen[velocityKey{targetType, targetID}]
+	if !ok || !time.Now().Before(until) {
+		return nil
+	}
+	return fmt.Errorf("ratings are temporarily frozen for this %s", targetType)
+}
+
+// Observe records a new rating on a target and raises an alert when the
+// target crosses the threshold. Only one alert fires per target per window.
+func (s *VelocityService) Observe(targetType, targetID string, at time.Time) {
+	key := velocityKey{targetType, targetID}
+	cutoff := at.Add(-s.cfg.Window)
+
+	s.mu.Lock()
+	times := s.recent[key]
+	kept := times[:0]
+	for _, t := range times {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	kept = append(kept, at)
+	s.recent[key] = kept
+
+	if len(kept) < s.cfg.Threshold || s.alertedSinceLocked(key, cutoff) {
+		s.mu.Unlock()
+		return
+	}
+
+	alert := model.RatingAlert{
+		ID:            "ralert_" + generateID()[:16],
+		TargetType:    targetType,
+		TargetID:      targetID,
+		TargetName:    s.targetNameLocked(targetType, targetID),
+		Count:         len(kept),
+		WindowMinutes: int(s.cfg.Window / time.Minute),
+		DetectedAt:    at,
+	}
+	if s.cfg.AutoFreeze {
+		until := at.Add(s.cfg.FreezeFor)
+		alert.FrozenUntil = &until
+		s.frozen[key] = until
+	}
+	s.alerts = append(s.alerts, alert)
+	notify := s.notify
+	s.mu.Unlock()
+
+	log.Printf("Rating spike on %s %s: %d ratings in %d minutes", targetType, targetID, alert.Count, alert.WindowMinutes)
+	s.persist(alert)
+	if notify != nil {
+		notify(alert)
+	}
+}
+
+// alertedSinceLocked reports whether the target already has an alert after
+// cutoff. Caller holds s.mu.
+func (s *VelocityService) alertedSinceLocked(key velocityKey, cutoff time.Time) bool {
+	for i := len(s.alerts) - 1; i >= 0; i-- {
+		a := s.alerts[i]
+		if a.DetectedAt.Before(cutoff) {
+			return false
+		}
+		if a.TargetType == key.Type && a.TargetID == key.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// Alerts returns recent alerts, newest first.
+func (s *VelocityService) Alerts() []model.RatingAlert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := time.Now().Add(-ratingAlertHistory)
+	out := make([]model.RatingAlert, 0, len(s.alerts))
+	for _, a := range s.alerts {
+		if a.DetectedAt.After(cutoff) {
+			out = append(out, a)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].DetectedAt.After(out[j].DetectedAt) })
+	return out
+}
+
+// Freeze stops new ratings on an alert's target for d. A zero duration lifts
+// the freeze.
+func (s *VelocityService) Freeze(alertID string, d time.Duration) (*model.RatingAlert, error) {
+	s.mu.Lock()
+	idx := -1
+	for i := range s.alerts {
+		if s.alerts[i].ID == alertID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("alert not found")
+	}
+	a := &s.alerts[idx]
+	key := velocityKey{a.TargetType, a.TargetID}
+	if d > 0 {
+		until := time.Now().Add(d)
+		a.FrozenUntil = &until
+		s.frozen[key] = until
+	} else {
+		a.FrozenUntil = nil
+		delete(s.frozen, key)
+	}
+	alert := *a
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`UPDATE rating_alerts SET frozen_until = $2 WHERE id = $1`, alert.ID, alert.FrozenUntil)
+		if err != nil {
+			log.Printf("WARNING: Failed to update rating alert: %v", err)
+		}
+	}
+	return &alert, nil
+}
+
+func (s *VelocityService) persist(a model.RatingAlert) {
+	if s.pool == nil {
+		return
+	}
+	_, err := s.pool.Exec(context.Background(),
+		`INSERT INTO rating_alerts (id, target_type, target_id, target_name, count, window_minutes, detected_at, frozen_until)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		a.ID, a.TargetType, a.TargetID, a.TargetName, a.Count, a.WindowMinutes, a.DetectedAt, a.FrozenUntil)
+	if err != nil {
+		log.Printf("WARNING: Failed to persist rating alert: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math"
@@ -20,6 +21,9 @@ type VenueService struct {
 	pool   *pgxpool.Pool
 	venues []model.Venue
 	nextID int
+
+	taxonomy    *TaxonomyService
+	autoApprove VenueAutoApprover // optional
 }
 
 func NewVenueService(pool *pgxpool.Pool) *VenueService {
@@ -38,7 +42,7 @@ func (s *VenueService) loadFromDB() {
 	rows, err := s.pool.Query(context.Background(),
 		`SELECT id, name, category, COALESCE(description,''), COALESCE(address,''),
 		        COALESCE(latitude,0), COALESCE(longitude,0), school_id, COALESCE(created_by,''),
-		        created_at, verified
+		        created_at, verified, hours
 		 FROM venues ORDER BY created_at`)
 	if err != nil {
 		log.Printf("WARNING: Failed to load venues from DB: %v", err)
@@ -48,28 +52,47 @@ func (s *VenueService) loadFromDB() {
 
 	for rows.Next() {
 		var v model.Venue
+		var hours []byte
 		if err := rows.Scan(&v.ID, &v.Name, &v.Category, &v.Description, &v.Address,
-			&v.Latitude, &v.Longitude, &v.SchoolID, &v.CreatedByID, &v.CreatedAt, &v.Verified); err != nil {
+			&v.Latitude, &v.Longitude, &v.SchoolID, &v.CreatedByID, &v.CreatedAt, &v.Verified, &hours); err != nil {
 			log.Printf("WARNING: Failed to scan venue row: %v", err)
 			continue
 		}
+		if len(hours) > 0 {
+			json.Unmarshal(hours, &v.Hours)
+		}
 		s.venues = append(s.venues, v)
 		s.nextID++
 	}
 	log.Printf("Loaded %d venues from DB", len(s.venues))
 }
 
-// Create adds a new venue. Admin submissions are auto-approved.
+// SetTaxonomy sets where valid venue categories are looked up.
+func (s *VenueService) SetTaxonomy(taxonomy *TaxonomyService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.taxonomy = taxonomy
+}
+
+// SetAutoApprover lets some non-admin submissions skip the review queue.
+func (s *VenueService) SetAutoApprover(fn VenueAutoApprover) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.autoApprove = fn
+}
+
+// Create adds a new venue. Admin submissions are auto-approved, as are those
+// the auto-approver accepts.
 func (s *VenueService) Create(ctx context.Context, req model.CreateVenueRequest) (*model.Venue, error) {
 	userID := middleware.GetUserID(ctx)
 	if userID == "" {
 		return nil, fmt.Errorf("authentication required")
 	}
 
-	validCategories := map[string]bool{
-		"bar": true, "nightclub": true, "frat": true, "party_host": true, "other": true,
-	}
-	if !validCategories[req.Category] {
+	s.mu.RLock()
+	taxonomy := s.taxonomy
+	s.mu.RUnlock()
+	if !taxonomy.Valid(TaxonomyCategory, req.Category) {
 		return nil, fmt.Errorf("invalid category: %s", req.Category)
 	}
 
@@ -86,8 +109,13 @@ func (s *VenueService) Create(ctx context.Context, req model.CreateVenueRequest)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	id := fmt.Sprintf("venue_%d", s.nextID)
+	if !approved && s.autoApprove != nil {
+		approved = s.autoApprove(userID, req.SchoolID, id)
+	}
+
 	venue := model.Venue{
-		ID:          fmt.Sprintf("venue_%d", s.nextID),
+		ID:          id,
 		Name:        middleware.SanitizeString(req.Name),
 		Category:    req.Category,
 		Description: middleware.SanitizeString(req.Description),
@@ -129,6 +157,34 @@ func (s *VenueService) GetByID(_ context.Context, id string) (*model.Venue, erro
 	return nil, fmt.Errorf("venue not found: %s", id)
 }
 
+// StatsFor returns the cached rating stats of the given approved venues,
+// keyed by venue ID, in a single pass. Unknown IDs are left out.
+func (s *VenueService) StatsFor(ids []string) map[string]model.VenueStats {
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]model.VenueStats, len(want))
+	for _, v := range s.venues {
+		if !want[v.ID] || !v.Verified {
+			continue
+		}
+		out[v.ID] = model.VenueStats{
+			AvgRating:   v.AvgRating,
+			RatingCount: v.RatingCount,
+			ThumbsUp:    v.ThumbsUp,
+			ThumbsDown:  v.ThumbsDown,
+
+			RecommendPct: v.RecommendPct,
+		}
+	}
+	return out
+}
+
 // ListBySchool returns approved venues for a school (public view).
 func (s *VenueService) ListBySchool(_ context.Context, schoolID string, page, limit int) (*model.PaginatedResponse, error) {
 	s.mu.RLock()
@@ -229,6 +285,87 @@ func (s *VenueService) RejectVenue(id string) error {
 	return fmt.Errorf("venue not found: %s", id)
 }
 
+// RejectPendingBy removes every pending venue a user submitted, e.g. when
+// they are banned, and returns their IDs.
+func (s *VenueService) RejectPendingBy(userID string) []string {
+	s.mu.Lock()
+	ids := []string{}
+	kept := s.venues[:0]
+	for _, v := range s.venues {
+		if !v.Verified && v.CreatedByID == userID {
+			ids = append(ids, v.ID)
+			continue
+		}
+		kept = append(kept, v)
+	}
+	s.venues = kept
+	s.mu.Unlock()
+
+	if len(ids) > 0 && s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`DELETE FROM venues WHERE id = ANY($1) AND verified = false`, ids); err != nil {
+			log.Printf("WARNING: Failed to delete rejected venues from DB: %v", err)
+		}
+	}
+	return ids
+}
+
+// IDsByCategory returns the venues filed under a category.
+func (s *VenueService) IDsByCategory(category string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := []string{}
+	for _, v := range s.venues {
+		if v.Category == category {
+			ids = append(ids, v.ID)
+		}
+	}
+	return ids
+}
+
+// DeleteCreator detaches a deleted account from the venues it submitted.
+func (s *VenueService) DeleteCreator(userID string) {
+	s.mu.Lock()
+	for i := range s.venues {
+		if s.venues[i].CreatedByID == userID {
+			s.venues[i].CreatedByID = DeletedAuthorID
+		}
+	}
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`UPDATE venues SET created_by = $1 WHERE created_by = $2`, DeletedAuthorID, userID); err != nil {
+			log.Printf("WARNING: Failed to detach deleted creator from venues: %v", err)
+		}
+	}
+}
+
+// MergeCreator credits fromID's submitted venues to toID and returns their
+// IDs; with dryRun nothing changes.
+func (s *VenueService) MergeCreator(fromID, toID string, dryRun bool) []string {
+	s.mu.Lock()
+	ids := []string{}
+	for i := range s.venues {
+		if s.venues[i].CreatedByID == fromID {
+			ids = append(ids, s.venues[i].ID)
+			if !dryRun {
+				s.venues[i].CreatedByID = toID
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if !dryRun && s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`UPDATE venues SET created_by = $1 WHERE created_by = $2`, toID, fromID); err != nil {
+			log.Printf("WARNING: Failed to move venues while merging users: %v", err)
+		}
+	}
+	return ids
+}
+
 // DeleteVenue removes a venue by ID (admin action, works on any venue).
 func (s *VenueService) DeleteVenue(id string) error {
 	s.mu.Lock()
@@ -251,20 +388,18 @@ func (s *VenueService) DeleteVenue(id string) error {
 	return fmt.Errorf("venue not found: %s", id)
 }
 
-// SearchVenues returns verified venues matching a query string.
+// SearchVenues returns verified venues matching a query string. A limit of
+// zero or less returns every match.
 func (s *VenueService) SearchVenues(query string, limit int) []model.Venue {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if limit <= 0 {
-		limit = 20
-	}
 	q := strings.ToLower(query)
 	var results []model.Venue
 	for _, v := range s.venues {
 		if v.Verified && strings.Contains(strings.ToLower(v.Name), q) {
 			results = append(results, v)
-			if len(results) >= limit {
+			if limit > 0 && len(results) >= limit {
 				break
 			}
 		}
@@ -359,13 +494,14 @@ func (s *VenueService) UpdateRatingStats(statsFunc func(venueID string) (float64
 
 	for i := range s.venues {
 		avg, count := statsFunc(s.venues[i].ID)
-		s.venues[i].AvgRating = avg
+		s.venues[i].AvgRating = RoundAverage(avg)
 		s.venues[i].RatingCount = count
 		if thumbsFunc != nil {
 			up, down := thumbsFunc(s.venues[i].ID)
 			s.venues[i].ThumbsUp = up
 			s.venues[i].ThumbsDown = down
 		}
+		s.venues[i].RecommendPct = RecommendPct(s.venues[i].ThumbsUp, count)
 	}
 }
 
@@ -376,10 +512,11 @@ func (s *VenueService) UpdateSingleVenueStats(venueID string, avgRating float64,
 
 	for i := range s.venues {
 		if s.venues[i].ID == venueID {
-			s.venues[i].AvgRating = avgRating
+			s.venues[i].AvgRating = RoundAverage(avgRating)
 			s.venues[i].RatingCount = ratingCount
 			s.venues[i].ThumbsUp = thumbsUp
 			s.venues[i].ThumbsDown = thumbsDown
+			s.venues[i].RecommendPct = RecommendPct(thumbsUp, ratingCount)
 			return s.venues[i].SchoolID
 		}
 	}
@@ -421,3 +558,49 @@ func (s *VenueService) GetSchoolAvgRating(schoolID string) float64 {
 	}
 	return total / float64(count)
 }
+
+// GetNearby returns approved venues within radiusKm of a point, excluding excludeID.
+func (s *VenueService) GetNearby(lat, lng, radiusKm float64, excludeID string) []model.Venue {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []model.Venue
+	for _, v := range s.venues {
+		if !v.Verified || v.ID == excludeID || (v.Latitude == 0 && v.Longitude == 0) {
+			continue
+		}
+		if haversineKm(lat, lng, v.Latitude, v.Longitude) <= radiusKm {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// SetHours replaces a venue's weekly opening hours. An empty schedule clears them.
+func (s *VenueService) SetHours(venueID string, hours []model.OpeningHours) error {
+	if err := validateHours(hours); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.venues {
+		if s.venues[i].ID != venueID {
+			continue
+		}
+		s.venues[i].Hours = hours
+		if s.pool != nil {
+			var payload []byte
+			if len(hours) > 0 {
+				payload, _ = json.Marshal(hours)
+			}
+			if _, err := s.pool.Exec(context.Background(),
+				`UPDATE venues SET hours=$1 WHERE id=$2`, payload, venueID); err != nil {
+				log.Printf("WARNING: Failed to persist venue hours: %v", err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("venue not found: %s", venueID)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const maxArchiveReasonLength = 200
+
+// VenueArchiveService keeps a record of approved venues that were removed,
+// with their final ratings, so a school's nightlife history outlives them.
+type VenueArchiveService struct {
+	mu      sync.RWMutex
+	pool    *pgxpool.Pool
+	entries []model.ArchivedVenue
+}
+
+func NewVenueArchiveService(pool *pgxpool.Pool) *VenueArchiveService {
+	svc := &VenueArchiveService{
+		pool:    pool,
+		entries: []model.ArchivedVenue{},
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *VenueArchiveService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, name, category, COALESCE(address,''), COALESCE(latitude,0), COALESCE(longitude,0), school_id,
+		        avg_rating, rating_count, thumbs_up, thumbs_down, recommend_pct, listed_at, last_rated_at,
+		        closed_at, COALESCE(reason,''), COALESCE(closed_by,'')
+		 FROM venue_archive ORDER BY closed_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load venue archive from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a model.ArchivedVenue
+		if err := rows.Scan(&a.ID, &a.Name, &a.Category, &a.Address, &a.Latitude, &a.Longitude, &a.SchoolID,
+			&a.AvgRating, &a.RatingCount, &a.ThumbsUp, &a.ThumbsDown, &a.RecommendPct, &a.ListedAt, &a.LastRatedAt,
+			&a.ClosedAt, &a.Reason, &a.ClosedByID); err != nil {
+			log.Printf("WARNING: Failed to scan venue archive row: %v", err)
+			continue
+		}
+		s.entries = append(s.entries, a)
+	}
+	log.Printf("Loaded %d archived venues from DB", len(s.entries))
+}
+
+// Archive records a venue as it stood just before removal. lastRatedAt is
+// when it was last rated, if ever.
+func (s *VenueArchiveService) Archive(ctx context.Context, v model.Venue, lastRatedAt *time.Time, reason string) (*model.ArchivedVenue, error) {
+	reason = strings.TrimSpace(middleware.SanitizeString(reason))
+	if len(reason) > maxArchiveReasonLength {
+		return nil, fmt.Errorf("reason must be at most %d characters", maxArchiveReasonLength)
+	}
+
+	a := model.ArchivedVenue{
+		ID:           v.ID,
+		Name:         v.Name,
+		Category:     v.Category,
+		Address:      v.Address,
+		Latitude:     v.Latitude,
+		Longitude:    v.Longitude,
+		SchoolID:     v.SchoolID,
+		AvgRating:    v.AvgRating,
+		RatingCount:  v.RatingCount,
+		ThumbsUp:     v.ThumbsUp,
+		ThumbsDown:   v.ThumbsDown,
+		RecommendPct: v.RecommendPct,
+		ListedAt:     v.CreatedAt,
+		LastRatedAt:  lastRatedAt,
+		ClosedAt:     time.Now(),
+		Reason:       reason,
+		ClosedByID:   middleware.GetUserID(ctx),
+	}
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO venue_archive (id, name, category, address, latitude, longitude, school_id,
+			   avg_rating, rating_count, thumbs_up, thumbs_down, recommend_pct, listed_at, last_rated_at,
+			   closed_at, reason, closed_by)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+			 ON CONFLICT (id) DO NOTHING`,
+			a.ID, a.Name, a.Category, a.Address, a.Latitude, a.Longitude, a.SchoolID,
+			a.AvgRating, a.RatingCount, a.ThumbsUp, a.ThumbsDown, a.RecommendPct, a.ListedAt, a.LastRatedAt,
+			a.ClosedAt, a.Reason, a.ClosedByID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to archive venue: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.entries = append(s.entries, a)
+	s.mu.Unlock()
+	return &a, nil
+}
+
+// ListBySchool returns a school's archived venues, most recently closed
+// first.
+func (s *VenueArchiveService) ListBySchool(schoolID string) []model.ArchivedVenue {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := []model.ArchivedVenue{}
+	for _, a := range s.entries {
+		if a.SchoolID == schoolID {
+			out = append(out, a)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ClosedAt.After(out[j].ClosedAt) })
+	return out
+}
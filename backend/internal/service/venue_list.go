@@ -0,0 +1,434 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	maxListItems       = 50
+	maxListTitleLength = 100
+	maxListNoteLength  = 280
+
+	// listViewWindow is how long repeat views of a list by the same viewer
+	// (the user, or their IP when signed out) count as one.
+	listViewWindow = 30 * time.Minute
+)
+
+// VenueListService manages user-curated venue lists.
+type VenueListService struct {
+	mu    sync.RWMutex
+	pool  *pgxpool.Pool
+	lists []model.VenueList
+
+	viewsMu   sync.Mutex
+	seenViews map[string]time.Time // list ID + viewer -> when their last view counted
+	views     map[string]int       // list ID -> views not yet written to the DB
+}
+
+func NewVenueListService(pool *pgxpool.Pool) *VenueListService {
+	svc := &VenueListService{
+		pool:      pool,
+		lists:     []model.VenueList{},
+		seenViews: make(map[string]time.Time),
+		views:     make(map[string]int),
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *VenueListService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT id, title, COALESCE(description,''), COALESCE(school_id,''), owner_id, COALESCE(owner_name,''),
+		        visibility, view_count, created_at, updated_at
+		 FROM venue_lists ORDER BY created_at`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load venue lists from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	idx := make(map[string]int)
+	for rows.Next() {
+		var l model.VenueList
+		if err := rows.Scan(&l.ID, &l.Title, &l.Description, &l.SchoolID, &l.OwnerID, &l.OwnerName,
+			&l.Visibility, &l.ViewCount, &l.CreatedAt, &l.UpdatedAt); err != nil {
+			log.Printf("WARNING: Failed to scan venue list row: %v", err)
+			continue
+		}
+		l.Items = []model.VenueListItem{}
+		idx[l.ID] = len(s.lists)
+		s.lists = append(s.lists, l)
+	}
+	rows.Close()
+
+	itemRows, err := s.pool.Query(context.Background(),
+		`SELECT list_id, rank, venue_id, COALESCE(note,'') FROM venue_list_items ORDER BY list_id, rank`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load venue list items from DB: %v", err)
+		return
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var listID string
+		var item model.VenueListItem
+		if err := itemRows.Scan(&listID, &item.Rank, &item.VenueID, &item.Note); err != nil {
+			log.Printf("WARNING: Failed to scan venue list item row: %v", err)
+			continue
+		}
+		if i, ok := idx[listID]; ok {
+			s.lists[i].Items = append(s.lists[i].Items, item)
+		}
+	}
+	log.Printf("Loaded %d venue lists from DB", len(s.lists))
+}
+
+// validateVenueListRequest checks and normalizes a create/update request.
+func validateVenueListRequest(req *model.VenueListRequest) error {
+	req.Title = strings.TrimSpace(middleware.SanitizeString(req.Title))
+	req.Description = middleware.SanitizeString(req.Description)
+	if req.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if len(req.Title) > maxListTitleLength {
+		return fmt.Errorf("title must be at most %d characters", maxListTitleLength)
+	}
+	if req.Visibility == "" {
+		req.Visibility = "public"
+	}
+	if req.Visibility != "public" && req.Visibility != "private" {
+		return fmt.Errorf("visibility must be 'public' or 'private'")
+	}
+	if len(req.Items) > maxListItems {
+		return fmt.Errorf("a list can contain at most %d venues", maxListItems)
+	}
+	seen := make(map[string]bool, len(req.Items))
+	for i := range req.Items {
+		if req.Items[i].VenueID == "" {
+			return fmt.Errorf("venue_id is required for every item")
+		}
+		if seen[req.Items[i].VenueID] {
+			return fmt.Errorf("venue %s appears more than once", req.Items[i].VenueID)
+		}
+		seen[req.Items[i].VenueID] = true
+		req.Items[i].Note = middleware.SanitizeString(req.Items[i].Note)
+		if len(req.Items[i].Note) > maxListNoteLength {
+			return fmt.Errorf("notes must be at most %d characters", maxListNoteLength)
+		}
+	}
+	return nil
+}
+
+func itemsFromRequest(req model.VenueListRequest) []model.VenueListItem {
+	items := make([]model.VenueListItem, len(req.Items))
+	for i, it := range req.Items {
+		items[i] = model.VenueListItem{Rank: i + 1, VenueID: it.VenueID, Note: it.Note}
+	}
+	return items
+}
+
+// Create adds a new list owned by the current user. Items are ranked in the order given.
+func (s *VenueListService) Create(ctx context.Context, req model.VenueListRequest) (*model.VenueList, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	if err := validateVenueListRequest(&req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	list := model.VenueList{
+		ID:          generateID(),
+		Title:       req.Title,
+		Description: req.Description,
+		SchoolID:    req.SchoolID,
+		OwnerID:     userID,
+		OwnerName:   middleware.GetUsername(ctx),
+		Visibility:  req.Visibility,
+		Items:       itemsFromRequest(req),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	s.mu.Lock()
+	s.lists = append(s.lists, list)
+	s.mu.Unlock()
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO venue_lists (id, title, description, school_id, owner_id, owner_name, visibility, view_count, created_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, 0, $8, $9)`,
+			list.ID, list.Title, list.Description, list.SchoolID, list.OwnerID, list.OwnerName, list.Visibility, list.CreatedAt, list.UpdatedAt)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist venue list: %v", err)
+		}
+		s.persistItems(list.ID, list.Items)
+	}
+
+	return &list, nil
+}
+
+func (s *VenueListService) persistItems(listID string, items []model.VenueListItem) {
+	ctx := context.Background()
+	if _, err := s.pool.Exec(ctx, `DELETE FROM venue_list_items WHERE list_id=$1`, listID); err != nil {
+		log.Printf("WARNING: Failed to clear venue list items: %v", err)
+		return
+	}
+	for _, it := range items {
+		_, err := s.pool.Exec(ctx,
+			`INSERT INTO venue_list_items (list_id, rank, venue_id, note) VALUES ($1, $2, $3, $4)`,
+			listID, it.Rank, it.VenueID, it.Note)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist venue list item: %v", err)
+		}
+	}
+}
+
+// Get returns a list by ID. Private lists are only visible to their owner;
+// to everyone else they are reported as not found. A view by anyone but the
+// owner counts once per listViewWindow; signed-out viewers are told apart by
+// clientIP.
+func (s *VenueListService) Get(ctx context.Context, id, clientIP string) (*model.VenueList, error) {
+	userID := middleware.GetUserID(ctx)
+
+	s.mu.RLock()
+	var list *model.VenueList
+	for i := range s.lists {
+		if s.lists[i].ID != id {
+			continue
+		}
+		if s.lists[i].Visibility == "private" && s.lists[i].OwnerID != userID {
+			break
+		}
+		l := s.lists[i]
+		l.Items = append([]model.VenueListItem(nil), l.Items...)
+		list = &l
+		break
+	}
+	s.mu.RUnlock()
+	if list == nil {
+		return nil, fmt.Errorf("list not found")
+	}
+
+	viewer := "ip:" + clientIP
+	if userID != "" {
+		viewer = "user:" + userID
+	}
+	if list.OwnerID != userID && s.countView(id, viewer, time.Now()) {
+		list.ViewCount++
+		s.mu.Lock()
+		for i := range s.lists {
+			if s.lists[i].ID == id {
+				s.lists[i].ViewCount++
+				break
+			}
+		}
+		s.mu.Unlock()
+	}
+	return list, nil
+}
+
+// countView reports whether a view counts, i.e. the viewer hasn't viewed
+// the list within listViewWindow, and queues it to be persisted.
+func (s *VenueListService) countView(id, viewer string, now time.Time) bool {
+	s.viewsMu.Lock()
+	defer s.viewsMu.Unlock()
+	key := id + "|" + viewer
+	if last, ok := s.seenViews[key]; ok && now.Sub(last) < listViewWindow {
+		return false
+	}
+	s.seenViews[key] = now
+	s.views[id]++
+	return true
+}
+
+// Start writes counted views to the DB every interval.
+func (s *VenueListService) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.FlushViews()
+		}
+	}()
+}
+
+// FlushViews adds the views counted since the last flush to each list's
+// view_count and forgets viewers whose window has passed. Views that fail
+// to persist are retried on the next flush.
+func (s *VenueListService) FlushViews() {
+	now := time.Now()
+	s.viewsMu.Lock()
+	pending := s.views
+	s.views = make(map[string]int)
+	for key, at := range s.seenViews {
+		if now.Sub(at) >= listViewWindow {
+			delete(s.seenViews, key)
+		}
+	}
+	s.viewsMu.Unlock()
+
+	if s.pool == nil {
+		return
+	}
+	for id, n := range pending {
+		_, err := s.pool.Exec(context.Background(),
+			`UPDATE venue_lists SET view_count = view_count + $2 WHERE id = $1`, id, n)
+		if err != nil {
+			log.Printf("WARNING: Failed to persist venue list views: %v", err)
+			s.viewsMu.Lock()
+			s.views[id] += n
+			s.viewsMu.Unlock()
+		}
+	}
+}
+
+// Update replaces a list's title, description, visibility, and items. Owner only.
+func (s *VenueListService) Update(ctx context.Context, id string, req model.VenueListRequest) (*model.VenueList, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	if err := validateVenueListRequest(&req); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.lists {
+		if s.lists[i].ID != id {
+			continue
+		}
+		if s.lists[i].OwnerID != userID {
+			return nil, fmt.Errorf("you can only edit your own lists")
+		}
+		s.lists[i].Title = req.Title
+		s.lists[i].Description = req.Description
+		s.lists[i].SchoolID = req.SchoolID
+		s.lists[i].Visibility = req.Visibility
+		s.lists[i].Items = itemsFromRequest(req)
+		s.lists[i].UpdatedAt = time.Now()
+
+		if s.pool != nil {
+			_, err := s.pool.Exec(context.Background(),
+				`UPDATE venue_lists SET title=$1, description=$2, school_id=$3, visibility=$4, updated_at=$5 WHERE id=$6`,
+				req.Title, req.Description, req.SchoolID, req.Visibility, s.lists[i].UpdatedAt, id)
+			if err != nil {
+				log.Printf("WARNING: Failed to persist venue list update: %v", err)
+			}
+			s.persistItems(id, s.lists[i].Items)
+		}
+
+		l := s.lists[i]
+		return &l, nil
+	}
+	return nil, fmt.Errorf("list not found")
+}
+
+// Delete removes a list. Owners can delete their own lists; admins can delete any.
+func (s *VenueListService) Delete(ctx context.Context, id string) error {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return fmt.Errorf("authentication required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.lists {
+		if s.lists[i].ID != id {
+			continue
+		}
+		if s.lists[i].OwnerID != userID && middleware.GetUserRole(ctx) != "admin" {
+			return fmt.Errorf("you can only delete your own lists")
+		}
+		s.lists = append(s.lists[:i], s.lists[i+1:]...)
+
+		if s.pool != nil {
+			ctx := context.Background()
+			if _, err := s.pool.Exec(ctx, `DELETE FROM venue_list_items WHERE list_id=$1`, id); err != nil {
+				log.Printf("WARNING: Failed to delete venue list items from DB: %v", err)
+			}
+			if _, err := s.pool.Exec(ctx, `DELETE FROM venue_lists WHERE id=$1`, id); err != nil {
+				log.Printf("WARNING: Failed to delete venue list from DB: %v", err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("list not found")
+}
+
+// MergeOwner moves fromID's lists to toID and returns their IDs; with dryRun
+// nothing changes.
+func (s *VenueListService) MergeOwner(fromID, toID, toName string, dryRun bool) []string {
+	s.mu.Lock()
+	ids := []string{}
+	for i := range s.lists {
+		if s.lists[i].OwnerID == fromID {
+			ids = append(ids, s.lists[i].ID)
+			if !dryRun {
+				s.lists[i].OwnerID, s.lists[i].OwnerName = toID, toName
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if !dryRun && s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(),
+			`UPDATE venue_lists SET owner_id = $1, owner_name = $2 WHERE owner_id = $3`, toID, toName, fromID); err != nil {
+			log.Printf("WARNING: Failed to move venue lists while merging users: %v", err)
+		}
+	}
+	return ids
+}
+
+// ListByOwner returns all lists (public and private) created by a user.
+func (s *VenueListService) ListByOwner(userID string) []model.VenueList {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []model.VenueList
+	for _, l := range s.lists {
+		if l.OwnerID == userID {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// GetPopularBySchool returns the most-viewed public lists for a school.
+func (s *VenueListService) GetPopularBySchool(schoolID string, limit int) []model.VenueList {
+	s.mu.RLock()
+	var out []model.VenueList
+	for _, l := range s.lists {
+		if l.SchoolID == schoolID && l.Visibility == "public" && len(l.Items) > 0 {
+			out = append(out, l)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].ViewCount != out[j].ViewCount {
+			return out[i].ViewCount > out[j].ViewCount
+		}
+		return out[i].UpdatedAt.After(out[j].UpdatedAt)
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
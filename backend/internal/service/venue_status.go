@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ratemybars/backend/internal/middleware"
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	maxVenueStatusCover = 60
+	maxVenueStatusNote  = 140
+	maxVenueStatusHours = 12
+)
+
+var venueCrowdLevels = map[string]bool{"quiet": true, "busy": true, "packed": true, "at_capacity": true}
+
+// VenueStatusService keeps the nightly status owners post for their venues.
+// A status expires at the end of the night (tonightEndHour local time) unless
+// the owner asks for a shorter one, and is never shown after it expires.
+type VenueStatusService struct {
+	mu       sync.RWMutex
+	pool     *pgxpool.Pool
+	statuses map[string]model.VenueStatus // venue ID -> status
+}
+
+func NewVenueStatusService(pool *pgxpool.Pool) *VenueStatusService {
+	svc := &VenueStatusService{
+		pool:     pool,
+		statuses: make(map[string]model.VenueStatus),
+	}
+	if pool != nil {
+		svc.loadFromDB()
+	}
+	return svc
+}
+
+func (s *VenueStatusService) loadFromDB() {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT venue_id, COALESCE(crowd,''), live_music, COALESCE(cover,''), COALESCE(note,''), posted_by, posted_at, expires_at
+		 FROM venue_statuses WHERE expires_at > NOW()`)
+	if err != nil {
+		log.Printf("WARNING: Failed to load venue statuses from DB: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var st model.VenueStatus
+		if err := rows.Scan(&st.VenueID, &st.Crowd, &st.LiveMusic, &st.Cover, &st.Note,
+			&st.PostedByID, &st.PostedAt, &st.ExpiresAt); err != nil {
+			log.Printf("WARNING: Failed to scan venue status row: %v", err)
+			continue
+		}
+		s.statuses[st.VenueID] = st
+	}
+	log.Printf("Loaded %d venue statuses from DB", len(s.statuses))
+}
+
+// Set replaces a venue's status. loc is the venue's school time zone, used to
+// find the end of the night. Callers check venue ownership.
+func (s *VenueStatusService) Set(ctx context.Context, venueID string, req model.VenueStatusRequest, loc *time.Location) (*model.VenueStatus, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+	if req.Crowd != "" && !venueCrowdLevels[req.Crowd] {
+		return nil, fmt.Errorf("crowd must be one of quiet, busy, packed or at_capacity")
+	}
+	req.Cover = strings.TrimSpace(middleware.SanitizeString(req.Cover))
+	if len(req.Cover) > maxVenueStatusCover {
+		return nil, fmt.Errorf("cover must be at most %d characters", maxVenueStatusCover)
+	}
+	req.Note = strings.TrimSpace(middleware.SanitizeString(req.Note))
+	if len(req.Note) > maxVenueStatusNote {
+		return nil, fmt.Errorf("note must be at most %d characters", maxVenueStatusNote)
+	}
+	if req.Crowd == "" && !req.LiveMusic && req.Cover == "" && req.Note == "" {
+		return nil, fmt.Errorf("status is empty")
+	}
+	if req.Hours < 0 || req.Hours > maxVenueStatusHours {
+		return nil, fmt.Errorf("hours must be between 1 and %d", maxVenueStatusHours)
+	}
+
+	now := time.Now()
+	expires := tonightEnd(now.In(loc))
+	if req.Hours > 0 {
+		if custom := now.Add(time.Duration(req.Hours) * time.Hour); custom.Before(expires) {
+			expires = custom
+		}
+	}
+
+	st := model.VenueStatus{
+		VenueID:    venueID,
+		Crowd:      req.Crowd,
+		LiveMusic:  req.LiveMusic,
+		Cover:      req.Cover,
+		Note:       req.Note,
+		PostedByID: userID,
+		PostedAt:   now,
+		ExpiresAt:  expires,
+	}
+
+	if s.pool != nil {
+		_, err := s.pool.Exec(context.Background(),
+			`INSERT INTO venue_statuses (venue_id, crowd, live_music, cover, note, posted_by, posted_at, expires_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			 ON CONFLICT (venue_id) DO UPDATE SET crowd = $2, live_music = $3, cover = $4, note = $5,
+			   posted_by = $6, posted_at = $7, expires_at = $8`,
+			st.VenueID, st.Crowd, st.LiveMusic, st.Cover, st.Note, st.PostedByID, st.PostedAt, st.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save venue status: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.statuses[venueID] = st
+	s.mu.Unlock()
+	return &st, nil
+}
+
+// Clear removes a venue's status before it expires. Callers check venue
+// ownership.
+func (s *VenueStatusService) Clear(venueID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.statuses[venueID]
+	if !ok || !time.Now().Before(st.ExpiresAt) {
+		return fmt.Errorf("no status posted for this venue")
+	}
+	delete(s.statuses, venueID)
+	if s.pool != nil {
+		if _, err := s.pool.Exec(context.Background(), `DELETE FROM venue_statuses WHERE venue_id=$1`, venueID); err != nil {
+			log.Printf("WARNING: Failed to delete venue status from DB: %v", err)
+		}
+	}
+	return nil
+}
+
+// Current returns a venue's status, or nil if none is posted or it has
+// expired. A nil service has no statuses.
+func (s *VenueStatusService) Current(venueID string, now time.Time) *model.VenueStatus {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st, ok := s.statuses[venueID]
+	if !ok || !now.Before(st.ExpiresAt) {
+		return nil
+	}
+	return &st
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ratemybars/backend/internal/model"
+)
+
+const (
+	defaultWeatherTTL = 30 * time.Minute
+	// weatherMaxAge is how long cached conditions are still shown while a
+	// refresh is failing.
+	weatherMaxAge = 3 * time.Hour
+	// weatherRetryDelay spaces out refreshes after a failed fetch.
+	weatherRetryDelay = 5 * time.Minute
+
+	// outdoorSeatingTag marks venues reviewers say have outdoor seating; a
+	// venue needs outdoorSeatingMinTags such reviews to count.
+	outdoorSeatingTag     = "outdoor_seating"
+	outdoorSeatingMinTags = 2
+
+	// Patio weather is dry, mild and not too windy.
+	patioMinTempC   = 15.0
+	patioMaxTempC   = 32.0
+	patioMaxWindKph = 30.0
+)
+
+// WeatherProvider fetches current conditions at a point.
+type WeatherProvider interface {
+	Current(ctx context.Context, lat, lng float64) (model.WeatherConditions, error)
+}
+
+// WeatherConfig selects the weather provider. The feature is off unless
+// Provider is set.
+type WeatherConfig struct {
+	Provider string        // "open-meteo"
+	BaseURL  string        // overrides the provider's API URL
+	TTL      time.Duration // how long conditions are cached per school
+}
+
+// LoadWeatherConfig reads WEATHER_PROVIDER, WEATHER_BASE_URL and
+// WEATHER_CACHE_MINUTES (default 30).
+func LoadWeatherConfig() WeatherConfig {
+	cfg := WeatherConfig{
+		Provider: strings.ToLower(strings.TrimSpace(os.Getenv("WEATHER_PROVIDER"))),
+		BaseURL:  os.Getenv("WEATHER_BASE_URL"),
+		TTL:      defaultWeatherTTL,
+	}
+	if v, err := strconv.Atoi(os.Getenv("WEATHER_CACHE_MINUTES")); err == nil && v > 0 {
+		cfg.TTL = time.Duration(v) * time.Minute
+	}
+	return cfg
+}
+
+type weatherEntry struct {
+	conditions *model.WeatherConditions
+	fetchedAt  time.Time
+	attemptAt  time.Time
+	refreshing bool
+}
+
+// WeatherService caches current conditions per school and decides whether
+// it's patio weather at venues with outdoor seating. Lookups never wait on
+// the provider: stale or missing entries are refreshed in the background and
+// the cached value (if any) is returned meanwhile.
+type WeatherService struct {
+	mu       sync.Mutex
+	provider WeatherProvider
+	ttl      time.Duration
+	cache    map[string]*weatherEntry // school ID -> conditions
+	outdoor  *taggedVenues
+
+	schools *SchoolService
+}
+
+// NewWeatherService returns nil when no provider is configured; a nil
+// service reports no weather.
+func NewWeatherService(cfg WeatherConfig, schools *SchoolService, ratings *RatingService) *WeatherService {
+	var provider WeatherProvider
+	switch cfg.Provider {
+	case "":
+		return nil
+	case "open-meteo", "openmeteo":
+		provider = newOpenMeteo(cfg.BaseURL)
+	default:
+		log.Printf("WARNING: Unknown WEATHER_PROVIDER %q, weather disabled", cfg.Provider)
+		return nil
+	}
+	return &WeatherService{
+		provider: provider,
+		ttl:      cfg.TTL,
+		cache:    make(map[string]*weatherEntry),
+		outdoor:  newTaggedVenues(ratings, outdoorSeatingTag, outdoorSeatingMinTags),
+		schools:  schools,
+	}
+}
+
+// ForSchool returns the cached conditions at a school, or nil if none are
+// known yet.
+func (s *WeatherService) ForSchool(schoolID string) *model.WeatherConditions {
+	if s == nil {
+		return nil
+	}
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.cache[schoolID]
+	if !ok {
+		e = &weatherEntry{}
+		s.cache[schoolID] = e
+	}
+	if !e.refreshing && now.Sub(e.fetchedAt) >= s.ttl && now.Sub(e.attemptAt) >= weatherRetryDelay {
+		e.refreshing = true
+		e.attemptAt = now
+		go s.refresh(schoolID)
+	}
+	if e.conditions == nil || now.Sub(e.fetchedAt) > weatherMaxAge {
+		return nil
+	}
+	c := *e.conditions
+	return &c
+}
+
+func (s *WeatherService) refresh(schoolID string) {
+	var conditions *model.WeatherConditions
+	school, err := s.schools.GetByID(context.Background(), schoolID)
+	if err == nil && (school.Latitude != 0 || school.Longitude != 0) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		c, ferr := s.provider.Current(ctx, school.Latitude, school.Longitude)
+		cancel()
+		if ferr != nil {
+			log.Printf("WARNING: Failed to fetch weather for school %s: %v", schoolID, ferr)
+		} else {
+			c.PatioWeather = isPatioWeather(c)
+			conditions = &c
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := s.cache[schoolID]
+	e.refreshing = false
+	if conditions != nil {
+		e.conditions = conditions
+		e.fetchedAt = time.Now()
+	}
+}
+
+func isPatioWeather(c model.WeatherConditions) bool {
+	return c.TemperatureC >= patioMinTempC && c.TemperatureC <= patioMaxTempC &&
+		c.PrecipitationMm == 0 && c.WindKph <= patioMaxWindKph && !c.Wet
+}
+
+// HasOutdoorSeating reports whether enough reviewers tagged the venue with
+// outdoor seating.
+func (s *WeatherService) HasOutdoorSeating(venueID string) bool {
+	if s == nil {
+		return false
+	}
+	return s.outdoor.Has(venueID)
+}
+
+// PatioWeather is the "patio tonight" flag for a venue: nil unless the venue
+// has outdoor seating and conditions at its school are known.
+func (s *WeatherService) PatioWeather(v model.Venue) *bool {
+	if s == nil || !s.HasOutdoorSeating(v.ID) {
+		return nil
+	}
+	c := s.ForSchool(v.SchoolID)
+	if c == nil {
+		return nil
+	}
+	return &c.PatioWeather
+}
+
+// openMeteo reads current conditions from the keyless Open-Meteo API.
+type openMeteo struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newOpenMeteo(baseURL string) *openMeteo {
+	if baseURL == "" {
+		baseURL = "https://api.open-meteo.com/v1/forecast"
+	}
+	return &openMeteo{baseURL: baseURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (p *openMeteo) Current(ctx context.Context, lat, lng float64) (model.WeatherConditions, error) {
+	q := url.Values{}
+	q.Set("latitude", strconv.FormatFloat(lat, 'f', 4, 64))
+	q.Set("longitude", strconv.FormatFloat(lng, 'f', 4, 64))
+	q.Set("current", "temperature_2m,precipitation,wind_speed_10m,weather_code")
+	q.Set("wind_speed_unit", "kmh")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return model.WeatherConditions{}, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return model.WeatherConditions{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return model.WeatherConditions{}, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Current struct {
+			Time          string  `json:"time"`
+			Temperature   float64 `json:"temperature_2m"`
+			Precipitation float64 `json:"precipitation"`
+			WindSpeed     float64 `json:"wind_speed_10m"`
+			WeatherCode   int     `json:"weather_code"`
+		} `json:"current"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return model.WeatherConditions{}, err
+	}
+	observed, err := time.Parse("2006-01-02T15:04", body.Current.Time)
+	if err != nil {
+		observed = time.Now().UTC()
+	}
+	return model.WeatherConditions{
+		TemperatureC:    body.Current.Temperature,
+		PrecipitationMm: body.Current.Precipitation,
+		WindKph:         body.Current.WindSpeed,
+		// WMO codes 51 and up are drizzle, rain, snow and thunderstorms.
+		Wet:        body.Current.WeatherCode >= 51,
+		ObservedAt: observed,
+	}, nil
+}
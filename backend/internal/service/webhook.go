@@ -0,0 +1,62 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// AdminWebhook posts operational alerts to ADMIN_ALERT_WEBHOOK_URL. The
+// payload carries both "text" and "content" so Slack and Discord incoming
+// webhooks render it without extra configuration.
+type AdminWebhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewAdminWebhook returns nil when ADMIN_ALERT_WEBHOOK_URL is unset; a nil
+// webhook silently drops alerts.
+func NewAdminWebhook() *AdminWebhook {
+	url := os.Getenv("ADMIN_ALERT_WEBHOOK_URL")
+	if url == "" {
+		return nil
+	}
+	return &AdminWebhook{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Send delivers an alert in the background.
+func (w *AdminWebhook) Send(event, text string, data interface{}) {
+	if w == nil {
+		return
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"event":   event,
+		"text":    text,
+		"content": text,
+		"data":    data,
+	})
+	if err != nil {
+		return
+	}
+	go func() {
+		if err := w.post(body); err != nil {
+			log.Printf("WARNING: Admin webhook %s failed: %v", event, err)
+		}
+	}()
+}
+
+func (w *AdminWebhook) post(body []byte) error {
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
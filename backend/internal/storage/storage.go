@@ -0,0 +1,70 @@
+// Package storage keeps user-uploaded files (avatars) in S3-compatible object
+// storage, or on local disk in development.
+package storage
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// Store saves objects under a key and returns the public URL they are served
+// from.
+type Store interface {
+	Put(ctx context.Context, key, contentType string, data []byte) (string, error)
+	Delete(ctx context.Context, key string) error
+	// KeyFromURL returns the key of an object this store served at url, or ""
+	// when url points elsewhere.
+	KeyFromURL(url string) string
+}
+
+// Config selects and configures the backend. S3 is used when S3_BUCKET is set.
+type Config struct {
+	S3Endpoint  string // e.g. https://s3.us-east-1.amazonaws.com or an R2/MinIO endpoint
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3PathStyle bool // bucket in the path rather than the host, needed by MinIO
+
+	LocalDir string // where files are written without S3
+
+	// PublicURL is the base objects are served from: a CDN or bucket website
+	// in front of S3, or this server's /uploads route for local disk.
+	PublicURL string
+}
+
+// LoadConfig reads S3_ENDPOINT, S3_REGION (default us-east-1), S3_BUCKET,
+// S3_ACCESS_KEY_ID, S3_SECRET_ACCESS_KEY, S3_PATH_STYLE, STORAGE_DIR
+// (default ./uploads) and STORAGE_PUBLIC_URL.
+func LoadConfig() Config {
+	cfg := Config{
+		S3Endpoint:  strings.TrimRight(os.Getenv("S3_ENDPOINT"), "/"),
+		S3Region:    os.Getenv("S3_REGION"),
+		S3Bucket:    os.Getenv("S3_BUCKET"),
+		S3AccessKey: os.Getenv("S3_ACCESS_KEY_ID"),
+		S3SecretKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		S3PathStyle: os.Getenv("S3_PATH_STYLE") == "true",
+		LocalDir:    os.Getenv("STORAGE_DIR"),
+		PublicURL:   strings.TrimRight(os.Getenv("STORAGE_PUBLIC_URL"), "/"),
+	}
+	if cfg.S3Region == "" {
+		cfg.S3Region = "us-east-1"
+	}
+	if cfg.S3Endpoint == "" {
+		cfg.S3Endpoint = "https://s3." + cfg.S3Region + ".amazonaws.com"
+	}
+	if cfg.LocalDir == "" {
+		cfg.LocalDir = "./uploads"
+	}
+	return cfg
+}
+
+// New returns an S3 store when a bucket is configured and a local-disk store
+// otherwise.
+func New(cfg Config) (Store, error) {
+	if cfg.S3Bucket != "" {
+		return NewS3(cfg), nil
+	}
+	return NewLocal(cfg.LocalDir, cfg.PublicURL)
+}
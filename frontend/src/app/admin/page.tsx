@@ -16,6 +16,7 @@ import {
   adminRemoveFrat,
   adminSearchVenues,
   adminDeleteVenue,
+  adminPreviewDeleteVenue,
   type Venue,
   type AdminUser,
   type School,
@@ -60,8 +61,8 @@ export default function AdminPage() {
 
   const fetchPending = useCallback(async () => {
     try {
-      const data = await getPendingVenues();
-      setVenues(data || []);
+      const res = await getPendingVenues();
+      setVenues(res.data || []);
     } catch (err) {
       setError(err instanceof Error ? err.message : "Failed to load pending venues");
     }
@@ -69,8 +70,8 @@ export default function AdminPage() {
 
   const fetchUsers = useCallback(async () => {
     try {
-      const data = await getAdminUsers();
-      setUsers(data || []);
+      const res = await getAdminUsers();
+      setUsers(res.data || []);
     } catch (err) {
       setError(err instanceof Error ? err.message : "Failed to load users");
     }
@@ -87,7 +88,7 @@ export default function AdminPage() {
 
   useEffect(() => {
     if (tab === "fraternities" && glAllFrats.length === 0) {
-      getAllFraternities().then(setGlAllFrats).catch(console.error);
+      getAllFraternities().then((res) => setGlAllFrats(res.data || [])).catch(console.error);
     }
   }, [tab, glAllFrats.length]);
 
@@ -104,7 +105,7 @@ export default function AdminPage() {
 
   useEffect(() => {
     if (!glSelectedSchool) return;
-    getSchoolFraternities(glSelectedSchool.id).then(setGlFrats).catch(console.error);
+    getSchoolFraternities(glSelectedSchool.id).then((res) => setGlFrats(res.data || [])).catch(console.error);
   }, [glSelectedSchool]);
 
   useEffect(() => {
@@ -123,8 +124,8 @@ export default function AdminPage() {
     setMvSearching(true);
     const t = setTimeout(async () => {
       try {
-        const data = await adminSearchVenues(mvQuery);
-        setMvResults(data || []);
+        const res = await adminSearchVenues(mvQuery);
+        setMvResults(res.data || []);
       } catch { setMvResults([]); }
       setMvSearching(false);
     }, 300);
@@ -210,7 +211,7 @@ export default function AdminPage() {
     try {
       await adminAddFrat(glNewFrat.trim(), glSelectedSchool.id);
       const updated = await getSchoolFraternities(glSelectedSchool.id);
-      setGlFrats(updated);
+      setGlFrats(updated.data || []);
       setGlNewFrat("");
     } catch (err) {
       setError(err instanceof Error ? err.message : "Failed to add fraternity");
@@ -238,10 +239,13 @@ export default function AdminPage() {
     : [];
 
   const handleDeleteVenue = async (venueId: string) => {
-    if (!confirm("Are you sure you want to permanently delete this venue?")) return;
     setActionLoading(venueId);
     setError("");
     try {
+      const preview = await adminPreviewDeleteVenue(venueId);
+      const ratings = preview.counts.ratings ?? 0;
+      const warning = ratings > 0 ? ` Its ${ratings} rating${ratings === 1 ? "" : "s"} will no longer be reachable.` : "";
+      if (!confirm(`Are you sure you want to permanently delete this venue?${warning}`)) return;
       await adminDeleteVenue(venueId);
       setMvResults((prev) => prev.filter((v) => v.id !== venueId));
     } catch (err) {
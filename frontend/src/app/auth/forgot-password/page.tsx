@@ -0,0 +1,82 @@
+"use client";
+
+import { useState } from "react";
+import Link from "next/link";
+import { forgotPassword } from "@/lib/api";
+import { Mail } from "lucide-react";
+
+export default function ForgotPasswordPage() {
+  const [email, setEmail] = useState("");
+  const [sent, setSent] = useState(false);
+  const [error, setError] = useState("");
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError("");
+    setLoading(true);
+
+    try {
+      await forgotPassword(email);
+      setSent(true);
+    } catch (err) {
+      setError(err instanceof Error ? err.message : "Something went wrong");
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div className="min-h-[calc(100vh-3.5rem)] flex items-center justify-center px-4">
+      <div className="w-full max-w-md">
+        <div className="text-center mb-8">
+          <h1 className="text-2xl font-bold text-white">Forgot password</h1>
+          <p className="text-zinc-400 text-sm mt-1">
+            We&apos;ll email you a link to choose a new one
+          </p>
+        </div>
+
+        {sent ? (
+          <div className="p-4 bg-violet-500/10 border border-violet-500/20 rounded-xl text-zinc-300 text-sm text-center">
+            If an account uses <span className="text-white font-medium">{email}</span>, a reset link is on its way.
+            It expires in an hour.
+          </div>
+        ) : (
+          <form onSubmit={handleSubmit} className="space-y-4">
+            {error && (
+              <div className="p-3 bg-red-500/10 border border-red-500/20 rounded-lg text-red-400 text-sm text-center">
+                {error}
+              </div>
+            )}
+
+            <div className="relative">
+              <Mail size={18} className="absolute left-3 top-1/2 -translate-y-1/2 text-zinc-500" />
+              <input
+                type="email"
+                value={email}
+                onChange={(e) => setEmail(e.target.value)}
+                placeholder="Email"
+                required
+                className="w-full pl-10 pr-4 py-3 bg-zinc-900 border border-zinc-800 rounded-xl text-white placeholder-zinc-500 text-sm focus:outline-none focus:border-violet-500 transition-colors"
+              />
+            </div>
+
+            <button
+              type="submit"
+              disabled={loading}
+              className="w-full py-3 bg-violet-600 hover:bg-violet-500 disabled:bg-zinc-700 disabled:text-zinc-500 text-white font-semibold rounded-xl transition-colors"
+            >
+              {loading ? "Sending..." : "Send reset link"}
+            </button>
+          </form>
+        )}
+
+        <p className="text-center text-zinc-500 text-sm mt-6">
+          <Link href="/auth/login" className="text-violet-400 hover:text-violet-300 font-medium transition-colors">
+            Back to login
+          </Link>
+        </p>
+      </div>
+    </div>
+  );
+}
@@ -83,6 +83,12 @@ export default function LoginPage() {
             </button>
           </div>
 
+          <div className="text-right -mt-2">
+            <Link href="/auth/forgot-password" className="text-xs text-zinc-500 hover:text-violet-300 transition-colors">
+              Forgot password?
+            </Link>
+          </div>
+
           <button
             type="submit"
             disabled={loading}
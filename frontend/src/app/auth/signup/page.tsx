@@ -1,11 +1,44 @@
 "use client";
 
-import { useState } from "react";
+import { useEffect, useState } from "react";
 import Link from "next/link";
 import { useRouter } from "next/navigation";
 import { useAuth } from "@/lib/auth-context";
+import { checkEmail, checkUsername, type Availability } from "@/lib/api";
 import { Mail, Lock, Eye, EyeOff, User } from "lucide-react";
 
+// useAvailability checks a field against the server once the user pauses
+// typing. It returns null while the value is too short or a check is pending.
+function useAvailability(value: string, check: (v: string) => Promise<Availability>, minLength: number) {
+  const [result, setResult] = useState<Availability | null>(null);
+
+  useEffect(() => {
+    setResult(null);
+    if (value.length < minLength) return;
+    let cancelled = false;
+    const timer = setTimeout(() => {
+      check(value)
+        .then((r) => !cancelled && setResult(r))
+        .catch(() => {});
+    }, 400);
+    return () => {
+      cancelled = true;
+      clearTimeout(timer);
+    };
+  }, [value, check, minLength]);
+
+  return result;
+}
+
+function AvailabilityHint({ result, label }: { result: Availability | null; label: string }) {
+  if (!result) return null;
+  return result.available ? (
+    <p className="mt-1 text-xs text-emerald-400">{label} is available</p>
+  ) : (
+    <p className="mt-1 text-xs text-red-400">{result.reason}</p>
+  );
+}
+
 export default function SignupPage() {
   const router = useRouter();
   const { register } = useAuth();
@@ -16,6 +49,8 @@ export default function SignupPage() {
   const [showPassword, setShowPassword] = useState(false);
   const [error, setError] = useState("");
   const [loading, setLoading] = useState(false);
+  const usernameStatus = useAvailability(username, checkUsername, 3);
+  const emailStatus = useAvailability(email.includes("@") ? email : "", checkEmail, 3);
 
   const handleSubmit = async (e: React.FormEvent) => {
     e.preventDefault();
@@ -36,10 +71,15 @@ export default function SignupPage() {
       return;
     }
 
+    if (usernameStatus?.available === false || emailStatus?.available === false) {
+      setError((usernameStatus?.available === false ? usernameStatus : emailStatus)?.reason ?? "");
+      return;
+    }
+
     setLoading(true);
     try {
       await register(email, password, username);
-      router.push("/");
+      router.push("/onboarding");
     } catch (err) {
       setError(err instanceof Error ? err.message : "Registration failed");
     } finally {
@@ -69,30 +109,36 @@ export default function SignupPage() {
             </div>
           )}
 
-          <div className="relative">
-            <User size={18} className="absolute left-3 top-1/2 -translate-y-1/2 text-zinc-500" />
-            <input
-              type="text"
-              value={username}
-              onChange={(e) => setUsername(e.target.value)}
-              placeholder="Username"
-              required
-              minLength={3}
-              maxLength={30}
-              className="w-full pl-10 pr-4 py-3 bg-zinc-900 border border-zinc-800 rounded-xl text-white placeholder-zinc-500 text-sm focus:outline-none focus:border-violet-500 transition-colors"
-            />
+          <div>
+            <div className="relative">
+              <User size={18} className="absolute left-3 top-1/2 -translate-y-1/2 text-zinc-500" />
+              <input
+                type="text"
+                value={username}
+                onChange={(e) => setUsername(e.target.value)}
+                placeholder="Username"
+                required
+                minLength={3}
+                maxLength={30}
+                className="w-full pl-10 pr-4 py-3 bg-zinc-900 border border-zinc-800 rounded-xl text-white placeholder-zinc-500 text-sm focus:outline-none focus:border-violet-500 transition-colors"
+              />
+            </div>
+            <AvailabilityHint result={usernameStatus} label="Username" />
           </div>
 
-          <div className="relative">
-            <Mail size={18} className="absolute left-3 top-1/2 -translate-y-1/2 text-zinc-500" />
-            <input
-              type="email"
-              value={email}
-              onChange={(e) => setEmail(e.target.value)}
-              placeholder="Email"
-              required
-              className="w-full pl-10 pr-4 py-3 bg-zinc-900 border border-zinc-800 rounded-xl text-white placeholder-zinc-500 text-sm focus:outline-none focus:border-violet-500 transition-colors"
-            />
+          <div>
+            <div className="relative">
+              <Mail size={18} className="absolute left-3 top-1/2 -translate-y-1/2 text-zinc-500" />
+              <input
+                type="email"
+                value={email}
+                onChange={(e) => setEmail(e.target.value)}
+                placeholder="Email"
+                required
+                className="w-full pl-10 pr-4 py-3 bg-zinc-900 border border-zinc-800 rounded-xl text-white placeholder-zinc-500 text-sm focus:outline-none focus:border-violet-500 transition-colors"
+              />
+            </div>
+            {emailStatus?.available === false && <AvailabilityHint result={emailStatus} label="Email" />}
           </div>
 
           <div className="relative">
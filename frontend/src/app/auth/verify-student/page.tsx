@@ -0,0 +1,71 @@
+"use client";
+
+import { useEffect, useRef, useState, Suspense } from "react";
+import Link from "next/link";
+import { useSearchParams } from "next/navigation";
+import { confirmStudent } from "@/lib/api";
+import { useAuth } from "@/lib/auth-context";
+import { GraduationCap } from "lucide-react";
+
+function VerifyStudent() {
+  const searchParams = useSearchParams();
+  const { user, updateUser } = useAuth();
+  const token = searchParams.get("token") || "";
+  const [status, setStatus] = useState<"pending" | "done" | "error">(token ? "pending" : "error");
+  const [error, setError] = useState(token ? "" : "This confirmation link is incomplete.");
+  const sent = useRef(false);
+
+  useEffect(() => {
+    // Tokens work once, so don't confirm twice under strict mode.
+    if (!token || sent.current) return;
+    sent.current = true;
+    confirmStudent(token)
+      .then((updated) => {
+        if (user?.id === updated.id) updateUser(updated);
+        setStatus("done");
+      })
+      .catch((err) => {
+        setError(err instanceof Error ? err.message : "Verification failed");
+        setStatus("error");
+      });
+  }, [token, user, updateUser]);
+
+  return (
+    <div className="min-h-[calc(100vh-3.5rem)] flex items-center justify-center px-4">
+      <div className="w-full max-w-md text-center">
+        <div className="w-16 h-16 rounded-2xl bg-gradient-to-br from-violet-500 to-fuchsia-500 flex items-center justify-center text-white mx-auto mb-4">
+          <GraduationCap size={30} />
+        </div>
+        {status === "pending" && <p className="text-zinc-400">Confirming your student email...</p>}
+        {status === "done" && (
+          <>
+            <h1 className="text-2xl font-bold text-white">You&apos;re a verified student</h1>
+            <p className="text-zinc-400 text-sm mt-1">Your reviews now carry the student badge.</p>
+          </>
+        )}
+        {status === "error" && (
+          <div className="p-4 bg-red-500/10 border border-red-500/20 rounded-xl text-red-400 text-sm">
+            {error}
+          </div>
+        )}
+        <p className="text-zinc-500 text-sm mt-6">
+          <Link href="/" className="text-violet-400 hover:text-violet-300 font-medium transition-colors">
+            Back to the map
+          </Link>
+        </p>
+      </div>
+    </div>
+  );
+}
+
+export default function VerifyStudentPage() {
+  return (
+    <Suspense fallback={
+      <div className="min-h-[calc(100vh-3.5rem)] flex items-center justify-center">
+        <div className="w-10 h-10 border-2 border-violet-500 border-t-transparent rounded-full animate-spin" />
+      </div>
+    }>
+      <VerifyStudent />
+    </Suspense>
+  );
+}
@@ -0,0 +1,140 @@
+"use client";
+
+import { useEffect, useState } from "react";
+import { useParams, useRouter } from "next/navigation";
+import Link from "next/link";
+import { ArrowLeft, Building2, GraduationCap, Trophy } from "lucide-react";
+import { getCity, type CityPage } from "@/lib/api";
+import VenueCard from "@/components/VenueCard";
+import Stars from "@/components/Stars";
+
+export default function CityPageView() {
+  const params = useParams();
+  const router = useRouter();
+  const state = params.state as string;
+  const city = params.city as string;
+  const [page, setPage] = useState<CityPage | null>(null);
+  const [loading, setLoading] = useState(true);
+
+  useEffect(() => {
+    getCity(state, city)
+      .then(setPage)
+      .catch(() => setPage(null))
+      .finally(() => setLoading(false));
+  }, [state, city]);
+
+  if (loading) {
+    return (
+      <div className="min-h-[calc(100vh-3.5rem)] flex items-center justify-center">
+        <div className="w-10 h-10 border-2 border-violet-500 border-t-transparent rounded-full animate-spin" />
+      </div>
+    );
+  }
+
+  if (!page) {
+    return (
+      <div className="min-h-[calc(100vh-3.5rem)] flex items-center justify-center">
+        <div className="text-center">
+          <p className="text-zinc-400">City not found</p>
+          <button onClick={() => router.back()} className="text-violet-400 hover:text-violet-300 mt-2 inline-block">
+            Go back
+          </button>
+        </div>
+      </div>
+    );
+  }
+
+  return (
+    <div className="max-w-4xl mx-auto px-4 py-8">
+      <button
+        onClick={() => router.back()}
+        className="flex items-center gap-1.5 text-sm text-zinc-400 hover:text-white transition-colors mb-6"
+      >
+        <ArrowLeft size={16} />
+        Back
+      </button>
+
+      <div className="mb-8">
+        <h1 className="text-3xl font-bold text-white">
+          {page.name}, {page.state}
+        </h1>
+        <div className="flex items-center gap-3 mt-2 text-sm text-zinc-400">
+          <span>{page.schools.length} schools</span>
+          <span>&middot;</span>
+          <span>{page.venues.length} venues</span>
+          {page.rating_count > 0 && (
+            <>
+              <span>&middot;</span>
+              <Stars value={page.avg_rating} />
+              <span>({page.rating_count})</span>
+            </>
+          )}
+        </div>
+      </div>
+
+      {page.leaderboard.length > 0 && (
+        <div className="mb-8">
+          <div className="flex items-center gap-2.5 mb-4">
+            <Trophy size={20} className="text-amber-400" />
+            <h2 className="text-xl font-bold text-white">City Leaderboard</h2>
+          </div>
+          <ol className="space-y-2">
+            {page.leaderboard.map((v, i) => (
+              <li key={v.id}>
+                <Link
+                  href={`/venue/${v.id}`}
+                  className="flex items-center gap-3 p-3 bg-zinc-900/50 border border-zinc-800/50 rounded-xl hover:border-zinc-700 transition-colors"
+                >
+                  <span className="w-6 text-center text-sm font-bold text-zinc-500">{i + 1}</span>
+                  <span className="flex-1 min-w-0 text-sm font-medium text-white truncate">{v.name}</span>
+                  <Stars value={v.avg_rating} />
+                  <span className="text-xs text-zinc-500">({v.rating_count})</span>
+                </Link>
+              </li>
+            ))}
+          </ol>
+        </div>
+      )}
+
+      {page.schools.length > 0 && (
+        <div className="mb-8">
+          <div className="flex items-center gap-2.5 mb-4">
+            <GraduationCap size={20} className="text-sky-400" />
+            <h2 className="text-xl font-bold text-white">Schools</h2>
+          </div>
+          <div className="grid grid-cols-1 sm:grid-cols-2 gap-3">
+            {page.schools.map((s) => (
+              <Link
+                key={s.id}
+                href={`/school/${s.id}`}
+                className="flex items-center justify-between p-4 bg-zinc-900/50 border border-zinc-800/50 rounded-xl hover:border-zinc-700 transition-colors"
+              >
+                <div className="min-w-0">
+                  <p className="text-sm font-medium text-white truncate">{s.name}</p>
+                  <p className="text-xs text-zinc-500">{s.venue_count} venues</p>
+                </div>
+                {s.party_score > 0 && (
+                  <span className="text-xs font-semibold text-violet-300 ml-3 shrink-0">{s.party_score} pts</span>
+                )}
+              </Link>
+            ))}
+          </div>
+        </div>
+      )}
+
+      {page.venues.length > 0 && (
+        <div>
+          <div className="flex items-center gap-2.5 mb-4">
+            <Building2 size={20} className="text-violet-400" />
+            <h2 className="text-xl font-bold text-white">Venues</h2>
+          </div>
+          <div className="grid grid-cols-1 sm:grid-cols-2 gap-3">
+            {page.venues.map((venue) => (
+              <VenueCard key={venue.id} venue={venue} />
+            ))}
+          </div>
+        </div>
+      )}
+    </div>
+  );
+}
@@ -12,9 +12,13 @@ import {
   MessageSquare,
   User,
   Flame,
+  ThumbsUp,
 } from "lucide-react";
+import { useAuth } from "@/lib/auth-context";
 import {
+  getConferences,
   getLeaderboardSchools,
+  getSchool,
   getLeaderboardUsers,
   type LeaderboardSchool,
   type LeaderboardUser,
@@ -82,21 +86,41 @@ export default function LeaderboardPage() {
   const [schools, setSchools] = useState<LeaderboardSchool[]>([]);
   const [users, setUsers] = useState<LeaderboardUser[]>([]);
   const [loading, setLoading] = useState(true);
+  const [conferences, setConferences] = useState<string[]>([]);
+  const [conference, setConference] = useState<string | null>(null);
+  const { user, loading: authLoading } = useAuth();
 
   useEffect(() => {
+    getConferences().then(setConferences).catch(() => setConferences([]));
+  }, []);
+
+  // Pre-filter to the user's conference once we know their home school
+  useEffect(() => {
+    if (authLoading || conference !== null) return;
+    if (!user?.home_school_id) {
+      setConference("");
+      return;
+    }
+    getSchool(user.home_school_id)
+      .then((s) => setConference(s.conference ?? ""))
+      .catch(() => setConference(""));
+  }, [authLoading, user?.home_school_id, conference]);
+
+  useEffect(() => {
+    if (tab === "schools" && conference === null) return;
     setLoading(true);
     if (tab === "schools") {
-      getLeaderboardSchools()
-        .then(setSchools)
+      getLeaderboardSchools(undefined, conference ?? undefined)
+        .then((res) => setSchools(res.data || []))
         .catch(console.error)
         .finally(() => setLoading(false));
     } else {
       getLeaderboardUsers()
-        .then(setUsers)
+        .then((res) => setUsers(res.data || []))
         .catch(console.error)
         .finally(() => setLoading(false));
     }
-  }, [tab]);
+  }, [tab, conference]);
 
   const maxPartyScore = schools.length > 0 ? schools[0].party_score : 100;
   const maxRatings = users.length > 0 ? users[0].rating_count : 1;
@@ -169,6 +193,24 @@ export default function LeaderboardPage() {
           </button>
         </div>
 
+        {tab === "schools" && conferences.length > 0 && (
+          <div className="flex flex-wrap gap-2 mb-4">
+            {["", ...conferences].map((c) => (
+              <button
+                key={c || "all"}
+                onClick={() => setConference(c)}
+                className={`px-3 py-1 rounded-full text-xs font-medium border transition-colors ${
+                  conference === c
+                    ? "bg-violet-600 border-violet-500 text-white"
+                    : "bg-zinc-900 border-zinc-800 text-zinc-400 hover:text-white"
+                }`}
+              >
+                {c || "All schools"}
+              </button>
+            ))}
+          </div>
+        )}
+
         {loading ? (
           <div className="space-y-3">
             {[...Array(5)].map((_, i) => (
@@ -218,6 +260,12 @@ export default function LeaderboardPage() {
                           ? school.avg_rating.toFixed(1)
                           : "N/A"}
                       </span>
+                      {school.recommend_pct !== undefined && (
+                        <span className="flex items-center gap-1">
+                          <ThumbsUp size={10} />
+                          {school.recommend_pct}% recommend
+                        </span>
+                      )}
                     </div>
                   </div>
                   <ScoreBar
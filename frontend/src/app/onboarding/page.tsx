@@ -0,0 +1,163 @@
+"use client";
+
+import { useEffect, useState } from "react";
+import Link from "next/link";
+import { useRouter } from "next/navigation";
+import { useAuth } from "@/lib/auth-context";
+import { searchSchools, setHomeSchool, requestStudentVerification, type School } from "@/lib/api";
+import { useToast } from "@/lib/toast-context";
+import { GraduationCap, Search, Check, Mail } from "lucide-react";
+
+export default function OnboardingPage() {
+  const router = useRouter();
+  const { user, loading: authLoading, updateUser } = useAuth();
+  const [query, setQuery] = useState("");
+  const [results, setResults] = useState<School[]>([]);
+  const [school, setSchool] = useState<School | null>(null);
+  const [gradYear, setGradYear] = useState("");
+  const [studentEmail, setStudentEmail] = useState("");
+  const [error, setError] = useState("");
+  const { showToast } = useToast();
+  const [saving, setSaving] = useState(false);
+
+  const thisYear = new Date().getFullYear();
+  const years = Array.from({ length: 9 }, (_, i) => thisYear - 1 + i);
+
+  useEffect(() => {
+    if (!authLoading && !user) router.replace("/auth/login");
+  }, [authLoading, user, router]);
+
+  useEffect(() => {
+    if (query.trim().length < 2) {
+      setResults([]);
+      return;
+    }
+    const t = setTimeout(() => {
+      searchSchools({ q: query.trim(), limit: "8" })
+        .then((res) => setResults(res.data || []))
+        .catch(() => setResults([]));
+    }, 250);
+    return () => clearTimeout(t);
+  }, [query]);
+
+  const handleSave = async () => {
+    if (!school) return;
+    setSaving(true);
+    setError("");
+    try {
+      const updated = await setHomeSchool(school.id, gradYear ? Number(gradYear) : undefined);
+      updateUser(updated);
+      if (studentEmail.trim()) {
+        await requestStudentVerification(studentEmail.trim(), school.id);
+        showToast("Check your school email to finish verifying", "success");
+      }
+      router.push("/");
+    } catch (err) {
+      setError(err instanceof Error ? err.message : "Failed to save");
+    } finally {
+      setSaving(false);
+    }
+  };
+
+  return (
+    <div className="min-h-[calc(100vh-3.5rem)] flex items-center justify-center px-4 py-8">
+      <div className="w-full max-w-md">
+        <div className="text-center mb-8">
+          <div className="w-16 h-16 rounded-2xl bg-gradient-to-br from-violet-500 to-fuchsia-500 flex items-center justify-center text-white mx-auto mb-4">
+            <GraduationCap size={30} />
+          </div>
+          <h1 className="text-2xl font-bold text-white">Where do you go?</h1>
+          <p className="text-zinc-400 text-sm mt-1">
+            We&apos;ll tailor your feed, map and leaderboards to your school
+          </p>
+        </div>
+
+        <div className="space-y-4">
+          {error && (
+            <div className="p-3 bg-red-500/10 border border-red-500/20 rounded-lg text-red-400 text-sm text-center">
+              {error}
+            </div>
+          )}
+
+          <div className="relative">
+            <Search size={18} className="absolute left-3 top-1/2 -translate-y-1/2 text-zinc-500" />
+            <input
+              type="text"
+              value={school ? school.name : query}
+              onChange={(e) => {
+                setSchool(null);
+                setQuery(e.target.value);
+              }}
+              placeholder="Search for your school"
+              className="w-full pl-10 pr-4 py-3 bg-zinc-900 border border-zinc-800 rounded-xl text-white placeholder-zinc-500 text-sm focus:outline-none focus:border-violet-500 transition-colors"
+            />
+          </div>
+
+          {!school && results.length > 0 && (
+            <ul className="bg-zinc-900 border border-zinc-800 rounded-xl divide-y divide-zinc-800 overflow-hidden">
+              {results.map((s) => (
+                <li key={s.id}>
+                  <button
+                    type="button"
+                    onClick={() => setSchool(s)}
+                    className="w-full text-left px-4 py-2.5 hover:bg-zinc-800 transition-colors"
+                  >
+                    <span className="block text-sm text-white">{s.name}</span>
+                    <span className="block text-xs text-zinc-500">
+                      {s.city}, {s.state}
+                    </span>
+                  </button>
+                </li>
+              ))}
+            </ul>
+          )}
+
+          <select
+            value={gradYear}
+            onChange={(e) => setGradYear(e.target.value)}
+            className="w-full px-4 py-3 bg-zinc-900 border border-zinc-800 rounded-xl text-white text-sm focus:outline-none focus:border-violet-500 transition-colors"
+          >
+            <option value="">Graduation year (optional)</option>
+            {years.map((y) => (
+              <option key={y} value={y}>
+                Class of {y}
+              </option>
+            ))}
+          </select>
+
+          <div>
+            <div className="relative">
+              <Mail size={18} className="absolute left-3 top-1/2 -translate-y-1/2 text-zinc-500" />
+              <input
+                type="email"
+                value={studentEmail}
+                onChange={(e) => setStudentEmail(e.target.value)}
+                placeholder="School .edu email (optional)"
+                className="w-full pl-10 pr-4 py-3 bg-zinc-900 border border-zinc-800 rounded-xl text-white placeholder-zinc-500 text-sm focus:outline-none focus:border-violet-500 transition-colors"
+              />
+            </div>
+            <p className="text-xs text-zinc-500 mt-1.5 px-1">
+              Verify it to get a student badge on your reviews
+            </p>
+          </div>
+
+          <button
+            type="button"
+            onClick={handleSave}
+            disabled={!school || saving}
+            className="flex items-center justify-center gap-2 w-full py-3 bg-violet-600 hover:bg-violet-500 disabled:bg-zinc-700 disabled:text-zinc-500 text-white font-semibold rounded-xl transition-colors"
+          >
+            <Check size={18} />
+            {saving ? "Saving..." : "Continue"}
+          </button>
+        </div>
+
+        <p className="text-center text-zinc-500 text-sm mt-6">
+          <Link href="/" className="text-violet-400 hover:text-violet-300 font-medium transition-colors">
+            Skip for now
+          </Link>
+        </p>
+      </div>
+    </div>
+  );
+}
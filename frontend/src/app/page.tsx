@@ -7,7 +7,8 @@ import SchoolPanel from "@/components/SchoolPanel";
 import StatsBar from "@/components/StatsBar";
 import ExplorePanel from "@/components/ExplorePanel";
 import SplashScreen from "@/components/SplashScreen";
-import { getSchoolsByFrat, DEFAULT_FILTERS, type School, type MapSchool, type FilterState } from "@/lib/api";
+import { getSchool, getSchoolsByFrat, DEFAULT_FILTERS, type School, type MapSchool, type FilterState } from "@/lib/api";
+import { useAuth } from "@/lib/auth-context";
 
 const Map = dynamic(() => import("@/components/Map"), {
   ssr: false,
@@ -26,6 +27,16 @@ export default function Home() {
   const [selectedFrat, setSelectedFrat] = useState("");
   const [fratSchoolIds, setFratSchoolIds] = useState<string[] | undefined>(undefined);
   const [visibleSchools, setVisibleSchools] = useState<number | undefined>(undefined);
+  const { user } = useAuth();
+
+  // Default the viewport to the user's home school unless they've already
+  // moved the map this session
+  useEffect(() => {
+    if (!user?.home_school_id || sessionStorage.getItem("rmcp-map-view")) return;
+    getSchool(user.home_school_id)
+      .then((s) => setFlyTo({ lng: s.longitude, lat: s.latitude, zoom: 12 }))
+      .catch(() => {});
+  }, [user?.home_school_id]);
 
   useEffect(() => {
     if (!selectedFrat) {
@@ -33,7 +44,7 @@ export default function Home() {
       return;
     }
     getSchoolsByFrat(selectedFrat)
-      .then((ids) => setFratSchoolIds(ids || []))
+      .then((res) => setFratSchoolIds(res.data || []))
       .catch(() => setFratSchoolIds(undefined));
   }, [selectedFrat]);
 
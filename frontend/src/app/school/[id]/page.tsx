@@ -16,6 +16,8 @@ import {
   MessageSquare,
   TrendingUp,
   Sparkles,
+  ThumbsUp,
+  GraduationCap,
 } from "lucide-react";
 import "maplibre-gl/dist/maplibre-gl.css";
 import {
@@ -23,7 +25,9 @@ import {
   getSchoolVenues,
   getSchoolFraternities,
   getSchoolRatings,
+  getNearbySchools,
   type School,
+  type SchoolSummary,
   type Venue,
   type FratWithRating,
   type Rating,
@@ -33,6 +37,7 @@ import FratCard from "@/components/FratCard";
 import TierBadge from "@/components/TierBadge";
 import PartyGauge from "@/components/PartyGauge";
 import AnimatedStats from "@/components/AnimatedStats";
+import AuthorLink from "@/components/AuthorLink";
 
 function MiniMap({ latitude, longitude }: { latitude: number; longitude: number }) {
   const mapRef = useRef<HTMLDivElement>(null);
@@ -147,9 +152,11 @@ function ReviewCard({ rating, venueName }: { rating: Rating; venueName?: string
             {(rating.author_name || "A")[0].toUpperCase()}
           </div>
           <div>
-            <span className="text-sm font-medium text-white">
-              {rating.author_name || "Anonymous"}
-            </span>
+            <AuthorLink
+              authorId={rating.author_id}
+              name={rating.author_name}
+              className="text-sm font-medium text-white"
+            />
             {venueName && (
               <p className="text-xs text-zinc-500">
                 on <span className="text-violet-400">{venueName}</span>
@@ -216,6 +223,7 @@ export default function SchoolPage() {
   const [venues, setVenues] = useState<Venue[]>([]);
   const [fraternities, setFraternities] = useState<FratWithRating[]>([]);
   const [reviews, setReviews] = useState<Rating[]>([]);
+  const [nearby, setNearby] = useState<SchoolSummary[]>([]);
   const [loading, setLoading] = useState(true);
   const setRef = useFadeIn();
 
@@ -224,14 +232,16 @@ export default function SchoolPage() {
       const s = await getSchool(schoolId);
       setSchool(s);
 
-      const [v, f, r] = await Promise.allSettled([
+      const [v, f, r, n] = await Promise.allSettled([
         getSchoolVenues(schoolId),
         getSchoolFraternities(schoolId),
         getSchoolRatings(schoolId),
+        getNearbySchools(schoolId),
       ]);
       setVenues(v.status === "fulfilled" ? v.value.data || [] : []);
-      setFraternities(f.status === "fulfilled" ? f.value || [] : []);
-      setReviews(r.status === "fulfilled" ? r.value || [] : []);
+      setFraternities(f.status === "fulfilled" ? f.value.data || [] : []);
+      setReviews(r.status === "fulfilled" ? r.value.data || [] : []);
+      setNearby(n.status === "fulfilled" ? (n.value.data || []).slice(0, 6) : []);
     } catch (err) {
       console.error(err);
     } finally {
@@ -241,7 +251,7 @@ export default function SchoolPage() {
 
   const refreshFrats = useCallback(() => {
     getSchoolFraternities(id)
-      .then((f) => setFraternities(f || []))
+      .then((f) => setFraternities(f.data || []))
       .catch(console.error);
   }, [id]);
 
@@ -350,7 +360,15 @@ export default function SchoolPage() {
               {/* Address inline */}
               <div className="flex items-center gap-1.5 mt-3 text-sm text-zinc-400">
                 <MapPin size={14} className="shrink-0" />
-                <span>{school.city}, {school.state} {school.zip}</span>
+                <span>
+                  <Link
+                    href={`/city/${school.state}/${school.city_slug}`}
+                    className="hover:text-violet-300 transition-colors"
+                  >
+                    {school.city}
+                  </Link>
+                  , {school.state} {school.zip}
+                </span>
               </div>
             </div>
 
@@ -397,6 +415,16 @@ export default function SchoolPage() {
           />
         </div>
 
+        {school.recommend_pct !== undefined && (
+          <div className="flex items-center gap-2 text-sm text-zinc-400">
+            <ThumbsUp size={14} className="text-emerald-400" />
+            <span>
+              <span className="text-white font-semibold">{school.recommend_pct}%</span>{" "}
+              would recommend {school.name}&apos;s nightlife to a friend
+            </span>
+          </div>
+        )}
+
         {/* Mobile Party Gauge */}
         <div className="sm:hidden flex justify-center">
           <PartyGauge
@@ -605,6 +633,35 @@ export default function SchoolPage() {
             </div>
           </div>
         )}
+
+        {/* Nearby Schools Section */}
+        {nearby.length > 0 && (
+          <div ref={setRef(8)} className="fade-in-section">
+            <div className="flex items-center gap-2.5 mb-4">
+              <GraduationCap size={20} className="text-sky-400" />
+              <h2 className="text-xl font-bold text-white">Nearby Schools</h2>
+            </div>
+            <div className="grid grid-cols-1 sm:grid-cols-2 gap-3">
+              {nearby.map((n) => (
+                <Link
+                  key={n.id}
+                  href={`/school/${n.id}`}
+                  className="flex items-center justify-between p-4 bg-zinc-900/50 border border-zinc-800/50 rounded-xl hover:border-zinc-700 transition-colors"
+                >
+                  <div className="min-w-0">
+                    <p className="text-sm font-medium text-white truncate">{n.name}</p>
+                    <p className="text-xs text-zinc-500">{n.distance_km} km away</p>
+                  </div>
+                  {n.party_score > 0 && (
+                    <span className="text-xs font-semibold text-violet-300 ml-3 shrink-0">
+                      {n.party_score} pts
+                    </span>
+                  )}
+                </Link>
+              ))}
+            </div>
+          </div>
+        )}
       </div>
     </div>
   );
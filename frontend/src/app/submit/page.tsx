@@ -4,7 +4,23 @@ import { useState, useEffect, Suspense } from "react";
 import { useRouter, useSearchParams } from "next/navigation";
 import Link from "next/link";
 import { ArrowLeft, Send, Search, Clock, CheckCircle } from "lucide-react";
-import { createVenue, searchSchools, type School } from "@/lib/api";
+import { createVenue, getTaxonomy, searchSchools, type School } from "@/lib/api";
+
+const CATEGORY_EMOJI: Record<string, string> = {
+  bar: "🍺",
+  nightclub: "🎵",
+  frat: "🏛️",
+  party_host: "🎉",
+  other: "✨",
+};
+
+const DEFAULT_CATEGORIES = [
+  { value: "bar", label: "Bar" },
+  { value: "nightclub", label: "Nightclub" },
+  { value: "frat", label: "Frat / Sorority" },
+  { value: "party_host", label: "Party Host" },
+  { value: "other", label: "Other" },
+];
 import { useAuth } from "@/lib/auth-context";
 
 function SubmitForm() {
@@ -14,6 +30,7 @@ function SubmitForm() {
 
   const [name, setName] = useState("");
   const [category, setCategory] = useState("");
+  const [categories, setCategories] = useState(DEFAULT_CATEGORIES);
   const [description, setDescription] = useState("");
   const [address, setAddress] = useState("");
   const [schoolId, setSchoolId] = useState(searchParams.get("school") || "");
@@ -25,6 +42,14 @@ function SubmitForm() {
   const [success, setSuccess] = useState<{ approved: boolean; id: string } | null>(null);
   const [submitting, setSubmitting] = useState(false);
 
+  useEffect(() => {
+    getTaxonomy("categories")
+      .then((terms) => {
+        if (terms.length > 0) setCategories(terms.map((t) => ({ value: t.slug, label: t.label })));
+      })
+      .catch(() => {});
+  }, []);
+
   // Search schools
   useEffect(() => {
     if (schoolQuery.length < 2) {
@@ -224,13 +249,7 @@ function SubmitForm() {
             Category <span className="text-red-400">*</span>
           </label>
           <div className="grid grid-cols-2 sm:grid-cols-3 gap-2">
-            {[
-              { value: "bar", label: "Bar", emoji: "🍺" },
-              { value: "nightclub", label: "Nightclub", emoji: "🎵" },
-              { value: "frat", label: "Frat / Sorority", emoji: "🏛️" },
-              { value: "party_host", label: "Party Host", emoji: "🎉" },
-              { value: "other", label: "Other", emoji: "✨" },
-            ].map((cat) => (
+            {categories.map((cat) => (
               <button
                 key={cat.value}
                 type="button"
@@ -241,7 +260,7 @@ function SubmitForm() {
                     : "bg-zinc-900 border-zinc-800 text-zinc-400 hover:border-zinc-600"
                 }`}
               >
-                {cat.emoji} {cat.label}
+                {CATEGORY_EMOJI[cat.value] ?? "📍"} {cat.label}
               </button>
             ))}
           </div>
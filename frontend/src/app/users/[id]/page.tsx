@@ -0,0 +1,139 @@
+"use client";
+
+import { useEffect, useState } from "react";
+import { useParams } from "next/navigation";
+import Link from "next/link";
+import { ArrowLeft, CalendarDays, GraduationCap, MessageSquare, Users } from "lucide-react";
+import { getUserProfile, type PublicProfile } from "@/lib/api";
+import Stars from "@/components/Stars";
+
+export default function UserProfilePage() {
+  const { id } = useParams<{ id: string }>();
+  const [profile, setProfile] = useState<PublicProfile | null>(null);
+  const [loading, setLoading] = useState(true);
+  const [notFound, setNotFound] = useState(false);
+
+  useEffect(() => {
+    setLoading(true);
+    getUserProfile(id)
+      .then(setProfile)
+      .catch(() => setNotFound(true))
+      .finally(() => setLoading(false));
+  }, [id]);
+
+  if (loading) {
+    return (
+      <div className="min-h-[calc(100vh-3.5rem)] flex items-center justify-center">
+        <div className="w-8 h-8 border-2 border-violet-500 border-t-transparent rounded-full animate-spin" />
+      </div>
+    );
+  }
+
+  if (notFound || !profile) {
+    return (
+      <div className="min-h-[calc(100vh-3.5rem)] flex flex-col items-center justify-center gap-3">
+        <p className="text-zinc-400">User not found</p>
+        <Link href="/" className="text-sm text-violet-400 hover:text-violet-300">
+          Back to map
+        </Link>
+      </div>
+    );
+  }
+
+  const name = profile.display_name || profile.username;
+  const reviews = profile.recent_reviews ?? [];
+
+  return (
+    <div className="min-h-[calc(100vh-3.5rem)]">
+      <div className="max-w-3xl mx-auto px-4 pt-6 pb-10">
+        <Link
+          href="/"
+          className="inline-flex items-center gap-1 text-zinc-400 hover:text-white text-sm mb-6 transition-colors"
+        >
+          <ArrowLeft size={16} />
+          Back to map
+        </Link>
+
+        <div className="flex items-center gap-4 mb-8">
+          {profile.avatar_url ? (
+            // eslint-disable-next-line @next/next/no-img-element
+            <img src={profile.avatar_url} alt={name} className="w-16 h-16 rounded-2xl object-cover" />
+          ) : (
+            <div className="w-16 h-16 rounded-2xl bg-gradient-to-br from-violet-500 to-fuchsia-500 flex items-center justify-center text-2xl font-bold text-white">
+              {name[0].toUpperCase()}
+            </div>
+          )}
+          <div>
+            <h1 className="text-2xl font-bold text-white flex items-center gap-2">
+              {name}
+              {profile.verified_student && (
+                <span className="flex items-center gap-1 px-1.5 py-0.5 rounded-full bg-violet-500/10 text-violet-300 text-[10px] font-medium">
+                  <GraduationCap size={11} />
+                  Student
+                </span>
+              )}
+            </h1>
+            {profile.display_name && <p className="text-sm text-zinc-500">@{profile.username}</p>}
+            <p className="flex items-center gap-1 text-xs text-zinc-500 mt-1">
+              <CalendarDays size={12} />
+              Member since{" "}
+              {new Date(profile.member_since).toLocaleDateString(undefined, { month: "long", year: "numeric" })}
+            </p>
+          </div>
+        </div>
+
+        {profile.bio && <p className="text-sm text-zinc-300 mb-6 whitespace-pre-line">{profile.bio}</p>}
+
+        <div className="grid grid-cols-2 gap-3 mb-8">
+          <div className="p-4 bg-zinc-900/60 border border-zinc-700/30 rounded-xl">
+            <p className="text-2xl font-bold text-white">{profile.rating_count}</p>
+            <p className="text-xs text-zinc-500">Venue ratings</p>
+          </div>
+          <div className="p-4 bg-zinc-900/60 border border-zinc-700/30 rounded-xl">
+            <p className="text-2xl font-bold text-white">{profile.frat_rating_count}</p>
+            <p className="text-xs text-zinc-500">Chapter ratings</p>
+          </div>
+        </div>
+
+        <h2 className="flex items-center gap-2 text-lg font-semibold text-white mb-3">
+          <MessageSquare size={18} className="text-violet-400" />
+          Recent reviews
+        </h2>
+        {reviews.length === 0 ? (
+          <p className="text-sm text-zinc-500">No reviews yet.</p>
+        ) : (
+          <div className="space-y-3">
+            {reviews.map((r) => (
+              <div key={`${r.type}-${r.id}`} className="p-4 bg-zinc-900/60 border border-zinc-700/30 rounded-xl">
+                <div className="flex items-center justify-between mb-1">
+                  {r.type === "venue" && r.venue_id ? (
+                    <Link href={`/venue/${r.venue_id}`} className="text-sm font-medium text-violet-400 hover:text-violet-300">
+                      {r.venue_name || "Venue"}
+                    </Link>
+                  ) : (
+                    <span className="flex items-center gap-1 text-sm font-medium text-blue-400">
+                      <Users size={14} />
+                      {r.frat_name}
+                    </span>
+                  )}
+                  <Stars value={r.score} />
+                </div>
+                {r.review && <p className="text-sm text-zinc-300">{r.review}</p>}
+                {r.tags && r.tags.length > 0 && (
+                  <div className="flex flex-wrap gap-1 mt-2">
+                    {r.tags.map((t) => (
+                      <span key={t} className="px-2 py-0.5 rounded-full bg-zinc-800 text-zinc-400 text-[10px]">
+                        {t}
+                      </span>
+                    ))}
+                  </div>
+                )}
+                <p className="text-[11px] text-zinc-600 mt-2">{new Date(r.created_at).toLocaleDateString()}</p>
+              </div>
+            ))}
+          </div>
+        )}
+      </div>
+    </div>
+  );
+}
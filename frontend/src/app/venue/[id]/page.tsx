@@ -3,10 +3,22 @@
 import { useEffect, useState, useCallback } from "react";
 import { useParams, useRouter } from "next/navigation";
 import Link from "next/link";
-import { ArrowLeft, Star, ChevronUp, ChevronDown, MapPin, Beer, Music, Users, PartyPopper, HelpCircle, Clock } from "lucide-react";
-import { getVenue, getVenueRatings, voteOnRating, type Venue, type Rating } from "@/lib/api";
+import { ArrowLeft, Star, ChevronUp, ChevronDown, MapPin, Beer, Music, Users, PartyPopper, HelpCircle, Clock, GraduationCap } from "lucide-react";
+import {
+  getVenue,
+  getVenueRatings,
+  getVenueSeasonality,
+  getVenueStatus,
+  voteOnRating,
+  type Venue,
+  type Rating,
+  type Seasonality,
+  type VenueStatus,
+} from "@/lib/api";
 import { useAuth } from "@/lib/auth-context";
 import RatingForm from "@/components/RatingForm";
+import Stars from "@/components/Stars";
+import AuthorLink from "@/components/AuthorLink";
 
 const categoryConfig: Record<string, { icon: React.ReactNode; label: string; color: string }> = {
   bar: { icon: <Beer size={18} />, label: "Bar", color: "text-amber-400 bg-amber-500/10" },
@@ -59,6 +71,8 @@ function ReviewVoteButtons({ rating, userId }: { rating: Rating; userId?: string
   );
 }
 
+const MONTH_INITIALS = ["J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"];
+
 export default function VenuePage() {
   const params = useParams();
   const router = useRouter();
@@ -66,19 +80,23 @@ export default function VenuePage() {
   const id = params.id as string;
   const [venue, setVenue] = useState<Venue | null>(null);
   const [ratings, setRatings] = useState<Rating[]>([]);
+  const [seasonality, setSeasonality] = useState<Seasonality | null>(null);
+  const [status, setStatus] = useState<VenueStatus | null>(null);
   const [loading, setLoading] = useState(true);
+  const [sort, setSort] = useState<"top" | "recent">("top");
+  const [studentsOnly, setStudentsOnly] = useState(false);
 
   const fetchData = useCallback(async (venueId: string) => {
     try {
-      const [v, r] = await Promise.all([getVenue(venueId), getVenueRatings(venueId)]);
+      const [v, r] = await Promise.all([getVenue(venueId), getVenueRatings(venueId, sort, studentsOnly)]);
       setVenue(v);
-      setRatings(r || []);
+      setRatings(r.data || []);
     } catch (err) {
       console.error(err);
     } finally {
       setLoading(false);
     }
-  }, []);
+  }, [sort, studentsOnly]);
 
   const handleRatingSubmitted = useCallback(() => {
     fetchData(id);
@@ -88,6 +106,11 @@ export default function VenuePage() {
     fetchData(id);
   }, [id, fetchData]);
 
+  useEffect(() => {
+    getVenueSeasonality(id).then(setSeasonality).catch(() => setSeasonality(null));
+    getVenueStatus(id).then(setStatus).catch(() => setStatus(null));
+  }, [id]);
+
   if (loading) {
     return (
       <div className="min-h-[calc(100vh-3.5rem)] flex items-center justify-center">
@@ -160,22 +183,15 @@ export default function VenuePage() {
           </div>
           <div className="text-right shrink-0 space-y-1.5">
             <div className="flex items-center gap-1">
-              {[1, 2, 3, 4, 5].map((i) => (
-                <Star
-                  key={i}
-                  size={16}
-                  className={
-                    i <= Math.round(venue.avg_rating)
-                      ? "text-amber-400 fill-amber-400"
-                      : "text-zinc-600"
-                  }
-                />
-              ))}
+              <Stars value={venue.avg_rating} size={16} />
               <span className="text-sm text-zinc-400 ml-1">
                 {venue.avg_rating > 0 ? venue.avg_rating.toFixed(1) : "N/A"}
               </span>
             </div>
             <p className="text-xs text-zinc-500">{venue.rating_count} {venue.rating_count === 1 ? "review" : "reviews"}</p>
+            {venue.recommend_pct !== null && (
+              <p className="text-xs text-emerald-400">{venue.recommend_pct}% recommend</p>
+            )}
           </div>
         </div>
 
@@ -189,8 +205,66 @@ export default function VenuePage() {
             {venue.address}
           </div>
         )}
+
+        {venue.cohorts && venue.cohorts.length > 1 && (
+          <div className="flex flex-wrap gap-x-4 gap-y-1 mt-4 text-xs text-zinc-400">
+            {venue.cohorts.map((c) => (
+              <span key={c.cohort}>
+                <span className="capitalize">{c.cohort === "alumni" ? "alumni" : `${c.cohort}s`}</span>{" "}
+                rate it <span className="text-white font-medium">{c.avg_rating.toFixed(1)}</span>
+              </span>
+            ))}
+          </div>
+        )}
       </div>
 
+      {/* Owner's status for tonight */}
+      {status && (
+        <div className="mb-6 p-4 bg-violet-500/10 border border-violet-500/20 rounded-xl">
+          <h4 className="text-sm font-semibold text-white mb-2">Tonight, from the venue</h4>
+          <div className="flex flex-wrap gap-2 text-xs">
+            {status.crowd && (
+              <span className="px-2 py-0.5 rounded-full bg-zinc-800 text-zinc-200">
+                {status.crowd === "at_capacity" ? "At capacity" : status.crowd.charAt(0).toUpperCase() + status.crowd.slice(1)}
+              </span>
+            )}
+            {status.live_music && (
+              <span className="flex items-center gap-1 px-2 py-0.5 rounded-full bg-fuchsia-500/10 text-fuchsia-300">
+                <Music size={11} />
+                Live music
+              </span>
+            )}
+            {status.cover && <span className="px-2 py-0.5 rounded-full bg-zinc-800 text-zinc-200">{status.cover}</span>}
+          </div>
+          {status.note && <p className="text-sm text-zinc-300 mt-2">{status.note}</p>}
+        </div>
+      )}
+
+      {/* Seasonality */}
+      {seasonality?.peak_month && (
+        <div className="mb-6 p-4 bg-zinc-900/50 border border-zinc-800/50 rounded-xl">
+          <h4 className="text-sm font-semibold text-white mb-3">Busiest months</h4>
+          <div className="flex items-end gap-1 h-16">
+            {seasonality.months.map((m) => (
+              <div key={m.month} className="flex-1 flex flex-col items-center gap-1">
+                <div
+                  className={`w-full rounded-sm ${m.month === seasonality.peak_month ? "bg-violet-500" : "bg-zinc-700"}`}
+                  style={{ height: `${Math.max(m.activity, 4)}%` }}
+                  title={`${m.ratings} ratings, ${m.check_ins} check-ins`}
+                />
+              </div>
+            ))}
+          </div>
+          <div className="flex gap-1 mt-1">
+            {seasonality.months.map((m) => (
+              <span key={m.month} className="flex-1 text-center text-[10px] text-zinc-500">
+                {MONTH_INITIALS[m.month - 1]}
+              </span>
+            ))}
+          </div>
+        </div>
+      )}
+
       {/* Rating Form */}
       <div className="mb-6">
         <RatingForm venueId={id} onRatingSubmitted={handleRatingSubmitted} />
@@ -198,17 +272,44 @@ export default function VenuePage() {
 
       {/* Ratings List */}
       <div>
-        <h2 className="text-lg font-semibold text-white mb-4">
-          Reviews
-          {ratings.length > 0 && (
-            <span className="text-zinc-500 font-normal ml-1">({ratings.length})</span>
+        <div className="flex items-center justify-between mb-4">
+          <h2 className="text-lg font-semibold text-white">
+            Reviews
+            {ratings.length > 0 && (
+              <span className="text-zinc-500 font-normal ml-1">({ratings.length})</span>
+            )}
+          </h2>
+          {(ratings.length > 1 || studentsOnly) && (
+            <div className="flex gap-1 text-xs">
+              <button
+                onClick={() => setStudentsOnly(!studentsOnly)}
+                className={`px-2.5 py-1 rounded-full transition-colors ${
+                  studentsOnly ? "bg-violet-600 text-white" : "bg-zinc-800 text-zinc-400 hover:text-white"
+                }`}
+              >
+                Students
+              </button>
+              {(["top", "recent"] as const).map((s) => (
+                <button
+                  key={s}
+                  onClick={() => setSort(s)}
+                  className={`px-2.5 py-1 rounded-full transition-colors ${
+                    sort === s ? "bg-violet-600 text-white" : "bg-zinc-800 text-zinc-400 hover:text-white"
+                  }`}
+                >
+                  {s === "top" ? "Top" : "Recent"}
+                </button>
+              ))}
+            </div>
           )}
-        </h2>
+        </div>
 
         {ratings.length === 0 ? (
           <div className="text-center py-8 bg-zinc-900/50 border border-zinc-800/50 rounded-xl">
             <Star size={24} className="mx-auto text-zinc-600 mb-2" />
-            <p className="text-zinc-500 text-sm">No reviews yet. Be the first!</p>
+            <p className="text-zinc-500 text-sm">
+              {studentsOnly ? "No reviews from verified students yet." : "No reviews yet. Be the first!"}
+            </p>
           </div>
         ) : (
           <div className="space-y-3">
@@ -222,23 +323,24 @@ export default function VenuePage() {
                     <div className="w-7 h-7 rounded-full bg-gradient-to-br from-violet-500 to-fuchsia-500 flex items-center justify-center text-xs font-bold text-white">
                       {(rating.author_name || "A")[0].toUpperCase()}
                     </div>
-                    <span className="text-sm font-medium text-white">
-                      {rating.author_name || "Anonymous"}
-                    </span>
-                  </div>
-                  <div className="flex items-center gap-0.5">
-                    {[1, 2, 3, 4, 5].map((i) => (
-                      <Star
-                        key={i}
-                        size={12}
-                        className={
-                          i <= Math.round(rating.score)
-                            ? "text-amber-400 fill-amber-400"
-                            : "text-zinc-600"
-                        }
-                      />
-                    ))}
+                    <AuthorLink
+                      authorId={rating.author_id}
+                      name={rating.author_name}
+                      className="text-sm font-medium text-white"
+                    />
+                    {rating.verified_student && (
+                      <span className="flex items-center gap-1 px-1.5 py-0.5 rounded-full bg-violet-500/10 text-violet-300 text-[10px] font-medium">
+                        <GraduationCap size={11} />
+                        Student
+                      </span>
+                    )}
+                    {rating.imported && (
+                      <span className="px-1.5 py-0.5 rounded-full bg-zinc-800 text-zinc-400 text-[10px] font-medium">
+                        Imported
+                      </span>
+                    )}
                   </div>
+                  <Stars value={rating.score} />
                 </div>
                 {rating.review && (
                   <p className="text-zinc-300 text-sm">{rating.review}</p>
@@ -247,6 +349,7 @@ export default function VenuePage() {
                   <div className="flex items-center gap-1 text-xs text-zinc-500">
                     <Clock size={12} />
                     {new Date(rating.created_at).toLocaleDateString()}
+                    {rating.edited_at && <span>(edited)</span>}
                   </div>
                   <ReviewVoteButtons rating={rating} userId={user?.id} />
                 </div>
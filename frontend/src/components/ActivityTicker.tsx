@@ -42,15 +42,15 @@ export default function ActivityTicker() {
 
   useEffect(() => {
     getRecentActivity()
-      .then((data) => {
-        if (data && data.length > 0) setItems(data);
+      .then((res) => {
+        if (res.data && res.data.length > 0) setItems(res.data);
       })
       .catch(() => {});
 
     const interval = setInterval(() => {
       getRecentActivity()
-        .then((data) => {
-          if (data && data.length > 0) setItems(data);
+        .then((res) => {
+          if (res.data && res.data.length > 0) setItems(res.data);
         })
         .catch(() => {});
     }, 60_000);
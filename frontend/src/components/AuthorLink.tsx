@@ -0,0 +1,22 @@
+import Link from "next/link";
+
+// Deleted or anonymized authors have no profile to link to.
+export default function AuthorLink({
+  authorId,
+  name,
+  className,
+}: {
+  authorId?: string;
+  name?: string;
+  className?: string;
+}) {
+  const label = name || "Anonymous";
+  if (!authorId || authorId === "deleted") {
+    return <span className={className}>{label}</span>;
+  }
+  return (
+    <Link href={`/users/${authorId}`} className={`${className ?? ""} hover:text-violet-300 transition-colors`}>
+      {label}
+    </Link>
+  );
+}
@@ -18,6 +18,7 @@ interface MapProps {
 }
 
 function schoolPassesFilter(s: MapSchool, filters: FilterState, fratSchoolIds?: string[]): boolean {
+  if (!filters.countries.includes(s.country || "US")) return false;
   if (!filters.controlTypes.includes(s.control)) return false;
   if (!filters.schoolLevels.includes(s.iclevel)) return false;
   if (filters.instSizes.length < 5 && s.instsize > 0 && !filters.instSizes.includes(s.instsize)) return false;
@@ -34,12 +35,22 @@ function schoolPassesFilter(s: MapSchool, filters: FilterState, fratSchoolIds?:
 }
 
 function buildMapFilter(filters: FilterState, fratSchoolIds?: string[]): maplibregl.FilterSpecification | null {
-  if (filters.controlTypes.length === 0 || filters.schoolLevels.length === 0 || filters.instSizes.length === 0) {
+  if (filters.countries.length === 0 || filters.controlTypes.length === 0 || filters.schoolLevels.length === 0 || filters.instSizes.length === 0) {
     return ["==", ["get", "id"], ""] as maplibregl.FilterSpecification;
   }
 
   const parts: maplibregl.ExpressionSpecification[] = [];
 
+  // Country
+  if (filters.countries.length < DEFAULT_FILTERS.countries.length) {
+    const matchExpr: unknown[] = ["match", ["get", "country"]];
+    for (const c of filters.countries) {
+      matchExpr.push(c, true);
+    }
+    matchExpr.push(false);
+    parts.push(matchExpr as maplibregl.ExpressionSpecification);
+  }
+
   // Institution type — use match expression for reliable multi-value check
   if (filters.controlTypes.length > 0 && filters.controlTypes.length < 3) {
     const matchExpr: unknown[] = ["match", ["get", "control"]];
@@ -131,6 +142,7 @@ export default function Map({ onSchoolClick, flyTo, filters = DEFAULT_FILTERS, f
             id: s.id,
             name: s.name,
             state: s.state,
+            country: s.country || "US",
             control: s.control,
             iclevel: s.iclevel || 1,
             venue_count: s.venue_count || 0,
@@ -1,10 +1,11 @@
 "use client";
 
-import { useState } from "react";
-import { Star, Send } from "lucide-react";
-import { createRating } from "@/lib/api";
+import { useEffect, useRef, useState, type MouseEvent } from "react";
+import { Star, StarHalf, Send } from "lucide-react";
+import { createRating, getRatingDraft, saveRatingDraft } from "@/lib/api";
 import { useAuth } from "@/lib/auth-context";
 import Link from "next/link";
+import { scoreLabel } from "./Stars";
 
 interface RatingFormProps {
   venueId: string;
@@ -16,9 +17,45 @@ export default function RatingForm({ venueId, onRatingSubmitted }: RatingFormPro
   const [score, setScore] = useState(0);
   const [hoverScore, setHoverScore] = useState(0);
   const [review, setReview] = useState("");
+  const [wouldRecommend, setWouldRecommend] = useState<boolean | null>(null);
   const [submitting, setSubmitting] = useState(false);
   const [error, setError] = useState("");
   const [success, setSuccess] = useState(false);
+  const [draftSavedAt, setDraftSavedAt] = useState<string | null>(null);
+  const draftLoaded = useRef(false);
+
+  // Resume an autosaved draft for this venue, if there is one
+  useEffect(() => {
+    if (!user) return;
+    draftLoaded.current = false;
+    getRatingDraft(venueId)
+      .then((d) => {
+        setScore(d.score ?? 0);
+        setReview(d.review ?? "");
+        setWouldRecommend(d.would_recommend ?? null);
+        setDraftSavedAt(d.updated_at);
+      })
+      .catch(() => {})
+      .finally(() => {
+        draftLoaded.current = true;
+      });
+  }, [user, venueId]);
+
+  // Autosave a couple of seconds after the user stops typing
+  useEffect(() => {
+    if (!user || !draftLoaded.current || success || (score === 0 && !review)) return;
+    const t = setTimeout(() => {
+      saveRatingDraft({
+        venue_id: venueId,
+        score: score || undefined,
+        review,
+        would_recommend: wouldRecommend ?? undefined,
+      })
+        .then((d) => setDraftSavedAt(d.updated_at))
+        .catch(() => {});
+    }, 2000);
+    return () => clearTimeout(t);
+  }, [user, venueId, score, review, wouldRecommend, success]);
 
   if (!user) {
     return (
@@ -53,7 +90,12 @@ export default function RatingForm({ venueId, onRatingSubmitted }: RatingFormPro
     setError("");
 
     try {
-      await createRating({ score, review, venue_id: venueId });
+      await createRating({
+        score,
+        review,
+        venue_id: venueId,
+        would_recommend: wouldRecommend ?? undefined,
+      });
       setSuccess(true);
       onRatingSubmitted?.();
     } catch (err) {
@@ -65,6 +107,12 @@ export default function RatingForm({ venueId, onRatingSubmitted }: RatingFormPro
 
   const displayScore = hoverScore || score;
 
+  // The left half of a star picks a half-star score.
+  const pointerScore = (e: MouseEvent<HTMLButtonElement>, i: number) => {
+    const rect = e.currentTarget.getBoundingClientRect();
+    return e.clientX - rect.left < rect.width / 2 ? i - 0.5 : i;
+  };
+
   return (
     <form onSubmit={handleSubmit} className="p-4 bg-zinc-900/50 border border-zinc-800/50 rounded-xl space-y-3">
       <h4 className="text-sm font-semibold text-white">Rate this venue</h4>
@@ -74,10 +122,11 @@ export default function RatingForm({ venueId, onRatingSubmitted }: RatingFormPro
           <button
             key={i}
             type="button"
-            onClick={() => setScore(i)}
-            onMouseEnter={() => setHoverScore(i)}
+            onClick={(e) => setScore(pointerScore(e, i))}
+            onMouseMove={(e) => setHoverScore(pointerScore(e, i))}
             onMouseLeave={() => setHoverScore(0)}
-            className="p-0.5 transition-transform hover:scale-110"
+            aria-label={`${i} stars`}
+            className="relative p-0.5 transition-transform hover:scale-110"
           >
             <Star
               size={28}
@@ -87,11 +136,14 @@ export default function RatingForm({ venueId, onRatingSubmitted }: RatingFormPro
                   : "text-zinc-600 hover:text-zinc-500"
               }
             />
+            {i - 0.5 === displayScore && (
+              <StarHalf size={28} className="absolute top-0.5 left-0.5 text-amber-400 fill-amber-400" />
+            )}
           </button>
         ))}
         {displayScore > 0 && (
           <span className="text-sm text-zinc-400 ml-2">
-            {displayScore === 1 ? "Bad" : displayScore === 2 ? "Meh" : displayScore === 3 ? "OK" : displayScore === 4 ? "Great" : "Amazing"}
+            {displayScore} &middot; {scoreLabel(displayScore)}
           </span>
         )}
       </div>
@@ -105,6 +157,32 @@ export default function RatingForm({ venueId, onRatingSubmitted }: RatingFormPro
         className="w-full px-3 py-2 bg-zinc-800 border border-zinc-700 rounded-lg text-white text-sm placeholder-zinc-500 focus:outline-none focus:border-violet-500 resize-none"
       />
 
+      <div className="flex items-center justify-between gap-2">
+        <span className="text-xs text-zinc-400">Would you recommend this school&apos;s nightlife to a friend?</span>
+        <div className="flex gap-1">
+          {[true, false].map((v) => (
+            <button
+              key={String(v)}
+              type="button"
+              onClick={() => setWouldRecommend(wouldRecommend === v ? null : v)}
+              className={`px-2.5 py-1 text-xs rounded-md border transition-colors ${
+                wouldRecommend === v
+                  ? "bg-violet-600 border-violet-500 text-white"
+                  : "bg-zinc-800 border-zinc-700 text-zinc-400 hover:text-white"
+              }`}
+            >
+              {v ? "Yes" : "No"}
+            </button>
+          ))}
+        </div>
+      </div>
+
+      {draftSavedAt && (
+        <p className="text-zinc-500 text-xs">
+          Draft saved {new Date(draftSavedAt).toLocaleTimeString([], { hour: "numeric", minute: "2-digit" })}
+        </p>
+      )}
+
       {error && (
         <p className="text-red-400 text-xs">{error}</p>
       )}
@@ -3,7 +3,7 @@
 import { useEffect, useState, useCallback } from "react";
 import { X, MapPin, Globe, Star, ChevronRight, ExternalLink, Users } from "lucide-react";
 import Link from "next/link";
-import { getSchool, getSchoolVenues, getSchoolFraternities, type School, type Venue, type FratWithRating } from "@/lib/api";
+import { getSchool, getSchoolFraternities, type School, type Venue, type FratWithRating } from "@/lib/api";
 import { useToast } from "@/lib/toast-context";
 import { SchoolPanelSkeleton } from "./Skeleton";
 import VenueCard from "./VenueCard";
@@ -25,14 +25,11 @@ export default function SchoolPanel({ schoolId, onClose }: SchoolPanelProps) {
 
   const fetchData = useCallback(async (id: string) => {
     try {
-      const [s, v, f] = await Promise.all([
-        getSchool(id),
-        getSchoolVenues(id),
-        getSchoolFraternities(id),
-      ]);
+      const s = await getSchool(id, ["venues", "fraternities"]);
       setSchool(s);
-      setVenues(v.data || []);
-      setFraternities(f || []);
+      setVenues(s.venues?.data || []);
+      // Left out for schools that opted out of Greek life.
+      setFraternities(s.fraternities?.data || []);
     } catch (err) {
       console.error(err);
     } finally {
@@ -42,7 +39,7 @@ export default function SchoolPanel({ schoolId, onClose }: SchoolPanelProps) {
 
   const refreshFrats = useCallback(() => {
     getSchoolFraternities(schoolId)
-      .then((f) => setFraternities(f || []))
+      .then((f) => setFraternities(f.data || []))
       .catch(console.error);
   }, [schoolId]);
 
@@ -18,6 +18,11 @@ const CONTROL_OPTIONS = [
   { value: "private_forprofit", label: "For-Profit" },
 ] as const;
 
+const COUNTRY_OPTIONS = [
+  { value: "US", label: "United States" },
+  { value: "CA", label: "Canada" },
+] as const;
+
 const LEVEL_OPTIONS = [
   { value: 1, label: "4-Year" },
   { value: 2, label: "2-Year" },
@@ -44,6 +49,8 @@ const TOGGLE_FILTERS: { key: keyof FilterState; label: string }[] = [
 
 const STATES = ["AL","AK","AZ","AR","CA","CO","CT","DE","FL","GA","HI","ID","IL","IN","IA","KS","KY","LA","ME","MD","MA","MI","MN","MS","MO","MT","NE","NV","NH","NJ","NM","NY","NC","ND","OH","OK","OR","PA","RI","SC","SD","TN","TX","UT","VT","VA","WA","WV","WI","WY","DC"];
 
+const PROVINCES = ["AB","BC","MB","NB","NL","NS","NT","NU","ON","PE","QC","SK","YT"];
+
 function isDefaultFilters(f: FilterState): boolean {
   return JSON.stringify(f) === JSON.stringify(DEFAULT_FILTERS);
 }
@@ -73,14 +80,24 @@ export default function SearchBar({
   useEffect(() => {
     if (showFilters && fratNames.length === 0) {
       getAllFraternities()
-        .then(setFratNames)
+        .then((res) => setFratNames(res.data || []))
         .catch(console.error);
     }
   }, [showFilters, fratNames.length]);
 
-  const filteredFrats = fratQuery
-    ? fratNames.filter((n) => n.toLowerCase().includes(fratQuery.toLowerCase()))
-    : fratNames;
+  const [fratMatches, setFratMatches] = useState<string[]>([]);
+
+  useEffect(() => {
+    if (!fratQuery.trim()) return;
+    const t = setTimeout(() => {
+      getAllFraternities(fratQuery.trim())
+        .then((res) => setFratMatches(res.data || []))
+        .catch(console.error);
+    }, 200);
+    return () => clearTimeout(t);
+  }, [fratQuery]);
+
+  const filteredFrats = fratQuery.trim() ? fratMatches : fratNames;
 
   const doSearch = useCallback(async (q: string, filterState: string) => {
     if (q.length < 2 && !filterState) {
@@ -95,6 +112,9 @@ export default function SearchBar({
         limit: "20",
         page: "1",
       };
+      if (filters.countries.length === 1) {
+        params.country = filters.countries[0];
+      }
       if (filters.controlTypes.length === 1) {
         params.control = filters.controlTypes[0];
       }
@@ -104,6 +124,7 @@ export default function SearchBar({
       const res = await searchSchools(params);
       let data = res.data || [];
       data = data.filter((s: School) => {
+        if (!filters.countries.includes(s.country || "US")) return false;
         if (!filters.controlTypes.includes(s.control)) return false;
         if (!filters.schoolLevels.includes(s.iclevel)) return false;
         return true;
@@ -173,6 +194,7 @@ export default function SearchBar({
 
   const hasActiveFilters = !isDefaultFilters(filters) || stateFilter || selectedFrat;
   const activeFilterCount =
+    (DEFAULT_FILTERS.countries.length - filters.countries.length) +
     (3 - filters.controlTypes.length === 0 ? 0 : 3 - filters.controlTypes.length) +
     (3 - filters.schoolLevels.length === 0 ? 0 : 3 - filters.schoolLevels.length) +
     (5 - filters.instSizes.length) +
@@ -254,7 +276,7 @@ export default function SearchBar({
           <div className="p-5 space-y-4">
             {/* State */}
             <div>
-              <label className="block text-xs text-zinc-400 mb-1.5 font-medium">State</label>
+              <label className="block text-xs text-zinc-400 mb-1.5 font-medium">State / Province</label>
               <select
                 value={stateFilter}
                 onChange={(e) => setStateFilter(e.target.value)}
@@ -264,9 +286,37 @@ export default function SearchBar({
                 {STATES.map((s) => (
                   <option key={s} value={s}>{s}</option>
                 ))}
+                <optgroup label="Canada">
+                  {PROVINCES.map((p) => (
+                    <option key={p} value={p}>{p}</option>
+                  ))}
+                </optgroup>
               </select>
             </div>
 
+            {/* Country */}
+            <div>
+              <label className="block text-xs text-zinc-400 mb-1.5 font-medium">Country</label>
+              <div className="flex flex-wrap gap-1.5">
+                {COUNTRY_OPTIONS.map((opt) => {
+                  const active = filters.countries.includes(opt.value);
+                  return (
+                    <button
+                      key={opt.value}
+                      onClick={() => onFiltersChange({ ...filters, countries: toggleMultiSelect(filters.countries, opt.value) })}
+                      className={`px-3 py-1.5 rounded-lg text-xs font-medium transition-all ${
+                        active
+                          ? "bg-violet-600/30 text-violet-300 border border-violet-500/40"
+                          : "bg-zinc-800 text-zinc-500 border border-zinc-700 hover:text-zinc-300 hover:border-zinc-600"
+                      }`}
+                    >
+                      {opt.label}
+                    </button>
+                  );
+                })}
+              </div>
+            </div>
+
             {/* Institution Type */}
             <div>
               <label className="block text-xs text-zinc-400 mb-1.5 font-medium">Institution Type</label>
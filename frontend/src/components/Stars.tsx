@@ -0,0 +1,32 @@
+"use client";
+
+import { Star, StarHalf } from "lucide-react";
+
+// Round to the nearest half star, matching how the API accepts scores.
+export function roundToHalf(value: number) {
+  return Math.round(value * 2) / 2;
+}
+
+export function scoreLabel(score: number) {
+  return score < 1.5 ? "Bad" : score < 2.5 ? "Meh" : score < 3.5 ? "OK" : score < 4.5 ? "Great" : "Amazing";
+}
+
+export default function Stars({ value, size = 12 }: { value: number; size?: number }) {
+  const rounded = roundToHalf(value);
+  return (
+    <div className="flex items-center gap-0.5">
+      {[1, 2, 3, 4, 5].map((i) =>
+        i <= rounded ? (
+          <Star key={i} size={size} className="text-amber-400 fill-amber-400" />
+        ) : i - 0.5 === rounded ? (
+          <span key={i} className="relative inline-flex">
+            <Star size={size} className="text-zinc-600" />
+            <StarHalf size={size} className="absolute inset-0 text-amber-400 fill-amber-400" />
+          </span>
+        ) : (
+          <Star key={i} size={size} className="text-zinc-600" />
+        )
+      )}
+    </div>
+  );
+}
@@ -1,7 +1,8 @@
 "use client";
 
 import Link from "next/link";
-import { Star, MapPin, Beer, Music, Users, PartyPopper, HelpCircle } from "lucide-react";
+import Stars from "./Stars";
+import { MapPin, Beer, Music, Users, PartyPopper, HelpCircle } from "lucide-react";
 
 interface VenueCardProps {
   venue: {
@@ -44,17 +45,7 @@ const categoryColors: Record<string, string> = {
 function VenueRating({ rating, count }: { rating: number; count: number }) {
   return (
     <div className="flex items-center gap-1.5">
-      <div className="flex items-center gap-0.5">
-        {[1, 2, 3, 4, 5].map((i) => (
-          <Star
-            key={i}
-            size={12}
-            className={
-              i <= Math.round(rating) ? "text-amber-400 fill-amber-400" : "text-zinc-600"
-            }
-          />
-        ))}
-      </div>
+      <Stars value={rating} />
       <span className="text-xs text-zinc-400">
         {rating > 0 ? rating.toFixed(1) : "N/A"}
       </span>
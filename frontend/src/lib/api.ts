@@ -36,8 +36,9 @@ async function apiFetch<T>(endpoint: string, options: FetchOptions = {}): Promis
   }
 
   // Build headers with auth token if available
+  // Multipart bodies need the browser to set their own boundary header
   const headers: Record<string, string> = {
-    "Content-Type": "application/json",
+    ...(fetchOptions.body instanceof FormData ? {} : { "Content-Type": "application/json" }),
     ...(fetchOptions.headers as Record<string, string>),
   };
   const token = getToken();
@@ -68,8 +69,10 @@ export interface School {
   alias_name?: string;
   address: string;
   city: string;
-  state: string;
+  city_slug: string; // for /api/cities/{state}/{city}
+  state: string; // province for Canadian schools
   zip: string;
+  country: string; // region code, e.g. "US", "CA"
   control: "public" | "private_nonprofit" | "private_forprofit";
   iclevel: number;
   website?: string;
@@ -79,7 +82,18 @@ export interface School {
   locale?: number;
   venue_count: number;
   frat_count: number;
+  greek_opt_out?: boolean;
   avg_rating?: number;
+  recommend_pct?: number;
+  conference?: string;
+  links?: SchoolLink[];
+}
+
+export interface SchoolLink {
+  id: string;
+  kind: "instagram" | "tiktok" | "x" | "barstool" | "website";
+  label?: string;
+  url: string;
 }
 
 export interface MapSchool {
@@ -88,11 +102,13 @@ export interface MapSchool {
   latitude: number;
   longitude: number;
   state: string;
+  country: string;
   control: string;
   iclevel: number;
   venue_count: number;
   avg_rating: number;
   frat_count: number;
+  greek_opt_out?: boolean;
   instsize: number;
   hbcu: boolean;
   is_online: boolean;
@@ -104,6 +120,7 @@ export interface MapSchool {
 }
 
 export interface FilterState {
+  countries: string[];
   controlTypes: string[];
   schoolLevels: number[];
   instSizes: number[];
@@ -118,6 +135,7 @@ export interface FilterState {
 }
 
 export const DEFAULT_FILTERS: FilterState = {
+  countries: ["US", "CA"],
   controlTypes: ["public", "private_nonprofit", "private_forprofit"],
   schoolLevels: [1, 2, 3],
   instSizes: [1, 2, 3, 4, 5],
@@ -137,12 +155,13 @@ export interface PaginatedResponse<T> {
   page: number;
   limit: number;
   total_pages: number;
+  max_limit?: number;
 }
 
 export interface Venue {
   id: string;
   name: string;
-  category: "bar" | "nightclub" | "frat" | "party_host" | "other";
+  category: string; // slug from the category taxonomy, e.g. "bar"
   description?: string;
   address?: string;
   latitude?: number;
@@ -155,34 +174,87 @@ export interface Venue {
   rating_count: number;
   thumbs_up: number;
   thumbs_down: number;
+  recommend_pct: number | null;
+  sponsored?: boolean;
+  rideshare?: RideshareLink[];
+  cohorts?: CohortStat[];
+}
+
+// Average rating among one class-year cohort of reviewers
+export interface CohortStat {
+  cohort: "freshman" | "sophomore" | "junior" | "senior" | "alumni";
+  avg_rating: number;
+  rating_count: number;
+}
+
+export interface RideshareLink {
+  provider: "uber" | "lyft";
+  label: string;
+  url: string;
+}
+
+export interface SchoolVenuesResponse extends PaginatedResponse<Venue> {
+  sponsored: Venue[];
 }
 
 export interface Rating {
   id: string;
   score: number;
   review?: string;
+  tags?: string[];
   venue_id: string;
   author_id: string;
   author_name?: string;
   created_at: string;
   upvotes: number;
   downvotes: number;
+  reactions?: Partial<Record<ReactionName, number>>;
+  redacted?: boolean;
+  pii?: { kind: "phone" | "email" | "name"; text: string; action: "warn" | "redact" | "queue" }[];
+  pending_review?: boolean;
+  verified_student?: boolean;
+  verified_visit?: boolean;
+  would_recommend?: boolean;
+  author_grad_year?: number;
+  edited_at?: string;
+  // Brought in from the author's Google Maps reviews
+  imported?: boolean;
 }
 
+export type ReactionName = "fire" | "skull" | "beers";
+
+export type ChapterStatus = "active" | "suspended" | "banned";
+
 export interface FratWithRating {
   name: string;
+  chapter?: string;
+  founded_year?: number;
+  status?: ChapterStatus;
   avg_rating: number;
   rating_count: number;
 }
 
 export interface AuthResponse {
   token: string;
+  // Echoes the csrf_token cookie; only cookie-authenticated clients need to
+  // send it back, since requests with a bearer token are exempt
+  csrf_token?: string;
   user: {
     id: string;
     username: string;
     role: string;
     display_name?: string;
     avatar_url?: string;
+    bio?: string;
+    home_school_id?: string;
+    grad_year?: number;
+    school_id?: string;
+    student_email?: string;
+    verified_student?: boolean;
+    // Banned users can read but every write answers 403
+    banned?: boolean;
+    banned_until?: string;
+    ban_reason?: string;
   };
 }
 
@@ -200,28 +272,167 @@ export const getStats = () =>
 export const searchSchools = (params: Record<string, string>) =>
   apiFetch<PaginatedResponse<School>>("/api/schools", { params });
 
-export const getSchoolMapData = () =>
-  apiFetch<MapSchool[]>("/api/schools/map");
+export const getSchoolMapData = (country?: string) =>
+  apiFetch<MapSchool[]>("/api/schools/map", country ? { params: { country } } : {});
 
-export const getSchool = (id: string) =>
-  apiFetch<School>(`/api/schools/${id}`);
+export interface Region {
+  code: string;
+  name: string;
+  school_count: number;
+}
+
+export const getRegions = () =>
+  apiFetch<Region[]>("/api/regions");
+
+export interface SchoolSummary {
+  id: string;
+  name: string;
+  city: string;
+  state: string;
+  country: string;
+  latitude: number;
+  longitude: number;
+  venue_count: number;
+  avg_rating: number;
+  party_score: number;
+  conference?: string;
+  links?: SchoolLink[];
+  distance_km?: number;
+}
+
+// Everything the app needs on cold start in one request
+export interface Bootstrap {
+  user: AuthResponse["user"] | null;
+  home_school: SchoolSummary | null;
+  nearby_schools: SchoolSummary[];
+  features: Record<string, boolean>;
+  categories: TaxonomyTerm[];
+  tags: TaxonomyTerm[];
+  regions: Region[];
+  terms_version: string;
+}
+
+export const getBootstrap = (opts: { lat?: number; lng?: number; schoolId?: string } = {}) =>
+  apiFetch<Bootstrap>("/api/bootstrap", {
+    params: {
+      lat: opts.lat !== undefined ? String(opts.lat) : "",
+      lng: opts.lng !== undefined ? String(opts.lng) : "",
+      school_id: opts.schoolId ?? "",
+    },
+  });
+
+export type SchoolInclude = "venues" | "venues.ratings" | "ratings" | "fraternities";
+
+// Related lists embedded via ?include=; each is the first page only.
+export interface SchoolDetail extends School {
+  venues?: PaginatedResponse<Venue & { ratings?: PaginatedResponse<Rating> }>;
+  ratings?: PaginatedResponse<Rating>;
+  fraternities?: PaginatedResponse<FratWithRating>;
+  // Set on home-game days, with the game
+  game_day?: boolean;
+  game?: FootballGame;
+}
+
+export const getSchool = (id: string, include: SchoolInclude[] = []) =>
+  apiFetch<SchoolDetail>(`/api/schools/${id}`, {
+    params: include.length ? { include: include.join(",") } : undefined,
+  });
+
+// Football schedule; home games make the day a game day for the school
+export interface FootballGame {
+  id: string;
+  school_id: string;
+  opponent: string;
+  kickoff: string;
+  home: boolean;
+  location?: string;
+  created_at: string;
+}
+
+// Upcoming games by default, the whole season with all=true
+export const getSchoolGames = (schoolId: string, all = false) =>
+  apiFetch<FootballGame[]>(`/api/schools/${schoolId}/games`, {
+    params: all ? { all: "true" } : undefined,
+  });
+
+// Every school and venue in a city, for commuter towns and multi-campus metros
+export interface CityPage {
+  state: string;
+  slug: string;
+  name: string;
+  avg_rating: number;
+  rating_count: number;
+  schools: SchoolSummary[]; // highest party score first
+  venues: Venue[];
+  leaderboard: Venue[]; // top rated venues
+}
+
+export const getCity = (state: string, city: string) =>
+  apiFetch<CityPage>(`/api/cities/${encodeURIComponent(state)}/${encodeURIComponent(city)}`);
+
+export type HeatmapMetric = "ratings" | "checkins";
+
+export interface Heatmap {
+  metric: HeatmapMetric;
+  zoom: number; // zoom bucket the grid was built for
+  cell_deg: number;
+  max: number;
+  cells: { lat: number; lng: number; value: number; intensity: number }[];
+}
+
+// bbox is [minLng, minLat, maxLng, maxLat], as returned by map.getBounds().toArray().flat()
+export const getHeatmap = (bbox: number[], zoom: number, metric: HeatmapMetric = "ratings") =>
+  apiFetch<Heatmap>("/api/heatmap", {
+    params: { bbox: bbox.join(","), zoom: String(Math.floor(zoom)), metric },
+  });
+
+export const getNearbySchools = (id: string, radiusKm?: number) =>
+  apiFetch<PaginatedResponse<SchoolSummary>>(`/api/schools/${id}/nearby`, {
+    params: radiusKm ? { radius_km: String(radiusKm) } : undefined,
+  });
 
 export const getSchoolVenues = (id: string, page = 1, limit = 20) =>
-  apiFetch<PaginatedResponse<Venue>>(`/api/schools/${id}/venues`, {
+  apiFetch<SchoolVenuesResponse>(`/api/schools/${id}/venues`, {
+    params: { page: String(page), limit: String(limit) },
+  });
+
+// Closed or removed venues with their final ratings, most recently closed first
+export interface ArchivedVenue {
+  id: string;
+  name: string;
+  category: string;
+  address?: string;
+  latitude?: number;
+  longitude?: number;
+  school_id: string;
+  avg_rating: number;
+  rating_count: number;
+  thumbs_up: number;
+  thumbs_down: number;
+  recommend_pct: number | null;
+  listed_at: string;
+  last_rated_at?: string;
+  closed_at: string;
+  reason?: string;
+}
+
+export const getSchoolVenueArchive = (id: string, page = 1, limit = 20) =>
+  apiFetch<PaginatedResponse<ArchivedVenue>>(`/api/schools/${id}/venues/archive`, {
     params: { page: String(page), limit: String(limit) },
   });
 
 export const getSchoolFraternities = (id: string) =>
-  apiFetch<FratWithRating[]>(`/api/schools/${id}/fraternities`);
+  apiFetch<PaginatedResponse<FratWithRating>>(`/api/schools/${id}/fraternities`);
 
 export const getSchoolRatings = (id: string) =>
-  apiFetch<Rating[]>(`/api/schools/${id}/ratings`);
+  apiFetch<PaginatedResponse<Rating>>(`/api/schools/${id}/ratings`);
 
-export const getAllFraternities = () =>
-  apiFetch<string[]>("/api/fraternities");
+// q matches names, Greek letters ("ΣΑΕ"), abbreviations ("SAE") and nicknames.
+export const getAllFraternities = (q = "") =>
+  apiFetch<PaginatedResponse<string>>("/api/fraternities", { params: { q } });
 
 export const getSchoolsByFrat = (name: string) =>
-  apiFetch<string[]>("/api/fraternities/schools", { params: { name } });
+  apiFetch<PaginatedResponse<string>>("/api/fraternities/schools", { params: { name } });
 
 // Leaderboard
 export interface LeaderboardSchool {
@@ -229,11 +440,14 @@ export interface LeaderboardSchool {
   id: string;
   name: string;
   state: string;
+  country: string;
   control: string;
   venue_count: number;
   avg_rating: number;
   frat_count: number;
   party_score: number;
+  recommend_pct?: number;
+  conference?: string;
 }
 
 export interface LeaderboardUser {
@@ -242,11 +456,111 @@ export interface LeaderboardUser {
   rating_count: number;
 }
 
-export const getLeaderboardSchools = () =>
-  apiFetch<LeaderboardSchool[]>("/api/leaderboard/schools");
+export const getLeaderboardSchools = (country?: string, conference?: string) =>
+  apiFetch<PaginatedResponse<LeaderboardSchool>>("/api/leaderboard/schools", {
+    params: { country: country ?? "", conference: conference ?? "" },
+  });
+
+export const getConferences = () =>
+  apiFetch<string[]>("/api/conferences");
 
 export const getLeaderboardUsers = () =>
-  apiFetch<LeaderboardUser[]>("/api/leaderboard/users");
+  apiFetch<PaginatedResponse<LeaderboardUser>>("/api/leaderboard/users");
+
+export interface ProfileReview {
+  type: "venue" | "frat";
+  id: string;
+  score: number;
+  review?: string;
+  tags?: string[];
+  venue_id?: string;
+  venue_name?: string;
+  frat_name?: string;
+  school_id?: string;
+  created_at: string;
+}
+
+export interface PublicProfile {
+  id: string;
+  username: string;
+  display_name?: string;
+  avatar_url?: string;
+  bio?: string;
+  member_since: string;
+  verified_student: boolean;
+  rating_count: number;
+  frat_rating_count: number;
+  recent_reviews: ProfileReview[] | null;
+}
+
+export const getUserProfile = (id: string) =>
+  apiFetch<PublicProfile>(`/api/users/${id}`);
+
+export interface Badge {
+  id: string;
+  name: string;
+  referrals: number;
+}
+
+export interface InviteSummary {
+  code: string;
+  referrals: number;
+  badges: Badge[];
+  next_badge?: Badge;
+}
+
+export interface ReferralLeader {
+  user_id: string;
+  username: string;
+  referrals: number;
+  badges: Badge[];
+}
+
+export const getMyInvite = () =>
+  apiFetch<InviteSummary>("/api/me/invite");
+
+export const getReferralLeaderboard = () =>
+  apiFetch<PaginatedResponse<ReferralLeader>>("/api/leaderboard/referrals");
+
+export interface Ambassador {
+  user_id: string;
+  username?: string;
+  school_id: string;
+  appointed_by?: string;
+  appointed_at: string;
+}
+
+export interface PinnedPost {
+  id: string;
+  school_id: string;
+  author_id: string;
+  author_name?: string;
+  title: string;
+  body: string;
+  week_start: string;
+  created_at: string;
+}
+
+export interface AmbassadorStatus extends Ambassador {
+  venue_quota: number;
+  venues_remaining: number;
+  pinned?: PinnedPost;
+}
+
+export const getSchoolPinnedPost = (schoolId: string) =>
+  apiFetch<PinnedPost>(`/api/schools/${schoolId}/pinned`);
+
+export const getAmbassadorStatus = () =>
+  apiFetch<AmbassadorStatus>("/api/ambassador/me");
+
+export const pinAmbassadorPost = (title: string, body: string) =>
+  apiFetch<PinnedPost>("/api/ambassador/pin", {
+    method: "PUT",
+    body: JSON.stringify({ title, body }),
+  });
+
+export const unpinAmbassadorPost = () =>
+  apiFetch<{ message: string }>("/api/ambassador/pin", { method: "DELETE" });
 
 export interface ActivityItem {
   type: "rating" | "venue" | "frat_rating";
@@ -255,14 +569,180 @@ export interface ActivityItem {
 }
 
 export const getRecentActivity = () =>
-  apiFetch<ActivityItem[]>("/api/activity/recent");
+  apiFetch<PaginatedResponse<ActivityItem>>("/api/activity/recent");
+
+// Activity scoped to the user's home school (or school_id), sitewide otherwise
+export interface FeedItem extends Omit<ActivityItem, "type"> {
+  type: ActivityItem["type"] | "crawl";
+  venue_id?: string;
+  school_id?: string;
+  crawl_id?: string;
+}
+
+export interface Feed {
+  school: SchoolSummary | null;
+  items: FeedItem[];
+}
+
+export const getFeed = (schoolId?: string) =>
+  apiFetch<Feed>("/api/feed", schoolId ? { params: { school_id: schoolId } } : {});
+
+// Crawls
+export interface CrawlStop {
+  rank: number;
+  venue_id: string;
+  note?: string;
+  venue?: Venue;
+}
+
+export interface CrawlRSVP {
+  user_id: string;
+  username: string;
+  status: "going" | "maybe";
+  updated_at: string;
+}
+
+export interface Crawl {
+  id: string;
+  title: string;
+  description?: string;
+  school_id: string;
+  owner_id: string;
+  owner_name?: string;
+  starts_at: string;
+  visibility: "public" | "private";
+  invite_code?: string; // host only; share as /crawls/{id}?invite={code}
+  stops: CrawlStop[];
+  rsvps: CrawlRSVP[];
+  going: number;
+  maybe: number;
+  my_rsvp?: "going" | "maybe";
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CrawlInput {
+  title: string;
+  description?: string;
+  school_id: string;
+  starts_at: string;
+  visibility?: "public" | "private";
+  stops: { venue_id: string; note?: string }[];
+}
+
+export const getCrawl = (id: string, invite?: string) =>
+  apiFetch<Crawl>(`/api/crawls/${id}`, invite ? { params: { invite } } : {});
+
+export const getSchoolCrawls = (schoolId: string) =>
+  apiFetch<PaginatedResponse<Crawl>>(`/api/schools/${schoolId}/crawls`);
+
+export const getMyCrawls = () => apiFetch<PaginatedResponse<Crawl>>("/api/me/crawls");
+
+export const createCrawl = (crawl: CrawlInput) =>
+  apiFetch<Crawl>("/api/crawls", { method: "POST", body: JSON.stringify(crawl) });
+
+export const updateCrawl = (id: string, crawl: CrawlInput) =>
+  apiFetch<Crawl>(`/api/crawls/${id}`, { method: "PUT", body: JSON.stringify(crawl) });
+
+export const deleteCrawl = (id: string) =>
+  apiFetch<{ message: string }>(`/api/crawls/${id}`, { method: "DELETE" });
+
+export const rsvpCrawl = (id: string, status: "going" | "maybe", inviteCode?: string) =>
+  apiFetch<Crawl>(`/api/crawls/${id}/rsvp`, {
+    method: "POST",
+    body: JSON.stringify({ status, invite_code: inviteCode }),
+  });
+
+export const cancelCrawlRsvp = (id: string) =>
+  apiFetch<{ message: string }>(`/api/crawls/${id}/rsvp`, { method: "DELETE" });
+
+// Old invite links stop working; people who already RSVP'd keep access
+export const rotateCrawlInvite = (id: string) =>
+  apiFetch<Crawl>(`/api/crawls/${id}/invite`, { method: "POST" });
+
+// Group votes
+export interface GroupCandidate {
+  venue_id: string;
+  venue?: Venue;
+  yes: number;
+  no: number;
+}
+
+export interface GroupSession {
+  id: string;
+  code: string;
+  host_id: string;
+  school_id: string;
+  status: "open" | "closed";
+  candidates: GroupCandidate[];
+  members: { user_id: string; username: string; voted: number }[];
+  my_votes: Record<string, boolean>;
+  pick?: GroupCandidate;
+  decided: boolean;
+  created_at: string;
+  expires_at: string;
+}
+
+// Without venueIds the school's top-rated venues are the candidates
+export const createGroupVote = (schoolId: string, venueIds?: string[]) =>
+  apiFetch<GroupSession>("/api/groups", {
+    method: "POST",
+    body: JSON.stringify({ school_id: schoolId, venue_ids: venueIds }),
+  });
 
-export const getStates = () =>
-  apiFetch<string[]>("/api/schools/states");
+export const joinGroupVote = (code: string) =>
+  apiFetch<GroupSession>("/api/groups/join", { method: "POST", body: JSON.stringify({ code }) });
+
+export const getGroupVote = (id: string) => apiFetch<GroupSession>(`/api/groups/${id}`);
+
+export const voteInGroup = (id: string, venueId: string, yes: boolean) =>
+  apiFetch<GroupSession>(`/api/groups/${id}/votes`, {
+    method: "POST",
+    body: JSON.stringify({ venue_id: venueId, yes }),
+  });
+
+export const closeGroupVote = (id: string) =>
+  apiFetch<GroupSession>(`/api/groups/${id}/close`, { method: "POST" });
+
+// Live updates: "state" events carry the session, "expired" means it's gone.
+// The server ends each stream after ~25s and EventSource reconnects.
+export const groupVoteEvents = (id: string) =>
+  new EventSource(`${API_URL}/api/groups/${id}/events`, { withCredentials: true });
+
+export const getStates = (country?: string) =>
+  apiFetch<string[]>("/api/schools/states", country ? { params: { country } } : {});
 
 // Venues
-export const getVenue = (id: string) =>
-  apiFetch<Venue>(`/api/venues/${id}`);
+export type VenueInclude = "ratings" | "school" | "school.fraternities";
+
+export interface VenueDetail extends Venue {
+  ratings?: PaginatedResponse<Rating>;
+  school?: SchoolDetail;
+}
+
+export const getVenue = (id: string, include: VenueInclude[] = []) =>
+  apiFetch<VenueDetail>(`/api/venues/${id}`, {
+    params: include.length ? { include: include.join(",") } : undefined,
+  });
+
+export interface MonthStat {
+  month: number; // 1 = January
+  ratings: number;
+  check_ins: number;
+  avg_rating?: number;
+  activity: number; // 0-100, relative to the busiest month
+}
+
+export interface Seasonality {
+  venue_id: string;
+  months: MonthStat[];
+  peak_month?: number;
+  quiet_month?: number;
+  generated_at: string;
+}
+
+export const getVenueSeasonality = (id: string) =>
+  apiFetch<Seasonality>(`/api/venues/${id}/seasonality`);
 
 export const createVenue = (data: {
   name: string;
@@ -278,14 +758,180 @@ export const createVenue = (data: {
     body: JSON.stringify(data),
   });
 
-export const getVenueRatings = (id: string) =>
-  apiFetch<Rating[]>(`/api/venues/${id}/ratings`);
+export const getVenueRatings = (id: string, sort?: string, studentsOnly = false) =>
+  apiFetch<PaginatedResponse<Rating>>(`/api/venues/${id}/ratings`, {
+    params: { sort: sort || "", verified_student: studentsOnly ? "true" : "" },
+  });
+
+export interface VenueStats {
+  avg_rating: number;
+  rating_count: number;
+  thumbs_up: number;
+  thumbs_down: number;
+  recommend_pct: number | null;
+  // Minutes until closing while open; null when closed or hours are unknown
+  closes_in_minutes: number | null;
+  // Only for venues with outdoor seating when the weather is known
+  patio_weather?: boolean;
+  // The owner's posted status for tonight, if any
+  status?: VenueStatus;
+}
+
+// Nightly status posted by a venue's verified owner; gone after expires_at
+export interface VenueStatus {
+  venue_id: string;
+  crowd?: "quiet" | "busy" | "packed" | "at_capacity";
+  live_music: boolean;
+  cover?: string;
+  note?: string;
+  posted_at: string;
+  expires_at: string;
+}
+
+export const getVenueStatus = (venueId: string) =>
+  apiFetch<VenueStatus | null>(`/api/venues/${venueId}/status`);
+
+// hours (1-12) ends the status early; otherwise it lasts until 4am local time
+export const setVenueStatus = (
+  venueId: string,
+  data: { crowd?: VenueStatus["crowd"]; live_music?: boolean; cover?: string; note?: string; hours?: number }
+) =>
+  apiFetch<VenueStatus>(`/api/owner/venues/${venueId}/status`, {
+    method: "PUT",
+    body: JSON.stringify(data),
+  });
+
+export const clearVenueStatus = (venueId: string) =>
+  apiFetch<{ message: string }>(`/api/owner/venues/${venueId}/status`, { method: "DELETE" });
+
+// Rating stats for up to 200 venues in one request, keyed by venue ID
+export const getVenueStats = (venueIds: string[]) =>
+  apiFetch<Record<string, VenueStats>>("/api/venues/stats", {
+    method: "POST",
+    body: JSON.stringify({ venue_ids: venueIds }),
+  });
+
+// Check-ins. Passing the user's location inside the venue's geofence makes a
+// verified check-in, which badges a rating posted soon after as a verified visit.
+export interface CheckIn {
+  id: string;
+  venue_id: string;
+  user_id: string;
+  created_at: string;
+  verified: boolean;
+}
+
+export const checkIn = (venueId: string, coords?: { latitude: number; longitude: number }) =>
+  apiFetch<CheckIn>(`/api/venues/${venueId}/checkin`, {
+    method: "POST",
+    ...(coords ? { body: JSON.stringify(coords) } : {}),
+  });
+
+// Taxonomies (admin-editable venue categories and review tags)
+export interface TaxonomyTerm {
+  kind: "category" | "tag";
+  slug: string;
+  label: string;
+  position: number;
+}
+
+export const getTaxonomy = (kind: "categories" | "tags") =>
+  apiFetch<TaxonomyTerm[]>(`/api/taxonomies/${kind}`);
+
+// Photos
+export interface Photo {
+  id: string;
+  venue_id: string;
+  rating_id?: string;
+  uploader_id: string;
+  status: "processing" | "quarantined" | "approved" | "rejected";
+  flags?: string[];
+  nsfw_score: number;
+  duplicate_of?: string;
+  geotag_distance_km?: number;
+  content_type: string;
+  width: number;
+  height: number;
+  size: number;
+  url?: string;
+  created_at: string;
+  reviewed_at?: string;
+}
+
+export const photoUrl = (photo: Photo) => (photo.url ? `${API_URL}${photo.url}` : "");
+
+export const getVenuePhotos = (venueId: string, page = 1) =>
+  apiFetch<PaginatedResponse<Photo>>(`/api/venues/${venueId}/photos`, { params: { page: String(page) } });
+
+export const uploadVenuePhoto = (venueId: string, file: File, ratingId?: string) => {
+  const form = new FormData();
+  form.append("photo", file);
+  if (ratingId) form.append("rating_id", ratingId);
+  return apiFetch<Photo>(`/api/venues/${venueId}/photos`, { method: "POST", body: form });
+};
 
 // Ratings
-export const createRating = (data: {
+export interface ReviewSearchResult {
+  rating: Rating;
+  venue_name?: string;
+  snippet: string; // HTML-escaped, matches wrapped in <mark>
+  phrase_match: boolean;
+}
+
+export const searchReviews = (q: string, scope: { school_id?: string; venue_id?: string }) =>
+  apiFetch<ReviewSearchResult[]>("/api/reviews/search", {
+    params: { q, school_id: scope.school_id ?? "", venue_id: scope.venue_id ?? "" },
+  });
+
+// Importing the user's own Google Maps reviews (takeout "Reviews.json")
+export interface ReviewImportItem {
+  place: string;
   score: number;
+  venue_id?: string;
+  venue_name?: string;
+  rating_id?: string;
+  pending?: boolean;
+  reason?: string;
+}
+
+export interface ReviewImportResult {
+  dry_run: boolean;
+  imported: ReviewImportItem[];
+  skipped: ReviewImportItem[];
+}
+
+export const importGoogleReviews = (takeoutJson: string, dryRun = false) =>
+  apiFetch<ReviewImportResult>("/api/me/import/google-maps", {
+    method: "POST",
+    body: takeoutJson,
+    params: dryRun ? { dry_run: "true" } : undefined,
+  });
+
+export interface RatingDraft {
+  venue_id: string;
+  score?: number;
+  review?: string;
+  tags?: string[];
+  would_recommend?: boolean;
+  updated_at: string;
+  expires_at: string;
+}
+
+export const getRatingDraft = (venueId: string) =>
+  apiFetch<RatingDraft>("/api/me/drafts/rating", { params: { venue_id: venueId } });
+
+export const saveRatingDraft = (draft: Omit<RatingDraft, "updated_at" | "expires_at">) =>
+  apiFetch<RatingDraft>("/api/me/drafts/rating", {
+    method: "PUT",
+    body: JSON.stringify(draft),
+  });
+
+export const createRating = (data: {
+  score: number; // 1-5 in half-star steps
   review?: string;
+  tags?: string[];
   venue_id: string;
+  would_recommend?: boolean;
 }) =>
   apiFetch<Rating>("/api/ratings", {
     method: "POST",
@@ -298,6 +944,12 @@ export const voteOnRating = (id: string, direction: "up" | "down") =>
     body: JSON.stringify({ direction }),
   });
 
+export const reactToRating = (id: string, reaction: ReactionName) =>
+  apiFetch<{ reactions: Partial<Record<ReactionName, number>> }>(`/api/ratings/${id}/react`, {
+    method: "POST",
+    body: JSON.stringify({ reaction }),
+  });
+
 export const createFratRating = (data: {
   frat_name: string;
   school_id: string;
@@ -313,24 +965,135 @@ export const register = (data: {
   email: string;
   password: string;
   username: string;
+  terms_version?: string;
+  invite_code?: string;
 }) =>
   apiFetch<AuthResponse>("/api/auth/register", {
     method: "POST",
     body: JSON.stringify(data),
   });
 
+export interface Availability {
+  available: boolean;
+  reason?: string;
+}
+
+export const checkUsername = (username: string) =>
+  apiFetch<Availability>("/api/auth/check-username", { params: { u: username } });
+
+export const checkEmail = (email: string) =>
+  apiFetch<Availability>("/api/auth/check-email", { params: { email } });
+
 export const login = (data: { email: string; password: string }) =>
   apiFetch<AuthResponse>("/api/auth/login", {
     method: "POST",
     body: JSON.stringify(data),
   });
 
+export const forgotPassword = (email: string) =>
+  apiFetch<{ message: string }>("/api/auth/forgot-password", {
+    method: "POST",
+    body: JSON.stringify({ email }),
+  });
+
+export const resetPassword = (token: string, password: string) =>
+  apiFetch<{ message: string }>("/api/auth/reset-password", {
+    method: "POST",
+    body: JSON.stringify({ token, password }),
+  });
+
+// Signs out every other device; the response carries a fresh token for this one.
+export const changePassword = (currentPassword: string, newPassword: string) =>
+  apiFetch<AuthResponse>("/api/auth/change-password", {
+    method: "POST",
+    body: JSON.stringify({ current_password: currentPassword, new_password: newPassword }),
+  }).then((res) => {
+    setToken(res.token);
+    return res;
+  });
+
+export const requestEmailChange = (newEmail: string, password: string) =>
+  apiFetch<{ message: string }>("/api/auth/change-email", {
+    method: "POST",
+    body: JSON.stringify({ new_email: newEmail, password }),
+  });
+
+export const confirmEmailChange = (token: string) =>
+  apiFetch<AuthResponse["user"]>("/api/auth/confirm-email", {
+    method: "POST",
+    body: JSON.stringify({ token }),
+  });
+
+export const requestStudentVerification = (email: string, schoolId?: string) =>
+  apiFetch<{ message: string; school: SchoolSummary }>("/api/me/student-verification", {
+    method: "POST",
+    body: JSON.stringify({ email, school_id: schoolId }),
+  });
+
+export const confirmStudent = (token: string) =>
+  apiFetch<AuthResponse["user"]>("/api/auth/verify-student", {
+    method: "POST",
+    body: JSON.stringify({ token }),
+  });
+
 export const logout = () =>
   apiFetch<{ message: string }>("/api/auth/logout", { method: "POST" });
 
+// Omitted fields are left unchanged; empty strings clear them.
+export const updateProfile = (data: { display_name?: string; avatar_url?: string; bio?: string }) =>
+  apiFetch<AuthResponse["user"]>("/api/auth/me", {
+    method: "PUT",
+    body: JSON.stringify(data),
+  });
+
+export const uploadAvatar = (file: File) => {
+  const form = new FormData();
+  form.append("avatar", file);
+  return apiFetch<AuthResponse["user"]>("/api/auth/me/avatar", { method: "POST", body: form });
+};
+
+export const deleteAccount = () =>
+  apiFetch<{ message: string }>("/api/auth/me", { method: "DELETE" });
+
+export interface Session {
+  id: string;
+  user_id?: string;
+  username?: string;
+  user_agent: string;
+  ip: string;
+  created_at: string;
+  last_seen_at: string;
+  expires_at: string;
+  current?: boolean;
+}
+
+export const listSessions = () => apiFetch<PaginatedResponse<Session>>("/api/auth/sessions");
+
+export const revokeSession = (id: string) =>
+  apiFetch<{ message: string }>(`/api/auth/sessions/${id}`, { method: "DELETE" });
+
+export const revokeOtherSessions = () =>
+  apiFetch<{ revoked: number }>("/api/auth/sessions", { method: "DELETE" });
+
 export const getMe = () =>
   apiFetch<AuthResponse["user"]>("/api/auth/me");
 
+// Terms of Service (posting returns 428 until the current version is accepted)
+export const getTermsVersion = () =>
+  apiFetch<{ version: string }>("/api/terms/version");
+
+export const acceptTerms = (version: string) =>
+  apiFetch<AuthResponse["user"]>("/api/me/accept-terms", {
+    method: "POST",
+    body: JSON.stringify({ version }),
+  });
+
+export const setHomeSchool = (schoolId: string, gradYear?: number) =>
+  apiFetch<AuthResponse["user"]>("/api/me/home-school", {
+    method: "PUT",
+    body: JSON.stringify({ school_id: schoolId, grad_year: gradYear }),
+  });
+
 // Admin
 export interface AdminUser {
   id: string;
@@ -338,10 +1101,265 @@ export interface AdminUser {
   username: string;
   role: string;
   created_at: string;
+  banned?: boolean;
+}
+
+export interface RetentionJobResult {
+  policy: "ip_device_data" | "inactive_accounts" | "expired_credentials";
+  job: string;
+  cutoff: string;
+  affected: number;
+  error?: string;
+}
+
+export interface RatingAlert {
+  id: string;
+  target_type: "venue" | "frat";
+  target_id: string;
+  target_name?: string;
+  count: number;
+  window_minutes: number;
+  detected_at: string;
+  frozen_until?: string;
+}
+
+export interface TextCluster {
+  id: string;
+  status: "open" | "dismissed";
+  authors: number;
+  members: {
+    rating_id: string;
+    venue_id: string;
+    author_id: string;
+    author_name?: string;
+    excerpt: string;
+    created_at: string;
+  }[];
+  detected_at: string;
+  dismissed_at?: string;
+}
+
+export interface RatingLock {
+  id: string;
+  target_type: "venue" | "frat";
+  target_id: string;
+  target_name?: string;
+  reason: string;
+  expires_at?: string;
+  created_by_id: string;
+  created_at: string;
+}
+
+export interface RetentionReport {
+  dry_run: boolean;
+  ran_at: string;
+  jobs: RetentionJobResult[];
+}
+
+export interface RetentionStatus {
+  config: { ip_data_days: number; inactive_years: number };
+  jobs: Record<RetentionJobResult["policy"], string[]>;
+  last_report: RetentionReport | null;
+}
+
+export const getRetention = () =>
+  apiFetch<RetentionStatus>("/api/admin/retention");
+
+export const runRetention = (dryRun = true) =>
+  apiFetch<RetentionReport>("/api/admin/retention/run", {
+    method: "POST",
+    body: JSON.stringify({ dry_run: dryRun }),
+  });
+
+export interface RecomputeStep {
+  name: string;
+  state: "pending" | "running" | "done" | "failed";
+  updated?: number;
+  duration_ms: number;
+  error?: string;
 }
 
+export interface RecomputeStatus {
+  state: "idle" | "running" | "done" | "failed";
+  started_at?: string;
+  finished_at?: string;
+  completed: number;
+  total: number;
+  steps: RecomputeStep[];
+  error?: string;
+}
+
+export const getRecompute = () =>
+  apiFetch<RecomputeStatus>("/api/admin/recompute");
+
+export const startRecompute = () =>
+  apiFetch<RecomputeStatus>("/api/admin/recompute", { method: "POST" });
+
+export const getRatingAlerts = () =>
+  apiFetch<RatingAlert[]>("/api/admin/rating-alerts");
+
+export const freezeRatingAlert = (id: string, hours: number) =>
+  apiFetch<RatingAlert>(`/api/admin/rating-alerts/${id}/freeze`, {
+    method: "POST",
+    body: JSON.stringify({ hours }),
+  });
+
+export const unfreezeRatingAlert = (id: string) =>
+  apiFetch<RatingAlert>(`/api/admin/rating-alerts/${id}/freeze`, { method: "DELETE" });
+
+export const getTextClusters = (status: "open" | "dismissed" | "all" = "open", page = 1) =>
+  apiFetch<PaginatedResponse<TextCluster>>("/api/admin/text-clusters", {
+    params: { status, page: String(page) },
+  });
+
+export const dismissTextCluster = (id: string) =>
+  apiFetch<TextCluster>(`/api/admin/text-clusters/${id}/dismiss`, { method: "POST" });
+
+export interface AmbassadorSchoolActivity {
+  school_id: string;
+  school_name?: string;
+  ambassadors: Ambassador[];
+  venues_seeded: number;
+  venues_seeded_7d: number;
+  posts_pinned: number;
+  last_active_at?: string;
+}
+
+export const getAmbassadorActivity = (page = 1) =>
+  apiFetch<PaginatedResponse<AmbassadorSchoolActivity>>("/api/admin/ambassadors", {
+    params: { page: String(page) },
+  });
+
+export const appointAmbassador = (userId: string, schoolId: string) =>
+  apiFetch<Ambassador>(`/api/admin/ambassadors/${userId}`, {
+    method: "PUT",
+    body: JSON.stringify({ school_id: schoolId }),
+  });
+
+export const removeAmbassador = (userId: string) =>
+  apiFetch<{ message: string }>(`/api/admin/ambassadors/${userId}`, { method: "DELETE" });
+
+export interface LoginLockout {
+  kind: "email" | "ip";
+  value: string;
+  failures: number;
+  lockouts: number;
+  locked: boolean;
+  until: string;
+}
+
+export const getLoginLockouts = (page = 1) =>
+  apiFetch<PaginatedResponse<LoginLockout>>("/api/admin/login-lockouts", {
+    params: { page: String(page) },
+  });
+
+export const clearLoginLockout = (target: { email?: string; ip?: string }) =>
+  apiFetch<{ message: string; cleared: number }>("/api/admin/login-lockouts/clear", {
+    method: "POST",
+    body: JSON.stringify(target),
+  });
+
+export const addSchoolLink = (schoolId: string, link: Omit<SchoolLink, "id">) =>
+  apiFetch<SchoolLink>(`/api/admin/schools/${schoolId}/links`, {
+    method: "POST",
+    body: JSON.stringify(link),
+  });
+
+export const removeSchoolLink = (schoolId: string, linkId: string) =>
+  apiFetch<{ message: string }>(`/api/admin/schools/${schoolId}/links/${linkId}`, { method: "DELETE" });
+
+export type FootballGameInput = Partial<Pick<FootballGame, "opponent" | "kickoff" | "home" | "location">>;
+
+export const createGame = (schoolId: string, game: FootballGameInput) =>
+  apiFetch<FootballGame>(`/api/admin/schools/${schoolId}/games`, {
+    method: "POST",
+    body: JSON.stringify(game),
+  });
+
+export const updateGame = (gameId: string, game: FootballGameInput) =>
+  apiFetch<FootballGame>(`/api/admin/games/${gameId}`, {
+    method: "PUT",
+    body: JSON.stringify(game),
+  });
+
+export const deleteGame = (gameId: string) =>
+  apiFetch<{ message: string }>(`/api/admin/games/${gameId}`, { method: "DELETE" });
+
+// Uploads a CSV or iCalendar schedule; games are matched by kickoff
+export const importGames = (schoolId: string, format: "csv" | "ics", file: string) =>
+  apiFetch<{ created: number; updated: number; unchanged: number; errors: string[] }>(
+    `/api/admin/schools/${schoolId}/games/import`,
+    {
+      method: "POST",
+      params: { format },
+      headers: { "Content-Type": format === "csv" ? "text/csv" : "text/calendar" },
+      body: file,
+    },
+  );
+
+export const getRatingLocks = () =>
+  apiFetch<RatingLock[]>("/api/admin/rating-locks");
+
+export const createRatingLock = (data: {
+  target_type: "venue" | "frat";
+  venue_id?: string;
+  school_id?: string;
+  frat_name?: string;
+  reason: string;
+  hours?: number;
+}) =>
+  apiFetch<RatingLock>("/api/admin/rating-locks", {
+    method: "POST",
+    body: JSON.stringify(data),
+  });
+
+export const deleteRatingLock = (id: string) =>
+  apiFetch<{ message: string }>(`/api/admin/rating-locks/${id}`, { method: "DELETE" });
+
+export const adminListSessions = (userId?: string) =>
+  apiFetch<Session[]>("/api/admin/sessions", { params: { user_id: userId ?? "" } });
+
+export const adminRevokeSession = (id: string) =>
+  apiFetch<{ message: string }>(`/api/admin/sessions/${id}`, { method: "DELETE" });
+
+export const setChapterStatus = (schoolId: string, fratName: string, status: ChapterStatus) =>
+  apiFetch<{ status: ChapterStatus }>("/api/admin/fraternities/status", {
+    method: "PUT",
+    body: JSON.stringify({ school_id: schoolId, frat_name: fratName, status }),
+  });
+
+export const getGreekOptOuts = () =>
+  apiFetch<string[]>("/api/admin/greek-opt-outs");
+
+export const setGreekOptOut = (schoolId: string, optOut: boolean) =>
+  apiFetch<{ school_id: string; opt_out: boolean }>(`/api/admin/schools/${schoolId}/greek-opt-out`, {
+    method: "PUT",
+    body: JSON.stringify({ opt_out: optOut }),
+  });
+
+export interface UsageCount {
+  key: string;
+  requests: number;
+  throttled: number;
+}
+
+export interface UsageSummary {
+  window: "1h" | "24h" | "7d";
+  from: string;
+  to: string;
+  requests: number;
+  throttled: number;
+  routes: UsageCount[];
+  api_keys: UsageCount[];
+  top_ips: UsageCount[];
+  hourly: { hour: string; requests: number; throttled: number }[];
+}
+
+export const getUsage = (window: UsageSummary["window"] = "24h") =>
+  apiFetch<UsageSummary>("/api/admin/usage", { params: { window } });
+
 export const getPendingVenues = () =>
-  apiFetch<Venue[]>("/api/admin/venues/pending");
+  apiFetch<PaginatedResponse<Venue>>("/api/admin/venues/pending");
 
 export const approveVenue = (id: string) =>
   apiFetch<{ message: string }>(`/api/admin/venues/${id}/approve`, { method: "POST" });
@@ -350,7 +1368,7 @@ export const rejectVenue = (id: string) =>
   apiFetch<{ message: string }>(`/api/admin/venues/${id}/reject`, { method: "DELETE" });
 
 export const getAdminUsers = () =>
-  apiFetch<AdminUser[]>("/api/admin/users");
+  apiFetch<PaginatedResponse<AdminUser>>("/api/admin/users");
 
 export const updateUserRole = (id: string, role: string) =>
   apiFetch<{ message: string }>(`/api/admin/users/${id}/role`, {
@@ -358,11 +1376,118 @@ export const updateUserRole = (id: string, role: string) =>
     body: JSON.stringify({ role }),
   });
 
+export interface UserBan {
+  user_id: string;
+  reason: string;
+  banned_until?: string;
+  permanent: boolean;
+  rejected_venues: string[];
+}
+
+// durationHours 0 bans permanently; the user's pending venues are rejected
+export const banUser = (id: string, reason: string, durationHours = 0) =>
+  apiFetch<UserBan>(`/api/admin/users/${id}/ban`, {
+    method: "POST",
+    body: JSON.stringify({ reason, duration_hours: durationHours }),
+  });
+
+export const unbanUser = (id: string) =>
+  apiFetch<{ message: string }>(`/api/admin/users/${id}/unban`, { method: "POST" });
+
+export interface AuditEntry {
+  id: string;
+  actor_id: string;
+  actor_name?: string;
+  action: string;
+  target_type: string;
+  target_id: string;
+  details?: Record<string, unknown>;
+  created_at: string;
+}
+
+// Newest first. An action ending in "." matches the whole family, e.g. "venue.";
+// since/until are RFC 3339 timestamps.
+export const getAuditLog = (filter: {
+  actor_id?: string;
+  action?: string;
+  target_type?: string;
+  target_id?: string;
+  since?: string;
+  until?: string;
+  page?: string;
+  limit?: string;
+} = {}) => apiFetch<PaginatedResponse<AuditEntry>>("/api/admin/audit", { params: filter as Record<string, string> });
+
+export interface MergeUsersResult {
+  kept_user: AuthResponse["user"];
+  merged_id: string;
+  counts: Record<string, number>;
+}
+
+// The older of the two accounts is kept.
+export const adminMergeUsers = (userIds: [string, string]) =>
+  apiFetch<MergeUsersResult>("/api/admin/users/merge", {
+    method: "POST",
+    body: JSON.stringify({ user_ids: userIds }),
+  });
+
+export const adminPreviewMergeUsers = (userIds: [string, string]) =>
+  apiFetch<DryRunResult>("/api/admin/users/merge", {
+    method: "POST",
+    params: { dry_run: "true" },
+    body: JSON.stringify({ user_ids: userIds }),
+  });
+
 export const adminSearchVenues = (q: string) =>
-  apiFetch<Venue[]>("/api/admin/venues/search", { params: { q } });
+  apiFetch<PaginatedResponse<Venue>>("/api/admin/venues/search", { params: { q } });
+
+// Approved venues go to the school's archive unless archive is false (e.g. duplicates)
+export const adminDeleteVenue = (id: string, opts: { reason?: string; archive?: boolean } = {}) =>
+  apiFetch<{ message: string }>(`/api/admin/venues/${id}`, {
+    method: "DELETE",
+    params: {
+      ...(opts.reason ? { reason: opts.reason } : {}),
+      ...(opts.archive === false ? { archive: "false" } : {}),
+    },
+  });
+
+// What a destructive admin action would change (?dry_run=true); ID lists may be truncated.
+export interface DryRunResult {
+  dry_run: true;
+  action: string;
+  counts: Record<string, number>;
+  ids: Record<string, string[]>;
+}
+
+export const adminPreviewDeleteVenue = (id: string) =>
+  apiFetch<DryRunResult>(`/api/admin/venues/${id}`, { method: "DELETE", params: { dry_run: "true" } });
+
+export const adminCreateTerm = (kind: "categories" | "tags", data: { slug: string; label: string; position?: number }) =>
+  apiFetch<TaxonomyTerm>(`/api/admin/taxonomies/${kind}`, {
+    method: "POST",
+    body: JSON.stringify(data),
+  });
+
+export const adminUpdateTerm = (kind: "categories" | "tags", slug: string, data: { label?: string; position?: number }) =>
+  apiFetch<TaxonomyTerm>(`/api/admin/taxonomies/${kind}/${slug}`, {
+    method: "PUT",
+    body: JSON.stringify(data),
+  });
+
+export const adminDeleteTerm = (kind: "categories" | "tags", slug: string) =>
+  apiFetch<{ message: string }>(`/api/admin/taxonomies/${kind}/${slug}`, { method: "DELETE" });
 
-export const adminDeleteVenue = (id: string) =>
-  apiFetch<{ message: string }>(`/api/admin/venues/${id}`, { method: "DELETE" });
+export const adminPreviewDeleteTerm = (kind: "categories" | "tags", slug: string) =>
+  apiFetch<DryRunResult>(`/api/admin/taxonomies/${kind}/${slug}`, { method: "DELETE", params: { dry_run: "true" } });
+
+export const adminGetQuarantinedPhotos = (page = 1) =>
+  apiFetch<PaginatedResponse<Photo>>("/api/admin/photos/quarantine", { params: { page: String(page) } });
+
+export const adminApprovePhoto = (id: string) =>
+  apiFetch<Photo>(`/api/admin/photos/${id}/approve`, { method: "POST" });
+
+export const adminRejectPhoto = (id: string) =>
+  apiFetch<Photo>(`/api/admin/photos/${id}`, { method: "DELETE" });
 
 export const adminAddFrat = (fratName: string, schoolId: string) =>
   apiFetch<{ status: string }>("/api/admin/fraternities", {
@@ -375,3 +1500,9 @@ export const adminRemoveFrat = (fratName: string, schoolId: string) =>
     method: "DELETE",
     params: { frat_name: fratName, school_id: schoolId },
   });
+
+export const adminPreviewRemoveFrat = (fratName: string, schoolId: string) =>
+  apiFetch<DryRunResult>("/api/admin/fraternities", {
+    method: "DELETE",
+    params: { frat_name: fratName, school_id: schoolId, dry_run: "true" },
+  });
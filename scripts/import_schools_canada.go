@@ -0,0 +1,304 @@
+// import_schools_canada.go - Import Canadian post-secondary institutions from CSV into JSON format.
+// Usage: go run scripts/import_schools_canada.go -input path/to/odef.csv -output data/schools_ca.json
+//
+// Accepts Statistics Canada's Open Database of Educational Facilities (ODEF)
+// or any CSV with equivalent columns; each field below lists the header names
+// it is read from (case-insensitive). Rows whose facility type isn't a
+// university, college, polytechnic or CEGEP are skipped, as are rows without
+// coordinates.
+//
+// Output uses the same schema as import_schools.go with the province code in
+// "state", country "CA" and a stable "id" derived from the name and province,
+// so venues keep their school across re-imports.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type School struct {
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	Alias          string  `json:"alias"`
+	Address        string  `json:"address"`
+	City           string  `json:"city"`
+	State          string  `json:"state"`
+	Zip            string  `json:"zip"`
+	Country        string  `json:"country"`
+	Control        string  `json:"control"`
+	ICLevel        int     `json:"iclevel"`
+	Website        string  `json:"website"`
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	InstSize       int     `json:"instsize"`
+	IsReligious    bool    `json:"is_religious,omitempty"`
+	IsCommunityCol bool    `json:"is_community_college,omitempty"`
+}
+
+// columns maps each field to the CSV headers it may appear under.
+var columns = map[string][]string{
+	"name":      {"FACILITY_NAME", "NAME", "INSTITUTION"},
+	"alias":     {"ALIAS", "ABBREVIATION"},
+	"type":      {"FACILITY_TYPE", "TYPE"},
+	"address":   {"FULL_ADDR", "ADDRESS"},
+	"city":      {"CITY", "CSDNAME"},
+	"province":  {"PROV_TERR", "PROVINCE"},
+	"postal":    {"POSTAL_CODE", "POSTAL"},
+	"control":   {"CONTROL", "AUTHORITY_TYPE"},
+	"website":   {"WEBSITE", "URL"},
+	"latitude":  {"LATITUDE", "LAT"},
+	"longitude": {"LONGITUDE", "LON", "LNG"},
+	"enrolment": {"ENROLMENT", "ENROLLMENT", "STUDENTS"},
+	"religious": {"RELIGIOUS", "DENOMINATION"},
+}
+
+var provinceCodes = map[string]string{
+	"ALBERTA":                   "AB",
+	"BRITISH COLUMBIA":          "BC",
+	"MANITOBA":                  "MB",
+	"NEW BRUNSWICK":             "NB",
+	"NEWFOUNDLAND AND LABRADOR": "NL",
+	"NOVA SCOTIA":               "NS",
+	"NORTHWEST TERRITORIES":     "NT",
+	"NUNAVUT":                   "NU",
+	"ONTARIO":                   "ON",
+	"PRINCE EDWARD ISLAND":      "PE",
+	"QUEBEC":                    "QC",
+	"QUÉBEC":                    "QC",
+	"SASKATCHEWAN":              "SK",
+	"YUKON":                     "YT",
+}
+
+func main() {
+	inputPath := flag.String("input", "", "Path to Canadian institutions CSV file")
+	outputPath := flag.String("output", "data/schools_ca.json", "Output JSON file path")
+	flag.Parse()
+
+	if *inputPath == "" {
+		log.Fatal("Usage: go run import_schools_canada.go -input path/to/odef.csv [-output data/schools_ca.json]")
+	}
+
+	f, err := os.Open(*inputPath)
+	if err != nil {
+		log.Fatalf("Failed to open input file: %v", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	// Read header
+	header, err := reader.Read()
+	if err != nil {
+		log.Fatalf("Failed to read CSV header: %v", err)
+	}
+
+	// Build column index
+	colIdx := make(map[string]int)
+	for i, col := range header {
+		clean := strings.TrimSpace(strings.ToUpper(col))
+		clean = strings.TrimLeft(clean, "\xef\xbb\xbf") // strip UTF-8 BOM
+		colIdx[clean] = i
+	}
+	fieldIdx := make(map[string]int)
+	for field, names := range columns {
+		for _, name := range names {
+			if i, ok := colIdx[name]; ok {
+				fieldIdx[field] = i
+				break
+			}
+		}
+	}
+
+	// Verify required columns
+	for _, field := range []string{"name", "province", "latitude", "longitude"} {
+		if _, ok := fieldIdx[field]; !ok {
+			log.Fatalf("Missing required column for %s (one of %s)", field, strings.Join(columns[field], ", "))
+		}
+	}
+
+	getCol := func(row []string, field string) string {
+		idx, ok := fieldIdx[field]
+		if !ok || idx >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[idx])
+	}
+
+	getFloat := func(row []string, field string) float64 {
+		v, _ := strconv.ParseFloat(getCol(row, field), 64)
+		return v
+	}
+
+	var schools []School
+	seen := make(map[string]bool)
+	lineNum := 1
+	skipped := 0
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		lineNum++
+		if err != nil {
+			log.Printf("Warning: skipping line %d: %v", lineNum, err)
+			continue
+		}
+
+		lat := getFloat(row, "latitude")
+		lon := getFloat(row, "longitude")
+		if lat == 0 || lon == 0 {
+			continue
+		}
+
+		iclevel, cc, ok := classify(getCol(row, "type"))
+		if !ok {
+			skipped++
+			continue
+		}
+
+		name := getCol(row, "name")
+		province := provinceCode(getCol(row, "province"))
+		if name == "" || province == "" {
+			continue
+		}
+
+		id := "ca-" + strings.ToLower(province) + "-" + slugify(name)
+		if seen[id] {
+			// ODEF lists each campus separately; keep the first
+			continue
+		}
+		seen[id] = true
+
+		control := "public"
+		if c := strings.ToLower(getCol(row, "control")); strings.Contains(c, "private") {
+			control = "private_nonprofit"
+		}
+
+		enrolment, _ := strconv.Atoi(strings.ReplaceAll(getCol(row, "enrolment"), ",", ""))
+		religious := getCol(row, "religious")
+
+		schools = append(schools, School{
+			ID:             id,
+			Name:           name,
+			Alias:          getCol(row, "alias"),
+			Address:        getCol(row, "address"),
+			City:           getCol(row, "city"),
+			State:          province,
+			Zip:            strings.ToUpper(getCol(row, "postal")),
+			Country:        "CA",
+			Control:        control,
+			ICLevel:        iclevel,
+			Website:        getCol(row, "website"),
+			Latitude:       lat,
+			Longitude:      lon,
+			InstSize:       instSize(enrolment),
+			IsReligious:    religious != "" && religious != "0" && !strings.EqualFold(religious, "none"),
+			IsCommunityCol: cc,
+		})
+	}
+
+	// Write JSON output
+	outFile, err := os.Create(*outputPath)
+	if err != nil {
+		log.Fatalf("Failed to create output file: %v", err)
+	}
+	defer outFile.Close()
+
+	encoder := json.NewEncoder(outFile)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(schools); err != nil {
+		log.Fatalf("Failed to write JSON: %v", err)
+	}
+
+	provinces := make(map[string]int)
+	levelCounts := map[int]int{}
+	for _, s := range schools {
+		provinces[s.State]++
+		levelCounts[s.ICLevel]++
+	}
+
+	fmt.Printf("Import complete!\n")
+	fmt.Printf("  Total schools: %d\n", len(schools))
+	fmt.Printf("  Universities (ICL=1): %d\n", levelCounts[1])
+	fmt.Printf("  Colleges/CEGEPs (ICL=2): %d\n", levelCounts[2])
+	fmt.Printf("  Provinces/territories: %d\n", len(provinces))
+	fmt.Printf("  Skipped (not post-secondary): %d\n", skipped)
+	fmt.Printf("  Output: %s\n", *outputPath)
+}
+
+// classify maps a facility type to an IPEDS-style level: universities are
+// 4-year (1); colleges, polytechnics and CEGEPs are 2-year (2) and count as
+// community colleges. An empty type is treated as a university so curated
+// lists without a type column import as-is.
+func classify(facilityType string) (iclevel int, communityCollege, ok bool) {
+	t := strings.ToLower(facilityType)
+	switch {
+	case t == "" || strings.Contains(t, "universit"):
+		return 1, false, true
+	case strings.Contains(t, "college"), strings.Contains(t, "polytechnic"),
+		strings.Contains(t, "cegep"), strings.Contains(t, "cégep"):
+		return 2, true, true
+	}
+	return 0, false, false
+}
+
+// provinceCode normalizes a province name or code to its two-letter code.
+func provinceCode(p string) string {
+	p = strings.ToUpper(strings.TrimSpace(p))
+	if len(p) == 2 {
+		return p
+	}
+	return provinceCodes[p]
+}
+
+// instSize buckets enrolment the same way IPEDS INSTSIZE does (0 = unknown).
+func instSize(enrolment int) int {
+	switch {
+	case enrolment <= 0:
+		return 0
+	case enrolment < 1000:
+		return 1
+	case enrolment < 5000:
+		return 2
+	case enrolment < 10000:
+		return 3
+	case enrolment < 20000:
+		return 4
+	}
+	return 5
+}
+
+// slugify lower-cases a name and joins its letters and digits with dashes,
+// dropping apostrophes ("Queen's University" -> "queens-university").
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '\'' || r == '’':
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		default:
+			dash = true
+		}
+	}
+	return b.String()
+}